@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"time"
 
 	"github.com/portainer/portainer-mcp/internal/mcp"
 	"github.com/portainer/portainer-mcp/internal/tooldef"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
 	"github.com/rs/zerolog/log"
 )
 
@@ -17,32 +20,68 @@ var (
 )
 
 func main() {
-	log.Info().
-		Str("version", Version).
-		Str("build-date", BuildDate).
-		Str("commit", Commit).
-		Msg("Portainer MCP server")
-
-	serverFlag := flag.String("server", "", "The Portainer server URL")
+	serverFlag := flag.String("server", "", "The Portainer server URL, or unix:///path/to/socket to connect through a Unix socket")
 	tokenFlag := flag.String("token", "", "The authentication token for the Portainer server")
 	toolsFlag := flag.String("tools", "", "The path to the tools YAML file")
 	readOnlyFlag := flag.Bool("read-only", false, "Run in read-only mode")
 	disableVersionCheckFlag := flag.Bool("disable-version-check", false, "Disable Portainer server version check")
+	startupRetryAttemptsFlag := flag.Int("startup-retry-attempts", 1, "Number of attempts of the startup version check while the Portainer server is not reachable")
+	startupRetryDelayFlag := flag.Duration("startup-retry-delay", time.Second, "Delay before retrying the startup version check, doubled after each retry")
 	transportFlag := flag.String("transport", "stdio", "Transport type: stdio, sse, or streamable-http")
 	portFlag := flag.Int("port", 6972, "Port to listen on (only used with sse or streamable-http transport)")
 	endpointFlag := flag.String("endpoint", "/mcp", "HTTP endpoint path (only used with sse or streamable-http transport)")
+	snapshotMetricsTTLFlag := flag.Duration("snapshot-metrics-ttl", 0, "Serve the snapshots of the environments in the Prometheus text format on /snapshot-metrics, read from the Portainer server at most once per TTL (0 disables them, only used with sse or streamable-http transport)")
+	hideVersionInfoFlag := flag.Bool("hide-version-info", false, "Hide the versions of the MCP server and of Portainer from the MCP clients, they are only logged at the debug level")
+	httpCompressionFlag := flag.Bool("http-compression", false, "Compress the HTTP responses with gzip for the clients accepting it (only used with sse or streamable-http transport)")
+	responseFormatFlag := flag.String("response-format", "", "Format of tool responses: json or text (defaults to the handler output)")
+	maxResponseBytesFlag := flag.Int("max-response-bytes", 0, "Maximum size in bytes of a tool response, larger responses are truncated (0 disables the limit)")
+	maxIdleConnsFlag := flag.Int("max-idle-conns", 0, "Maximum number of idle connections to the Portainer server kept open in total (0 keeps the default: 100)")
+	maxIdleConnsPerHostFlag := flag.Int("max-idle-conns-per-host", 0, "Maximum number of idle connections kept open per Portainer host (0 keeps the default: 32)")
+	idleConnTimeoutFlag := flag.Duration("idle-conn-timeout", 0, "How long an idle connection to the Portainer server is kept open (0 keeps the default: 90s)")
+	stackURLAllowedHostsFlag := flag.String("stack-url-allowed-hosts", "", "Comma-separated list of hosts stack files can be fetched from, internal addresses included (any host with a public address when empty)")
+	edgeAgentOfflineThresholdFlag := flag.Duration("edge-agent-offline-threshold", 0, "How long an edge agent can go without checking in before it is reported offline (0 derives it from the check-in interval)")
+	dockerAPIVersionFlag := flag.String("docker-api-version", "", "Highest Docker API version sent to the Docker daemons, lowered for older daemons (unversioned requests when empty)")
+	toolPriorityFlag := flag.String("tool-priority", "", "Comma-separated list of tool names to present first to the AI model")
+	validateToolsFlag := flag.Bool("validate-tools", false, "Validate the tools YAML file and exit, without connecting to the Portainer server")
 
 	flag.Parse()
 
-	if *serverFlag == "" || *tokenFlag == "" {
-		log.Fatal().Msg("Both -server and -token flags are required")
+	versionLog := log.Info()
+	if *hideVersionInfoFlag {
+		versionLog = log.Debug()
 	}
+	versionLog.
+		Str("version", Version).
+		Str("build-date", BuildDate).
+		Str("commit", Commit).
+		Str("supported-portainer-version", mcp.SupportedPortainerVersion).
+		Msg("Portainer MCP server")
 
 	toolsPath := *toolsFlag
 	if toolsPath == "" {
 		toolsPath = defaultToolsPath
 	}
 
+	if *validateToolsFlag {
+		if err := toolgen.ValidateToolsFile(toolsPath, mcp.MinimumToolsVersion, mcp.MaximumToolsVersion); err != nil {
+			log.Fatal().Err(err).Msg("invalid tools.yaml file")
+		}
+		log.Info().Str("tools-path", toolsPath).Msg("tools.yaml file is valid")
+		return
+	}
+
+	if *serverFlag == "" || *tokenFlag == "" {
+		log.Fatal().Msg("Both -server and -token flags are required")
+	}
+
+	responseFormat, err := mcp.ParseResponseFormat(*responseFormatFlag)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid -response-format flag")
+	}
+
+	toolPriority := mcp.ParseToolPriority(*toolPriorityFlag)
+	stackURLAllowedHosts := mcp.ParseAllowedHosts(*stackURLAllowedHostsFlag)
+
 	// We first check if the tools.yaml file exists
 	// We'll create it from the embedded version if it doesn't exist
 	exists, err := tooldef.CreateToolsFileIfNotExists(toolsPath)
@@ -61,13 +100,31 @@ func main() {
 		Str("tools-path", toolsPath).
 		Bool("read-only", *readOnlyFlag).
 		Bool("disable-version-check", *disableVersionCheckFlag).
+		Int("startup-retry-attempts", *startupRetryAttemptsFlag).
+		Dur("startup-retry-delay", *startupRetryDelayFlag).
 		Str("transport", *transportFlag).
 		Int("port", *portFlag).
 		Str("endpoint", *endpointFlag).
+		Bool("hide-version-info", *hideVersionInfoFlag).
+		Bool("http-compression", *httpCompressionFlag).
+		Dur("snapshot-metrics-ttl", *snapshotMetricsTTLFlag).
+		Str("response-format", string(responseFormat)).
+		Int("max-response-bytes", *maxResponseBytesFlag).
+		Strs("tool-priority", toolPriority).
+		Strs("stack-url-allowed-hosts", stackURLAllowedHosts).
+		Int("max-idle-conns", *maxIdleConnsFlag).
+		Int("max-idle-conns-per-host", *maxIdleConnsPerHostFlag).
+		Dur("idle-conn-timeout", *idleConnTimeoutFlag).
+		Dur("edge-agent-offline-threshold", *edgeAgentOfflineThresholdFlag).
+		Str("docker-api-version", *dockerAPIVersionFlag).
 		Msg("starting MCP server")
 
-	server, err := mcp.NewPortainerMCPServer(*serverFlag, *tokenFlag, toolsPath, mcp.WithReadOnly(*readOnlyFlag), mcp.WithDisableVersionCheck(*disableVersionCheckFlag))
+	server, err := mcp.NewPortainerMCPServer(*serverFlag, *tokenFlag, toolsPath, mcp.WithReadOnly(*readOnlyFlag), mcp.WithDisableVersionCheck(*disableVersionCheckFlag), mcp.WithStartupRetry(*startupRetryAttemptsFlag, *startupRetryDelayFlag), mcp.WithResponseFormat(responseFormat), mcp.WithMaxResponseBytes(*maxResponseBytesFlag), mcp.WithToolPriority(toolPriority), mcp.WithTransportConfig(*maxIdleConnsFlag, *maxIdleConnsPerHostFlag, *idleConnTimeoutFlag), mcp.WithAllowedStackURLHosts(stackURLAllowedHosts), mcp.WithEdgeAgentOfflineThreshold(*edgeAgentOfflineThresholdFlag), mcp.WithDockerAPIVersion(*dockerAPIVersionFlag), mcp.WithHideVersionInfo(*hideVersionInfoFlag), mcp.WithHTTPCompression(*httpCompressionFlag), mcp.WithSnapshotMetrics(*snapshotMetricsTTLFlag))
 	if err != nil {
+		var versionErr *toolgen.UnsupportedVersionError
+		if errors.As(err, &versionErr) {
+			log.Fatal().Err(err).Str("tools-path", toolsPath).Msg("the tools.yaml file was written by a newer release, upgrade portainer-mcp or remove the file to recreate it with the default tool definitions")
+		}
 		log.Fatal().Err(err).Msg("failed to create server")
 	}
 
@@ -80,7 +137,17 @@ func main() {
 	server.AddTeamFeatures()
 	server.AddAccessGroupFeatures()
 	server.AddDockerProxyFeatures()
+	server.AddSwarmFeatures()
 	server.AddKubernetesProxyFeatures()
+	server.AddHelmFeatures()
+	server.AddActivityLogFeatures()
+	server.AddResourceControlFeatures()
+	server.AddEdgeConfigFeatures()
+	server.AddCustomTemplateFeatures()
+	server.AddScheduleFeatures()
+	server.AddOperationFeatures()
+	server.AddToolDefinitionFeatures()
+	server.AddServerConfigFeatures()
 
 	switch *transportFlag {
 	case "stdio":
@@ -7,14 +7,18 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 func (s *PortainerMCPServer) AddAccessGroupFeatures() {
 	s.addToolIfExists(ToolListAccessGroups, s.HandleGetAccessGroups())
+	s.addToolIfExists(ToolExportAccessReport, s.HandleExportAccessReport())
+	s.addToolIfExists(ToolGetAccessGroupInheritedAccess, s.HandleGetAccessGroupInheritedAccess())
 
 	if !s.readOnly {
 		s.addToolIfExists(ToolCreateAccessGroup, s.HandleCreateAccessGroup())
+		s.addToolIfExists(ToolUpdateAccessGroup, s.HandleUpdateAccessGroup())
 		s.addToolIfExists(ToolUpdateAccessGroupName, s.HandleUpdateAccessGroupName())
 		s.addToolIfExists(ToolUpdateAccessGroupUserAccesses, s.HandleUpdateAccessGroupUserAccesses())
 		s.addToolIfExists(ToolUpdateAccessGroupTeamAccesses, s.HandleUpdateAccessGroupTeamAccesses())
@@ -25,12 +29,12 @@ func (s *PortainerMCPServer) AddAccessGroupFeatures() {
 
 func (s *PortainerMCPServer) HandleGetAccessGroups() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		accessGroups, err := s.cli.GetAccessGroups()
+		accessGroups, err := s.client(ctx).GetAccessGroups()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get access groups", err), nil
 		}
 
-		data, err := json.Marshal(accessGroups)
+		data, err := json.Marshal(newListResponse(accessGroups))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to marshal access groups", err), nil
 		}
@@ -53,7 +57,7 @@ func (s *PortainerMCPServer) HandleCreateAccessGroup() server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid environmentIds parameter", err), nil
 		}
 
-		groupID, err := s.cli.CreateAccessGroup(name, environmentIds)
+		groupID, err := s.client(ctx).CreateAccessGroup(name, environmentIds)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to create access group", err), nil
 		}
@@ -62,6 +66,29 @@ func (s *PortainerMCPServer) HandleCreateAccessGroup() server.ToolHandlerFunc {
 	}
 }
 
+func (s *PortainerMCPServer) HandleUpdateAccessGroup() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		update, err := parseAccessGroupUpdate(parser)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid access group update", err), nil
+		}
+
+		err = s.client(ctx).UpdateAccessGroup(id, update)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update access group", err), nil
+		}
+
+		return mcp.NewToolResultText("Access group updated successfully"), nil
+	}
+}
+
 func (s *PortainerMCPServer) HandleUpdateAccessGroupName() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		parser := toolgen.NewParameterParser(request)
@@ -76,7 +103,7 @@ func (s *PortainerMCPServer) HandleUpdateAccessGroupName() server.ToolHandlerFun
 			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
 		}
 
-		err = s.cli.UpdateAccessGroupName(id, name)
+		err = s.client(ctx).UpdateAccessGroupName(id, name)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update access group name", err), nil
 		}
@@ -104,7 +131,7 @@ func (s *PortainerMCPServer) HandleUpdateAccessGroupUserAccesses() server.ToolHa
 			return mcp.NewToolResultErrorFromErr("invalid user accesses", err), nil
 		}
 
-		err = s.cli.UpdateAccessGroupUserAccesses(id, userAccessesMap)
+		err = s.client(ctx).UpdateAccessGroupUserAccesses(id, userAccessesMap)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update access group user accesses", err), nil
 		}
@@ -132,7 +159,7 @@ func (s *PortainerMCPServer) HandleUpdateAccessGroupTeamAccesses() server.ToolHa
 			return mcp.NewToolResultErrorFromErr("invalid team accesses", err), nil
 		}
 
-		err = s.cli.UpdateAccessGroupTeamAccesses(id, teamAccessesMap)
+		err = s.client(ctx).UpdateAccessGroupTeamAccesses(id, teamAccessesMap)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update access group team accesses", err), nil
 		}
@@ -155,7 +182,7 @@ func (s *PortainerMCPServer) HandleAddEnvironmentToAccessGroup() server.ToolHand
 			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
 		}
 
-		err = s.cli.AddEnvironmentToAccessGroup(id, environmentId)
+		err = s.client(ctx).AddEnvironmentToAccessGroup(id, environmentId)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to add environment to access group", err), nil
 		}
@@ -178,7 +205,7 @@ func (s *PortainerMCPServer) HandleRemoveEnvironmentFromAccessGroup() server.Too
 			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
 		}
 
-		err = s.cli.RemoveEnvironmentFromAccessGroup(id, environmentId)
+		err = s.client(ctx).RemoveEnvironmentFromAccessGroup(id, environmentId)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to remove environment from access group", err), nil
 		}
@@ -186,3 +213,85 @@ func (s *PortainerMCPServer) HandleRemoveEnvironmentFromAccessGroup() server.Too
 		return mcp.NewToolResultText("Environment removed from access group successfully"), nil
 	}
 }
+
+func (s *PortainerMCPServer) HandleExportAccessReport() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		report, err := s.client(ctx).ExportAccessReport()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to export access report", err), nil
+		}
+
+		return mcp.NewToolResultText(report), nil
+	}
+}
+
+// parseAccessGroupUpdate parses the changes to apply to an access group.
+// Parameters that are not provided are left nil so that they are not changed.
+func parseAccessGroupUpdate(parser *toolgen.ParameterParser) (models.AccessGroupUpdate, error) {
+	var update models.AccessGroupUpdate
+
+	if parser.Has("name") {
+		name, err := parser.GetString("name", true)
+		if err != nil {
+			return update, fmt.Errorf("invalid name parameter: %w", err)
+		}
+		update.Name = &name
+	}
+
+	if parser.Has("environmentIds") {
+		environmentIds, err := parser.GetArrayOfIntegers("environmentIds", true)
+		if err != nil {
+			return update, fmt.Errorf("invalid environmentIds parameter: %w", err)
+		}
+		update.EnvironmentIds = environmentIds
+	}
+
+	if parser.Has("userAccesses") {
+		userAccesses, err := parser.GetArrayOfObjects("userAccesses", true)
+		if err != nil {
+			return update, fmt.Errorf("invalid userAccesses parameter: %w", err)
+		}
+
+		update.UserAccesses, err = parseAccessMap(userAccesses)
+		if err != nil {
+			return update, fmt.Errorf("invalid user accesses: %w", err)
+		}
+	}
+
+	if parser.Has("teamAccesses") {
+		teamAccesses, err := parser.GetArrayOfObjects("teamAccesses", true)
+		if err != nil {
+			return update, fmt.Errorf("invalid teamAccesses parameter: %w", err)
+		}
+
+		update.TeamAccesses, err = parseAccessMap(teamAccesses)
+		if err != nil {
+			return update, fmt.Errorf("invalid team accesses: %w", err)
+		}
+	}
+
+	return update, nil
+}
+
+func (s *PortainerMCPServer) HandleGetAccessGroupInheritedAccess() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		inherited, err := s.client(ctx).GetGroupInheritedAccess(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get access group inherited access", err), nil
+		}
+
+		data, err := json.Marshal(inherited)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal inherited access", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
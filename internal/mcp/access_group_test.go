@@ -10,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandleGetAccessGroups(t *testing.T) {
@@ -66,10 +67,12 @@ func TestHandleGetAccessGroups(t *testing.T) {
 				textContent, ok := result.Content[0].(mcp.TextContent)
 				assert.True(t, ok)
 
-				var groups []models.AccessGroup
+				var groups listResponse[models.AccessGroup]
 				err = json.Unmarshal([]byte(textContent.Text), &groups)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockGroups, groups)
+				assert.Equal(t, tt.mockGroups, groups.Items)
+				assert.Equal(t, len(tt.mockGroups), groups.TotalCount)
+				assert.False(t, groups.HasMore)
 			}
 
 			mockClient.AssertExpectations(t)
@@ -206,6 +209,105 @@ func TestHandleCreateAccessGroup(t *testing.T) {
 	}
 }
 
+func TestHandleUpdateAccessGroup(t *testing.T) {
+	name := "prod"
+
+	tests := []struct {
+		name          string
+		input         map[string]any
+		mockUpdate    *models.AccessGroupUpdate
+		mockError     error
+		expectedError string
+	}{
+		{
+			name: "all fields",
+			input: map[string]any{
+				"id":             float64(2),
+				"name":           "prod",
+				"environmentIds": []any{float64(1), float64(3)},
+				"userAccesses": []any{
+					map[string]any{"id": float64(1), "access": "environment_administrator"},
+				},
+				"teamAccesses": []any{},
+			},
+			mockUpdate: &models.AccessGroupUpdate{
+				Name:           &name,
+				EnvironmentIds: []int{1, 3},
+				UserAccesses:   map[int]string{1: "environment_administrator"},
+				TeamAccesses:   map[int]string{},
+			},
+		},
+		{
+			name: "omitted fields are left unchanged",
+			input: map[string]any{
+				"id":             float64(2),
+				"environmentIds": []any{},
+			},
+			mockUpdate: &models.AccessGroupUpdate{EnvironmentIds: []int{}},
+		},
+		{
+			name: "update error",
+			input: map[string]any{
+				"id":   float64(2),
+				"name": "prod",
+			},
+			mockUpdate: &models.AccessGroupUpdate{Name: &name},
+			mockError:  fmt.Errorf("failed to update access group 2: conflict, the changes already applied were rolled back"),
+		},
+		{
+			name:          "missing id parameter",
+			input:         map[string]any{"name": "prod"},
+			expectedError: "invalid id parameter",
+		},
+		{
+			name: "invalid access level",
+			input: map[string]any{
+				"id": float64(2),
+				"userAccesses": []any{
+					map[string]any{"id": float64(1), "access": "superuser"},
+				},
+			},
+			expectedError: "invalid user accesses",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.mockUpdate != nil {
+				mockClient.On("UpdateAccessGroup", 2, *tt.mockUpdate).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			request := CreateMCPRequest(tt.input)
+			handler := server.HandleUpdateAccessGroup()
+			result, err := handler(context.Background(), request)
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok)
+
+			switch {
+			case tt.mockError != nil:
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			case tt.expectedError != "":
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tt.expectedError)
+				mockClient.AssertNotCalled(t, "UpdateAccessGroup", mock.Anything, mock.Anything)
+			default:
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Access group updated successfully", textContent.Text)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandleUpdateAccessGroupName(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -782,3 +884,132 @@ func TestHandleRemoveEnvironmentFromAccessGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleExportAccessReport(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockReport  string
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:       "successful export",
+			mockReport: "user,environment,effective_role,source\nalice,prod,standard_user,user access on environment\n",
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("ExportAccessReport").Return(tt.mockReport, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleExportAccessReport()
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				assert.Equal(t, tt.mockReport, textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetAccessGroupInheritedAccess(t *testing.T) {
+	mockInherited := models.InheritedAccess{
+		AccessGroupID:   2,
+		AccessGroupName: "production",
+		UserAccesses:    map[int]string{1: "standard_user", 2: "readonly_user"},
+		TeamAccesses:    map[int]string{},
+		Environments: []models.EnvironmentInheritedAccess{
+			{
+				EnvironmentID:          4,
+				EnvironmentName:        "prod-eu",
+				InheritedUserAccesses:  map[int]string{1: "standard_user"},
+				InheritedTeamAccesses:  map[int]string{},
+				OverriddenUserAccesses: map[int]string{2: "environment_administrator"},
+				OverriddenTeamAccesses: map[int]string{},
+				DirectUserAccesses:     map[int]string{},
+				DirectTeamAccesses:     map[int]string{5: "operator_user"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(2)},
+			expectCall:  true,
+		},
+		{
+			name:        "access group not found",
+			inputParams: map[string]any{"id": float64(2)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("access group 2 not found"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetGroupInheritedAccess", 2).Return(mockInherited, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetAccessGroupInheritedAccess()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if tt.expectError {
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				}
+			} else {
+				var inherited models.InheritedAccess
+				err = json.Unmarshal([]byte(textContent.Text), &inherited)
+				assert.NoError(t, err)
+				assert.Equal(t, mockInherited, inherited)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+func (s *PortainerMCPServer) AddActivityLogFeatures() {
+	s.addToolIfExists(ToolListActivityLogs, s.HandleGetActivityLogs())
+}
+
+func (s *PortainerMCPServer) HandleGetActivityLogs() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		after, err := parseOptionalTime(parser, "after")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid after parameter", err), nil
+		}
+
+		before, err := parseOptionalTime(parser, "before")
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid before parameter", err), nil
+		}
+
+		username, err := parser.GetString("username", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid username parameter", err), nil
+		}
+
+		action, err := parser.GetString("action", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid action parameter", err), nil
+		}
+
+		limit, err := parser.GetInt("limit", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid limit parameter", err), nil
+		}
+		if limit < 0 {
+			return mcp.NewToolResultError("limit must be a positive number"), nil
+		}
+
+		logs, err := s.client(ctx).GetActivityLogs(models.ActivityLogFilter{
+			After:    after,
+			Before:   before,
+			Username: username,
+			Action:   action,
+			Limit:    limit,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get activity logs", err), nil
+		}
+
+		data, err := json.Marshal(logs)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal activity logs", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
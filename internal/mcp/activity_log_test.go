@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetActivityLogs(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]any
+		expectFilter *models.ActivityLogFilter
+		mockLogs     models.ActivityLogPage
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name:         "successful retrieval without filters",
+			args:         map[string]any{},
+			expectFilter: &models.ActivityLogFilter{},
+			mockLogs: models.ActivityLogPage{
+				Items: []models.ActivityLog{
+					{ID: 2, Username: "admin", Action: "POST /stacks"},
+					{ID: 1, Username: "alice", Action: "POST /endpoints"},
+				},
+				TotalCount: 2,
+			},
+		},
+		{
+			name: "successful retrieval with all filters",
+			args: map[string]any{
+				"after":    "2025-01-01T00:00:00Z",
+				"before":   "2025-01-31T00:00:00Z",
+				"username": "admin",
+				"action":   "DELETE",
+				"limit":    float64(50),
+			},
+			expectFilter: &models.ActivityLogFilter{
+				After:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Before:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+				Username: "admin",
+				Action:   "DELETE",
+				Limit:    50,
+			},
+			mockLogs: models.ActivityLogPage{
+				Items: []models.ActivityLog{
+					{ID: 5, Username: "admin", Action: "DELETE /stacks/3"},
+				},
+				TotalCount: 1,
+				HasMore:    true,
+			},
+		},
+		{
+			name:         "api error",
+			args:         map[string]any{},
+			expectFilter: &models.ActivityLogFilter{},
+			mockError:    fmt.Errorf("activity logs require Portainer Business Edition"),
+			expectError:  true,
+		},
+		{
+			name:        "invalid after timestamp",
+			args:        map[string]any{"after": "yesterday"},
+			expectError: true,
+		},
+		{
+			name:        "invalid before type",
+			args:        map[string]any{"before": float64(1)},
+			expectError: true,
+		},
+		{
+			name:        "negative limit",
+			args:        map[string]any{"limit": float64(-1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectFilter != nil {
+				mockClient.On("GetActivityLogs", *tt.expectFilter).Return(tt.mockLogs, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetActivityLogs()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.args))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var logs models.ActivityLogPage
+				err = json.Unmarshal([]byte(textContent.Text), &logs)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockLogs, logs)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
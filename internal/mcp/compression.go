@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// acceptsGzip checks whether the Accept-Encoding header of a request accepts the gzip encoding,
+// an encoding listed with a zero quality value is refused
+func acceptsGzip(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept-Encoding") {
+		for _, encoding := range strings.Split(header, ",") {
+			name, params, _ := strings.Cut(encoding, ";")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name != "gzip" && name != "*" {
+				continue
+			}
+
+			quality := strings.ReplaceAll(strings.ToLower(params), " ", "")
+			if quality == "q=0" || (strings.HasPrefix(quality, "q=0.") && strings.Trim(quality[len("q=0."):], "0") == "") {
+				return false
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// withGzip compresses the responses of the handler with gzip for the clients accepting it.
+// The server-sent event streams are not compressed: gzip buffers its output, the events would only
+// reach the client once enough of them were written.
+func withGzip(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		defer gw.close()
+
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// gzipResponseWriter compresses the body of a response. The status code is held until the body is
+// written, so that whether the response is compressed is decided from its final headers.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader holds the status code until the first write or flush of the response
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+	}
+}
+
+// Write compresses the body of the response, unless the response is not compressed
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	w.writeHeader(true)
+	if w.gz == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.gz.Write(data)
+}
+
+// Flush sends the data compressed so far to the client, it is required for streamed responses
+func (w *gzipResponseWriter) Flush() {
+	w.writeHeader(true)
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original response writer, for http.ResponseController
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writeHeader decides whether the response is compressed and sends its headers. The responses without
+// a body, the event streams and the responses already encoded by the handler are sent as is.
+func (w *gzipResponseWriter) writeHeader(hasBody bool) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	header := w.Header()
+	compress := hasBody &&
+		w.status != http.StatusNoContent && w.status != http.StatusNotModified &&
+		header.Get("Content-Encoding") == "" &&
+		!strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+
+	if compress {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// close sends the headers of a response without a body and completes the compressed body
+func (w *gzipResponseWriter) close() {
+	w.writeHeader(false)
+	if w.gz != nil {
+		w.gz.Close()
+	}
+}
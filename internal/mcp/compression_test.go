@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  []string
+		expected bool
+	}{
+		{name: "no header", expected: false},
+		{name: "gzip", headers: []string{"gzip"}, expected: true},
+		{name: "list of encodings", headers: []string{"deflate, GZIP;q=0.8, br"}, expected: true},
+		{name: "several headers", headers: []string{"br", "gzip"}, expected: true},
+		{name: "wildcard", headers: []string{"*"}, expected: true},
+		{name: "other encodings", headers: []string{"deflate, br"}, expected: false},
+		{name: "refused gzip", headers: []string{"gzip;q=0"}, expected: false},
+		{name: "refused gzip with decimals", headers: []string{"br, gzip; q=0.000"}, expected: false},
+		{name: "low quality gzip", headers: []string{"gzip;q=0.1"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			for _, header := range tt.headers {
+				req.Header.Add("Accept-Encoding", header)
+			}
+
+			assert.Equal(t, tt.expected, acceptsGzip(req))
+		})
+	}
+}
+
+func TestWithGzip(t *testing.T) {
+	body := `{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"` + strings.Repeat("container ", 100) + `"}]}}`
+
+	tests := []struct {
+		name             string
+		acceptEncoding   string
+		handler          http.HandlerFunc
+		expectedStatus   int
+		expectedEncoding string
+		expectedBody     string
+	}{
+		{
+			name:           "json response",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Content-Length", "1024")
+				w.WriteHeader(http.StatusOK)
+				io.WriteString(w, body)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedEncoding: "gzip",
+			expectedBody:     body,
+		},
+		{
+			name: "client without gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, body)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   body,
+		},
+		{
+			name:           "error response",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "session not found", http.StatusNotFound)
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedEncoding: "gzip",
+			expectedBody:     "session not found\n",
+		},
+		{
+			name:           "response without body",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "already encoded response",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "br")
+				io.WriteString(w, "brotli")
+			},
+			expectedStatus:   http.StatusOK,
+			expectedEncoding: "br",
+			expectedBody:     "brotli",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+
+			withGzip(tt.handler).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedEncoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+
+			respBody := rec.Body.String()
+			if tt.expectedEncoding == "gzip" {
+				assert.Empty(t, rec.Header().Get("Content-Length"))
+				assert.Less(t, rec.Body.Len(), len(tt.expectedBody)+32)
+
+				reader, err := gzip.NewReader(rec.Body)
+				require.NoError(t, err)
+				decoded, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				respBody = string(decoded)
+			}
+			assert.Equal(t, tt.expectedBody, respBody)
+		})
+	}
+}
+
+func TestWithGzipEventStream(t *testing.T) {
+	events := make(chan string)
+	handler := withGzip(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		for event := range events {
+			io.WriteString(w, "data: "+event+"\n\n")
+			w.(http.Flusher).Flush()
+		}
+	}))
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	defer close(events)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	// Setting the header disables the transparent decompression of the Go client
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+
+	// Each event is received as soon as it is written, the stream is not buffered
+	reader := bufio.NewReader(resp.Body)
+	for _, event := range []string{"first", "second"} {
+		events <- event
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "data: "+event+"\n", line)
+		_, err = reader.ReadString('\n')
+		require.NoError(t, err)
+	}
+}
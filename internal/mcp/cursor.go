@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// containerCursorTTL is how long a container listing cursor can be used to fetch the next page
+const containerCursorTTL = 5 * time.Minute
+
+// containerCursor holds the position of a paged container listing.
+// The listing is fetched again from Portainer for every page, the cursor only keeps
+// the filter and the offset of the next page.
+type containerCursor struct {
+	environmentID int
+	filter        models.ContainerFilter
+	offset        int
+	expiresAt     time.Time
+}
+
+// containerCursorStore keeps the cursors of the paged container listings in memory.
+// Cursors are opaque random tokens so that MCP clients cannot forge or alter them,
+// they expire after a TTL and are discarded when the server restarts.
+type containerCursorStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	cursors map[string]containerCursor
+}
+
+// newContainerCursorStore creates a cursor store whose cursors expire after the given TTL
+func newContainerCursorStore(ttl time.Duration) *containerCursorStore {
+	return &containerCursorStore{
+		ttl:     ttl,
+		now:     time.Now,
+		cursors: make(map[string]containerCursor),
+	}
+}
+
+// save stores a cursor and returns the token that identifies it.
+// Expired cursors are removed at the same time so that the store does not grow unbounded.
+func (s *containerCursorStore) save(cursor containerCursor) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate cursor: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for t, c := range s.cursors {
+		if !now.Before(c.expiresAt) {
+			delete(s.cursors, t)
+		}
+	}
+
+	cursor.expiresAt = now.Add(s.ttl)
+	s.cursors[token] = cursor
+
+	return token, nil
+}
+
+// load returns the cursor identified by a token, unknown and expired cursors are rejected
+func (s *containerCursorStore) load(token string) (containerCursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursor, ok := s.cursors[token]
+	if !ok || !s.now().Before(cursor.expiresAt) {
+		delete(s.cursors, token)
+		return containerCursor{}, fmt.Errorf("cursor is invalid or expired, list the containers again without a cursor")
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerCursorStore(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newContainerCursorStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	cursor := containerCursor{
+		environmentID: 1,
+		filter:        models.ContainerFilter{All: true, Name: "web"},
+		offset:        100,
+	}
+
+	token, err := store.save(cursor)
+	require.NoError(t, err)
+	assert.Len(t, token, 32)
+
+	other, err := store.save(cursor)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other, "every cursor should get a distinct token")
+
+	loaded, err := store.load(token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.environmentID)
+	assert.Equal(t, cursor.filter, loaded.filter)
+	assert.Equal(t, 100, loaded.offset)
+
+	_, err = store.load("unknown")
+	assert.Error(t, err)
+
+	now = now.Add(time.Minute)
+	_, err = store.load(token)
+	assert.Error(t, err, "expired cursors should be rejected")
+
+	_, err = store.save(cursor)
+	require.NoError(t, err)
+	assert.Len(t, store.cursors, 1, "expired cursors should be removed when a cursor is saved")
+}
@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+func (s *PortainerMCPServer) AddCustomTemplateFeatures() {
+	s.addToolIfExists(ToolListCustomTemplates, s.HandleGetCustomTemplates())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolCreateCustomTemplate, s.HandleCreateCustomTemplate())
+		s.addToolIfExists(ToolDeleteCustomTemplate, s.HandleDeleteCustomTemplate())
+		s.addToolIfExists(ToolDeployCustomTemplate, s.HandleDeployCustomTemplate())
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetCustomTemplates() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		templates, err := s.client(ctx).GetCustomTemplates()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get custom templates", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(templates))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal custom templates", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCreateCustomTemplate() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		title, err := parser.GetString("title", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid title parameter", err), nil
+		}
+
+		description, err := parser.GetString("description", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid description parameter", err), nil
+		}
+
+		note, err := parser.GetString("note", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid note parameter", err), nil
+		}
+
+		logo, err := parser.GetString("logo", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid logo parameter", err), nil
+		}
+
+		templateType, err := parser.GetString("type", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid type parameter", err), nil
+		}
+		if templateType == "" {
+			templateType = models.CustomTemplateTypeCompose
+		}
+
+		platform, err := parser.GetString("platform", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid platform parameter", err), nil
+		}
+		if platform == "" && templateType != models.CustomTemplateTypeKubernetes {
+			platform = models.CustomTemplatePlatformLinux
+		}
+
+		edgeTemplate, err := parser.GetBoolean("edgeTemplate", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid edgeTemplate parameter", err), nil
+		}
+
+		file, err := parser.GetString("file", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid file parameter", err), nil
+		}
+
+		git, err := parseCustomTemplateGit(parser)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid git parameters", err), nil
+		}
+
+		template := models.CustomTemplate{
+			Title:        title,
+			Description:  description,
+			Note:         note,
+			Logo:         logo,
+			Type:         templateType,
+			Platform:     platform,
+			EdgeTemplate: edgeTemplate,
+			FileContent:  file,
+			Git:          git,
+		}
+
+		id, err := s.client(ctx).CreateCustomTemplate(template)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to create custom template", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Custom template created successfully with ID: %d", id)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleDeleteCustomTemplate() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		err = s.client(ctx).DeleteCustomTemplate(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to delete custom template", err), nil
+		}
+
+		return mcp.NewToolResultText("Custom template deleted successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleDeployCustomTemplate() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		templateId, err := parser.GetInt("templateId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid templateId parameter", err), nil
+		}
+
+		name, err := parser.GetString("name", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
+		}
+
+		environmentGroupIds, err := parser.GetArrayOfIntegers("environmentGroupIds", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentGroupIds parameter", err), nil
+		}
+
+		entries, err := parser.GetArrayOfObjects("variables", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid variables parameter", err), nil
+		}
+
+		variables, err := parseKeyValueMap(entries)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid variables", err), nil
+		}
+
+		id, err := s.client(ctx).DeployCustomTemplate(templateId, name, environmentGroupIds, variables)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to deploy custom template", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Custom template deployed successfully as stack with ID: %d", id)), nil
+	}
+}
+
+// parseCustomTemplateGit parses the git repository of a custom template, it returns nil when no
+// git repository URL is provided. The git settings are rejected without a URL so that they are
+// not silently ignored.
+func parseCustomTemplateGit(parser *toolgen.ParameterParser) (*models.CustomTemplateGit, error) {
+	url, err := parser.GetString("gitUrl", false)
+	if err != nil {
+		return nil, err
+	}
+
+	if url == "" {
+		for _, name := range []string{"gitReference", "gitFilePath", "gitUsername", "gitPassword", "gitTlsSkipVerify"} {
+			if parser.Has(name) {
+				return nil, fmt.Errorf("%s requires gitUrl", name)
+			}
+		}
+		return nil, nil
+	}
+
+	git := &models.CustomTemplateGit{URL: url}
+
+	if git.ReferenceName, err = parser.GetString("gitReference", false); err != nil {
+		return nil, err
+	}
+	if git.ConfigFilePath, err = parser.GetString("gitFilePath", false); err != nil {
+		return nil, err
+	}
+	if git.Username, err = parser.GetString("gitUsername", false); err != nil {
+		return nil, err
+	}
+	if git.Password, err = parser.GetString("gitPassword", false); err != nil {
+		return nil, err
+	}
+	if git.TLSSkipVerify, err = parser.GetBoolean("gitTlsSkipVerify", false); err != nil {
+		return nil, err
+	}
+
+	return git, nil
+}
@@ -0,0 +1,386 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetCustomTemplates(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockTemplates []models.CustomTemplate
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "successful retrieval",
+			mockTemplates: []models.CustomTemplate{
+				{ID: 1, Title: "nginx", Description: "Nginx web server", Type: models.CustomTemplateTypeCompose, Platform: models.CustomTemplatePlatformLinux},
+				{ID: 2, Title: "agent", Description: "Monitoring agent", Type: models.CustomTemplateTypeSwarm, Platform: models.CustomTemplatePlatformLinux, EdgeTemplate: true,
+					Git: &models.CustomTemplateGit{URL: "https://github.com/example/stacks.git", ReferenceName: "refs/heads/main"}},
+			},
+		},
+		{
+			name:          "empty templates",
+			mockTemplates: []models.CustomTemplate{},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetCustomTemplates").Return(tt.mockTemplates, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetCustomTemplates()
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var templates listResponse[models.CustomTemplate]
+				err = json.Unmarshal([]byte(textContent.Text), &templates)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockTemplates, templates.Items)
+				assert.Equal(t, len(tt.mockTemplates), templates.TotalCount)
+				assert.False(t, templates.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCreateCustomTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedInput models.CustomTemplate
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "from inline file with defaults",
+			inputParams: map[string]any{
+				"title":       "nginx",
+				"description": "Nginx web server",
+				"file":        "services: {}",
+			},
+			expectCall: true,
+			expectedInput: models.CustomTemplate{
+				Title:       "nginx",
+				Description: "Nginx web server",
+				Type:        models.CustomTemplateTypeCompose,
+				Platform:    models.CustomTemplatePlatformLinux,
+				FileContent: "services: {}",
+			},
+		},
+		{
+			name: "from git",
+			inputParams: map[string]any{
+				"title":            "agent",
+				"description":      "Monitoring agent",
+				"note":             "Requires the host network",
+				"type":             "swarm",
+				"platform":         "windows",
+				"edgeTemplate":     true,
+				"gitUrl":           "https://github.com/example/stacks.git",
+				"gitReference":     "refs/heads/main",
+				"gitFilePath":      "agent/docker-compose.yml",
+				"gitUsername":      "deploy",
+				"gitPassword":      "s3cret",
+				"gitTlsSkipVerify": true,
+			},
+			expectCall: true,
+			expectedInput: models.CustomTemplate{
+				Title:        "agent",
+				Description:  "Monitoring agent",
+				Note:         "Requires the host network",
+				Type:         models.CustomTemplateTypeSwarm,
+				Platform:     models.CustomTemplatePlatformWindows,
+				EdgeTemplate: true,
+				Git: &models.CustomTemplateGit{
+					URL:            "https://github.com/example/stacks.git",
+					ReferenceName:  "refs/heads/main",
+					ConfigFilePath: "agent/docker-compose.yml",
+					Username:       "deploy",
+					Password:       "s3cret",
+					TLSSkipVerify:  true,
+				},
+			},
+		},
+		{
+			name: "kubernetes template has no default platform",
+			inputParams: map[string]any{
+				"title":       "redis",
+				"description": "Redis deployment",
+				"type":        "kubernetes",
+				"file":        "kind: Deployment",
+			},
+			expectCall: true,
+			expectedInput: models.CustomTemplate{
+				Title:       "redis",
+				Description: "Redis deployment",
+				Type:        models.CustomTemplateTypeKubernetes,
+				FileContent: "kind: Deployment",
+			},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"title":       "nginx",
+				"description": "Nginx web server",
+				"file":        "services: {}",
+			},
+			expectCall: true,
+			expectedInput: models.CustomTemplate{
+				Title:       "nginx",
+				Description: "Nginx web server",
+				Type:        models.CustomTemplateTypeCompose,
+				Platform:    models.CustomTemplatePlatformLinux,
+				FileContent: "services: {}",
+			},
+			mockError:   fmt.Errorf("failed to create custom template"),
+			expectError: true,
+		},
+		{
+			name:        "missing title parameter",
+			inputParams: map[string]any{"description": "Nginx web server", "file": "services: {}"},
+			expectError: true,
+		},
+		{
+			name:        "missing description parameter",
+			inputParams: map[string]any{"title": "nginx", "file": "services: {}"},
+			expectError: true,
+		},
+		{
+			name:        "git settings without gitUrl",
+			inputParams: map[string]any{"title": "nginx", "description": "Nginx web server", "file": "services: {}", "gitReference": "refs/heads/main"},
+			expectError: true,
+		},
+		{
+			name:        "invalid edgeTemplate parameter",
+			inputParams: map[string]any{"title": "nginx", "description": "Nginx web server", "file": "services: {}", "edgeTemplate": "yes"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CreateCustomTemplate", tt.expectedInput).Return(5, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCreateCustomTemplate()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Equal(t, "Custom template created successfully with ID: 5", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteCustomTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful deletion",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to delete custom template"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("DeleteCustomTemplate", 1).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDeleteCustomTemplate()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeployCustomTemplate(t *testing.T) {
+	tests := []struct {
+		name              string
+		inputParams       map[string]any
+		expectCall        bool
+		expectedVariables map[string]string
+		mockError         error
+		expectError       bool
+	}{
+		{
+			name: "successful deployment",
+			inputParams: map[string]any{
+				"templateId":          float64(4),
+				"name":                "web",
+				"environmentGroupIds": []any{float64(1), float64(2)},
+				"variables": []any{
+					map[string]any{"key": "domain", "value": "example.com"},
+					map[string]any{"key": "port", "value": "8080"},
+				},
+			},
+			expectCall:        true,
+			expectedVariables: map[string]string{"domain": "example.com", "port": "8080"},
+		},
+		{
+			name: "without variables",
+			inputParams: map[string]any{
+				"templateId":          float64(4),
+				"name":                "web",
+				"environmentGroupIds": []any{float64(1), float64(2)},
+			},
+			expectCall:        true,
+			expectedVariables: map[string]string{},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"templateId":          float64(4),
+				"name":                "web",
+				"environmentGroupIds": []any{float64(1), float64(2)},
+			},
+			expectCall:        true,
+			expectedVariables: map[string]string{},
+			mockError:         fmt.Errorf("missing required variables: domain"),
+			expectError:       true,
+		},
+		{
+			name:        "missing templateId parameter",
+			inputParams: map[string]any{"name": "web", "environmentGroupIds": []any{float64(1)}},
+			expectError: true,
+		},
+		{
+			name:        "missing environmentGroupIds parameter",
+			inputParams: map[string]any{"templateId": float64(4), "name": "web"},
+			expectError: true,
+		},
+		{
+			name: "invalid variables",
+			inputParams: map[string]any{
+				"templateId":          float64(4),
+				"name":                "web",
+				"environmentGroupIds": []any{float64(1)},
+				"variables":           []any{map[string]any{"key": "domain"}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("DeployCustomTemplate", 4, "web", []int{1, 2}, tt.expectedVariables).Return(12, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDeployCustomTemplate()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Equal(t, "Custom template deployed successfully as stack with ID: 12", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -1,10 +1,17 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -13,8 +20,26 @@ import (
 )
 
 func (s *PortainerMCPServer) AddDockerProxyFeatures() {
+	s.addToolIfExists(ToolGetDockerInfo, s.HandleGetDockerInfo())
+	s.addToolIfExists(ToolListContainers, s.HandleListContainers())
+	s.addToolIfExists(ToolGetContainerChanges, s.HandleGetContainerChanges())
+	s.addToolIfExists(ToolGetContainerProcesses, s.HandleGetContainerProcesses())
+	s.addToolIfExists(ToolGetContainerHealth, s.HandleGetContainerHealth())
+	s.addToolIfExists(ToolGetContainerMounts, s.HandleGetContainerMounts())
+	s.addToolIfExists(ToolCheckImageUpdates, s.HandleCheckImageUpdates())
+	s.addToolIfExists(ToolFindOrphanedResources, s.HandleFindOrphanedResources())
+	s.addToolIfExists(ToolGetContainerFile, s.HandleGetContainerFile())
+	s.addToolIfExists(ToolFollowContainerLogs, s.HandleFollowContainerLogs())
+
 	if !s.readOnly {
 		s.addToolIfExists(ToolDockerProxy, s.HandleDockerProxy())
+		s.addToolIfExists(ToolPutContainerFile, s.HandlePutContainerFile())
+		s.addToolIfExists(ToolUpdateContainerResources, s.HandleUpdateContainerResources())
+		s.addToolIfExists(ToolUpdateContainerRestartPolicy, s.HandleUpdateContainerRestartPolicy())
+		s.addToolIfExists(ToolRecreateContainer, s.HandleRecreateContainer())
+		s.addToolIfExists(ToolConnectContainerToNetwork, s.HandleConnectContainerToNetwork())
+		s.addToolIfExists(ToolDisconnectContainerFromNetwork, s.HandleDisconnectContainerFromNetwork())
+		s.addToolIfExists(ToolPruneDockerResources, s.HandlePruneDockerResources())
 	}
 }
 
@@ -43,6 +68,11 @@ func (s *PortainerMCPServer) HandleDockerProxy() server.ToolHandlerFunc {
 			return mcp.NewToolResultError("dockerAPIPath must start with a leading slash"), nil
 		}
 
+		apiVersion, err := parser.GetString("apiVersion", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid apiVersion parameter", err), nil
+		}
+
 		queryParams, err := parser.GetArrayOfObjects("queryParams", false)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("invalid queryParams parameter", err), nil
@@ -69,6 +99,7 @@ func (s *PortainerMCPServer) HandleDockerProxy() server.ToolHandlerFunc {
 		opts := models.DockerProxyRequestOptions{
 			EnvironmentID: environmentId,
 			Path:          dockerAPIPath,
+			APIVersion:    apiVersion,
 			Method:        method,
 			QueryParams:   queryParamsMap,
 			Headers:       headersMap,
@@ -78,7 +109,7 @@ func (s *PortainerMCPServer) HandleDockerProxy() server.ToolHandlerFunc {
 			opts.Body = strings.NewReader(body)
 		}
 
-		response, err := s.cli.ProxyDockerRequest(opts)
+		response, err := s.client(ctx).ProxyDockerRequest(opts)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to send Docker API request", err), nil
 		}
@@ -91,3 +122,776 @@ func (s *PortainerMCPServer) HandleDockerProxy() server.ToolHandlerFunc {
 		return mcp.NewToolResultText(string(responseBody)), nil
 	}
 }
+
+const (
+	// defaultContainerPageSize is the number of containers returned per page when no limit is provided
+	defaultContainerPageSize = 100
+	// maxContainerPageSize is the maximum number of containers returned per page
+	maxContainerPageSize = 500
+)
+
+func (s *PortainerMCPServer) HandleListContainers() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		limit, err := parser.GetInt("limit", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid limit parameter", err), nil
+		}
+		if limit == 0 {
+			limit = defaultContainerPageSize
+		}
+		if limit < 0 || limit > maxContainerPageSize {
+			return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxContainerPageSize)), nil
+		}
+
+		token, err := parser.GetString("cursor", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid cursor parameter", err), nil
+		}
+
+		// The filter of a paged listing is kept in its cursor so that every page is taken from the same listing
+		var cursor containerCursor
+		if token != "" {
+			cursor, err = s.containerCursors.load(token)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid cursor parameter", err), nil
+			}
+			if cursor.environmentID != environmentId {
+				return mcp.NewToolResultError(fmt.Sprintf("cursor belongs to the listing of environment %d", cursor.environmentID)), nil
+			}
+		} else {
+			all, err := parser.GetBoolean("all", false)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid all parameter", err), nil
+			}
+
+			name, err := parser.GetString("name", false)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
+			}
+
+			filterEntries, err := parser.GetArrayOfObjects("filters", false)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid filters parameter", err), nil
+			}
+
+			var filters map[string][]string
+			if len(filterEntries) > 0 {
+				if filters, err = parseKeyValuesMap(filterEntries); err != nil {
+					return mcp.NewToolResultErrorFromErr("invalid filters parameter", err), nil
+				}
+			}
+
+			cursor = containerCursor{
+				environmentID: environmentId,
+				filter:        models.ContainerFilter{All: all, Name: name, Filters: filters},
+			}
+		}
+
+		containers, err := s.client(ctx).ListContainers(environmentId, cursor.filter)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to list containers", err), nil
+		}
+
+		start := min(cursor.offset, len(containers))
+		end := min(start+limit, len(containers))
+
+		page := models.ContainerPage{
+			EnvironmentID: environmentId,
+			Items:         containers[start:end],
+			TotalCount:    len(containers),
+			HasMore:       end < len(containers),
+		}
+
+		if page.HasMore {
+			cursor.offset = end
+			page.NextCursor, err = s.containerCursors.save(cursor)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to save cursor", err), nil
+			}
+		}
+
+		data, err := json.Marshal(page)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal containers", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetDockerInfo() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		info, err := s.client(ctx).GetDockerInfo(environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get docker info", err), nil
+		}
+
+		data, err := json.Marshal(info)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal docker info", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetContainerChanges() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		changes, err := s.client(ctx).GetContainerChanges(environmentId, containerId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get container changes", err), nil
+		}
+
+		data, err := json.Marshal(models.GroupFilesystemChanges(changes))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal container changes", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetContainerProcesses() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		processes, err := s.client(ctx).GetContainerProcesses(environmentId, containerId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get container processes", err), nil
+		}
+
+		data, err := json.Marshal(processes)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal container processes", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetContainerHealth() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		health, err := s.client(ctx).GetContainerHealth(environmentId, containerId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get container health", err), nil
+		}
+
+		data, err := json.Marshal(health)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal container health", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCheckImageUpdates() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		statuses, err := s.client(ctx).CheckImageUpdates(environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to check image updates", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(statuses))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal image update statuses", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleFindOrphanedResources() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		report, err := s.client(ctx).FindOrphanedResources(environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to find orphaned resources", err), nil
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal orphan report", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetContainerMounts() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		mounts, err := s.client(ctx).GetContainerMounts(environmentId, containerId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get container mounts", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(mounts))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal container mounts", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetContainerFile() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		path, err := parser.GetString("path", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid path parameter", err), nil
+		}
+
+		content, err := s.client(ctx).GetFileFromContainer(environmentId, containerId, path)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get file from container", err), nil
+		}
+
+		file := models.ContainerFile{
+			Path:     path,
+			Size:     len(content),
+			Encoding: FileEncodingText,
+			Content:  string(content),
+		}
+		if isBinaryContent(content) {
+			file.Encoding = FileEncodingBase64
+			file.Content = base64.StdEncoding.EncodeToString(content)
+			file.Warning = "the file has binary content, it is encoded in base64"
+		}
+
+		data, err := json.Marshal(file)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal container file", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandlePutContainerFile() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		path, err := parser.GetString("path", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid path parameter", err), nil
+		}
+
+		rawContent, err := parser.GetString("content", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid content parameter", err), nil
+		}
+
+		encoding, err := parser.GetString("encoding", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid encoding parameter", err), nil
+		}
+
+		var content []byte
+		switch encoding {
+		case "", FileEncodingText:
+			content = []byte(rawContent)
+		case FileEncodingBase64:
+			content, err = base64.StdEncoding.DecodeString(rawContent)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid base64 content", err), nil
+			}
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("invalid encoding %s: must be %s or %s", encoding, FileEncodingText, FileEncodingBase64)), nil
+		}
+
+		err = s.client(ctx).PutFileInContainer(environmentId, containerId, path, content)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to put file in container", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("File %s copied successfully (%d bytes)", path, len(content))), nil
+	}
+}
+
+// isBinaryContent reports whether a file content cannot be returned as text,
+// either because it is not valid UTF-8 or because it holds NUL bytes
+func isBinaryContent(content []byte) bool {
+	return !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0
+}
+
+func (s *PortainerMCPServer) HandlePruneDockerResources() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		resources, err := parser.GetArrayOfStrings("resources", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid resources parameter", err), nil
+		}
+		if len(resources) == 0 {
+			return mcp.NewToolResultError("invalid resources parameter: at least one resource must be provided"), nil
+		}
+		for _, resource := range resources {
+			if !slices.Contains(models.AllPruneResources, resource) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid resources parameter: unknown resource %s, valid values are %s", resource, strings.Join(models.AllPruneResources, ", "))), nil
+			}
+		}
+
+		labels, err := parser.GetArrayOfStrings("labels", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid labels parameter", err), nil
+		}
+		if len(labels) > 0 && slices.Contains(resources, models.PruneResourceBuildCache) {
+			return mcp.NewToolResultError("invalid labels parameter: the build cache cannot be pruned by label, prune it in a separate call without labels"), nil
+		}
+
+		prunes := map[string]func(int, []string) (models.PruneReport, error){
+			models.PruneResourceContainers: s.client(ctx).PruneContainers,
+			models.PruneResourceNetworks:   s.client(ctx).PruneNetworks,
+			models.PruneResourceBuildCache: s.client(ctx).PruneBuildCache,
+		}
+
+		// Containers are pruned first so that the networks they used can be pruned with them
+		var reports []models.PruneReport
+		for _, resource := range models.AllPruneResources {
+			if !slices.Contains(resources, resource) {
+				continue
+			}
+
+			report, err := prunes[resource](environmentId, labels)
+			if err != nil {
+				if len(reports) == 0 {
+					return mcp.NewToolResultErrorFromErr("failed to prune Docker resources", err), nil
+				}
+				data, _ := json.Marshal(models.SummarizePruneReports(reports))
+				return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to prune Docker resources, already pruned: %s", data), err), nil
+			}
+			reports = append(reports, report)
+		}
+
+		data, err := json.Marshal(models.SummarizePruneReports(reports))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal prune summary", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+const (
+	// defaultContainerLogTail is the number of existing log lines sent when no tail is provided
+	defaultContainerLogTail = 100
+	// maxContainerLogTail is the maximum number of existing log lines sent
+	maxContainerLogTail = 1000
+	// defaultContainerLogFollowDuration is how long the logs are followed when no duration is provided
+	defaultContainerLogFollowDuration = 60 * time.Second
+	// maxContainerLogFollowDuration is the maximum time the logs are followed for
+	maxContainerLogFollowDuration = 600 * time.Second
+)
+
+// Reasons for the end of a container log stream
+const (
+	logStreamStoppedContainer = "container_stopped"
+	logStreamStoppedDeadline  = "deadline"
+	logStreamStoppedCancelled = "cancelled"
+)
+
+// containerLogsResult is the result of the followContainerLogs tool. When the logs are streamed the
+// lines are sent in notifications and only counted here, they are returned in Lines otherwise.
+type containerLogsResult struct {
+	Streamed     bool                      `json:"streamed"`
+	LinesSent    int                       `json:"lines_sent"`
+	DroppedLines int                       `json:"dropped_lines,omitempty"`
+	StoppedBy    string                    `json:"stopped_by,omitempty"`
+	Lines        []models.ContainerLogLine `json:"lines,omitempty"`
+	Note         string                    `json:"note,omitempty"`
+}
+
+// canStreamNotifications reports whether the client of a tool call can receive notifications while
+// the call is in progress. Only the HTTP transport upgrades the response of a call to an SSE stream.
+func canStreamNotifications(ctx context.Context) bool {
+	_, ok := server.ClientSessionFromContext(ctx).(server.SessionWithStreamableHTTPConfig)
+	return ok
+}
+
+func (s *PortainerMCPServer) HandleFollowContainerLogs() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		tail := defaultContainerLogTail
+		if parser.Has("tail") {
+			tail, err = parser.GetInt("tail", false)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid tail parameter", err), nil
+			}
+			if tail < 0 || tail > maxContainerLogTail {
+				return mcp.NewToolResultError(fmt.Sprintf("tail must be between 0 and %d", maxContainerLogTail)), nil
+			}
+		}
+
+		durationSeconds, err := parser.GetInt("duration", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid duration parameter", err), nil
+		}
+		duration := time.Duration(durationSeconds) * time.Second
+		if duration == 0 {
+			duration = defaultContainerLogFollowDuration
+		}
+		if duration < 0 || duration > maxContainerLogFollowDuration {
+			return mcp.NewToolResultError(fmt.Sprintf("duration must be between 1 and %d seconds", int(maxContainerLogFollowDuration.Seconds()))), nil
+		}
+
+		// Notifications sent under stdio would only be read once the call returns, the latest lines
+		// are fetched once instead
+		if !canStreamNotifications(ctx) {
+			lines, err := s.client(ctx).GetContainerLogs(environmentId, containerId, tail)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to get container logs", err), nil
+			}
+
+			return marshalContainerLogsResult(containerLogsResult{
+				LinesSent: len(lines),
+				Lines:     lines,
+				Note:      "logs can only be followed over the HTTP transport, the latest lines were returned instead",
+			})
+		}
+
+		// The stream is registered so that it can be listed and cancelled while it is in progress
+		streamCtx, done, err := s.operations.start(ctx, ToolFollowContainerLogs, fmt.Sprintf("Following the logs of container %s in environment %d", containerId, environmentId))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to register log stream", err), nil
+		}
+		defer done()
+
+		streamCtx, cancel := context.WithTimeout(streamCtx, duration)
+		defer cancel()
+
+		result := containerLogsResult{Streamed: true}
+		err = s.client(streamCtx).FollowContainerLogs(streamCtx, environmentId, containerId, tail, func(line models.ContainerLogLine) error {
+			err := s.srv.SendNotificationToClient(streamCtx, "notifications/message", map[string]any{
+				"level":  mcp.LoggingLevelInfo,
+				"logger": ToolFollowContainerLogs,
+				"data":   line,
+			})
+			// Lines arriving faster than the client reads them are dropped rather than blocking the stream
+			if errors.Is(err, server.ErrNotificationChannelBlocked) {
+				result.DroppedLines++
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			result.LinesSent++
+			return nil
+		})
+
+		switch {
+		case err == nil:
+			result.StoppedBy = logStreamStoppedContainer
+		case errors.Is(err, context.DeadlineExceeded):
+			result.StoppedBy = logStreamStoppedDeadline
+		case errors.Is(err, context.Canceled):
+			result.StoppedBy = logStreamStoppedCancelled
+		case result.LinesSent == 0:
+			return mcp.NewToolResultErrorFromErr("failed to follow container logs", err), nil
+		default:
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to follow container logs after %d lines", result.LinesSent), err), nil
+		}
+
+		return marshalContainerLogsResult(result)
+	}
+}
+
+// marshalContainerLogsResult returns the result of the followContainerLogs tool as JSON
+func marshalContainerLogsResult(result containerLogsResult) (*mcp.CallToolResult, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to marshal container logs", err), nil
+	}
+
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+func (s *PortainerMCPServer) HandleUpdateContainerResources() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		limits := models.ResourceLimits{}
+
+		if parser.Has("cpus") {
+			cpus, err := parser.GetNumber("cpus", true)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid cpus parameter", err), nil
+			}
+			limits.CPUs = &cpus
+		}
+
+		intLimits := []struct {
+			name  string
+			value **int64
+		}{
+			{"cpuShares", &limits.CPUShares},
+			{"memoryBytes", &limits.MemoryBytes},
+			{"memoryReservationBytes", &limits.MemoryReservationBytes},
+			{"memorySwapBytes", &limits.MemorySwapBytes},
+			{"pidsLimit", &limits.PidsLimit},
+		}
+		for _, limit := range intLimits {
+			if !parser.Has(limit.name) {
+				continue
+			}
+			value, err := parser.GetInt(limit.name, true)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr(fmt.Sprintf("invalid %s parameter", limit.name), err), nil
+			}
+			v := int64(value)
+			*limit.value = &v
+		}
+
+		err = s.client(ctx).UpdateContainerResources(environmentId, containerId, limits)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update container resources", err), nil
+		}
+
+		return mcp.NewToolResultText("Container resources updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateContainerRestartPolicy() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		policy, err := parser.GetString("policy", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid policy parameter", err), nil
+		}
+
+		maximumRetryCount, err := parser.GetInt("maximumRetryCount", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid maximumRetryCount parameter", err), nil
+		}
+
+		err = s.client(ctx).UpdateContainerRestartPolicy(environmentId, containerId, models.RestartPolicy{
+			Name:              policy,
+			MaximumRetryCount: maximumRetryCount,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update container restart policy", err), nil
+		}
+
+		return mcp.NewToolResultText("Container restart policy updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleRecreateContainer() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		pullLatest, err := parser.GetBoolean("pullLatest", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid pullLatest parameter", err), nil
+		}
+
+		err = s.client(ctx).RecreateContainer(environmentId, containerId, pullLatest)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to recreate container", err), nil
+		}
+
+		return mcp.NewToolResultText("Container recreated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleConnectContainerToNetwork() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		networkId, err := parser.GetString("networkId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid networkId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		err = s.client(ctx).ConnectContainerToNetwork(environmentId, networkId, containerId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to connect container to network", err), nil
+		}
+
+		return mcp.NewToolResultText("Container connected to network successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleDisconnectContainerFromNetwork() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		networkId, err := parser.GetString("networkId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid networkId parameter", err), nil
+		}
+
+		containerId, err := parser.GetString("containerId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid containerId parameter", err), nil
+		}
+
+		force, err := parser.GetBoolean("force", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid force parameter", err), nil
+		}
+
+		err = s.client(ctx).DisconnectContainerFromNetwork(environmentId, networkId, containerId, force)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to disconnect container from network", err), nil
+		}
+
+		return mcp.NewToolResultText("Container disconnected from network successfully"), nil
+	}
+}
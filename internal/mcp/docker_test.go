@@ -2,16 +2,21 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func createMockHttpResponse(statusCode int, body string) *http.Response {
@@ -152,6 +157,29 @@ func TestHandleDockerProxy_ParameterValidation(t *testing.T) {
 	}
 }
 
+func TestHandleDockerProxy_APIVersion(t *testing.T) {
+	mockClient := new(MockPortainerClient)
+	mockClient.On("ProxyDockerRequest", mock.MatchedBy(func(opts models.DockerProxyRequestOptions) bool {
+		return opts.Path == "/containers/json" && opts.APIVersion == "1.41"
+	})).Return(createMockHttpResponse(http.StatusOK, `[]`), nil)
+
+	server := &PortainerMCPServer{
+		cli: mockClient,
+	}
+
+	handler := server.HandleDockerProxy()
+	result, err := handler(context.Background(), CreateMCPRequest(map[string]any{
+		"environmentId": float64(1),
+		"dockerAPIPath": "/containers/json",
+		"method":        "GET",
+		"apiVersion":    "1.41",
+	}))
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	mockClient.AssertExpectations(t)
+}
+
 func TestHandleDockerProxy_ClientInteraction(t *testing.T) {
 	type testCase struct {
 		name  string
@@ -301,3 +329,1564 @@ func TestHandleDockerProxy_ClientInteraction(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGetContainerChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockChanges []models.FilesystemChange
+		mockError   error
+		expected    models.ContainerChanges
+		expectError bool
+	}{
+		{
+			name:        "changes grouped by kind",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+			mockChanges: []models.FilesystemChange{
+				{Path: "/etc/nginx", Kind: models.FilesystemChangeModified},
+				{Path: "/etc/nginx/conf.d/site.conf", Kind: models.FilesystemChangeAdded},
+				{Path: "/tmp/cache", Kind: models.FilesystemChangeDeleted},
+				{Path: "/var/log/nginx/access.log", Kind: models.FilesystemChangeAdded},
+			},
+			expected: models.ContainerChanges{
+				Added:    []string{"/etc/nginx/conf.d/site.conf", "/var/log/nginx/access.log"},
+				Modified: []string{"/etc/nginx"},
+				Deleted:  []string{"/tmp/cache"},
+			},
+		},
+		{
+			name:        "no changes",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+			mockChanges: []models.FilesystemChange{},
+			expected: models.ContainerChanges{
+				Added:    []string{},
+				Modified: []string{},
+				Deleted:  []string{},
+			},
+		},
+		{
+			name:        "container not found",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to get container changes: unexpected status 404: No such container: web"),
+			expectError: true,
+		},
+		{
+			name:        "missing containerId parameter",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetContainerChanges", 1, "web").Return(tt.mockChanges, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetContainerChanges()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var changes models.ContainerChanges
+				err = json.Unmarshal([]byte(textContent.Text), &changes)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, changes)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetContainerProcesses(t *testing.T) {
+	mockProcesses := models.ProcessList{
+		Titles: []string{"UID", "PID", "CMD"},
+		Processes: []map[string]string{
+			{"UID": "root", "PID": "1234", "CMD": "nginx: master process nginx -g daemon off;"},
+			{"UID": "101", "PID": "1290", "CMD": "nginx: worker process"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "running container",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+		},
+		{
+			name:        "stopped container",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("container web is not running, start it to list its processes"),
+			expectError: true,
+		},
+		{
+			name:        "missing containerId parameter",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetContainerProcesses", 1, "web").Return(mockProcesses, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetContainerProcesses()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var processes models.ProcessList
+				err = json.Unmarshal([]byte(textContent.Text), &processes)
+				assert.NoError(t, err)
+				assert.Equal(t, mockProcesses, processes)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetContainerHealth(t *testing.T) {
+	mockHealth := models.HealthStatus{
+		Status:        models.ContainerHealthUnhealthy,
+		FailingStreak: 3,
+		LastProbe: &models.HealthProbe{
+			Start:    "2024-05-01T10:00:30Z",
+			End:      "2024-05-01T10:00:35Z",
+			ExitCode: 1,
+			Output:   "curl: (7) Failed to connect to localhost port 80",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "unhealthy container",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+		},
+		{
+			name:        "container not found",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to inspect container: unexpected status 404"),
+			expectError: true,
+		},
+		{
+			name:        "missing containerId parameter",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetContainerHealth", 1, "web").Return(mockHealth, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetContainerHealth()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var health models.HealthStatus
+				err = json.Unmarshal([]byte(textContent.Text), &health)
+				assert.NoError(t, err)
+				assert.Equal(t, mockHealth, health)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCheckImageUpdates(t *testing.T) {
+	mockStatuses := []models.ImageUpdateStatus{
+		{Image: "myorg/api:1.2", ImageID: "sha256:a1", Containers: []string{"api"}, LocalDigest: "sha256:old", RemoteDigest: "sha256:new", Status: models.ImageUpdateAvailable},
+		{Image: "myapp:dev", ImageID: "sha256:l1", Containers: []string{"dev"}, Status: models.ImageUpdateUnknown, Reason: "the image has no digest from its registry, it was built or loaded locally"},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "images checked",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "kubernetes environment",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("environment 1 is a kubernetes-local environment, it has no Docker daemon"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CheckImageUpdates", 1).Return(mockStatuses, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCheckImageUpdates()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var statuses listResponse[models.ImageUpdateStatus]
+				err = json.Unmarshal([]byte(textContent.Text), &statuses)
+				assert.NoError(t, err)
+				assert.Equal(t, mockStatuses, statuses.Items)
+				assert.Equal(t, len(mockStatuses), statuses.TotalCount)
+				assert.False(t, statuses.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleFindOrphanedResources(t *testing.T) {
+	mockReport := models.OrphanReport{
+		EnvironmentID: 1,
+		Volumes:       []models.OrphanedVolume{{Name: "old-logs", Driver: "local"}},
+		Networks:      []models.OrphanedNetwork{{ID: "n2", Name: "frontend", Driver: "bridge", Scope: "local"}},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "orphaned resources found",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "kubernetes environment",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("environment 1 is a kubernetes-local environment, it has no Docker daemon"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("FindOrphanedResources", 1).Return(mockReport, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleFindOrphanedResources()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var report models.OrphanReport
+				err = json.Unmarshal([]byte(textContent.Text), &report)
+				assert.NoError(t, err)
+				assert.Equal(t, mockReport, report)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetContainerMounts(t *testing.T) {
+	mockMounts := []models.Mount{
+		{Type: models.MountTypeVolume, Source: "/var/lib/docker/volumes/web_data/_data", Destination: "/data", Name: "web_data", Driver: "local", ReadWrite: true, Persistent: true},
+		{Type: models.MountTypeTmpfs, Destination: "/run", ReadWrite: true, Options: "size=64m"},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "volume and tmpfs mounts",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+		},
+		{
+			name:        "container not found",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to inspect container: unexpected status 404"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{"containerId": "web"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				if tt.mockError != nil {
+					mockClient.On("GetContainerMounts", 1, "web").Return(nil, tt.mockError)
+				} else {
+					mockClient.On("GetContainerMounts", 1, "web").Return(mockMounts, nil)
+				}
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetContainerMounts()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var mounts listResponse[models.Mount]
+				err = json.Unmarshal([]byte(textContent.Text), &mounts)
+				assert.NoError(t, err)
+				assert.Equal(t, mockMounts, mounts.Items)
+				assert.Equal(t, len(mockMounts), mounts.TotalCount)
+				assert.False(t, mounts.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetContainerFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockContent []byte
+		mockError   error
+		expected    models.ContainerFile
+		expectError bool
+	}{
+		{
+			name:        "text file",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf"},
+			expectCall:  true,
+			mockContent: []byte("worker_processes auto;\n"),
+			expected: models.ContainerFile{
+				Path:     "/etc/nginx/nginx.conf",
+				Size:     23,
+				Encoding: FileEncodingText,
+				Content:  "worker_processes auto;\n",
+			},
+		},
+		{
+			name:        "binary file",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf"},
+			expectCall:  true,
+			mockContent: []byte{0x7f, 'E', 'L', 'F', 0x00},
+			expected: models.ContainerFile{
+				Path:     "/etc/nginx/nginx.conf",
+				Size:     5,
+				Encoding: FileEncodingBase64,
+				Content:  "f0VMRgA=",
+				Warning:  "the file has binary content, it is encoded in base64",
+			},
+		},
+		{
+			name:        "file too large",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("file /etc/nginx/nginx.conf is 2097152 bytes, larger than the limit of 1048576 bytes"),
+			expectError: true,
+		},
+		{
+			name:        "missing path parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetFileFromContainer", 1, "web", "/etc/nginx/nginx.conf").Return(tt.mockContent, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetContainerFile()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var file models.ContainerFile
+				err = json.Unmarshal([]byte(textContent.Text), &file)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, file)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandlePutContainerFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectContent []byte
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name:          "text content",
+			inputParams:   map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf", "content": "worker_processes 4;\n"},
+			expectCall:    true,
+			expectContent: []byte("worker_processes 4;\n"),
+		},
+		{
+			name:          "base64 content",
+			inputParams:   map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf", "content": "f0VMRgA=", "encoding": "base64"},
+			expectCall:    true,
+			expectContent: []byte{0x7f, 'E', 'L', 'F', 0x00},
+		},
+		{
+			name:          "api error",
+			inputParams:   map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf", "content": "worker_processes 4;\n"},
+			expectCall:    true,
+			expectContent: []byte("worker_processes 4;\n"),
+			mockError:     fmt.Errorf("failed to put file in container: unexpected status 404: Could not find the file /etc/nginx in container web"),
+			expectError:   true,
+		},
+		{
+			name:        "invalid base64 content",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf", "content": "not base64!", "encoding": "base64"},
+			expectError: true,
+		},
+		{
+			name:        "invalid encoding",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf", "content": "data", "encoding": "hex"},
+			expectError: true,
+		},
+		{
+			name:        "missing content parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "path": "/etc/nginx/nginx.conf"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("PutFileInContainer", 1, "web", "/etc/nginx/nginx.conf", tt.expectContent).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandlePutContainerFile()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "copied successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetDockerInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockInfo    models.DockerInfo
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockInfo: models.DockerInfo{
+				Name:             "node-1",
+				OperatingSystem:  "Ubuntu 24.04.1 LTS",
+				Architecture:     "x86_64",
+				StorageDriver:    "overlay2",
+				TotalMemoryBytes: 16777216000,
+			},
+		},
+		{
+			name:        "kubernetes environment",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("environment 1 is a kubernetes-agent environment, it has no Docker daemon"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetDockerInfo", 1).Return(tt.mockInfo, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetDockerInfo()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var info models.DockerInfo
+				err = json.Unmarshal([]byte(textContent.Text), &info)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockInfo, info)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleListContainers(t *testing.T) {
+	containers := make([]models.Container, 5)
+	for i := range containers {
+		containers[i] = models.Container{ID: fmt.Sprintf("c%d", i), Name: fmt.Sprintf("web-%d", i), State: "running"}
+	}
+
+	tests := []struct {
+		name           string
+		inputParams    map[string]any
+		expectCall     bool
+		expectedFilter models.ContainerFilter
+		mockError      error
+		expectedIDs    []string
+		expectCursor   bool
+		expectError    bool
+	}{
+		{
+			name:           "first page",
+			inputParams:    map[string]any{"environmentId": float64(1), "all": true, "name": "web", "limit": float64(2)},
+			expectCall:     true,
+			expectedFilter: models.ContainerFilter{All: true, Name: "web"},
+			expectedIDs:    []string{"c0", "c1"},
+			expectCursor:   true,
+		},
+		{
+			name: "filtered by labels and status",
+			inputParams: map[string]any{"environmentId": float64(1), "filters": []any{
+				map[string]any{"key": "label", "value": "com.docker.compose.project=web"},
+				map[string]any{"key": "status", "value": "exited"},
+				map[string]any{"key": "label", "value": "tier=front"},
+			}},
+			expectCall: true,
+			expectedFilter: models.ContainerFilter{Filters: map[string][]string{
+				"label":  {"com.docker.compose.project=web", "tier=front"},
+				"status": {"exited"},
+			}},
+			expectedIDs: []string{"c0", "c1", "c2", "c3", "c4"},
+		},
+		{
+			name:        "invalid filters parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "filters": []any{map[string]any{"key": "label"}}},
+			expectError: true,
+		},
+		{
+			name:        "single page with default limit",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			expectedIDs: []string{"c0", "c1", "c2", "c3", "c4"},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("environment 1 is a kubernetes-local environment, it has no Docker daemon"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+		{
+			name:        "limit too large",
+			inputParams: map[string]any{"environmentId": float64(1), "limit": float64(1000)},
+			expectError: true,
+		},
+		{
+			name:        "unknown cursor",
+			inputParams: map[string]any{"environmentId": float64(1), "cursor": "unknown"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ListContainers", 1, tt.expectedFilter).Return(containers, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli:              mockClient,
+				containerCursors: newContainerCursorStore(containerCursorTTL),
+			}
+
+			handler := server.HandleListContainers()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var page models.ContainerPage
+				err = json.Unmarshal([]byte(textContent.Text), &page)
+				assert.NoError(t, err)
+				assert.Equal(t, 5, page.TotalCount)
+				assert.Equal(t, tt.expectCursor, page.HasMore)
+
+				ids := make([]string, len(page.Items))
+				for i, container := range page.Items {
+					ids[i] = container.ID
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+				assert.Equal(t, tt.expectCursor, page.NextCursor != "")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleListContainersPaging(t *testing.T) {
+	containers := make([]models.Container, 5)
+	for i := range containers {
+		containers[i] = models.Container{ID: fmt.Sprintf("c%d", i), Name: fmt.Sprintf("web-%d", i)}
+	}
+	filter := models.ContainerFilter{All: true}
+
+	mockClient := &MockPortainerClient{}
+	mockClient.On("ListContainers", 1, filter).Return(containers, nil)
+
+	server := &PortainerMCPServer{
+		cli:              mockClient,
+		containerCursors: newContainerCursorStore(containerCursorTTL),
+	}
+	handler := server.HandleListContainers()
+
+	params := map[string]any{"environmentId": float64(1), "all": true, "limit": float64(2)}
+	var ids []string
+	for pages := 0; ; pages++ {
+		if !assert.Less(t, pages, 3, "listing should end after 3 pages") {
+			return
+		}
+
+		result, err := handler(context.Background(), CreateMCPRequest(params))
+		assert.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		var page models.ContainerPage
+		err = json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &page)
+		assert.NoError(t, err)
+
+		for _, container := range page.Items {
+			ids = append(ids, container.ID)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		// The filter is taken from the cursor, the all parameter is not repeated
+		params = map[string]any{"environmentId": float64(1), "limit": float64(2), "cursor": page.NextCursor}
+	}
+
+	assert.Equal(t, []string{"c0", "c1", "c2", "c3", "c4"}, ids)
+
+	// A cursor cannot be used to page the containers of another environment
+	token, err := server.containerCursors.save(containerCursor{environmentID: 1, filter: filter, offset: 2})
+	assert.NoError(t, err)
+	result, err := handler(context.Background(), CreateMCPRequest(map[string]any{"environmentId": float64(2), "cursor": token}))
+	assert.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestHandlePruneDockerResources(t *testing.T) {
+	containersReport := models.PruneReport{Resource: models.PruneResourceContainers, Deleted: []string{"4f2a", "9c1e"}, SpaceReclaimed: 2048}
+	networksReport := models.PruneReport{Resource: models.PruneResourceNetworks, Deleted: []string{"frontend"}}
+	buildCacheReport := models.PruneReport{Resource: models.PruneResourceBuildCache, Deleted: []string{"u3wl8b5w0x7d"}, SpaceReclaimed: 1048576}
+
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		setupMock     func(*MockPortainerClient)
+		expected      models.PruneSummary
+		errorContains string
+	}{
+		{
+			name: "all resources",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resources":     []any{"build_cache", "networks", "containers"},
+			},
+			setupMock: func(m *MockPortainerClient) {
+				m.On("PruneContainers", 1, []string{}).Return(containersReport, nil)
+				m.On("PruneNetworks", 1, []string{}).Return(networksReport, nil)
+				m.On("PruneBuildCache", 1, []string{}).Return(buildCacheReport, nil)
+			},
+			expected: models.PruneSummary{
+				Reports:             []models.PruneReport{containersReport, networksReport, buildCacheReport},
+				TotalDeleted:        4,
+				TotalSpaceReclaimed: 1050624,
+			},
+		},
+		{
+			name: "containers by label",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resources":     []any{"containers"},
+				"labels":        []any{"env=dev"},
+			},
+			setupMock: func(m *MockPortainerClient) {
+				m.On("PruneContainers", 1, []string{"env=dev"}).Return(containersReport, nil)
+			},
+			expected: models.PruneSummary{
+				Reports:             []models.PruneReport{containersReport},
+				TotalDeleted:        2,
+				TotalSpaceReclaimed: 2048,
+			},
+		},
+		{
+			name: "partial failure",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resources":     []any{"containers", "networks"},
+			},
+			setupMock: func(m *MockPortainerClient) {
+				m.On("PruneContainers", 1, []string{}).Return(containersReport, nil)
+				m.On("PruneNetworks", 1, []string{}).Return(models.PruneReport{}, fmt.Errorf("failed to prune networks: unexpected status 409"))
+			},
+			errorContains: `already pruned: {"reports":[{"resource":"containers"`,
+		},
+		{
+			name: "build cache by label",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resources":     []any{"containers", "build_cache"},
+				"labels":        []any{"env=dev"},
+			},
+			errorContains: "the build cache cannot be pruned by label",
+		},
+		{
+			name: "unknown resource",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resources":     []any{"images"},
+			},
+			errorContains: "unknown resource images",
+		},
+		{
+			name: "no resources",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resources":     []any{},
+			},
+			errorContains: "at least one resource must be provided",
+		},
+		{
+			name:          "missing resources parameter",
+			inputParams:   map[string]any{"environmentId": float64(1)},
+			errorContains: "resources is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandlePruneDockerResources()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.errorContains != "" {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.errorContains)
+			} else {
+				assert.False(t, result.IsError)
+				var summary models.PruneSummary
+				err = json.Unmarshal([]byte(textContent.Text), &summary)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, summary)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+// testStreamingSession is a client session of the HTTP transport, able to receive notifications during a tool call
+type testStreamingSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testStreamingSession) SessionID() string { return "test-session" }
+
+func (s *testStreamingSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *testStreamingSession) Initialize() {}
+
+func (s *testStreamingSession) Initialized() bool { return true }
+
+func (s *testStreamingSession) UpgradeToSSEWhenReceiveNotification() {}
+
+func TestHandleFollowContainerLogs(t *testing.T) {
+	mockLines := []models.ContainerLogLine{
+		{Stream: "stdout", Timestamp: "2024-05-01T10:00:00Z", Text: "starting nginx"},
+		{Stream: "stderr", Timestamp: "2024-05-01T10:00:01Z", Text: "warn: no upstream configured"},
+	}
+
+	tests := []struct {
+		name             string
+		inputParams      map[string]any
+		streaming        bool
+		channelSize      int
+		expectedTail     int
+		expectedDuration time.Duration
+		mockError        error
+		expectError      bool
+		expected         containerLogsResult
+	}{
+		{
+			name:         "stdio transport",
+			inputParams:  map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectedTail: 100,
+			expected: containerLogsResult{
+				LinesSent: 2,
+				Lines:     mockLines,
+				Note:      "logs can only be followed over the HTTP transport, the latest lines were returned instead",
+			},
+		},
+		{
+			name:         "stdio transport error",
+			inputParams:  map[string]any{"environmentId": float64(1), "containerId": "web", "tail": float64(0)},
+			expectedTail: 0,
+			mockError:    errors.New("environment 1 is a kubernetes-local environment, it has no Docker daemon"),
+			expectError:  true,
+		},
+		{
+			name:             "container stops",
+			inputParams:      map[string]any{"environmentId": float64(1), "containerId": "web", "tail": float64(10), "duration": float64(30)},
+			streaming:        true,
+			channelSize:      10,
+			expectedTail:     10,
+			expectedDuration: 30 * time.Second,
+			expected:         containerLogsResult{Streamed: true, LinesSent: 2, StoppedBy: "container_stopped"},
+		},
+		{
+			name:             "deadline reached",
+			inputParams:      map[string]any{"environmentId": float64(1), "containerId": "web"},
+			streaming:        true,
+			channelSize:      10,
+			expectedTail:     100,
+			expectedDuration: 60 * time.Second,
+			mockError:        fmt.Errorf("stopped following the logs of container web: %w", context.DeadlineExceeded),
+			expected:         containerLogsResult{Streamed: true, LinesSent: 2, StoppedBy: "deadline"},
+		},
+		{
+			name:             "client not reading",
+			inputParams:      map[string]any{"environmentId": float64(1), "containerId": "web"},
+			streaming:        true,
+			expectedTail:     100,
+			expectedDuration: 60 * time.Second,
+			expected:         containerLogsResult{Streamed: true, DroppedLines: 2, StoppedBy: "container_stopped"},
+		},
+		{
+			name:             "stream error",
+			inputParams:      map[string]any{"environmentId": float64(1), "containerId": "web"},
+			streaming:        true,
+			channelSize:      10,
+			expectedTail:     100,
+			expectedDuration: 60 * time.Second,
+			mockError:        errors.New("failed to read container logs: unexpected EOF"),
+			expectError:      true,
+		},
+		{
+			name:        "tail too large",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "tail": float64(5000)},
+			expectError: true,
+		},
+		{
+			name:        "duration too long",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "duration": float64(3600)},
+			expectError: true,
+		},
+		{
+			name:        "missing containerId parameter",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcpServer := server.NewMCPServer("test", "0.0.0")
+			server := &PortainerMCPServer{
+				srv:        mcpServer,
+				operations: newOperationRegistry(),
+			}
+
+			ctx := context.Background()
+			session := &testStreamingSession{notifications: make(chan mcp.JSONRPCNotification, tt.channelSize)}
+			if tt.streaming {
+				ctx = mcpServer.WithContext(ctx, session)
+			}
+
+			mockClient := &MockPortainerClient{}
+			if tt.expectedTail != 0 || tt.mockError != nil {
+				if tt.streaming {
+					mockClient.On("FollowContainerLogs", mock.Anything, 1, "web", tt.expectedTail, mock.Anything).Run(func(args mock.Arguments) {
+						streamCtx := args.Get(0).(context.Context)
+						deadline, ok := streamCtx.Deadline()
+						assert.True(t, ok, "the stream should have a deadline")
+						assert.WithinDuration(t, time.Now().Add(tt.expectedDuration), deadline, 5*time.Second)
+						assert.Len(t, server.operations.list(), 1, "the stream should be registered as an operation")
+
+						handle := args.Get(4).(func(models.ContainerLogLine) error)
+						for _, line := range mockLines {
+							if tt.mockError == nil || errors.Is(tt.mockError, context.DeadlineExceeded) {
+								assert.NoError(t, handle(line))
+							}
+						}
+					}).Return(tt.mockError)
+				} else {
+					mockClient.On("GetContainerLogs", 1, "web", tt.expectedTail).Return(mockLines, tt.mockError)
+				}
+			}
+			server.cli = mockClient
+
+			handler := server.HandleFollowContainerLogs()
+			result, err := handler(ctx, CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var logs containerLogsResult
+				err = json.Unmarshal([]byte(textContent.Text), &logs)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, logs)
+			}
+
+			if tt.streaming && tt.channelSize > 0 && !tt.expectError {
+				require.Len(t, session.notifications, len(mockLines))
+				for _, line := range mockLines {
+					notification := <-session.notifications
+					assert.Equal(t, "notifications/message", notification.Method)
+					assert.Equal(t, ToolFollowContainerLogs, notification.Params.AdditionalFields["logger"])
+					assert.Equal(t, line, notification.Params.AdditionalFields["data"])
+				}
+			}
+			assert.Empty(t, server.operations.list(), "the stream should be unregistered once finished")
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateContainerResources(t *testing.T) {
+	cpus := 1.5
+	memory := int64(536870912)
+	swap := int64(-1)
+
+	tests := []struct {
+		name           string
+		inputParams    map[string]any
+		expectedLimits models.ResourceLimits
+		expectCall     bool
+		mockError      error
+		expectError    bool
+	}{
+		{
+			name: "successful update",
+			inputParams: map[string]any{
+				"environmentId":   float64(1),
+				"containerId":     "web",
+				"cpus":            1.5,
+				"memoryBytes":     float64(536870912),
+				"memorySwapBytes": float64(-1),
+			},
+			expectedLimits: models.ResourceLimits{CPUs: &cpus, MemoryBytes: &memory, MemorySwapBytes: &swap},
+			expectCall:     true,
+		},
+		{
+			name: "limit not supported by the host",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"containerId":   "web",
+				"memoryBytes":   float64(536870912),
+			},
+			expectedLimits: models.ResourceLimits{MemoryBytes: &memory},
+			expectCall:     true,
+			mockError:      errors.New("container web was updated but some limits were not applied: Memory limited without swap"),
+			expectError:    true,
+		},
+		{
+			name: "invalid memoryBytes parameter",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"containerId":   "web",
+				"memoryBytes":   "512m",
+			},
+			expectError: true,
+		},
+		{
+			name:        "missing containerId parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "cpus": 1.5},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateContainerResources", 1, "web", tt.expectedLimits).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateContainerResources()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Container resources updated successfully", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateContainerRestartPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputParams    map[string]any
+		expectCall     bool
+		expectedPolicy models.RestartPolicy
+		mockError      error
+		expectError    bool
+	}{
+		{
+			name:           "unless-stopped policy",
+			inputParams:    map[string]any{"environmentId": float64(1), "containerId": "web", "policy": "unless-stopped"},
+			expectCall:     true,
+			expectedPolicy: models.RestartPolicy{Name: models.RestartPolicyUnlessStopped},
+		},
+		{
+			name:           "on-failure policy with max retries",
+			inputParams:    map[string]any{"environmentId": float64(1), "containerId": "web", "policy": "on-failure", "maximumRetryCount": float64(3)},
+			expectCall:     true,
+			expectedPolicy: models.RestartPolicy{Name: models.RestartPolicyOnFailure, MaximumRetryCount: 3},
+		},
+		{
+			name:           "invalid policy",
+			inputParams:    map[string]any{"environmentId": float64(1), "containerId": "web", "policy": "always", "maximumRetryCount": float64(3)},
+			expectCall:     true,
+			expectedPolicy: models.RestartPolicy{Name: models.RestartPolicyAlways, MaximumRetryCount: 3},
+			mockError:      fmt.Errorf("invalid restart policy: maximum retry count is only allowed with the on-failure policy"),
+			expectError:    true,
+		},
+		{
+			name:        "missing policy parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectError: true,
+		},
+		{
+			name:        "invalid maximumRetryCount parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "policy": "on-failure", "maximumRetryCount": "3"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateContainerRestartPolicy", 1, "web", tt.expectedPolicy).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateContainerRestartPolicy()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "updated successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleRecreateContainer(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputParams  map[string]any
+		expectCall   bool
+		expectedPull bool
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name:         "recreate with the latest image",
+			inputParams:  map[string]any{"environmentId": float64(1), "containerId": "web", "pullLatest": true},
+			expectCall:   true,
+			expectedPull: true,
+		},
+		{
+			name:        "recreate with the local image",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+		},
+		{
+			name:        "recreation rolled back",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to start container: unexpected status 500, the old container was restored"),
+			expectError: true,
+		},
+		{
+			name:        "missing containerId parameter",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectError: true,
+		},
+		{
+			name:        "invalid pullLatest parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web", "pullLatest": "yes"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("RecreateContainer", 1, "web", tt.expectedPull).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleRecreateContainer()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "recreated successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleConnectContainerToNetwork(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful connection",
+			inputParams: map[string]any{"environmentId": float64(1), "networkId": "backend", "containerId": "web"},
+			expectCall:  true,
+		},
+		{
+			name:        "already attached",
+			inputParams: map[string]any{"environmentId": float64(1), "networkId": "backend", "containerId": "web"},
+			expectCall:  true,
+			mockError:   errors.New(`unexpected status 403: {"message":"endpoint with name web already exists in network backend"}`),
+			expectError: true,
+		},
+		{
+			name:        "missing networkId parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "containerId": "web"},
+			expectError: true,
+		},
+		{
+			name:        "missing containerId parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "networkId": "backend"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ConnectContainerToNetwork", 1, "backend", "web").Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleConnectContainerToNetwork()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Container connected to network successfully", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDisconnectContainerFromNetwork(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectedForce bool
+		expectCall    bool
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name:        "successful disconnection",
+			inputParams: map[string]any{"environmentId": float64(1), "networkId": "backend", "containerId": "web"},
+			expectCall:  true,
+		},
+		{
+			name:          "forced disconnection",
+			inputParams:   map[string]any{"environmentId": float64(1), "networkId": "backend", "containerId": "web", "force": true},
+			expectedForce: true,
+			expectCall:    true,
+		},
+		{
+			name:        "container not attached",
+			inputParams: map[string]any{"environmentId": float64(1), "networkId": "backend", "containerId": "web"},
+			expectCall:  true,
+			mockError:   errors.New(`unexpected status 403: {"message":"container 3f4e9a is not connected to network backend"}`),
+			expectError: true,
+		},
+		{
+			name:        "invalid force parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "networkId": "backend", "containerId": "web", "force": "yes"},
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{"networkId": "backend", "containerId": "web"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("DisconnectContainerFromNetwork", 1, "backend", "web", tt.expectedForce).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDisconnectContainerFromNetwork()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Container disconnected from network successfully", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+func (s *PortainerMCPServer) AddEdgeConfigFeatures() {
+	s.addToolIfExists(ToolListEdgeConfigurations, s.HandleGetEdgeConfigurations())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolCreateEdgeConfiguration, s.HandleCreateEdgeConfiguration())
+		s.addToolIfExists(ToolDeleteEdgeConfiguration, s.HandleDeleteEdgeConfiguration())
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetEdgeConfigurations() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		configs, err := s.client(ctx).GetEdgeConfigurations()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get edge configurations", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(configs))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal edge configurations", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCreateEdgeConfiguration() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		name, err := parser.GetString("name", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
+		}
+
+		configType, err := parser.GetString("type", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid type parameter", err), nil
+		}
+		if configType == "" {
+			configType = models.EdgeConfigTypeGeneral
+		}
+
+		category, err := parser.GetString("category", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid category parameter", err), nil
+		}
+		if category == "" {
+			category = models.EdgeConfigCategoryConfiguration
+		}
+
+		baseDir, err := parser.GetString("baseDir", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid baseDir parameter", err), nil
+		}
+
+		environmentGroupIds, err := parser.GetArrayOfIntegers("environmentGroupIds", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentGroupIds parameter", err), nil
+		}
+
+		fileEntries, err := parser.GetArrayOfObjects("files", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid files parameter", err), nil
+		}
+
+		files, err := parseEdgeConfigFiles(fileEntries)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid files", err), nil
+		}
+
+		config := models.EdgeConfig{
+			Name:                name,
+			Type:                configType,
+			Category:            category,
+			BaseDir:             baseDir,
+			EnvironmentGroupIds: environmentGroupIds,
+		}
+
+		err = s.client(ctx).CreateEdgeConfiguration(config, files)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to create edge configuration", err), nil
+		}
+
+		return mcp.NewToolResultText("Edge configuration created successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleDeleteEdgeConfiguration() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		err = s.client(ctx).DeleteEdgeConfiguration(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to delete edge configuration", err), nil
+		}
+
+		return mcp.NewToolResultText("Edge configuration deleted successfully"), nil
+	}
+}
+
+// parseEdgeConfigFiles parses file entries from an array of objects with a path and a content.
+// Error messages only reference the path of an entry so that file contents never end up in tool results or logs.
+func parseEdgeConfigFiles(entries []any) (map[string]string, error) {
+	files := make(map[string]string, len(entries))
+
+	for i, entry := range entries {
+		entryMap, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid file entry at index %d", i)
+		}
+
+		path, ok := entryMap["path"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid path for file entry at index %d", i)
+		}
+
+		content, ok := entryMap["content"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid content for file %q", path)
+		}
+
+		if _, exists := files[path]; exists {
+			return nil, fmt.Errorf("duplicate file path %q", path)
+		}
+
+		files[path] = content
+	}
+
+	return files, nil
+}
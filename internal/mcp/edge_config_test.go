@@ -0,0 +1,298 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetEdgeConfigurations(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockConfigs []models.EdgeConfig
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful retrieval",
+			mockConfigs: []models.EdgeConfig{
+				{ID: 1, Name: "nginx-conf", Type: models.EdgeConfigTypeGeneral, Category: models.EdgeConfigCategoryConfiguration, BaseDir: "/etc/nginx", EnvironmentGroupIds: []int{1}},
+				{ID: 2, Name: "certs", Type: models.EdgeConfigTypeFilename, Category: models.EdgeConfigCategorySecret, BaseDir: "/certs", EnvironmentGroupIds: []int{2, 3}},
+			},
+		},
+		{
+			name:        "empty configurations",
+			mockConfigs: []models.EdgeConfig{},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetEdgeConfigurations").Return(tt.mockConfigs, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetEdgeConfigurations()
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var configs listResponse[models.EdgeConfig]
+				err = json.Unmarshal([]byte(textContent.Text), &configs)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockConfigs, configs.Items)
+				assert.Equal(t, len(tt.mockConfigs), configs.TotalCount)
+				assert.False(t, configs.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCreateEdgeConfiguration(t *testing.T) {
+	validParams := func() map[string]any {
+		return map[string]any{
+			"name":                "nginx-conf",
+			"type":                "filename",
+			"category":            "secret",
+			"baseDir":             "/etc/nginx",
+			"environmentGroupIds": []any{float64(1), float64(2)},
+			"files": []any{
+				map[string]any{"path": "nginx.conf", "content": "worker_processes 1;"},
+				map[string]any{"path": "conf.d/site.conf", "content": "server {}"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedInput models.EdgeConfig
+		expectedFiles map[string]string
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name:        "successful creation",
+			inputParams: validParams(),
+			expectCall:  true,
+			expectedInput: models.EdgeConfig{
+				Name:                "nginx-conf",
+				Type:                models.EdgeConfigTypeFilename,
+				Category:            models.EdgeConfigCategorySecret,
+				BaseDir:             "/etc/nginx",
+				EnvironmentGroupIds: []int{1, 2},
+			},
+			expectedFiles: map[string]string{
+				"nginx.conf":       "worker_processes 1;",
+				"conf.d/site.conf": "server {}",
+			},
+		},
+		{
+			name: "default type and category",
+			inputParams: func() map[string]any {
+				params := validParams()
+				delete(params, "type")
+				delete(params, "category")
+				return params
+			}(),
+			expectCall: true,
+			expectedInput: models.EdgeConfig{
+				Name:                "nginx-conf",
+				Type:                models.EdgeConfigTypeGeneral,
+				Category:            models.EdgeConfigCategoryConfiguration,
+				BaseDir:             "/etc/nginx",
+				EnvironmentGroupIds: []int{1, 2},
+			},
+			expectedFiles: map[string]string{
+				"nginx.conf":       "worker_processes 1;",
+				"conf.d/site.conf": "server {}",
+			},
+		},
+		{
+			name:        "api error",
+			inputParams: validParams(),
+			expectCall:  true,
+			expectedInput: models.EdgeConfig{
+				Name:                "nginx-conf",
+				Type:                models.EdgeConfigTypeFilename,
+				Category:            models.EdgeConfigCategorySecret,
+				BaseDir:             "/etc/nginx",
+				EnvironmentGroupIds: []int{1, 2},
+			},
+			expectedFiles: map[string]string{
+				"nginx.conf":       "worker_processes 1;",
+				"conf.d/site.conf": "server {}",
+			},
+			mockError:   fmt.Errorf("failed to create edge configuration"),
+			expectError: true,
+		},
+		{
+			name: "missing name parameter",
+			inputParams: func() map[string]any {
+				params := validParams()
+				delete(params, "name")
+				return params
+			}(),
+			expectError: true,
+		},
+		{
+			name: "missing environmentGroupIds parameter",
+			inputParams: func() map[string]any {
+				params := validParams()
+				delete(params, "environmentGroupIds")
+				return params
+			}(),
+			expectError: true,
+		},
+		{
+			name: "missing files parameter",
+			inputParams: func() map[string]any {
+				params := validParams()
+				delete(params, "files")
+				return params
+			}(),
+			expectError: true,
+		},
+		{
+			name: "invalid file entry",
+			inputParams: func() map[string]any {
+				params := validParams()
+				params["files"] = []any{map[string]any{"path": "nginx.conf"}}
+				return params
+			}(),
+			expectError: true,
+		},
+		{
+			name: "duplicate file path",
+			inputParams: func() map[string]any {
+				params := validParams()
+				params["files"] = []any{
+					map[string]any{"path": "nginx.conf", "content": "worker_processes 1;"},
+					map[string]any{"path": "nginx.conf", "content": "worker_processes 2;"},
+				}
+				return params
+			}(),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CreateEdgeConfiguration", tt.expectedInput, tt.expectedFiles).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCreateEdgeConfiguration()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+				assert.NotContains(t, textContent.Text, "worker_processes", "file content should not leak in errors")
+			} else {
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteEdgeConfiguration(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful deletion",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to delete edge configuration"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("DeleteEdgeConfiguration", 1).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDeleteEdgeConfiguration()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -3,30 +3,57 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/client"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 func (s *PortainerMCPServer) AddEnvironmentFeatures() {
 	s.addToolIfExists(ToolListEnvironments, s.HandleGetEnvironments())
+	s.addToolIfExists(ToolGetEnvironmentGPUs, s.HandleGetEnvironmentGPUs())
+	s.addToolIfExists(ToolPlanEnvironmentConfig, s.HandlePlanEnvironmentConfig())
+	s.addToolIfExists(ToolCompareEnvironments, s.HandleCompareEnvironments())
+	s.addToolIfExists(ToolListEnvironmentRegistries, s.HandleGetEnvironmentRegistries())
+	s.addToolIfExists(ToolGetEdgeAgentStatus, s.HandleGetEdgeAgentStatus())
+	s.addToolIfExists(ToolTestEnvironmentConnectivity, s.HandleTestEnvironmentConnectivity())
+	s.addToolIfExists(ToolCheckFleetConnectivity, s.HandleCheckFleetConnectivity())
+	s.addToolIfExists(ToolGetEnvironmentMetadata, s.HandleGetEnvironmentMetadata())
+	s.addToolIfExists(ToolGetFleetStats, s.HandleGetFleetStats())
+	s.addToolIfExists(ToolFindEnvironmentsByImage, s.HandleFindEnvironmentsByImage())
+	s.addToolIfExists(ToolExportEnvironmentAccess, s.HandleExportEnvironmentAccess())
+	s.addToolIfExists(ToolGetEnvironmentPublicURL, s.HandleGetEnvironmentPublicURL())
 
 	if !s.readOnly {
 		s.addToolIfExists(ToolUpdateEnvironmentTags, s.HandleUpdateEnvironmentTags())
 		s.addToolIfExists(ToolUpdateEnvironmentUserAccesses, s.HandleUpdateEnvironmentUserAccesses())
 		s.addToolIfExists(ToolUpdateEnvironmentTeamAccesses, s.HandleUpdateEnvironmentTeamAccesses())
+		s.addToolIfExists(ToolUpdateEnvironmentsUserAccessesBulk, s.HandleUpdateEnvironmentsUserAccessesBulk())
+		s.addToolIfExists(ToolUpdateEnvironmentsTeamAccessesBulk, s.HandleUpdateEnvironmentsTeamAccessesBulk())
+		s.addToolIfExists(ToolUpdateEnvironmentGPUs, s.HandleUpdateEnvironmentGPUs())
+		s.addToolIfExists(ToolApplyEnvironmentConfig, s.HandleApplyEnvironmentConfig())
+		s.addToolIfExists(ToolTriggerEnvironmentSnapshot, s.HandleTriggerEnvironmentSnapshot())
+		s.addToolIfExists(ToolUpdateEnvironmentRegistries, s.HandleUpdateEnvironmentRegistries())
+		s.addToolIfExists(ToolUpdateEnvironmentMetadata, s.HandleUpdateEnvironmentMetadata())
+		s.addToolIfExists(ToolDetachEnvironment, s.HandleDetachEnvironment())
+		s.addToolIfExists(ToolRestoreEnvironmentAccess, s.HandleRestoreEnvironmentAccess())
+		s.addToolIfExists(ToolUpdateEnvironmentPublicURL, s.HandleUpdateEnvironmentPublicURL())
 	}
 }
 
 func (s *PortainerMCPServer) HandleGetEnvironments() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		environments, err := s.cli.GetEnvironments()
+		environments, err := s.client(ctx).GetEnvironments()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get environments", err), nil
 		}
 
-		data, err := json.Marshal(environments)
+		data, err := json.Marshal(newListResponse(environments))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to marshal environments", err), nil
 		}
@@ -49,7 +76,7 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentTags() server.ToolHandlerFun
 			return mcp.NewToolResultErrorFromErr("invalid tagIds parameter", err), nil
 		}
 
-		err = s.cli.UpdateEnvironmentTags(id, tagIds)
+		err = s.client(ctx).UpdateEnvironmentTags(id, tagIds)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update environment tags", err), nil
 		}
@@ -77,7 +104,7 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentUserAccesses() server.ToolHa
 			return mcp.NewToolResultErrorFromErr("invalid user accesses", err), nil
 		}
 
-		err = s.cli.UpdateEnvironmentUserAccesses(id, userAccessesMap)
+		err = s.client(ctx).UpdateEnvironmentUserAccesses(id, userAccessesMap)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update environment user accesses", err), nil
 		}
@@ -105,7 +132,7 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentTeamAccesses() server.ToolHa
 			return mcp.NewToolResultErrorFromErr("invalid team accesses", err), nil
 		}
 
-		err = s.cli.UpdateEnvironmentTeamAccesses(id, teamAccessesMap)
+		err = s.client(ctx).UpdateEnvironmentTeamAccesses(id, teamAccessesMap)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update environment team accesses", err), nil
 		}
@@ -113,3 +140,684 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentTeamAccesses() server.ToolHa
 		return mcp.NewToolResultText("Environment team accesses updated successfully"), nil
 	}
 }
+
+func (s *PortainerMCPServer) HandleUpdateEnvironmentsUserAccessesBulk() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentIds, err := parser.GetArrayOfIntegers("environmentIds", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentIds parameter", err), nil
+		}
+
+		userAccesses, err := parser.GetArrayOfObjects("userAccesses", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid userAccesses parameter", err), nil
+		}
+
+		userAccessesMap, err := parseAccessMap(userAccesses)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid user accesses", err), nil
+		}
+
+		err = s.client(ctx).UpdateEnvironmentsUserAccessesBulk(environmentIds, userAccessesMap)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update environments user accesses", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("User accesses granted successfully on %d environments", len(environmentIds))), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateEnvironmentsTeamAccessesBulk() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentIds, err := parser.GetArrayOfIntegers("environmentIds", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentIds parameter", err), nil
+		}
+
+		teamAccesses, err := parser.GetArrayOfObjects("teamAccesses", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid teamAccesses parameter", err), nil
+		}
+
+		teamAccessesMap, err := parseAccessMap(teamAccesses)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid team accesses", err), nil
+		}
+
+		err = s.client(ctx).UpdateEnvironmentsTeamAccessesBulk(environmentIds, teamAccessesMap)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update environments team accesses", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Team accesses granted successfully on %d environments", len(environmentIds))), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetEnvironmentGPUs() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		settings, err := s.client(ctx).GetEnvironmentGPUs(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get environment gpus", err), nil
+		}
+
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal environment gpus", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateEnvironmentGPUs() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		gpuEntries, err := parser.GetArrayOfObjects("gpus", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid gpus parameter", err), nil
+		}
+
+		gpus, err := parseGPUConfigs(gpuEntries)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid gpus", err), nil
+		}
+
+		err = s.client(ctx).UpdateEnvironmentGPUs(id, gpus)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update environment gpus", err), nil
+		}
+
+		return mcp.NewToolResultText("Environment GPUs updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandlePlanEnvironmentConfig() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		desired, err := parseEnvironmentDesiredState(parser)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid desired state", err), nil
+		}
+
+		diff, err := s.client(ctx).PlanEnvironmentConfig(id, desired)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to plan environment config", err), nil
+		}
+
+		data, err := json.Marshal(diff)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal environment config diff", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleApplyEnvironmentConfig() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		desired, err := parseEnvironmentDesiredState(parser)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid desired state", err), nil
+		}
+
+		diff, err := s.client(ctx).ApplyEnvironmentConfig(id, desired)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to apply environment config", err), nil
+		}
+
+		data, err := json.Marshal(diff)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal environment config diff", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+const (
+	// defaultSnapshotWaitTimeout is how long to wait for a new snapshot when no timeout is provided
+	defaultSnapshotWaitTimeout = 30 * time.Second
+	// maxSnapshotWaitTimeout is the maximum time to wait for a new snapshot
+	maxSnapshotWaitTimeout = 120 * time.Second
+)
+
+func (s *PortainerMCPServer) HandleTriggerEnvironmentSnapshot() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		wait, err := parser.GetBoolean("wait", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid wait parameter", err), nil
+		}
+
+		timeoutSeconds, err := parser.GetInt("timeout", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid timeout parameter", err), nil
+		}
+		timeout := time.Duration(timeoutSeconds) * time.Second
+		if timeout == 0 {
+			timeout = defaultSnapshotWaitTimeout
+		}
+		if timeout < 0 || timeout > maxSnapshotWaitTimeout {
+			return mcp.NewToolResultError(fmt.Sprintf("timeout must be between 1 and %d seconds", int(maxSnapshotWaitTimeout.Seconds()))), nil
+		}
+
+		var previous time.Time
+		if wait {
+			previous, err = s.client(ctx).GetEnvironmentSnapshotTime(id)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to get environment snapshot time", err), nil
+			}
+		}
+
+		err = s.client(ctx).TriggerEnvironmentSnapshot(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to trigger environment snapshot", err), nil
+		}
+
+		if !wait {
+			return mcp.NewToolResultText("Environment snapshot triggered successfully"), nil
+		}
+
+		// The wait is registered so that it can be listed and cancelled while it is in progress
+		waitCtx, done, err := s.operations.start(ctx, ToolTriggerEnvironmentSnapshot, fmt.Sprintf("Waiting for a new snapshot of environment %d", id))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to register snapshot wait", err), nil
+		}
+		defer done()
+
+		snapshotTime, err := s.client(ctx).WaitForEnvironmentSnapshot(waitCtx, id, previous, timeout)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("environment snapshot triggered but not yet available", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Environment snapshot taken successfully at %s", snapshotTime.Format(time.RFC3339))), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCompareEnvironments() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		firstId, err := parser.GetInt("firstId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid firstId parameter", err), nil
+		}
+
+		secondId, err := parser.GetInt("secondId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid secondId parameter", err), nil
+		}
+
+		comparison, err := s.client(ctx).CompareEnvironments(firstId, secondId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to compare environments", err), nil
+		}
+
+		data, err := json.Marshal(comparison)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal environment comparison", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetEnvironmentRegistries() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		registries, err := s.client(ctx).GetEnvironmentRegistries(environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get environment registries", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(registries))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal environment registries", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateEnvironmentRegistries() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		registryIds, err := parser.GetArrayOfIntegers("registryIds", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid registryIds parameter", err), nil
+		}
+
+		err = s.client(ctx).UpdateEnvironmentRegistries(environmentId, registryIds)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update environment registries", err), nil
+		}
+
+		return mcp.NewToolResultText("Environment registries updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetEdgeAgentStatus() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		statuses, err := s.client(ctx).GetEdgeAgentStatus()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get edge agent status", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(statuses))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal edge agent status", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// parseEnvironmentDesiredState parses the desired state of an environment.
+// Parameters that are not provided are left nil so that they are not managed.
+func parseEnvironmentDesiredState(parser *toolgen.ParameterParser) (models.EnvironmentDesiredState, error) {
+	var desired models.EnvironmentDesiredState
+
+	if parser.Has("tagIds") {
+		tagIds, err := parser.GetArrayOfIntegers("tagIds", true)
+		if err != nil {
+			return desired, fmt.Errorf("invalid tagIds parameter: %w", err)
+		}
+		desired.TagIds = tagIds
+	}
+
+	if parser.Has("accessGroupId") {
+		accessGroupId, err := parser.GetInt("accessGroupId", true)
+		if err != nil {
+			return desired, fmt.Errorf("invalid accessGroupId parameter: %w", err)
+		}
+		desired.AccessGroupID = &accessGroupId
+	}
+
+	if parser.Has("userAccesses") {
+		userAccesses, err := parser.GetArrayOfObjects("userAccesses", true)
+		if err != nil {
+			return desired, fmt.Errorf("invalid userAccesses parameter: %w", err)
+		}
+
+		desired.UserAccesses, err = parseAccessMap(userAccesses)
+		if err != nil {
+			return desired, fmt.Errorf("invalid user accesses: %w", err)
+		}
+	}
+
+	if parser.Has("teamAccesses") {
+		teamAccesses, err := parser.GetArrayOfObjects("teamAccesses", true)
+		if err != nil {
+			return desired, fmt.Errorf("invalid teamAccesses parameter: %w", err)
+		}
+
+		desired.TeamAccesses, err = parseAccessMap(teamAccesses)
+		if err != nil {
+			return desired, fmt.Errorf("invalid team accesses: %w", err)
+		}
+	}
+
+	return desired, nil
+}
+
+// parseGPUConfigs parses GPU entries from an array of objects with a name and a deviceId
+func parseGPUConfigs(entries []any) ([]models.GPUConfig, error) {
+	gpus := make([]models.GPUConfig, 0, len(entries))
+
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid gpu entry: %v", entry)
+		}
+
+		name, ok := entryMap["name"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid name: %v", entryMap["name"])
+		}
+
+		deviceID, ok := entryMap["deviceId"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid deviceId: %v", entryMap["deviceId"])
+		}
+
+		gpus = append(gpus, models.GPUConfig{Name: name, DeviceID: deviceID})
+	}
+
+	return gpus, nil
+}
+
+func (s *PortainerMCPServer) HandleGetEnvironmentMetadata() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		metadata, err := s.client(ctx).GetEnvironmentMetadata(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get environment metadata", err), nil
+		}
+
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal environment metadata", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateEnvironmentMetadata() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		entries, err := parser.GetArrayOfObjects("metadata", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid metadata parameter", err), nil
+		}
+
+		metadata, err := parseKeyValueMap(entries)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid metadata", err), nil
+		}
+
+		err = s.client(ctx).UpdateEnvironmentMetadata(id, metadata)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update environment metadata", err), nil
+		}
+
+		return mcp.NewToolResultText("Environment metadata updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetFleetStats() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		stats, err := s.client(ctx).GetFleetStats()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get fleet statistics", err), nil
+		}
+
+		data, err := json.Marshal(stats)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal fleet statistics", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleDetachEnvironment() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		report, err := s.client(ctx).DetachEnvironment(id)
+
+		var detachErr *client.EnvironmentDetachError
+		if err != nil && !errors.As(err, &detachErr) {
+			return mcp.NewToolResultErrorFromErr("failed to detach environment", err), nil
+		}
+
+		data, marshalErr := json.Marshal(report)
+		if marshalErr != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal detach report", marshalErr), nil
+		}
+
+		// Some steps were applied, the report is returned along with the failures
+		if detachErr != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%v, detached: %s", detachErr, data)), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleExportEnvironmentAccess() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		snapshot, err := s.client(ctx).ExportEnvironmentAccess(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to export environment access", err), nil
+		}
+
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal access snapshot", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleRestoreEnvironmentAccess() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		rawSnapshot, err := parser.GetString("snapshot", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid snapshot parameter", err), nil
+		}
+
+		var snapshot models.AccessSnapshot
+		if err := json.Unmarshal([]byte(rawSnapshot), &snapshot); err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid snapshot", err), nil
+		}
+
+		err = s.client(ctx).RestoreEnvironmentAccess(id, snapshot)
+
+		// The accesses of the remaining users and teams were restored, the missing ones are reported
+		var restoreErr *client.AccessSnapshotRestoreError
+		if errors.As(err, &restoreErr) {
+			return mcp.NewToolResultError(restoreErr.Error()), nil
+		}
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to restore environment access", err), nil
+		}
+
+		return mcp.NewToolResultText("Environment access restored successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleTestEnvironmentConnectivity() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		result, err := s.client(ctx).TestEnvironmentConnectivity(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to test environment connectivity", err), nil
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal connectivity result", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// fleetConnectivityResult is the result of the checkFleetConnectivity tool, the connectivity results
+// of the environments with the number of environments that could and could not be reached
+type fleetConnectivityResult struct {
+	Healthy   int                         `json:"healthy"`
+	Unhealthy int                         `json:"unhealthy"`
+	Results   []models.ConnectivityResult `json:"results"`
+}
+
+func (s *PortainerMCPServer) HandleCheckFleetConnectivity() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		results, err := s.client(ctx).CheckFleetConnectivity()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to check fleet connectivity", err), nil
+		}
+
+		fleet := fleetConnectivityResult{Results: results}
+		if fleet.Results == nil {
+			fleet.Results = []models.ConnectivityResult{}
+		}
+		for _, result := range results {
+			if result.Success {
+				fleet.Healthy++
+			} else {
+				fleet.Unhealthy++
+			}
+		}
+
+		data, err := json.Marshal(fleet)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal fleet connectivity", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleFindEnvironmentsByImage() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		image, err := parser.GetString("image", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid image parameter", err), nil
+		}
+
+		environments, err := s.client(ctx).FindEnvironmentsByImage(image)
+
+		var searchErr *client.EnvironmentSearchError
+		if err != nil && !errors.As(err, &searchErr) {
+			return mcp.NewToolResultErrorFromErr("failed to find environments by image", err), nil
+		}
+
+		data, marshalErr := json.Marshal(newListResponse(environments))
+		if marshalErr != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal environments", marshalErr), nil
+		}
+
+		// Some environments were searched, their matches are returned along with the failures
+		if searchErr != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%v, matching environments: %s", searchErr, data)), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetEnvironmentPublicURL() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		publicURL, err := s.client(ctx).GetEnvironmentPublicURL(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get environment public URL", err), nil
+		}
+
+		if publicURL == "" {
+			return mcp.NewToolResultText(fmt.Sprintf("Environment %d has no public URL, Portainer uses the environment URL to build the links to the published ports", id)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Public URL of environment %d: %s", id, publicURL)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateEnvironmentPublicURL() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		publicURL, err := parser.GetString("publicURL", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid publicURL parameter", err), nil
+		}
+
+		err = s.client(ctx).UpdateEnvironmentPublicURL(id, publicURL)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update environment public URL", err), nil
+		}
+
+		return mcp.NewToolResultText("Environment public URL updated successfully"), nil
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/client"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandleGetEnvironments(t *testing.T) {
@@ -66,10 +69,12 @@ func TestHandleGetEnvironments(t *testing.T) {
 				textContent, ok := result.Content[0].(mcp.TextContent)
 				assert.True(t, ok)
 
-				var environments []models.Environment
+				var environments listResponse[models.Environment]
 				err = json.Unmarshal([]byte(textContent.Text), &environments)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockEnvironments, environments)
+				assert.Equal(t, tt.mockEnvironments, environments.Items)
+				assert.Equal(t, len(tt.mockEnvironments), environments.TotalCount)
+				assert.False(t, environments.HasMore)
 			}
 
 			mockClient.AssertExpectations(t)
@@ -445,3 +450,1843 @@ func TestHandleUpdateEnvironmentTeamAccesses(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGetEnvironmentGPUs(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputID     int
+		mockResult  models.EnvironmentGPUSettings
+		mockError   error
+		expectError bool
+		setupParams func(request *mcp.CallToolRequest)
+	}{
+		{
+			name:    "successful gpus retrieval",
+			inputID: 1,
+			mockResult: models.EnvironmentGPUSettings{
+				EnableGPUManagement: true,
+				GPUs: []models.GPUConfig{
+					{Name: "gpu0", DeviceID: "0"},
+				},
+			},
+			mockError:   nil,
+			expectError: false,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"id": float64(1),
+				}
+			},
+		},
+		{
+			name:        "api error",
+			inputID:     1,
+			mockResult:  models.EnvironmentGPUSettings{},
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"id": float64(1),
+				}
+			},
+		},
+		{
+			name:        "missing id parameter",
+			mockResult:  models.EnvironmentGPUSettings{},
+			mockError:   nil,
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if !tt.expectError || tt.mockError != nil {
+				mockClient.On("GetEnvironmentGPUs", tt.inputID).Return(tt.mockResult, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			request := CreateMCPRequest(map[string]any{})
+			tt.setupParams(&request)
+
+			handler := server.HandleGetEnvironmentGPUs()
+			result, err := handler(context.Background(), request)
+
+			if tt.expectError {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Len(t, result.Content, 1)
+				textContent, ok := result.Content[0].(mcp.TextContent)
+				assert.True(t, ok, "Result content should be mcp.TextContent for errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Content, 1)
+				textContent, ok := result.Content[0].(mcp.TextContent)
+				assert.True(t, ok)
+
+				var settings models.EnvironmentGPUSettings
+				err = json.Unmarshal([]byte(textContent.Text), &settings)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockResult, settings)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateEnvironmentGPUs(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputID     int
+		inputGPUs   []models.GPUConfig
+		mockError   error
+		expectError bool
+		setupParams func(request *mcp.CallToolRequest)
+	}{
+		{
+			name:    "successful gpus update",
+			inputID: 1,
+			inputGPUs: []models.GPUConfig{
+				{Name: "gpu0", DeviceID: "0"},
+				{Name: "gpu1", DeviceID: "GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"},
+			},
+			mockError:   nil,
+			expectError: false,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"id": float64(1),
+					"gpus": []any{
+						map[string]any{"name": "gpu0", "deviceId": "0"},
+						map[string]any{"name": "gpu1", "deviceId": "GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"},
+					},
+				}
+			},
+		},
+		{
+			name:        "remove all gpus",
+			inputID:     1,
+			inputGPUs:   []models.GPUConfig{},
+			mockError:   nil,
+			expectError: false,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"id":   float64(1),
+					"gpus": []any{},
+				}
+			},
+		},
+		{
+			name:    "api error",
+			inputID: 1,
+			inputGPUs: []models.GPUConfig{
+				{Name: "gpu0", DeviceID: "0"},
+			},
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"id": float64(1),
+					"gpus": []any{
+						map[string]any{"name": "gpu0", "deviceId": "0"},
+					},
+				}
+			},
+		},
+		{
+			name:        "missing id parameter",
+			mockError:   nil,
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"gpus": []any{
+						map[string]any{"name": "gpu0", "deviceId": "0"},
+					},
+				}
+			},
+		},
+		{
+			name:        "missing gpus parameter",
+			inputID:     1,
+			mockError:   nil,
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"id": float64(1),
+				}
+			},
+		},
+		{
+			name:        "invalid gpu entry",
+			inputID:     1,
+			mockError:   nil,
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"id": float64(1),
+					"gpus": []any{
+						map[string]any{"name": "gpu0", "deviceId": float64(0)},
+					},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if !tt.expectError || tt.mockError != nil {
+				mockClient.On("UpdateEnvironmentGPUs", tt.inputID, tt.inputGPUs).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			request := CreateMCPRequest(map[string]any{})
+			tt.setupParams(&request)
+
+			handler := server.HandleUpdateEnvironmentGPUs()
+			result, err := handler(context.Background(), request)
+
+			if tt.expectError {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Len(t, result.Content, 1)
+				textContent, ok := result.Content[0].(mcp.TextContent)
+				assert.True(t, ok, "Result content should be mcp.TextContent for errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Content, 1)
+				textContent, ok := result.Content[0].(mcp.TextContent)
+				assert.True(t, ok)
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandlePlanEnvironmentConfig(t *testing.T) {
+	accessGroupId := 2
+	mockDiff := models.ConfigDiff{
+		EnvironmentID:     1,
+		TagsAdded:         []int{2},
+		TagsRemoved:       []int{},
+		AccessGroupChange: &models.AccessGroupChange{From: 1, To: 2},
+		UserAccesses: models.AccessMapDiff{
+			Added:   map[int]string{3: models.AccessLevelReadonlyUser},
+			Removed: map[int]string{},
+			Changed: map[int]models.AccessLevelChange{},
+		},
+		TeamAccesses: models.AccessMapDiff{
+			Added:   map[int]string{},
+			Removed: map[int]string{},
+			Changed: map[int]models.AccessLevelChange{},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		inputParams     map[string]any
+		expectCall      bool
+		expectedDesired models.EnvironmentDesiredState
+		mockError       error
+		expectError     bool
+	}{
+		{
+			name: "all fields managed",
+			inputParams: map[string]any{
+				"id":            float64(1),
+				"tagIds":        []any{float64(1), float64(2)},
+				"accessGroupId": float64(2),
+				"userAccesses":  []any{map[string]any{"id": float64(3), "access": "readonly_user"}},
+				"teamAccesses":  []any{},
+			},
+			expectCall: true,
+			expectedDesired: models.EnvironmentDesiredState{
+				TagIds:        []int{1, 2},
+				AccessGroupID: &accessGroupId,
+				UserAccesses:  map[int]string{3: models.AccessLevelReadonlyUser},
+				TeamAccesses:  map[int]string{},
+			},
+		},
+		{
+			name:            "only tags managed",
+			inputParams:     map[string]any{"id": float64(1), "tagIds": []any{}},
+			expectCall:      true,
+			expectedDesired: models.EnvironmentDesiredState{TagIds: []int{}},
+		},
+		{
+			name:            "api error",
+			inputParams:     map[string]any{"id": float64(1)},
+			expectCall:      true,
+			expectedDesired: models.EnvironmentDesiredState{},
+			mockError:       fmt.Errorf("tag 9 does not exist"),
+			expectError:     true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{"tagIds": []any{float64(1)}},
+			expectError: true,
+		},
+		{
+			name: "invalid access level",
+			inputParams: map[string]any{
+				"id":           float64(1),
+				"userAccesses": []any{map[string]any{"id": float64(3), "access": "superuser"}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("PlanEnvironmentConfig", 1, tt.expectedDesired).Return(mockDiff, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandlePlanEnvironmentConfig()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var diff models.ConfigDiff
+				err = json.Unmarshal([]byte(textContent.Text), &diff)
+				assert.NoError(t, err)
+				assert.Equal(t, mockDiff, diff)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleApplyEnvironmentConfig(t *testing.T) {
+	mockDiff := models.ConfigDiff{
+		EnvironmentID: 1,
+		TagsAdded:     []int{},
+		TagsRemoved:   []int{1},
+		UserAccesses: models.AccessMapDiff{
+			Added:   map[int]string{},
+			Removed: map[int]string{},
+			Changed: map[int]models.AccessLevelChange{},
+		},
+		TeamAccesses: models.AccessMapDiff{
+			Added:   map[int]string{},
+			Removed: map[int]string{},
+			Changed: map[int]models.AccessLevelChange{},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful apply",
+			inputParams: map[string]any{"id": float64(1), "tagIds": []any{}},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1), "tagIds": []any{}},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to update environment"),
+			expectError: true,
+		},
+		{
+			name:        "invalid tagIds parameter",
+			inputParams: map[string]any{"id": float64(1), "tagIds": "1"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ApplyEnvironmentConfig", 1, models.EnvironmentDesiredState{TagIds: []int{}}).Return(mockDiff, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleApplyEnvironmentConfig()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var diff models.ConfigDiff
+				err = json.Unmarshal([]byte(textContent.Text), &diff)
+				assert.NoError(t, err)
+				assert.Equal(t, mockDiff, diff)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleTriggerEnvironmentSnapshot(t *testing.T) {
+	previous := time.Unix(1700000000, 0).UTC()
+	snapshotTime := time.Unix(1700000060, 0).UTC()
+
+	tests := []struct {
+		name            string
+		inputParams     map[string]any
+		expectTrigger   bool
+		mockTriggerErr  error
+		expectWait      bool
+		expectedTimeout time.Duration
+		mockWaitErr     error
+		expectError     bool
+		expectedText    string
+	}{
+		{
+			name:          "trigger without waiting",
+			inputParams:   map[string]any{"id": float64(1)},
+			expectTrigger: true,
+			expectedText:  "Environment snapshot triggered successfully",
+		},
+		{
+			name:            "trigger and wait",
+			inputParams:     map[string]any{"id": float64(1), "wait": true, "timeout": float64(10)},
+			expectTrigger:   true,
+			expectWait:      true,
+			expectedTimeout: 10 * time.Second,
+			expectedText:    "2023-11-14T22:14:20Z",
+		},
+		{
+			name:            "wait timeout",
+			inputParams:     map[string]any{"id": float64(1), "wait": true},
+			expectTrigger:   true,
+			expectWait:      true,
+			expectedTimeout: defaultSnapshotWaitTimeout,
+			mockWaitErr:     fmt.Errorf("no new snapshot of environment 1 after 30s"),
+			expectError:     true,
+		},
+		{
+			name:           "snapshots not supported",
+			inputParams:    map[string]any{"id": float64(1)},
+			expectTrigger:  true,
+			mockTriggerErr: fmt.Errorf("environment 1 is a docker-edge-agent environment, it does not support snapshots on demand"),
+			expectError:    true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+		{
+			name:        "timeout too large",
+			inputParams: map[string]any{"id": float64(1), "wait": true, "timeout": float64(600)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectTrigger {
+				mockClient.On("TriggerEnvironmentSnapshot", 1).Return(tt.mockTriggerErr)
+			}
+			if tt.expectWait {
+				mockClient.On("GetEnvironmentSnapshotTime", 1).Return(previous, nil)
+				mockClient.On("WaitForEnvironmentSnapshot", mock.Anything, 1, previous, tt.expectedTimeout).Return(snapshotTime, tt.mockWaitErr)
+			}
+
+			server := &PortainerMCPServer{
+				cli:        mockClient,
+				operations: newOperationRegistry(),
+			}
+
+			handler := server.HandleTriggerEnvironmentSnapshot()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				switch {
+				case tt.mockTriggerErr != nil:
+					assert.Contains(t, textContent.Text, tt.mockTriggerErr.Error())
+				case tt.mockWaitErr != nil:
+					assert.Contains(t, textContent.Text, tt.mockWaitErr.Error())
+				default:
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, tt.expectedText)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCompareEnvironments(t *testing.T) {
+	mockComparison := models.EnvironmentComparison{
+		First:  models.ComparedEnvironment{ID: 1, Name: "prod", Type: models.EnvironmentTypeDockerAgent},
+		Second: models.ComparedEnvironment{ID: 2, Name: "staging", Type: models.EnvironmentTypeDockerAgent},
+		Tags: models.ItemSetComparison{
+			OnlyInFirst:  []models.ComparedItem{{ID: 1, Name: "critical"}},
+			OnlyInSecond: []models.ComparedItem{},
+			InBoth:       []models.ComparedItem{},
+		},
+		AccessGroup: models.ValueComparison{Same: true, First: "production", Second: "production"},
+		EnvironmentGroups: models.ItemSetComparison{
+			Same:         true,
+			OnlyInFirst:  []models.ComparedItem{},
+			OnlyInSecond: []models.ComparedItem{},
+			InBoth:       []models.ComparedItem{},
+		},
+		UserAccesses:  models.AccessComparison{Same: true, Differences: []models.AccessDifference{}},
+		TeamAccesses:  models.AccessComparison{Same: true, Differences: []models.AccessDifference{}},
+		DockerVersion: models.ValueComparison{First: "27.3.1", Second: "26.1.4"},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful comparison",
+			inputParams: map[string]any{"firstId": float64(1), "secondId": float64(2)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"firstId": float64(1), "secondId": float64(2)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to get endpoint 2"),
+			expectError: true,
+		},
+		{
+			name:        "missing secondId parameter",
+			inputParams: map[string]any{"firstId": float64(1)},
+			expectError: true,
+		},
+		{
+			name:        "invalid firstId parameter",
+			inputParams: map[string]any{"firstId": "prod", "secondId": float64(2)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CompareEnvironments", 1, 2).Return(mockComparison, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCompareEnvironments()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var comparison models.EnvironmentComparison
+				err = json.Unmarshal([]byte(textContent.Text), &comparison)
+				assert.NoError(t, err)
+				assert.Equal(t, mockComparison, comparison)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetEnvironmentRegistries(t *testing.T) {
+	mockRegistries := []models.EnvironmentRegistry{
+		{ID: 1, Name: "ghcr", URL: "ghcr.io", Enabled: true, UserIds: []int{2}, TeamIds: []int{}},
+		{ID: 2, Name: "quay", URL: "quay.io", UserIds: []int{}, TeamIds: []int{}},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to list registries"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				if tt.mockError != nil {
+					mockClient.On("GetEnvironmentRegistries", 1).Return(nil, tt.mockError)
+				} else {
+					mockClient.On("GetEnvironmentRegistries", 1).Return(mockRegistries, nil)
+				}
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetEnvironmentRegistries()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var registries listResponse[models.EnvironmentRegistry]
+				err = json.Unmarshal([]byte(textContent.Text), &registries)
+				assert.NoError(t, err)
+				assert.Equal(t, mockRegistries, registries.Items)
+				assert.Equal(t, len(mockRegistries), registries.TotalCount)
+				assert.False(t, registries.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateEnvironmentRegistries(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful update",
+			inputParams: map[string]any{"environmentId": float64(1), "registryIds": []any{float64(1), float64(2)}},
+			expectCall:  true,
+		},
+		{
+			name:        "unknown registry",
+			inputParams: map[string]any{"environmentId": float64(1), "registryIds": []any{float64(1), float64(2)}},
+			expectCall:  true,
+			mockError:   fmt.Errorf("registry 2 does not exist"),
+			expectError: true,
+		},
+		{
+			name:        "missing registryIds parameter",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectError: true,
+		},
+		{
+			name:        "invalid registryIds parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "registryIds": []any{"ghcr"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateEnvironmentRegistries", 1, []int{1, 2}).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateEnvironmentRegistries()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Environment registries updated successfully", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetEdgeAgentStatus(t *testing.T) {
+	secondsSinceCheckIn := 10
+	mockStatuses := []models.EdgeAgentStatus{
+		{
+			EnvironmentID:           2,
+			Name:                    "store-1",
+			Type:                    models.EnvironmentTypeDockerEdgeAgent,
+			EdgeID:                  "edge-2",
+			Status:                  models.EdgeAgentStatusOnline,
+			LastCheckIn:             "2023-11-14T22:13:10Z",
+			SecondsSinceCheckIn:     &secondsSinceCheckIn,
+			CheckinIntervalSeconds:  5,
+			OfflineThresholdSeconds: 30,
+		},
+		{
+			EnvironmentID:           4,
+			Name:                    "store-3",
+			Type:                    models.EnvironmentTypeDockerEdgeAgent,
+			EdgeID:                  "edge-4",
+			Status:                  models.EdgeAgentStatusOffline,
+			CheckinIntervalSeconds:  5,
+			OfflineThresholdSeconds: 30,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful retrieval",
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("failed to list endpoints"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.mockError != nil {
+				mockClient.On("GetEdgeAgentStatus").Return(nil, tt.mockError)
+			} else {
+				mockClient.On("GetEdgeAgentStatus").Return(mockStatuses, nil)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetEdgeAgentStatus()
+			result, err := handler(context.Background(), CreateMCPRequest(map[string]any{}))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var statuses listResponse[models.EdgeAgentStatus]
+				err = json.Unmarshal([]byte(textContent.Text), &statuses)
+				assert.NoError(t, err)
+				assert.Equal(t, mockStatuses, statuses.Items)
+				assert.Equal(t, len(mockStatuses), statuses.TotalCount)
+				assert.False(t, statuses.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateEnvironmentsUserAccessesBulk(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedEnvs  []int
+		expectedInput map[int]string
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "successful update",
+			inputParams: map[string]any{
+				"environmentIds": []any{float64(1), float64(2)},
+				"userAccesses": []any{
+					map[string]any{"id": float64(3), "access": "standard_user"},
+				},
+			},
+			expectCall:    true,
+			expectedEnvs:  []int{1, 2},
+			expectedInput: map[int]string{3: "standard_user"},
+		},
+		{
+			name: "partial failure",
+			inputParams: map[string]any{
+				"environmentIds": []any{float64(1), float64(2)},
+				"userAccesses": []any{
+					map[string]any{"id": float64(3), "access": "standard_user"},
+				},
+			},
+			expectCall:    true,
+			expectedEnvs:  []int{1, 2},
+			expectedInput: map[int]string{3: "standard_user"},
+			mockError:     fmt.Errorf("failed to update the user accesses of 1 of 2 environments"),
+			expectError:   true,
+		},
+		{
+			name: "missing environmentIds parameter",
+			inputParams: map[string]any{
+				"userAccesses": []any{
+					map[string]any{"id": float64(3), "access": "standard_user"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid access entry",
+			inputParams: map[string]any{
+				"environmentIds": []any{float64(1)},
+				"userAccesses":   []any{map[string]any{"id": "three", "access": "standard_user"}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateEnvironmentsUserAccessesBulk", tt.expectedEnvs, tt.expectedInput).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateEnvironmentsUserAccessesBulk()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "2 environments")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateEnvironmentsTeamAccessesBulk(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedEnvs  []int
+		expectedInput map[int]string
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "successful update",
+			inputParams: map[string]any{
+				"environmentIds": []any{float64(1), float64(2)},
+				"teamAccesses": []any{
+					map[string]any{"id": float64(4), "access": "readonly_user"},
+					map[string]any{"id": float64(5), "access": "operator_user"},
+				},
+			},
+			expectCall:    true,
+			expectedEnvs:  []int{1, 2},
+			expectedInput: map[int]string{4: "readonly_user", 5: "operator_user"},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"environmentIds": []any{float64(1), float64(2)},
+				"teamAccesses": []any{
+					map[string]any{"id": float64(4), "access": "readonly_user"},
+				},
+			},
+			expectCall:    true,
+			expectedEnvs:  []int{1, 2},
+			expectedInput: map[int]string{4: "readonly_user"},
+			mockError:     fmt.Errorf("team 4 does not exist"),
+			expectError:   true,
+		},
+		{
+			name: "missing teamAccesses parameter",
+			inputParams: map[string]any{
+				"environmentIds": []any{float64(1)},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateEnvironmentsTeamAccessesBulk", tt.expectedEnvs, tt.expectedInput).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateEnvironmentsTeamAccessesBulk()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "2 environments")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetEnvironmentMetadata(t *testing.T) {
+	mockMetadata := map[string]string{"region": "eu-west", "owner": "team-a"}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to get endpoint"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				if tt.mockError != nil {
+					mockClient.On("GetEnvironmentMetadata", 1).Return(nil, tt.mockError)
+				} else {
+					mockClient.On("GetEnvironmentMetadata", 1).Return(mockMetadata, nil)
+				}
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetEnvironmentMetadata()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var metadata map[string]string
+				err = json.Unmarshal([]byte(textContent.Text), &metadata)
+				assert.NoError(t, err)
+				assert.Equal(t, mockMetadata, metadata)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateEnvironmentMetadata(t *testing.T) {
+	tests := []struct {
+		name             string
+		inputParams      map[string]any
+		expectedMetadata map[string]string
+		mockError        error
+		expectError      bool
+	}{
+		{
+			name: "successful update",
+			inputParams: map[string]any{
+				"id": float64(1),
+				"metadata": []any{
+					map[string]any{"key": "region", "value": "eu-west"},
+					map[string]any{"key": "owner", "value": ""},
+				},
+			},
+			expectedMetadata: map[string]string{"region": "eu-west", "owner": ""},
+		},
+		{
+			name: "invalid key",
+			inputParams: map[string]any{
+				"id":       float64(1),
+				"metadata": []any{map[string]any{"key": "a=b", "value": "c"}},
+			},
+			expectedMetadata: map[string]string{"a=b": "c"},
+			mockError:        fmt.Errorf("metadata key a=b cannot contain ="),
+			expectError:      true,
+		},
+		{
+			name: "invalid metadata entry",
+			inputParams: map[string]any{
+				"id":       float64(1),
+				"metadata": []any{map[string]any{"key": "region"}},
+			},
+			expectError: true,
+		},
+		{
+			name:        "missing metadata parameter",
+			inputParams: map[string]any{"id": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectedMetadata != nil {
+				mockClient.On("UpdateEnvironmentMetadata", 1, tt.expectedMetadata).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateEnvironmentMetadata()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Environment metadata updated successfully", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetFleetStats(t *testing.T) {
+	mockStats := models.FleetStats{
+		TotalEnvironments:         3,
+		OnlineEnvironments:        2,
+		OfflineEnvironments:       1,
+		TotalStacks:               4,
+		TotalContainers:           12,
+		RunningContainers:         9,
+		UnreachableEnvironments:   1,
+		UnreachableEnvironmentIDs: []int{3},
+	}
+
+	tests := []struct {
+		name        string
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful retrieval",
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("failed to list endpoints"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetFleetStats").Return(mockStats, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetFleetStats()
+			result, err := handler(context.Background(), CreateMCPRequest(map[string]any{}))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var stats models.FleetStats
+				err = json.Unmarshal([]byte(textContent.Text), &stats)
+				assert.NoError(t, err)
+				assert.Equal(t, mockStats, stats)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDetachEnvironment(t *testing.T) {
+	mockReport := models.EnvironmentDetachReport{
+		EnvironmentID:              3,
+		RemovedEnvironmentGroupIds: []int{1},
+		RemovedAccessGroupID:       2,
+		RemovedTagIds:              []int{1, 4},
+		RemovedUserAccesses:        []int{5},
+		RemovedTeamAccesses:        []int{},
+	}
+
+	tests := []struct {
+		name          string
+		input         map[string]any
+		mockError     error
+		expectError   bool
+		expectReport  bool
+		expectedError string
+	}{
+		{
+			name:         "successful detach",
+			input:        map[string]any{"id": float64(3)},
+			expectReport: true,
+		},
+		{
+			name:  "partial detach",
+			input: map[string]any{"id": float64(3)},
+			mockError: &client.EnvironmentDetachError{
+				EnvironmentID: 3,
+				Failed:        []error{fmt.Errorf("failed to remove environment from environment group 4: locked")},
+			},
+			expectError:   true,
+			expectReport:  true,
+			expectedError: "environment group 4: locked",
+		},
+		{
+			name:          "environment not found",
+			input:         map[string]any{"id": float64(3)},
+			mockError:     fmt.Errorf("failed to get endpoint: not found"),
+			expectError:   true,
+			expectedError: "failed to get endpoint: not found",
+		},
+		{
+			name:          "missing id parameter",
+			input:         map[string]any{},
+			expectError:   true,
+			expectedError: "invalid id parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if _, ok := tt.input["id"]; ok {
+				mockClient.On("DetachEnvironment", 3).Return(mockReport, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDetachEnvironment()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.input))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if tt.expectedError != "" {
+				assert.Contains(t, textContent.Text, tt.expectedError)
+			}
+			if tt.expectReport {
+				assert.Contains(t, textContent.Text, `"removed_access_group_id":2`)
+			} else {
+				assert.NotContains(t, textContent.Text, "removed_access_group_id")
+			}
+			if !tt.expectError {
+				var report models.EnvironmentDetachReport
+				err = json.Unmarshal([]byte(textContent.Text), &report)
+				assert.NoError(t, err)
+				assert.Equal(t, mockReport, report)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleExportEnvironmentAccess(t *testing.T) {
+	mockSnapshot := models.AccessSnapshot{
+		EnvironmentID:   1,
+		EnvironmentName: "production",
+		CapturedAt:      "2024-05-01T10:00:00Z",
+		AccessGroupID:   2,
+		UserAccesses:    map[int]string{1: "environment_administrator"},
+		TeamAccesses:    map[int]string{3: "readonly_user"},
+	}
+
+	tests := []struct {
+		name          string
+		input         map[string]any
+		mockError     error
+		expectError   bool
+		expectedError string
+	}{
+		{
+			name:  "successful export",
+			input: map[string]any{"id": float64(1)},
+		},
+		{
+			name:          "client error",
+			input:         map[string]any{"id": float64(1)},
+			mockError:     fmt.Errorf("failed to get endpoint: not found"),
+			expectError:   true,
+			expectedError: "failed to export environment access",
+		},
+		{
+			name:          "missing id parameter",
+			input:         map[string]any{},
+			expectError:   true,
+			expectedError: "invalid id parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if _, ok := tt.input["id"]; ok {
+				mockClient.On("ExportEnvironmentAccess", 1).Return(mockSnapshot, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleExportEnvironmentAccess()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.input))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if tt.expectError {
+				assert.Contains(t, textContent.Text, tt.expectedError)
+			} else {
+				var snapshot models.AccessSnapshot
+				err = json.Unmarshal([]byte(textContent.Text), &snapshot)
+				assert.NoError(t, err)
+				assert.Equal(t, mockSnapshot, snapshot)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleRestoreEnvironmentAccess(t *testing.T) {
+	snapshot := `{"environment_id":1,"environment_name":"production","captured_at":"2024-05-01T10:00:00Z","access_group_id":2,"user_accesses":{"1":"environment_administrator"},"team_accesses":{"3":"readonly_user"}}`
+	expectedSnapshot := models.AccessSnapshot{
+		EnvironmentID:   1,
+		EnvironmentName: "production",
+		CapturedAt:      "2024-05-01T10:00:00Z",
+		AccessGroupID:   2,
+		UserAccesses:    map[int]string{1: "environment_administrator"},
+		TeamAccesses:    map[int]string{3: "readonly_user"},
+	}
+
+	tests := []struct {
+		name         string
+		input        map[string]any
+		expectCall   bool
+		mockError    error
+		expectError  bool
+		expectedText string
+	}{
+		{
+			name:         "successful restore",
+			input:        map[string]any{"id": float64(1), "snapshot": snapshot},
+			expectCall:   true,
+			expectedText: "Environment access restored successfully",
+		},
+		{
+			name:         "users no longer exist",
+			input:        map[string]any{"id": float64(1), "snapshot": snapshot},
+			expectCall:   true,
+			mockError:    &client.AccessSnapshotRestoreError{EnvironmentID: 1, MissingUsers: []int{1}, MissingTeams: []int{}},
+			expectError:  true,
+			expectedText: "without the accesses of users [1]",
+		},
+		{
+			name:         "client error",
+			input:        map[string]any{"id": float64(1), "snapshot": snapshot},
+			expectCall:   true,
+			mockError:    fmt.Errorf("snapshot of environment 2 cannot be restored on environment 1"),
+			expectError:  true,
+			expectedText: "failed to restore environment access",
+		},
+		{
+			name:         "invalid snapshot",
+			input:        map[string]any{"id": float64(1), "snapshot": "{not json"},
+			expectError:  true,
+			expectedText: "invalid snapshot",
+		},
+		{
+			name:         "missing snapshot parameter",
+			input:        map[string]any{"id": float64(1)},
+			expectError:  true,
+			expectedText: "invalid snapshot parameter",
+		},
+		{
+			name:         "missing id parameter",
+			input:        map[string]any{"snapshot": snapshot},
+			expectError:  true,
+			expectedText: "invalid id parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("RestoreEnvironmentAccess", 1, expectedSnapshot).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleRestoreEnvironmentAccess()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.input))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+			assert.Contains(t, textContent.Text, tt.expectedText)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleTestEnvironmentConnectivity(t *testing.T) {
+	mockResult := models.ConnectivityResult{
+		EnvironmentID:   1,
+		EnvironmentName: "production",
+		Type:            models.EnvironmentTypeDockerAgent,
+		Path:            "/_ping",
+		StatusCode:      502,
+		Failure:         models.ConnectivityFailureUnreachable,
+		Error:           "unexpected status 502",
+		Hint:            "Portainer could not reach the environment",
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful test",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to get endpoint"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("TestEnvironmentConnectivity", 1).Return(mockResult, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleTestEnvironmentConnectivity()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var connectivity models.ConnectivityResult
+				err = json.Unmarshal([]byte(textContent.Text), &connectivity)
+				assert.NoError(t, err)
+				assert.Equal(t, mockResult, connectivity)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCheckFleetConnectivity(t *testing.T) {
+	mockResults := []models.ConnectivityResult{
+		{EnvironmentID: 1, EnvironmentName: "local", Type: models.EnvironmentTypeDockerLocal, Path: "/_ping", Success: true, StatusCode: 200},
+		{EnvironmentID: 2, EnvironmentName: "cluster", Type: models.EnvironmentTypeKubernetesAgent, Path: "/healthz", Success: true, StatusCode: 200},
+		{EnvironmentID: 3, EnvironmentName: "remote", Type: models.EnvironmentTypeDockerAgent, Path: "/_ping", Failure: models.ConnectivityFailureUnreachable, Error: "connection refused"},
+	}
+
+	tests := []struct {
+		name              string
+		mockResults       []models.ConnectivityResult
+		mockError         error
+		expectError       bool
+		expectedHealthy   int
+		expectedUnhealthy int
+	}{
+		{
+			name:              "successful check",
+			mockResults:       mockResults,
+			expectedHealthy:   2,
+			expectedUnhealthy: 1,
+		},
+		{
+			name:        "no environments",
+			mockResults: nil,
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("failed to list endpoints"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("CheckFleetConnectivity").Return(tt.mockResults, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCheckFleetConnectivity()
+			result, err := handler(context.Background(), CreateMCPRequest(map[string]any{}))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var fleet fleetConnectivityResult
+				err = json.Unmarshal([]byte(textContent.Text), &fleet)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedHealthy, fleet.Healthy)
+				assert.Equal(t, tt.expectedUnhealthy, fleet.Unhealthy)
+				assert.NotNil(t, fleet.Results)
+				assert.Len(t, fleet.Results, len(tt.mockResults))
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleFindEnvironmentsByImage(t *testing.T) {
+	mockEnvironments := []models.Environment{
+		{ID: 1, Name: "local", Status: models.EnvironmentStatusActive, Type: models.EnvironmentTypeDockerLocal},
+	}
+
+	tests := []struct {
+		name          string
+		input         map[string]any
+		expectCall    bool
+		mockError     error
+		expectError   bool
+		expectMatches bool
+		expectedError string
+	}{
+		{
+			name:          "successful search",
+			input:         map[string]any{"image": "nginx"},
+			expectCall:    true,
+			expectMatches: true,
+		},
+		{
+			name:       "partial search",
+			input:      map[string]any{"image": "nginx"},
+			expectCall: true,
+			mockError: &client.EnvironmentSearchError{
+				Searched: 1,
+				Failed:   map[int]error{2: fmt.Errorf("failed to list containers: unexpected status 502")},
+			},
+			expectError:   true,
+			expectMatches: true,
+			expectedError: "failed to search 1 of 2 environments",
+		},
+		{
+			name:          "api error",
+			input:         map[string]any{"image": "nginx"},
+			expectCall:    true,
+			mockError:     fmt.Errorf("failed to list endpoints"),
+			expectError:   true,
+			expectedError: "failed to list endpoints",
+		},
+		{
+			name:          "missing image parameter",
+			input:         map[string]any{},
+			expectError:   true,
+			expectedError: "invalid image parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				if tt.expectMatches {
+					mockClient.On("FindEnvironmentsByImage", "nginx").Return(mockEnvironments, tt.mockError)
+				} else {
+					mockClient.On("FindEnvironmentsByImage", "nginx").Return(nil, tt.mockError)
+				}
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleFindEnvironmentsByImage()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.input))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if tt.expectedError != "" {
+				assert.Contains(t, textContent.Text, tt.expectedError)
+			}
+			if tt.expectMatches {
+				assert.Contains(t, textContent.Text, `"name":"local"`)
+			}
+			if !tt.expectError {
+				var environments listResponse[models.Environment]
+				err = json.Unmarshal([]byte(textContent.Text), &environments)
+				assert.NoError(t, err)
+				assert.Equal(t, mockEnvironments, environments.Items)
+				assert.Equal(t, len(mockEnvironments), environments.TotalCount)
+				assert.False(t, environments.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetEnvironmentPublicURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputParams  map[string]any
+		expectCall   bool
+		mockResult   string
+		mockError    error
+		expectError  bool
+		expectedText string
+	}{
+		{
+			name:         "public URL set",
+			inputParams:  map[string]any{"id": float64(1)},
+			expectCall:   true,
+			mockResult:   "docker.example.com",
+			expectedText: "Public URL of environment 1: docker.example.com",
+		},
+		{
+			name:         "public URL not set",
+			inputParams:  map[string]any{"id": float64(1)},
+			expectCall:   true,
+			expectedText: "Environment 1 has no public URL",
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to get endpoint"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetEnvironmentPublicURL", 1).Return(tt.mockResult, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetEnvironmentPublicURL()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, tt.expectedText)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateEnvironmentPublicURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful update",
+			inputParams: map[string]any{"id": float64(1), "publicURL": "docker.example.com"},
+			expectCall:  true,
+		},
+		{
+			name:        "invalid public URL",
+			inputParams: map[string]any{"id": float64(1), "publicURL": "docker.example.com"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("invalid public URL: has a port"),
+			expectError: true,
+		},
+		{
+			name:        "missing publicURL parameter",
+			inputParams: map[string]any{"id": float64(1)},
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{"publicURL": "docker.example.com"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateEnvironmentPublicURL", 1, "docker.example.com").Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateEnvironmentPublicURL()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Environment public URL updated successfully", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
@@ -15,6 +16,7 @@ func (s *PortainerMCPServer) AddEnvironmentGroupFeatures() {
 
 	if !s.readOnly {
 		s.addToolIfExists(ToolCreateEnvironmentGroup, s.HandleCreateEnvironmentGroup())
+		s.addToolIfExists(ToolCreateEnvironmentGroupsBulk, s.HandleCreateEnvironmentGroupsBulk())
 		s.addToolIfExists(ToolUpdateEnvironmentGroupName, s.HandleUpdateEnvironmentGroupName())
 		s.addToolIfExists(ToolUpdateEnvironmentGroupEnvironments, s.HandleUpdateEnvironmentGroupEnvironments())
 		s.addToolIfExists(ToolUpdateEnvironmentGroupTags, s.HandleUpdateEnvironmentGroupTags())
@@ -23,12 +25,12 @@ func (s *PortainerMCPServer) AddEnvironmentGroupFeatures() {
 
 func (s *PortainerMCPServer) HandleGetEnvironmentGroups() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		edgeGroups, err := s.cli.GetEnvironmentGroups()
+		edgeGroups, err := s.client(ctx).GetEnvironmentGroups()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get environment groups", err), nil
 		}
 
-		data, err := json.Marshal(edgeGroups)
+		data, err := json.Marshal(newListResponse(edgeGroups))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to marshal environment groups", err), nil
 		}
@@ -51,7 +53,7 @@ func (s *PortainerMCPServer) HandleCreateEnvironmentGroup() server.ToolHandlerFu
 			return mcp.NewToolResultErrorFromErr("invalid environmentIds parameter", err), nil
 		}
 
-		id, err := s.cli.CreateEnvironmentGroup(name, environmentIds)
+		id, err := s.client(ctx).CreateEnvironmentGroup(name, environmentIds)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to create environment group", err), nil
 		}
@@ -60,6 +62,29 @@ func (s *PortainerMCPServer) HandleCreateEnvironmentGroup() server.ToolHandlerFu
 	}
 }
 
+func (s *PortainerMCPServer) HandleCreateEnvironmentGroupsBulk() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		groupEntries, err := parser.GetArrayOfObjects("groups", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid groups parameter", err), nil
+		}
+
+		specs, err := parseGroupSpecs(groupEntries)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environment groups", err), nil
+		}
+
+		ids, err := s.client(ctx).CreateEnvironmentGroupsBulk(specs)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to create environment groups", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("%d environment groups created successfully with IDs: %v", len(ids), ids)), nil
+	}
+}
+
 func (s *PortainerMCPServer) HandleUpdateEnvironmentGroupName() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		parser := toolgen.NewParameterParser(request)
@@ -74,7 +99,7 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentGroupName() server.ToolHandl
 			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
 		}
 
-		err = s.cli.UpdateEnvironmentGroupName(id, name)
+		err = s.client(ctx).UpdateEnvironmentGroupName(id, name)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update environment group name", err), nil
 		}
@@ -97,7 +122,7 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentGroupEnvironments() server.T
 			return mcp.NewToolResultErrorFromErr("invalid environmentIds parameter", err), nil
 		}
 
-		err = s.cli.UpdateEnvironmentGroupEnvironments(id, environmentIds)
+		err = s.client(ctx).UpdateEnvironmentGroupEnvironments(id, environmentIds)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update environment group environments", err), nil
 		}
@@ -120,7 +145,7 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentGroupTags() server.ToolHandl
 			return mcp.NewToolResultErrorFromErr("invalid tagIds parameter", err), nil
 		}
 
-		err = s.cli.UpdateEnvironmentGroupTags(id, tagIds)
+		err = s.client(ctx).UpdateEnvironmentGroupTags(id, tagIds)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update environment group tags", err), nil
 		}
@@ -128,3 +153,55 @@ func (s *PortainerMCPServer) HandleUpdateEnvironmentGroupTags() server.ToolHandl
 		return mcp.NewToolResultText("Environment group tags updated successfully"), nil
 	}
 }
+
+// parseGroupSpecs parses environment group entries from an array of objects with a name and
+// optional environmentIds and environmentNames arrays
+func parseGroupSpecs(entries []any) ([]models.GroupSpec, error) {
+	specs := make([]models.GroupSpec, 0, len(entries))
+
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid environment group entry: %v", entry)
+		}
+
+		name, ok := entryMap["name"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid name: %v", entryMap["name"])
+		}
+
+		spec := models.GroupSpec{Name: name}
+
+		if rawIds, exists := entryMap["environmentIds"]; exists {
+			ids, ok := rawIds.([]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid environmentIds for group %s: %v", name, rawIds)
+			}
+			for _, rawId := range ids {
+				id, ok := rawId.(float64)
+				if !ok {
+					return nil, fmt.Errorf("invalid environment ID for group %s: %v", name, rawId)
+				}
+				spec.EnvironmentIds = append(spec.EnvironmentIds, int(id))
+			}
+		}
+
+		if rawNames, exists := entryMap["environmentNames"]; exists {
+			names, ok := rawNames.([]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid environmentNames for group %s: %v", name, rawNames)
+			}
+			for _, rawName := range names {
+				environmentName, ok := rawName.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid environment name for group %s: %v", name, rawName)
+				}
+				spec.EnvironmentNames = append(spec.EnvironmentNames, environmentName)
+			}
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
@@ -65,10 +65,12 @@ func TestHandleGetEnvironmentGroups(t *testing.T) {
 				textContent, ok := result.Content[0].(mcp.TextContent)
 				assert.True(t, ok)
 
-				var groups []models.Group
+				var groups listResponse[models.Group]
 				err = json.Unmarshal([]byte(textContent.Text), &groups)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockGroups, groups)
+				assert.Equal(t, tt.mockGroups, groups.Items)
+				assert.Equal(t, len(tt.mockGroups), groups.TotalCount)
+				assert.False(t, groups.HasMore)
 			}
 
 			mockClient.AssertExpectations(t)
@@ -180,6 +182,98 @@ func TestHandleCreateEnvironmentGroup(t *testing.T) {
 	}
 }
 
+func TestHandleCreateEnvironmentGroupsBulk(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		expectSpecs []models.GroupSpec
+		mockIDs     []int
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful creation",
+			inputParams: map[string]any{
+				"groups": []any{
+					map[string]any{"name": "web", "environmentIds": []any{float64(1), float64(2)}},
+					map[string]any{"name": "db", "environmentNames": []any{"prod-db"}},
+				},
+			},
+			expectCall: true,
+			expectSpecs: []models.GroupSpec{
+				{Name: "web", EnvironmentIds: []int{1, 2}},
+				{Name: "db", EnvironmentNames: []string{"prod-db"}},
+			},
+			mockIDs: []int{10, 11},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"groups": []any{map[string]any{"name": "web", "environmentIds": []any{float64(1)}}},
+			},
+			expectCall:  true,
+			expectSpecs: []models.GroupSpec{{Name: "web", EnvironmentIds: []int{1}}},
+			mockError:   fmt.Errorf("unknown environment names, no environment group was created: qa (group web)"),
+			expectError: true,
+		},
+		{
+			name:        "missing groups parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+		{
+			name: "missing group name",
+			inputParams: map[string]any{
+				"groups": []any{map[string]any{"environmentIds": []any{float64(1)}}},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid environment name",
+			inputParams: map[string]any{
+				"groups": []any{map[string]any{"name": "web", "environmentNames": []any{float64(1)}}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CreateEnvironmentGroupsBulk", tt.expectSpecs).Return(tt.mockIDs, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCreateEnvironmentGroupsBulk()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "2 environment groups created successfully with IDs: [10 11]", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandleUpdateEnvironmentGroupName(t *testing.T) {
 	tests := []struct {
 		name        string
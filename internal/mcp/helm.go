@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+func (s *PortainerMCPServer) AddHelmFeatures() {
+	s.addToolIfExists(ToolListHelmReleases, s.HandleListHelmReleases())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolInstallHelmChart, s.HandleInstallHelmChart())
+	}
+}
+
+func (s *PortainerMCPServer) HandleListHelmReleases() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		namespace, err := parser.GetString("namespace", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid namespace parameter", err), nil
+		}
+
+		releases, err := s.client(ctx).ListHelmReleases(environmentId, namespace)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to list helm releases", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(releases))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal helm releases", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleInstallHelmChart() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		repo, err := parser.GetString("repo", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid repo parameter", err), nil
+		}
+
+		chart, err := parser.GetString("chart", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid chart parameter", err), nil
+		}
+
+		version, err := parser.GetString("version", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid version parameter", err), nil
+		}
+
+		namespace, err := parser.GetString("namespace", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid namespace parameter", err), nil
+		}
+
+		name, err := parser.GetString("name", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
+		}
+
+		values, err := parser.GetString("values", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid values parameter", err), nil
+		}
+
+		opts := models.HelmInstallOptions{
+			Repo:      repo,
+			Chart:     chart,
+			Version:   version,
+			Namespace: namespace,
+			Name:      name,
+			Values:    values,
+		}
+
+		err = s.client(ctx).InstallHelmChart(environmentId, opts)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to install helm chart", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Helm release %s installed successfully in namespace %s", name, namespace)), nil
+	}
+}
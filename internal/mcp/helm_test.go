@@ -0,0 +1,208 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleListHelmReleases(t *testing.T) {
+	tests := []struct {
+		name              string
+		inputParams       map[string]any
+		expectCall        bool
+		expectedNamespace string
+		mockReleases      []models.HelmRelease
+		mockError         error
+		expectError       bool
+	}{
+		{
+			name:        "successful listing",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockReleases: []models.HelmRelease{
+				{Name: "redis", Namespace: "cache", Chart: "redis", Version: "18.1.0", AppVersion: "7.2.1", Revision: "2", Status: "failed"},
+				{Name: "nginx", Namespace: "web", Chart: "nginx", Version: "15.0.0", AppVersion: "1.25.2", Revision: "1", Status: "deployed"},
+			},
+		},
+		{
+			name:              "namespace filter",
+			inputParams:       map[string]any{"environmentId": float64(1), "namespace": "web"},
+			expectCall:        true,
+			expectedNamespace: "web",
+			mockReleases: []models.HelmRelease{
+				{Name: "nginx", Namespace: "web", Chart: "nginx", Version: "15.0.0", AppVersion: "1.25.2", Revision: "1", Status: "deployed"},
+			},
+		},
+		{
+			name:        "helm not enabled",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("helm is not enabled on environment 1"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ListHelmReleases", 1, tt.expectedNamespace).Return(tt.mockReleases, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleListHelmReleases()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var releases listResponse[models.HelmRelease]
+				err = json.Unmarshal([]byte(textContent.Text), &releases)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockReleases, releases.Items)
+				assert.Equal(t, len(tt.mockReleases), releases.TotalCount)
+				assert.False(t, releases.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleInstallHelmChart(t *testing.T) {
+	validParams := func() map[string]any {
+		return map[string]any{
+			"environmentId": float64(1),
+			"repo":          "https://charts.bitnami.com/bitnami",
+			"chart":         "nginx",
+			"version":       "15.0.0",
+			"namespace":     "web",
+			"name":          "web",
+			"values":        `{"replicaCount": 2}`,
+		}
+	}
+	validOpts := models.HelmInstallOptions{
+		Repo:      "https://charts.bitnami.com/bitnami",
+		Chart:     "nginx",
+		Version:   "15.0.0",
+		Namespace: "web",
+		Name:      "web",
+		Values:    `{"replicaCount": 2}`,
+	}
+
+	tests := []struct {
+		name         string
+		inputParams  map[string]any
+		expectCall   bool
+		expectedOpts models.HelmInstallOptions
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name:         "successful installation",
+			inputParams:  validParams(),
+			expectCall:   true,
+			expectedOpts: validOpts,
+		},
+		{
+			name: "latest version without values",
+			inputParams: func() map[string]any {
+				params := validParams()
+				delete(params, "version")
+				delete(params, "values")
+				return params
+			}(),
+			expectCall: true,
+			expectedOpts: models.HelmInstallOptions{
+				Repo:      "https://charts.bitnami.com/bitnami",
+				Chart:     "nginx",
+				Namespace: "web",
+				Name:      "web",
+			},
+		},
+		{
+			name:         "chart not found",
+			inputParams:  validParams(),
+			expectCall:   true,
+			expectedOpts: validOpts,
+			mockError:    fmt.Errorf("chart nginx@15.0.0 not found in repository https://charts.bitnami.com/bitnami"),
+			expectError:  true,
+		},
+		{
+			name: "missing chart parameter",
+			inputParams: func() map[string]any {
+				params := validParams()
+				delete(params, "chart")
+				return params
+			}(),
+			expectError: true,
+		},
+		{
+			name: "missing namespace parameter",
+			inputParams: func() map[string]any {
+				params := validParams()
+				delete(params, "namespace")
+				return params
+			}(),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("InstallHelmChart", 1, tt.expectedOpts).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleInstallHelmChart()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
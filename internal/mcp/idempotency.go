@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// stackIdempotencyTTL is how long the ID of a stack created with an idempotency key is returned
+// again instead of creating a new stack
+const stackIdempotencyTTL = 10 * time.Minute
+
+// idempotentCreation is a resource creation made with an idempotency key.
+// done is closed once the creation has completed, id is only valid after that.
+type idempotentCreation struct {
+	fingerprint string
+	done        chan struct{}
+	id          int
+	expiresAt   time.Time
+}
+
+// idempotencyStore keeps the results of the creations made with an idempotency key in memory,
+// so that a retried creation returns the resource created by the first attempt instead of
+// creating a duplicate. Only the successful creations are kept, a failed creation can be retried
+// with the same key. The results expire after a TTL and are discarded when the server restarts.
+type idempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time
+	creations map[string]*idempotentCreation
+}
+
+// newIdempotencyStore creates an idempotency store whose results expire after the given TTL
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		ttl:       ttl,
+		now:       time.Now,
+		creations: make(map[string]*idempotentCreation),
+	}
+}
+
+// do runs create unless a creation was already made with the same key, in which case the ID of
+// the resource it created is returned instead. A call made while the first creation with the key
+// is in progress waits for its result. The fingerprint identifies the content of the creation, a
+// key cannot be reused to create a different resource.
+//
+// Returns:
+//   - The ID of the resource
+//   - true when the resource was created by a previous call
+//   - An error if the key was used for a different resource or if the creation fails
+func (s *idempotencyStore) do(key, fingerprint string, create func() (int, error)) (int, bool, error) {
+	s.mu.Lock()
+
+	now := s.now()
+	for k, c := range s.creations {
+		if !c.expiresAt.IsZero() && !now.Before(c.expiresAt) {
+			delete(s.creations, k)
+		}
+	}
+
+	if existing, ok := s.creations[key]; ok {
+		s.mu.Unlock()
+
+		if existing.fingerprint != fingerprint {
+			return 0, false, fmt.Errorf("idempotency key %s was already used with different parameters", key)
+		}
+
+		<-existing.done
+		if existing.expiresAt.IsZero() {
+			return 0, false, fmt.Errorf("the creation in progress with idempotency key %s failed, retry it", key)
+		}
+		return existing.id, true, nil
+	}
+
+	creation := &idempotentCreation{
+		fingerprint: fingerprint,
+		done:        make(chan struct{}),
+	}
+	s.creations[key] = creation
+	s.mu.Unlock()
+
+	id, err := create()
+
+	s.mu.Lock()
+	if err != nil {
+		delete(s.creations, key)
+	} else {
+		creation.id = id
+		creation.expiresAt = s.now().Add(s.ttl)
+	}
+	s.mu.Unlock()
+	close(creation.done)
+
+	return id, false, err
+}
+
+// stackFingerprint identifies the parameters of a stack creation
+func stackFingerprint(name, file string, environmentGroupIds []int) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s\x00%s\x00%v", name, file, environmentGroupIds))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newIdempotencyStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	calls := 0
+	create := func() (int, error) {
+		calls++
+		return 10 + calls, nil
+	}
+
+	id, existing, err := store.do("key", "web", create)
+	require.NoError(t, err)
+	assert.Equal(t, 11, id)
+	assert.False(t, existing)
+
+	id, existing, err = store.do("key", "web", create)
+	require.NoError(t, err)
+	assert.Equal(t, 11, id)
+	assert.True(t, existing, "a creation retried with the same key should return the first result")
+	assert.Equal(t, 1, calls)
+
+	_, _, err = store.do("key", "api", create)
+	assert.ErrorContains(t, err, "idempotency key key was already used with different parameters")
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Minute)
+	id, existing, err = store.do("key", "api", create)
+	require.NoError(t, err)
+	assert.Equal(t, 12, id, "expired keys should be usable again")
+	assert.False(t, existing)
+}
+
+func TestIdempotencyStoreFailedCreation(t *testing.T) {
+	store := newIdempotencyStore(time.Minute)
+
+	_, _, err := store.do("key", "web", func() (int, error) {
+		return 0, errors.New("api error")
+	})
+	assert.ErrorContains(t, err, "api error")
+	assert.Empty(t, store.creations, "failed creations should not be kept")
+
+	id, existing, err := store.do("key", "web", func() (int, error) {
+		return 3, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, id)
+	assert.False(t, existing)
+}
+
+func TestIdempotencyStoreConcurrentCreations(t *testing.T) {
+	store := newIdempotencyStore(time.Minute)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	calls := 0
+
+	create := func() (int, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		close(started)
+		<-release
+		return 7, nil
+	}
+
+	first := make(chan int)
+	go func() {
+		id, _, _ := store.do("key", "web", create)
+		first <- id
+	}()
+	<-started
+
+	second := make(chan bool)
+	go func() {
+		id, existing, err := store.do("key", "web", create)
+		second <- err == nil && existing && id == 7
+	}()
+
+	close(release)
+	assert.Equal(t, 7, <-first)
+	assert.True(t, <-second, "a concurrent creation with the same key should wait for the first one")
+	assert.Equal(t, 1, calls)
+}
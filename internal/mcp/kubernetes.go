@@ -15,6 +15,7 @@ import (
 
 func (s *PortainerMCPServer) AddKubernetesProxyFeatures() {
 	s.addToolIfExists(ToolKubernetesProxyStripped, s.HandleKubernetesProxyStripped())
+	s.addToolIfExists(ToolGetKubeconfig, s.HandleGetKubeconfig())
 
 	if !s.readOnly {
 		s.addToolIfExists(ToolKubernetesProxy, s.HandleKubernetesProxy())
@@ -64,7 +65,7 @@ func (s *PortainerMCPServer) HandleKubernetesProxyStripped() server.ToolHandlerF
 			Headers:       headersMap,
 		}
 
-		response, err := s.cli.ProxyKubernetesRequest(opts)
+		response, err := s.client(ctx).ProxyKubernetesRequest(opts)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to send Kubernetes API request", err), nil
 		}
@@ -138,7 +139,7 @@ func (s *PortainerMCPServer) HandleKubernetesProxy() server.ToolHandlerFunc {
 			opts.Body = strings.NewReader(body)
 		}
 
-		response, err := s.cli.ProxyKubernetesRequest(opts)
+		response, err := s.client(ctx).ProxyKubernetesRequest(opts)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to send Kubernetes API request", err), nil
 		}
@@ -151,3 +152,24 @@ func (s *PortainerMCPServer) HandleKubernetesProxy() server.ToolHandlerFunc {
 		return mcp.NewToolResultText(string(responseBody)), nil
 	}
 }
+
+// HandleGetKubeconfig returns the kubeconfig of a Kubernetes environment.
+// The kubeconfig embeds a token tied to the Portainer identity of the server, it is only
+// returned as the tool result and never logged or included in error messages.
+func (s *PortainerMCPServer) HandleGetKubeconfig() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		kubeconfig, err := s.client(ctx).GetKubeconfig(environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get kubeconfig", err), nil
+		}
+
+		return mcp.NewToolResultText(kubeconfig), nil
+	}
+}
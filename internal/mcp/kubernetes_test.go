@@ -606,3 +606,72 @@ func TestHandleKubernetesProxyStripped_ClientInteraction(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGetKubeconfig(t *testing.T) {
+	const kubeconfig = "apiVersion: v1\nkind: Config\ncurrent-context: portainer-ctx-prod\n"
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   errors.New("environment 1 is a docker-local environment, kubeconfig is only available for Kubernetes environments"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockKubeconfig := kubeconfig
+				if tt.mockError != nil {
+					mockKubeconfig = ""
+				}
+				mockClient.On("GetKubeconfig", 1).Return(mockKubeconfig, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetKubeconfig()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, kubeconfig, textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package mcp
+
+// listResponse is the envelope of the results of the list tools. Items holds the listed objects in
+// the same shape as the other tools return them, TotalCount is the number of objects matching the
+// request and HasMore reports that some of them were left out of the response.
+type listResponse[T any] struct {
+	Items      []T  `json:"items"`
+	TotalCount int  `json:"totalCount"`
+	HasMore    bool `json:"hasMore"`
+}
+
+// newListResponse wraps a complete listing in a listResponse.
+// A nil slice is returned as an empty list so that the items are never null.
+func newListResponse[T any](items []T) listResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return listResponse[T]{
+		Items:      items,
+		TotalCount: len(items),
+	}
+}
+
+// newLimitedListResponse wraps the first limit items of a complete listing in a listResponse, HasMore
+// reports that items were left out. A limit of 0 keeps all the items.
+func newLimitedListResponse[T any](items []T, limit int) listResponse[T] {
+	response := newListResponse(items)
+	if limit > 0 && len(response.Items) > limit {
+		response.Items = response.Items[:limit]
+		response.HasMore = true
+	}
+
+	return response
+}
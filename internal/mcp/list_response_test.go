@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewListResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []models.EnvironmentTag
+		expected string
+	}{
+		{
+			name:     "items",
+			items:    []models.EnvironmentTag{{ID: 1, Name: "prod", EnvironmentIds: []int{1}}, {ID: 2, Name: "dev", EnvironmentIds: []int{}}},
+			expected: `{"items":[{"id":1,"name":"prod","environment_ids":[1]},{"id":2,"name":"dev","environment_ids":[]}],"totalCount":2,"hasMore":false}`,
+		},
+		{
+			name:     "nil items are an empty list",
+			expected: `{"items":[],"totalCount":0,"hasMore":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(newListResponse(tt.items))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestNewLimitedListResponse(t *testing.T) {
+	tags := []models.EnvironmentTag{{ID: 1, Name: "prod"}, {ID: 2, Name: "dev"}, {ID: 3, Name: "test"}}
+
+	tests := []struct {
+		name            string
+		items           []models.EnvironmentTag
+		limit           int
+		expectedItems   []models.EnvironmentTag
+		expectedHasMore bool
+	}{
+		{
+			name:            "items left out",
+			items:           tags,
+			limit:           2,
+			expectedItems:   tags[:2],
+			expectedHasMore: true,
+		},
+		{
+			name:          "limit equal to the items",
+			items:         tags,
+			limit:         3,
+			expectedItems: tags,
+		},
+		{
+			name:          "no limit",
+			items:         tags,
+			expectedItems: tags,
+		},
+		{
+			name:          "nil items are an empty list",
+			limit:         2,
+			expectedItems: []models.EnvironmentTag{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := newLimitedListResponse(tt.items, tt.limit)
+			assert.Equal(t, tt.expectedItems, response.Items)
+			assert.Equal(t, len(tt.items), response.TotalCount)
+			assert.Equal(t, tt.expectedHasMore, response.HasMore)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package mcp
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/mock"
@@ -69,6 +71,47 @@ func (m *MockPortainerClient) GetEnvironments() ([]models.Environment, error) {
 	return args.Get(0).([]models.Environment), args.Error(1)
 }
 
+func (m *MockPortainerClient) GetEnvironmentMetadata(id int) (map[string]string, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateEnvironmentMetadata(id int, metadata map[string]string) error {
+	args := m.Called(id, metadata)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetFleetStats() (models.FleetStats, error) {
+	args := m.Called()
+	return args.Get(0).(models.FleetStats), args.Error(1)
+}
+
+func (m *MockPortainerClient) FindEnvironmentsByImage(image string) ([]models.Environment, error) {
+	args := m.Called(image)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Environment), args.Error(1)
+}
+
+func (m *MockPortainerClient) DetachEnvironment(id int) (models.EnvironmentDetachReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.EnvironmentDetachReport), args.Error(1)
+}
+
+func (m *MockPortainerClient) ExportEnvironmentAccess(id int) (models.AccessSnapshot, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.AccessSnapshot), args.Error(1)
+}
+
+func (m *MockPortainerClient) RestoreEnvironmentAccess(id int, snapshot models.AccessSnapshot) error {
+	args := m.Called(id, snapshot)
+	return args.Error(0)
+}
+
 func (m *MockPortainerClient) UpdateEnvironmentTags(id int, tagIds []int) error {
 	args := m.Called(id, tagIds)
 	return args.Error(0)
@@ -84,6 +127,108 @@ func (m *MockPortainerClient) UpdateEnvironmentTeamAccesses(id int, teamAccesses
 	return args.Error(0)
 }
 
+func (m *MockPortainerClient) UpdateEnvironmentsUserAccessesBulk(envIds []int, userAccesses map[int]string) error {
+	args := m.Called(envIds, userAccesses)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) UpdateEnvironmentsTeamAccessesBulk(envIds []int, teamAccesses map[int]string) error {
+	args := m.Called(envIds, teamAccesses)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetEnvironmentGPUs(id int) (models.EnvironmentGPUSettings, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.EnvironmentGPUSettings), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateEnvironmentGPUs(id int, gpus []models.GPUConfig) error {
+	args := m.Called(id, gpus)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) PlanEnvironmentConfig(id int, desired models.EnvironmentDesiredState) (models.ConfigDiff, error) {
+	args := m.Called(id, desired)
+	return args.Get(0).(models.ConfigDiff), args.Error(1)
+}
+
+func (m *MockPortainerClient) ApplyEnvironmentConfig(id int, desired models.EnvironmentDesiredState) (models.ConfigDiff, error) {
+	args := m.Called(id, desired)
+	return args.Get(0).(models.ConfigDiff), args.Error(1)
+}
+
+func (m *MockPortainerClient) TriggerEnvironmentSnapshot(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetEnvironmentSnapshotTime(id int) (time.Time, error) {
+	args := m.Called(id)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockPortainerClient) WaitForEnvironmentSnapshot(ctx context.Context, id int, after time.Time, timeout time.Duration) (time.Time, error) {
+	args := m.Called(ctx, id, after, timeout)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockPortainerClient) CompareEnvironments(id1, id2 int) (models.EnvironmentComparison, error) {
+	args := m.Called(id1, id2)
+	return args.Get(0).(models.EnvironmentComparison), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetEnvironmentRegistries(environmentId int) ([]models.EnvironmentRegistry, error) {
+	args := m.Called(environmentId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EnvironmentRegistry), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateEnvironmentRegistries(environmentId int, registryIds []int) error {
+	args := m.Called(environmentId, registryIds)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetEdgeAgentStatus() ([]models.EdgeAgentStatus, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EdgeAgentStatus), args.Error(1)
+}
+
+func (m *MockPortainerClient) TestEnvironmentConnectivity(id int) (models.ConnectivityResult, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.ConnectivityResult), args.Error(1)
+}
+
+func (m *MockPortainerClient) CheckFleetConnectivity() ([]models.ConnectivityResult, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ConnectivityResult), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetEnvironmentSnapshots() ([]models.EnvironmentSnapshot, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EnvironmentSnapshot), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetEnvironmentPublicURL(id int) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateEnvironmentPublicURL(id int, publicURL string) error {
+	args := m.Called(id, publicURL)
+	return args.Error(0)
+}
+
 // Environment Group methods
 
 func (m *MockPortainerClient) GetEnvironmentGroups() ([]models.Group, error) {
@@ -99,6 +244,14 @@ func (m *MockPortainerClient) CreateEnvironmentGroup(name string, environmentIds
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockPortainerClient) CreateEnvironmentGroupsBulk(specs []models.GroupSpec) ([]int, error) {
+	args := m.Called(specs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
 func (m *MockPortainerClient) UpdateEnvironmentGroupName(id int, name string) error {
 	args := m.Called(id, name)
 	return args.Error(0)
@@ -129,6 +282,11 @@ func (m *MockPortainerClient) CreateAccessGroup(name string, environmentIds []in
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockPortainerClient) UpdateAccessGroup(id int, update models.AccessGroupUpdate) error {
+	args := m.Called(id, update)
+	return args.Error(0)
+}
+
 func (m *MockPortainerClient) UpdateAccessGroupName(id int, name string) error {
 	args := m.Called(id, name)
 	return args.Error(0)
@@ -154,6 +312,16 @@ func (m *MockPortainerClient) RemoveEnvironmentFromAccessGroup(id int, environme
 	return args.Error(0)
 }
 
+func (m *MockPortainerClient) ExportAccessReport() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetGroupInheritedAccess(groupId int) (models.InheritedAccess, error) {
+	args := m.Called(groupId)
+	return args.Get(0).(models.InheritedAccess), args.Error(1)
+}
+
 // Stack methods
 
 func (m *MockPortainerClient) GetStacks() ([]models.Stack, error) {
@@ -169,6 +337,11 @@ func (m *MockPortainerClient) GetStackFile(id int) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockPortainerClient) CreateStackFromURL(name, composeURL string, environmentGroupIds []int) (int, error) {
+	args := m.Called(name, composeURL, environmentGroupIds)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockPortainerClient) CreateStack(name string, file string, environmentGroupIds []int) (int, error) {
 	args := m.Called(name, file, environmentGroupIds)
 	return args.Int(0), args.Error(1)
@@ -179,6 +352,95 @@ func (m *MockPortainerClient) UpdateStack(id int, file string, environmentGroupI
 	return args.Error(0)
 }
 
+func (m *MockPortainerClient) CloneStack(sourceStackId int, newName string, targetGroupIds []int, imageReplacements map[string]string) (int, error) {
+	args := m.Called(sourceStackId, newName, targetGroupIds, imageReplacements)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPortainerClient) FindStacksByImage(imagePattern string) ([]models.Stack, error) {
+	args := m.Called(imagePattern)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Stack), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetRecentlyChangedStacks(limit int) ([]models.Stack, error) {
+	args := m.Called(limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Stack), args.Error(1)
+}
+
+func (m *MockPortainerClient) TestStackFile(file string, environmentId int) (models.StackTestResult, error) {
+	args := m.Called(file, environmentId)
+	return args.Get(0).(models.StackTestResult), args.Error(1)
+}
+
+func (m *MockPortainerClient) FormatComposeFile(file string) (string, error) {
+	args := m.Called(file)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetStackGitConfig(stackId int) (models.GitConfig, error) {
+	args := m.Called(stackId)
+	return args.Get(0).(models.GitConfig), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetStackGitDrift(stackId int) (models.GitDrift, error) {
+	args := m.Called(stackId)
+	return args.Get(0).(models.GitDrift), args.Error(1)
+}
+
+func (m *MockPortainerClient) RedeployStackFromGit(stackId int, pullImage bool) error {
+	args := m.Called(stackId, pullImage)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) UpdateStackAutoUpdate(stackId int, opts models.AutoUpdateOptions) (models.AutoUpdateSettings, error) {
+	args := m.Called(stackId, opts)
+	return args.Get(0).(models.AutoUpdateSettings), args.Error(1)
+}
+
+func (m *MockPortainerClient) ExportAllStacks() (map[string]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetStackAccess(stackId int) (models.ResourceAccess, error) {
+	args := m.Called(stackId)
+	return args.Get(0).(models.ResourceAccess), args.Error(1)
+}
+
+func (m *MockPortainerClient) DeleteStacksMatching(filter models.StackFilter) (models.BulkDeleteResult, error) {
+	args := m.Called(filter)
+	return args.Get(0).(models.BulkDeleteResult), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetStackHealth(stackId int) (models.StackHealth, error) {
+	args := m.Called(stackId)
+	return args.Get(0).(models.StackHealth), args.Error(1)
+}
+
+func (m *MockPortainerClient) DetectStackDrift(stackId, environmentId int) (models.DriftReport, error) {
+	args := m.Called(stackId, environmentId)
+	return args.Get(0).(models.DriftReport), args.Error(1)
+}
+
+func (m *MockPortainerClient) RestartStack(stackId, environmentId int) (models.StackRestartReport, error) {
+	args := m.Called(stackId, environmentId)
+	return args.Get(0).(models.StackRestartReport), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetStackDeploymentLogs(stackId, environmentId int) (string, error) {
+	args := m.Called(stackId, environmentId)
+	return args.String(0), args.Error(1)
+}
+
 // Team methods
 
 func (m *MockPortainerClient) CreateTeam(name string) (int, error) {
@@ -204,6 +466,21 @@ func (m *MockPortainerClient) UpdateTeamMembers(id int, userIds []int) error {
 	return args.Error(0)
 }
 
+func (m *MockPortainerClient) UpdateTeamMemberships(id int, members map[int]string) error {
+	args := m.Called(id, members)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetTeamAccessOverview(teamId int) (models.TeamAccessOverview, error) {
+	args := m.Called(teamId)
+	return args.Get(0).(models.TeamAccessOverview), args.Error(1)
+}
+
+func (m *MockPortainerClient) ApplyTeamAccess(teamId int, desired models.TeamAccessDesiredState) (models.TeamAccessOverview, error) {
+	args := m.Called(teamId, desired)
+	return args.Get(0).(models.TeamAccessOverview), args.Error(1)
+}
+
 // User methods
 
 func (m *MockPortainerClient) GetUsers() ([]models.User, error) {
@@ -214,11 +491,34 @@ func (m *MockPortainerClient) GetUsers() ([]models.User, error) {
 	return args.Get(0).([]models.User), args.Error(1)
 }
 
+func (m *MockPortainerClient) GetCurrentUser() (models.CurrentUser, error) {
+	args := m.Called()
+	return args.Get(0).(models.CurrentUser), args.Error(1)
+}
+
 func (m *MockPortainerClient) UpdateUserRole(id int, role string) error {
 	args := m.Called(id, role)
 	return args.Error(0)
 }
 
+func (m *MockPortainerClient) UpdateUserRolesBulk(updates map[int]string) error {
+	args := m.Called(updates)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetUserPreferences(userId int) (models.UserPreferences, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return models.UserPreferences{}, args.Error(1)
+	}
+	return args.Get(0).(models.UserPreferences), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateUserPreferences(userId int, prefs models.UserPreferences) error {
+	args := m.Called(userId, prefs)
+	return args.Error(0)
+}
+
 // Settings methods
 
 func (m *MockPortainerClient) GetSettings() (models.PortainerSettings, error) {
@@ -229,6 +529,54 @@ func (m *MockPortainerClient) GetSettings() (models.PortainerSettings, error) {
 	return args.Get(0).(models.PortainerSettings), args.Error(1)
 }
 
+func (m *MockPortainerClient) GetPublicSettings() (models.PublicSettings, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return models.PublicSettings{}, args.Error(1)
+	}
+	return args.Get(0).(models.PublicSettings), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetAuthSettings() (models.AuthSettings, error) {
+	args := m.Called()
+	return args.Get(0).(models.AuthSettings), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateAuthSettings(settings models.AuthSettings) error {
+	args := m.Called(settings)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetSSLSettings() (models.SSLSettings, error) {
+	args := m.Called()
+	return args.Get(0).(models.SSLSettings), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateSSLSettings(settings models.SSLSettings) error {
+	args := m.Called(settings)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetBrandingSettings() (models.Branding, error) {
+	args := m.Called()
+	return args.Get(0).(models.Branding), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateBrandingSettings(branding models.Branding) error {
+	args := m.Called(branding)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) UpdateSessionTimeout(sessionTimeout string) error {
+	args := m.Called(sessionTimeout)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetLicenseInfo() (models.LicenseInfo, error) {
+	args := m.Called()
+	return args.Get(0).(models.LicenseInfo), args.Error(1)
+}
+
 func (m *MockPortainerClient) GetVersion() (string, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -246,6 +594,147 @@ func (m *MockPortainerClient) ProxyDockerRequest(opts models.DockerProxyRequestO
 	return args.Get(0).(*http.Response), args.Error(1)
 }
 
+func (m *MockPortainerClient) GetDockerInfo(environmentId int) (models.DockerInfo, error) {
+	args := m.Called(environmentId)
+	return args.Get(0).(models.DockerInfo), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetContainerChanges(environmentId int, containerId string) ([]models.FilesystemChange, error) {
+	args := m.Called(environmentId, containerId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.FilesystemChange), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetContainerProcesses(environmentId int, containerId string) (models.ProcessList, error) {
+	args := m.Called(environmentId, containerId)
+	return args.Get(0).(models.ProcessList), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetContainerHealth(environmentId int, containerId string) (models.HealthStatus, error) {
+	args := m.Called(environmentId, containerId)
+	return args.Get(0).(models.HealthStatus), args.Error(1)
+}
+
+func (m *MockPortainerClient) CheckImageUpdates(environmentId int) ([]models.ImageUpdateStatus, error) {
+	args := m.Called(environmentId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ImageUpdateStatus), args.Error(1)
+}
+
+func (m *MockPortainerClient) FindOrphanedResources(environmentId int) (models.OrphanReport, error) {
+	args := m.Called(environmentId)
+	return args.Get(0).(models.OrphanReport), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetContainerMounts(environmentId int, containerId string) ([]models.Mount, error) {
+	args := m.Called(environmentId, containerId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Mount), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetFileFromContainer(environmentId int, containerId, path string) ([]byte, error) {
+	args := m.Called(environmentId, containerId, path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetContainerLogs(environmentId int, containerId string, tail int) ([]models.ContainerLogLine, error) {
+	args := m.Called(environmentId, containerId, tail)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ContainerLogLine), args.Error(1)
+}
+
+func (m *MockPortainerClient) FollowContainerLogs(ctx context.Context, environmentId int, containerId string, tail int, handle func(models.ContainerLogLine) error) error {
+	args := m.Called(ctx, environmentId, containerId, tail, handle)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) PutFileInContainer(environmentId int, containerId, path string, content []byte) error {
+	args := m.Called(environmentId, containerId, path, content)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) UpdateContainerResources(environmentId int, containerId string, limits models.ResourceLimits) error {
+	args := m.Called(environmentId, containerId, limits)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) UpdateContainerRestartPolicy(environmentId int, containerId string, policy models.RestartPolicy) error {
+	args := m.Called(environmentId, containerId, policy)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) RecreateContainer(environmentId int, containerId string, pullLatest bool) error {
+	args := m.Called(environmentId, containerId, pullLatest)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) ConnectContainerToNetwork(environmentId int, networkId, containerId string) error {
+	args := m.Called(environmentId, networkId, containerId)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) DisconnectContainerFromNetwork(environmentId int, networkId, containerId string, force bool) error {
+	args := m.Called(environmentId, networkId, containerId, force)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) PruneContainers(environmentId int, labels []string) (models.PruneReport, error) {
+	args := m.Called(environmentId, labels)
+	return args.Get(0).(models.PruneReport), args.Error(1)
+}
+
+func (m *MockPortainerClient) PruneNetworks(environmentId int, labels []string) (models.PruneReport, error) {
+	args := m.Called(environmentId, labels)
+	return args.Get(0).(models.PruneReport), args.Error(1)
+}
+
+func (m *MockPortainerClient) PruneBuildCache(environmentId int, labels []string) (models.PruneReport, error) {
+	args := m.Called(environmentId, labels)
+	return args.Get(0).(models.PruneReport), args.Error(1)
+}
+
+func (m *MockPortainerClient) ListContainers(environmentId int, filter models.ContainerFilter) ([]models.Container, error) {
+	args := m.Called(environmentId, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Container), args.Error(1)
+}
+
+// Swarm methods
+
+func (m *MockPortainerClient) ListSwarmServices(environmentId int) ([]models.SwarmService, error) {
+	args := m.Called(environmentId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SwarmService), args.Error(1)
+}
+
+func (m *MockPortainerClient) ListSwarmTasks(environmentId int, serviceId string) ([]models.SwarmTask, error) {
+	args := m.Called(environmentId, serviceId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SwarmTask), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateSwarmService(environmentId int, serviceId string, opts models.SwarmServiceUpdate) error {
+	args := m.Called(environmentId, serviceId, opts)
+	return args.Error(0)
+}
+
 // Kubernetes Proxy methods
 func (m *MockPortainerClient) ProxyKubernetesRequest(opts models.KubernetesProxyRequestOptions) (*http.Response, error) {
 	args := m.Called(opts)
@@ -254,3 +743,117 @@ func (m *MockPortainerClient) ProxyKubernetesRequest(opts models.KubernetesProxy
 	}
 	return args.Get(0).(*http.Response), args.Error(1)
 }
+
+func (m *MockPortainerClient) GetKubeconfig(environmentId int) (string, error) {
+	args := m.Called(environmentId)
+	return args.String(0), args.Error(1)
+}
+
+// Helm methods
+func (m *MockPortainerClient) ListHelmReleases(environmentId int, namespace string) ([]models.HelmRelease, error) {
+	args := m.Called(environmentId, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.HelmRelease), args.Error(1)
+}
+
+func (m *MockPortainerClient) InstallHelmChart(environmentId int, opts models.HelmInstallOptions) error {
+	args := m.Called(environmentId, opts)
+	return args.Error(0)
+}
+
+// Activity Log methods
+func (m *MockPortainerClient) GetActivityLogs(opts models.ActivityLogFilter) (models.ActivityLogPage, error) {
+	args := m.Called(opts)
+	if args.Get(0) == nil {
+		return models.ActivityLogPage{}, args.Error(1)
+	}
+	return args.Get(0).(models.ActivityLogPage), args.Error(1)
+}
+
+// Resource Control methods
+func (m *MockPortainerClient) GetResourceControl(environmentId int, resourceType, resourceId string) (models.ResourceControl, error) {
+	args := m.Called(environmentId, resourceType, resourceId)
+	return args.Get(0).(models.ResourceControl), args.Error(1)
+}
+
+func (m *MockPortainerClient) UpdateResourceControl(resourceControlId int, opts models.ResourceControlOptions) error {
+	args := m.Called(resourceControlId, opts)
+	return args.Error(0)
+}
+
+// Edge Configuration methods
+func (m *MockPortainerClient) GetEdgeConfigurations() ([]models.EdgeConfig, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.EdgeConfig), args.Error(1)
+}
+
+func (m *MockPortainerClient) CreateEdgeConfiguration(config models.EdgeConfig, files map[string]string) error {
+	args := m.Called(config, files)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) DeleteEdgeConfiguration(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Custom Template methods
+func (m *MockPortainerClient) GetCustomTemplates() ([]models.CustomTemplate, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CustomTemplate), args.Error(1)
+}
+
+func (m *MockPortainerClient) CreateCustomTemplate(template models.CustomTemplate) (int, error) {
+	args := m.Called(template)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPortainerClient) DeleteCustomTemplate(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) DeployCustomTemplate(templateId int, name string, environmentGroupIds []int, variables map[string]string) (int, error) {
+	args := m.Called(templateId, name, environmentGroupIds, variables)
+	return args.Int(0), args.Error(1)
+}
+
+// Schedule methods
+func (m *MockPortainerClient) GetSchedules() ([]models.Schedule, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Schedule), args.Error(1)
+}
+
+func (m *MockPortainerClient) CreateSchedule(schedule models.Schedule) (models.Schedule, error) {
+	args := m.Called(schedule)
+	return args.Get(0).(models.Schedule), args.Error(1)
+}
+
+func (m *MockPortainerClient) DeleteSchedule(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPortainerClient) GetScheduleResults(scheduleId int) ([]models.ScheduleResult, error) {
+	args := m.Called(scheduleId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ScheduleResult), args.Error(1)
+}
+
+func (m *MockPortainerClient) GetScheduleResultLog(scheduleId, environmentId int) (models.ScheduleResult, error) {
+	args := m.Called(scheduleId, environmentId)
+	return args.Get(0).(models.ScheduleResult), args.Error(1)
+}
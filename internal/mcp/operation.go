@@ -0,0 +1,168 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+// operation is a long-running tool call in progress, such as a wait for an environment snapshot
+type operation struct {
+	id          string
+	tool        string
+	description string
+	startedAt   time.Time
+	cancel      context.CancelFunc
+}
+
+// operationInfo is the description of an operation in progress returned by the listOperations tool
+type operationInfo struct {
+	ID             string `json:"id"`
+	Tool           string `json:"tool"`
+	Description    string `json:"description"`
+	StartedAt      string `json:"started_at"`
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+}
+
+// operationRegistry keeps track of the long-running tool calls in progress so that they can be
+// listed and cancelled. Portainer has no API to follow or cancel its own asynchronous jobs, only
+// the waits done by this server are registered and cancelling one does not undo what was already
+// sent to Portainer.
+type operationRegistry struct {
+	mu         sync.Mutex
+	now        func() time.Time
+	operations map[string]operation
+}
+
+// newOperationRegistry creates an empty operation registry
+func newOperationRegistry() *operationRegistry {
+	return &operationRegistry{
+		now:        time.Now,
+		operations: make(map[string]operation),
+	}
+}
+
+// start registers a new operation and returns a context derived from ctx that is cancelled when the
+// operation is cancelled. The returned function must be called when the operation ends, it removes
+// the operation from the registry and releases its context.
+func (r *operationRegistry) start(ctx context.Context, tool, description string) (context.Context, func(), error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate operation ID: %w", err)
+	}
+	id := hex.EncodeToString(buf)
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.mu.Lock()
+	r.operations[id] = operation{
+		id:          id,
+		tool:        tool,
+		description: description,
+		startedAt:   r.now(),
+		cancel:      cancel,
+	}
+	r.mu.Unlock()
+
+	done := func() {
+		r.mu.Lock()
+		delete(r.operations, id)
+		r.mu.Unlock()
+		cancel()
+	}
+
+	return ctx, done, nil
+}
+
+// list returns the operations in progress, the oldest first
+func (r *operationRegistry) list() []operationInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	operations := make([]operation, 0, len(r.operations))
+	for _, op := range r.operations {
+		operations = append(operations, op)
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].startedAt.Equal(operations[j].startedAt) {
+			return operations[i].id < operations[j].id
+		}
+		return operations[i].startedAt.Before(operations[j].startedAt)
+	})
+
+	infos := make([]operationInfo, 0, len(operations))
+	for _, op := range operations {
+		infos = append(infos, operationInfo{
+			ID:             op.id,
+			Tool:           op.tool,
+			Description:    op.description,
+			StartedAt:      op.startedAt.UTC().Format(time.RFC3339),
+			ElapsedSeconds: int(now.Sub(op.startedAt).Seconds()),
+		})
+	}
+
+	return infos
+}
+
+// cancel cancels the operation identified by id, unknown and already finished operations are rejected
+func (r *operationRegistry) cancel(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, ok := r.operations[id]
+	if !ok {
+		return fmt.Errorf("operation %s not found, it may have already finished", id)
+	}
+
+	op.cancel()
+	delete(r.operations, id)
+
+	return nil
+}
+
+// AddOperationFeatures registers the tools listing and cancelling the long-running tool calls in progress
+func (s *PortainerMCPServer) AddOperationFeatures() {
+	s.addToolIfExists(ToolListOperations, s.HandleListOperations())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolCancelOperation, s.HandleCancelOperation())
+	}
+}
+
+func (s *PortainerMCPServer) HandleListOperations() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := json.Marshal(newListResponse(s.operations.list()))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal operations", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCancelOperation() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetString("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		if err := s.operations.cancel(id); err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to cancel operation", err), nil
+		}
+
+		return mcp.NewToolResultText("Operation cancelled successfully"), nil
+	}
+}
@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationRegistry(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	registry := newOperationRegistry()
+	registry.now = func() time.Time { return now }
+
+	firstCtx, firstDone, err := registry.start(context.Background(), ToolTriggerEnvironmentSnapshot, "Waiting for a new snapshot of environment 1")
+	require.NoError(t, err)
+	defer firstDone()
+
+	now = now.Add(5 * time.Second)
+	secondCtx, secondDone, err := registry.start(context.Background(), ToolTriggerEnvironmentSnapshot, "Waiting for a new snapshot of environment 2")
+	require.NoError(t, err)
+
+	now = now.Add(5 * time.Second)
+	operations := registry.list()
+	require.Len(t, operations, 2)
+	assert.Equal(t, "Waiting for a new snapshot of environment 1", operations[0].Description)
+	assert.Equal(t, ToolTriggerEnvironmentSnapshot, operations[0].Tool)
+	assert.Equal(t, "2023-11-14T22:13:20Z", operations[0].StartedAt)
+	assert.Equal(t, 10, operations[0].ElapsedSeconds)
+	assert.Equal(t, "Waiting for a new snapshot of environment 2", operations[1].Description)
+	assert.Equal(t, 5, operations[1].ElapsedSeconds)
+
+	t.Run("cancel", func(t *testing.T) {
+		assert.NoError(t, registry.cancel(operations[0].ID))
+		assert.ErrorIs(t, firstCtx.Err(), context.Canceled)
+		assert.NoError(t, secondCtx.Err())
+		assert.Len(t, registry.list(), 1)
+
+		assert.ErrorContains(t, registry.cancel(operations[0].ID), "not found")
+	})
+
+	t.Run("done", func(t *testing.T) {
+		secondDone()
+		assert.ErrorIs(t, secondCtx.Err(), context.Canceled)
+		assert.Empty(t, registry.list())
+	})
+
+	t.Run("parent context cancelled", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		ctx, done, err := registry.start(parent, ToolTriggerEnvironmentSnapshot, "Waiting for a new snapshot of environment 3")
+		require.NoError(t, err)
+		defer done()
+
+		cancel()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
+
+func TestHandleListOperations(t *testing.T) {
+	server := &PortainerMCPServer{
+		operations: newOperationRegistry(),
+	}
+
+	handler := server.HandleListOperations()
+
+	result, err := handler(context.Background(), CreateMCPRequest(map[string]any{}))
+	assert.NoError(t, err)
+	assert.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(mcp.TextContent)
+	assert.True(t, ok, "Result content should be mcp.TextContent")
+	assert.False(t, result.IsError)
+	assert.JSONEq(t, `{"items":[],"totalCount":0,"hasMore":false}`, textContent.Text)
+
+	_, done, err := server.operations.start(context.Background(), ToolTriggerEnvironmentSnapshot, "Waiting for a new snapshot of environment 1")
+	require.NoError(t, err)
+	defer done()
+
+	result, err = handler(context.Background(), CreateMCPRequest(map[string]any{}))
+	assert.NoError(t, err)
+	assert.Len(t, result.Content, 1)
+	textContent, ok = result.Content[0].(mcp.TextContent)
+	assert.True(t, ok, "Result content should be mcp.TextContent")
+	assert.False(t, result.IsError)
+
+	var operations listResponse[operationInfo]
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &operations))
+	require.Len(t, operations.Items, 1)
+	assert.Equal(t, 1, operations.TotalCount)
+	assert.Equal(t, ToolTriggerEnvironmentSnapshot, operations.Items[0].Tool)
+	assert.NotEmpty(t, operations.Items[0].ID)
+}
+
+func TestHandleCancelOperation(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		useStarted  bool
+		expectError bool
+	}{
+		{
+			name:       "successful cancellation",
+			useStarted: true,
+		},
+		{
+			name:        "unknown operation",
+			inputParams: map[string]any{"id": "0123456789abcdef"},
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &PortainerMCPServer{
+				operations: newOperationRegistry(),
+			}
+
+			opCtx, done, err := server.operations.start(context.Background(), ToolTriggerEnvironmentSnapshot, "Waiting for a new snapshot of environment 1")
+			require.NoError(t, err)
+			defer done()
+
+			params := tt.inputParams
+			if tt.useStarted {
+				params = map[string]any{"id": server.operations.list()[0].ID}
+			}
+
+			handler := server.HandleCancelOperation()
+			result, err := handler(context.Background(), CreateMCPRequest(params))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.NotEmpty(t, textContent.Text, "Error message should not be empty")
+				assert.NoError(t, opCtx.Err())
+			} else {
+				assert.False(t, result.IsError)
+				assert.Equal(t, "Operation cancelled successfully", textContent.Text)
+				assert.ErrorIs(t, opCtx.Err(), context.Canceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+// forbiddenPattern matches the errors of the Portainer API and of the Docker and Kubernetes proxies
+// answering with a 403 status code, e.g. "[DELETE /endpoints/{id}][403] endpointDeleteForbidden" or
+// "unexpected status 403: access denied to resource"
+var forbiddenPattern = regexp.MustCompile(`\[403\]|status 403\b`)
+
+// adminOnlyTools are the tools whose operations Portainer only allows to administrators
+var adminOnlyTools = map[string]bool{
+	ToolCreateEnvironmentGroup:             true,
+	ToolUpdateEnvironmentGroup:             true,
+	ToolUpdateEnvironmentGroupName:         true,
+	ToolUpdateEnvironmentGroupEnvironments: true,
+	ToolUpdateEnvironmentGroupTags:         true,
+	ToolCreateEnvironmentGroupsBulk:        true,
+	ToolCreateAccessGroup:                  true,
+	ToolUpdateAccessGroup:                  true,
+	ToolUpdateAccessGroupName:              true,
+	ToolUpdateAccessGroupUserAccesses:      true,
+	ToolUpdateAccessGroupTeamAccesses:      true,
+	ToolAddEnvironmentToAccessGroup:        true,
+	ToolRemoveEnvironmentFromAccessGroup:   true,
+	ToolUpdateEnvironment:                  true,
+	ToolUpdateEnvironmentTags:              true,
+	ToolUpdateEnvironmentUserAccesses:      true,
+	ToolUpdateEnvironmentTeamAccesses:      true,
+	ToolUpdateEnvironmentsUserAccessesBulk: true,
+	ToolUpdateEnvironmentsTeamAccessesBulk: true,
+	ToolUpdateEnvironmentRegistries:        true,
+	ToolUpdateEnvironmentMetadata:          true,
+	ToolRestoreEnvironmentAccess:           true,
+	ToolDetachEnvironment:                  true,
+	ToolCreateEnvironmentTag:               true,
+	ToolCreateTeam:                         true,
+	ToolApplyTeamAccess:                    true,
+	ToolUpdateUserRole:                     true,
+	ToolUpdateUserRolesBulk:                true,
+	ToolGetSettings:                        true,
+	ToolGetAuthSettings:                    true,
+	ToolUpdateAuthSettings:                 true,
+	ToolGetSSLSettings:                     true,
+	ToolUpdateSSLSettings:                  true,
+	ToolGetBrandingSettings:                true,
+	ToolUpdateBrandingSettings:             true,
+	ToolListActivityLogs:                   true,
+	ToolExportAccessReport:                 true,
+	ToolGetLicenseInfo:                     true,
+}
+
+// withPermissionHints wraps a tool handler so that its errors caused by the Portainer API answering with a
+// 403 status code explain the privilege the API token is missing. The user the token belongs to is read
+// with GetCurrentUser once the operation failed: the hint tells apart the admin-only operations, the
+// operations on an environment, identified by the environmentId parameter, and the tokens of administrators.
+func (s *PortainerMCPServer) withPermissionHints(toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || !result.IsError {
+			return result, err
+		}
+
+		for i, content := range result.Content {
+			textContent, ok := content.(mcp.TextContent)
+			if !ok || !forbiddenPattern.MatchString(textContent.Text) {
+				continue
+			}
+
+			textContent.Text = fmt.Sprintf("%s\nPermission denied: %s", textContent.Text, s.permissionHint(ctx, toolName, request))
+			result.Content[i] = textContent
+			break
+		}
+
+		return result, nil
+	}
+}
+
+// permissionHint describes the privilege missing for a tool call forbidden by Portainer
+func (s *PortainerMCPServer) permissionHint(ctx context.Context, toolName string, request mcp.CallToolRequest) string {
+	user, err := s.client(ctx).GetCurrentUser()
+	if err != nil {
+		return "the API token lacks the privileges required by this operation. Operations on users, teams, settings, " +
+			"access groups and environment groups require the API token of an administrator, operations on an environment " +
+			"require an access to the environment with a role allowing them, such as environment administrator"
+	}
+
+	if user.IsAdmin {
+		return fmt.Sprintf("the API token belongs to the administrator %q, Portainer does not allow this operation on this "+
+			"resource whatever the privileges of the token", user.Username)
+	}
+
+	owner := fmt.Sprintf("the API token belongs to the user %q with the %s role", user.Username, user.Role)
+	if adminOnlyTools[toolName] {
+		return fmt.Sprintf("this operation requires the API token of an administrator, %s", owner)
+	}
+
+	parser := toolgen.NewParameterParser(request)
+	if environmentId, err := parser.GetInt("environmentId", true); err == nil {
+		return fmt.Sprintf("this operation requires an access to environment %d with a role allowing it, such as environment "+
+			"administrator, %s. An administrator can grant the access with the %s or %s tools",
+			environmentId, owner, ToolUpdateEnvironmentUserAccesses, ToolUpdateEnvironmentTeamAccesses)
+	}
+
+	return fmt.Sprintf("this operation may require the API token of an administrator or a higher access on the resource, %s. "+
+		"Use the %s tool to list the authorizations of the token", owner, ToolWhoAmI)
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPermissionHints(t *testing.T) {
+	const forbidden = "failed to update environment: [PUT /endpoints/{id}][403] endpointUpdateForbidden"
+
+	standardUser := models.CurrentUser{User: models.User{ID: 2, Username: "bob", Role: models.UserRoleUser}}
+	admin := models.CurrentUser{User: models.User{ID: 1, Username: "admin", Role: models.UserRoleAdmin}, IsAdmin: true}
+
+	tests := []struct {
+		name         string
+		toolName     string
+		inputParams  map[string]any
+		result       *mcp.CallToolResult
+		mockUser     *models.CurrentUser
+		mockError    error
+		expectedText string
+	}{
+		{
+			name:         "successful result",
+			toolName:     ToolUpdateEnvironment,
+			result:       mcp.NewToolResultText("Environment updated successfully"),
+			expectedText: "Environment updated successfully",
+		},
+		{
+			name:         "other error",
+			toolName:     ToolUpdateEnvironment,
+			result:       mcp.NewToolResultError("failed to update environment: [PUT /endpoints/{id}][404] endpointUpdateNotFound"),
+			expectedText: "failed to update environment: [PUT /endpoints/{id}][404] endpointUpdateNotFound",
+		},
+		{
+			name:         "admin-only tool",
+			toolName:     ToolUpdateEnvironment,
+			result:       mcp.NewToolResultError(forbidden),
+			mockUser:     &standardUser,
+			expectedText: forbidden + "\nPermission denied: this operation requires the API token of an administrator, the API token belongs to the user \"bob\" with the user role",
+		},
+		{
+			name:        "environment tool",
+			toolName:    ToolListContainers,
+			inputParams: map[string]any{"environmentId": float64(4)},
+			result:      mcp.NewToolResultError("failed to list containers: unexpected status 403: access denied to resource"),
+			mockUser:    &standardUser,
+			expectedText: "failed to list containers: unexpected status 403: access denied to resource\nPermission denied: this operation " +
+				"requires an access to environment 4 with a role allowing it, such as environment administrator, the API token belongs to " +
+				"the user \"bob\" with the user role. An administrator can grant the access with the updateEnvironmentUserAccesses or " +
+				"updateEnvironmentTeamAccesses tools",
+		},
+		{
+			name:     "other tool",
+			toolName: ToolListStacks,
+			result:   mcp.NewToolResultError("failed to get stacks: get edge stacks (status 403): {}"),
+			mockUser: &standardUser,
+			expectedText: "failed to get stacks: get edge stacks (status 403): {}\nPermission denied: this operation may require the API token " +
+				"of an administrator or a higher access on the resource, the API token belongs to the user \"bob\" with the user role. " +
+				"Use the whoami tool to list the authorizations of the token",
+		},
+		{
+			name:     "administrator token",
+			toolName: ToolUpdateEnvironment,
+			result:   mcp.NewToolResultError(forbidden),
+			mockUser: &admin,
+			expectedText: forbidden + "\nPermission denied: the API token belongs to the administrator \"admin\", Portainer does not " +
+				"allow this operation on this resource whatever the privileges of the token",
+		},
+		{
+			name:      "current user error",
+			toolName:  ToolUpdateEnvironment,
+			result:    mcp.NewToolResultError(forbidden),
+			mockError: errors.New("failed to get current user"),
+			expectedText: forbidden + "\nPermission denied: the API token lacks the privileges required by this operation. Operations on " +
+				"users, teams, settings, access groups and environment groups require the API token of an administrator, operations on " +
+				"an environment require an access to the environment with a role allowing them, such as environment administrator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.mockUser != nil || tt.mockError != nil {
+				user := models.CurrentUser{}
+				if tt.mockUser != nil {
+					user = *tt.mockUser
+				}
+				mockClient.On("GetCurrentUser").Return(user, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.withPermissionHints(tt.toolName, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tt.result, nil
+			})
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectedText, textContent.Text)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+func (s *PortainerMCPServer) AddResourceControlFeatures() {
+	s.addToolIfExists(ToolGetResourceControl, s.HandleGetResourceControl())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolUpdateResourceControl, s.HandleUpdateResourceControl())
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetResourceControl() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		resourceType, err := parser.GetString("resourceType", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid resourceType parameter", err), nil
+		}
+
+		resourceId, err := parser.GetString("resourceId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid resourceId parameter", err), nil
+		}
+
+		resourceControl, err := s.client(ctx).GetResourceControl(environmentId, resourceType, resourceId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get resource control", err), nil
+		}
+
+		data, err := json.Marshal(resourceControl)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal resource control", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateResourceControl() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		public, err := parser.GetBoolean("public", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid public parameter", err), nil
+		}
+
+		administratorsOnly, err := parser.GetBoolean("administratorsOnly", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid administratorsOnly parameter", err), nil
+		}
+
+		userIds, err := parser.GetArrayOfIntegers("userIds", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid userIds parameter", err), nil
+		}
+
+		teamIds, err := parser.GetArrayOfIntegers("teamIds", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid teamIds parameter", err), nil
+		}
+
+		err = s.client(ctx).UpdateResourceControl(id, models.ResourceControlOptions{
+			Public:             public,
+			AdministratorsOnly: administratorsOnly,
+			UserIDs:            userIds,
+			TeamIDs:            teamIds,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update resource control", err), nil
+		}
+
+		return mcp.NewToolResultText("Resource control updated successfully"), nil
+	}
+}
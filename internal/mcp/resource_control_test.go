@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetResourceControl(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockRC      models.ResourceControl
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful retrieval",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resourceType":  "volume",
+				"resourceId":    "data",
+			},
+			expectCall: true,
+			mockRC: models.ResourceControl{
+				ID:         4,
+				ResourceID: "data",
+				Type:       models.ResourceTypeVolume,
+				UserIDs:    []int{2},
+				TeamIDs:    []int{},
+			},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resourceType":  "volume",
+				"resourceId":    "data",
+			},
+			expectCall:  true,
+			mockError:   fmt.Errorf("volume data has no resource control"),
+			expectError: true,
+		},
+		{
+			name: "missing environmentId parameter",
+			inputParams: map[string]any{
+				"resourceType": "volume",
+				"resourceId":   "data",
+			},
+			expectError: true,
+		},
+		{
+			name: "missing resourceType parameter",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resourceId":    "data",
+			},
+			expectError: true,
+		},
+		{
+			name: "missing resourceId parameter",
+			inputParams: map[string]any{
+				"environmentId": float64(1),
+				"resourceType":  "volume",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetResourceControl", 1, "volume", "data").Return(tt.mockRC, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetResourceControl()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var rc models.ResourceControl
+				err = json.Unmarshal([]byte(textContent.Text), &rc)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockRC, rc)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateResourceControl(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		expectOpts  models.ResourceControlOptions
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "restrict to users and teams",
+			inputParams: map[string]any{
+				"id":      float64(4),
+				"userIds": []any{float64(2)},
+				"teamIds": []any{float64(3), float64(5)},
+			},
+			expectCall: true,
+			expectOpts: models.ResourceControlOptions{
+				UserIDs: []int{2},
+				TeamIDs: []int{3, 5},
+			},
+		},
+		{
+			name: "make public",
+			inputParams: map[string]any{
+				"id":     float64(4),
+				"public": true,
+			},
+			expectCall: true,
+			expectOpts: models.ResourceControlOptions{
+				Public:  true,
+				UserIDs: []int{},
+				TeamIDs: []int{},
+			},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"id":                 float64(4),
+				"administratorsOnly": true,
+			},
+			expectCall: true,
+			expectOpts: models.ResourceControlOptions{
+				AdministratorsOnly: true,
+				UserIDs:            []int{},
+				TeamIDs:            []int{},
+			},
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{"public": true},
+			expectError: true,
+		},
+		{
+			name: "invalid public parameter",
+			inputParams: map[string]any{
+				"id":     float64(4),
+				"public": "yes",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid userIds parameter",
+			inputParams: map[string]any{
+				"id":      float64(4),
+				"userIds": []any{"admin"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateResourceControl", 4, tt.expectOpts).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateResourceControl()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, "Resource control updated successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ResponseFormat controls how tool results are rendered before being sent to MCP clients
+type ResponseFormat string
+
+const (
+	// ResponseFormatDefault returns tool results as produced by the handlers:
+	// JSON documents for data and plain text for messages and errors
+	ResponseFormatDefault ResponseFormat = ""
+	// ResponseFormatJSON returns every tool result, including messages and errors, as a JSON document
+	ResponseFormatJSON ResponseFormat = "json"
+	// ResponseFormatText returns every tool result as human-readable text, JSON documents are indented
+	ResponseFormatText ResponseFormat = "text"
+)
+
+// ParseResponseFormat converts a string into a ResponseFormat.
+// An empty string maps to ResponseFormatDefault.
+func ParseResponseFormat(format string) (ResponseFormat, error) {
+	switch ResponseFormat(format) {
+	case ResponseFormatDefault, ResponseFormatJSON, ResponseFormatText:
+		return ResponseFormat(format), nil
+	default:
+		return "", fmt.Errorf("invalid response format: %s, must be one of: %s, %s", format, ResponseFormatJSON, ResponseFormatText)
+	}
+}
+
+// jsonMessage is the JSON document used for plain text results in JSON mode
+type jsonMessage struct {
+	Message string `json:"message"`
+}
+
+// jsonError is the JSON document used for error results in JSON mode
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+// withResponseFormat wraps a tool handler so that its text results are rendered in the given format.
+// Handlers are returned unchanged for ResponseFormatDefault.
+func withResponseFormat(format ResponseFormat, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	if format == ResponseFormatDefault {
+		return handler
+	}
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+
+		for i, content := range result.Content {
+			textContent, ok := content.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+
+			textContent.Text = formatText(format, textContent.Text, result.IsError)
+			result.Content[i] = textContent
+		}
+
+		return result, nil
+	}
+}
+
+// formatText renders a single text result in the given format
+func formatText(format ResponseFormat, text string, isError bool) string {
+	switch format {
+	case ResponseFormatJSON:
+		if isError {
+			return marshalOrRaw(jsonError{Error: text}, text)
+		}
+		if json.Valid([]byte(text)) {
+			return text
+		}
+		return marshalOrRaw(jsonMessage{Message: text}, text)
+	case ResponseFormatText:
+		if isError || !json.Valid([]byte(text)) {
+			return text
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, []byte(text), "", "  "); err != nil {
+			return text
+		}
+		return indented.String()
+	default:
+		return text
+	}
+}
+
+// marshalOrRaw marshals v to JSON and falls back to the raw text if marshalling fails
+func marshalOrRaw(v any, raw string) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return raw
+	}
+	return string(data)
+}
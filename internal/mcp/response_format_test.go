@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResponseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ResponseFormat
+		wantErr bool
+	}{
+		{"Empty", "", ResponseFormatDefault, false},
+		{"JSON", "json", ResponseFormatJSON, false},
+		{"Text", "text", ResponseFormatText, false},
+		{"Invalid", "xml", "", true},
+		{"CaseSensitive", "JSON", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResponseFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWithResponseFormat(t *testing.T) {
+	tests := []struct {
+		name         string
+		format       ResponseFormat
+		result       *mcp.CallToolResult
+		expectedText string
+		expectError  bool
+	}{
+		{
+			name:         "default format keeps message",
+			format:       ResponseFormatDefault,
+			result:       mcp.NewToolResultText("Stack updated successfully"),
+			expectedText: "Stack updated successfully",
+		},
+		{
+			name:         "default format keeps error",
+			format:       ResponseFormatDefault,
+			result:       mcp.NewToolResultErrorFromErr("failed to update stack", errors.New("api error")),
+			expectedText: "failed to update stack: api error",
+			expectError:  true,
+		},
+		{
+			name:         "json format keeps json document",
+			format:       ResponseFormatJSON,
+			result:       mcp.NewToolResultText(`[{"id":1}]`),
+			expectedText: `[{"id":1}]`,
+		},
+		{
+			name:         "json format wraps message",
+			format:       ResponseFormatJSON,
+			result:       mcp.NewToolResultText("Stack updated successfully"),
+			expectedText: `{"message":"Stack updated successfully"}`,
+		},
+		{
+			name:         "json format wraps error",
+			format:       ResponseFormatJSON,
+			result:       mcp.NewToolResultErrorFromErr("failed to update stack", errors.New("api error")),
+			expectedText: `{"error":"failed to update stack: api error"}`,
+			expectError:  true,
+		},
+		{
+			name:         "text format indents json document",
+			format:       ResponseFormatText,
+			result:       mcp.NewToolResultText(`{"id":1,"name":"stack1"}`),
+			expectedText: "{\n  \"id\": 1,\n  \"name\": \"stack1\"\n}",
+		},
+		{
+			name:         "text format keeps message",
+			format:       ResponseFormatText,
+			result:       mcp.NewToolResultText("Stack updated successfully"),
+			expectedText: "Stack updated successfully",
+		},
+		{
+			name:         "text format keeps error",
+			format:       ResponseFormatText,
+			result:       mcp.NewToolResultError("invalid id parameter"),
+			expectedText: "invalid id parameter",
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tt.result, nil
+			}
+
+			result, err := withResponseFormat(tt.format, handler)(context.Background(), mcp.CallToolRequest{})
+
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectedText, textContent.Text)
+			assert.Equal(t, tt.expectError, result.IsError)
+		})
+	}
+}
+
+func TestWithResponseFormatHandlerError(t *testing.T) {
+	handlerErr := errors.New("handler error")
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, handlerErr
+	}
+
+	result, err := withResponseFormat(ResponseFormatJSON, handler)(context.Background(), mcp.CallToolRequest{})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, handlerErr)
+}
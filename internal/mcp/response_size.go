@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// truncationNotice is the JSON document appended to the results cut to the maximum response size
+type truncationNotice struct {
+	Truncated        bool `json:"truncated"`
+	OriginalSize     int  `json:"original_size"`
+	MaxResponseBytes int  `json:"max_response_bytes"`
+}
+
+// withMaxResponseBytes wraps a tool handler so that the text of its results never exceeds limit bytes.
+// The text contents of a larger result are cut, at a UTF-8 character boundary, and a truncationNotice
+// holding the original size is appended to the result as an additional text content. The notice is not
+// counted in the limit. Handlers are returned unchanged when limit is lower than or equal to zero.
+func withMaxResponseBytes(limit int, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	if limit <= 0 {
+		return handler
+	}
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+
+		size := 0
+		for _, content := range result.Content {
+			if textContent, ok := content.(mcp.TextContent); ok {
+				size += len(textContent.Text)
+			}
+		}
+		if size <= limit {
+			return result, nil
+		}
+
+		remaining := limit
+		contents := make([]mcp.Content, 0, len(result.Content)+1)
+		for _, content := range result.Content {
+			textContent, ok := content.(mcp.TextContent)
+			if !ok {
+				contents = append(contents, content)
+				continue
+			}
+
+			textContent.Text = truncateUTF8(textContent.Text, remaining)
+			if textContent.Text == "" {
+				continue
+			}
+			remaining -= len(textContent.Text)
+			contents = append(contents, textContent)
+		}
+
+		notice, err := json.Marshal(truncationNotice{
+			Truncated:        true,
+			OriginalSize:     size,
+			MaxResponseBytes: limit,
+		})
+		if err != nil {
+			return result, nil
+		}
+		result.Content = append(contents, mcp.NewTextContent(string(notice)))
+
+		return result, nil
+	}
+}
+
+// truncateUTF8 returns the longest prefix of text of at most limit bytes that does not split a character
+func truncateUTF8(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          int
+		result         *mcp.CallToolResult
+		expectedTexts  []string
+		expectedNotice *truncationNotice
+	}{
+		{
+			name:          "limit disabled",
+			limit:         0,
+			result:        mcp.NewToolResultText(strings.Repeat("a", 100)),
+			expectedTexts: []string{strings.Repeat("a", 100)},
+		},
+		{
+			name:          "result within the limit",
+			limit:         10,
+			result:        mcp.NewToolResultText("0123456789"),
+			expectedTexts: []string{"0123456789"},
+		},
+		{
+			name:           "result over the limit",
+			limit:          4,
+			result:         mcp.NewToolResultText(`[{"id":1},{"id":2}]`),
+			expectedTexts:  []string{`[{"i`},
+			expectedNotice: &truncationNotice{Truncated: true, OriginalSize: 19, MaxResponseBytes: 4},
+		},
+		{
+			name:           "multi-byte character at the limit",
+			limit:          4,
+			result:         mcp.NewToolResultText("abcé"),
+			expectedTexts:  []string{"abc"},
+			expectedNotice: &truncationNotice{Truncated: true, OriginalSize: 5, MaxResponseBytes: 4},
+		},
+		{
+			name:  "limit shared by the text contents",
+			limit: 6,
+			result: &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent("abcd"),
+					mcp.NewTextContent("efgh"),
+					mcp.NewTextContent("ijkl"),
+				},
+			},
+			expectedTexts:  []string{"abcd", "ef"},
+			expectedNotice: &truncationNotice{Truncated: true, OriginalSize: 12, MaxResponseBytes: 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tt.result, nil
+			}
+
+			result, err := withMaxResponseBytes(tt.limit, handler)(context.Background(), mcp.CallToolRequest{})
+			require.NoError(t, err)
+
+			texts := make([]string, 0, len(result.Content))
+			for _, content := range result.Content {
+				textContent, ok := content.(mcp.TextContent)
+				require.True(t, ok, "Result content should be mcp.TextContent")
+				texts = append(texts, textContent.Text)
+			}
+
+			if tt.expectedNotice == nil {
+				assert.Equal(t, tt.expectedTexts, texts)
+				return
+			}
+
+			require.Len(t, texts, len(tt.expectedTexts)+1)
+			assert.Equal(t, tt.expectedTexts, texts[:len(tt.expectedTexts)])
+
+			var notice truncationNotice
+			require.NoError(t, json.Unmarshal([]byte(texts[len(texts)-1]), &notice))
+			assert.Equal(t, *tt.expectedNotice, notice)
+		})
+	}
+}
+
+func TestWithMaxResponseBytesHandlerError(t *testing.T) {
+	handlerErr := errors.New("handler error")
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, handlerErr
+	}
+
+	result, err := withMaxResponseBytes(10, handler)(context.Background(), mcp.CallToolRequest{})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, handlerErr)
+}
@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+func (s *PortainerMCPServer) AddScheduleFeatures() {
+	s.addToolIfExists(ToolListSchedules, s.HandleGetSchedules())
+	s.addToolIfExists(ToolGetScheduleResults, s.HandleGetScheduleResults())
+	s.addToolIfExists(ToolGetScheduleResultLog, s.HandleGetScheduleResultLog())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolCreateSchedule, s.HandleCreateSchedule())
+		s.addToolIfExists(ToolDeleteSchedule, s.HandleDeleteSchedule())
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetSchedules() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		schedules, err := s.client(ctx).GetSchedules()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get schedules", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(schedules))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal schedules", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetScheduleResults() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		results, err := s.client(ctx).GetScheduleResults(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get schedule results", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(results))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal schedule results", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetScheduleResultLog() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		result, err := s.client(ctx).GetScheduleResultLog(id, environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get schedule result log", err), nil
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal schedule result", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCreateSchedule() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		name, err := parser.GetString("name", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
+		}
+
+		cronExpression, err := parser.GetString("cronExpression", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid cronExpression parameter", err), nil
+		}
+
+		recurring := true
+		if parser.Has("recurring") {
+			if recurring, err = parser.GetBoolean("recurring", false); err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid recurring parameter", err), nil
+			}
+		}
+
+		script, err := parser.GetString("script", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid script parameter", err), nil
+		}
+
+		environmentIds, err := parser.GetArrayOfIntegers("environmentIds", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentIds parameter", err), nil
+		}
+
+		environmentGroupIds, err := parser.GetArrayOfIntegers("environmentGroupIds", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentGroupIds parameter", err), nil
+		}
+
+		schedule, err := s.client(ctx).CreateSchedule(models.Schedule{
+			Name:                name,
+			CronExpression:      cronExpression,
+			Recurring:           recurring,
+			Script:              script,
+			EnvironmentIds:      environmentIds,
+			EnvironmentGroupIds: environmentGroupIds,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to create schedule", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Schedule created successfully with ID: %d, next runs: %s", schedule.ID, strings.Join(schedule.NextRuns, ", "))), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleDeleteSchedule() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		err = s.client(ctx).DeleteSchedule(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to delete schedule", err), nil
+		}
+
+		return mcp.NewToolResultText("Schedule deleted successfully"), nil
+	}
+}
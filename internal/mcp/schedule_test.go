@@ -0,0 +1,391 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetSchedules(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSchedules []models.Schedule
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "successful retrieval",
+			mockSchedules: []models.Schedule{
+				{ID: 1, Name: "cleanup", CronExpression: "0 3 * * *", Recurring: true, EnvironmentIds: []int{1}, EnvironmentGroupIds: []int{},
+					NextRuns: []string{"2024-05-02T03:00:00Z", "2024-05-03T03:00:00Z"}},
+				{ID: 2, Name: "reboot", CronExpression: "30 4 1 6 *", EnvironmentIds: []int{}, EnvironmentGroupIds: []int{2},
+					NextRuns: []string{"2024-06-01T04:30:00Z"}},
+			},
+		},
+		{
+			name:          "empty schedules",
+			mockSchedules: []models.Schedule{},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetSchedules").Return(tt.mockSchedules, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetSchedules()
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var schedules listResponse[models.Schedule]
+				err = json.Unmarshal([]byte(textContent.Text), &schedules)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockSchedules, schedules.Items)
+				assert.Equal(t, len(tt.mockSchedules), schedules.TotalCount)
+				assert.False(t, schedules.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetScheduleResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockResults []models.ScheduleResult
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(3)},
+			expectCall:  true,
+			mockResults: []models.ScheduleResult{
+				{ScheduleID: 3, EnvironmentID: 1, Status: models.ScheduleResultStatusCollected, Log: "backup done\n"},
+				{ScheduleID: 3, EnvironmentID: 2, Status: models.ScheduleResultStatusPending, LogsRequested: true},
+			},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(3)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to list edge job tasks"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetScheduleResults", 3).Return(tt.mockResults, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetScheduleResults()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if !tt.expectError {
+				var results listResponse[models.ScheduleResult]
+				err = json.Unmarshal([]byte(textContent.Text), &results)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockResults, results.Items)
+				assert.Equal(t, len(tt.mockResults), results.TotalCount)
+				assert.False(t, results.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetScheduleResultLog(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockResult  models.ScheduleResult
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(3), "environmentId": float64(1)},
+			expectCall:  true,
+			mockResult:  models.ScheduleResult{ScheduleID: 3, EnvironmentID: 1, Status: models.ScheduleResultStatusCollected, Log: "backup done\n"},
+		},
+		{
+			name:        "pending result",
+			inputParams: map[string]any{"id": float64(3), "environmentId": float64(1)},
+			expectCall:  true,
+			mockResult:  models.ScheduleResult{ScheduleID: 3, EnvironmentID: 1, Status: models.ScheduleResultStatusPending},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(3), "environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("schedule 3 does not run on environment 1"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{"id": float64(3)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetScheduleResultLog", 3, 1).Return(tt.mockResult, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetScheduleResultLog()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if !tt.expectError {
+				var scheduleResult models.ScheduleResult
+				err = json.Unmarshal([]byte(textContent.Text), &scheduleResult)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockResult, scheduleResult)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCreateSchedule(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedInput models.Schedule
+		mockRuns      []string
+		mockError     error
+		expectError   bool
+		expectedText  string
+	}{
+		{
+			name: "recurring by default",
+			inputParams: map[string]any{
+				"name":           "cleanup",
+				"cronExpression": "0 3 * * *",
+				"script":         "docker system prune -f",
+				"environmentIds": []any{float64(1), float64(2)},
+			},
+			expectCall: true,
+			expectedInput: models.Schedule{
+				Name:                "cleanup",
+				CronExpression:      "0 3 * * *",
+				Recurring:           true,
+				Script:              "docker system prune -f",
+				EnvironmentIds:      []int{1, 2},
+				EnvironmentGroupIds: []int{},
+			},
+			mockRuns:     []string{"2024-05-02T03:00:00Z", "2024-05-03T03:00:00Z"},
+			expectedText: "Schedule created successfully with ID: 5, next runs: 2024-05-02T03:00:00Z, 2024-05-03T03:00:00Z",
+		},
+		{
+			name: "one-time schedule on environment groups",
+			inputParams: map[string]any{
+				"name":                "reboot",
+				"cronExpression":      "30 4 1 6 *",
+				"recurring":           false,
+				"script":              "reboot",
+				"environmentGroupIds": []any{float64(3)},
+			},
+			expectCall: true,
+			expectedInput: models.Schedule{
+				Name:                "reboot",
+				CronExpression:      "30 4 1 6 *",
+				Script:              "reboot",
+				EnvironmentIds:      []int{},
+				EnvironmentGroupIds: []int{3},
+			},
+			mockRuns:     []string{"2024-06-01T04:30:00Z"},
+			expectedText: "Schedule created successfully with ID: 5, next runs: 2024-06-01T04:30:00Z",
+		},
+		{
+			name: "invalid cron expression",
+			inputParams: map[string]any{
+				"name":           "cleanup",
+				"cronExpression": "0 25 * * *",
+				"script":         "docker system prune -f",
+				"environmentIds": []any{float64(1)},
+			},
+			expectCall: true,
+			expectedInput: models.Schedule{
+				Name:                "cleanup",
+				CronExpression:      "0 25 * * *",
+				Recurring:           true,
+				Script:              "docker system prune -f",
+				EnvironmentIds:      []int{1},
+				EnvironmentGroupIds: []int{},
+			},
+			mockError:    fmt.Errorf("invalid schedule: value 25 of the hour field must be between 0 and 23"),
+			expectError:  true,
+			expectedText: "value 25 of the hour field must be between 0 and 23",
+		},
+		{
+			name:         "missing name parameter",
+			inputParams:  map[string]any{"cronExpression": "0 3 * * *", "script": "docker system prune -f"},
+			expectError:  true,
+			expectedText: "invalid name parameter",
+		},
+		{
+			name:         "missing cronExpression parameter",
+			inputParams:  map[string]any{"name": "cleanup", "script": "docker system prune -f"},
+			expectError:  true,
+			expectedText: "invalid cronExpression parameter",
+		},
+		{
+			name:         "missing script parameter",
+			inputParams:  map[string]any{"name": "cleanup", "cronExpression": "0 3 * * *"},
+			expectError:  true,
+			expectedText: "invalid script parameter",
+		},
+		{
+			name:         "invalid recurring parameter",
+			inputParams:  map[string]any{"name": "cleanup", "cronExpression": "0 3 * * *", "script": "docker system prune -f", "recurring": "yes"},
+			expectError:  true,
+			expectedText: "invalid recurring parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CreateSchedule", tt.expectedInput).Return(models.Schedule{ID: 5, NextRuns: tt.mockRuns}, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCreateSchedule()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if tt.expectError {
+				assert.Contains(t, textContent.Text, tt.expectedText)
+			} else {
+				assert.Equal(t, tt.expectedText, textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteSchedule(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful deletion",
+			inputParams: map[string]any{"id": float64(3)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(3)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to delete edge job"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("DeleteSchedule", 3).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDeleteSchedule()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if !tt.expectError {
+				assert.Equal(t, "Schedule deleted successfully", textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
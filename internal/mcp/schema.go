@@ -1,6 +1,10 @@
 package mcp
 
-import "slices"
+import (
+	"slices"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
 
 // Tool names as defined in the YAML file
 const (
@@ -39,20 +43,120 @@ const (
 	ToolDockerProxy                        = "dockerProxy"
 	ToolKubernetesProxy                    = "kubernetesProxy"
 	ToolKubernetesProxyStripped            = "getKubernetesResourceStripped"
+	ToolListActivityLogs                   = "listActivityLogs"
+	ToolDetectStackDrift                   = "detectStackDrift"
+	ToolCloneStack                         = "cloneStack"
+	ToolGetResourceControl                 = "getResourceControl"
+	ToolUpdateResourceControl              = "updateResourceControl"
+	ToolGetStackHealth                     = "getStackHealth"
+	ToolGetEnvironmentGPUs                 = "getEnvironmentGPUs"
+	ToolUpdateEnvironmentGPUs              = "updateEnvironmentGPUs"
+	ToolFindStacksByImage                  = "findStacksByImage"
+	ToolUpdateUserRolesBulk                = "updateUserRolesBulk"
+	ToolListEdgeConfigurations             = "listEdgeConfigurations"
+	ToolCreateEdgeConfiguration            = "createEdgeConfiguration"
+	ToolDeleteEdgeConfiguration            = "deleteEdgeConfiguration"
+	ToolExportAccessReport                 = "exportAccessReport"
+	ToolTestStackFile                      = "testStackFile"
+	ToolGetAuthSettings                    = "getAuthSettings"
+	ToolUpdateAuthSettings                 = "updateAuthSettings"
+	ToolGetDockerInfo                      = "getDockerInfo"
+	ToolListSwarmServices                  = "listSwarmServices"
+	ToolListSwarmTasks                     = "listSwarmTasks"
+	ToolUpdateSwarmService                 = "updateSwarmService"
+	ToolGetLicenseInfo                     = "getLicenseInfo"
+	ToolPlanEnvironmentConfig              = "planEnvironmentConfig"
+	ToolApplyEnvironmentConfig             = "applyEnvironmentConfig"
+	ToolListContainers                     = "listContainers"
+	ToolTriggerEnvironmentSnapshot         = "triggerEnvironmentSnapshot"
+	ToolGetKubeconfig                      = "getKubeconfig"
+	ToolListHelmReleases                   = "listHelmReleases"
+	ToolInstallHelmChart                   = "installHelmChart"
+	ToolUpdateTeamMemberships              = "updateTeamMemberships"
+	ToolDescribeTools                      = "describeTools"
+	ToolCompareEnvironments                = "compareEnvironments"
+	ToolListOperations                     = "listOperations"
+	ToolCancelOperation                    = "cancelOperation"
+	ToolCreateEnvironmentGroupsBulk        = "createEnvironmentGroupsBulk"
+	ToolCreateStackFromURL                 = "createStackFromURL"
+	ToolGetContainerChanges                = "getContainerChanges"
+	ToolGetContainerFile                   = "getContainerFile"
+	ToolPutContainerFile                   = "putContainerFile"
+	ToolListEnvironmentRegistries          = "listEnvironmentRegistries"
+	ToolUpdateEnvironmentRegistries        = "updateEnvironmentRegistries"
+	ToolGetEdgeAgentStatus                 = "getEdgeAgentStatus"
+	ToolGetContainerProcesses              = "getContainerProcesses"
+	ToolPruneDockerResources               = "pruneDockerResources"
+	ToolGetStackGitConfig                  = "getStackGitConfig"
+	ToolRedeployStackFromGit               = "redeployStackFromGit"
+	ToolGetSSLSettings                     = "getSSLSettings"
+	ToolUpdateSSLSettings                  = "updateSSLSettings"
+	ToolExportStacks                       = "exportStacks"
+	ToolGetStackAccess                     = "getStackAccess"
+	ToolUpdateEnvironmentsUserAccessesBulk = "updateEnvironmentsUserAccessesBulk"
+	ToolUpdateEnvironmentsTeamAccessesBulk = "updateEnvironmentsTeamAccessesBulk"
+	ToolFollowContainerLogs                = "followContainerLogs"
+	ToolUpdateContainerResources           = "updateContainerResources"
+	ToolGetEnvironmentMetadata             = "getEnvironmentMetadata"
+	ToolUpdateEnvironmentMetadata          = "updateEnvironmentMetadata"
+	ToolGetFleetStats                      = "getFleetStats"
+	ToolDetachEnvironment                  = "detachEnvironment"
+	ToolGetContainerHealth                 = "getContainerHealth"
+	ToolUpdateStackAutoUpdate              = "updateStackAutoUpdate"
+	ToolGetContainerMounts                 = "getContainerMounts"
+	ToolWhoAmI                             = "whoami"
+	ToolListCustomTemplates                = "listCustomTemplates"
+	ToolCreateCustomTemplate               = "createCustomTemplate"
+	ToolDeleteCustomTemplate               = "deleteCustomTemplate"
+	ToolDeployCustomTemplate               = "deployCustomTemplate"
+	ToolGetStackDeploymentLogs             = "getStackDeploymentLogs"
+	ToolExportEnvironmentAccess            = "exportEnvironmentAccess"
+	ToolRestoreEnvironmentAccess           = "restoreEnvironmentAccess"
+	ToolListSchedules                      = "listSchedules"
+	ToolCreateSchedule                     = "createSchedule"
+	ToolDeleteSchedule                     = "deleteSchedule"
+	ToolGetAccessGroupInheritedAccess      = "getAccessGroupInheritedAccess"
+	ToolConnectContainerToNetwork          = "connectContainerToNetwork"
+	ToolDisconnectContainerFromNetwork     = "disconnectContainerFromNetwork"
+	ToolRecentlyChangedStacks              = "recentlyChangedStacks"
+	ToolGetStackGitDrift                   = "getStackGitDrift"
+	ToolGetTeamAccessOverview              = "getTeamAccessOverview"
+	ToolApplyTeamAccess                    = "applyTeamAccess"
+	ToolTestEnvironmentConnectivity        = "testEnvironmentConnectivity"
+	ToolGetServerConfig                    = "getServerConfig"
+	ToolRestartStack                       = "restartStack"
+	ToolFindEnvironmentsByImage            = "findEnvironmentsByImage"
+	ToolGetScheduleResults                 = "getScheduleResults"
+	ToolGetScheduleResultLog               = "getScheduleResultLog"
+	ToolFormatStackFile                    = "formatStackFile"
+	ToolGetEnvironmentPublicURL            = "getEnvironmentPublicURL"
+	ToolUpdateEnvironmentPublicURL         = "updateEnvironmentPublicURL"
+	ToolDeleteStacksMatching               = "deleteStacksMatching"
+	ToolGetBrandingSettings                = "getBrandingSettings"
+	ToolUpdateBrandingSettings             = "updateBrandingSettings"
+	ToolCheckImageUpdates                  = "checkImageUpdates"
+	ToolRecreateContainer                  = "recreateContainer"
+	ToolGetUserPreferences                 = "getUserPreferences"
+	ToolUpdateUserPreferences              = "updateUserPreferences"
+	ToolUpdateContainerRestartPolicy       = "updateContainerRestartPolicy"
+	ToolGetPublicSettings                  = "getPublicSettings"
+	ToolCheckFleetConnectivity             = "checkFleetConnectivity"
+	ToolUpdateSessionTimeout               = "updateSessionTimeout"
+	ToolFindOrphanedResources              = "findOrphanedResources"
 )
 
-// Access levels for users and teams
+// Access levels for users and teams, see models.AllAccessLevels for the list of the accepted levels
 const (
 	// AccessLevelEnvironmentAdmin represents the environment administrator access level
-	AccessLevelEnvironmentAdmin = "environment_administrator"
+	AccessLevelEnvironmentAdmin = models.AccessLevelEnvironmentAdmin
 	// AccessLevelHelpdeskUser represents the helpdesk user access level
-	AccessLevelHelpdeskUser = "helpdesk_user"
+	AccessLevelHelpdeskUser = models.AccessLevelHelpdeskUser
 	// AccessLevelStandardUser represents the standard user access level
-	AccessLevelStandardUser = "standard_user"
+	AccessLevelStandardUser = models.AccessLevelStandardUser
 	// AccessLevelReadonlyUser represents the readonly user access level
-	AccessLevelReadonlyUser = "readonly_user"
+	AccessLevelReadonlyUser = models.AccessLevelReadonlyUser
 	// AccessLevelOperatorUser represents the operator user access level
-	AccessLevelOperatorUser = "operator_user"
+	AccessLevelOperatorUser = models.AccessLevelOperatorUser
 )
 
 // User roles
@@ -65,14 +169,29 @@ const (
 	UserRoleEdgeAdmin = "edge_admin"
 )
 
-// All available access levels
-var AllAccessLevels = []string{
-	AccessLevelEnvironmentAdmin,
-	AccessLevelHelpdeskUser,
-	AccessLevelStandardUser,
-	AccessLevelReadonlyUser,
-	AccessLevelOperatorUser,
-}
+// Team membership roles
+const (
+	// TeamMembershipRoleLeader represents a team leader, who can manage the members of the team
+	TeamMembershipRoleLeader = "leader"
+	// TeamMembershipRoleMember represents a regular team member
+	TeamMembershipRoleMember = "member"
+)
+
+// Encodings of the file contents exchanged with the container file tools
+const (
+	// FileEncodingText represents a UTF-8 text file sent as is
+	FileEncodingText = "text"
+	// FileEncodingBase64 represents a binary file sent encoded in base64
+	FileEncodingBase64 = "base64"
+)
+
+// Formats of the stack export
+const (
+	// StackExportFormatJSON returns the stack files in a JSON object keyed by stack name
+	StackExportFormatJSON = "json"
+	// StackExportFormatZip returns the stack files in a zip archive encoded in base64
+	StackExportFormatZip = "zip"
+)
 
 // All available user roles
 var AllUserRoles = []string{
@@ -81,12 +200,18 @@ var AllUserRoles = []string{
 	UserRoleEdgeAdmin,
 }
 
-// isValidAccessLevel checks if a given string is a valid access level
-func isValidAccessLevel(access string) bool {
-	return slices.Contains(AllAccessLevels, access)
+// All available team membership roles
+var AllTeamMembershipRoles = []string{
+	TeamMembershipRoleLeader,
+	TeamMembershipRoleMember,
 }
 
 // isValidUserRole checks if a given string is a valid user role
 func isValidUserRole(role string) bool {
 	return slices.Contains(AllUserRoles, role)
 }
+
+// isValidTeamMembershipRole checks if a given string is a valid team membership role
+func isValidTeamMembershipRole(role string) bool {
+	return slices.Contains(AllTeamMembershipRoles, role)
+}
@@ -1,9 +1,13 @@
 package mcp
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -12,13 +16,19 @@ import (
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/toolgen"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	// MinimumToolsVersion is the minimum supported version of the tools.yaml file
-	MinimumToolsVersion = "1.0"
+	MinimumToolsVersion = "v1.0"
+	// MaximumToolsVersion is the most recent version of the tools.yaml file supported by this build, it must
+	// be raised with the version of the embedded tools.yaml file
+	MaximumToolsVersion = "v1.3"
 	// SupportedPortainerVersion is the version of Portainer that is supported by this tool
 	SupportedPortainerVersion = "2.31.2"
+	// serverVersion is the version of the MCP server advertised to MCP clients
+	serverVersion = "0.5.1"
 )
 
 // PortainerClient defines the interface for the wrapper client used by the MCP server
@@ -30,12 +40,38 @@ type PortainerClient interface {
 	// Environment methods
 	GetEnvironments() ([]models.Environment, error)
 	UpdateEnvironmentTags(id int, tagIds []int) error
+	GetEnvironmentMetadata(id int) (map[string]string, error)
+	UpdateEnvironmentMetadata(id int, metadata map[string]string) error
+	GetFleetStats() (models.FleetStats, error)
+	FindEnvironmentsByImage(image string) ([]models.Environment, error)
+	DetachEnvironment(id int) (models.EnvironmentDetachReport, error)
+	ExportEnvironmentAccess(id int) (models.AccessSnapshot, error)
+	RestoreEnvironmentAccess(id int, snapshot models.AccessSnapshot) error
 	UpdateEnvironmentUserAccesses(id int, userAccesses map[int]string) error
 	UpdateEnvironmentTeamAccesses(id int, teamAccesses map[int]string) error
+	UpdateEnvironmentsUserAccessesBulk(envIds []int, userAccesses map[int]string) error
+	UpdateEnvironmentsTeamAccessesBulk(envIds []int, teamAccesses map[int]string) error
+	GetEnvironmentGPUs(id int) (models.EnvironmentGPUSettings, error)
+	UpdateEnvironmentGPUs(id int, gpus []models.GPUConfig) error
+	PlanEnvironmentConfig(id int, desired models.EnvironmentDesiredState) (models.ConfigDiff, error)
+	ApplyEnvironmentConfig(id int, desired models.EnvironmentDesiredState) (models.ConfigDiff, error)
+	TriggerEnvironmentSnapshot(id int) error
+	GetEnvironmentSnapshotTime(id int) (time.Time, error)
+	WaitForEnvironmentSnapshot(ctx context.Context, id int, after time.Time, timeout time.Duration) (time.Time, error)
+	CompareEnvironments(id1, id2 int) (models.EnvironmentComparison, error)
+	GetEnvironmentRegistries(environmentId int) ([]models.EnvironmentRegistry, error)
+	UpdateEnvironmentRegistries(environmentId int, registryIds []int) error
+	GetEdgeAgentStatus() ([]models.EdgeAgentStatus, error)
+	TestEnvironmentConnectivity(id int) (models.ConnectivityResult, error)
+	CheckFleetConnectivity() ([]models.ConnectivityResult, error)
+	GetEnvironmentSnapshots() ([]models.EnvironmentSnapshot, error)
+	GetEnvironmentPublicURL(id int) (string, error)
+	UpdateEnvironmentPublicURL(id int, publicURL string) error
 
 	// Environment Group methods
 	GetEnvironmentGroups() ([]models.Group, error)
 	CreateEnvironmentGroup(name string, environmentIds []int) (int, error)
+	CreateEnvironmentGroupsBulk(specs []models.GroupSpec) ([]int, error)
 	UpdateEnvironmentGroupName(id int, name string) error
 	UpdateEnvironmentGroupEnvironments(id int, environmentIds []int) error
 	UpdateEnvironmentGroupTags(id int, tagIds []int) error
@@ -43,39 +79,130 @@ type PortainerClient interface {
 	// Access Group methods
 	GetAccessGroups() ([]models.AccessGroup, error)
 	CreateAccessGroup(name string, environmentIds []int) (int, error)
+	UpdateAccessGroup(id int, update models.AccessGroupUpdate) error
 	UpdateAccessGroupName(id int, name string) error
 	UpdateAccessGroupUserAccesses(id int, userAccesses map[int]string) error
 	UpdateAccessGroupTeamAccesses(id int, teamAccesses map[int]string) error
 	AddEnvironmentToAccessGroup(id int, environmentId int) error
 	RemoveEnvironmentFromAccessGroup(id int, environmentId int) error
+	ExportAccessReport() (string, error)
+	GetGroupInheritedAccess(groupId int) (models.InheritedAccess, error)
 
 	// Stack methods
 	GetStacks() ([]models.Stack, error)
 	GetStackFile(id int) (string, error)
 	CreateStack(name string, file string, environmentGroupIds []int) (int, error)
+	CreateStackFromURL(name, composeURL string, environmentGroupIds []int) (int, error)
 	UpdateStack(id int, file string, environmentGroupIds []int) error
+	DetectStackDrift(stackId, environmentId int) (models.DriftReport, error)
+	RestartStack(stackId, environmentId int) (models.StackRestartReport, error)
+	GetStackDeploymentLogs(stackId, environmentId int) (string, error)
+	GetStackHealth(stackId int) (models.StackHealth, error)
+	CloneStack(sourceStackId int, newName string, targetGroupIds []int, imageReplacements map[string]string) (int, error)
+	FindStacksByImage(imagePattern string) ([]models.Stack, error)
+	GetRecentlyChangedStacks(limit int) ([]models.Stack, error)
+	TestStackFile(file string, environmentId int) (models.StackTestResult, error)
+	FormatComposeFile(file string) (string, error)
+	GetStackGitConfig(stackId int) (models.GitConfig, error)
+	GetStackGitDrift(stackId int) (models.GitDrift, error)
+	RedeployStackFromGit(stackId int, pullImage bool) error
+	UpdateStackAutoUpdate(stackId int, opts models.AutoUpdateOptions) (models.AutoUpdateSettings, error)
+	ExportAllStacks() (map[string]string, error)
+	GetStackAccess(stackId int) (models.ResourceAccess, error)
+	DeleteStacksMatching(filter models.StackFilter) (models.BulkDeleteResult, error)
 
 	// Team methods
 	CreateTeam(name string) (int, error)
 	GetTeams() ([]models.Team, error)
 	UpdateTeamName(id int, name string) error
 	UpdateTeamMembers(id int, userIds []int) error
+	UpdateTeamMemberships(id int, members map[int]string) error
+	GetTeamAccessOverview(teamId int) (models.TeamAccessOverview, error)
+	ApplyTeamAccess(teamId int, desired models.TeamAccessDesiredState) (models.TeamAccessOverview, error)
 
 	// User methods
 	GetUsers() ([]models.User, error)
+	GetCurrentUser() (models.CurrentUser, error)
 	UpdateUserRole(id int, role string) error
+	UpdateUserRolesBulk(updates map[int]string) error
+	GetUserPreferences(userId int) (models.UserPreferences, error)
+	UpdateUserPreferences(userId int, prefs models.UserPreferences) error
 
 	// Settings methods
 	GetSettings() (models.PortainerSettings, error)
+	GetPublicSettings() (models.PublicSettings, error)
+	GetAuthSettings() (models.AuthSettings, error)
+	UpdateAuthSettings(settings models.AuthSettings) error
+	GetSSLSettings() (models.SSLSettings, error)
+	UpdateSSLSettings(settings models.SSLSettings) error
+	GetBrandingSettings() (models.Branding, error)
+	UpdateBrandingSettings(branding models.Branding) error
+	UpdateSessionTimeout(sessionTimeout string) error
+	GetLicenseInfo() (models.LicenseInfo, error)
 
 	// Version methods
 	GetVersion() (string, error)
 
 	// Docker Proxy methods
 	ProxyDockerRequest(opts models.DockerProxyRequestOptions) (*http.Response, error)
+	GetDockerInfo(environmentId int) (models.DockerInfo, error)
+	ListContainers(environmentId int, filter models.ContainerFilter) ([]models.Container, error)
+	GetContainerChanges(environmentId int, containerId string) ([]models.FilesystemChange, error)
+	GetContainerProcesses(environmentId int, containerId string) (models.ProcessList, error)
+	GetContainerHealth(environmentId int, containerId string) (models.HealthStatus, error)
+	GetContainerMounts(environmentId int, containerId string) ([]models.Mount, error)
+	CheckImageUpdates(environmentId int) ([]models.ImageUpdateStatus, error)
+	FindOrphanedResources(environmentId int) (models.OrphanReport, error)
+	GetFileFromContainer(environmentId int, containerId, path string) ([]byte, error)
+	GetContainerLogs(environmentId int, containerId string, tail int) ([]models.ContainerLogLine, error)
+	FollowContainerLogs(ctx context.Context, environmentId int, containerId string, tail int, handle func(models.ContainerLogLine) error) error
+	PutFileInContainer(environmentId int, containerId, path string, content []byte) error
+	UpdateContainerResources(environmentId int, containerId string, limits models.ResourceLimits) error
+	UpdateContainerRestartPolicy(environmentId int, containerId string, policy models.RestartPolicy) error
+	RecreateContainer(environmentId int, containerId string, pullLatest bool) error
+	ConnectContainerToNetwork(environmentId int, networkId, containerId string) error
+	DisconnectContainerFromNetwork(environmentId int, networkId, containerId string, force bool) error
+	PruneContainers(environmentId int, labels []string) (models.PruneReport, error)
+	PruneNetworks(environmentId int, labels []string) (models.PruneReport, error)
+	PruneBuildCache(environmentId int, labels []string) (models.PruneReport, error)
+
+	// Swarm methods
+	ListSwarmServices(environmentId int) ([]models.SwarmService, error)
+	ListSwarmTasks(environmentId int, serviceId string) ([]models.SwarmTask, error)
+	UpdateSwarmService(environmentId int, serviceId string, opts models.SwarmServiceUpdate) error
 
 	// Kubernetes Proxy methods
 	ProxyKubernetesRequest(opts models.KubernetesProxyRequestOptions) (*http.Response, error)
+	GetKubeconfig(environmentId int) (string, error)
+
+	// Helm methods
+	ListHelmReleases(environmentId int, namespace string) ([]models.HelmRelease, error)
+	InstallHelmChart(environmentId int, opts models.HelmInstallOptions) error
+
+	// Activity Log methods
+	GetActivityLogs(opts models.ActivityLogFilter) (models.ActivityLogPage, error)
+
+	// Resource Control methods
+	GetResourceControl(environmentId int, resourceType, resourceId string) (models.ResourceControl, error)
+	UpdateResourceControl(resourceControlId int, opts models.ResourceControlOptions) error
+
+	// Edge Configuration methods
+	GetEdgeConfigurations() ([]models.EdgeConfig, error)
+	CreateEdgeConfiguration(config models.EdgeConfig, files map[string]string) error
+	DeleteEdgeConfiguration(id int) error
+
+	// Custom Template methods
+	GetCustomTemplates() ([]models.CustomTemplate, error)
+	CreateCustomTemplate(template models.CustomTemplate) (int, error)
+	DeleteCustomTemplate(id int) error
+	DeployCustomTemplate(templateId int, name string, environmentGroupIds []int, variables map[string]string) (int, error)
+
+	// Schedule methods
+	GetSchedules() ([]models.Schedule, error)
+	CreateSchedule(schedule models.Schedule) (models.Schedule, error)
+	DeleteSchedule(id int) error
+	GetScheduleResults(scheduleId int) ([]models.ScheduleResult, error)
+	GetScheduleResultLog(scheduleId, environmentId int) (models.ScheduleResult, error)
 }
 
 // PortainerMCPServer is the main server that handles MCP protocol communication
@@ -85,6 +212,43 @@ type PortainerMCPServer struct {
 	cli      PortainerClient
 	tools    map[string]mcp.Tool
 	readOnly bool
+	// registered holds the definitions of the tools exposed to MCP clients, keyed by name, guarded by
+	// registeredMu as custom tools can be registered while tools are called
+	registered     map[string]mcp.Tool
+	registeredMu   sync.RWMutex
+	responseFormat ResponseFormat
+	// maxResponseBytes is the maximum size of the text of a tool result, 0 when results are not truncated
+	maxResponseBytes int
+	// containerCursors holds the cursors of the paged container listings
+	containerCursors *containerCursorStore
+	// stackCreations holds the stacks created with an idempotency key
+	stackCreations *idempotencyStore
+	// operations holds the long-running tool calls in progress
+	operations *operationRegistry
+	// traced is set when the tool calls are traced
+	traced bool
+	// httpCompression is set when the HTTP responses are compressed with gzip
+	httpCompression bool
+	// snapshotMetrics serves the snapshot metrics of the environments, nil when they are not served
+	snapshotMetrics *snapshotMetricsCache
+	// config is the effective configuration of the server, guarded by configMu
+	config   models.ServerConfig
+	configMu sync.Mutex
+}
+
+// contextClient is implemented by the clients that can send their requests with the context of a tool call
+type contextClient interface {
+	WithContext(ctx context.Context) *client.PortainerClient
+}
+
+// client returns the client a tool call sends its Portainer requests with. The requests carry the context
+// of the call, so that they are traced as children of its span and cancelled with it. Clients that do not
+// take a context, such as custom clients set with WithClient, are returned as is.
+func (s *PortainerMCPServer) client(ctx context.Context) PortainerClient {
+	if cli, ok := s.cli.(contextClient); ok {
+		return cli.WithContext(ctx)
+	}
+	return s.cli
 }
 
 // ServerOption is a function that configures the server
@@ -95,8 +259,31 @@ type serverOptions struct {
 	client              PortainerClient
 	readOnly            bool
 	disableVersionCheck bool
+	hideVersionInfo     bool
+	responseFormat      ResponseFormat
+	maxResponseBytes    int
+	toolPriority        []string
+	clientOptions       []client.ClientOption
+	tracerProvider      trace.TracerProvider
+	startupAttempts     int
+	startupDelay        time.Duration
+	cachedVersion       string
+	versionCacheTTL     time.Duration
+	httpCompression     bool
+	snapshotMetricsTTL  time.Duration
+	// The client options are also recorded to report them in the server config
+	connectionPool            *models.ConnectionPoolConfig
+	allowedStackURLHosts      []string
+	edgeAgentOfflineThreshold time.Duration
+	dockerAPIVersion          string
 }
 
+// maxStartupRetryDelay caps the delay between two attempts of the startup version check
+const maxStartupRetryDelay = 30 * time.Second
+
+// startupSleep waits between two attempts of the startup version check, it is replaced in tests
+var startupSleep = time.Sleep
+
 // WithClient sets a custom client for the server.
 // This is primarily used for testing to inject mock clients.
 func WithClient(client PortainerClient) ServerOption {
@@ -121,13 +308,206 @@ func WithDisableVersionCheck(disable bool) ServerOption {
 	}
 }
 
+// WithHideVersionInfo hides the version of the MCP server and the supported Portainer version from
+// the server information sent to MCP clients and from the getServerConfig tool, to reduce the
+// fingerprinting surface of servers exposed over the HTTP transport. The startup version check of
+// the Portainer server still runs.
+func WithHideVersionInfo(hide bool) ServerOption {
+	return func(opts *serverOptions) {
+		opts.hideVersionInfo = hide
+	}
+}
+
+// WithCachedVersion supplies the version of the Portainer server so that the startup version check
+// does not call the server. The version is still checked against the supported version.
+// Note that the live check also verifies that the server is reachable and that the token is valid,
+// with a cached version these errors only surface on the first tool call.
+func WithCachedVersion(version string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.cachedVersion = version
+	}
+}
+
+// WithVersionCacheTTL caches the version read by the startup version check for the given duration,
+// keyed by server URL. The servers created in the same process for the same Portainer server within
+// the TTL reuse the cached version instead of calling the server, which speeds up the startup of
+// short-lived servers, e.g. one server per request in a gateway. A TTL of zero disables the cache.
+func WithVersionCacheTTL(ttl time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		opts.versionCacheTTL = ttl
+	}
+}
+
+// WithResponseFormat sets the format used to render the results of every tool.
+// ResponseFormatDefault keeps the results as produced by the handlers.
+func WithResponseFormat(format ResponseFormat) ServerOption {
+	return func(opts *serverOptions) {
+		opts.responseFormat = format
+	}
+}
+
+// WithMaxResponseBytes limits the size of the text of every tool result to n bytes so that large results
+// do not overflow the context of the AI model. Larger results are truncated and a JSON document holding
+// "truncated": true and the original size is appended to them. Values lower than or equal to zero disable the limit.
+func WithMaxResponseBytes(n int) ServerOption {
+	return func(opts *serverOptions) {
+		opts.maxResponseBytes = n
+	}
+}
+
+// WithToolPriority sets the order in which the listed tools are presented to MCP clients.
+// The listed tools are presented first, in the given order, followed by all the other tools sorted by name.
+// This only affects the order of the tools list: tools removed from the tools file or not loaded
+// in read-only mode are not registered and their names are ignored.
+// MCP clients are not required to honor the order of the tools list.
+func WithToolPriority(order []string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.toolPriority = order
+	}
+}
+
+// WithStartupRetry retries the version check done at startup when the Portainer server is not ready yet,
+// typically because both are started at the same time by Compose or Kubernetes. The check is attempted
+// at most attempts times, waiting delay before the first retry and doubling the delay after each retry,
+// up to 30 seconds. Only connection errors and 5xx status codes are retried, authentication failures and
+// unsupported versions fail immediately. Values lower than or equal to one attempt disable the retry.
+func WithStartupRetry(attempts int, delay time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		opts.startupAttempts = attempts
+		opts.startupDelay = delay
+	}
+}
+
+// WithTransportConfig tunes the connection pool of the HTTP transport used to talk to the Portainer server.
+// See client.WithTransportConfig for details, it has no effect when a custom client is set with WithClient.
+func WithTransportConfig(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		opts.clientOptions = append(opts.clientOptions, client.WithTransportConfig(maxIdleConns, maxIdleConnsPerHost, idleTimeout))
+		opts.connectionPool = &models.ConnectionPoolConfig{
+			MaxIdleConns:        maxIdleConns,
+			MaxIdleConnsPerHost: maxIdleConnsPerHost,
+			IdleConnTimeout:     formatOptionDuration(idleTimeout),
+		}
+	}
+}
+
+// WithAllowedStackURLHosts restricts the hosts stack files can be fetched from when creating a stack from a URL.
+// See client.WithAllowedStackURLHosts for details, it has no effect when a custom client is set with WithClient.
+func WithAllowedStackURLHosts(hosts []string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.clientOptions = append(opts.clientOptions, client.WithAllowedStackURLHosts(hosts))
+		opts.allowedStackURLHosts = append(opts.allowedStackURLHosts, hosts...)
+	}
+}
+
+// WithEdgeAgentOfflineThreshold sets how long an edge agent can go without checking in before it is reported offline.
+// See client.WithEdgeAgentOfflineThreshold for details, it has no effect when a custom client is set with WithClient.
+func WithEdgeAgentOfflineThreshold(threshold time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		opts.clientOptions = append(opts.clientOptions, client.WithEdgeAgentOfflineThreshold(threshold))
+		opts.edgeAgentOfflineThreshold = threshold
+	}
+}
+
+// WithDockerAPIVersion sets the highest Docker API version the Docker proxy requests are sent with.
+// See client.WithDockerAPIVersion for details, it has no effect when a custom client is set with WithClient.
+func WithDockerAPIVersion(version string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.clientOptions = append(opts.clientOptions, client.WithDockerAPIVersion(version))
+		opts.dockerAPIVersion = version
+	}
+}
+
+// ParseAllowedHosts converts a comma-separated list of host names into the hosts accepted by WithAllowedStackURLHosts.
+// Empty entries and surrounding spaces are ignored.
+func ParseAllowedHosts(list string) []string {
+	var hosts []string
+	for _, host := range strings.Split(list, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+// WithTracerProvider traces the tool calls and the requests sent to the Portainer server with the given
+// tracer provider. The trace context of inbound HTTP requests is propagated to the tool call spans.
+// Nothing is traced when no tracer provider is set.
+func WithTracerProvider(tp trace.TracerProvider) ServerOption {
+	return func(opts *serverOptions) {
+		opts.tracerProvider = tp
+		opts.clientOptions = append(opts.clientOptions, client.WithTracerProvider(tp))
+	}
+}
+
+// WithHTTPCompression compresses the responses of the HTTP transport with gzip for the clients sending
+// an Accept-Encoding header accepting it. The server-sent event streams are never compressed so that
+// the events are not held back. It has no effect on the stdio transport.
+func WithHTTPCompression(enabled bool) ServerOption {
+	return func(opts *serverOptions) {
+		opts.httpCompression = enabled
+	}
+}
+
+// WithSnapshotMetrics serves the latest snapshots of the environments in the Prometheus text format on
+// the snapshotMetricsPath path of the HTTP transport. The snapshots are read from the Portainer server
+// when the metrics are scraped, at most once per TTL. The metrics are not served when the TTL is not
+// positive, nor on the stdio transport.
+func WithSnapshotMetrics(ttl time.Duration) ServerOption {
+	return func(opts *serverOptions) {
+		opts.snapshotMetricsTTL = ttl
+	}
+}
+
+// getVersionWithRetry gets the version of the Portainer server, retrying transient errors with an
+// exponential backoff as configured with WithStartupRetry.
+func getVersionWithRetry(cli PortainerClient, attempts int, delay time.Duration) (string, error) {
+	for attempt := 1; ; attempt++ {
+		version, err := cli.GetVersion()
+		if err == nil || attempt >= attempts || !client.IsTransientError(err) {
+			return version, err
+		}
+
+		log.Printf("Portainer server not ready (attempt %d of %d): %v, retrying in %s", attempt, attempts, err, delay)
+		startupSleep(delay)
+		delay = min(2*delay, maxStartupRetryDelay)
+	}
+}
+
+// getServerVersion gets the version of the Portainer server for the startup version check: the version
+// supplied with WithCachedVersion, the version cached with WithVersionCacheTTL or else the version
+// read from the server.
+func getServerVersion(serverURL string, cli PortainerClient, opts *serverOptions) (string, error) {
+	if opts.cachedVersion != "" {
+		return opts.cachedVersion, nil
+	}
+
+	if opts.versionCacheTTL > 0 {
+		if version, ok := versionCache.get(serverURL); ok {
+			return version, nil
+		}
+	}
+
+	version, err := getVersionWithRetry(cli, opts.startupAttempts, opts.startupDelay)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.versionCacheTTL > 0 {
+		versionCache.set(serverURL, version, opts.versionCacheTTL)
+	}
+
+	return version, nil
+}
+
 // NewPortainerMCPServer creates a new Portainer MCP server.
 //
 // This server provides an implementation of the MCP protocol for Portainer,
 // allowing AI assistants to interact with Portainer through a structured API.
 //
 // Parameters:
-//   - serverURL: The base URL of the Portainer server (e.g., "https://portainer.example.com")
+//   - serverURL: The base URL of the Portainer server (e.g., "https://portainer.example.com"), or "unix:///path/to/socket" for a Unix socket
 //   - token: The API token for authenticating with the Portainer server
 //   - toolsPath: Path to the tools.yaml file that defines the available MCP tools
 //   - options: Optional functional options for customizing server behavior (e.g., WithClient)
@@ -147,7 +527,7 @@ func NewPortainerMCPServer(serverURL, token, toolsPath string, options ...Server
 		option(opts)
 	}
 
-	tools, err := toolgen.LoadToolsFromYAML(toolsPath, MinimumToolsVersion)
+	tools, err := toolgen.LoadToolsFromYAML(toolsPath, MinimumToolsVersion, MaximumToolsVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tools: %w", err)
 	}
@@ -156,11 +536,11 @@ func NewPortainerMCPServer(serverURL, token, toolsPath string, options ...Server
 	if opts.client != nil {
 		portainerClient = opts.client
 	} else {
-		portainerClient = client.NewPortainerClient(serverURL, token, client.WithSkipTLSVerify(true))
+		portainerClient = client.NewPortainerClient(serverURL, token, append(opts.clientOptions, client.WithSkipTLSVerify(true))...)
 	}
 
 	if !opts.disableVersionCheck {
-		version, err := portainerClient.GetVersion()
+		version, err := getServerVersion(serverURL, portainerClient, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Portainer server version: %w", err)
 		}
@@ -170,22 +550,52 @@ func NewPortainerMCPServer(serverURL, token, toolsPath string, options ...Server
 		}
 	}
 
+	serverOpts := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithLogging(),
+	}
+	if len(opts.toolPriority) > 0 {
+		serverOpts = append(serverOpts, server.WithToolFilter(prioritizeTools(opts.toolPriority)))
+	}
+	if opts.tracerProvider != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(traceToolCalls(opts.tracerProvider.Tracer(tracerName))))
+	}
+
+	var snapshotMetrics *snapshotMetricsCache
+	if opts.snapshotMetricsTTL > 0 {
+		snapshotMetrics = newSnapshotMetricsCache(portainerClient, opts.snapshotMetricsTTL)
+	}
+
+	advertisedVersion := serverVersion
+	if opts.hideVersionInfo {
+		advertisedVersion = ""
+	}
+
 	return &PortainerMCPServer{
 		srv: server.NewMCPServer(
 			"Portainer MCP Server",
-			"0.5.1",
-			server.WithToolCapabilities(true),
-			server.WithLogging(),
+			advertisedVersion,
+			serverOpts...,
 		),
-		cli:      portainerClient,
-		tools:    tools,
-		readOnly: opts.readOnly,
+		cli:              portainerClient,
+		tools:            tools,
+		readOnly:         opts.readOnly,
+		responseFormat:   opts.responseFormat,
+		maxResponseBytes: opts.maxResponseBytes,
+		containerCursors: newContainerCursorStore(containerCursorTTL),
+		stackCreations:   newIdempotencyStore(stackIdempotencyTTL),
+		operations:       newOperationRegistry(),
+		traced:           opts.tracerProvider != nil,
+		httpCompression:  opts.httpCompression,
+		snapshotMetrics:  snapshotMetrics,
+		config:           newServerConfig(serverURL, token, toolsPath, opts),
 	}, nil
 }
 
 // Start begins listening for MCP protocol messages on standard input/output.
 // This is a blocking call that will run until the connection is closed.
 func (s *PortainerMCPServer) Start() error {
+	s.setTransport("stdio", 0, "")
 	return server.ServeStdio(s.srv)
 }
 
@@ -193,25 +603,38 @@ func (s *PortainerMCPServer) Start() error {
 // This allows remote clients to connect via HTTP instead of stdio.
 func (s *PortainerMCPServer) StartHTTP(port int, endpoint string) error {
 	addr := fmt.Sprintf(":%d", port)
+	s.setTransport("http", port, endpoint)
 
 	// Create a zerolog-compatible logger for the HTTP server
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Logger()
 
-	httpServer := server.NewStreamableHTTPServer(
-		s.srv,
+	httpOpts := []server.StreamableHTTPOption{
 		server.WithEndpointPath(endpoint),
-		server.WithHeartbeatInterval(30*time.Second),
-	)
+		server.WithHeartbeatInterval(30 * time.Second),
+	}
+	if s.traced {
+		httpOpts = append(httpOpts, server.WithHTTPContextFunc(extractTraceContext))
+	}
+
+	httpServer := server.NewStreamableHTTPServer(s.srv, httpOpts...)
 
 	log.Printf("Starting HTTP/SSE server on %s%s", addr, endpoint)
 
+	var mcpHandler http.Handler = httpServer
+	if s.httpCompression {
+		mcpHandler = withGzip(mcpHandler)
+	}
+
 	mux := http.NewServeMux()
-	mux.Handle(endpoint, httpServer)
+	mux.Handle(endpoint, mcpHandler)
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"ok"}`))
 	})
+	if s.snapshotMetrics != nil {
+		mux.Handle(snapshotMetricsPath, s.snapshotMetrics)
+	}
 
 	srv := &http.Server{
 		Addr:    addr,
@@ -223,11 +646,73 @@ func (s *PortainerMCPServer) StartHTTP(port int, endpoint string) error {
 	return srv.ListenAndServe()
 }
 
+// RegisterCustomTool adds a tool that is not defined in the tools.yaml file.
+// This allows embedders to expose their own operations next to the built-in tools.
+// When the server runs in read-only mode, only tools whose ReadOnlyHint annotation
+// is explicitly set to true are registered.
+// Registering a tool with the name of an already registered tool replaces it.
+func (s *PortainerMCPServer) RegisterCustomTool(def mcp.Tool, handler server.ToolHandlerFunc) {
+	if s.readOnly && !isReadOnlyTool(def) {
+		log.Printf("Custom tool %s is not read-only, will not be registered in read-only mode", def.Name)
+		return
+	}
+
+	s.registerTool(def, handler)
+}
+
+// RegisteredTools returns the sorted names of all the tools registered on the server,
+// including custom tools added with RegisterCustomTool.
+func (s *PortainerMCPServer) RegisteredTools() []string {
+	s.registeredMu.RLock()
+	defer s.registeredMu.RUnlock()
+
+	names := make([]string, 0, len(s.registered))
+	for name := range s.registered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// GetToolDefinitions returns the definitions of all the tools registered on the server, keyed by name.
+// Tools filtered out in read-only mode are not registered and are not part of the definitions.
+// The returned map is a copy and can be modified by the caller.
+func (s *PortainerMCPServer) GetToolDefinitions() map[string]mcp.Tool {
+	s.registeredMu.RLock()
+	defer s.registeredMu.RUnlock()
+
+	definitions := make(map[string]mcp.Tool, len(s.registered))
+	for name, tool := range s.registered {
+		definitions[name] = tool
+	}
+
+	return definitions
+}
+
 // addToolIfExists adds a tool to the server if it exists in the tools map
 func (s *PortainerMCPServer) addToolIfExists(toolName string, handler server.ToolHandlerFunc) {
 	if tool, exists := s.tools[toolName]; exists {
-		s.srv.AddTool(tool, handler)
+		s.registerTool(tool, handler)
 	} else {
 		log.Printf("Tool %s not found, will not be registered for MCP usage", toolName)
 	}
 }
+
+// registerTool adds a tool to the underlying MCP server and records its definition
+func (s *PortainerMCPServer) registerTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	s.srv.AddTool(tool, withMaxResponseBytes(s.maxResponseBytes, withResponseFormat(s.responseFormat, s.withPermissionHints(tool.Name, handler))))
+
+	s.registeredMu.Lock()
+	defer s.registeredMu.Unlock()
+
+	if s.registered == nil {
+		s.registered = make(map[string]mcp.Tool)
+	}
+	s.registered[tool.Name] = tool
+}
+
+// isReadOnlyTool reports whether the tool is annotated as read-only
+func isReadOnlyTool(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
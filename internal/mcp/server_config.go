@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// AddServerConfigFeatures registers the tool describing the configuration of the server
+func (s *PortainerMCPServer) AddServerConfigFeatures() {
+	s.addToolIfExists(ToolGetServerConfig, s.HandleGetServerConfig())
+}
+
+func (s *PortainerMCPServer) HandleGetServerConfig() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := json.Marshal(s.GetServerConfig())
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal server config", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// GetServerConfig returns the effective configuration of the server, as built from the options it was
+// created with, with the secrets redacted. The transport is only known once the server is started.
+func (s *PortainerMCPServer) GetServerConfig() models.ServerConfig {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	config := s.config
+	config.ToolPriority = slices.Clone(config.ToolPriority)
+	config.AllowedStackURLHosts = slices.Clone(config.AllowedStackURLHosts)
+	if config.ConnectionPool != nil {
+		pool := *config.ConnectionPool
+		config.ConnectionPool = &pool
+	}
+	config.RegisteredTools = s.registeredToolCount()
+
+	return config
+}
+
+// registeredToolCount returns the number of tools registered on the server
+func (s *PortainerMCPServer) registeredToolCount() int {
+	s.registeredMu.RLock()
+	defer s.registeredMu.RUnlock()
+
+	return len(s.registered)
+}
+
+// setTransport records the transport the server was started with
+func (s *PortainerMCPServer) setTransport(transport string, port int, endpoint string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	s.config.Transport = transport
+	s.config.HTTPPort = port
+	s.config.HTTPEndpoint = endpoint
+}
+
+// newServerConfig builds the configuration of a server from its options
+func newServerConfig(serverURL, token, toolsPath string, opts *serverOptions) models.ServerConfig {
+	config := models.ServerConfig{
+		Version:                   serverVersion,
+		SupportedPortainerVersion: SupportedPortainerVersion,
+		PortainerURL:              redactURL(serverURL),
+		ToolsPath:                 toolsPath,
+		ReadOnly:                  opts.readOnly,
+		DisableVersionCheck:       opts.disableVersionCheck,
+		HideVersionInfo:           opts.hideVersionInfo,
+		CachedVersion:             opts.cachedVersion,
+		VersionCacheTTL:           formatOptionDuration(opts.versionCacheTTL),
+		StartupAttempts:           opts.startupAttempts,
+		StartupDelay:              formatOptionDuration(opts.startupDelay),
+		ResponseFormat:            string(opts.responseFormat),
+		MaxResponseBytes:          max(opts.maxResponseBytes, 0),
+		ToolPriority:              slices.Clone(opts.toolPriority),
+		HTTPCompression:           opts.httpCompression,
+		Tracing:                   opts.tracerProvider != nil,
+		CustomClient:              opts.client != nil,
+		SkipTLSVerify:             opts.client == nil,
+		ConnectionPool:            opts.connectionPool,
+		AllowedStackURLHosts:      slices.Clone(opts.allowedStackURLHosts),
+		EdgeAgentOfflineThreshold: formatOptionDuration(opts.edgeAgentOfflineThreshold),
+		DockerAPIVersion:          opts.dockerAPIVersion,
+		SnapshotMetricsTTL:        formatOptionDuration(max(opts.snapshotMetricsTTL, 0)),
+	}
+	if config.ResponseFormat == "" {
+		config.ResponseFormat = "default"
+	}
+	if config.ToolPriority == nil {
+		config.ToolPriority = []string{}
+	}
+	if config.AllowedStackURLHosts == nil {
+		config.AllowedStackURLHosts = []string{}
+	}
+	if token != "" {
+		config.Token = models.RedactedValue
+	}
+	if opts.hideVersionInfo {
+		config.Version = ""
+		config.SupportedPortainerVersion = ""
+		config.CachedVersion = ""
+	}
+
+	return config
+}
+
+// redactURL redacts the password of the user information of a URL, URLs that cannot be parsed are
+// redacted entirely as they could hold credentials
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return models.RedactedValue
+	}
+
+	return u.Redacted()
+}
+
+// formatOptionDuration formats the duration of an option, empty when the option is not set
+func formatOptionDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	return d.String()
+}
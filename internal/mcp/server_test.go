@@ -3,10 +3,23 @@ package mcp
 import (
 	"context"
 	"errors"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/internal/tooldef"
+	"github.com/portainer/portainer-mcp/pkg/portainer/rawclient"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -53,6 +66,15 @@ func TestNewPortainerMCPServer(t *testing.T) {
 			expectError:   true,
 			errorContains: "invalid version in tools.yaml",
 		},
+		{
+			name:          "tools version newer than supported",
+			serverURL:     "https://portainer.example.com",
+			token:         "valid-token",
+			toolsPath:     "testdata/newer_tools.yaml",
+			mockSetup:     func(m *MockPortainerClient) {},
+			expectError:   true,
+			errorContains: "tools.yaml version v99.0 is newer than the maximum supported version " + MaximumToolsVersion,
+		},
 		{
 			name:      "API communication error",
 			serverURL: "https://portainer.example.com",
@@ -129,6 +151,154 @@ func TestNewPortainerMCPServer(t *testing.T) {
 	}
 }
 
+func TestEmbeddedToolsVersionIsSupported(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "tools.yaml")
+	require.NoError(t, os.WriteFile(filePath, tooldef.ToolsFile, 0644))
+
+	_, err := toolgen.LoadToolsFromYAML(filePath, MinimumToolsVersion, MaximumToolsVersion)
+
+	assert.NoError(t, err, "MaximumToolsVersion must be raised with the version of the embedded tools.yaml file")
+}
+
+func TestNewPortainerMCPServerStartupRetry(t *testing.T) {
+	connectionRefused := &url.Error{Op: "Get", URL: "https://portainer.example.com/api/system/status", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+
+	tests := []struct {
+		name          string
+		attempts      int
+		mockSetup     func(*MockPortainerClient)
+		expectedSleep []time.Duration
+		errorContains string
+	}{
+		{
+			name:     "server ready after retries",
+			attempts: 5,
+			mockSetup: func(m *MockPortainerClient) {
+				m.On("GetVersion").Return("", connectionRefused).Times(3)
+				m.On("GetVersion").Return(SupportedPortainerVersion, nil).Once()
+			},
+			expectedSleep: []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second},
+		},
+		{
+			name:     "attempts exhausted",
+			attempts: 2,
+			mockSetup: func(m *MockPortainerClient) {
+				m.On("GetVersion").Return("", connectionRefused).Times(2)
+			},
+			expectedSleep: []time.Duration{10 * time.Second},
+			errorContains: "connection refused",
+		},
+		{
+			name:     "authentication failure not retried",
+			attempts: 5,
+			mockSetup: func(m *MockPortainerClient) {
+				m.On("GetVersion").Return("", &rawclient.APIError{StatusCode: http.StatusUnauthorized}).Once()
+			},
+			errorContains: "status 401",
+		},
+		{
+			name:     "server error retried",
+			attempts: 3,
+			mockSetup: func(m *MockPortainerClient) {
+				m.On("GetVersion").Return("", &rawclient.APIError{StatusCode: http.StatusServiceUnavailable}).Once()
+				m.On("GetVersion").Return(SupportedPortainerVersion, nil).Once()
+			},
+			expectedSleep: []time.Duration{10 * time.Second},
+		},
+		{
+			name:     "retry disabled",
+			attempts: 0,
+			mockSetup: func(m *MockPortainerClient) {
+				m.On("GetVersion").Return("", connectionRefused).Once()
+			},
+			errorContains: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var slept []time.Duration
+			startupSleep = func(d time.Duration) { slept = append(slept, d) }
+			defer func() { startupSleep = time.Sleep }()
+
+			mockClient := new(MockPortainerClient)
+			tt.mockSetup(mockClient)
+
+			server, err := NewPortainerMCPServer("https://portainer.example.com", "valid-token", "testdata/valid_tools.yaml",
+				WithClient(mockClient), WithStartupRetry(tt.attempts, 10*time.Second))
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, server)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, server)
+			}
+			assert.Equal(t, tt.expectedSleep, slept)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewPortainerMCPServerVersionCache(t *testing.T) {
+	t.Run("cached version skips the live check", func(t *testing.T) {
+		mockClient := new(MockPortainerClient)
+
+		server, err := NewPortainerMCPServer("https://portainer.example.com", "valid-token", "testdata/valid_tools.yaml",
+			WithClient(mockClient), WithCachedVersion(SupportedPortainerVersion))
+
+		require.NoError(t, err)
+		assert.NotNil(t, server)
+		mockClient.AssertNotCalled(t, "GetVersion")
+	})
+
+	t.Run("unsupported cached version", func(t *testing.T) {
+		mockClient := new(MockPortainerClient)
+
+		server, err := NewPortainerMCPServer("https://portainer.example.com", "valid-token", "testdata/valid_tools.yaml",
+			WithClient(mockClient), WithCachedVersion("2.0.0"))
+
+		assert.ErrorContains(t, err, "unsupported Portainer server version: 2.0.0")
+		assert.Nil(t, server)
+		mockClient.AssertNotCalled(t, "GetVersion")
+	})
+
+	t.Run("version cached per server URL", func(t *testing.T) {
+		versionCache = newVersionCacheStore()
+		defer func() { versionCache = newVersionCacheStore() }()
+
+		mockClient := new(MockPortainerClient)
+		mockClient.On("GetVersion").Return(SupportedPortainerVersion, nil).Twice()
+
+		for _, serverURL := range []string{"https://portainer.example.com", "https://portainer.example.com", "https://other.example.com"} {
+			_, err := NewPortainerMCPServer(serverURL, "valid-token", "testdata/valid_tools.yaml",
+				WithClient(mockClient), WithVersionCacheTTL(time.Minute))
+			require.NoError(t, err)
+		}
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("failures are not cached", func(t *testing.T) {
+		versionCache = newVersionCacheStore()
+		defer func() { versionCache = newVersionCacheStore() }()
+
+		mockClient := new(MockPortainerClient)
+		mockClient.On("GetVersion").Return("", errors.New("connection refused")).Once()
+		mockClient.On("GetVersion").Return(SupportedPortainerVersion, nil).Once()
+
+		_, err := NewPortainerMCPServer("https://portainer.example.com", "valid-token", "testdata/valid_tools.yaml",
+			WithClient(mockClient), WithVersionCacheTTL(time.Minute))
+		assert.ErrorContains(t, err, "connection refused")
+
+		_, err = NewPortainerMCPServer("https://portainer.example.com", "valid-token", "testdata/valid_tools.yaml",
+			WithClient(mockClient), WithVersionCacheTTL(time.Minute))
+		require.NoError(t, err)
+
+		mockClient.AssertExpectations(t)
+	})
+}
+
 func TestAddToolIfExists(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -191,6 +361,185 @@ func TestAddToolIfExists(t *testing.T) {
 			// Verify if the tool exists in the tools map
 			_, toolExists := server.tools[tt.toolName]
 			assert.Equal(t, tt.exists, toolExists)
+
+			// Verify the tool is only reported as registered when it exists
+			assert.Equal(t, tt.exists, slices.Contains(server.RegisteredTools(), tt.toolName))
+		})
+	}
+}
+
+func TestRegisterCustomTool(t *testing.T) {
+	readOnlyTool := mcp.NewTool("customRead",
+		mcp.WithDescription("Custom read-only tool"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	)
+	writeTool := mcp.NewTool("customWrite",
+		mcp.WithDescription("Custom write tool"),
+		mcp.WithReadOnlyHintAnnotation(false),
+	)
+	unannotatedTool := mcp.Tool{
+		Name:        "customUnannotated",
+		Description: "Custom tool without annotations",
+	}
+
+	tests := []struct {
+		name         string
+		readOnly     bool
+		tool         mcp.Tool
+		expectExists bool
+	}{
+		{
+			name:         "read-only tool in read-write mode",
+			tool:         readOnlyTool,
+			expectExists: true,
+		},
+		{
+			name:         "write tool in read-write mode",
+			tool:         writeTool,
+			expectExists: true,
+		},
+		{
+			name:         "read-only tool in read-only mode",
+			readOnly:     true,
+			tool:         readOnlyTool,
+			expectExists: true,
+		},
+		{
+			name:         "write tool in read-only mode",
+			readOnly:     true,
+			tool:         writeTool,
+			expectExists: false,
+		},
+		{
+			name:         "unannotated tool in read-only mode",
+			readOnly:     true,
+			tool:         unannotatedTool,
+			expectExists: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &PortainerMCPServer{
+				srv:      server.NewMCPServer("Test Server", "1.0.0", server.WithToolCapabilities(true)),
+				tools:    map[string]mcp.Tool{},
+				readOnly: tt.readOnly,
+			}
+
+			handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("ok"), nil
+			}
+
+			server.RegisterCustomTool(tt.tool, handler)
+
+			assert.Equal(t, tt.expectExists, slices.Contains(server.RegisteredTools(), tt.tool.Name))
 		})
 	}
 }
+
+func TestRegisteredTools(t *testing.T) {
+	server := &PortainerMCPServer{
+		srv: server.NewMCPServer("Test Server", "1.0.0", server.WithToolCapabilities(true)),
+		tools: map[string]mcp.Tool{
+			"listThings": {Name: "listThings"},
+		},
+	}
+
+	handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+
+	assert.Empty(t, server.RegisteredTools())
+
+	server.addToolIfExists("listThings", handler)
+	server.RegisterCustomTool(mcp.NewTool("customThing"), handler)
+	server.RegisterCustomTool(mcp.NewTool("anotherThing"), handler)
+
+	assert.Equal(t, []string{"anotherThing", "customThing", "listThings"}, server.RegisteredTools())
+}
+
+func TestGetToolDefinitions(t *testing.T) {
+	tests := []struct {
+		name          string
+		readOnly      bool
+		expectedTools []string
+	}{
+		{
+			name:          "all tools registered",
+			expectedTools: []string{"createThing", "customThing", "listThings"},
+		},
+		{
+			name:          "write tools filtered in read-only mode",
+			readOnly:      true,
+			expectedTools: []string{"listThings"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &PortainerMCPServer{
+				srv: server.NewMCPServer("Test Server", "1.0.0", server.WithToolCapabilities(true)),
+				tools: map[string]mcp.Tool{
+					"listThings":  mcp.NewTool("listThings", mcp.WithDescription("List things")),
+					"createThing": mcp.NewTool("createThing", mcp.WithDescription("Create a thing")),
+				},
+				readOnly: tt.readOnly,
+			}
+
+			handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			}
+
+			server.addToolIfExists("listThings", handler)
+			if !server.readOnly {
+				server.addToolIfExists("createThing", handler)
+			}
+			server.RegisterCustomTool(mcp.NewTool("customThing"), handler)
+
+			definitions := server.GetToolDefinitions()
+
+			assert.Equal(t, tt.expectedTools, slices.Sorted(maps.Keys(definitions)))
+			assert.Equal(t, server.tools["listThings"], definitions["listThings"])
+
+			// Modifying the returned definitions must not affect the registered tools
+			delete(definitions, "listThings")
+			assert.Contains(t, server.GetToolDefinitions(), "listThings")
+		})
+	}
+}
+
+func TestRegisterCustomToolConcurrently(t *testing.T) {
+	server := &PortainerMCPServer{
+		srv: server.NewMCPServer("Test Server", "1.0.0", server.WithToolCapabilities(true)),
+	}
+	handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+
+	const toolCount = 50
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range toolCount {
+			server.RegisterCustomTool(mcp.NewTool("custom"+strconv.Itoa(i)), handler)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range toolCount {
+			server.GetToolDefinitions()
+			server.RegisteredTools()
+			server.GetServerConfig()
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, server.GetToolDefinitions(), toolCount)
+}
+
+func TestParseAllowedHosts(t *testing.T) {
+	assert.Nil(t, ParseAllowedHosts(""))
+	assert.Equal(t, []string{"raw.githubusercontent.com", "gitlab.example.com"}, ParseAllowedHosts(" raw.githubusercontent.com, ,gitlab.example.com,"))
+}
@@ -6,15 +6,29 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 func (s *PortainerMCPServer) AddSettingsFeatures() {
 	s.addToolIfExists(ToolGetSettings, s.HandleGetSettings())
+	s.addToolIfExists(ToolGetPublicSettings, s.HandleGetPublicSettings())
+	s.addToolIfExists(ToolGetAuthSettings, s.HandleGetAuthSettings())
+	s.addToolIfExists(ToolGetLicenseInfo, s.HandleGetLicenseInfo())
+	s.addToolIfExists(ToolGetSSLSettings, s.HandleGetSSLSettings())
+	s.addToolIfExists(ToolGetBrandingSettings, s.HandleGetBrandingSettings())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolUpdateAuthSettings, s.HandleUpdateAuthSettings())
+		s.addToolIfExists(ToolUpdateSSLSettings, s.HandleUpdateSSLSettings())
+		s.addToolIfExists(ToolUpdateBrandingSettings, s.HandleUpdateBrandingSettings())
+		s.addToolIfExists(ToolUpdateSessionTimeout, s.HandleUpdateSessionTimeout())
+	}
 }
 
 func (s *PortainerMCPServer) HandleGetSettings() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		settings, err := s.cli.GetSettings()
+		settings, err := s.client(ctx).GetSettings()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get settings", err), nil
 		}
@@ -27,3 +41,253 @@ func (s *PortainerMCPServer) HandleGetSettings() server.ToolHandlerFunc {
 		return mcp.NewToolResultText(string(data)), nil
 	}
 }
+
+func (s *PortainerMCPServer) HandleGetPublicSettings() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		settings, err := s.client(ctx).GetPublicSettings()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get public settings", err), nil
+		}
+
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal public settings", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetAuthSettings() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		settings, err := s.client(ctx).GetAuthSettings()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get auth settings", err), nil
+		}
+
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal auth settings", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateAuthSettings() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		method, err := parser.GetString("method", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid method parameter", err), nil
+		}
+
+		clientId, err := parser.GetString("clientId", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid clientId parameter", err), nil
+		}
+
+		settings := models.AuthSettings{
+			Method: method,
+		}
+
+		// The OAuth settings are only updated when a client ID is provided
+		if clientId != "" {
+			oauth, err := parseOAuthSettings(parser)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid oauth parameters", err), nil
+			}
+			oauth.ClientID = clientId
+			settings.OAuth = &oauth
+		}
+
+		err = s.client(ctx).UpdateAuthSettings(settings)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update auth settings", err), nil
+		}
+
+		return mcp.NewToolResultText("Auth settings updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetSSLSettings() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		settings, err := s.client(ctx).GetSSLSettings()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get SSL settings", err), nil
+		}
+
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal SSL settings", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateSSLSettings() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		certificate, err := parser.GetString("certificate", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid certificate parameter", err), nil
+		}
+
+		// The key is never included in tool results, only the parameter name is reported
+		key, err := parser.GetString("key", false)
+		if err != nil {
+			return mcp.NewToolResultError("invalid key parameter"), nil
+		}
+
+		if certificate == "" && key == "" && !parser.Has("httpEnabled") {
+			return mcp.NewToolResultError("nothing to update: provide a certificate and a key or httpEnabled"), nil
+		}
+
+		settings := models.SSLSettings{
+			Certificate: certificate,
+			Key:         key,
+		}
+
+		// HTTP is left as it is unless httpEnabled is provided
+		if parser.Has("httpEnabled") {
+			settings.HTTPEnabled, err = parser.GetBoolean("httpEnabled", false)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid httpEnabled parameter", err), nil
+			}
+		} else {
+			current, err := s.client(ctx).GetSSLSettings()
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to get SSL settings", err), nil
+			}
+			settings.HTTPEnabled = current.HTTPEnabled
+		}
+
+		err = s.client(ctx).UpdateSSLSettings(settings)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update SSL settings", err), nil
+		}
+
+		return mcp.NewToolResultText("SSL settings updated successfully. The Portainer server restarts to apply them, requests may fail until it is back."), nil
+	}
+}
+
+// parseOAuthSettings parses the OAuth settings parameters, except for the client ID.
+// Errors only reference parameter names so that the client secret never ends up in tool results or logs.
+func parseOAuthSettings(parser *toolgen.ParameterParser) (models.OAuthSettings, error) {
+	var (
+		oauth models.OAuthSettings
+		err   error
+	)
+
+	stringParams := []struct {
+		name  string
+		value *string
+	}{
+		{"clientSecret", &oauth.ClientSecret},
+		{"authorizationUri", &oauth.AuthorizationURI},
+		{"accessTokenUri", &oauth.AccessTokenURI},
+		{"resourceUri", &oauth.ResourceURI},
+		{"redirectUri", &oauth.RedirectURI},
+		{"logoutUri", &oauth.LogoutURI},
+		{"userIdentifier", &oauth.UserIdentifier},
+		{"scopes", &oauth.Scopes},
+	}
+	for _, param := range stringParams {
+		if *param.value, err = parser.GetString(param.name, false); err != nil {
+			return models.OAuthSettings{}, err
+		}
+	}
+
+	if oauth.SSO, err = parser.GetBoolean("sso", false); err != nil {
+		return models.OAuthSettings{}, err
+	}
+
+	if oauth.AutoCreateUsers, err = parser.GetBoolean("autoCreateUsers", false); err != nil {
+		return models.OAuthSettings{}, err
+	}
+
+	if oauth.DefaultTeamID, err = parser.GetInt("defaultTeamId", false); err != nil {
+		return models.OAuthSettings{}, err
+	}
+
+	return oauth, nil
+}
+
+func (s *PortainerMCPServer) HandleGetLicenseInfo() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		info, err := s.client(ctx).GetLicenseInfo()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get license info", err), nil
+		}
+
+		data, err := json.Marshal(info)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal license info", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetBrandingSettings() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		branding, err := s.client(ctx).GetBrandingSettings()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get branding settings", err), nil
+		}
+
+		data, err := json.Marshal(branding)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal branding settings", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateBrandingSettings() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		logoURL, err := parser.GetString("logoUrl", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid logoUrl parameter", err), nil
+		}
+
+		loginBanner, err := parser.GetString("loginBanner", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid loginBanner parameter", err), nil
+		}
+
+		err = s.client(ctx).UpdateBrandingSettings(models.Branding{
+			LogoURL:     logoURL,
+			LoginBanner: loginBanner,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update branding settings", err), nil
+		}
+
+		return mcp.NewToolResultText("Branding settings updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateSessionTimeout() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		sessionTimeout, err := parser.GetString("sessionTimeout", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid sessionTimeout parameter", err), nil
+		}
+
+		err = s.client(ctx).UpdateSessionTimeout(sessionTimeout)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update session timeout", err), nil
+		}
+
+		return mcp.NewToolResultText("Session timeout updated successfully. Users may have to log in again for the new session timeout to apply"), nil
+	}
+}
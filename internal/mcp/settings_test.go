@@ -3,6 +3,7 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -23,7 +24,8 @@ func TestHandleGetSettings(t *testing.T) {
 			name: "successful settings retrieval",
 			settings: models.PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
 					Method: models.AuthenticationMethodInternal,
 				},
@@ -94,3 +96,620 @@ func TestHandleGetSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGetAuthSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockSettings models.AuthSettings
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name: "successful retrieval",
+			mockSettings: models.AuthSettings{
+				Method: models.AuthenticationMethodOAuth,
+				OAuth: &models.OAuthSettings{
+					ClientID:       "portainer",
+					UserIdentifier: "email",
+					SSO:            true,
+				},
+			},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetAuthSettings").Return(tt.mockSettings, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetAuthSettings()
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var settings models.AuthSettings
+				err = json.Unmarshal([]byte(textContent.Text), &settings)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockSettings, settings)
+				assert.NotContains(t, textContent.Text, "client_secret")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateAuthSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedInput models.AuthSettings
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "update oauth settings",
+			inputParams: map[string]any{
+				"method":           "oauth",
+				"clientId":         "portainer",
+				"clientSecret":     "super-secret",
+				"authorizationUri": "https://idp.example.com/authorize",
+				"accessTokenUri":   "https://idp.example.com/token",
+				"resourceUri":      "https://idp.example.com/userinfo",
+				"redirectUri":      "https://portainer.example.com",
+				"userIdentifier":   "email",
+				"scopes":           "openid email",
+				"sso":              true,
+				"autoCreateUsers":  true,
+				"defaultTeamId":    float64(2),
+			},
+			expectCall: true,
+			expectedInput: models.AuthSettings{
+				Method: models.AuthenticationMethodOAuth,
+				OAuth: &models.OAuthSettings{
+					ClientID:         "portainer",
+					ClientSecret:     "super-secret",
+					AuthorizationURI: "https://idp.example.com/authorize",
+					AccessTokenURI:   "https://idp.example.com/token",
+					ResourceURI:      "https://idp.example.com/userinfo",
+					RedirectURI:      "https://portainer.example.com",
+					UserIdentifier:   "email",
+					Scopes:           "openid email",
+					SSO:              true,
+					AutoCreateUsers:  true,
+					DefaultTeamID:    2,
+				},
+			},
+		},
+		{
+			name:          "update authentication method only",
+			inputParams:   map[string]any{"method": "internal"},
+			expectCall:    true,
+			expectedInput: models.AuthSettings{Method: models.AuthenticationMethodInternal},
+		},
+		{
+			name:          "api error",
+			inputParams:   map[string]any{"method": "ldap"},
+			expectCall:    true,
+			expectedInput: models.AuthSettings{Method: models.AuthenticationMethodLDAP},
+			mockError:     fmt.Errorf("failed to update settings"),
+			expectError:   true,
+		},
+		{
+			name:        "missing method parameter",
+			inputParams: map[string]any{"clientId": "portainer"},
+			expectError: true,
+		},
+		{
+			name:        "invalid sso parameter",
+			inputParams: map[string]any{"method": "oauth", "clientId": "portainer", "clientSecret": "super-secret", "sso": "yes"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateAuthSettings", tt.expectedInput).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateAuthSettings()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+				assert.NotContains(t, textContent.Text, "super-secret", "client secret should not leak in errors")
+			} else {
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetLicenseInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockInfo    models.LicenseInfo
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "business edition",
+			mockInfo: models.LicenseInfo{
+				Edition:       models.EditionBusiness,
+				Type:          models.LicenseTypeSubscription,
+				Valid:         true,
+				LicensedNodes: 10,
+				UsedNodes:     7,
+				ExpiresAt:     "2026-12-01T12:00:00Z",
+				Summary:       "subscription license: 7 of 10 nodes used, expires on 2026-12-01 (48 days left)",
+			},
+		},
+		{
+			name:     "community edition",
+			mockInfo: models.CommunityLicenseInfo(),
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("api error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetLicenseInfo").Return(tt.mockInfo, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetLicenseInfo()
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				assert.False(t, result.IsError)
+				var info models.LicenseInfo
+				err = json.Unmarshal([]byte(textContent.Text), &info)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockInfo, info)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetSSLSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockSettings models.SSLSettings
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name: "successful retrieval",
+			mockSettings: models.SSLSettings{
+				CertPath:    "/certs/portainer.crt",
+				KeyPath:     "/certs/portainer.key",
+				HTTPEnabled: true,
+			},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("access denied"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetSSLSettings").Return(tt.mockSettings, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetSSLSettings()
+			result, err := handler(context.Background(), CreateMCPRequest(map[string]any{}))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var settings models.SSLSettings
+				err = json.Unmarshal([]byte(textContent.Text), &settings)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockSettings, settings)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateSSLSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCurrent bool
+		expectCall    bool
+		expectedInput models.SSLSettings
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "upload certificate keeping http",
+			inputParams: map[string]any{
+				"certificate": "-----BEGIN CERTIFICATE-----",
+				"key":         "super-secret-key",
+			},
+			expectCurrent: true,
+			expectCall:    true,
+			expectedInput: models.SSLSettings{Certificate: "-----BEGIN CERTIFICATE-----", Key: "super-secret-key", HTTPEnabled: true},
+		},
+		{
+			name:          "disable http",
+			inputParams:   map[string]any{"httpEnabled": false},
+			expectCall:    true,
+			expectedInput: models.SSLSettings{HTTPEnabled: false},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"certificate": "-----BEGIN CERTIFICATE-----",
+				"key":         "super-secret-key",
+				"httpEnabled": true,
+			},
+			expectCall:    true,
+			expectedInput: models.SSLSettings{Certificate: "-----BEGIN CERTIFICATE-----", Key: "super-secret-key", HTTPEnabled: true},
+			mockError:     fmt.Errorf("invalid certificate and key pair"),
+			expectError:   true,
+		},
+		{
+			name:        "nothing to update",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+		{
+			name:        "invalid httpEnabled parameter",
+			inputParams: map[string]any{"key": "super-secret-key", "httpEnabled": "yes"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCurrent {
+				mockClient.On("GetSSLSettings").Return(models.SSLSettings{HTTPEnabled: true}, nil)
+			}
+			if tt.expectCall {
+				mockClient.On("UpdateSSLSettings", tt.expectedInput).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateSSLSettings()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "restarts")
+			}
+			assert.NotContains(t, textContent.Text, "super-secret-key", "private key should not leak in results")
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetPublicSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockSettings models.PublicSettings
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name: "successful retrieval",
+			mockSettings: models.PublicSettings{
+				AuthenticationMethod:   models.AuthenticationMethodOAuth,
+				OAuthLoginURI:          "https://sso.example.com/authorize",
+				RequiredPasswordLength: 12,
+				Features:               map[string]bool{"disableRoleDiagnostics": false},
+			},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("connection refused"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetPublicSettings").Return(tt.mockSettings, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetPublicSettings()
+			result, err := handler(context.Background(), CreateMCPRequest(map[string]any{}))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var settings models.PublicSettings
+				err = json.Unmarshal([]byte(textContent.Text), &settings)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockSettings, settings)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetBrandingSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBranding models.Branding
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name: "successful retrieval",
+			mockBranding: models.Branding{
+				LogoURL:     "https://example.com/logo.png",
+				LoginBanner: "Authorized users only",
+			},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("access denied"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetBrandingSettings").Return(tt.mockBranding, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetBrandingSettings()
+			result, err := handler(context.Background(), CreateMCPRequest(map[string]any{}))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				var branding models.Branding
+				err = json.Unmarshal([]byte(textContent.Text), &branding)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockBranding, branding)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateBrandingSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedInput models.Branding
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "update logo and login banner",
+			inputParams: map[string]any{
+				"logoUrl":     "https://example.com/logo.png",
+				"loginBanner": "Authorized users only",
+			},
+			expectCall:    true,
+			expectedInput: models.Branding{LogoURL: "https://example.com/logo.png", LoginBanner: "Authorized users only"},
+		},
+		{
+			name:          "update login banner only",
+			inputParams:   map[string]any{"loginBanner": "Authorized users only"},
+			expectCall:    true,
+			expectedInput: models.Branding{LoginBanner: "Authorized users only"},
+		},
+		{
+			name:          "invalid logo URL",
+			inputParams:   map[string]any{"logoUrl": "ftp://example.com/logo.png"},
+			expectCall:    true,
+			expectedInput: models.Branding{LogoURL: "ftp://example.com/logo.png"},
+			mockError:     fmt.Errorf(`invalid logo URL: unsupported scheme "ftp"`),
+			expectError:   true,
+		},
+		{
+			name:        "invalid logoUrl parameter",
+			inputParams: map[string]any{"logoUrl": 42.0},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateBrandingSettings", tt.expectedInput).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateBrandingSettings()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "updated successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateSessionTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "update session timeout",
+			inputParams: map[string]any{"sessionTimeout": "8h"},
+			expectCall:  true,
+		},
+		{
+			name:        "invalid duration",
+			inputParams: map[string]any{"sessionTimeout": "8h"},
+			expectCall:  true,
+			mockError:   fmt.Errorf(`invalid session timeout "8h"`),
+			expectError: true,
+		},
+		{
+			name:        "missing sessionTimeout parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateSessionTimeout", "8h").Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateSessionTimeout()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "updated successfully")
+				assert.Contains(t, textContent.Text, "log in again")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
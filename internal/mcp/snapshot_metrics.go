@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// snapshotMetricsPath is the path of the HTTP transport serving the snapshot metrics. It is kept apart
+// from a /metrics path, which is left to the metrics of the process itself.
+const snapshotMetricsPath = "/snapshot-metrics"
+
+// snapshotMetricsContentType is the content type of the Prometheus text exposition format
+const snapshotMetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// snapshotMetricsCache serves the latest snapshots of the environments in the Prometheus text format.
+// The snapshots are read from the Portainer server when the metrics are scraped and are cached for
+// a TTL, the scrapes arriving while the snapshots are read wait for them instead of reading them again.
+// When reading the snapshots fails, the snapshots read last are served.
+type snapshotMetricsCache struct {
+	cli PortainerClient
+	ttl time.Duration
+	now func() time.Time
+
+	mu           sync.Mutex
+	snapshots    []models.EnvironmentSnapshot
+	fetchedAt    time.Time
+	refreshedAt  time.Time
+	refreshError error
+}
+
+// newSnapshotMetricsCache creates a cache of the snapshots of the environments
+func newSnapshotMetricsCache(cli PortainerClient, ttl time.Duration) *snapshotMetricsCache {
+	return &snapshotMetricsCache{
+		cli: cli,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// ServeHTTP renders the snapshot metrics, refreshing the snapshots when the TTL expired. It answers
+// with a 503 status code until the snapshots could be read once.
+func (c *snapshotMetricsCache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := c.render()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get environment snapshots: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", snapshotMetricsContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// render refreshes the snapshots when needed and renders them
+func (c *snapshotMetricsCache) render() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.refreshedAt.IsZero() || now.Sub(c.refreshedAt) >= c.ttl {
+		snapshots, err := c.cli.GetEnvironmentSnapshots()
+		c.refreshedAt, c.refreshError = now, err
+		if err == nil {
+			c.snapshots, c.fetchedAt = snapshots, now
+		} else {
+			log.Printf("Failed to refresh the snapshot metrics: %v", err)
+		}
+	}
+
+	if c.fetchedAt.IsZero() {
+		return nil, c.refreshError
+	}
+
+	return renderSnapshotMetrics(c.snapshots, c.fetchedAt, c.refreshError == nil), nil
+}
+
+// renderSnapshotMetrics renders the snapshots of the environments in the Prometheus text format. The
+// environments without a snapshot only have the portainer_environment_up metric.
+func renderSnapshotMetrics(snapshots []models.EnvironmentSnapshot, fetchedAt time.Time, refreshed bool) []byte {
+	var buf bytes.Buffer
+
+	writeSnapshotGauge(&buf, "portainer_environment_up", "Whether Portainer reports the environment as active (1) or inactive (0).", snapshots, false,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			up := int64(0)
+			if s.Status == models.EnvironmentStatusActive {
+				up = 1
+			}
+			emit("", up)
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_snapshot_timestamp_seconds", "Time of the latest snapshot of the environment.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit("", s.SnapshotTime)
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_containers", "Number of containers of the environment by state, in the latest snapshot.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit(`,state="running"`, int64(s.RunningContainers))
+			emit(`,state="stopped"`, int64(s.StoppedContainers))
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_healthy_containers", "Number of healthy containers of the environment, in the latest snapshot.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit("", int64(s.HealthyContainers))
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_unhealthy_containers", "Number of unhealthy containers of the environment, in the latest snapshot.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit("", int64(s.UnhealthyContainers))
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_stacks", "Number of stacks of the environment, in the latest snapshot.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit("", int64(s.Stacks))
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_images", "Number of images of the environment, in the latest snapshot.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit("", int64(s.Images))
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_volumes", "Number of volumes of the environment, in the latest snapshot.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit("", int64(s.Volumes))
+		})
+	writeSnapshotGauge(&buf, "portainer_environment_nodes", "Number of nodes of the environment, in the latest snapshot.", snapshots, true,
+		func(s models.EnvironmentSnapshot, emit func(labels string, value int64)) {
+			emit("", int64(s.Nodes))
+		})
+
+	success := 0
+	if refreshed {
+		success = 1
+	}
+	fmt.Fprintf(&buf, "# HELP portainer_snapshot_metrics_last_refresh_success Whether the latest refresh of the snapshots succeeded.\n")
+	fmt.Fprintf(&buf, "# TYPE portainer_snapshot_metrics_last_refresh_success gauge\n")
+	fmt.Fprintf(&buf, "portainer_snapshot_metrics_last_refresh_success %d\n", success)
+	fmt.Fprintf(&buf, "# HELP portainer_snapshot_metrics_last_refresh_timestamp_seconds Time the served snapshots were read from Portainer.\n")
+	fmt.Fprintf(&buf, "# TYPE portainer_snapshot_metrics_last_refresh_timestamp_seconds gauge\n")
+	fmt.Fprintf(&buf, "portainer_snapshot_metrics_last_refresh_timestamp_seconds %d\n", fetchedAt.Unix())
+
+	return buf.Bytes()
+}
+
+// writeSnapshotGauge writes a gauge with a sample per environment, the samples are labelled with the
+// environment and the labels given to emit. When snapshotOnly is set, the environments without a
+// snapshot are skipped.
+func writeSnapshotGauge(buf *bytes.Buffer, name, help string, snapshots []models.EnvironmentSnapshot, snapshotOnly bool,
+	samples func(s models.EnvironmentSnapshot, emit func(labels string, value int64))) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buf, "# TYPE %s gauge\n", name)
+
+	for _, s := range snapshots {
+		if snapshotOnly && s.SnapshotTime == 0 {
+			continue
+		}
+
+		environment := fmt.Sprintf(`environment_id="%d",environment_name="%s",environment_type="%s"`,
+			s.EnvironmentID, escapeLabelValue(s.EnvironmentName), escapeLabelValue(s.Type))
+		samples(s, func(labels string, value int64) {
+			fmt.Fprintf(buf, "%s{%s%s} %d\n", name, environment, labels, value)
+		})
+	}
+}
+
+// labelValueEscaper escapes the backslashes, double quotes and line feeds of the label values
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes a label value of the Prometheus text format
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
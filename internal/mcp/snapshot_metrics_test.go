@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSnapshotMetrics(t *testing.T) {
+	snapshots := []models.EnvironmentSnapshot{
+		{
+			EnvironmentID:       1,
+			EnvironmentName:     `prod "eu"`,
+			Type:                models.EnvironmentTypeDockerLocal,
+			Status:              models.EnvironmentStatusActive,
+			SnapshotTime:        1700000000,
+			RunningContainers:   5,
+			StoppedContainers:   2,
+			HealthyContainers:   3,
+			UnhealthyContainers: 1,
+			Stacks:              2,
+			Images:              10,
+			Volumes:             4,
+			Nodes:               1,
+		},
+		{
+			EnvironmentID:   2,
+			EnvironmentName: "lab",
+			Type:            models.EnvironmentTypeDockerLocal,
+			Status:          models.EnvironmentStatusInactive,
+		},
+	}
+
+	metrics := string(renderSnapshotMetrics(snapshots, time.Unix(1700000100, 0), true))
+
+	prod := `environment_id="1",environment_name="prod \"eu\"",environment_type="` + models.EnvironmentTypeDockerLocal + `"`
+	lab := `environment_id="2",environment_name="lab",environment_type="` + models.EnvironmentTypeDockerLocal + `"`
+
+	assert.Contains(t, metrics, "# HELP portainer_environment_up ")
+	assert.Contains(t, metrics, "# TYPE portainer_environment_up gauge\n")
+	assert.Contains(t, metrics, "portainer_environment_up{"+prod+"} 1\n")
+	assert.Contains(t, metrics, "portainer_environment_up{"+lab+"} 0\n")
+	assert.Contains(t, metrics, "portainer_environment_snapshot_timestamp_seconds{"+prod+"} 1700000000\n")
+	assert.Contains(t, metrics, "portainer_environment_containers{"+prod+`,state="running"} 5`+"\n")
+	assert.Contains(t, metrics, "portainer_environment_containers{"+prod+`,state="stopped"} 2`+"\n")
+	assert.Contains(t, metrics, "portainer_environment_healthy_containers{"+prod+"} 3\n")
+	assert.Contains(t, metrics, "portainer_environment_unhealthy_containers{"+prod+"} 1\n")
+	assert.Contains(t, metrics, "portainer_environment_stacks{"+prod+"} 2\n")
+	assert.Contains(t, metrics, "portainer_environment_images{"+prod+"} 10\n")
+	assert.Contains(t, metrics, "portainer_environment_volumes{"+prod+"} 4\n")
+	assert.Contains(t, metrics, "portainer_environment_nodes{"+prod+"} 1\n")
+	assert.Contains(t, metrics, "portainer_snapshot_metrics_last_refresh_success 1\n")
+	assert.Contains(t, metrics, "portainer_snapshot_metrics_last_refresh_timestamp_seconds 1700000100\n")
+
+	// The environments without a snapshot only have the up metric
+	assert.NotContains(t, metrics, "portainer_environment_containers{"+lab)
+	assert.NotContains(t, metrics, "portainer_environment_snapshot_timestamp_seconds{"+lab)
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	assert.Equal(t, `a\\b \"c\"\nd`, escapeLabelValue("a\\b \"c\"\nd"))
+}
+
+func TestSnapshotMetricsCache(t *testing.T) {
+	snapshots := []models.EnvironmentSnapshot{
+		{EnvironmentID: 1, EnvironmentName: "prod", Status: models.EnvironmentStatusActive, SnapshotTime: 1700000000, RunningContainers: 5},
+	}
+
+	scrape := func(cache *snapshotMetricsCache) (int, string, string) {
+		rec := httptest.NewRecorder()
+		cache.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, snapshotMetricsPath, nil))
+		body, _ := io.ReadAll(rec.Body)
+		return rec.Code, rec.Header().Get("Content-Type"), string(body)
+	}
+
+	t.Run("cached for the TTL", func(t *testing.T) {
+		mockClient := new(MockPortainerClient)
+		mockClient.On("GetEnvironmentSnapshots").Return(snapshots, nil).Twice()
+
+		now := time.Unix(1700000100, 0)
+		cache := newSnapshotMetricsCache(mockClient, time.Minute)
+		cache.now = func() time.Time { return now }
+
+		code, contentType, body := scrape(cache)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, snapshotMetricsContentType, contentType)
+		assert.Contains(t, body, `portainer_environment_containers{environment_id="1",environment_name="prod",environment_type="",state="running"} 5`)
+
+		now = now.Add(30 * time.Second)
+		scrape(cache)
+		mockClient.AssertNumberOfCalls(t, "GetEnvironmentSnapshots", 1)
+
+		now = now.Add(time.Minute)
+		scrape(cache)
+		mockClient.AssertNumberOfCalls(t, "GetEnvironmentSnapshots", 2)
+	})
+
+	t.Run("failed refresh serves the last snapshots", func(t *testing.T) {
+		mockClient := new(MockPortainerClient)
+		mockClient.On("GetEnvironmentSnapshots").Return(snapshots, nil).Once()
+		mockClient.On("GetEnvironmentSnapshots").Return(nil, errors.New("connection refused")).Once()
+
+		now := time.Unix(1700000100, 0)
+		cache := newSnapshotMetricsCache(mockClient, time.Minute)
+		cache.now = func() time.Time { return now }
+
+		scrape(cache)
+		now = now.Add(2 * time.Minute)
+		code, _, body := scrape(cache)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "portainer_environment_containers{")
+		assert.Contains(t, body, "portainer_snapshot_metrics_last_refresh_success 0\n")
+		assert.Contains(t, body, "portainer_snapshot_metrics_last_refresh_timestamp_seconds 1700000100\n")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("no snapshots read yet", func(t *testing.T) {
+		mockClient := new(MockPortainerClient)
+		mockClient.On("GetEnvironmentSnapshots").Return(nil, errors.New("connection refused"))
+
+		cache := newSnapshotMetricsCache(mockClient, time.Minute)
+
+		code, _, body := scrape(cache)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Contains(t, body, "connection refused")
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		mockClient := new(MockPortainerClient)
+		cache := newSnapshotMetricsCache(mockClient, time.Minute)
+
+		rec := httptest.NewRecorder()
+		cache.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, snapshotMetricsPath, nil))
+
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		mockClient.AssertNotCalled(t, "GetEnvironmentSnapshots")
+	})
+}
@@ -1,33 +1,57 @@
 package mcp
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/client"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 func (s *PortainerMCPServer) AddStackFeatures() {
 	s.addToolIfExists(ToolListStacks, s.HandleGetStacks())
 	s.addToolIfExists(ToolGetStackFile, s.HandleGetStackFile())
+	s.addToolIfExists(ToolDetectStackDrift, s.HandleDetectStackDrift())
+	s.addToolIfExists(ToolGetStackHealth, s.HandleGetStackHealth())
+	s.addToolIfExists(ToolFindStacksByImage, s.HandleFindStacksByImage())
+	s.addToolIfExists(ToolTestStackFile, s.HandleTestStackFile())
+	s.addToolIfExists(ToolFormatStackFile, s.HandleFormatStackFile())
+	s.addToolIfExists(ToolGetStackGitConfig, s.HandleGetStackGitConfig())
+	s.addToolIfExists(ToolGetStackGitDrift, s.HandleGetStackGitDrift())
+	s.addToolIfExists(ToolExportStacks, s.HandleExportStacks())
+	s.addToolIfExists(ToolGetStackAccess, s.HandleGetStackAccess())
+	s.addToolIfExists(ToolGetStackDeploymentLogs, s.HandleGetStackDeploymentLogs())
+	s.addToolIfExists(ToolRecentlyChangedStacks, s.HandleRecentlyChangedStacks())
 
 	if !s.readOnly {
 		s.addToolIfExists(ToolCreateStack, s.HandleCreateStack())
+		s.addToolIfExists(ToolCreateStackFromURL, s.HandleCreateStackFromURL())
 		s.addToolIfExists(ToolUpdateStack, s.HandleUpdateStack())
+		s.addToolIfExists(ToolCloneStack, s.HandleCloneStack())
+		s.addToolIfExists(ToolRedeployStackFromGit, s.HandleRedeployStackFromGit())
+		s.addToolIfExists(ToolUpdateStackAutoUpdate, s.HandleUpdateStackAutoUpdate())
+		s.addToolIfExists(ToolRestartStack, s.HandleRestartStack())
+		s.addToolIfExists(ToolDeleteStacksMatching, s.HandleDeleteStacksMatching())
 	}
 }
 
 func (s *PortainerMCPServer) HandleGetStacks() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		stacks, err := s.cli.GetStacks()
+		stacks, err := s.client(ctx).GetStacks()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get stacks", err), nil
 		}
 
-		data, err := json.Marshal(stacks)
+		data, err := json.Marshal(newListResponse(stacks))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to marshal stacks", err), nil
 		}
@@ -45,7 +69,7 @@ func (s *PortainerMCPServer) HandleGetStackFile() server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
 		}
 
-		stackFile, err := s.cli.GetStackFile(id)
+		stackFile, err := s.client(ctx).GetStackFile(id)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get stack file", err), nil
 		}
@@ -73,11 +97,58 @@ func (s *PortainerMCPServer) HandleCreateStack() server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid environmentGroupIds parameter", err), nil
 		}
 
-		id, err := s.cli.CreateStack(name, file, environmentGroupIds)
+		idempotencyKey, err := parser.GetString("idempotencyKey", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid idempotencyKey parameter", err), nil
+		}
+
+		if idempotencyKey == "" {
+			id, err := s.client(ctx).CreateStack(name, file, environmentGroupIds)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("error creating stack", err), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Stack created successfully with ID: %d", id)), nil
+		}
+
+		id, existing, err := s.stackCreations.do(idempotencyKey, stackFingerprint(name, file, environmentGroupIds), func() (int, error) {
+			return s.client(ctx).CreateStack(name, file, environmentGroupIds)
+		})
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("error creating stack", err), nil
 		}
 
+		if existing {
+			return mcp.NewToolResultText(fmt.Sprintf("Stack already created with ID: %d, no new stack was created for idempotency key %s", id, idempotencyKey)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Stack created successfully with ID: %d", id)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCreateStackFromURL() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		name, err := parser.GetString("name", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
+		}
+
+		composeURL, err := parser.GetString("url", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid url parameter", err), nil
+		}
+
+		environmentGroupIds, err := parser.GetArrayOfIntegers("environmentGroupIds", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentGroupIds parameter", err), nil
+		}
+
+		id, err := s.client(ctx).CreateStackFromURL(name, composeURL, environmentGroupIds)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("error creating stack from URL", err), nil
+		}
+
 		return mcp.NewToolResultText(fmt.Sprintf("Stack created successfully with ID: %d", id)), nil
 	}
 }
@@ -101,7 +172,7 @@ func (s *PortainerMCPServer) HandleUpdateStack() server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid environmentGroupIds parameter", err), nil
 		}
 
-		err = s.cli.UpdateStack(id, file, environmentGroupIds)
+		err = s.client(ctx).UpdateStack(id, file, environmentGroupIds)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update stack", err), nil
 		}
@@ -109,3 +180,508 @@ func (s *PortainerMCPServer) HandleUpdateStack() server.ToolHandlerFunc {
 		return mcp.NewToolResultText("Stack updated successfully"), nil
 	}
 }
+
+func (s *PortainerMCPServer) HandleDetectStackDrift() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		stackId, err := parser.GetInt("stackId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid stackId parameter", err), nil
+		}
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		report, err := s.client(ctx).DetectStackDrift(stackId, environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to detect stack drift", err), nil
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal drift report", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetStackDeploymentLogs() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		stackId, err := parser.GetInt("stackId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid stackId parameter", err), nil
+		}
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		log, err := s.client(ctx).GetStackDeploymentLogs(stackId, environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get stack deployment logs", err), nil
+		}
+
+		return mcp.NewToolResultText(log), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleCloneStack() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		sourceStackId, err := parser.GetInt("sourceStackId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid sourceStackId parameter", err), nil
+		}
+
+		name, err := parser.GetString("name", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
+		}
+
+		environmentGroupIds, err := parser.GetArrayOfIntegers("environmentGroupIds", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentGroupIds parameter", err), nil
+		}
+
+		imageReplacements, err := parser.GetArrayOfObjects("imageReplacements", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid imageReplacements parameter", err), nil
+		}
+		imageReplacementsMap, err := parseKeyValueMap(imageReplacements)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid image replacements", err), nil
+		}
+
+		id, err := s.client(ctx).CloneStack(sourceStackId, name, environmentGroupIds, imageReplacementsMap)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("error cloning stack", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Stack cloned successfully with ID: %d", id)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetStackHealth() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		health, err := s.client(ctx).GetStackHealth(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get stack health", err), nil
+		}
+
+		data, err := json.Marshal(health)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stack health", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetStackAccess() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		access, err := s.client(ctx).GetStackAccess(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get stack access", err), nil
+		}
+
+		data, err := json.Marshal(access)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stack access", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleFindStacksByImage() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		imagePattern, err := parser.GetString("imagePattern", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid imagePattern parameter", err), nil
+		}
+
+		stacks, err := s.client(ctx).FindStacksByImage(imagePattern)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to find stacks by image", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(stacks))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stacks", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleRecentlyChangedStacks() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		limit, err := parser.GetInt("limit", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid limit parameter", err), nil
+		}
+		if limit < 0 {
+			return mcp.NewToolResultError("limit must be a positive number"), nil
+		}
+
+		// All the stacks are retrieved so that the total count and hasMore account for the stacks left out
+		stacks, err := s.client(ctx).GetRecentlyChangedStacks(0)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get recently changed stacks", err), nil
+		}
+
+		data, err := json.Marshal(newLimitedListResponse(stacks, limit))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stacks", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleTestStackFile() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		file, err := parser.GetString("file", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid file parameter", err), nil
+		}
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		result, err := s.client(ctx).TestStackFile(file, environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to test stack file", err), nil
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stack test result", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleFormatStackFile() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		file, err := parser.GetString("file", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid file parameter", err), nil
+		}
+
+		formatted, err := s.client(ctx).FormatComposeFile(file)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to format stack file", err), nil
+		}
+
+		return mcp.NewToolResultText(formatted), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetStackGitConfig() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		config, err := s.client(ctx).GetStackGitConfig(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get stack git config", err), nil
+		}
+
+		data, err := json.Marshal(config)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stack git config", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetStackGitDrift() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		drift, err := s.client(ctx).GetStackGitDrift(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get stack git drift", err), nil
+		}
+
+		data, err := json.Marshal(drift)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stack git drift", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleRedeployStackFromGit() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		pullImage, err := parser.GetBoolean("pullImage", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid pullImage parameter", err), nil
+		}
+
+		if err := s.client(ctx).RedeployStackFromGit(id, pullImage); err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to redeploy stack from git", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Stack %d redeployed successfully from git", id)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateStackAutoUpdate() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		opts := models.AutoUpdateOptions{}
+
+		if parser.Has("interval") {
+			interval, err := parser.GetString("interval", true)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid interval parameter", err), nil
+			}
+			opts.Interval = &interval
+		}
+
+		if parser.Has("webhook") {
+			webhook, err := parser.GetBoolean("webhook", true)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid webhook parameter", err), nil
+			}
+			opts.Webhook = &webhook
+		}
+
+		if parser.Has("forcePullImage") {
+			forcePullImage, err := parser.GetBoolean("forcePullImage", true)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid forcePullImage parameter", err), nil
+			}
+			opts.ForcePullImage = &forcePullImage
+		}
+
+		settings, err := s.client(ctx).UpdateStackAutoUpdate(id, opts)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update stack auto update", err), nil
+		}
+
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal auto update settings", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleExportStacks() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		format, err := parser.GetString("format", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid format parameter", err), nil
+		}
+		if format == "" {
+			format = StackExportFormatJSON
+		}
+		if format != StackExportFormatJSON && format != StackExportFormatZip {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid format %s: must be %s or %s", format, StackExportFormatJSON, StackExportFormatZip)), nil
+		}
+
+		stacks, err := s.client(ctx).ExportAllStacks()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to export stacks", err), nil
+		}
+
+		export := models.StackExport{
+			Format:     format,
+			StackCount: len(stacks),
+		}
+		for name, file := range stacks {
+			if file == "" {
+				export.EmptyStacks = append(export.EmptyStacks, name)
+			}
+		}
+		slices.Sort(export.EmptyStacks)
+
+		if format == StackExportFormatZip {
+			archive, err := buildStackExportArchive(stacks)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to build stack archive", err), nil
+			}
+			export.Archive = base64.StdEncoding.EncodeToString(archive)
+		} else {
+			export.Stacks = stacks
+		}
+
+		data, err := json.Marshal(export)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal stack export", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// buildStackExportArchive bundles the stack files in a zip archive, one <name>.yml file per stack.
+// Files are added in name order so that the same stacks always produce the same archive.
+func buildStackExportArchive(stacks map[string]string) ([]byte, error) {
+	names := make([]string, 0, len(stacks))
+	for name := range stacks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	for _, name := range names {
+		w, err := archive.Create(name + ".yml")
+		if err != nil {
+			return nil, fmt.Errorf("failed to add stack %s to archive: %w", name, err)
+		}
+
+		if _, err := w.Write([]byte(stacks[name])); err != nil {
+			return nil, fmt.Errorf("failed to add stack %s to archive: %w", name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *PortainerMCPServer) HandleRestartStack() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		stackId, err := parser.GetInt("stackId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid stackId parameter", err), nil
+		}
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		report, err := s.client(ctx).RestartStack(stackId, environmentId)
+
+		var restartErr *client.StackRestartError
+		if err != nil && !errors.As(err, &restartErr) {
+			return mcp.NewToolResultErrorFromErr("failed to restart stack", err), nil
+		}
+
+		data, marshalErr := json.Marshal(report)
+		if marshalErr != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal restart report", marshalErr), nil
+		}
+
+		// Some containers were restarted, the report is returned along with the failures
+		if restartErr != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%v, report: %s", restartErr, data)), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleDeleteStacksMatching() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		namePattern, err := parser.GetString("namePattern", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid namePattern parameter", err), nil
+		}
+
+		environmentGroupId, err := parser.GetInt("environmentGroupId", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentGroupId parameter", err), nil
+		}
+
+		confirm, err := parser.GetBoolean("confirm", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid confirm parameter", err), nil
+		}
+
+		result, err := s.client(ctx).DeleteStacksMatching(models.StackFilter{
+			NamePattern:        namePattern,
+			EnvironmentGroupID: environmentGroupId,
+			Confirm:            confirm,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to delete stacks", err), nil
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal delete result", err), nil
+		}
+
+		// Some stacks were deleted, the result is returned along with the failures
+		if result.FailedCount > 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to delete %d of %d stacks, result: %s",
+				result.FailedCount, len(result.Stacks), data)), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
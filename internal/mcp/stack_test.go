@@ -1,14 +1,20 @@
 package mcp
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/client"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandleGetStacks(t *testing.T) {
@@ -65,10 +71,12 @@ func TestHandleGetStacks(t *testing.T) {
 				textContent, ok := result.Content[0].(mcp.TextContent)
 				assert.True(t, ok)
 
-				var stacks []models.Stack
+				var stacks listResponse[models.Stack]
 				err = json.Unmarshal([]byte(textContent.Text), &stacks)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockStacks, stacks)
+				assert.Equal(t, tt.mockStacks, stacks.Items)
+				assert.Equal(t, len(tt.mockStacks), stacks.TotalCount)
+				assert.False(t, stacks.HasMore)
 			}
 
 			mockClient.AssertExpectations(t)
@@ -295,6 +303,127 @@ func TestHandleCreateStack(t *testing.T) {
 	}
 }
 
+func TestHandleCreateStackIdempotencyKey(t *testing.T) {
+	file := "services:\n  web:\n    image: nginx"
+	params := map[string]any{
+		"name":                "test-stack",
+		"file":                file,
+		"environmentGroupIds": []any{float64(1)},
+		"idempotencyKey":      "5b1c7a0e-2f4d-4f8e-9a51-0c2d3e4f5a6b",
+	}
+
+	mockClient := &MockPortainerClient{}
+	mockClient.On("CreateStack", "test-stack", file, []int{1}).Return(4, nil).Once()
+
+	server := &PortainerMCPServer{
+		cli:            mockClient,
+		stackCreations: newIdempotencyStore(stackIdempotencyTTL),
+	}
+	handler := server.HandleCreateStack()
+
+	result, err := handler(context.Background(), CreateMCPRequest(params))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Stack created successfully with ID: 4")
+
+	result, err = handler(context.Background(), CreateMCPRequest(params))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Stack already created with ID: 4")
+
+	params["name"] = "other-stack"
+	result, err = handler(context.Background(), CreateMCPRequest(params))
+	require.NoError(t, err)
+	assert.True(t, result.IsError, "a key reused with different parameters should be rejected")
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "already used with different parameters")
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestHandleCreateStackFromURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockID      int
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful stack creation",
+			inputParams: map[string]any{
+				"name":                "web",
+				"url":                 "https://example.com/docker-compose.yml",
+				"environmentGroupIds": []any{float64(1), float64(2)},
+			},
+			expectCall: true,
+			mockID:     3,
+		},
+		{
+			name: "url not allowed",
+			inputParams: map[string]any{
+				"name":                "web",
+				"url":                 "https://example.com/docker-compose.yml",
+				"environmentGroupIds": []any{float64(1), float64(2)},
+			},
+			expectCall:  true,
+			mockError:   fmt.Errorf("invalid stack file URL https://example.com/docker-compose.yml: host example.com is not allowed"),
+			expectError: true,
+		},
+		{
+			name: "missing url parameter",
+			inputParams: map[string]any{
+				"name":                "web",
+				"environmentGroupIds": []any{float64(1), float64(2)},
+			},
+			expectError: true,
+		},
+		{
+			name: "missing environmentGroupIds parameter",
+			inputParams: map[string]any{
+				"name": "web",
+				"url":  "https://example.com/docker-compose.yml",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CreateStackFromURL", "web", "https://example.com/docker-compose.yml", []int{1, 2}).Return(tt.mockID, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCreateStackFromURL()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, fmt.Sprintf("ID: %d", tt.mockID))
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandleUpdateStack(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -420,3 +549,1379 @@ func TestHandleUpdateStack(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleDetectStackDrift(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		mockReport  models.DriftReport
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful drift detection",
+			inputParams: map[string]any{
+				"stackId":       float64(1),
+				"environmentId": float64(3),
+			},
+			mockReport: models.DriftReport{
+				StackID:         1,
+				StackName:       "shop",
+				EnvironmentID:   3,
+				MissingServices: []string{"api"},
+				ExtraServices:   []string{},
+				ImageMismatches: []models.ServiceImageMismatch{},
+			},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"stackId":       float64(1),
+				"environmentId": float64(5),
+			},
+			mockError:   fmt.Errorf("stack 1 is not deployed to environment 5"),
+			expectError: true,
+		},
+		{
+			name:        "missing stackId parameter",
+			inputParams: map[string]any{"environmentId": float64(3)},
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{"stackId": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			_, hasStack := tt.inputParams["stackId"]
+			_, hasEnv := tt.inputParams["environmentId"]
+			if hasStack && hasEnv {
+				mockClient.On("DetectStackDrift", int(tt.inputParams["stackId"].(float64)), int(tt.inputParams["environmentId"].(float64))).
+					Return(tt.mockReport, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDetectStackDrift()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var report models.DriftReport
+				err = json.Unmarshal([]byte(textContent.Text), &report)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockReport, report)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetStackDeploymentLogs(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		mockLog     string
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "successful retrieval",
+			inputParams: map[string]any{
+				"stackId":       float64(1),
+				"environmentId": float64(3),
+			},
+			mockLog: "Deployment log of stack shop on environment 3\n2024-05-01T10:00:00Z running",
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"stackId":       float64(1),
+				"environmentId": float64(5),
+			},
+			mockError:   fmt.Errorf("stack 1 has no deployment on environment 5"),
+			expectError: true,
+		},
+		{
+			name:        "missing stackId parameter",
+			inputParams: map[string]any{"environmentId": float64(3)},
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{"stackId": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			_, hasStack := tt.inputParams["stackId"]
+			_, hasEnv := tt.inputParams["environmentId"]
+			if hasStack && hasEnv {
+				mockClient.On("GetStackDeploymentLogs", int(tt.inputParams["stackId"].(float64)), int(tt.inputParams["environmentId"].(float64))).
+					Return(tt.mockLog, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetStackDeploymentLogs()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Equal(t, tt.mockLog, textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleCloneStack(t *testing.T) {
+	tests := []struct {
+		name                   string
+		inputParams            map[string]any
+		expectCall             bool
+		inputSourceID          int
+		inputName              string
+		inputEnvGroupIDs       []int
+		inputImageReplacements map[string]string
+		mockID                 int
+		mockError              error
+		expectError            bool
+	}{
+		{
+			name: "successful clone without replacements",
+			inputParams: map[string]any{
+				"sourceStackId":       float64(1),
+				"name":                "shop-prod",
+				"environmentGroupIds": []any{float64(3)},
+			},
+			expectCall:             true,
+			inputSourceID:          1,
+			inputName:              "shop-prod",
+			inputEnvGroupIDs:       []int{3},
+			inputImageReplacements: map[string]string{},
+			mockID:                 7,
+		},
+		{
+			name: "successful clone with replacements",
+			inputParams: map[string]any{
+				"sourceStackId":       float64(1),
+				"name":                "shop-prod",
+				"environmentGroupIds": []any{float64(3), float64(4)},
+				"imageReplacements": []any{
+					map[string]any{"key": "myorg/api:1.2.0", "value": "myorg/api:1.3.0"},
+				},
+			},
+			expectCall:             true,
+			inputSourceID:          1,
+			inputName:              "shop-prod",
+			inputEnvGroupIDs:       []int{3, 4},
+			inputImageReplacements: map[string]string{"myorg/api:1.2.0": "myorg/api:1.3.0"},
+			mockID:                 8,
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"sourceStackId":       float64(1),
+				"name":                "shop-prod",
+				"environmentGroupIds": []any{float64(3)},
+			},
+			expectCall:             true,
+			inputSourceID:          1,
+			inputName:              "shop-prod",
+			inputEnvGroupIDs:       []int{3},
+			inputImageReplacements: map[string]string{},
+			mockError:              fmt.Errorf("api error"),
+			expectError:            true,
+		},
+		{
+			name: "missing sourceStackId parameter",
+			inputParams: map[string]any{
+				"name":                "shop-prod",
+				"environmentGroupIds": []any{float64(3)},
+			},
+			expectError: true,
+		},
+		{
+			name: "missing name parameter",
+			inputParams: map[string]any{
+				"sourceStackId":       float64(1),
+				"environmentGroupIds": []any{float64(3)},
+			},
+			expectError: true,
+		},
+		{
+			name: "missing environmentGroupIds parameter",
+			inputParams: map[string]any{
+				"sourceStackId": float64(1),
+				"name":          "shop-prod",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid imageReplacements entry",
+			inputParams: map[string]any{
+				"sourceStackId":       float64(1),
+				"name":                "shop-prod",
+				"environmentGroupIds": []any{float64(3)},
+				"imageReplacements":   []any{map[string]any{"key": "myorg/api:1.2.0"}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("CloneStack", tt.inputSourceID, tt.inputName, tt.inputEnvGroupIDs, tt.inputImageReplacements).Return(tt.mockID, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleCloneStack()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, fmt.Sprintf("ID: %d", tt.mockID))
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetStackHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockHealth  models.StackHealth
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockHealth: models.StackHealth{
+				StackID:   1,
+				StackName: "shop",
+				Status:    models.StackHealthDegraded,
+				Environments: []models.StackEnvironmentHealth{
+					{EnvironmentID: 3, Status: models.StackHealthHealthy, HealthyContainers: 2, TotalContainers: 2},
+					{EnvironmentID: 4, Status: models.StackHealthUnhealthy, TotalContainers: 1},
+				},
+				UnhealthyContainers: []models.UnhealthyContainer{
+					{EnvironmentID: 4, Name: "edge_shop-web-1", Service: "web", State: "exited", Status: "Exited (1)"},
+				},
+			},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("stack 1 does not exist"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetStackHealth", 1).Return(tt.mockHealth, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetStackHealth()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var health models.StackHealth
+				err = json.Unmarshal([]byte(textContent.Text), &health)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockHealth, health)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleRecentlyChangedStacks(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputParams    map[string]any
+		expectCall     bool
+		mockStacks     []models.Stack
+		mockError      error
+		expectError    bool
+		expectedStacks []models.Stack
+		expectHasMore  bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"limit": float64(2)},
+			expectCall:  true,
+			mockStacks: []models.Stack{
+				{ID: 3, Name: "wiki", CreatedAt: "2024-04-13T09:20:00Z", UpdatedAt: "2024-05-04T05:20:00Z", UpdatedBy: "alice"},
+				{ID: 2, Name: "blog", CreatedAt: "2024-05-03T01:33:20Z"},
+			},
+			expectedStacks: []models.Stack{
+				{ID: 3, Name: "wiki", CreatedAt: "2024-04-13T09:20:00Z", UpdatedAt: "2024-05-04T05:20:00Z", UpdatedBy: "alice"},
+				{ID: 2, Name: "blog", CreatedAt: "2024-05-03T01:33:20Z"},
+			},
+		},
+		{
+			name:        "more stacks than the limit",
+			inputParams: map[string]any{"limit": float64(1)},
+			expectCall:  true,
+			mockStacks: []models.Stack{
+				{ID: 3, Name: "wiki", CreatedAt: "2024-04-13T09:20:00Z", UpdatedAt: "2024-05-04T05:20:00Z", UpdatedBy: "alice"},
+				{ID: 2, Name: "blog", CreatedAt: "2024-05-03T01:33:20Z"},
+			},
+			expectedStacks: []models.Stack{
+				{ID: 3, Name: "wiki", CreatedAt: "2024-04-13T09:20:00Z", UpdatedAt: "2024-05-04T05:20:00Z", UpdatedBy: "alice"},
+			},
+			expectHasMore: true,
+		},
+		{
+			name:           "without limit",
+			inputParams:    map[string]any{},
+			expectCall:     true,
+			mockStacks:     []models.Stack{},
+			expectedStacks: []models.Stack{},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to list edge stacks"),
+			expectError: true,
+		},
+		{
+			name:        "negative limit",
+			inputParams: map[string]any{"limit": float64(-1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetRecentlyChangedStacks", 0).Return(tt.mockStacks, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleRecentlyChangedStacks()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var stacks listResponse[models.Stack]
+				err = json.Unmarshal([]byte(textContent.Text), &stacks)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStacks, stacks.Items)
+				assert.Equal(t, len(tt.mockStacks), stacks.TotalCount)
+				assert.Equal(t, tt.expectHasMore, stacks.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleFindStacksByImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockStacks  []models.Stack
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful search",
+			inputParams: map[string]any{"imagePattern": "nginx:1.2*"},
+			expectCall:  true,
+			mockStacks: []models.Stack{
+				{ID: 1, Name: "web", EnvironmentGroupIds: []int{1}},
+				{ID: 4, Name: "proxy", EnvironmentGroupIds: []int{2, 3}},
+			},
+		},
+		{
+			name:        "no matching stacks",
+			inputParams: map[string]any{"imagePattern": "nginx:1.2*"},
+			expectCall:  true,
+			mockStacks:  []models.Stack{},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"imagePattern": "nginx:1.2*"},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to scan stack 1"),
+			expectError: true,
+		},
+		{
+			name:        "missing imagePattern parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("FindStacksByImage", "nginx:1.2*").Return(tt.mockStacks, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleFindStacksByImage()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var stacks listResponse[models.Stack]
+				err = json.Unmarshal([]byte(textContent.Text), &stacks)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockStacks, stacks.Items)
+				assert.Equal(t, len(tt.mockStacks), stacks.TotalCount)
+				assert.False(t, stacks.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleTestStackFile(t *testing.T) {
+	const stackFile = "services:\n  web:\n    image: nginx:latest\n"
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockResult  models.StackTestResult
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful test",
+			inputParams: map[string]any{"file": stackFile, "environmentId": float64(1)},
+			expectCall:  true,
+			mockResult: models.StackTestResult{
+				EnvironmentID: 1,
+				SyntaxValid:   true,
+				WouldDeploy:   true,
+				Images: []models.StackImageCheck{
+					{Service: "web", Image: "nginx:latest", Available: true, Source: models.StackImageSourceLocal},
+				},
+				Issues: []string{},
+			},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"file": stackFile, "environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to get endpoint"),
+			expectError: true,
+		},
+		{
+			name:        "missing file parameter",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{"file": stackFile},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("TestStackFile", stackFile, 1).Return(tt.mockResult, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleTestStackFile()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var testResult models.StackTestResult
+				err = json.Unmarshal([]byte(textContent.Text), &testResult)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockResult, testResult)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleFormatStackFile(t *testing.T) {
+	const stackFile = "services:\n    web:\n        image: nginx:latest\n"
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockResult  string
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful formatting",
+			inputParams: map[string]any{"file": stackFile},
+			expectCall:  true,
+			mockResult:  "services:\n  web:\n    image: nginx:latest\n",
+		},
+		{
+			name:        "invalid file",
+			inputParams: map[string]any{"file": stackFile},
+			expectCall:  true,
+			mockError:   fmt.Errorf("the compose file must be a YAML mapping"),
+			expectError: true,
+		},
+		{
+			name:        "missing file parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("FormatComposeFile", stackFile).Return(tt.mockResult, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleFormatStackFile()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				}
+			} else {
+				assert.Equal(t, tt.mockResult, textContent.Text)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetStackGitConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockConfig  models.GitConfig
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockConfig: models.GitConfig{
+				URL:            "https://github.com/example/web.git",
+				ReferenceName:  "refs/heads/main",
+				ConfigFilePath: "docker-compose.yml",
+				CommitHash:     "3f2a9c1",
+			},
+		},
+		{
+			name:        "stack not deployed from git",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("stack 1 is not deployed from a git repository"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetStackGitConfig", 1).Return(tt.mockConfig, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetStackGitConfig()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var config models.GitConfig
+				err = json.Unmarshal([]byte(textContent.Text), &config)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockConfig, config)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetStackGitDrift(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockDrift   models.GitDrift
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockDrift: models.GitDrift{
+				StackID:        1,
+				StackName:      "web",
+				RepositoryURL:  "https://github.com/example/web.git",
+				ReferenceName:  "refs/heads/main",
+				ConfigFilePath: "docker-compose.yml",
+				DeployedCommit: "3f2a9c1",
+				LatestCommit:   "9b1c2d3",
+				BehindLatest:   true,
+				Diff:           []string{"-    image: nginx:1.25", "+    image: nginx:1.27"},
+			},
+		},
+		{
+			name:        "stack not deployed from git",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("stack 1 is not deployed from a git repository"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetStackGitDrift", 1).Return(tt.mockDrift, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetStackGitDrift()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var drift models.GitDrift
+				err = json.Unmarshal([]byte(textContent.Text), &drift)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockDrift, drift)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleRedeployStackFromGit(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		pullImage   bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful redeploy",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+		},
+		{
+			name:        "successful redeploy with image pull",
+			inputParams: map[string]any{"id": float64(1), "pullImage": true},
+			expectCall:  true,
+			pullImage:   true,
+		},
+		{
+			name:        "git authentication failure",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to pull the repository of stack 1: %w", client.ErrGitAuthentication),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("RedeployStackFromGit", 1, tt.pullImage).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleRedeployStackFromGit()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "redeployed successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateStackAutoUpdate(t *testing.T) {
+	interval := func(s string) *string { return &s }
+	enabled := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name         string
+		inputParams  map[string]any
+		expectCall   bool
+		expectedOpts models.AutoUpdateOptions
+		mockSettings models.AutoUpdateSettings
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name:         "enable polling",
+			inputParams:  map[string]any{"id": float64(1), "interval": "5m"},
+			expectCall:   true,
+			expectedOpts: models.AutoUpdateOptions{Interval: interval("5m")},
+			mockSettings: models.AutoUpdateSettings{Enabled: true, Interval: "5m"},
+		},
+		{
+			name:         "enable webhook with image pull",
+			inputParams:  map[string]any{"id": float64(1), "webhook": true, "forcePullImage": true},
+			expectCall:   true,
+			expectedOpts: models.AutoUpdateOptions{Webhook: enabled(true), ForcePullImage: enabled(true)},
+			mockSettings: models.AutoUpdateSettings{
+				Enabled:        true,
+				WebhookEnabled: true,
+				WebhookToken:   "05de31a2-79fa-4644-9c12-faa67e5c49f0",
+				WebhookPath:    "/api/edge_stacks/webhooks/05de31a2-79fa-4644-9c12-faa67e5c49f0",
+				ForcePullImage: true,
+			},
+		},
+		{
+			name:         "disable automatic updates",
+			inputParams:  map[string]any{"id": float64(1), "interval": "", "webhook": false},
+			expectCall:   true,
+			expectedOpts: models.AutoUpdateOptions{Interval: interval(""), Webhook: enabled(false)},
+		},
+		{
+			name:         "client error",
+			inputParams:  map[string]any{"id": float64(1), "interval": "30s"},
+			expectCall:   true,
+			expectedOpts: models.AutoUpdateOptions{Interval: interval("30s")},
+			mockError:    fmt.Errorf("auto update interval 30s is shorter than the minimum of 1m0s"),
+			expectError:  true,
+		},
+		{
+			name:        "invalid webhook parameter",
+			inputParams: map[string]any{"id": float64(1), "webhook": "yes"},
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{"interval": "5m"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateStackAutoUpdate", 1, tt.expectedOpts).Return(tt.mockSettings, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateStackAutoUpdate()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.False(t, result.IsError)
+				var settings models.AutoUpdateSettings
+				err = json.Unmarshal([]byte(textContent.Text), &settings)
+				require.NoError(t, err, "Failed to unmarshal result text")
+				assert.Equal(t, tt.mockSettings, settings)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleExportStacks(t *testing.T) {
+	mockStacks := map[string]string{
+		"web":   "services:\n  web:\n    image: nginx\n",
+		"cache": "",
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "json export",
+			inputParams: map[string]any{},
+			expectCall:  true,
+		},
+		{
+			name:        "zip export",
+			inputParams: map[string]any{"format": "zip"},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{},
+			expectCall:  true,
+			mockError:   fmt.Errorf("failed to export stack 1"),
+			expectError: true,
+		},
+		{
+			name:        "invalid format",
+			inputParams: map[string]any{"format": "tar"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ExportAllStacks").Return(mockStacks, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleExportStacks()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+				mockClient.AssertExpectations(t)
+				return
+			}
+
+			var export models.StackExport
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &export))
+			assert.Equal(t, 2, export.StackCount)
+			assert.Equal(t, []string{"cache"}, export.EmptyStacks)
+
+			if export.Format == StackExportFormatZip {
+				assert.Empty(t, export.Stacks)
+				data, err := base64.StdEncoding.DecodeString(export.Archive)
+				require.NoError(t, err)
+				archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+				require.NoError(t, err)
+
+				files := map[string]string{}
+				for _, f := range archive.File {
+					rc, err := f.Open()
+					require.NoError(t, err)
+					content, err := io.ReadAll(rc)
+					require.NoError(t, err)
+					rc.Close()
+					files[f.Name] = string(content)
+				}
+				assert.Equal(t, map[string]string{"cache.yml": "", "web.yml": mockStacks["web"]}, files)
+			} else {
+				assert.Equal(t, StackExportFormatJSON, export.Format)
+				assert.Equal(t, mockStacks, export.Stacks)
+				assert.Empty(t, export.Archive)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetStackAccess(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockAccess  models.ResourceAccess
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful retrieval",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockAccess: models.ResourceAccess{
+				ResourceID:           "1",
+				Type:                 models.ResourceTypeStack,
+				Name:                 "web",
+				AdministratorsOnly:   true,
+				UserIDs:              []int{},
+				TeamIDs:              []int{},
+				AdministratorUserIDs: []int{1},
+			},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("edge stack not found"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetStackAccess", 1).Return(tt.mockAccess, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetStackAccess()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var access models.ResourceAccess
+				err = json.Unmarshal([]byte(textContent.Text), &access)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockAccess, access)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleRestartStack(t *testing.T) {
+	mockReport := models.StackRestartReport{
+		StackID:       1,
+		StackName:     "shop",
+		EnvironmentID: 3,
+		Restarted: []models.StackContainerRestart{
+			{Service: "web", ContainerID: "c2", ContainerName: "edge_shop-web-1"},
+		},
+		Failed: []models.StackContainerRestart{},
+	}
+
+	tests := []struct {
+		name          string
+		input         map[string]any
+		expectCall    bool
+		mockError     error
+		expectError   bool
+		expectReport  bool
+		expectedError string
+	}{
+		{
+			name:         "successful restart",
+			input:        map[string]any{"stackId": float64(1), "environmentId": float64(3)},
+			expectCall:   true,
+			expectReport: true,
+		},
+		{
+			name:          "partial restart",
+			input:         map[string]any{"stackId": float64(1), "environmentId": float64(3)},
+			expectCall:    true,
+			mockError:     &client.StackRestartError{StackID: 1, EnvironmentID: 3, Failed: 1, Total: 2},
+			expectError:   true,
+			expectReport:  true,
+			expectedError: "failed to restart 1 of 2 containers",
+		},
+		{
+			name:          "stack not deployed",
+			input:         map[string]any{"stackId": float64(1), "environmentId": float64(3)},
+			expectCall:    true,
+			mockError:     fmt.Errorf("stack 1 is not deployed to environment 3"),
+			expectError:   true,
+			expectedError: "stack 1 is not deployed to environment 3",
+		},
+		{
+			name:          "missing environmentId parameter",
+			input:         map[string]any{"stackId": float64(1)},
+			expectError:   true,
+			expectedError: "invalid environmentId parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("RestartStack", 1, 3).Return(mockReport, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleRestartStack()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.input))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if tt.expectedError != "" {
+				assert.Contains(t, textContent.Text, tt.expectedError)
+			}
+			if tt.expectReport {
+				assert.Contains(t, textContent.Text, `"container_id":"c2"`)
+			} else {
+				assert.NotContains(t, textContent.Text, "container_id")
+			}
+			if !tt.expectError {
+				var report models.StackRestartReport
+				err = json.Unmarshal([]byte(textContent.Text), &report)
+				assert.NoError(t, err)
+				assert.Equal(t, mockReport, report)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeleteStacksMatching(t *testing.T) {
+	deleted := models.BulkDeleteResult{
+		Confirmed:    true,
+		Stacks:       []models.StackDeleteStatus{{StackID: 2, StackName: "test-db", Deleted: true}},
+		DeletedCount: 1,
+	}
+	partial := models.BulkDeleteResult{
+		Confirmed: true,
+		Stacks: []models.StackDeleteStatus{
+			{StackID: 2, StackName: "test-db", Deleted: true},
+			{StackID: 4, StackName: "test-web", Error: "forbidden"},
+		},
+		DeletedCount: 1,
+		FailedCount:  1,
+	}
+
+	tests := []struct {
+		name          string
+		input         map[string]any
+		expectFilter  *models.StackFilter
+		mockResult    models.BulkDeleteResult
+		mockError     error
+		expectError   bool
+		expectedText  string
+		expectedError string
+	}{
+		{
+			name:         "confirmed deletion",
+			input:        map[string]any{"namePattern": "test-*", "environmentGroupId": float64(2), "confirm": true},
+			expectFilter: &models.StackFilter{NamePattern: "test-*", EnvironmentGroupID: 2, Confirm: true},
+			mockResult:   deleted,
+			expectedText: `"deleted":true`,
+		},
+		{
+			name:         "not confirmed",
+			input:        map[string]any{"namePattern": "test-*", "confirm": false},
+			expectFilter: &models.StackFilter{NamePattern: "test-*"},
+			mockResult:   models.BulkDeleteResult{Stacks: []models.StackDeleteStatus{{StackID: 2, StackName: "test-db"}}},
+			expectedText: `"confirmed":false`,
+		},
+		{
+			name:          "partial failure",
+			input:         map[string]any{"namePattern": "test-*", "confirm": true},
+			expectFilter:  &models.StackFilter{NamePattern: "test-*", Confirm: true},
+			mockResult:    partial,
+			expectError:   true,
+			expectedError: `failed to delete 1 of 2 stacks, result: {"confirmed":true`,
+		},
+		{
+			name:          "invalid filter",
+			input:         map[string]any{"confirm": true},
+			expectFilter:  &models.StackFilter{Confirm: true},
+			mockError:     fmt.Errorf("invalid filter: a name pattern or an environment group is required"),
+			expectError:   true,
+			expectedError: "a name pattern or an environment group is required",
+		},
+		{
+			name:          "missing confirm parameter",
+			input:         map[string]any{"namePattern": "test-*"},
+			expectError:   true,
+			expectedError: "invalid confirm parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectFilter != nil {
+				mockClient.On("DeleteStacksMatching", *tt.expectFilter).Return(tt.mockResult, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleDeleteStacksMatching()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.input))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			if tt.expectedError != "" {
+				assert.Contains(t, textContent.Text, tt.expectedError)
+			}
+			if !tt.expectError {
+				assert.Contains(t, textContent.Text, tt.expectedText)
+				var deleteResult models.BulkDeleteResult
+				err = json.Unmarshal([]byte(textContent.Text), &deleteResult)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockResult, deleteResult)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
+)
+
+func (s *PortainerMCPServer) AddSwarmFeatures() {
+	s.addToolIfExists(ToolListSwarmServices, s.HandleListSwarmServices())
+	s.addToolIfExists(ToolListSwarmTasks, s.HandleListSwarmTasks())
+
+	if !s.readOnly {
+		s.addToolIfExists(ToolUpdateSwarmService, s.HandleUpdateSwarmService())
+	}
+}
+
+func (s *PortainerMCPServer) HandleListSwarmServices() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		services, err := s.client(ctx).ListSwarmServices(environmentId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to list swarm services", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(services))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal swarm services", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleListSwarmTasks() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		serviceId, err := parser.GetString("serviceId", false)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid serviceId parameter", err), nil
+		}
+
+		tasks, err := s.client(ctx).ListSwarmTasks(environmentId, serviceId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to list swarm tasks", err), nil
+		}
+
+		data, err := json.Marshal(newListResponse(tasks))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal swarm tasks", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateSwarmService() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		environmentId, err := parser.GetInt("environmentId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid environmentId parameter", err), nil
+		}
+
+		serviceId, err := parser.GetString("serviceId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid serviceId parameter", err), nil
+		}
+
+		opts := models.SwarmServiceUpdate{}
+
+		if parser.Has("replicas") {
+			replicas, err := parser.GetInt("replicas", true)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid replicas parameter", err), nil
+			}
+			opts.Replicas = &replicas
+		}
+
+		if parser.Has("image") {
+			image, err := parser.GetString("image", true)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("invalid image parameter", err), nil
+			}
+			opts.Image = &image
+		}
+
+		err = s.client(ctx).UpdateSwarmService(environmentId, serviceId, opts)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update swarm service", err), nil
+		}
+
+		return mcp.NewToolResultText("Swarm service updated successfully"), nil
+	}
+}
@@ -0,0 +1,246 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleListSwarmServices(t *testing.T) {
+	replicas := 3
+
+	tests := []struct {
+		name         string
+		inputParams  map[string]any
+		expectCall   bool
+		mockServices []models.SwarmService
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name:        "successful listing",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockServices: []models.SwarmService{
+				{ID: "svc1", Name: "agent", Image: "portainer/agent:2.31.2", Mode: models.SwarmServiceModeGlobal, RunningTasks: 4, DesiredTasks: 4},
+				{ID: "svc2", Name: "web", Image: "nginx:1.27", Mode: models.SwarmServiceModeReplicated, Replicas: &replicas, RunningTasks: 3, DesiredTasks: 3},
+			},
+		},
+		{
+			name:        "not a swarm manager",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("environment 1 is not a Swarm manager"),
+			expectError: true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ListSwarmServices", 1).Return(tt.mockServices, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleListSwarmServices()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var services listResponse[models.SwarmService]
+				err = json.Unmarshal([]byte(textContent.Text), &services)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockServices, services.Items)
+				assert.Equal(t, len(tt.mockServices), services.TotalCount)
+				assert.False(t, services.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleListSwarmTasks(t *testing.T) {
+	tests := []struct {
+		name              string
+		inputParams       map[string]any
+		expectCall        bool
+		expectedServiceId string
+		mockTasks         []models.SwarmTask
+		mockError         error
+		expectError       bool
+	}{
+		{
+			name:              "tasks of a service",
+			inputParams:       map[string]any{"environmentId": float64(1), "serviceId": "svc1"},
+			expectCall:        true,
+			expectedServiceId: "svc1",
+			mockTasks: []models.SwarmTask{
+				{ID: "task1", ServiceID: "svc1", NodeID: "node1", Slot: 1, Image: "nginx:1.27", State: "running", DesiredState: "running"},
+			},
+		},
+		{
+			name:        "tasks of all services",
+			inputParams: map[string]any{"environmentId": float64(1)},
+			expectCall:  true,
+			mockTasks:   []models.SwarmTask{},
+		},
+		{
+			name:              "not a swarm manager",
+			inputParams:       map[string]any{"environmentId": float64(1), "serviceId": "svc1"},
+			expectCall:        true,
+			expectedServiceId: "svc1",
+			mockError:         fmt.Errorf("environment 1 is not a Swarm manager"),
+			expectError:       true,
+		},
+		{
+			name:        "missing environmentId parameter",
+			inputParams: map[string]any{"serviceId": "svc1"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ListSwarmTasks", 1, tt.expectedServiceId).Return(tt.mockTasks, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleListSwarmTasks()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var tasks listResponse[models.SwarmTask]
+				err = json.Unmarshal([]byte(textContent.Text), &tasks)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockTasks, tasks.Items)
+				assert.Equal(t, len(tt.mockTasks), tasks.TotalCount)
+				assert.False(t, tasks.HasMore)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateSwarmService(t *testing.T) {
+	replicas := 3
+	zero := 0
+	image := "nginx:1.27"
+
+	tests := []struct {
+		name         string
+		inputParams  map[string]any
+		expectCall   bool
+		expectedOpts models.SwarmServiceUpdate
+		mockError    error
+		expectError  bool
+	}{
+		{
+			name:         "scale and update image",
+			inputParams:  map[string]any{"environmentId": float64(1), "serviceId": "svc1", "replicas": float64(3), "image": "nginx:1.27"},
+			expectCall:   true,
+			expectedOpts: models.SwarmServiceUpdate{Replicas: &replicas, Image: &image},
+		},
+		{
+			name:         "scale to zero",
+			inputParams:  map[string]any{"environmentId": float64(1), "serviceId": "svc1", "replicas": float64(0)},
+			expectCall:   true,
+			expectedOpts: models.SwarmServiceUpdate{Replicas: &zero},
+		},
+		{
+			name:         "api error",
+			inputParams:  map[string]any{"environmentId": float64(1), "serviceId": "svc1", "image": "nginx:1.27"},
+			expectCall:   true,
+			expectedOpts: models.SwarmServiceUpdate{Image: &image},
+			mockError:    fmt.Errorf("environment 1 is not a Swarm manager"),
+			expectError:  true,
+		},
+		{
+			name:        "missing serviceId parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "replicas": float64(3)},
+			expectError: true,
+		},
+		{
+			name:        "invalid replicas parameter",
+			inputParams: map[string]any{"environmentId": float64(1), "serviceId": "svc1", "replicas": "three"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateSwarmService", 1, "svc1", tt.expectedOpts).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateSwarmService()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
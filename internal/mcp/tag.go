@@ -20,12 +20,12 @@ func (s *PortainerMCPServer) AddTagFeatures() {
 
 func (s *PortainerMCPServer) HandleGetEnvironmentTags() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		environmentTags, err := s.cli.GetEnvironmentTags()
+		environmentTags, err := s.client(ctx).GetEnvironmentTags()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get environment tags", err), nil
 		}
 
-		data, err := json.Marshal(environmentTags)
+		data, err := json.Marshal(newListResponse(environmentTags))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to marshal environment tags", err), nil
 		}
@@ -43,7 +43,7 @@ func (s *PortainerMCPServer) HandleCreateEnvironmentTag() server.ToolHandlerFunc
 			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
 		}
 
-		id, err := s.cli.CreateEnvironmentTag(name)
+		id, err := s.client(ctx).CreateEnvironmentTag(name)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to create environment tag", err), nil
 		}
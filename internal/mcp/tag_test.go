@@ -70,10 +70,12 @@ func TestHandleGetEnvironmentTags(t *testing.T) {
 				textContent, ok := result.Content[0].(mcp.TextContent)
 				assert.True(t, ok)
 
-				var tags []models.EnvironmentTag
+				var tags listResponse[models.EnvironmentTag]
 				err = json.Unmarshal([]byte(textContent.Text), &tags)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockTags, tags)
+				assert.Equal(t, tt.mockTags, tags.Items)
+				assert.Equal(t, len(tt.mockTags), tags.TotalCount)
+				assert.False(t, tags.HasMore)
 			}
 
 			// Verify mock expectations
@@ -7,16 +7,20 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 func (s *PortainerMCPServer) AddTeamFeatures() {
 	s.addToolIfExists(ToolListTeams, s.HandleGetTeams())
+	s.addToolIfExists(ToolGetTeamAccessOverview, s.HandleGetTeamAccessOverview())
 
 	if !s.readOnly {
 		s.addToolIfExists(ToolCreateTeam, s.HandleCreateTeam())
 		s.addToolIfExists(ToolUpdateTeamName, s.HandleUpdateTeamName())
 		s.addToolIfExists(ToolUpdateTeamMembers, s.HandleUpdateTeamMembers())
+		s.addToolIfExists(ToolUpdateTeamMemberships, s.HandleUpdateTeamMemberships())
+		s.addToolIfExists(ToolApplyTeamAccess, s.HandleApplyTeamAccess())
 	}
 }
 
@@ -29,7 +33,7 @@ func (s *PortainerMCPServer) HandleCreateTeam() server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
 		}
 
-		teamID, err := s.cli.CreateTeam(name)
+		teamID, err := s.client(ctx).CreateTeam(name)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to create team", err), nil
 		}
@@ -40,12 +44,12 @@ func (s *PortainerMCPServer) HandleCreateTeam() server.ToolHandlerFunc {
 
 func (s *PortainerMCPServer) HandleGetTeams() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		teams, err := s.cli.GetTeams()
+		teams, err := s.client(ctx).GetTeams()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get teams", err), nil
 		}
 
-		data, err := json.Marshal(teams)
+		data, err := json.Marshal(newListResponse(teams))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to marshal teams", err), nil
 		}
@@ -68,7 +72,7 @@ func (s *PortainerMCPServer) HandleUpdateTeamName() server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid name parameter", err), nil
 		}
 
-		err = s.cli.UpdateTeamName(id, name)
+		err = s.client(ctx).UpdateTeamName(id, name)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update team name", err), nil
 		}
@@ -91,7 +95,7 @@ func (s *PortainerMCPServer) HandleUpdateTeamMembers() server.ToolHandlerFunc {
 			return mcp.NewToolResultErrorFromErr("invalid userIds parameter", err), nil
 		}
 
-		err = s.cli.UpdateTeamMembers(id, userIDs)
+		err = s.client(ctx).UpdateTeamMembers(id, userIDs)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update team members", err), nil
 		}
@@ -99,3 +103,114 @@ func (s *PortainerMCPServer) HandleUpdateTeamMembers() server.ToolHandlerFunc {
 		return mcp.NewToolResultText("Team members updated successfully"), nil
 	}
 }
+
+func (s *PortainerMCPServer) HandleUpdateTeamMemberships() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		memberEntries, err := parser.GetArrayOfObjects("members", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid members parameter", err), nil
+		}
+
+		members, err := parseTeamMembershipMap(memberEntries)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid team members", err), nil
+		}
+
+		err = s.client(ctx).UpdateTeamMemberships(id, members)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update team memberships", err), nil
+		}
+
+		return mcp.NewToolResultText("Team memberships updated successfully"), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetTeamAccessOverview() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		overview, err := s.client(ctx).GetTeamAccessOverview(id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get team access overview", err), nil
+		}
+
+		data, err := json.Marshal(overview)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal team access overview", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleApplyTeamAccess() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		id, err := parser.GetInt("id", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid id parameter", err), nil
+		}
+
+		desired, err := parseTeamAccessDesiredState(parser)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid desired state", err), nil
+		}
+
+		overview, err := s.client(ctx).ApplyTeamAccess(id, desired)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to apply team access", err), nil
+		}
+
+		data, err := json.Marshal(overview)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal team access overview", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+// parseTeamAccessDesiredState parses the desired accesses of a team.
+// Parameters that are not provided are left nil so that they are not managed.
+func parseTeamAccessDesiredState(parser *toolgen.ParameterParser) (models.TeamAccessDesiredState, error) {
+	var desired models.TeamAccessDesiredState
+
+	if parser.Has("environmentAccesses") {
+		environmentAccesses, err := parser.GetArrayOfObjects("environmentAccesses", true)
+		if err != nil {
+			return desired, fmt.Errorf("invalid environmentAccesses parameter: %w", err)
+		}
+
+		desired.EnvironmentAccesses, err = parseAccessMap(environmentAccesses)
+		if err != nil {
+			return desired, fmt.Errorf("invalid environment accesses: %w", err)
+		}
+	}
+
+	if parser.Has("accessGroupAccesses") {
+		accessGroupAccesses, err := parser.GetArrayOfObjects("accessGroupAccesses", true)
+		if err != nil {
+			return desired, fmt.Errorf("invalid accessGroupAccesses parameter: %w", err)
+		}
+
+		desired.AccessGroupAccesses, err = parseAccessMap(accessGroupAccesses)
+		if err != nil {
+			return desired, fmt.Errorf("invalid access group accesses: %w", err)
+		}
+	}
+
+	return desired, nil
+}
@@ -152,10 +152,12 @@ func TestHandleGetTeams(t *testing.T) {
 				textContent, ok := result.Content[0].(mcp.TextContent)
 				assert.True(t, ok)
 
-				var teams []models.Team
+				var teams listResponse[models.Team]
 				err = json.Unmarshal([]byte(textContent.Text), &teams)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockTeams, teams)
+				assert.Equal(t, tt.mockTeams, teams.Items)
+				assert.Equal(t, len(tt.mockTeams), teams.TotalCount)
+				assert.False(t, teams.HasMore)
 			}
 
 			mockClient.AssertExpectations(t)
@@ -368,3 +370,274 @@ func TestHandleUpdateTeamMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleUpdateTeamMemberships(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputParams     map[string]any
+		expectCall      bool
+		expectedMembers map[int]string
+		mockError       error
+		expectError     bool
+	}{
+		{
+			name: "successful memberships update",
+			inputParams: map[string]any{
+				"id": float64(1),
+				"members": []any{
+					map[string]any{"id": float64(2), "role": "leader"},
+					map[string]any{"id": float64(3), "role": "member"},
+				},
+			},
+			expectCall:      true,
+			expectedMembers: map[int]string{2: models.TeamMembershipRoleLeader, 3: models.TeamMembershipRoleMember},
+		},
+		{
+			name: "leader assignment rejected",
+			inputParams: map[string]any{
+				"id":      float64(1),
+				"members": []any{map[string]any{"id": float64(2), "role": "leader"}},
+			},
+			expectCall:      true,
+			expectedMembers: map[int]string{2: models.TeamMembershipRoleLeader},
+			mockError:       fmt.Errorf("failed to make user 2 a leader of team 1: portainer API error (status 403): Permission denied"),
+			expectError:     true,
+		},
+		{
+			name: "invalid role",
+			inputParams: map[string]any{
+				"id":      float64(1),
+				"members": []any{map[string]any{"id": float64(2), "role": "owner"}},
+			},
+			expectError: true,
+		},
+		{
+			name:        "missing members parameter",
+			inputParams: map[string]any{"id": float64(1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateTeamMemberships", 1, tt.expectedMembers).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateTeamMemberships()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetTeamAccessOverview(t *testing.T) {
+	mockOverview := models.TeamAccessOverview{
+		TeamID:       5,
+		TeamName:     "devs",
+		AccessGroups: []models.TeamAccessGroupAccess{},
+		Environments: []models.TeamEnvironmentAccess{
+			{EnvironmentID: 4, EnvironmentName: "prod-eu", AccessLevel: models.AccessLevelReadonlyUser, Source: models.TeamAccessSourceEnvironment, AccessGroupID: 1},
+		},
+		EnvironmentGroups: []models.TeamEnvironmentGroupAccess{},
+	}
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "successful overview",
+			inputParams: map[string]any{"id": float64(5)},
+			expectCall:  true,
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"id": float64(5)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("team 5 not found"),
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetTeamAccessOverview", 5).Return(mockOverview, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetTeamAccessOverview()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var overview models.TeamAccessOverview
+				err = json.Unmarshal([]byte(textContent.Text), &overview)
+				assert.NoError(t, err)
+				assert.Equal(t, mockOverview, overview)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleApplyTeamAccess(t *testing.T) {
+	mockOverview := models.TeamAccessOverview{
+		TeamID:            5,
+		TeamName:          "devs",
+		AccessGroups:      []models.TeamAccessGroupAccess{},
+		Environments:      []models.TeamEnvironmentAccess{},
+		EnvironmentGroups: []models.TeamEnvironmentGroupAccess{},
+	}
+
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectDesired models.TeamAccessDesiredState
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name: "environment accesses only",
+			inputParams: map[string]any{
+				"id": float64(5),
+				"environmentAccesses": []any{
+					map[string]any{"id": float64(4), "access": "readonly_user"},
+				},
+			},
+			expectCall: true,
+			expectDesired: models.TeamAccessDesiredState{
+				EnvironmentAccesses: map[int]string{4: "readonly_user"},
+			},
+		},
+		{
+			name: "remove all access group accesses",
+			inputParams: map[string]any{
+				"id":                  float64(5),
+				"accessGroupAccesses": []any{},
+			},
+			expectCall: true,
+			expectDesired: models.TeamAccessDesiredState{
+				AccessGroupAccesses: map[int]string{},
+			},
+		},
+		{
+			name: "api error",
+			inputParams: map[string]any{
+				"id":                  float64(5),
+				"accessGroupAccesses": []any{},
+			},
+			expectCall: true,
+			expectDesired: models.TeamAccessDesiredState{
+				AccessGroupAccesses: map[int]string{},
+			},
+			mockError:   fmt.Errorf("failed to update access group"),
+			expectError: true,
+		},
+		{
+			name: "invalid access level",
+			inputParams: map[string]any{
+				"id": float64(5),
+				"environmentAccesses": []any{
+					map[string]any{"id": float64(4), "access": "owner"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "invalid environmentAccesses parameter",
+			inputParams: map[string]any{"id": float64(5), "environmentAccesses": "4"},
+			expectError: true,
+		},
+		{
+			name:        "missing id parameter",
+			inputParams: map[string]any{"accessGroupAccesses": []any{}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("ApplyTeamAccess", 5, tt.expectDesired).Return(mockOverview, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleApplyTeamAccess()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter errors")
+				}
+			} else {
+				var overview models.TeamAccessOverview
+				err = json.Unmarshal([]byte(textContent.Text), &overview)
+				assert.NoError(t, err)
+				assert.Equal(t, mockOverview, overview)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolDefinition is the description of a registered tool returned by the describeTools tool
+type toolDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	InputSchema any    `json:"input_schema"`
+}
+
+// AddToolDefinitionFeatures registers the tools describing the other tools of the server.
+// The definitions are read when the tool is called, so tools registered after this feature are described as well.
+func (s *PortainerMCPServer) AddToolDefinitionFeatures() {
+	s.addToolIfExists(ToolDescribeTools, s.HandleDescribeTools())
+}
+
+func (s *PortainerMCPServer) HandleDescribeTools() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tools := s.GetToolDefinitions()
+
+		definitions := make([]toolDefinition, 0, len(tools))
+		for _, tool := range tools {
+			// Custom tools can define their parameters with a raw JSON schema instead
+			var inputSchema any = tool.InputSchema
+			if tool.RawInputSchema != nil {
+				inputSchema = tool.RawInputSchema
+			}
+
+			definitions = append(definitions, toolDefinition{
+				Name:        tool.Name,
+				Description: tool.Description,
+				InputSchema: inputSchema,
+			})
+		}
+		sort.Slice(definitions, func(i, j int) bool {
+			return definitions[i].Name < definitions[j].Name
+		})
+
+		data, err := json.Marshal(newListResponse(definitions))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal tool definitions", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
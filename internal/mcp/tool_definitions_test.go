@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleDescribeTools(t *testing.T) {
+	tests := []struct {
+		name          string
+		readOnly      bool
+		expectedTools []string
+	}{
+		{
+			name:          "all tools described",
+			expectedTools: []string{"createThing", "describeTools", "listThings"},
+		},
+		{
+			name:          "write tools not described in read-only mode",
+			readOnly:      true,
+			expectedTools: []string{"describeTools", "listThings"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &PortainerMCPServer{
+				srv: server.NewMCPServer("Test Server", "1.0.0", server.WithToolCapabilities(true)),
+				tools: map[string]mcp.Tool{
+					ToolDescribeTools: mcp.NewTool(ToolDescribeTools, mcp.WithDescription("Describe the tools")),
+					"listThings": mcp.NewTool("listThings",
+						mcp.WithDescription("List things"),
+						mcp.WithString("filter", mcp.Required(), mcp.Description("Filter the things")),
+					),
+					"createThing": mcp.NewTool("createThing", mcp.WithDescription("Create a thing")),
+				},
+				readOnly: tt.readOnly,
+			}
+
+			handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			}
+
+			s.AddToolDefinitionFeatures()
+			s.addToolIfExists("listThings", handler)
+			if !s.readOnly {
+				s.addToolIfExists("createThing", handler)
+			}
+
+			result, err := s.HandleDescribeTools()(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok, "Result content should be mcp.TextContent")
+
+			var response listResponse[struct {
+				Name        string              `json:"name"`
+				Description string              `json:"description"`
+				InputSchema mcp.ToolInputSchema `json:"input_schema"`
+			}]
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.expectedTools), response.TotalCount)
+			assert.False(t, response.HasMore)
+
+			definitions := response.Items
+
+			names := make([]string, 0, len(definitions))
+			for _, definition := range definitions {
+				names = append(names, definition.Name)
+			}
+			assert.Equal(t, tt.expectedTools, names)
+
+			listThings := definitions[len(definitions)-1]
+			assert.Equal(t, "List things", listThings.Description)
+			assert.Equal(t, []string{"filter"}, listThings.InputSchema.Required)
+			assert.Contains(t, listThings.InputSchema.Properties, "filter")
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ParseToolPriority converts a comma-separated list of tool names into a tool priority order.
+// Empty entries and surrounding spaces are ignored.
+func ParseToolPriority(list string) []string {
+	var order []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			order = append(order, name)
+		}
+	}
+
+	return order
+}
+
+// prioritizeTools returns a tool filter that presents the tools listed in order first,
+// in the given order, followed by all the other tools in their original order.
+//
+// The MCP server stores tools in a map and lists them sorted by name, so the order of the
+// AddTool calls has no effect on the listing: reordering is done when the tools are listed instead.
+// The filter never adds or removes tools, names of tools that are not registered are ignored.
+func prioritizeTools(order []string) server.ToolFilterFunc {
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		if _, exists := rank[name]; !exists {
+			rank[name] = i
+		}
+	}
+
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		sorted := make([]mcp.Tool, len(tools))
+		copy(sorted, tools)
+
+		sort.SliceStable(sorted, func(i, j int) bool {
+			ri, iPrioritized := rank[sorted[i].Name]
+			rj, jPrioritized := rank[sorted[j].Name]
+
+			switch {
+			case iPrioritized && jPrioritized:
+				return ri < rj
+			default:
+				return iPrioritized && !jPrioritized
+			}
+		})
+
+		return sorted
+	}
+}
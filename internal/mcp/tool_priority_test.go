@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToolPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "empty list",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "single tool",
+			input:    "listStacks",
+			expected: []string{"listStacks"},
+		},
+		{
+			name:     "multiple tools with spaces and empty entries",
+			input:    " listStacks, ,getStackHealth,",
+			expected: []string{"listStacks", "getStackHealth"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseToolPriority(tt.input))
+		})
+	}
+}
+
+func TestPrioritizeTools(t *testing.T) {
+	tools := []mcp.Tool{
+		{Name: "a"},
+		{Name: "b"},
+		{Name: "c"},
+		{Name: "d"},
+	}
+
+	tests := []struct {
+		name     string
+		order    []string
+		expected []string
+	}{
+		{
+			name:     "no priority keeps original order",
+			order:    []string{},
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "prioritized tools first in given order",
+			order:    []string{"d", "b"},
+			expected: []string{"d", "b", "a", "c"},
+		},
+		{
+			name:     "unknown and duplicate names are ignored",
+			order:    []string{"unknown", "c", "c", "a"},
+			expected: []string{"c", "a", "b", "d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := prioritizeTools(tt.order)(context.Background(), tools)
+
+			names := make([]string, len(result))
+			for i, tool := range result {
+				names[i] = tool.Name
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, "a", tools[0].Name, "input slice should not be modified")
+		})
+	}
+}
+
+func TestWithToolPriority(t *testing.T) {
+	mockClient := &MockPortainerClient{}
+
+	srv, err := NewPortainerMCPServer("https://portainer.example.com", "token", "testdata/valid_tools.yaml",
+		WithClient(mockClient),
+		WithDisableVersionCheck(true),
+		WithToolPriority([]string{"customC", "notRegistered", "customB"}),
+	)
+	require.NoError(t, err)
+
+	handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+	srv.RegisterCustomTool(mcp.NewTool("customA"), handler)
+	srv.RegisterCustomTool(mcp.NewTool("customB"), handler)
+	srv.RegisterCustomTool(mcp.NewTool("customC"), handler)
+
+	response := srv.srv.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	var listResponse struct {
+		Result mcp.ListToolsResult `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(data, &listResponse))
+
+	names := make([]string, len(listResponse.Result.Tools))
+	for i, tool := range listResponse.Result.Tools {
+		names[i] = tool.Name
+	}
+	assert.Equal(t, []string{"customC", "customB", "customA"}, names)
+}
@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer used for the tool calls
+const tracerName = "github.com/portainer/portainer-mcp/internal/mcp"
+
+// tracePropagator extracts the trace context from the inbound HTTP requests
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// traceToolCalls returns a tool handler middleware recording a span for every tool call.
+// The span is tagged with the name of the tool and marked as failed when the tool returns an error result.
+// Tool arguments are not recorded as they may hold sensitive values.
+func traceToolCalls(tracer trace.Tracer) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, span := tracer.Start(ctx, "tools/call "+request.Params.Name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attribute.String("mcp.tool.name", request.Params.Name)),
+			)
+			defer span.End()
+
+			result, err := next(ctx, request)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			case result != nil && result.IsError:
+				span.SetStatus(codes.Error, "tool returned an error result")
+			}
+
+			return result, err
+		}
+	}
+}
+
+// extractTraceContext adds the trace context of an inbound HTTP request to the context of the tool calls it carries
+func extractTraceContext(ctx context.Context, r *http.Request) context.Context {
+	return tracePropagator.Extract(ctx, propagation.HeaderCarrier(r.Header))
+}
@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingTracerProvider records the spans started by its tracers
+type recordingTracerProvider struct {
+	noop.TracerProvider
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: p}
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	provider *recordingTracerProvider
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	parent := trace.SpanContextFromContext(ctx)
+
+	traceID := parent.TraceID()
+	if !traceID.IsValid() {
+		traceID = trace.TraceID{0x01}
+	}
+
+	t.provider.mu.Lock()
+	defer t.provider.mu.Unlock()
+
+	span := &recordingSpan{
+		name:       name,
+		kind:       config.SpanKind(),
+		attributes: config.Attributes(),
+		parent:     parent,
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{byte(len(t.provider.spans) + 1)},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+	t.provider.spans = append(t.provider.spans, span)
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	noop.Span
+	name        string
+	kind        trace.SpanKind
+	attributes  []attribute.KeyValue
+	parent      trace.SpanContext
+	spanContext trace.SpanContext
+	status      codes.Code
+	ended       bool
+}
+
+func (s *recordingSpan) SpanContext() trace.SpanContext      { return s.spanContext }
+func (s *recordingSpan) IsRecording() bool                   { return true }
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+func (s *recordingSpan) End(...trace.SpanEndOption)          { s.ended = true }
+
+func TestTraceToolCalls(t *testing.T) {
+	tests := []struct {
+		name           string
+		result         *mcp.CallToolResult
+		err            error
+		expectedStatus codes.Code
+	}{
+		{
+			name:           "successful call",
+			result:         mcp.NewToolResultText("ok"),
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "error result",
+			result:         mcp.NewToolResultError("failed to get environments"),
+			expectedStatus: codes.Error,
+		},
+		{
+			name:           "handler error",
+			err:            errors.New("unexpected error"),
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp := &recordingTracerProvider{}
+
+			var handlerSpan trace.SpanContext
+			handler := traceToolCalls(tp.Tracer(tracerName))(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return tt.result, tt.err
+			})
+
+			request := CreateMCPRequest(map[string]any{"id": float64(1)})
+			request.Params.Name = ToolListEnvironments
+			result, err := handler(context.Background(), request)
+
+			assert.Equal(t, tt.result, result)
+			assert.Equal(t, tt.err, err)
+
+			require.Len(t, tp.spans, 1)
+			span := tp.spans[0]
+			assert.Equal(t, "tools/call "+ToolListEnvironments, span.name)
+			assert.Equal(t, trace.SpanKindServer, span.kind)
+			assert.Contains(t, span.attributes, attribute.String("mcp.tool.name", ToolListEnvironments))
+			assert.Equal(t, tt.expectedStatus, span.status)
+			assert.True(t, span.ended)
+			assert.Equal(t, span.spanContext, handlerSpan, "the handler should run in the context of the tool call span")
+		})
+	}
+}
+
+func TestExtractTraceContext(t *testing.T) {
+	tp := &recordingTracerProvider{}
+	handler := traceToolCalls(tp.Tracer(tracerName))(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx := extractTraceContext(context.Background(), r)
+	_, err := handler(ctx, CreateMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+
+	require.Len(t, tp.spans, 1)
+	parent := tp.spans[0].parent
+	assert.True(t, parent.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", parent.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", parent.SpanID().String())
+	assert.Equal(t, parent.TraceID(), tp.spans[0].spanContext.TraceID())
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	tp := &recordingTracerProvider{}
+
+	srv, err := NewPortainerMCPServer("https://portainer.example.com", "token", "testdata/valid_tools.yaml",
+		WithClient(&MockPortainerClient{}),
+		WithDisableVersionCheck(true),
+		WithTracerProvider(tp),
+	)
+	require.NoError(t, err)
+	assert.True(t, srv.traced)
+
+	srv.RegisterCustomTool(mcp.NewTool("customA"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	srv.srv.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"customA"}}`))
+
+	require.Len(t, tp.spans, 1)
+	assert.Equal(t, "tools/call customA", tp.spans[0].name)
+}
+
+func TestNoTracerProvider(t *testing.T) {
+	srv, err := NewPortainerMCPServer("https://portainer.example.com", "token", "testdata/valid_tools.yaml",
+		WithClient(&MockPortainerClient{}),
+		WithDisableVersionCheck(true),
+	)
+	require.NoError(t, err)
+	assert.False(t, srv.traced)
+}
+
+func TestToolCallPortainerRequestSpans(t *testing.T) {
+	tp := &recordingTracerProvider{}
+	var traceparent string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	s := &PortainerMCPServer{
+		cli: client.NewPortainerClient(strings.TrimPrefix(srv.URL, "https://"), "token",
+			client.WithSkipTLSVerify(true), client.WithTracerProvider(tp)),
+	}
+	handler := traceToolCalls(tp.Tracer(tracerName))(s.HandleGetEnvironmentTags())
+
+	request := CreateMCPRequest(map[string]any{})
+	request.Params.Name = "listEnvironmentTags"
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	require.Len(t, tp.spans, 2)
+	toolSpan, requestSpan := tp.spans[0], tp.spans[1]
+	assert.Equal(t, "portainer GET", requestSpan.name)
+	assert.Equal(t, toolSpan.spanContext, requestSpan.parent, "the Portainer request span should be a child of the tool call span")
+	assert.Equal(t, "00-"+toolSpan.spanContext.TraceID().String()+"-"+requestSpan.spanContext.SpanID().String()+"-01", traceparent)
+}
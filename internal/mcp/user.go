@@ -7,25 +7,30 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 func (s *PortainerMCPServer) AddUserFeatures() {
 	s.addToolIfExists(ToolListUsers, s.HandleGetUsers())
+	s.addToolIfExists(ToolWhoAmI, s.HandleWhoAmI())
+	s.addToolIfExists(ToolGetUserPreferences, s.HandleGetUserPreferences())
 
 	if !s.readOnly {
 		s.addToolIfExists(ToolUpdateUserRole, s.HandleUpdateUserRole())
+		s.addToolIfExists(ToolUpdateUserRolesBulk, s.HandleUpdateUserRolesBulk())
+		s.addToolIfExists(ToolUpdateUserPreferences, s.HandleUpdateUserPreferences())
 	}
 }
 
 func (s *PortainerMCPServer) HandleGetUsers() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		users, err := s.cli.GetUsers()
+		users, err := s.client(ctx).GetUsers()
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to get users", err), nil
 		}
 
-		data, err := json.Marshal(users)
+		data, err := json.Marshal(newListResponse(users))
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to marshal users", err), nil
 		}
@@ -34,6 +39,22 @@ func (s *PortainerMCPServer) HandleGetUsers() server.ToolHandlerFunc {
 	}
 }
 
+func (s *PortainerMCPServer) HandleWhoAmI() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		user, err := s.client(ctx).GetCurrentUser()
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get current user", err), nil
+		}
+
+		data, err := json.Marshal(user)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal current user", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
 func (s *PortainerMCPServer) HandleUpdateUserRole() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		parser := toolgen.NewParameterParser(request)
@@ -52,7 +73,7 @@ func (s *PortainerMCPServer) HandleUpdateUserRole() server.ToolHandlerFunc {
 			return mcp.NewToolResultError(fmt.Sprintf("invalid role %s: must be one of: %v", role, AllUserRoles)), nil
 		}
 
-		err = s.cli.UpdateUserRole(id, role)
+		err = s.client(ctx).UpdateUserRole(id, role)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("failed to update user role", err), nil
 		}
@@ -60,3 +81,102 @@ func (s *PortainerMCPServer) HandleUpdateUserRole() server.ToolHandlerFunc {
 		return mcp.NewToolResultText("User updated successfully"), nil
 	}
 }
+
+func (s *PortainerMCPServer) HandleUpdateUserRolesBulk() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		updateEntries, err := parser.GetArrayOfObjects("updates", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid updates parameter", err), nil
+		}
+
+		updates, err := parseUserRoleMap(updateEntries)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid user roles", err), nil
+		}
+
+		err = s.client(ctx).UpdateUserRolesBulk(updates)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update user roles", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("%d users updated successfully", len(updates))), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleGetUserPreferences() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		userId, err := parser.GetInt("userId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid userId parameter", err), nil
+		}
+
+		prefs, err := s.client(ctx).GetUserPreferences(userId)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get user preferences", err), nil
+		}
+
+		data, err := json.Marshal(prefs)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to marshal user preferences", err), nil
+		}
+
+		return mcp.NewToolResultText(string(data)), nil
+	}
+}
+
+func (s *PortainerMCPServer) HandleUpdateUserPreferences() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parser := toolgen.NewParameterParser(request)
+
+		userId, err := parser.GetInt("userId", true)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid userId parameter", err), nil
+		}
+
+		prefs, err := parseUserPreferences(parser)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("invalid preferences", err), nil
+		}
+
+		err = s.client(ctx).UpdateUserPreferences(userId, prefs)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to update user preferences", err), nil
+		}
+
+		return mcp.NewToolResultText("User preferences updated successfully"), nil
+	}
+}
+
+// parseUserPreferences parses the preferences to apply to a user.
+// Flags that are not provided are left nil so that they are not changed.
+func parseUserPreferences(parser *toolgen.ParameterParser) (models.UserPreferences, error) {
+	var prefs models.UserPreferences
+
+	theme, err := parser.GetString("theme", false)
+	if err != nil {
+		return prefs, fmt.Errorf("invalid theme parameter: %w", err)
+	}
+	prefs.Theme = theme
+
+	if parser.Has("subtleUpgradeButton") {
+		subtleUpgradeButton, err := parser.GetBoolean("subtleUpgradeButton", true)
+		if err != nil {
+			return prefs, fmt.Errorf("invalid subtleUpgradeButton parameter: %w", err)
+		}
+		prefs.SubtleUpgradeButton = &subtleUpgradeButton
+	}
+
+	if parser.Has("useCache") {
+		useCache, err := parser.GetBoolean("useCache", true)
+		if err != nil {
+			return prefs, fmt.Errorf("invalid useCache parameter: %w", err)
+		}
+		prefs.UseCache = &useCache
+	}
+
+	return prefs, nil
+}
@@ -67,10 +67,12 @@ func TestHandleGetUsers(t *testing.T) {
 				textContent, ok := result.Content[0].(mcp.TextContent)
 				assert.True(t, ok)
 
-				var users []models.User
+				var users listResponse[models.User]
 				err = json.Unmarshal([]byte(textContent.Text), &users)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.mockUsers, users)
+				assert.Equal(t, tt.mockUsers, users.Items)
+				assert.Equal(t, len(tt.mockUsers), users.TotalCount)
+				assert.False(t, users.HasMore)
 			}
 
 			// Verify mock expectations
@@ -79,6 +81,61 @@ func TestHandleGetUsers(t *testing.T) {
 	}
 }
 
+func TestHandleWhoAmI(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockUser    models.CurrentUser
+		mockError   error
+		expectError bool
+	}{
+		{
+			name: "regular user",
+			mockUser: models.CurrentUser{
+				User:           models.User{ID: 2, Username: "operator", Role: "user"},
+				Authorizations: []string{"PortainerUserListToken"},
+			},
+		},
+		{
+			name:        "api error",
+			mockError:   fmt.Errorf("invalid JWT token"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			mockClient.On("GetCurrentUser").Return(tt.mockUser, tt.mockError)
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleWhoAmI()
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for API errors")
+				assert.Contains(t, textContent.Text, tt.mockError.Error())
+			} else {
+				assert.False(t, result.IsError)
+				var user models.CurrentUser
+				err = json.Unmarshal([]byte(textContent.Text), &user)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockUser, user)
+				assert.Contains(t, textContent.Text, `"is_admin":false`)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
 func TestHandleUpdateUserRole(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -203,3 +260,261 @@ func TestHandleUpdateUserRole(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleUpdateUserRolesBulk(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputUpdates map[int]string
+		mockError    error
+		expectError  bool
+		setupParams  func(request *mcp.CallToolRequest)
+	}{
+		{
+			name:         "successful roles update",
+			inputUpdates: map[int]string{1: "admin", 2: "user"},
+			mockError:    nil,
+			expectError:  false,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"updates": []any{
+						map[string]any{"id": float64(1), "role": "admin"},
+						map[string]any{"id": float64(2), "role": "user"},
+					},
+				}
+			},
+		},
+		{
+			name:         "api error",
+			inputUpdates: map[int]string{1: "admin"},
+			mockError:    fmt.Errorf("failed to update the role of 1 of 1 users"),
+			expectError:  true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"updates": []any{
+						map[string]any{"id": float64(1), "role": "admin"},
+					},
+				}
+			},
+		},
+		{
+			name:        "missing updates parameter",
+			mockError:   nil,
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{}
+			},
+		},
+		{
+			name:        "invalid role",
+			mockError:   nil,
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"updates": []any{
+						map[string]any{"id": float64(1), "role": "admin"},
+						map[string]any{"id": float64(2), "role": "invalid_role"},
+					},
+				}
+			},
+		},
+		{
+			name:        "duplicate user",
+			mockError:   nil,
+			expectError: true,
+			setupParams: func(request *mcp.CallToolRequest) {
+				request.Params.Arguments = map[string]any{
+					"updates": []any{
+						map[string]any{"id": float64(1), "role": "admin"},
+						map[string]any{"id": float64(1), "role": "user"},
+					},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if !tt.expectError || tt.mockError != nil {
+				mockClient.On("UpdateUserRolesBulk", tt.inputUpdates).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			request := CreateMCPRequest(map[string]any{})
+			tt.setupParams(&request)
+
+			handler := server.HandleUpdateUserRolesBulk()
+			result, err := handler(context.Background(), request)
+
+			if tt.expectError {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				assert.Len(t, result.Content, 1)
+				textContent, ok := result.Content[0].(mcp.TextContent)
+				assert.True(t, ok, "Result content should be mcp.TextContent for errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				} else {
+					assert.NotEmpty(t, textContent.Text, "Error message should not be empty for parameter/validation errors")
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Content, 1)
+				textContent, ok := result.Content[0].(mcp.TextContent)
+				assert.True(t, ok)
+				assert.Contains(t, textContent.Text, "successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleGetUserPreferences(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name        string
+		inputParams map[string]any
+		expectCall  bool
+		mockPrefs   models.UserPreferences
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:        "preferences of a user",
+			inputParams: map[string]any{"userId": float64(2)},
+			expectCall:  true,
+			mockPrefs:   models.UserPreferences{Theme: models.ThemeDark, SubtleUpgradeButton: &disabled, UseCache: &enabled},
+		},
+		{
+			name:        "api error",
+			inputParams: map[string]any{"userId": float64(2)},
+			expectCall:  true,
+			mockError:   fmt.Errorf("user not found"),
+			expectError: true,
+		},
+		{
+			name:        "missing userId parameter",
+			inputParams: map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("GetUserPreferences", 2).Return(tt.mockPrefs, tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleGetUserPreferences()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				}
+			} else {
+				assert.False(t, result.IsError)
+				var prefs models.UserPreferences
+				err = json.Unmarshal([]byte(textContent.Text), &prefs)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockPrefs, prefs)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUpdateUserPreferences(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name          string
+		inputParams   map[string]any
+		expectCall    bool
+		expectedPrefs models.UserPreferences
+		mockError     error
+		expectError   bool
+	}{
+		{
+			name:          "update theme only",
+			inputParams:   map[string]any{"userId": float64(2), "theme": "dark"},
+			expectCall:    true,
+			expectedPrefs: models.UserPreferences{Theme: models.ThemeDark},
+		},
+		{
+			name:          "false flag is passed through",
+			inputParams:   map[string]any{"userId": float64(2), "useCache": false},
+			expectCall:    true,
+			expectedPrefs: models.UserPreferences{UseCache: &disabled},
+		},
+		{
+			name:          "invalid theme",
+			inputParams:   map[string]any{"userId": float64(2), "theme": "purple"},
+			expectCall:    true,
+			expectedPrefs: models.UserPreferences{Theme: "purple"},
+			mockError:     fmt.Errorf(`invalid theme "purple"`),
+			expectError:   true,
+		},
+		{
+			name:        "invalid subtleUpgradeButton parameter",
+			inputParams: map[string]any{"userId": float64(2), "subtleUpgradeButton": "yes"},
+			expectError: true,
+		},
+		{
+			name:        "missing userId parameter",
+			inputParams: map[string]any{"theme": "dark"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockPortainerClient{}
+			if tt.expectCall {
+				mockClient.On("UpdateUserPreferences", 2, tt.expectedPrefs).Return(tt.mockError)
+			}
+
+			server := &PortainerMCPServer{
+				cli: mockClient,
+			}
+
+			handler := server.HandleUpdateUserPreferences()
+			result, err := handler(context.Background(), CreateMCPRequest(tt.inputParams))
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			assert.True(t, ok, "Result content should be mcp.TextContent")
+
+			if tt.expectError {
+				assert.True(t, result.IsError, "result.IsError should be true for expected errors")
+				if tt.mockError != nil {
+					assert.Contains(t, textContent.Text, tt.mockError.Error())
+				}
+			} else {
+				assert.False(t, result.IsError)
+				assert.Contains(t, textContent.Text, "updated successfully")
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
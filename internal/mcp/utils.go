@@ -3,8 +3,11 @@ package mcp
 import (
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 // parseAccessMap parses access entries from an array of objects and returns a map of ID to access level
@@ -27,7 +30,7 @@ func parseAccessMap(entries []any) (map[int]string, error) {
 			return nil, fmt.Errorf("invalid access: %v", entryMap["access"])
 		}
 
-		if !isValidAccessLevel(access) {
+		if !models.IsValidAccessLevel(access) {
 			return nil, fmt.Errorf("invalid access level: %s", access)
 		}
 
@@ -37,6 +40,74 @@ func parseAccessMap(entries []any) (map[int]string, error) {
 	return accessMap, nil
 }
 
+// parseUserRoleMap parses user role entries from an array of objects and returns a map of user ID to role
+func parseUserRoleMap(entries []any) (map[int]string, error) {
+	roleMap := map[int]string{}
+
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid user role entry: %v", entry)
+		}
+
+		id, ok := entryMap["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid ID: %v", entryMap["id"])
+		}
+
+		role, ok := entryMap["role"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid role: %v", entryMap["role"])
+		}
+
+		if !isValidUserRole(role) {
+			return nil, fmt.Errorf("invalid role %s for user %d: must be one of: %v", role, int(id), AllUserRoles)
+		}
+
+		if _, exists := roleMap[int(id)]; exists {
+			return nil, fmt.Errorf("duplicate user ID: %d", int(id))
+		}
+
+		roleMap[int(id)] = role
+	}
+
+	return roleMap, nil
+}
+
+// parseTeamMembershipMap parses team membership entries from an array of objects and returns a map of user ID to team membership role
+func parseTeamMembershipMap(entries []any) (map[int]string, error) {
+	memberMap := map[int]string{}
+
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid team member entry: %v", entry)
+		}
+
+		id, ok := entryMap["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid ID: %v", entryMap["id"])
+		}
+
+		role, ok := entryMap["role"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid role: %v", entryMap["role"])
+		}
+
+		if !isValidTeamMembershipRole(role) {
+			return nil, fmt.Errorf("invalid role %s for user %d: must be one of: %v", role, int(id), AllTeamMembershipRoles)
+		}
+
+		if _, exists := memberMap[int(id)]; exists {
+			return nil, fmt.Errorf("duplicate user ID: %d", int(id))
+		}
+
+		memberMap[int(id)] = role
+	}
+
+	return memberMap, nil
+}
+
 // parseKeyValueMap parses a slice of map[string]any into a map[string]string,
 // expecting each map to have "key" and "value" string fields.
 func parseKeyValueMap(items []any) (map[string]string, error) {
@@ -64,6 +135,50 @@ func parseKeyValueMap(items []any) (map[string]string, error) {
 	return resultMap, nil
 }
 
+// parseKeyValuesMap parses a slice of map[string]any into a map[string][]string,
+// expecting each map to have "key" and "value" string fields. The values of the
+// repeated keys are kept in order.
+func parseKeyValuesMap(items []any) (map[string][]string, error) {
+	resultMap := map[string][]string{}
+
+	for _, item := range items {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid item: %v", item)
+		}
+
+		key, ok := itemMap["key"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid key: %v", itemMap["key"])
+		}
+
+		value, ok := itemMap["value"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value: %v", itemMap["value"])
+		}
+
+		resultMap[key] = append(resultMap[key], value)
+	}
+
+	return resultMap, nil
+}
+
+// parseOptionalTime parses an optional RFC3339 timestamp parameter.
+// It returns the zero time when the parameter is not provided.
+func parseOptionalTime(parser *toolgen.ParameterParser, name string) (time.Time, error) {
+	value, err := parser.GetString(name, false)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp: %w", name, err)
+	}
+
+	return t, nil
+}
+
 func isValidHTTPMethod(method string) bool {
 	validMethods := []string{"GET", "POST", "PUT", "DELETE", "HEAD"}
 	return slices.Contains(validMethods, method)
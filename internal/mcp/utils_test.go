@@ -3,6 +3,9 @@ package mcp
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
 )
 
 func TestParseAccessMap(t *testing.T) {
@@ -126,6 +129,67 @@ func TestParseAccessMap(t *testing.T) {
 	}
 }
 
+func TestParseTeamMembershipMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []any
+		want    map[int]string
+		wantErr bool
+	}{
+		{
+			name: "Valid entries",
+			entries: []any{
+				map[string]any{"id": float64(1), "role": TeamMembershipRoleLeader},
+				map[string]any{"id": float64(2), "role": TeamMembershipRoleMember},
+			},
+			want: map[int]string{
+				1: TeamMembershipRoleLeader,
+				2: TeamMembershipRoleMember,
+			},
+		},
+		{
+			name:    "Invalid entry type",
+			entries: []any{"not a map"},
+			wantErr: true,
+		},
+		{
+			name: "Invalid role",
+			entries: []any{
+				map[string]any{"id": float64(1), "role": "owner"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing role field",
+			entries: []any{
+				map[string]any{"id": float64(1)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Duplicate user ID",
+			entries: []any{
+				map[string]any{"id": float64(1), "role": TeamMembershipRoleLeader},
+				map[string]any{"id": float64(1), "role": TeamMembershipRoleMember},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTeamMembershipMap(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseTeamMembershipMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTeamMembershipMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsValidHTTPMethod(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -244,3 +308,99 @@ func TestParseKeyValueMap(t *testing.T) {
 		})
 	}
 }
+
+func TestParseKeyValuesMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []any
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "Valid repeated keys",
+			items: []any{
+				map[string]any{"key": "label", "value": "env=dev"},
+				map[string]any{"key": "status", "value": "running"},
+				map[string]any{"key": "label", "value": "tier"},
+			},
+			want: map[string][]string{
+				"label":  {"env=dev", "tier"},
+				"status": {"running"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Empty items",
+			items:   []any{},
+			want:    map[string][]string{},
+			wantErr: false,
+		},
+		{
+			name: "Invalid item type",
+			items: []any{
+				"not a map",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Invalid value type",
+			items: []any{
+				map[string]any{"key": "label", "value": 123},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Missing key field",
+			items: []any{
+				map[string]any{"value": "env=dev"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseKeyValuesMap(tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseKeyValuesMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeyValuesMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOptionalTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    time.Time
+		wantErr bool
+	}{
+		{"Missing parameter", map[string]any{}, time.Time{}, false},
+		{"Empty string", map[string]any{"after": ""}, time.Time{}, false},
+		{"Valid UTC timestamp", map[string]any{"after": "2025-01-02T03:04:05Z"}, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), false},
+		{"Valid timestamp with offset", map[string]any{"after": "2025-01-02T05:04:05+02:00"}, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), false},
+		{"Invalid format", map[string]any{"after": "2025-01-02"}, time.Time{}, true},
+		{"Invalid type", map[string]any{"after": float64(12)}, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := toolgen.NewParameterParser(CreateMCPRequest(tt.args))
+			got, err := parseOptionalTime(parser, "after")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseOptionalTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseOptionalTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"sync"
+	"time"
+)
+
+// versionCache holds the versions of the Portainer servers checked at startup by the servers
+// created with WithVersionCacheTTL. It is shared across the process so that the servers
+// created for the same Portainer server only check its version once per TTL.
+var versionCache = newVersionCacheStore()
+
+// cachedVersion is the version of a Portainer server and the time after which it must be checked again
+type cachedVersion struct {
+	version   string
+	expiresAt time.Time
+}
+
+// versionCacheStore keeps the versions of Portainer servers in memory, keyed by server URL.
+// Only the versions that were read successfully are cached, failures are checked again.
+type versionCacheStore struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	versions map[string]cachedVersion
+}
+
+// newVersionCacheStore creates an empty version cache
+func newVersionCacheStore() *versionCacheStore {
+	return &versionCacheStore{
+		now:      time.Now,
+		versions: make(map[string]cachedVersion),
+	}
+}
+
+// get returns the cached version of a Portainer server, expired versions are discarded
+func (s *versionCacheStore) get(serverURL string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached, ok := s.versions[serverURL]
+	if !ok || !s.now().Before(cached.expiresAt) {
+		delete(s.versions, serverURL)
+		return "", false
+	}
+
+	return cached.version, true
+}
+
+// set caches the version of a Portainer server for the given TTL
+func (s *versionCacheStore) set(serverURL, version string, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.versions[serverURL] = cachedVersion{
+		version:   version,
+		expiresAt: s.now().Add(ttl),
+	}
+}
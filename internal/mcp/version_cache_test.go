@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionCacheStore(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newVersionCacheStore()
+	store.now = func() time.Time { return now }
+
+	_, ok := store.get("https://portainer.example.com")
+	assert.False(t, ok)
+
+	store.set("https://portainer.example.com", "2.31.2", time.Minute)
+
+	version, ok := store.get("https://portainer.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "2.31.2", version)
+
+	_, ok = store.get("https://other.example.com")
+	assert.False(t, ok, "versions should be cached per server URL")
+
+	now = now.Add(time.Minute)
+	_, ok = store.get("https://portainer.example.com")
+	assert.False(t, ok, "expired versions should be checked again")
+	assert.Empty(t, store.versions)
+}
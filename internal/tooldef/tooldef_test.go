@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/portainer/portainer-mcp/pkg/toolgen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -66,3 +67,10 @@ func TestCreateToolsFileIfNotExists(t *testing.T) {
 		assert.False(t, exists, "Function should return false when an error occurs")
 	})
 }
+
+func TestEmbeddedToolsFileIsValid(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "tools.yaml")
+	require.NoError(t, os.WriteFile(filePath, ToolsFile, 0644))
+
+	assert.NoError(t, toolgen.ValidateToolsFile(filePath, "v1.0", ""))
+}
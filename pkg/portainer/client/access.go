@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// validateUserAccesses checks that every access level in the map is accepted by Portainer
+// and that every user ID refers to an existing user.
+// Nothing is sent to Portainer when validation fails, so an access change is never half-applied.
+//
+// Parameters:
+//   - userAccesses: Map of user IDs to their access level
+//
+// Returns:
+//   - An error naming the first offending user ID or access level
+func (c *PortainerClient) validateUserAccesses(userAccesses map[int]string) error {
+	if err := validateAccessLevels("user", userAccesses); err != nil {
+		return err
+	}
+
+	return c.validateUserIDs(sortedAccessIDs(userAccesses))
+}
+
+// validateUserIDs checks that every user ID refers to an existing user.
+// Users are only listed when at least one ID is provided.
+func (c *PortainerClient) validateUserIDs(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	users, err := c.cli.ListUsers()
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	existing := make(map[int]bool, len(users))
+	for _, user := range users {
+		existing[int(user.ID)] = true
+	}
+
+	for _, id := range ids {
+		if !existing[id] {
+			return fmt.Errorf("user %d does not exist", id)
+		}
+	}
+
+	return nil
+}
+
+// validateTeamAccesses checks that every access level in the map is accepted by Portainer
+// and that every team ID refers to an existing team.
+// Nothing is sent to Portainer when validation fails, so an access change is never half-applied.
+//
+// Parameters:
+//   - teamAccesses: Map of team IDs to their access level
+//
+// Returns:
+//   - An error naming the first offending team ID or access level
+func (c *PortainerClient) validateTeamAccesses(teamAccesses map[int]string) error {
+	if err := validateAccessLevels("team", teamAccesses); err != nil {
+		return err
+	}
+
+	return c.validateTeamIDs(sortedAccessIDs(teamAccesses))
+}
+
+// validateTeamIDs checks that every team ID refers to an existing team.
+// Teams are only listed when at least one ID is provided.
+func (c *PortainerClient) validateTeamIDs(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	teams, err := c.cli.ListTeams()
+	if err != nil {
+		return fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	existing := make(map[int]bool, len(teams))
+	for _, team := range teams {
+		existing[int(team.ID)] = true
+	}
+
+	for _, id := range ids {
+		if !existing[id] {
+			return fmt.Errorf("team %d does not exist", id)
+		}
+	}
+
+	return nil
+}
+
+// validateAccessLevels checks the access levels of an access map without contacting Portainer.
+// The kind is used to name the offender in the error message (e.g. "user" or "team").
+func validateAccessLevels(kind string, accesses map[int]string) error {
+	for _, id := range sortedAccessIDs(accesses) {
+		if !models.IsValidAccessLevel(accesses[id]) {
+			return fmt.Errorf("invalid access level %q for %s %d: must be one of: %v", accesses[id], kind, id, models.AllAccessLevels)
+		}
+	}
+	return nil
+}
+
+// sortedAccessIDs returns the IDs of an access map in ascending order so that
+// validation errors are reported deterministically.
+func sortedAccessIDs(accesses map[int]string) []int {
+	ids := make([]int, 0, len(accesses))
+	for id := range accesses {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -2,11 +2,17 @@ package client
 
 import (
 	"fmt"
+	"slices"
 
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
 )
 
+// unassignedAccessGroupID is the ID of the Unassigned access group, Portainer moves the environments
+// removed from an access group to this group
+const unassignedAccessGroupID = 1
+
 // GetAccessGroups retrieves all access groups from the Portainer server.
 // Access groups are the equivalent of Endpoint Groups in Portainer.
 //
@@ -78,9 +84,16 @@ func (c *PortainerClient) UpdateAccessGroupName(id int, name string) error {
 //   - readonly_user
 //   - operator_user
 //
+// The access map is validated before anything is sent to Portainer: every access level
+// must be valid and every user must exist.
+//
 // Returns:
-//   - An error if the operation fails
+//   - An error if the validation or the operation fails
 func (c *PortainerClient) UpdateAccessGroupUserAccesses(id int, userAccesses map[int]string) error {
+	if err := c.validateUserAccesses(userAccesses); err != nil {
+		return fmt.Errorf("invalid user accesses: %w", err)
+	}
+
 	uac := utils.IntToInt64Map(userAccesses)
 	err := c.cli.UpdateEndpointGroup(int64(id), nil, &uac, nil)
 	if err != nil {
@@ -102,9 +115,16 @@ func (c *PortainerClient) UpdateAccessGroupUserAccesses(id int, userAccesses map
 //   - readonly_user
 //   - operator_user
 //
+// The access map is validated before anything is sent to Portainer: every access level
+// must be valid and every team must exist.
+//
 // Returns:
-//   - An error if the operation fails
+//   - An error if the validation or the operation fails
 func (c *PortainerClient) UpdateAccessGroupTeamAccesses(id int, teamAccesses map[int]string) error {
+	if err := c.validateTeamAccesses(teamAccesses); err != nil {
+		return fmt.Errorf("invalid team accesses: %w", err)
+	}
+
 	tac := utils.IntToInt64Map(teamAccesses)
 	err := c.cli.UpdateEndpointGroup(int64(id), nil, nil, &tac)
 	if err != nil {
@@ -136,3 +156,182 @@ func (c *PortainerClient) AddEnvironmentToAccessGroup(id int, environmentId int)
 func (c *PortainerClient) RemoveEnvironmentFromAccessGroup(id int, environmentId int) error {
 	return c.cli.RemoveEnvironmentFromEndpointGroup(int64(id), int64(environmentId))
 }
+
+// UpdateAccessGroup applies several changes to an access group in one logical operation: its name,
+// its environments and its user and team accesses. Everything is validated before anything is sent
+// to Portainer.
+//
+// Portainer has no transaction across these changes. The name and the accesses are updated in a
+// single request, then each environment is moved in or out of the group with its own request.
+// When a request fails, the changes already applied are rolled back: the environments go back to
+// their previous access group and the previous name and accesses are restored. If the rollback
+// fails too, the access group is left partially updated and the returned AccessGroupUpdateError
+// lists what could not be restored.
+//
+// Parameters:
+//   - id: The ID of the access group
+//   - update: The changes to apply, nil fields are left unchanged
+//
+// Returns:
+//   - An AccessGroupUpdateError if the update failed after some changes were applied
+//   - An error if the update is invalid or if the operation fails before any change is applied
+func (c *PortainerClient) UpdateAccessGroup(id int, update models.AccessGroupUpdate) error {
+	if update.IsEmpty() {
+		return fmt.Errorf("at least one change is required")
+	}
+
+	if update.Name != nil && *update.Name == "" {
+		return fmt.Errorf("access group name cannot be empty")
+	}
+
+	if update.UserAccesses != nil {
+		if err := c.validateUserAccesses(update.UserAccesses); err != nil {
+			return fmt.Errorf("invalid user accesses: %w", err)
+		}
+	}
+
+	if update.TeamAccesses != nil {
+		if err := c.validateTeamAccesses(update.TeamAccesses); err != nil {
+			return fmt.Errorf("invalid team accesses: %w", err)
+		}
+	}
+
+	groups, err := c.cli.ListEndpointGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list access groups: %w", err)
+	}
+
+	groupIndex := slices.IndexFunc(groups, func(group *apimodels.PortainerEndpointGroup) bool {
+		return group.ID == int64(id)
+	})
+	if groupIndex < 0 {
+		return fmt.Errorf("access group %d not found", id)
+	}
+
+	endpoints, err := c.cli.ListEndpoints()
+	if err != nil {
+		return fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	current := models.ConvertEndpointGroupToAccessGroup(groups[groupIndex], endpoints)
+
+	previousGroups := make(map[int]int, len(endpoints))
+	for _, endpoint := range endpoints {
+		previousGroups[int(endpoint.ID)] = int(endpoint.GroupID)
+	}
+
+	var added, removed []int
+	if update.EnvironmentIds != nil {
+		if id == unassignedAccessGroupID {
+			return fmt.Errorf("the environments of the Unassigned access group cannot be changed, move them to another access group instead")
+		}
+
+		for _, environmentId := range update.EnvironmentIds {
+			if _, exists := previousGroups[environmentId]; !exists {
+				return fmt.Errorf("environment %d not found", environmentId)
+			}
+		}
+
+		for _, environmentId := range uniqueSortedIDs(update.EnvironmentIds) {
+			if !slices.Contains(current.EnvironmentIds, environmentId) {
+				added = append(added, environmentId)
+			}
+		}
+		for _, environmentId := range current.EnvironmentIds {
+			if !slices.Contains(update.EnvironmentIds, environmentId) {
+				removed = append(removed, environmentId)
+			}
+		}
+	}
+
+	updateErr := &AccessGroupUpdateError{GroupID: id}
+
+	if update.Name != nil || update.UserAccesses != nil || update.TeamAccesses != nil {
+		var userAccesses, teamAccesses *map[int64]string
+		if update.UserAccesses != nil {
+			uac := utils.IntToInt64Map(update.UserAccesses)
+			userAccesses = &uac
+		}
+		if update.TeamAccesses != nil {
+			tac := utils.IntToInt64Map(update.TeamAccesses)
+			teamAccesses = &tac
+		}
+
+		// Nothing was applied yet, a failure of the first request leaves the access group unchanged
+		if err := c.cli.UpdateEndpointGroup(int64(id), update.Name, userAccesses, teamAccesses); err != nil {
+			return fmt.Errorf("failed to update access group: %w", err)
+		}
+		updateErr.settingsUpdated = true
+	}
+
+	for _, environmentId := range added {
+		if err := c.cli.AddEnvironmentToEndpointGroup(int64(id), int64(environmentId)); err != nil {
+			updateErr.Err = fmt.Errorf("failed to add environment %d to access group: %w", environmentId, err)
+			c.rollbackAccessGroupUpdate(current, update, previousGroups, updateErr)
+			return updateErr
+		}
+		updateErr.added = append(updateErr.added, environmentId)
+	}
+
+	for _, environmentId := range removed {
+		if err := c.cli.RemoveEnvironmentFromEndpointGroup(int64(id), int64(environmentId)); err != nil {
+			updateErr.Err = fmt.Errorf("failed to remove environment %d from access group: %w", environmentId, err)
+			c.rollbackAccessGroupUpdate(current, update, previousGroups, updateErr)
+			return updateErr
+		}
+		updateErr.removed = append(updateErr.removed, environmentId)
+	}
+
+	return nil
+}
+
+// rollbackAccessGroupUpdate undoes the changes recorded in updateErr, in the reverse order they were applied,
+// and records the changes that could not be undone in updateErr.RollbackFailures
+func (c *PortainerClient) rollbackAccessGroupUpdate(previous models.AccessGroup, update models.AccessGroupUpdate, previousGroups map[int]int, updateErr *AccessGroupUpdateError) {
+	for _, environmentId := range slices.Backward(updateErr.removed) {
+		if err := c.cli.AddEnvironmentToEndpointGroup(int64(previous.ID), int64(environmentId)); err != nil {
+			updateErr.RollbackFailures = append(updateErr.RollbackFailures,
+				fmt.Sprintf("environment %d was not added back to the access group: %v", environmentId, err))
+		}
+	}
+
+	for _, environmentId := range slices.Backward(updateErr.added) {
+		previousGroup := previousGroups[environmentId]
+
+		var err error
+		if previousGroup == unassignedAccessGroupID {
+			err = c.cli.RemoveEnvironmentFromEndpointGroup(int64(previous.ID), int64(environmentId))
+		} else {
+			err = c.cli.AddEnvironmentToEndpointGroup(int64(previousGroup), int64(environmentId))
+		}
+		if err != nil {
+			updateErr.RollbackFailures = append(updateErr.RollbackFailures,
+				fmt.Sprintf("environment %d was not moved back to access group %d: %v", environmentId, previousGroup, err))
+		}
+	}
+
+	if !updateErr.settingsUpdated {
+		return
+	}
+
+	var (
+		name                       *string
+		userAccesses, teamAccesses *map[int64]string
+	)
+	if update.Name != nil {
+		name = &previous.Name
+	}
+	if update.UserAccesses != nil {
+		uac := utils.IntToInt64Map(previous.UserAccesses)
+		userAccesses = &uac
+	}
+	if update.TeamAccesses != nil {
+		tac := utils.IntToInt64Map(previous.TeamAccesses)
+		teamAccesses = &tac
+	}
+
+	if err := c.cli.UpdateEndpointGroup(int64(previous.ID), name, userAccesses, teamAccesses); err != nil {
+		updateErr.RollbackFailures = append(updateErr.RollbackFailures,
+			fmt.Sprintf("the name and accesses of the access group were not restored: %v", err))
+	}
+}
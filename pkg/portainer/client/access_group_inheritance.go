@@ -0,0 +1,99 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// GetGroupInheritedAccess retrieves the user and team accesses of an access group (endpoint group)
+// and resolves how each environment of the group inherits them. For a given user or team, an access
+// set on the environment replaces the access inherited from the group, even when it grants fewer
+// privileges. Note that a user access always takes precedence over the team accesses of the user,
+// including a user access inherited from the group over a team access set on the environment.
+//
+// Parameters:
+//   - groupId: The ID of the access group
+//
+// Returns:
+//   - The accesses of the group and of each of its environments
+//   - An error if the access group does not exist or if the operation fails
+func (c *PortainerClient) GetGroupInheritedAccess(groupId int) (models.InheritedAccess, error) {
+	groups, err := c.cli.ListEndpointGroups()
+	if err != nil {
+		return models.InheritedAccess{}, fmt.Errorf("failed to list endpoint groups: %w", err)
+	}
+
+	endpoints, err := c.cli.ListEndpoints()
+	if err != nil {
+		return models.InheritedAccess{}, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	var group *models.AccessGroup
+	for _, rawGroup := range groups {
+		if int(rawGroup.ID) == groupId {
+			accessGroup := models.ConvertEndpointGroupToAccessGroup(rawGroup, endpoints)
+			group = &accessGroup
+			break
+		}
+	}
+	if group == nil {
+		return models.InheritedAccess{}, fmt.Errorf("access group %d not found", groupId)
+	}
+
+	inherited := models.InheritedAccess{
+		AccessGroupID:   group.ID,
+		AccessGroupName: group.Name,
+		UserAccesses:    group.UserAccesses,
+		TeamAccesses:    group.TeamAccesses,
+		Environments:    []models.EnvironmentInheritedAccess{},
+	}
+
+	for _, endpoint := range endpoints {
+		if int(endpoint.GroupID) != groupId {
+			continue
+		}
+
+		environment := models.ConvertEndpointToEnvironment(endpoint)
+		access := models.EnvironmentInheritedAccess{
+			EnvironmentID:   environment.ID,
+			EnvironmentName: environment.Name,
+		}
+		access.InheritedUserAccesses, access.OverriddenUserAccesses, access.DirectUserAccesses = resolveInheritedAccesses(group.UserAccesses, environment.UserAccesses)
+		access.InheritedTeamAccesses, access.OverriddenTeamAccesses, access.DirectTeamAccesses = resolveInheritedAccesses(group.TeamAccesses, environment.TeamAccesses)
+
+		inherited.Environments = append(inherited.Environments, access)
+	}
+
+	sort.Slice(inherited.Environments, func(i, j int) bool {
+		return inherited.Environments[i].EnvironmentID < inherited.Environments[j].EnvironmentID
+	})
+
+	return inherited, nil
+}
+
+// resolveInheritedAccesses splits the accesses of an access group and of one of its environments into
+// the group accesses inherited by the environment, the environment accesses overriding a group access
+// and the environment accesses of the users or teams the group gives no access to
+func resolveInheritedAccesses(groupAccesses, environmentAccesses map[int]string) (map[int]string, map[int]string, map[int]string) {
+	inherited := map[int]string{}
+	overridden := map[int]string{}
+	direct := map[int]string{}
+
+	for id, level := range groupAccesses {
+		if environmentLevel, ok := environmentAccesses[id]; ok {
+			overridden[id] = environmentLevel
+			continue
+		}
+		inherited[id] = level
+	}
+
+	for id, level := range environmentAccesses {
+		if _, ok := groupAccesses[id]; !ok {
+			direct[id] = level
+		}
+	}
+
+	return inherited, overridden, direct
+}
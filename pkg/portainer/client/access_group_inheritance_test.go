@@ -0,0 +1,140 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGroupInheritedAccess(t *testing.T) {
+	mockGroups := []*apimodels.PortainerEndpointGroup{
+		{ID: 1, Name: "Unassigned"},
+		{
+			ID:   2,
+			Name: "production",
+			UserAccessPolicies: apimodels.PortainerUserAccessPolicies{
+				"1": apimodels.PortainerAccessPolicy{RoleID: 3}, // standard_user
+				"2": apimodels.PortainerAccessPolicy{RoleID: 4}, // readonly_user
+			},
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+				"5": apimodels.PortainerAccessPolicy{RoleID: 5}, // operator_user
+			},
+		},
+	}
+	mockEndpoints := []*apimodels.PortainereeEndpoint{
+		{
+			ID:      4,
+			Name:    "prod-eu",
+			GroupID: 2,
+			UserAccessPolicies: apimodels.PortainerUserAccessPolicies{
+				"2": apimodels.PortainerAccessPolicy{RoleID: 1}, // environment_administrator
+				"3": apimodels.PortainerAccessPolicy{RoleID: 4}, // readonly_user
+			},
+		},
+		{ID: 3, Name: "prod-us", GroupID: 2},
+		{ID: 5, Name: "staging", GroupID: 1},
+	}
+
+	tests := []struct {
+		name              string
+		groupId           int
+		mockGroupsError   error
+		mockEndpointError error
+		expected          models.InheritedAccess
+		expectedError     string
+	}{
+		{
+			name:    "group with environments",
+			groupId: 2,
+			expected: models.InheritedAccess{
+				AccessGroupID:   2,
+				AccessGroupName: "production",
+				UserAccesses:    map[int]string{1: models.AccessLevelStandardUser, 2: models.AccessLevelReadonlyUser},
+				TeamAccesses:    map[int]string{5: models.AccessLevelOperatorUser},
+				Environments: []models.EnvironmentInheritedAccess{
+					{
+						EnvironmentID:          3,
+						EnvironmentName:        "prod-us",
+						InheritedUserAccesses:  map[int]string{1: models.AccessLevelStandardUser, 2: models.AccessLevelReadonlyUser},
+						InheritedTeamAccesses:  map[int]string{5: models.AccessLevelOperatorUser},
+						OverriddenUserAccesses: map[int]string{},
+						OverriddenTeamAccesses: map[int]string{},
+						DirectUserAccesses:     map[int]string{},
+						DirectTeamAccesses:     map[int]string{},
+					},
+					{
+						EnvironmentID:          4,
+						EnvironmentName:        "prod-eu",
+						InheritedUserAccesses:  map[int]string{1: models.AccessLevelStandardUser},
+						InheritedTeamAccesses:  map[int]string{5: models.AccessLevelOperatorUser},
+						OverriddenUserAccesses: map[int]string{2: models.AccessLevelEnvironmentAdmin},
+						OverriddenTeamAccesses: map[int]string{},
+						DirectUserAccesses:     map[int]string{3: models.AccessLevelReadonlyUser},
+						DirectTeamAccesses:     map[int]string{},
+					},
+				},
+			},
+		},
+		{
+			name:    "group without accesses",
+			groupId: 1,
+			expected: models.InheritedAccess{
+				AccessGroupID:   1,
+				AccessGroupName: "Unassigned",
+				UserAccesses:    map[int]string{},
+				TeamAccesses:    map[int]string{},
+				Environments: []models.EnvironmentInheritedAccess{
+					{
+						EnvironmentID:          5,
+						EnvironmentName:        "staging",
+						InheritedUserAccesses:  map[int]string{},
+						InheritedTeamAccesses:  map[int]string{},
+						OverriddenUserAccesses: map[int]string{},
+						OverriddenTeamAccesses: map[int]string{},
+						DirectUserAccesses:     map[int]string{},
+						DirectTeamAccesses:     map[int]string{},
+					},
+				},
+			},
+		},
+		{
+			name:          "group not found",
+			groupId:       9,
+			expectedError: "access group 9 not found",
+		},
+		{
+			name:            "list groups error",
+			groupId:         2,
+			mockGroupsError: errors.New("unauthorized"),
+			expectedError:   "failed to list endpoint groups",
+		},
+		{
+			name:              "list endpoints error",
+			groupId:           2,
+			mockEndpointError: errors.New("unauthorized"),
+			expectedError:     "failed to list endpoints",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEndpointGroups").Return(mockGroups, tt.mockGroupsError)
+			mockAPI.On("ListEndpoints").Return(mockEndpoints, tt.mockEndpointError).Maybe()
+
+			client := &PortainerClient{cli: mockAPI}
+
+			inherited, err := client.GetGroupInheritedAccess(tt.groupId)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, inherited)
+		})
+	}
+}
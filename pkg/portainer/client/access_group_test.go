@@ -237,7 +237,9 @@ func TestUpdateAccessGroupUserAccesses(t *testing.T) {
 		groupID       int
 		userAccesses  map[int]string
 		mockError     error
+		mockListError error
 		expectedError bool
+		skipUpdate    bool
 	}{
 		{
 			name:    "successful update",
@@ -256,12 +258,45 @@ func TestUpdateAccessGroupUserAccesses(t *testing.T) {
 			mockError:     errors.New("failed to update user accesses"),
 			expectedError: true,
 		},
+		{
+			name:    "invalid access level",
+			groupID: 1,
+			userAccesses: map[int]string{
+				1: "environment_administrator",
+				2: "superuser",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:    "non-existent user",
+			groupID: 1,
+			userAccesses: map[int]string{
+				1:  "environment_administrator",
+				99: "standard_user",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:    "list users error",
+			groupID: 1,
+			userAccesses: map[int]string{
+				1: "environment_administrator",
+			},
+			mockListError: errors.New("failed to list users"),
+			expectedError: true,
+			skipUpdate:    true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAPI := new(MockPortainerAPI)
-			mockAPI.On("UpdateEndpointGroup", int64(tt.groupID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			mockAPI.On("ListUsers").Return(existingUsers, tt.mockListError).Maybe()
+			if !tt.skipUpdate {
+				mockAPI.On("UpdateEndpointGroup", int64(tt.groupID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			}
 
 			client := &PortainerClient{cli: mockAPI}
 
@@ -269,6 +304,9 @@ func TestUpdateAccessGroupUserAccesses(t *testing.T) {
 
 			if tt.expectedError {
 				assert.Error(t, err)
+				if tt.skipUpdate {
+					mockAPI.AssertNotCalled(t, "UpdateEndpointGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -283,7 +321,9 @@ func TestUpdateAccessGroupTeamAccesses(t *testing.T) {
 		groupID       int
 		teamAccesses  map[int]string
 		mockError     error
+		mockListError error
 		expectedError bool
+		skipUpdate    bool
 	}{
 		{
 			name:    "successful update",
@@ -302,12 +342,45 @@ func TestUpdateAccessGroupTeamAccesses(t *testing.T) {
 			mockError:     errors.New("failed to update team accesses"),
 			expectedError: true,
 		},
+		{
+			name:    "invalid access level",
+			groupID: 1,
+			teamAccesses: map[int]string{
+				1: "environment_administrator",
+				2: "superuser",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:    "non-existent team",
+			groupID: 1,
+			teamAccesses: map[int]string{
+				1:  "environment_administrator",
+				99: "standard_user",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:    "list teams error",
+			groupID: 1,
+			teamAccesses: map[int]string{
+				1: "environment_administrator",
+			},
+			mockListError: errors.New("failed to list teams"),
+			expectedError: true,
+			skipUpdate:    true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAPI := new(MockPortainerAPI)
-			mockAPI.On("UpdateEndpointGroup", int64(tt.groupID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			mockAPI.On("ListTeams").Return(existingTeams, tt.mockListError).Maybe()
+			if !tt.skipUpdate {
+				mockAPI.On("UpdateEndpointGroup", int64(tt.groupID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			}
 
 			client := &PortainerClient{cli: mockAPI}
 
@@ -315,6 +388,9 @@ func TestUpdateAccessGroupTeamAccesses(t *testing.T) {
 
 			if tt.expectedError {
 				assert.Error(t, err)
+				if tt.skipUpdate {
+					mockAPI.AssertNotCalled(t, "UpdateEndpointGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -404,3 +480,172 @@ func TestRemoveEnvironmentFromAccessGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateAccessGroup(t *testing.T) {
+	prodName := "prod"
+	devName := "dev"
+	mockGroups := []*apimodels.PortainerEndpointGroup{
+		{ID: 1, Name: "Unassigned"},
+		{
+			ID:   2,
+			Name: "dev",
+			UserAccessPolicies: apimodels.PortainerUserAccessPolicies{
+				"1": apimodels.PortainerAccessPolicy{RoleID: 4}, // readonly_user
+			},
+		},
+		{ID: 3, Name: "staging"},
+	}
+	mockEndpoints := []*apimodels.PortainereeEndpoint{
+		{ID: 1, GroupID: 2},
+		{ID: 2, GroupID: 2},
+		{ID: 3, GroupID: 3},
+		{ID: 4, GroupID: 1},
+	}
+	newUserAccesses := map[int64]string{1: "environment_administrator"}
+	previousUserAccesses := map[int64]string{1: "readonly_user"}
+
+	tests := []struct {
+		name                  string
+		groupID               int
+		update                models.AccessGroupUpdate
+		setupMock             func(mockAPI *MockPortainerAPI)
+		expectedError         string
+		expectUpdateError     bool
+		expectRollbackFailure bool
+	}{
+		{
+			name:    "successful update",
+			groupID: 2,
+			update: models.AccessGroupUpdate{
+				Name:           &prodName,
+				EnvironmentIds: []int{1, 3, 4},
+				UserAccesses:   map[int]string{1: "environment_administrator"},
+			},
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("UpdateEndpointGroup", int64(2), &prodName, &newUserAccesses, (*map[int64]string)(nil)).Return(nil)
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(3)).Return(nil)
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(4)).Return(nil)
+				mockAPI.On("RemoveEnvironmentFromEndpointGroup", int64(2), int64(2)).Return(nil)
+			},
+		},
+		{
+			name:    "environments only",
+			groupID: 2,
+			update:  models.AccessGroupUpdate{EnvironmentIds: []int{1, 2, 3}},
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(3)).Return(nil)
+			},
+		},
+		{
+			name:          "empty update",
+			groupID:       2,
+			expectedError: "at least one change is required",
+		},
+		{
+			name:          "invalid access level",
+			groupID:       2,
+			update:        models.AccessGroupUpdate{UserAccesses: map[int]string{1: "superuser"}},
+			expectedError: "invalid user accesses",
+		},
+		{
+			name:          "access group not found",
+			groupID:       9,
+			update:        models.AccessGroupUpdate{Name: &prodName},
+			expectedError: "access group 9 not found",
+		},
+		{
+			name:          "environment not found",
+			groupID:       2,
+			update:        models.AccessGroupUpdate{EnvironmentIds: []int{1, 99}},
+			expectedError: "environment 99 not found",
+		},
+		{
+			name:          "environments of the unassigned group",
+			groupID:       1,
+			update:        models.AccessGroupUpdate{EnvironmentIds: []int{4}},
+			expectedError: "Unassigned access group cannot be changed",
+		},
+		{
+			name:    "name and accesses update error",
+			groupID: 2,
+			update: models.AccessGroupUpdate{
+				Name:           &prodName,
+				EnvironmentIds: []int{1, 2, 3},
+			},
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("UpdateEndpointGroup", int64(2), &prodName, (*map[int64]string)(nil), (*map[int64]string)(nil)).Return(errors.New("conflict"))
+			},
+			expectedError: "failed to update access group: conflict",
+		},
+		{
+			name:    "environment move error rolled back",
+			groupID: 2,
+			update: models.AccessGroupUpdate{
+				Name:           &prodName,
+				EnvironmentIds: []int{1, 3, 4},
+				UserAccesses:   map[int]string{1: "environment_administrator"},
+			},
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("UpdateEndpointGroup", int64(2), &prodName, &newUserAccesses, (*map[int64]string)(nil)).Return(nil).Once()
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(3)).Return(nil).Once()
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(4)).Return(nil).Once()
+				mockAPI.On("RemoveEnvironmentFromEndpointGroup", int64(2), int64(2)).Return(errors.New("server error")).Once()
+				// Rollback
+				mockAPI.On("RemoveEnvironmentFromEndpointGroup", int64(2), int64(4)).Return(nil).Once()
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(3), int64(3)).Return(nil).Once()
+				mockAPI.On("UpdateEndpointGroup", int64(2), &devName, &previousUserAccesses, (*map[int64]string)(nil)).Return(nil).Once()
+			},
+			expectedError:     "the changes already applied were rolled back",
+			expectUpdateError: true,
+		},
+		{
+			name:    "rollback error",
+			groupID: 2,
+			update: models.AccessGroupUpdate{
+				Name:           &prodName,
+				EnvironmentIds: []int{1, 2, 3},
+			},
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("UpdateEndpointGroup", int64(2), &prodName, (*map[int64]string)(nil), (*map[int64]string)(nil)).Return(nil).Once()
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(3)).Return(errors.New("server error")).Once()
+				// Rollback
+				mockAPI.On("UpdateEndpointGroup", int64(2), &devName, (*map[int64]string)(nil), (*map[int64]string)(nil)).Return(errors.New("server unreachable")).Once()
+			},
+			expectedError:         "the access group is partially updated",
+			expectUpdateError:     true,
+			expectRollbackFailure: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListUsers").Return(existingUsers, nil).Maybe()
+			mockAPI.On("ListEndpointGroups").Return(mockGroups, nil).Maybe()
+			mockAPI.On("ListEndpoints").Return(mockEndpoints, nil).Maybe()
+			if tt.setupMock != nil {
+				tt.setupMock(mockAPI)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateAccessGroup(tt.groupID, tt.update)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+
+				var updateErr *AccessGroupUpdateError
+				assert.Equal(t, tt.expectUpdateError, errors.As(err, &updateErr))
+				if tt.expectUpdateError {
+					assert.Equal(t, tt.expectRollbackFailure, len(updateErr.RollbackFailures) > 0)
+				}
+				if tt.setupMock == nil {
+					mockAPI.AssertNotCalled(t, "UpdateEndpointGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// accessReportHeader is the header row of the CSV access report
+var accessReportHeader = []string{"user", "environment", "effective_role", "source"}
+
+// ExportAccessReport builds a CSV report of the effective role of every user on every environment
+// they can access. Each row holds the username, the environment name, the effective role and
+// the access policy the role comes from. Rows are sorted by username and environment name.
+//
+// Returns:
+//   - The CSV report, including a header row
+//   - An error if the operation fails
+func (c *PortainerClient) ExportAccessReport() (string, error) {
+	users, err := c.GetUsers()
+	if err != nil {
+		return "", err
+	}
+
+	teams, err := c.GetTeams()
+	if err != nil {
+		return "", err
+	}
+
+	accessGroups, err := c.GetAccessGroups()
+	if err != nil {
+		return "", fmt.Errorf("failed to get access groups: %w", err)
+	}
+
+	environments, err := c.GetEnvironments()
+	if err != nil {
+		return "", err
+	}
+
+	accesses := resolveEffectiveAccesses(users, teams, accessGroups, environments)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(accessReportHeader); err != nil {
+		return "", fmt.Errorf("failed to write access report: %w", err)
+	}
+	for _, access := range accesses {
+		if err := w.Write([]string{access.Username, access.EnvironmentName, access.Role, access.Source}); err != nil {
+			return "", fmt.Errorf("failed to write access report: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write access report: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// resolveEffectiveAccesses resolves the effective role of every user on every environment
+// the same way Portainer does. For each user and environment, the first matching policy wins:
+//  1. Portainer and edge administrators are environment administrators everywhere
+//  2. The user access policy of the environment
+//  3. The user access policy of the access group of the environment
+//  4. The team access policies of the environment, keeping the most privileged role across the user's teams
+//  5. The team access policies of the access group of the environment, resolved like the above
+//
+// Users without any policy on an environment cannot access it and get no entry for it.
+// The accesses are sorted by username and environment name.
+func resolveEffectiveAccesses(users []models.User, teams []models.Team, accessGroups []models.AccessGroup, environments []models.Environment) []models.EffectiveAccess {
+	environmentGroups := make(map[int]models.AccessGroup)
+	for _, group := range accessGroups {
+		for _, environmentId := range group.EnvironmentIds {
+			environmentGroups[environmentId] = group
+		}
+	}
+
+	userTeams := make(map[int][]models.Team)
+	for _, team := range teams {
+		for _, memberId := range team.MemberIDs {
+			userTeams[memberId] = append(userTeams[memberId], team)
+		}
+	}
+
+	accesses := []models.EffectiveAccess{}
+	for _, user := range users {
+		for _, environment := range environments {
+			role, source, ok := resolveEffectiveAccess(user, userTeams[user.ID], environment, environmentGroups)
+			if !ok {
+				continue
+			}
+
+			accesses = append(accesses, models.EffectiveAccess{
+				UserID:          user.ID,
+				Username:        user.Username,
+				EnvironmentID:   environment.ID,
+				EnvironmentName: environment.Name,
+				Role:            role,
+				Source:          source,
+			})
+		}
+	}
+
+	sort.SliceStable(accesses, func(i, j int) bool {
+		if accesses[i].Username != accesses[j].Username {
+			return accesses[i].Username < accesses[j].Username
+		}
+		return accesses[i].EnvironmentName < accesses[j].EnvironmentName
+	})
+
+	return accesses
+}
+
+// resolveEffectiveAccess returns the effective role of a user on an environment and the policy it comes from.
+// The last return value is false when the user cannot access the environment.
+func resolveEffectiveAccess(user models.User, teams []models.Team, environment models.Environment, environmentGroups map[int]models.AccessGroup) (string, string, bool) {
+	switch user.Role {
+	case models.UserRoleAdmin:
+		return models.AccessLevelEnvironmentAdmin, "Portainer administrator", true
+	case models.UserRoleEdgeAdmin:
+		return models.AccessLevelEnvironmentAdmin, "Edge administrator", true
+	}
+
+	if role, ok := environment.UserAccesses[user.ID]; ok {
+		return role, "user access on environment", true
+	}
+
+	group, hasGroup := environmentGroups[environment.ID]
+	if hasGroup {
+		if role, ok := group.UserAccesses[user.ID]; ok {
+			return role, fmt.Sprintf("user access on access group %s", group.Name), true
+		}
+	}
+
+	if role, team, ok := mostPrivilegedTeamAccess(teams, environment.TeamAccesses); ok {
+		return role, fmt.Sprintf("team %s access on environment", team.Name), true
+	}
+
+	if hasGroup {
+		if role, team, ok := mostPrivilegedTeamAccess(teams, group.TeamAccesses); ok {
+			return role, fmt.Sprintf("team %s access on access group %s", team.Name, group.Name), true
+		}
+	}
+
+	return "", "", false
+}
+
+// mostPrivilegedTeamAccess returns the most privileged role granted to one of the teams by the team accesses
+// and the team granting it. Ties are resolved in favor of the team with the lowest ID.
+func mostPrivilegedTeamAccess(teams []models.Team, teamAccesses map[int]string) (string, models.Team, bool) {
+	var (
+		bestRole string
+		bestTeam models.Team
+		found    bool
+	)
+
+	for _, team := range teams {
+		role, ok := teamAccesses[team.ID]
+		if !ok {
+			continue
+		}
+
+		if !found || models.IsMorePrivilegedAccessLevel(role, bestRole) || (role == bestRole && team.ID < bestTeam.ID) {
+			bestRole, bestTeam, found = role, team, true
+		}
+	}
+
+	return bestRole, bestTeam, found
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveEffectiveAccesses(t *testing.T) {
+	users := []models.User{
+		{ID: 1, Username: "admin", Role: models.UserRoleAdmin},
+		{ID: 2, Username: "alice", Role: models.UserRoleUser},
+		{ID: 3, Username: "bob", Role: models.UserRoleUser},
+		{ID: 4, Username: "carol", Role: models.UserRoleUser},
+		{ID: 5, Username: "dave", Role: models.UserRoleUser},
+	}
+	teams := []models.Team{
+		{ID: 1, Name: "devs", MemberIDs: []int{3, 4}},
+		{ID: 2, Name: "ops", MemberIDs: []int{4}},
+	}
+	accessGroups := []models.AccessGroup{
+		{
+			ID:             2,
+			Name:           "production",
+			EnvironmentIds: []int{10},
+			UserAccesses:   map[int]string{2: models.AccessLevelReadonlyUser},
+			TeamAccesses:   map[int]string{1: models.AccessLevelReadonlyUser},
+		},
+	}
+	environments := []models.Environment{
+		{
+			ID:           10,
+			Name:         "prod",
+			UserAccesses: map[int]string{2: models.AccessLevelEnvironmentAdmin},
+			TeamAccesses: map[int]string{},
+		},
+		{
+			ID:           20,
+			Name:         "dev",
+			UserAccesses: map[int]string{},
+			TeamAccesses: map[int]string{
+				1: models.AccessLevelStandardUser,
+				2: models.AccessLevelOperatorUser,
+			},
+		},
+	}
+
+	expected := []models.EffectiveAccess{
+		{UserID: 1, Username: "admin", EnvironmentID: 20, EnvironmentName: "dev", Role: models.AccessLevelEnvironmentAdmin, Source: "Portainer administrator"},
+		{UserID: 1, Username: "admin", EnvironmentID: 10, EnvironmentName: "prod", Role: models.AccessLevelEnvironmentAdmin, Source: "Portainer administrator"},
+		{UserID: 2, Username: "alice", EnvironmentID: 10, EnvironmentName: "prod", Role: models.AccessLevelEnvironmentAdmin, Source: "user access on environment"},
+		{UserID: 3, Username: "bob", EnvironmentID: 20, EnvironmentName: "dev", Role: models.AccessLevelStandardUser, Source: "team devs access on environment"},
+		{UserID: 3, Username: "bob", EnvironmentID: 10, EnvironmentName: "prod", Role: models.AccessLevelReadonlyUser, Source: "team devs access on access group production"},
+		{UserID: 4, Username: "carol", EnvironmentID: 20, EnvironmentName: "dev", Role: models.AccessLevelOperatorUser, Source: "team ops access on environment"},
+		{UserID: 4, Username: "carol", EnvironmentID: 10, EnvironmentName: "prod", Role: models.AccessLevelReadonlyUser, Source: "team devs access on access group production"},
+	}
+
+	assert.Equal(t, expected, resolveEffectiveAccesses(users, teams, accessGroups, environments))
+}
+
+func TestResolveEffectiveAccessPrecedence(t *testing.T) {
+	user := models.User{ID: 2, Username: "alice", Role: models.UserRoleUser}
+	teams := []models.Team{{ID: 1, Name: "devs", MemberIDs: []int{2}}}
+
+	tests := []struct {
+		name           string
+		environment    models.Environment
+		group          models.AccessGroup
+		expectedRole   string
+		expectedSource string
+		expectedAccess bool
+	}{
+		{
+			name: "user environment access overrides everything",
+			environment: models.Environment{
+				ID:           10,
+				UserAccesses: map[int]string{2: models.AccessLevelReadonlyUser},
+				TeamAccesses: map[int]string{1: models.AccessLevelEnvironmentAdmin},
+			},
+			group: models.AccessGroup{
+				Name:         "production",
+				UserAccesses: map[int]string{2: models.AccessLevelEnvironmentAdmin},
+			},
+			expectedRole:   models.AccessLevelReadonlyUser,
+			expectedSource: "user access on environment",
+			expectedAccess: true,
+		},
+		{
+			name: "user group access overrides team accesses",
+			environment: models.Environment{
+				ID:           10,
+				TeamAccesses: map[int]string{1: models.AccessLevelEnvironmentAdmin},
+			},
+			group: models.AccessGroup{
+				Name:         "production",
+				UserAccesses: map[int]string{2: models.AccessLevelHelpdeskUser},
+			},
+			expectedRole:   models.AccessLevelHelpdeskUser,
+			expectedSource: "user access on access group production",
+			expectedAccess: true,
+		},
+		{
+			name: "team environment access overrides team group access",
+			environment: models.Environment{
+				ID:           10,
+				TeamAccesses: map[int]string{1: models.AccessLevelReadonlyUser},
+			},
+			group: models.AccessGroup{
+				Name:         "production",
+				TeamAccesses: map[int]string{1: models.AccessLevelEnvironmentAdmin},
+			},
+			expectedRole:   models.AccessLevelReadonlyUser,
+			expectedSource: "team devs access on environment",
+			expectedAccess: true,
+		},
+		{
+			name:        "no access",
+			environment: models.Environment{ID: 10},
+			group:       models.AccessGroup{Name: "production"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.group.EnvironmentIds = []int{tt.environment.ID}
+			groups := map[int]models.AccessGroup{tt.environment.ID: tt.group}
+
+			role, source, ok := resolveEffectiveAccess(user, teams, tt.environment, groups)
+
+			assert.Equal(t, tt.expectedAccess, ok)
+			assert.Equal(t, tt.expectedRole, role)
+			assert.Equal(t, tt.expectedSource, source)
+		})
+	}
+}
+
+func TestExportAccessReport(t *testing.T) {
+	mockUsers := []*apimodels.PortainereeUser{
+		{ID: 1, Username: "admin", Role: 1},
+		{ID: 2, Username: "alice, jr", Role: 2},
+		{ID: 3, Username: "bob", Role: 2},
+	}
+	mockTeams := []*apimodels.PortainerTeam{{ID: 1, Name: "devs"}}
+	mockMemberships := []*apimodels.PortainerTeamMembership{{TeamID: 1, UserID: 3}}
+	mockGroups := []*apimodels.PortainerEndpointGroup{
+		{
+			ID:                 2,
+			Name:               "production",
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"1": {RoleID: 4}},
+		},
+	}
+	mockEndpoints := []*apimodels.PortainereeEndpoint{
+		{
+			ID:                 10,
+			Name:               "prod",
+			GroupID:            2,
+			UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"2": {RoleID: 3}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		mockUsersErr  error
+		expected      string
+		expectedError bool
+	}{
+		{
+			name: "successful export",
+			expected: "user,environment,effective_role,source\n" +
+				"admin,prod,environment_administrator,Portainer administrator\n" +
+				"\"alice, jr\",prod,standard_user,user access on environment\n" +
+				"bob,prod,readonly_user,team devs access on access group production\n",
+		},
+		{
+			name:          "list users error",
+			mockUsersErr:  errors.New("failed to list users"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListUsers").Return(mockUsers, tt.mockUsersErr)
+			mockAPI.On("ListTeams").Return(mockTeams, nil)
+			mockAPI.On("ListTeamMemberships").Return(mockMemberships, nil)
+			mockAPI.On("ListEndpointGroups").Return(mockGroups, nil)
+			mockAPI.On("ListEndpoints").Return(mockEndpoints, nil)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			report, err := client.ExportAccessReport()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, report)
+		})
+	}
+}
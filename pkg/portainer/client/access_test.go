@@ -0,0 +1,144 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// existingUsers and existingTeams are the users and teams known to the mocked Portainer instance
+// in the access validation tests.
+var (
+	existingUsers = []*apimodels.PortainereeUser{
+		{ID: 1, Username: "user1"},
+		{ID: 2, Username: "user2"},
+		{ID: 3, Username: "user3"},
+		{ID: 4, Username: "user4"},
+		{ID: 5, Username: "user5"},
+	}
+	existingTeams = []*apimodels.PortainerTeam{
+		{ID: 1, Name: "team1"},
+		{ID: 2, Name: "team2"},
+		{ID: 3, Name: "team3"},
+		{ID: 4, Name: "team4"},
+		{ID: 5, Name: "team5"},
+	}
+)
+
+func TestValidateUserAccesses(t *testing.T) {
+	tests := []struct {
+		name          string
+		userAccesses  map[int]string
+		mockError     error
+		expectList    bool
+		errorContains string
+	}{
+		{
+			name:         "valid accesses",
+			userAccesses: map[int]string{1: "environment_administrator", 5: "operator_user"},
+			expectList:   true,
+		},
+		{
+			name:         "empty accesses skip lookup",
+			userAccesses: map[int]string{},
+		},
+		{
+			name:          "invalid access level is named",
+			userAccesses:  map[int]string{1: "standard_user", 3: "root"},
+			errorContains: `invalid access level "root" for user 3`,
+		},
+		{
+			name:          "unknown user is named",
+			userAccesses:  map[int]string{42: "standard_user"},
+			expectList:    true,
+			errorContains: "user 42 does not exist",
+		},
+		{
+			name:          "list error",
+			userAccesses:  map[int]string{1: "standard_user"},
+			mockError:     errors.New("api error"),
+			expectList:    true,
+			errorContains: "failed to list users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectList {
+				mockAPI.On("ListUsers").Return(existingUsers, tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.validateUserAccesses(tt.userAccesses)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidateTeamAccesses(t *testing.T) {
+	tests := []struct {
+		name          string
+		teamAccesses  map[int]string
+		mockError     error
+		expectList    bool
+		errorContains string
+	}{
+		{
+			name:         "valid accesses",
+			teamAccesses: map[int]string{2: "helpdesk_user", 4: "readonly_user"},
+			expectList:   true,
+		},
+		{
+			name:         "empty accesses skip lookup",
+			teamAccesses: map[int]string{},
+		},
+		{
+			name:          "invalid access level is named",
+			teamAccesses:  map[int]string{2: "admin"},
+			errorContains: `invalid access level "admin" for team 2`,
+		},
+		{
+			name:          "unknown team is named",
+			teamAccesses:  map[int]string{1: "standard_user", 7: "standard_user"},
+			expectList:    true,
+			errorContains: "team 7 does not exist",
+		},
+		{
+			name:          "list error",
+			teamAccesses:  map[int]string{1: "standard_user"},
+			mockError:     errors.New("api error"),
+			expectList:    true,
+			errorContains: "failed to list teams",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectList {
+				mockAPI.On("ListTeams").Return(existingTeams, tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.validateTeamAccesses(tt.teamAccesses)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
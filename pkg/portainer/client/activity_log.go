@@ -0,0 +1,120 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// activityLogPageSize is the number of entries retrieved per request when the activity logs are
+// filtered by username or action
+const activityLogPageSize = 500
+
+// maxActivityLogPages is the maximum number of pages of activity logs scanned by a single query, so that
+// a query without time range on a busy Portainer server does not send thousands of requests
+const maxActivityLogPages = 20
+
+// GetActivityLogs retrieves the user activity logs from the Portainer server.
+// Activity logs are only available on Portainer Business Edition.
+//
+// The time range and, without username and action filters, the limit are applied by Portainer.
+// Portainer cannot filter by username or action, when these filters are set the entries of the time
+// range are retrieved page by page and filtered here, so that the limit and the total count apply to
+// the matching entries. The scan stops once one matching entry more than the limit is found, which is
+// enough to know that some were left out, or after maxActivityLogPages pages. The total count is then
+// a lower bound: it only counts the matching entries found so far. When the scan stops at the page cap
+// with older entries left, HasMore is set as well, narrowing the time range searches the older entries.
+//
+// Parameters:
+//   - opts: The filter to apply to the activity logs
+//
+// Returns:
+//   - An ActivityLogPage holding the ActivityLog objects, most recent first
+//   - ErrFeatureUnavailable if the Portainer server does not expose activity logs
+//   - An error if the operation fails
+func (c *PortainerClient) GetActivityLogs(opts models.ActivityLogFilter) (models.ActivityLogPage, error) {
+	var after, before int64
+	if !opts.After.IsZero() {
+		after = opts.After.Unix()
+	}
+	if !opts.Before.IsZero() {
+		before = opts.Before.Unix()
+	}
+
+	if opts.Username == "" && opts.Action == "" {
+		rawLogs, totalCount, err := c.cli.PageUserActivityLogs(after, before, int64(opts.Limit), 0)
+		if err != nil {
+			return models.ActivityLogPage{}, convertActivityLogError(err)
+		}
+
+		logs := make([]models.ActivityLog, 0, len(rawLogs))
+		for _, rawLog := range rawLogs {
+			logs = append(logs, models.ConvertToActivityLog(rawLog))
+		}
+
+		total := max(int(totalCount), len(logs))
+		return models.ActivityLogPage{
+			Items:      logs,
+			TotalCount: total,
+			HasMore:    total > len(logs),
+		}, nil
+	}
+
+	logs := []models.ActivityLog{}
+	matching := 0
+	exhausted := false
+	var offset int64
+	for page := 0; page < maxActivityLogPages && !exhausted; page++ {
+		rawLogs, totalCount, err := c.cli.PageUserActivityLogs(after, before, activityLogPageSize, offset)
+		if err != nil {
+			return models.ActivityLogPage{}, convertActivityLogError(err)
+		}
+
+		for _, rawLog := range rawLogs {
+			if !matchesActivityLogFilter(rawLog, opts) {
+				continue
+			}
+
+			matching++
+			if opts.Limit <= 0 || len(logs) < opts.Limit {
+				logs = append(logs, models.ConvertToActivityLog(rawLog))
+			}
+		}
+
+		offset += int64(len(rawLogs))
+		exhausted = len(rawLogs) < activityLogPageSize || offset >= totalCount
+
+		if opts.Limit > 0 && matching > opts.Limit {
+			break
+		}
+	}
+
+	return models.ActivityLogPage{
+		Items:      logs,
+		TotalCount: matching,
+		HasMore:    matching > len(logs) || !exhausted,
+	}, nil
+}
+
+// matchesActivityLogFilter reports whether an activity log entry matches the username and action filters
+func matchesActivityLogFilter(rawLog *apimodels.PortainereeUserActivityLog, opts models.ActivityLogFilter) bool {
+	if opts.Username != "" && !strings.EqualFold(rawLog.Username, opts.Username) {
+		return false
+	}
+
+	if opts.Action != "" && !strings.Contains(strings.ToLower(rawLog.Action), strings.ToLower(opts.Action)) {
+		return false
+	}
+
+	return true
+}
+
+// convertActivityLogError reports a missing activity log endpoint as ErrFeatureUnavailable
+func convertActivityLogError(err error) error {
+	if isNotFoundError(err) {
+		return fmt.Errorf("activity logs require Portainer Business Edition: %w", ErrFeatureUnavailable)
+	}
+	return fmt.Errorf("failed to list user activity logs: %w", err)
+}
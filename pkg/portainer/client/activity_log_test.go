@@ -0,0 +1,210 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetActivityLogs(t *testing.T) {
+	rawLogs := []*apimodels.PortainereeUserActivityLog{
+		{ID: 3, Timestamp: 300, Username: "admin", Context: "Portainer", Action: "DELETE /stacks/1"},
+		{ID: 2, Timestamp: 200, Username: "alice", Context: "Portainer", Action: "POST /endpoints"},
+		{ID: 1, Timestamp: 100, Username: "Admin", Context: "Portainer", Action: "POST /stacks"},
+	}
+
+	tests := []struct {
+		name               string
+		filter             models.ActivityLogFilter
+		expectedAfter      int64
+		expectedBefore     int64
+		expectedLimit      int64
+		mockLogs           []*apimodels.PortainereeUserActivityLog
+		mockTotalCount     int64
+		mockError          error
+		expectedIDs        []int
+		expectedTotalCount int
+		expectHasMore      bool
+		expectedError      bool
+		unavailable        bool
+	}{
+		{
+			name:               "no filter",
+			mockLogs:           rawLogs,
+			mockTotalCount:     3,
+			expectedIDs:        []int{3, 2, 1},
+			expectedTotalCount: 3,
+		},
+		{
+			name: "time range and limit are sent to portainer",
+			filter: models.ActivityLogFilter{
+				After:  time.Unix(50, 0),
+				Before: time.Unix(400, 0),
+				Limit:  3,
+			},
+			expectedAfter:      50,
+			expectedBefore:     400,
+			expectedLimit:      3,
+			mockLogs:           rawLogs,
+			mockTotalCount:     40,
+			expectedIDs:        []int{3, 2, 1},
+			expectedTotalCount: 40,
+			expectHasMore:      true,
+		},
+		{
+			name:               "limit leaving out older matching entries",
+			filter:             models.ActivityLogFilter{Username: "admin", Limit: 1},
+			expectedLimit:      activityLogPageSize,
+			mockLogs:           rawLogs,
+			mockTotalCount:     3,
+			expectedIDs:        []int{3},
+			expectedTotalCount: 2,
+			expectHasMore:      true,
+		},
+		{
+			name:               "username filter is case-insensitive",
+			filter:             models.ActivityLogFilter{Username: "admin"},
+			expectedLimit:      activityLogPageSize,
+			mockLogs:           rawLogs,
+			mockTotalCount:     3,
+			expectedIDs:        []int{3, 1},
+			expectedTotalCount: 2,
+		},
+		{
+			name:               "action filter matches substrings",
+			filter:             models.ActivityLogFilter{Action: "stacks"},
+			expectedLimit:      activityLogPageSize,
+			mockLogs:           rawLogs,
+			mockTotalCount:     3,
+			expectedIDs:        []int{3, 1},
+			expectedTotalCount: 2,
+		},
+		{
+			name:               "combined filters",
+			filter:             models.ActivityLogFilter{Username: "admin", Action: "delete"},
+			expectedLimit:      activityLogPageSize,
+			mockLogs:           rawLogs,
+			mockTotalCount:     3,
+			expectedIDs:        []int{3},
+			expectedTotalCount: 1,
+		},
+		{
+			name:          "endpoint not available",
+			mockError:     runtime.NewAPIError("LogsList", nil, http.StatusNotFound),
+			expectedError: true,
+			unavailable:   true,
+		},
+		{
+			name:          "list error",
+			mockError:     errors.New("failed to list logs"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("PageUserActivityLogs", tt.expectedAfter, tt.expectedBefore, tt.expectedLimit, int64(0)).Return(tt.mockLogs, tt.mockTotalCount, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			page, err := client.GetActivityLogs(tt.filter)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Equal(t, tt.unavailable, errors.Is(err, ErrFeatureUnavailable))
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := make([]int, len(page.Items))
+			for i, log := range page.Items {
+				ids[i] = log.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedTotalCount, page.TotalCount)
+			assert.Equal(t, tt.expectHasMore, page.HasMore)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetActivityLogsFilterPaging(t *testing.T) {
+	// The first page only holds entries of other users, the matching entries are on the second page
+	firstPage := make([]*apimodels.PortainereeUserActivityLog, activityLogPageSize)
+	for i := range firstPage {
+		firstPage[i] = &apimodels.PortainereeUserActivityLog{ID: int64(1000 - i), Username: "alice", Action: "GET /stacks"}
+	}
+	secondPage := []*apimodels.PortainereeUserActivityLog{
+		{ID: 5, Username: "bob", Action: "POST /stacks"},
+		{ID: 4, Username: "alice", Action: "GET /stacks"},
+		{ID: 3, Username: "bob", Action: "DELETE /stacks/1"},
+		{ID: 2, Username: "bob", Action: "POST /endpoints"},
+	}
+	totalCount := int64(activityLogPageSize + len(secondPage))
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("PageUserActivityLogs", int64(0), int64(0), int64(activityLogPageSize), int64(0)).Return(firstPage, totalCount, nil)
+	mockAPI.On("PageUserActivityLogs", int64(0), int64(0), int64(activityLogPageSize), int64(activityLogPageSize)).Return(secondPage, totalCount, nil)
+
+	client := &PortainerClient{cli: mockAPI}
+
+	page, err := client.GetActivityLogs(models.ActivityLogFilter{Username: "bob", Limit: 2})
+
+	assert.NoError(t, err)
+	ids := make([]int, len(page.Items))
+	for i, log := range page.Items {
+		ids[i] = log.ID
+	}
+	assert.Equal(t, []int{5, 3}, ids)
+	assert.Equal(t, 3, page.TotalCount)
+	assert.True(t, page.HasMore)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestGetActivityLogsFilterStopsAfterLimit(t *testing.T) {
+	firstPage := make([]*apimodels.PortainereeUserActivityLog, activityLogPageSize)
+	for i := range firstPage {
+		firstPage[i] = &apimodels.PortainereeUserActivityLog{ID: int64(1000 - i), Username: "bob", Action: "GET /stacks"}
+	}
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("PageUserActivityLogs", int64(0), int64(0), int64(activityLogPageSize), int64(0)).Return(firstPage, int64(10*activityLogPageSize), nil)
+
+	client := &PortainerClient{cli: mockAPI}
+
+	page, err := client.GetActivityLogs(models.ActivityLogFilter{Username: "bob", Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, activityLogPageSize, page.TotalCount, "the total count only counts the entries scanned")
+	assert.True(t, page.HasMore)
+	mockAPI.AssertNumberOfCalls(t, "PageUserActivityLogs", 1)
+}
+
+func TestGetActivityLogsFilterPageCap(t *testing.T) {
+	otherUserPage := make([]*apimodels.PortainereeUserActivityLog, activityLogPageSize)
+	for i := range otherUserPage {
+		otherUserPage[i] = &apimodels.PortainereeUserActivityLog{ID: int64(i + 1), Username: "alice", Action: "GET /stacks"}
+	}
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("PageUserActivityLogs", int64(0), int64(0), int64(activityLogPageSize), mock.Anything).Return(otherUserPage, int64(100*activityLogPageSize), nil)
+
+	client := &PortainerClient{cli: mockAPI}
+
+	page, err := client.GetActivityLogs(models.ActivityLogFilter{Username: "bob", Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.Equal(t, 0, page.TotalCount)
+	assert.True(t, page.HasMore, "older entries were not scanned")
+	mockAPI.AssertNumberOfCalls(t, "PageUserActivityLogs", maxActivityLogPages)
+}
@@ -1,13 +1,18 @@
 package client
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/portainer/client-api-go/v2/client"
 	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/rawclient"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// PortainerAPIClient defines the interface for the underlying Portainer API client
+// PortainerAPIClient defines the interface for the underlying Portainer API client.
+// It is implemented by the raw client, which extends the client-api-go SDK client.
 type PortainerAPIClient interface {
 	ListEdgeGroups() ([]*apimodels.EdgegroupsDecoratedEdgeGroup, error)
 	CreateEdgeGroup(name string, environmentIds []int64) (int64, error)
@@ -15,7 +20,11 @@ type PortainerAPIClient interface {
 	ListEdgeStacks() ([]*apimodels.PortainereeEdgeStack, error)
 	CreateEdgeStack(name string, file string, environmentGroupIds []int64) (int64, error)
 	UpdateEdgeStack(id int64, file string, environmentGroupIds []int64) error
+	DeleteEdgeStack(id int64) error
 	GetEdgeStackFile(id int64) (string, error)
+	GetEdgeStack(id int64) (*apimodels.PortainereeEdgeStack, error)
+	RedeployEdgeStackFromGit(stack *apimodels.PortainereeEdgeStack, rePullImage bool) error
+	UpdateEdgeStackAutoUpdate(stack *apimodels.PortainereeEdgeStack, autoUpdate *apimodels.PortainerAutoUpdateSettings) error
 	ListEndpointGroups() ([]*apimodels.PortainerEndpointGroup, error)
 	CreateEndpointGroup(name string, associatedEndpoints []int64) (int64, error)
 	UpdateEndpointGroup(id int64, name *string, userAccesses *map[int64]string, teamAccesses *map[int64]string) error
@@ -25,6 +34,12 @@ type PortainerAPIClient interface {
 	GetEndpoint(id int64) (*apimodels.PortainereeEndpoint, error)
 	UpdateEndpoint(id int64, tagIds *[]int64, userAccesses *map[int64]string, teamAccesses *map[int64]string) error
 	GetSettings() (*apimodels.PortainereeSettings, error)
+	GetPublicSettings() (*apimodels.SettingsPublicSettingsResponse, error)
+	UpdateAuthSettings(authenticationMethod int64, oauthSettings *apimodels.PortainereeOAuthSettings) error
+	UpdateBrandingSettings(logoURL, loginBanner string) error
+	UpdateSessionTimeout(sessionTimeout string) error
+	GetSSLSettings() (*apimodels.PortainereeSSLSettings, error)
+	UpdateSSLSettings(cert, key string, httpEnabled bool) error
 	ListTags() ([]*apimodels.PortainerTag, error)
 	CreateTag(name string) (int64, error)
 	ListTeams() ([]*apimodels.PortainerTeam, error)
@@ -34,16 +49,60 @@ type PortainerAPIClient interface {
 	DeleteTeamMembership(id int) error
 	CreateTeamMembership(teamId int, userId int) error
 	ListUsers() ([]*apimodels.PortainereeUser, error)
+	GetCurrentUser() (*apimodels.PortainereeUser, error)
 	UpdateUserRole(id int, role int64) error
+	GetUser(id int) (*apimodels.PortainereeUser, error)
+	UpdateUserPreferences(id int, themeColor string, subtleUpgradeButton, useCache bool) error
 	GetVersion() (string, error)
 	ProxyDockerRequest(environmentId int, opts client.ProxyRequestOptions) (*http.Response, error)
 	ProxyKubernetesRequest(environmentId int, opts client.ProxyRequestOptions) (*http.Response, error)
+	ListUserActivityLogs(after, before, limit int64) ([]*apimodels.PortainereeUserActivityLog, error)
+	PageUserActivityLogs(after, before, limit, offset int64) ([]*apimodels.PortainereeUserActivityLog, int64, error)
+	UpdateEndpointGPUs(id int64, gpus []*apimodels.PortainerPair) error
+	UpdateEndpointPublicURL(id int64, publicURL string) error
+	UpdateResourceControl(id int64, public, administratorsOnly bool, users, teams []int64) (*apimodels.PortainerResourceControl, error)
+	ListEdgeConfigs() ([]*apimodels.PortainereeEdgeConfig, error)
+	CreateEdgeConfig(name, configType, category, baseDir string, edgeGroupIDs []int64, archive []byte) error
+	DeleteEdgeConfig(id int64) error
+	ListCustomTemplates() ([]*apimodels.PortainereeCustomTemplate, error)
+	GetCustomTemplate(id int64) (*apimodels.PortainereeCustomTemplate, error)
+	GetCustomTemplateFile(id int64) (string, error)
+	CreateCustomTemplateFromFile(payload *apimodels.CustomtemplatesCustomTemplateFromFileContentPayload) (int64, error)
+	CreateCustomTemplateFromGit(payload *apimodels.CustomtemplatesCustomTemplateFromGitRepositoryPayload) (int64, error)
+	DeleteCustomTemplate(id int64) error
+	ListEdgeJobs() ([]*apimodels.PortainerEdgeJob, error)
+	CreateEdgeJob(payload *apimodels.EdgejobsEdgeJobCreateFromFileContentPayload) (int64, error)
+	DeleteEdgeJob(id int64) error
+	ListEdgeJobTasks(id int64) ([]*apimodels.EdgejobsTaskContainer, error)
+	GetEdgeJobTaskLogs(id, taskId int64) (string, error)
+	GetLicenseInfo() (*apimodels.LicensesLicenseInfo, error)
+	GetNodesCount() (int64, error)
+	SnapshotEndpoint(id int64) error
+	GetKubeconfig(environmentId int64) (string, error)
+	ListHelmReleases(environmentId int64, namespace string) ([]*apimodels.ReleaseReleaseElement, error)
+	InstallHelmChart(environmentId int64, repo, chart, version, namespace, name, values string) error
+	CreateTeamMembershipWithRole(teamId, userId int, role int64) error
+	UpdateTeamMembershipRole(id, teamId, userId int, role int64) error
+	ListRegistries() ([]*apimodels.PortainereeRegistry, error)
+	UpdateEndpointRegistryAccess(endpointId, registryId int64, userIds, teamIds []int64) error
+	GetGitRepositoryFile(payload *apimodels.GitopsRepositoryFilePreviewPayload) (string, error)
 }
 
 // PortainerClient is a wrapper around the Portainer SDK client
 // that provides simplified access to Portainer API functionality.
 type PortainerClient struct {
 	cli PortainerAPIClient
+	// stackURLHosts restricts the hosts stack files can be fetched from, any host with a public address is
+	// allowed when empty. The listed hosts can also be reached on internal addresses.
+	stackURLHosts []string
+	// edgeAgentOfflineThreshold is how long an edge agent can go without checking in before it is
+	// considered offline, it is derived from the check-in interval of each environment when zero
+	edgeAgentOfflineThreshold time.Duration
+	// maxDockerAPIVersion is the highest Docker API version the Docker proxy requests are sent with,
+	// the requests are sent unversioned when it is empty
+	maxDockerAPIVersion string
+	// dockerAPIVersions holds the Docker API version negotiated with the daemon of each environment
+	dockerAPIVersions *dockerAPIVersionCache
 }
 
 // ClientOption defines a function that configures a PortainerClient.
@@ -51,7 +110,11 @@ type ClientOption func(*clientOptions)
 
 // clientOptions holds configuration options for the PortainerClient.
 type clientOptions struct {
-	skipTLSVerify bool
+	skipTLSVerify             bool
+	rawOptions                []rawclient.ClientOption
+	stackURLHosts             []string
+	edgeAgentOfflineThreshold time.Duration
+	maxDockerAPIVersion       string
 }
 
 // WithSkipTLSVerify configures whether to skip TLS certificate verification.
@@ -62,11 +125,58 @@ func WithSkipTLSVerify(skip bool) ClientOption {
 	}
 }
 
+// WithTransportConfig tunes the connection pool of the HTTP transport used to talk to the Portainer server.
+// Raising the number of idle connections kept per host lets concurrent requests reuse connections
+// instead of opening new ones, which avoids exhausting sockets in TIME_WAIT under heavy load.
+// Values lower than or equal to zero keep the defaults.
+func WithTransportConfig(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.rawOptions = append(o.rawOptions, rawclient.WithTransportConfig(maxIdleConns, maxIdleConnsPerHost, idleTimeout))
+	}
+}
+
+// WithTracerProvider traces the requests sent to the Portainer server with the given tracer provider.
+// See rawclient.WithTracerProvider for the recorded spans.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(o *clientOptions) {
+		o.rawOptions = append(o.rawOptions, rawclient.WithTracerProvider(tp))
+	}
+}
+
+// WithAllowedStackURLHosts restricts the hosts CreateStackFromURL can fetch stack files from.
+// Hosts are compared case-insensitively without their port. Any host is allowed when no host is set, as
+// long as it resolves to a public address: the allowed hosts are the only ones that can be reached on
+// loopback, link-local, private or unspecified addresses.
+func WithAllowedStackURLHosts(hosts []string) ClientOption {
+	return func(o *clientOptions) {
+		o.stackURLHosts = append(o.stackURLHosts, hosts...)
+	}
+}
+
+// WithEdgeAgentOfflineThreshold sets how long an edge agent can go without checking in before
+// GetEdgeAgentStatus reports it offline. Values lower than or equal to zero keep the Portainer rule:
+// twice the check-in interval of the environment plus 20 seconds.
+func WithEdgeAgentOfflineThreshold(threshold time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.edgeAgentOfflineThreshold = threshold
+	}
+}
+
+// WithDockerAPIVersion sends the Docker proxy requests with a versioned path, e.g. /v1.41/containers/json,
+// instead of letting each Docker daemon use its own API version. The version is negotiated once per
+// environment: the given version is used unless the daemon of the environment is older, in which case
+// the version of the daemon is used. An empty version keeps the unversioned paths.
+func WithDockerAPIVersion(version string) ClientOption {
+	return func(o *clientOptions) {
+		o.maxDockerAPIVersion = version
+	}
+}
+
 // NewPortainerClient creates a new PortainerClient instance with the provided
 // server URL and authentication token.
 //
 // Parameters:
-//   - serverURL: The base URL of the Portainer server
+//   - serverURL: The address of the Portainer server, or "unix:///path/to/socket" to connect to a Unix socket
 //   - token: The authentication token for API access
 //   - opts: Optional configuration options for the client
 //
@@ -82,6 +192,26 @@ func NewPortainerClient(serverURL string, token string, opts ...ClientOption) *P
 	}
 
 	return &PortainerClient{
-		cli: client.NewPortainerClient(serverURL, token, client.WithSkipTLSVerify(options.skipTLSVerify)),
+		cli:                       rawclient.NewPortainerClient(serverURL, token, append(options.rawOptions, rawclient.WithSkipTLSVerify(options.skipTLSVerify))...),
+		stackURLHosts:             options.stackURLHosts,
+		edgeAgentOfflineThreshold: options.edgeAgentOfflineThreshold,
+		maxDockerAPIVersion:       options.maxDockerAPIVersion,
+		dockerAPIVersions:         newDockerAPIVersionCache(),
+	}
+}
+
+// contextAPIClient is implemented by the API clients that can send their requests with a context
+type contextAPIClient interface {
+	WithContext(ctx context.Context) *rawclient.PortainerClient
+}
+
+// WithContext returns a copy of the client sending the requests of its operations with the given context,
+// e.g. the context of an MCP tool call, so that they are cancelled with it and traced as children of its span.
+// The copy shares the configuration and the caches of the client.
+func (c *PortainerClient) WithContext(ctx context.Context) *PortainerClient {
+	clone := *c
+	if cli, ok := c.cli.(contextAPIClient); ok {
+		clone.cli = cli.WithContext(ctx)
 	}
+	return &clone
 }
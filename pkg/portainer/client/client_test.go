@@ -2,6 +2,7 @@ package client
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -70,3 +71,9 @@ func TestWithSkipTLSVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestWithEdgeAgentOfflineThreshold(t *testing.T) {
+	c := NewPortainerClient("https://portainer.example.com", "test-token", WithEdgeAgentOfflineThreshold(2*time.Minute))
+
+	assert.Equal(t, 2*time.Minute, c.edgeAgentOfflineThreshold)
+}
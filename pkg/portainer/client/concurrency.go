@@ -0,0 +1,33 @@
+package client
+
+import "sync"
+
+// Bounds on the number of requests sent in parallel by the operations covering all the environments or
+// all the stacks, so that a large fleet does not flood the Portainer server.
+const (
+	// maxConcurrentEnvironmentRequests bounds the number of environments inspected in parallel
+	maxConcurrentEnvironmentRequests = 5
+	// maxConcurrentStackFileRequests bounds the number of stack files fetched in parallel
+	maxConcurrentStackFileRequests = 5
+)
+
+// forEachConcurrently calls fn with every index from 0 to n-1, at most limit calls at a time, and returns
+// once all the calls are done. The semaphore is taken before starting a goroutine, so that no more than
+// limit goroutines are running whatever the number of items.
+func forEachConcurrently(n, limit int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+
+	for i := range n {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fn(i)
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachConcurrently(t *testing.T) {
+	const n, limit = 20, 3
+
+	var running, maxRunning atomic.Int32
+	var mu sync.Mutex
+	called := make([]int, 0, n)
+
+	forEachConcurrently(n, limit, func(i int) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		called = append(called, i)
+		mu.Unlock()
+	})
+
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, called)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(limit))
+	assert.Equal(t, int32(0), running.Load(), "all the calls should be done on return")
+}
+
+func TestForEachConcurrentlyNoItems(t *testing.T) {
+	forEachConcurrently(0, 5, func(int) {
+		t.Fatal("fn should not be called without items")
+	})
+}
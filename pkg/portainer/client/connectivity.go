@@ -0,0 +1,228 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// maxConnectivityErrorSize bounds the part of an error response kept to describe a connectivity failure
+const maxConnectivityErrorSize = 4 << 10
+
+// tlsErrorMessages are the messages of the TLS handshake and certificate verification failures
+var tlsErrorMessages = []string{
+	"x509:",
+	"tls:",
+	"certificate",
+}
+
+// unreachableErrorMessages are the messages Portainer reports when it cannot reach an environment
+var unreachableErrorMessages = []string{
+	"connection refused",
+	"no such host",
+	"no route to host",
+	"i/o timeout",
+	"unable to find an agent",
+	"tunnel",
+}
+
+// connectivityHints suggest what to check for each type of connectivity failure
+var connectivityHints = map[string]string{
+	models.ConnectivityFailureUnreachable:    "Portainer could not reach the environment, check that the Docker daemon, the agent or the cluster is running and that the network path from Portainer is open. Edge environments are only reachable while their agent is connected.",
+	models.ConnectivityFailureAuthentication: "The credentials were rejected, check that the Portainer API token is valid and has access to the environment, and the credentials Portainer uses for the environment.",
+	models.ConnectivityFailureTLS:            "The TLS connection failed, check the certificates configured for the environment in Portainer and that they are not expired.",
+	models.ConnectivityFailureUnexpected:     "The environment answered with an unexpected error, check the error and the Portainer logs.",
+}
+
+// TestEnvironmentConnectivity sends a cheap request to an environment through the Portainer proxy,
+// /_ping for the Docker environments and /healthz for the Kubernetes environments, and reports whether
+// it succeeded, its latency and the version of the Docker daemon or of Kubernetes. A failure of the
+// request is reported in the result, classified as unreachable, authentication, TLS or unexpected.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - The ConnectivityResult of the environment
+//   - ErrFeatureUnavailable if the environment is neither a Docker nor a Kubernetes environment
+//   - An error if the environment could not be retrieved
+func (c *PortainerClient) TestEnvironmentConnectivity(id int) (models.ConnectivityResult, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return models.ConnectivityResult{}, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	return c.testEndpointConnectivity(endpoint)
+}
+
+// CheckFleetConnectivity tests the connectivity of every Docker and Kubernetes environment, the same
+// way as TestEnvironmentConnectivity. The environments are tested concurrently, at most
+// maxConcurrentEnvironmentRequests at a time, and the other environments are skipped. A failure to
+// reach an environment is reported in its result and does not fail the check.
+//
+// Returns:
+//   - A slice of ConnectivityResult objects, in the order of the environment listing
+//   - An error if the environments cannot be listed
+func (c *PortainerClient) CheckFleetConnectivity() ([]models.ConnectivityResult, error) {
+	endpoints, err := c.cli.ListEndpoints()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	tested := make([]*apimodels.PortainereeEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		environmentType := models.ConvertEndpointToEnvironment(endpoint).Type
+		if models.IsDockerEnvironment(environmentType) || models.IsKubernetesEnvironment(environmentType) {
+			tested = append(tested, endpoint)
+		}
+	}
+
+	results := make([]models.ConnectivityResult, len(tested))
+
+	forEachConcurrently(len(tested), maxConcurrentEnvironmentRequests, func(i int) {
+		// The environment type was checked above, testing the endpoint cannot fail
+		results[i], _ = c.testEndpointConnectivity(tested[i])
+	})
+
+	return results, nil
+}
+
+// testEndpointConnectivity sends the connectivity test request of TestEnvironmentConnectivity to an endpoint
+func (c *PortainerClient) testEndpointConnectivity(endpoint *apimodels.PortainereeEndpoint) (models.ConnectivityResult, error) {
+	id := int(endpoint.ID)
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	result := models.ConnectivityResult{
+		EnvironmentID:   id,
+		EnvironmentName: environment.Name,
+		Type:            environment.Type,
+	}
+
+	var proxy func(environmentId int, opts client.ProxyRequestOptions) (*http.Response, error)
+	switch {
+	case models.IsDockerEnvironment(environment.Type):
+		result.Path, proxy = "/_ping", c.cli.ProxyDockerRequest
+	case models.IsKubernetesEnvironment(environment.Type):
+		result.Path, proxy = "/healthz", c.cli.ProxyKubernetesRequest
+	default:
+		return models.ConnectivityResult{}, fmt.Errorf("environment %d is a %s environment, connectivity can only be tested on Docker and Kubernetes environments: %w", id, environment.Type, ErrFeatureUnavailable)
+	}
+
+	start := time.Now()
+	resp, err := proxy(id, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: result.Path})
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Failure = classifyConnectivityError(0, err.Error())
+		if result.Failure == models.ConnectivityFailureUnexpected && isNetworkError(err) {
+			result.Failure = models.ConnectivityFailureUnreachable
+		}
+		result.Error = err.Error()
+		result.Hint = connectivityHints[result.Failure]
+		return result, nil
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxConnectivityErrorSize))
+		message := strings.TrimSpace(string(body))
+		if message == "" {
+			message = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		}
+
+		result.Failure = classifyConnectivityError(resp.StatusCode, message)
+		result.Error = message
+		result.Hint = connectivityHints[result.Failure]
+		return result, nil
+	}
+
+	result.Success = true
+	if models.IsDockerEnvironment(environment.Type) {
+		result.DaemonVersion, result.APIVersion = c.getDockerDaemonVersion(id)
+	} else {
+		result.DaemonVersion = c.getKubernetesVersion(id)
+	}
+
+	return result, nil
+}
+
+// classifyConnectivityError classifies the failure of a connectivity test from the status code of the
+// response, zero when no response was received, and the error message
+func classifyConnectivityError(statusCode int, message string) string {
+	lower := strings.ToLower(message)
+	for _, tlsMessage := range tlsErrorMessages {
+		if strings.Contains(lower, tlsMessage) {
+			return models.ConnectivityFailureTLS
+		}
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return models.ConnectivityFailureAuthentication
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return models.ConnectivityFailureUnreachable
+	}
+
+	for _, unreachableMessage := range unreachableErrorMessages {
+		if strings.Contains(lower, unreachableMessage) {
+			return models.ConnectivityFailureUnreachable
+		}
+	}
+
+	return models.ConnectivityFailureUnexpected
+}
+
+// isNetworkError reports whether err was caused by a failure of the network, such as a timeout
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// getDockerDaemonVersion reads the version and the API version of the Docker daemon of an environment,
+// empty when they cannot be read
+func (c *PortainerClient) getDockerDaemonVersion(environmentId int) (string, string) {
+	var version struct {
+		Version    string `json:"Version"`
+		APIVersion string `json:"ApiVersion"`
+	}
+	if err := getProxiedJSON(c.cli.ProxyDockerRequest, environmentId, "/version", &version); err != nil {
+		return "", ""
+	}
+
+	return version.Version, version.APIVersion
+}
+
+// getKubernetesVersion reads the version of the Kubernetes API of an environment, empty when it cannot be read
+func (c *PortainerClient) getKubernetesVersion(environmentId int) string {
+	var version struct {
+		GitVersion string `json:"gitVersion"`
+	}
+	if err := getProxiedJSON(c.cli.ProxyKubernetesRequest, environmentId, "/version", &version); err != nil {
+		return ""
+	}
+
+	return version.GitVersion
+}
+
+// getProxiedJSON decodes the JSON response of a GET request sent through a Portainer proxy
+func getProxiedJSON(proxy func(int, client.ProxyRequestOptions) (*http.Response, error), environmentId int, path string, v any) error {
+	resp, err := proxy(environmentId, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: path})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
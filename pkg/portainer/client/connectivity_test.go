@@ -0,0 +1,225 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newConnectivityResponse(statusCode int, body string) *http.Response {
+	return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestTestEnvironmentConnectivity(t *testing.T) {
+	pingOpts := client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/_ping"}
+	healthzOpts := client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/healthz"}
+	versionOpts := client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/version"}
+
+	tests := []struct {
+		name           string
+		endpointType   int64
+		setupMock      func(mockAPI *MockPortainerAPI)
+		expectedResult models.ConnectivityResult
+	}{
+		{
+			name:         "docker environment reachable",
+			endpointType: 1,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("ProxyDockerRequest", 1, pingOpts).Return(newConnectivityResponse(http.StatusOK, "OK"), nil)
+				mockAPI.On("ProxyDockerRequest", 1, versionOpts).Return(newConnectivityResponse(http.StatusOK, `{"Version":"24.0.7","ApiVersion":"1.43"}`), nil)
+			},
+			expectedResult: models.ConnectivityResult{
+				Type:          models.EnvironmentTypeDockerLocal,
+				Path:          "/_ping",
+				Success:       true,
+				StatusCode:    http.StatusOK,
+				DaemonVersion: "24.0.7",
+				APIVersion:    "1.43",
+			},
+		},
+		{
+			name:         "docker version not readable",
+			endpointType: 1,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("ProxyDockerRequest", 1, pingOpts).Return(newConnectivityResponse(http.StatusOK, "OK"), nil)
+				mockAPI.On("ProxyDockerRequest", 1, versionOpts).Return(newConnectivityResponse(http.StatusInternalServerError, ""), nil)
+			},
+			expectedResult: models.ConnectivityResult{
+				Type:       models.EnvironmentTypeDockerLocal,
+				Path:       "/_ping",
+				Success:    true,
+				StatusCode: http.StatusOK,
+			},
+		},
+		{
+			name:         "kubernetes environment reachable",
+			endpointType: 5,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("ProxyKubernetesRequest", 1, healthzOpts).Return(newConnectivityResponse(http.StatusOK, "ok"), nil)
+				mockAPI.On("ProxyKubernetesRequest", 1, versionOpts).Return(newConnectivityResponse(http.StatusOK, `{"gitVersion":"v1.29.2"}`), nil)
+			},
+			expectedResult: models.ConnectivityResult{
+				Type:          models.EnvironmentTypeKubernetesLocal,
+				Path:          "/healthz",
+				Success:       true,
+				StatusCode:    http.StatusOK,
+				DaemonVersion: "v1.29.2",
+			},
+		},
+		{
+			name:         "environment unreachable",
+			endpointType: 2,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("ProxyDockerRequest", 1, pingOpts).Return(newConnectivityResponse(http.StatusBadGateway, ""), nil)
+			},
+			expectedResult: models.ConnectivityResult{
+				Type:       models.EnvironmentTypeDockerAgent,
+				Path:       "/_ping",
+				StatusCode: http.StatusBadGateway,
+				Failure:    models.ConnectivityFailureUnreachable,
+				Error:      "unexpected status 502",
+				Hint:       connectivityHints[models.ConnectivityFailureUnreachable],
+			},
+		},
+		{
+			name:         "access denied",
+			endpointType: 1,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("ProxyDockerRequest", 1, pingOpts).Return(newConnectivityResponse(http.StatusForbidden, `{"message":"Access denied to resource"}`), nil)
+			},
+			expectedResult: models.ConnectivityResult{
+				Type:       models.EnvironmentTypeDockerLocal,
+				Path:       "/_ping",
+				StatusCode: http.StatusForbidden,
+				Failure:    models.ConnectivityFailureAuthentication,
+				Error:      `{"message":"Access denied to resource"}`,
+				Hint:       connectivityHints[models.ConnectivityFailureAuthentication],
+			},
+		},
+		{
+			name:         "certificate error",
+			endpointType: 2,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("ProxyDockerRequest", 1, pingOpts).Return(newConnectivityResponse(http.StatusBadGateway, "x509: certificate has expired or is not yet valid"), nil)
+			},
+			expectedResult: models.ConnectivityResult{
+				Type:       models.EnvironmentTypeDockerAgent,
+				Path:       "/_ping",
+				StatusCode: http.StatusBadGateway,
+				Failure:    models.ConnectivityFailureTLS,
+				Error:      "x509: certificate has expired or is not yet valid",
+				Hint:       connectivityHints[models.ConnectivityFailureTLS],
+			},
+		},
+		{
+			name:         "portainer not reachable",
+			endpointType: 1,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("ProxyDockerRequest", 1, pingOpts).Return(nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: network is unreachable")})
+			},
+			expectedResult: models.ConnectivityResult{
+				Type:    models.EnvironmentTypeDockerLocal,
+				Path:    "/_ping",
+				Failure: models.ConnectivityFailureUnreachable,
+				Error:   "dial tcp: connect: network is unreachable",
+				Hint:    connectivityHints[models.ConnectivityFailureUnreachable],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Name: "production", Type: tt.endpointType}, nil)
+			tt.setupMock(mockAPI)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			result, err := client.TestEnvironmentConnectivity(1)
+
+			require.NoError(t, err)
+			result.LatencyMs = 0
+			tt.expectedResult.EnvironmentID = 1
+			tt.expectedResult.EnvironmentName = "production"
+			assert.Equal(t, tt.expectedResult, result)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unsupported environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 3}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.TestEnvironmentConnectivity(1)
+
+		assert.ErrorIs(t, err, ErrFeatureUnavailable)
+	})
+
+	t.Run("get endpoint error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(nil, errors.New("not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.TestEnvironmentConnectivity(1)
+
+		assert.ErrorContains(t, err, "failed to get endpoint")
+	})
+}
+
+func TestCheckFleetConnectivity(t *testing.T) {
+	t.Run("docker and kubernetes environments", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return([]*apimodels.PortainereeEndpoint{
+			{ID: 1, Name: "docker", Type: 1},
+			{ID: 2, Name: "azure", Type: 3},
+			{ID: 3, Name: "kubernetes", Type: 5},
+			{ID: 4, Name: "offline", Type: 2},
+		}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Return(newConnectivityResponse(http.StatusOK, "OK"), nil)
+		mockAPI.On("ProxyKubernetesRequest", 3, mock.Anything).Return(newConnectivityResponse(http.StatusOK, "ok"), nil)
+		mockAPI.On("ProxyDockerRequest", 4, mock.Anything).Return(nil, errors.New("dial tcp 10.0.0.4:9001: connect: connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		results, err := client.CheckFleetConnectivity()
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		for i := range results {
+			results[i].LatencyMs = 0
+		}
+		assert.Equal(t, []int{1, 3, 4}, []int{results[0].EnvironmentID, results[1].EnvironmentID, results[2].EnvironmentID})
+		assert.True(t, results[0].Success)
+		assert.Equal(t, "/_ping", results[0].Path)
+		assert.True(t, results[1].Success)
+		assert.Equal(t, "/healthz", results[1].Path)
+		assert.False(t, results[2].Success)
+		assert.Equal(t, "offline", results[2].EnvironmentName)
+		assert.Equal(t, models.ConnectivityFailureUnreachable, results[2].Failure)
+		mockAPI.AssertNotCalled(t, "ProxyDockerRequest", 2, mock.Anything)
+	})
+
+	t.Run("list endpoints error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return(nil, errors.New("unauthorized"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.CheckFleetConnectivity()
+
+		assert.ErrorContains(t, err, "failed to list endpoints: unauthorized")
+	})
+}
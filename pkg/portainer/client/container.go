@@ -0,0 +1,351 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// dockerContainerFilters are the filters supported by the container listing of the Docker API
+var dockerContainerFilters = []string{
+	"ancestor", "before", "expose", "exited", "health", "id", "isolation", "is-task",
+	"label", "name", "network", "publish", "since", "status", "volume",
+}
+
+// ListContainers lists the containers of a Docker environment through the Docker proxy.
+// The containers are sorted by name, then by ID, so that consecutive listings can be paged consistently.
+// The filters are sent to Docker JSON-encoded in the filters query parameter, the name of the filter is
+// added to the name filter. A status filter also selects stopped containers when All is false.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - filter: The criteria used to select the containers
+//
+// Returns:
+//   - A slice of Container objects sorted by name
+//   - An error if a filter is not supported by Docker
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) ListContainers(environmentId int, filter models.ContainerFilter) ([]models.Container, error) {
+	filters := make(map[string][]string, len(filter.Filters)+1)
+	for name, values := range filter.Filters {
+		if !slices.Contains(dockerContainerFilters, name) {
+			return nil, fmt.Errorf("unsupported container filter %q, must be one of: %s", name, strings.Join(dockerContainerFilters, ", "))
+		}
+		if len(values) > 0 {
+			filters[name] = slices.Clone(values)
+		}
+	}
+	if filter.Name != "" {
+		filters["name"] = append(filters["name"], filter.Name)
+	}
+
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string]string{"all": "0"}
+	if filter.All {
+		queryParams["all"] = "1"
+	}
+
+	if len(filters) > 0 {
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode container filters: %w", err)
+		}
+		queryParams["filters"] = string(encoded)
+	}
+
+	var rawContainers []dockerContainer
+	if err := c.getDockerJSON(environmentId, "/containers/json", queryParams, &rawContainers); err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	containers := make([]models.Container, len(rawContainers))
+	for i, rawContainer := range rawContainers {
+		containers[i] = models.Container{
+			ID:      rawContainer.ID,
+			Name:    containerName(rawContainer),
+			Image:   rawContainer.Image,
+			State:   rawContainer.State,
+			Status:  rawContainer.Status,
+			Created: time.Unix(rawContainer.Created, 0).UTC().Format(time.RFC3339),
+		}
+	}
+
+	sort.Slice(containers, func(i, j int) bool {
+		if containers[i].Name != containers[j].Name {
+			return containers[i].Name < containers[j].Name
+		}
+		return containers[i].ID < containers[j].ID
+	})
+
+	return containers, nil
+}
+
+// dockerFilesystemChange is a change to the filesystem of a container as returned by the Docker API
+type dockerFilesystemChange struct {
+	Path string `json:"Path"`
+	Kind int    `json:"Kind"`
+}
+
+// GetContainerChanges retrieves the changes to the filesystem of a container compared to its image
+// through the Docker proxy, the equivalent of the `docker diff` command.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//
+// Returns:
+//   - A slice of FilesystemChange objects sorted by path
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) GetContainerChanges(environmentId int, containerId string) ([]models.FilesystemChange, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return nil, err
+	}
+
+	var rawChanges []dockerFilesystemChange
+	if err := c.getDockerJSON(environmentId, fmt.Sprintf("/containers/%s/changes", url.PathEscape(containerId)), nil, &rawChanges); err != nil {
+		return nil, fmt.Errorf("failed to get container changes: %w", err)
+	}
+
+	changes := make([]models.FilesystemChange, len(rawChanges))
+	for i, rawChange := range rawChanges {
+		changes[i] = models.FilesystemChange{
+			Path: rawChange.Path,
+			Kind: convertFilesystemChangeKind(rawChange.Kind),
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, nil
+}
+
+// convertFilesystemChangeKind converts the kind of a filesystem change reported by Docker
+func convertFilesystemChangeKind(kind int) string {
+	switch kind {
+	case 0:
+		return models.FilesystemChangeModified
+	case 1:
+		return models.FilesystemChangeAdded
+	case 2:
+		return models.FilesystemChangeDeleted
+	default:
+		return fmt.Sprintf("unknown (%d)", kind)
+	}
+}
+
+// dockerProcessList is the list of processes of a container as returned by the Docker API
+type dockerProcessList struct {
+	Titles    []string   `json:"Titles"`
+	Processes [][]string `json:"Processes"`
+}
+
+// GetContainerProcesses retrieves the processes running in a container through the Docker proxy,
+// the equivalent of the `docker top` command. Each process is returned as a row labeled with the
+// titles reported by ps.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//
+// Returns:
+//   - A ProcessList object
+//   - An error if the container is not running, if the environment has no Docker daemon
+//     or if the operation fails
+func (c *PortainerClient) GetContainerProcesses(environmentId int, containerId string) (models.ProcessList, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return models.ProcessList{}, err
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          fmt.Sprintf("/containers/%s/top", url.PathEscape(containerId)),
+	})
+	if err != nil {
+		return models.ProcessList{}, fmt.Errorf("failed to get container processes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Docker answers with a conflict when the container is not running
+	if resp.StatusCode == http.StatusConflict {
+		return models.ProcessList{}, fmt.Errorf("container %s is not running, start it to list its processes", containerId)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return models.ProcessList{}, fmt.Errorf("failed to get container processes: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var rawList dockerProcessList
+	if err := json.NewDecoder(resp.Body).Decode(&rawList); err != nil {
+		return models.ProcessList{}, fmt.Errorf("failed to decode container processes: %w", err)
+	}
+
+	list := models.ProcessList{
+		Titles:    rawList.Titles,
+		Processes: make([]map[string]string, len(rawList.Processes)),
+	}
+	if list.Titles == nil {
+		list.Titles = []string{}
+	}
+	for i, rawProcess := range rawList.Processes {
+		process := make(map[string]string, len(rawList.Titles))
+		for j, title := range rawList.Titles {
+			if j < len(rawProcess) {
+				process[title] = rawProcess[j]
+			}
+		}
+		list.Processes[i] = process
+	}
+
+	return list, nil
+}
+
+// dockerContainerHealth is the subset of the Docker container inspect response holding the healthcheck state
+type dockerContainerHealth struct {
+	State struct {
+		Health *struct {
+			Status        string `json:"Status"`
+			FailingStreak int    `json:"FailingStreak"`
+			Log           []struct {
+				Start    string `json:"Start"`
+				End      string `json:"End"`
+				ExitCode int    `json:"ExitCode"`
+				Output   string `json:"Output"`
+			} `json:"Log"`
+		} `json:"Health"`
+	} `json:"State"`
+}
+
+// GetContainerHealth retrieves the state of the healthcheck of a container through the Docker proxy,
+// the State.Health part of the `docker inspect` command. Docker keeps the last probes of the
+// healthcheck, only the most recent one is returned.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//
+// Returns:
+//   - A HealthStatus object, with the ContainerHealthNone status when the container has no healthcheck
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) GetContainerHealth(environmentId int, containerId string) (models.HealthStatus, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return models.HealthStatus{}, err
+	}
+
+	var inspect dockerContainerHealth
+	if err := c.getDockerJSON(environmentId, fmt.Sprintf("/containers/%s/json", url.PathEscape(containerId)), nil, &inspect); err != nil {
+		return models.HealthStatus{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	// Docker has no health state for the containers without a healthcheck, and reports none when it is disabled
+	health := inspect.State.Health
+	if health == nil || health.Status == models.ContainerHealthNone {
+		return models.HealthStatus{
+			Status:  models.ContainerHealthNone,
+			Message: "no healthcheck configured",
+		}, nil
+	}
+
+	status := models.HealthStatus{
+		Status:        health.Status,
+		FailingStreak: health.FailingStreak,
+	}
+	if len(health.Log) > 0 {
+		probe := health.Log[len(health.Log)-1]
+		status.LastProbe = &models.HealthProbe{
+			Start:    probe.Start,
+			End:      probe.End,
+			ExitCode: probe.ExitCode,
+			Output:   strings.TrimSpace(probe.Output),
+		}
+	}
+
+	return status, nil
+}
+
+// dockerContainerMounts is the subset of the Docker container inspect response describing its mounts
+type dockerContainerMounts struct {
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+		Driver      string `json:"Driver"`
+		Mode        string `json:"Mode"`
+		RW          bool   `json:"RW"`
+		Propagation string `json:"Propagation"`
+	} `json:"Mounts"`
+	HostConfig struct {
+		Tmpfs map[string]string `json:"Tmpfs"`
+	} `json:"HostConfig"`
+}
+
+// GetContainerMounts retrieves the bind mounts, volumes and tmpfs mounts of a container through the
+// Docker proxy, the Mounts part of the `docker inspect` command. The tmpfs mounts created with the
+// --tmpfs flag are not listed by Docker with the other mounts, they are read from the host config.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//
+// Returns:
+//   - A slice of Mount objects sorted by destination
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) GetContainerMounts(environmentId int, containerId string) ([]models.Mount, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return nil, err
+	}
+
+	var inspect dockerContainerMounts
+	if err := c.getDockerJSON(environmentId, fmt.Sprintf("/containers/%s/json", url.PathEscape(containerId)), nil, &inspect); err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	mounts := make([]models.Mount, 0, len(inspect.Mounts)+len(inspect.HostConfig.Tmpfs))
+	destinations := make(map[string]bool, len(inspect.Mounts))
+	for _, m := range inspect.Mounts {
+		mounts = append(mounts, models.Mount{
+			Type:        m.Type,
+			Source:      m.Source,
+			Destination: m.Destination,
+			Name:        m.Name,
+			Driver:      m.Driver,
+			ReadWrite:   m.RW,
+			Persistent:  m.Type == models.MountTypeBind || m.Type == models.MountTypeVolume,
+			Options:     m.Mode,
+			Propagation: m.Propagation,
+		})
+		destinations[m.Destination] = true
+	}
+
+	for destination, options := range inspect.HostConfig.Tmpfs {
+		if destinations[destination] {
+			continue
+		}
+		mounts = append(mounts, models.Mount{
+			Type:        models.MountTypeTmpfs,
+			Destination: destination,
+			ReadWrite:   !slices.Contains(strings.Split(options, ","), "ro"),
+			Options:     options,
+		})
+	}
+
+	sort.Slice(mounts, func(i, j int) bool {
+		return mounts[i].Destination < mounts[j].Destination
+	})
+
+	return mounts, nil
+}
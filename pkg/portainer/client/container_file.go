@@ -0,0 +1,154 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// maxContainerFileSize is the maximum size of a file copied from or to a container
+const maxContainerFileSize = 1 << 20
+
+// GetFileFromContainer copies a file out of a container through the Docker archive endpoint,
+// the equivalent of the `docker cp` command. Docker sends the file in a tar archive, only the
+// content of the file is returned.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//   - filePath: The absolute path of the file in the container
+//
+// Returns:
+//   - The content of the file
+//   - An error if the path is not a regular file, if the file is larger than maxContainerFileSize
+//     or if the operation fails
+func (c *PortainerClient) GetFileFromContainer(environmentId int, containerId, filePath string) ([]byte, error) {
+	if !path.IsAbs(filePath) {
+		return nil, fmt.Errorf("file path %s must be absolute", filePath)
+	}
+
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          fmt.Sprintf("/containers/%s/archive", url.PathEscape(containerId)),
+		QueryParams:   map[string]string{"path": filePath},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file from container: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get file from container: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	archive := tar.NewReader(resp.Body)
+	header, err := archive.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to get file from container: empty archive")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file archive: %w", err)
+	}
+
+	switch header.Typeflag {
+	case tar.TypeReg:
+	case tar.TypeDir:
+		return nil, fmt.Errorf("%s is a directory, only regular files can be copied", filePath)
+	case tar.TypeSymlink:
+		return nil, fmt.Errorf("%s is a symbolic link to %s, copy the target instead", filePath, header.Linkname)
+	default:
+		return nil, fmt.Errorf("%s is not a regular file, only regular files can be copied", filePath)
+	}
+
+	if header.Size > maxContainerFileSize {
+		return nil, fmt.Errorf("file %s is %d bytes, larger than the limit of %d bytes", filePath, header.Size, maxContainerFileSize)
+	}
+
+	content, err := io.ReadAll(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file archive: %w", err)
+	}
+
+	return content, nil
+}
+
+// PutFileInContainer copies a file into a container through the Docker archive endpoint,
+// the equivalent of the `docker cp` command. The file is sent in a tar archive extracted by
+// Docker in the parent directory of the file, replacing any existing file with the same name.
+// The parent directory must already exist in the container.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//   - filePath: The absolute path of the file in the container
+//   - content: The content of the file, at most maxContainerFileSize bytes
+//
+// Returns:
+//   - An error if the file is too large or if the operation fails
+func (c *PortainerClient) PutFileInContainer(environmentId int, containerId, filePath string, content []byte) error {
+	dir, name := path.Split(filePath)
+	if !path.IsAbs(filePath) || name == "" {
+		return fmt.Errorf("file path %s must be the absolute path of a file", filePath)
+	}
+
+	if len(content) > maxContainerFileSize {
+		return fmt.Errorf("file %s is %d bytes, larger than the limit of %d bytes", filePath, len(content), maxContainerFileSize)
+	}
+
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return err
+	}
+
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+	if err := writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to create file archive: %w", err)
+	}
+	if _, err := writer.Write(content); err != nil {
+		return fmt.Errorf("failed to create file archive: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to create file archive: %w", err)
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodPut,
+		Path:          fmt.Sprintf("/containers/%s/archive", url.PathEscape(containerId)),
+		QueryParams:   map[string]string{"path": path.Clean(dir)},
+		Headers:       map[string]string{"Content-Type": "application/x-tar"},
+		Body:          &archive,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put file in container: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to put file in container: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestArchive returns a tar archive holding a single entry
+func newTestArchive(t *testing.T, header *tar.Header, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	require.NoError(t, writer.WriteHeader(header))
+	if len(content) > 0 {
+		_, err := writer.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestGetFileFromContainer(t *testing.T) {
+	content := []byte("worker_processes auto;\n")
+
+	tests := []struct {
+		name          string
+		filePath      string
+		endpointType  int64
+		mockStatus    int
+		mockBody      []byte
+		expectProxy   bool
+		expected      []byte
+		expectedError string
+	}{
+		{
+			name:         "regular file",
+			filePath:     "/etc/nginx/nginx.conf",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     newTestArchive(t, &tar.Header{Typeflag: tar.TypeReg, Name: "nginx.conf", Size: int64(len(content)), Mode: 0644}, content),
+			expectProxy:  true,
+			expected:     content,
+		},
+		{
+			name:          "directory",
+			filePath:      "/etc/nginx/nginx.conf",
+			endpointType:  1,
+			mockStatus:    http.StatusOK,
+			mockBody:      newTestArchive(t, &tar.Header{Typeflag: tar.TypeDir, Name: "nginx.conf/", Mode: 0755}, nil),
+			expectProxy:   true,
+			expectedError: "is a directory",
+		},
+		{
+			name:          "symbolic link",
+			filePath:      "/etc/nginx/nginx.conf",
+			endpointType:  1,
+			mockStatus:    http.StatusOK,
+			mockBody:      newTestArchive(t, &tar.Header{Typeflag: tar.TypeSymlink, Name: "nginx.conf", Linkname: "/etc/nginx.conf"}, nil),
+			expectProxy:   true,
+			expectedError: "is a symbolic link to /etc/nginx.conf",
+		},
+		{
+			name:         "file too large",
+			filePath:     "/etc/nginx/nginx.conf",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody: newTestArchive(t, &tar.Header{Typeflag: tar.TypeReg, Name: "nginx.conf", Size: maxContainerFileSize + 1, Mode: 0644},
+				bytes.Repeat([]byte("a"), maxContainerFileSize+1)),
+			expectProxy:   true,
+			expectedError: "larger than the limit",
+		},
+		{
+			name:          "file not found",
+			filePath:      "/etc/nginx/nginx.conf",
+			endpointType:  1,
+			mockStatus:    http.StatusNotFound,
+			mockBody:      []byte(`{"message":"Could not find the file /etc/nginx/nginx.conf in container web"}`),
+			expectProxy:   true,
+			expectedError: "unexpected status 404",
+		},
+		{
+			name:          "relative path",
+			filePath:      "nginx.conf",
+			expectedError: "must be absolute",
+		},
+		{
+			name:          "kubernetes environment",
+			filePath:      "/etc/nginx/nginx.conf",
+			endpointType:  5,
+			expectedError: "it has no Docker daemon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectProxy {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/web/archive" && opts.QueryParams["path"] == tt.filePath
+				})).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(bytes.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			data, err := client.GetFileFromContainer(1, "web", tt.filePath)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, data)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPutFileInContainer(t *testing.T) {
+	content := []byte("worker_processes 4;\n")
+
+	t.Run("successful copy", func(t *testing.T) {
+		var sent client.ProxyRequestOptions
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(client.ProxyRequestOptions)
+		}).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.PutFileInContainer(1, "web", "/etc/nginx/nginx.conf", content)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, sent.Method)
+		assert.Equal(t, "/containers/web/archive", sent.APIPath)
+		assert.Equal(t, map[string]string{"path": "/etc/nginx"}, sent.QueryParams)
+		assert.Equal(t, "application/x-tar", sent.Headers["Content-Type"])
+
+		archive := tar.NewReader(sent.Body)
+		header, err := archive.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "nginx.conf", header.Name)
+		assert.Equal(t, int64(len(content)), header.Size)
+		data, err := io.ReadAll(archive)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+		_, err = archive.Next()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("directory not found", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"message":"Could not find the file /etc/nginx in container web"}`)),
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.PutFileInContainer(1, "web", "/etc/nginx/nginx.conf", content)
+
+		assert.ErrorContains(t, err, "unexpected status 404")
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		client := &PortainerClient{cli: new(MockPortainerAPI)}
+
+		assert.ErrorContains(t, client.PutFileInContainer(1, "web", "nginx.conf", content), "must be the absolute path of a file")
+		assert.ErrorContains(t, client.PutFileInContainer(1, "web", "/etc/nginx/", content), "must be the absolute path of a file")
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		client := &PortainerClient{cli: new(MockPortainerAPI)}
+
+		err := client.PutFileInContainer(1, "web", "/etc/nginx/nginx.conf", bytes.Repeat([]byte("a"), maxContainerFileSize+1))
+
+		assert.ErrorContains(t, err, "larger than the limit")
+	})
+}
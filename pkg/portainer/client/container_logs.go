@@ -0,0 +1,264 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// maxContainerLogLineSize is the size after which a log line without a line break is sent as is
+const maxContainerLogLineSize = 64 << 10
+
+// dockerContainerConfig is the subset of the Docker container inspect response used to read logs
+type dockerContainerConfig struct {
+	Config struct {
+		Tty bool `json:"Tty"`
+	} `json:"Config"`
+}
+
+// GetContainerLogs retrieves the latest log lines of a container through the Docker proxy,
+// the equivalent of the `docker logs --tail` command.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//   - tail: The number of lines to retrieve from the end of the logs
+//
+// Returns:
+//   - The log lines, the oldest first
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) GetContainerLogs(environmentId int, containerId string, tail int) ([]models.ContainerLogLine, error) {
+	body, tty, err := c.openContainerLogs(environmentId, containerId, tail, false)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	lines := []models.ContainerLogLine{}
+	err = readContainerLogs(body, tty, func(line models.ContainerLogLine) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read container logs: %w", err)
+	}
+
+	return lines, nil
+}
+
+// FollowContainerLogs follows the logs of a container through the Docker proxy, the equivalent of
+// the `docker logs --follow` command. Each line is passed to handle as soon as Docker sends it, until
+// the container stops, handle returns an error or the context is cancelled. The connection to
+// Portainer is closed as soon as the context is cancelled.
+//
+// Parameters:
+//   - ctx: The context of the stream, cancelling it stops the stream
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//   - tail: The number of existing lines to send before the new ones
+//   - handle: The function called with each log line
+//
+// Returns:
+//   - nil when the container stops and its logs end
+//   - The context error, wrapped, if the stream is stopped by the context
+//   - An error if the environment has no Docker daemon, if handle fails or if the operation fails
+func (c *PortainerClient) FollowContainerLogs(ctx context.Context, environmentId int, containerId string, tail int, handle func(models.ContainerLogLine) error) error {
+	body, tty, err := c.openContainerLogs(environmentId, containerId, tail, true)
+	if err != nil {
+		return err
+	}
+
+	// The Docker proxy request is sent with the context of the client, cancelled with ctx when the client
+	// was bound to it with WithContext. Closing the body is a backstop stopping the stream once ctx is done
+	// when the client is bound to another context, or to none.
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+		}
+		body.Close()
+	}()
+
+	err = readContainerLogs(body, tty, handle)
+	if ctx.Err() != nil {
+		return fmt.Errorf("stopped following the logs of container %s: %w", containerId, ctx.Err())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read container logs: %w", err)
+	}
+
+	return nil
+}
+
+// openContainerLogs checks that an environment is a Docker environment and opens the log stream of
+// one of its containers. It also reports whether the container has a TTY, the logs of these
+// containers are sent as a raw stream instead of being multiplexed.
+func (c *PortainerClient) openContainerLogs(environmentId int, containerId string, tail int, follow bool) (io.ReadCloser, bool, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return nil, false, err
+	}
+
+	containerPath := fmt.Sprintf("/containers/%s", url.PathEscape(containerId))
+
+	var config dockerContainerConfig
+	if err := c.getDockerJSON(environmentId, containerPath+"/json", nil, &config); err != nil {
+		return nil, false, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          containerPath + "/logs",
+		QueryParams: map[string]string{
+			"stdout":     "1",
+			"stderr":     "1",
+			"timestamps": "1",
+			"follow":     strconv.FormatBool(follow),
+			"tail":       strconv.Itoa(tail),
+		},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get container logs: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, false, fmt.Errorf("failed to get container logs: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp.Body, config.Config.Tty, nil
+}
+
+// readContainerLogs reads a Docker log stream and passes each of its lines to handle.
+// The logs of containers without a TTY are multiplexed in frames starting with an 8 bytes header
+// holding the stream of the frame and the size of its payload.
+func readContainerLogs(r io.Reader, tty bool, handle func(models.ContainerLogLine) error) error {
+	splitter := newLogLineSplitter(handle)
+
+	if tty {
+		buf := make([]byte, 32<<10)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				if werr := splitter.write(models.ContainerLogStreamStdout, buf[:n]); werr != nil {
+					return werr
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return splitter.flush()
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return splitter.flush()
+			}
+			return err
+		}
+
+		// Docker also multiplexes stdin (0) and its own errors (3) but they are not requested
+		stream := models.ContainerLogStreamStdout
+		if header[0] == 2 {
+			stream = models.ContainerLogStreamStderr
+		}
+
+		payload := make([]byte, binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return err
+		}
+		if err := splitter.write(stream, payload); err != nil {
+			return err
+		}
+	}
+}
+
+// logLineSplitter splits the chunks of a log stream into lines, keeping the incomplete
+// line of each stream until the rest of it is received
+type logLineSplitter struct {
+	handle  func(models.ContainerLogLine) error
+	pending map[string][]byte
+}
+
+// newLogLineSplitter creates a log line splitter passing the complete lines to handle
+func newLogLineSplitter(handle func(models.ContainerLogLine) error) *logLineSplitter {
+	return &logLineSplitter{
+		handle:  handle,
+		pending: make(map[string][]byte),
+	}
+}
+
+// write adds a chunk of a stream and passes the lines it completes to the handler
+func (s *logLineSplitter) write(stream string, data []byte) error {
+	buf := append(s.pending[stream], data...)
+
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := s.handle(parseContainerLogLine(stream, buf[:i])); err != nil {
+			return err
+		}
+		buf = buf[i+1:]
+	}
+
+	if len(buf) > maxContainerLogLineSize {
+		if err := s.handle(parseContainerLogLine(stream, buf)); err != nil {
+			return err
+		}
+		buf = nil
+	}
+
+	s.pending[stream] = append([]byte(nil), buf...)
+	return nil
+}
+
+// flush passes the incomplete lines left at the end of the stream to the handler
+func (s *logLineSplitter) flush() error {
+	for _, stream := range []string{models.ContainerLogStreamStdout, models.ContainerLogStreamStderr} {
+		if len(s.pending[stream]) == 0 {
+			continue
+		}
+		if err := s.handle(parseContainerLogLine(stream, s.pending[stream])); err != nil {
+			return err
+		}
+		delete(s.pending, stream)
+	}
+	return nil
+}
+
+// parseContainerLogLine separates the timestamp added by Docker from the text of a log line
+func parseContainerLogLine(stream string, raw []byte) models.ContainerLogLine {
+	text := strings.TrimSuffix(string(raw), "\r")
+	line := models.ContainerLogLine{Stream: stream, Text: text}
+
+	timestamp, rest, found := strings.Cut(text, " ")
+	if !found {
+		timestamp, rest = text, ""
+	}
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		line.Timestamp = t.UTC().Format(time.RFC3339Nano)
+		line.Text = rest
+	}
+
+	return line
+}
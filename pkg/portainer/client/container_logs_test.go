@@ -0,0 +1,237 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLogFrame returns a frame of a multiplexed Docker log stream
+func newTestLogFrame(stream byte, payload string) []byte {
+	frame := make([]byte, 8, 8+len(payload))
+	frame[0] = stream
+	binary.BigEndian.PutUint32(frame[4:], uint32(len(payload)))
+	return append(frame, payload...)
+}
+
+// mockContainerLogs sets up the calls made to open the log stream of the web container
+func mockContainerLogs(mockAPI *MockPortainerAPI, tty bool, follow string, resp *http.Response) {
+	inspect := `{"Config":{"Tty":false}}`
+	if tty {
+		inspect = `{"Config":{"Tty":true}}`
+	}
+
+	mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+	mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.APIPath == "/containers/web/json"
+	})).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(inspect)),
+	}, nil)
+	mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.APIPath == "/containers/web/logs" && opts.QueryParams["follow"] == follow &&
+			opts.QueryParams["tail"] == "50" && opts.QueryParams["timestamps"] == "1"
+	})).Return(resp, nil)
+}
+
+func TestGetContainerLogs(t *testing.T) {
+	var multiplexed bytes.Buffer
+	multiplexed.Write(newTestLogFrame(1, "2024-05-01T10:00:00.000000001Z starting nginx\n"))
+	multiplexed.Write(newTestLogFrame(2, "2024-05-01T10:00:01.5Z warn: no "))
+	multiplexed.Write(newTestLogFrame(2, "upstream configured\n"))
+	multiplexed.Write(newTestLogFrame(1, "2024-05-01T10:00:02Z ready"))
+
+	tests := []struct {
+		name          string
+		tty           bool
+		mockStatus    int
+		mockBody      []byte
+		expected      []models.ContainerLogLine
+		expectedError string
+	}{
+		{
+			name:       "multiplexed stream",
+			mockStatus: http.StatusOK,
+			mockBody:   multiplexed.Bytes(),
+			expected: []models.ContainerLogLine{
+				{Stream: "stdout", Timestamp: "2024-05-01T10:00:00.000000001Z", Text: "starting nginx"},
+				{Stream: "stderr", Timestamp: "2024-05-01T10:00:01.5Z", Text: "warn: no upstream configured"},
+				{Stream: "stdout", Timestamp: "2024-05-01T10:00:02Z", Text: "ready"},
+			},
+		},
+		{
+			name:       "tty stream",
+			tty:        true,
+			mockStatus: http.StatusOK,
+			mockBody:   []byte("2024-05-01T10:00:00Z $ ls\r\n2024-05-01T10:00:01Z bin etc\r\n"),
+			expected: []models.ContainerLogLine{
+				{Stream: "stdout", Timestamp: "2024-05-01T10:00:00Z", Text: "$ ls"},
+				{Stream: "stdout", Timestamp: "2024-05-01T10:00:01Z", Text: "bin etc"},
+			},
+		},
+		{
+			name:       "empty logs",
+			mockStatus: http.StatusOK,
+			expected:   []models.ContainerLogLine{},
+		},
+		{
+			name:          "truncated frame",
+			mockStatus:    http.StatusOK,
+			mockBody:      newTestLogFrame(1, "2024-05-01T10:00:00Z starting nginx\n")[:20],
+			expectedError: "failed to read container logs",
+		},
+		{
+			name:          "logs error",
+			mockStatus:    http.StatusNotImplemented,
+			mockBody:      []byte(`{"message":"configured logging driver does not support reading"}`),
+			expectedError: "unexpected status 501",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockContainerLogs(mockAPI, tt.tty, "false", &http.Response{
+				StatusCode: tt.mockStatus,
+				Body:       io.NopCloser(bytes.NewReader(tt.mockBody)),
+			})
+
+			client := &PortainerClient{cli: mockAPI}
+
+			lines, err := client.GetContainerLogs(1, "web", 50)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, lines)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("kubernetes environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 5}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetContainerLogs(1, "web", 50)
+
+		assert.ErrorContains(t, err, "it has no Docker daemon")
+		mockAPI.AssertNotCalled(t, "ProxyDockerRequest", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFollowContainerLogs(t *testing.T) {
+	t.Run("container stops", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockContainerLogs(mockAPI, false, "true", &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(newTestLogFrame(1, "2024-05-01T10:00:00Z exiting\n"))),
+		})
+
+		client := &PortainerClient{cli: mockAPI}
+
+		var lines []models.ContainerLogLine
+		err := client.FollowContainerLogs(context.Background(), 1, "web", 50, func(line models.ContainerLogLine) error {
+			lines = append(lines, line)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.ContainerLogLine{{Stream: "stdout", Timestamp: "2024-05-01T10:00:00Z", Text: "exiting"}}, lines)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		reader, writer := io.Pipe()
+		defer writer.Close()
+
+		mockAPI := new(MockPortainerAPI)
+		mockContainerLogs(mockAPI, false, "true", &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       reader,
+		})
+
+		client := &PortainerClient{cli: mockAPI}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		received := make(chan models.ContainerLogLine)
+		result := make(chan error)
+		go func() {
+			result <- client.FollowContainerLogs(ctx, 1, "web", 50, func(line models.ContainerLogLine) error {
+				received <- line
+				return nil
+			})
+		}()
+
+		_, err := writer.Write(newTestLogFrame(1, "2024-05-01T10:00:00Z GET /index.html\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "GET /index.html", (<-received).Text)
+
+		cancel()
+
+		select {
+		case err := <-result:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the stream was not closed when the context was cancelled")
+		}
+
+		// The upstream body is closed, writes from Portainer now fail
+		_, err = writer.Write(newTestLogFrame(1, "2024-05-01T10:00:01Z GET /favicon.ico\n"))
+		assert.ErrorIs(t, err, io.ErrClosedPipe)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockContainerLogs(mockAPI, true, "true", &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("first\nsecond\n")),
+		})
+
+		client := &PortainerClient{cli: mockAPI}
+
+		calls := 0
+		err := client.FollowContainerLogs(context.Background(), 1, "web", 50, func(line models.ContainerLogLine) error {
+			calls++
+			return errors.New("client gone")
+		})
+
+		assert.ErrorContains(t, err, "client gone")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestLogLineSplitter(t *testing.T) {
+	var lines []models.ContainerLogLine
+	splitter := newLogLineSplitter(func(line models.ContainerLogLine) error {
+		lines = append(lines, line)
+		return nil
+	})
+
+	require.NoError(t, splitter.write("stdout", []byte("no timestamp\npartial ")))
+	require.NoError(t, splitter.write("stderr", []byte(strings.Repeat("a", maxContainerLogLineSize+1))))
+	require.NoError(t, splitter.write("stdout", []byte("line")))
+	require.NoError(t, splitter.flush())
+
+	require.Len(t, lines, 3)
+	assert.Equal(t, models.ContainerLogLine{Stream: "stdout", Text: "no timestamp"}, lines[0])
+	assert.Equal(t, "stderr", lines[1].Stream)
+	assert.Len(t, lines[1].Text, maxContainerLogLineSize+1)
+	assert.Equal(t, models.ContainerLogLine{Stream: "stdout", Text: "partial line"}, lines[2])
+}
@@ -0,0 +1,437 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// swarmServiceLabel is the label Docker Swarm sets on the containers of the tasks of a service
+const swarmServiceLabel = "com.docker.swarm.service.id"
+
+// imageDefaultConfigKeys are the keys of the container configuration that are inherited from the image
+// when the container is created without them
+var imageDefaultConfigKeys = []string{"Cmd", "Entrypoint", "WorkingDir", "User", "Healthcheck", "StopSignal", "Shell", "OnBuild"}
+
+// endpointConfigKeys are the user provided settings of the connection of a container to a network,
+// the other settings are allocated by Docker
+var endpointConfigKeys = []string{"IPAMConfig", "Links", "Aliases", "DriverOpts"}
+
+// dockerContainerInspectConfig is the subset of the Docker container inspect response used to recreate
+// a container. The configurations are kept generic so that the settings unknown to this client are
+// sent back to Docker unchanged.
+type dockerContainerInspectConfig struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	Image string `json:"Image"`
+	State struct {
+		Running bool `json:"Running"`
+	} `json:"State"`
+	Config     map[string]any `json:"Config"`
+	HostConfig map[string]any `json:"HostConfig"`
+	Mounts     []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Destination string `json:"Destination"`
+	} `json:"Mounts"`
+	NetworkSettings struct {
+		Networks map[string]map[string]any `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// dockerImageConfig is the subset of the Docker image inspect response holding the default container
+// configuration of an image
+type dockerImageConfig struct {
+	Config map[string]any `json:"Config"`
+}
+
+// RecreateContainer recreates a container from the current version of its image through the Docker proxy,
+// e.g. to apply an image update. The container is recreated with the same name, configuration, volumes,
+// port bindings and networks. The settings the old container inherited from its image, such as the
+// environment variables or the command defined by the image, are taken from the new image.
+//
+// The old container is stopped and renamed, then the new container is created, connected to the networks
+// and started if the old one was running. The old container is only removed once the new one is started:
+// when a step fails, the new container is removed and the old one is renamed back and restarted.
+// Anonymous volumes are mounted by name in the new container so that their data is kept.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//   - pullLatest: Whether to pull the image tag of the container from its registry first
+//
+// Returns:
+//   - An error if the environment has no Docker daemon, if the container is managed by Docker Swarm,
+//     if the image cannot be pulled or if the container cannot be recreated. The error reports whether
+//     the old container was restored.
+func (c *PortainerClient) RecreateContainer(environmentId int, containerId string, pullLatest bool) error {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return err
+	}
+
+	var container dockerContainerInspectConfig
+	if err := c.getDockerJSON(environmentId, "/containers/"+url.PathEscape(containerId)+"/json", nil, &container); err != nil {
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	labels, _ := container.Config["Labels"].(map[string]any)
+	if _, ok := labels[swarmServiceLabel]; ok {
+		return fmt.Errorf("container %s is a task of a Docker Swarm service, update the service instead", containerId)
+	}
+
+	image, _ := container.Config["Image"].(string)
+	if image == "" {
+		return fmt.Errorf("container %s has no image", containerId)
+	}
+
+	if pullLatest {
+		if err := c.pullImage(environmentId, image); err != nil {
+			return err
+		}
+	}
+
+	// The new image must be available before the container is stopped
+	var oldImage, newImage dockerImageConfig
+	if err := c.getDockerJSON(environmentId, "/images/"+container.Image+"/json", nil, &oldImage); err != nil {
+		return fmt.Errorf("failed to inspect the image of the container: %w", err)
+	}
+	if err := c.getDockerJSON(environmentId, "/images/"+image+"/json", nil, &newImage); err != nil {
+		return fmt.Errorf("failed to inspect image %s: %w", image, err)
+	}
+
+	createConfig, extraNetworks := buildRecreateConfig(container, oldImage.Config)
+	name := strings.TrimPrefix(container.Name, "/")
+	backupName := fmt.Sprintf("%s-old-%s", name, shortContainerID(container.ID))
+
+	if container.State.Running {
+		if _, err := c.sendDockerRequest(environmentId, http.MethodPost, "/containers/"+container.ID+"/stop", nil, nil); err != nil {
+			return fmt.Errorf("failed to stop container: %w", err)
+		}
+	}
+
+	if _, err := c.sendDockerRequest(environmentId, http.MethodPost, "/containers/"+container.ID+"/rename", map[string]string{"name": backupName}, nil); err != nil {
+		return c.rollbackRecreate(environmentId, container, false, "", fmt.Errorf("failed to rename container: %w", err))
+	}
+
+	body, err := c.sendDockerRequest(environmentId, http.MethodPost, "/containers/create", map[string]string{"name": name}, createConfig)
+	if err != nil {
+		return c.rollbackRecreate(environmentId, container, true, "", fmt.Errorf("failed to create container: %w", err))
+	}
+
+	var created struct {
+		ID string `json:"Id"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil || created.ID == "" {
+		return c.rollbackRecreate(environmentId, container, true, "", fmt.Errorf("failed to decode container create response: %s", body))
+	}
+
+	for _, network := range extraNetworks {
+		payload := map[string]any{"Container": created.ID, "EndpointConfig": network.endpoint}
+		if _, err := c.sendDockerRequest(environmentId, http.MethodPost, "/networks/"+url.PathEscape(network.name)+"/connect", nil, payload); err != nil {
+			return c.rollbackRecreate(environmentId, container, true, created.ID, fmt.Errorf("failed to connect container to network %s: %w", network.name, err))
+		}
+	}
+
+	if container.State.Running {
+		if _, err := c.sendDockerRequest(environmentId, http.MethodPost, "/containers/"+created.ID+"/start", nil, nil); err != nil {
+			return c.rollbackRecreate(environmentId, container, true, created.ID, fmt.Errorf("failed to start container: %w", err))
+		}
+	}
+
+	if _, err := c.sendDockerRequest(environmentId, http.MethodDelete, "/containers/"+container.ID, nil, nil); err != nil {
+		return fmt.Errorf("container %s was recreated but the old container %s could not be removed: %w", name, backupName, err)
+	}
+
+	return nil
+}
+
+// rollbackRecreate removes the new container, if it was created, and restores the old container
+// under its name, if it was renamed, restarting it if it was running. The returned error wraps the
+// cause of the rollback.
+func (c *PortainerClient) rollbackRecreate(environmentId int, container dockerContainerInspectConfig, renamed bool, newContainerId string, cause error) error {
+	var failures []string
+
+	if newContainerId != "" {
+		if _, err := c.sendDockerRequest(environmentId, http.MethodDelete, "/containers/"+newContainerId, map[string]string{"force": "1"}, nil); err != nil {
+			failures = append(failures, fmt.Sprintf("failed to remove the new container: %s", err))
+		}
+	}
+
+	if renamed {
+		name := strings.TrimPrefix(container.Name, "/")
+		if _, err := c.sendDockerRequest(environmentId, http.MethodPost, "/containers/"+container.ID+"/rename", map[string]string{"name": name}, nil); err != nil {
+			failures = append(failures, fmt.Sprintf("failed to rename the old container back: %s", err))
+		}
+	}
+
+	if container.State.Running {
+		if _, err := c.sendDockerRequest(environmentId, http.MethodPost, "/containers/"+container.ID+"/start", nil, nil); err != nil {
+			failures = append(failures, fmt.Sprintf("failed to restart the old container: %s", err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%w, the rollback failed: %s", cause, strings.Join(failures, "; "))
+	}
+
+	return fmt.Errorf("%w, the old container was restored", cause)
+}
+
+// recreateNetwork is a network a recreated container is connected to after its creation
+type recreateNetwork struct {
+	name     string
+	endpoint map[string]any
+}
+
+// buildRecreateConfig builds the body of the creation of a container with the configuration of an
+// existing container. It also returns the networks to connect the container to after its creation,
+// Docker only accepts a single network at creation on older API versions.
+func buildRecreateConfig(container dockerContainerInspectConfig, oldImageConfig map[string]any) (map[string]any, []recreateNetwork) {
+	config := make(map[string]any, len(container.Config)+2)
+	for key, value := range container.Config {
+		config[key] = value
+	}
+
+	removeImageDefaults(config, oldImageConfig)
+
+	// Docker uses the short container ID as the hostname when none is set
+	if hostname, _ := config["Hostname"].(string); hostname == shortContainerID(container.ID) {
+		delete(config, "Hostname")
+	}
+
+	hostConfig := make(map[string]any, len(container.HostConfig))
+	for key, value := range container.HostConfig {
+		hostConfig[key] = value
+	}
+	preserveVolumes(hostConfig, container)
+	config["HostConfig"] = hostConfig
+
+	networkMode, _ := hostConfig["NetworkMode"].(string)
+	if networkMode == "default" {
+		networkMode = "bridge"
+	}
+	if networkMode == "host" || networkMode == "none" || strings.HasPrefix(networkMode, "container:") {
+		return config, nil
+	}
+
+	var extraNetworks []recreateNetwork
+	endpoints := map[string]any{}
+	for _, name := range slices.Sorted(maps.Keys(container.NetworkSettings.Networks)) {
+		endpoint := userEndpointConfig(container.NetworkSettings.Networks[name], shortContainerID(container.ID))
+		if name == networkMode {
+			endpoints[name] = endpoint
+			continue
+		}
+		extraNetworks = append(extraNetworks, recreateNetwork{name: name, endpoint: endpoint})
+	}
+	if len(endpoints) > 0 {
+		config["NetworkingConfig"] = map[string]any{"EndpointsConfig": endpoints}
+	}
+
+	return config, extraNetworks
+}
+
+// removeImageDefaults removes from a container configuration the settings inherited from its image,
+// so that the container created with the configuration inherits the settings of the new image instead
+func removeImageDefaults(config, imageConfig map[string]any) {
+	if imageConfig == nil {
+		return
+	}
+
+	for _, key := range imageDefaultConfigKeys {
+		if value, ok := config[key]; ok && reflect.DeepEqual(value, imageConfig[key]) {
+			delete(config, key)
+		}
+	}
+
+	if env, ok := config["Env"].([]any); ok {
+		imageEnv, _ := imageConfig["Env"].([]any)
+		config["Env"] = slices.DeleteFunc(slices.Clone(env), func(value any) bool {
+			return slices.Contains(imageEnv, value)
+		})
+	}
+
+	for _, key := range []string{"Labels", "ExposedPorts", "Volumes"} {
+		values, ok := config[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		imageValues, _ := imageConfig[key].(map[string]any)
+
+		kept := make(map[string]any, len(values))
+		for name, value := range values {
+			if imageValue, ok := imageValues[name]; !ok || !reflect.DeepEqual(value, imageValue) {
+				kept[name] = value
+			}
+		}
+		config[key] = kept
+	}
+}
+
+// preserveVolumes mounts by name the volumes of a container that are not declared in its host
+// configuration, i.e. its anonymous volumes, so that a container created with the host configuration
+// uses the same volumes
+func preserveVolumes(hostConfig map[string]any, container dockerContainerInspectConfig) {
+	declared := map[string]bool{}
+
+	binds, _ := hostConfig["Binds"].([]any)
+	for _, bind := range binds {
+		if spec, ok := bind.(string); ok {
+			if parts := strings.Split(spec, ":"); len(parts) > 1 {
+				declared[parts[1]] = true
+			}
+		}
+	}
+
+	mounts, _ := hostConfig["Mounts"].([]any)
+	for _, mount := range mounts {
+		if spec, ok := mount.(map[string]any); ok {
+			if target, ok := spec["Target"].(string); ok {
+				declared[target] = true
+			}
+		}
+	}
+
+	for _, mount := range container.Mounts {
+		if mount.Type != "volume" || mount.Name == "" || declared[mount.Destination] {
+			continue
+		}
+		binds = append(binds, mount.Name+":"+mount.Destination)
+	}
+
+	if len(binds) > 0 {
+		hostConfig["Binds"] = binds
+	}
+}
+
+// userEndpointConfig keeps the user provided settings of the connection of a container to a network,
+// without the alias Docker adds for the short ID of the container
+func userEndpointConfig(endpoint map[string]any, shortId string) map[string]any {
+	config := map[string]any{}
+	for _, key := range endpointConfigKeys {
+		if value, ok := endpoint[key]; ok && value != nil {
+			config[key] = value
+		}
+	}
+
+	if aliases, ok := config["Aliases"].([]any); ok {
+		config["Aliases"] = slices.DeleteFunc(slices.Clone(aliases), func(alias any) bool {
+			return alias == shortId
+		})
+	}
+
+	return config
+}
+
+// pullImage pulls an image tag from its registry through the Docker daemon of an environment. Images
+// hosted on a registry configured with credentials in Portainer are pulled with those credentials.
+func (c *PortainerClient) pullImage(environmentId int, image string) error {
+	if strings.HasPrefix(image, "sha256:") {
+		return fmt.Errorf("the container was created from the image ID %s, it cannot be pulled", image)
+	}
+
+	// Docker pulls every tag of the repository when the reference has no tag
+	if !imageHasTagOrDigest(image) {
+		image += ":latest"
+	}
+
+	registries, err := c.cli.ListRegistries()
+	if err != nil {
+		return fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	headers := map[string]string{}
+	if registry := findAuthenticatedRegistry(registries, imageRegistryDomain(imageRepository(image))); registry != nil {
+		headers["X-Registry-Auth"] = registryAuthHeader(registry.ID)
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodPost,
+		Path:          "/images/create",
+		QueryParams:   map[string]string{"fromImage": image},
+		Headers:       headers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", image, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to pull image %s: unexpected status %d: %s", image, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Docker reports the pull failures in the progress messages once the response has started
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var message struct {
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&message); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read the pull progress of image %s: %w", image, err)
+		}
+		if message.Error != "" {
+			return fmt.Errorf("failed to pull image %s: %s", image, message.Error)
+		}
+	}
+}
+
+// sendDockerRequest sends a request to the Docker API of an environment through the Docker proxy, with
+// the payload encoded in JSON when it is not nil, and returns the body of the successful responses
+func (c *PortainerClient) sendDockerRequest(environmentId int, method, path string, queryParams map[string]string, payload any) ([]byte, error) {
+	opts := models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        method,
+		Path:          path,
+		QueryParams:   queryParams,
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		opts.Headers = map[string]string{"Content-Type": "application/json"}
+		opts.Body = bytes.NewReader(data)
+	}
+
+	resp, err := c.ProxyDockerRequest(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker response: %w", err)
+	}
+
+	// Docker answers with a 304 status code when a container is already stopped or started
+	if resp.StatusCode == http.StatusNotModified {
+		return body, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// shortContainerID returns the 12 characters prefix of a container ID Docker displays
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,261 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recreateDockerCall is a request received by the fake Docker API of the recreate tests
+type recreateDockerCall struct {
+	Request string
+	Query   map[string]string
+	Body    map[string]any
+}
+
+// mockRecreateDocker answers the Docker proxy requests with the response registered for their method and
+// path, a 404 status code for the other requests, and records the requests in the order they are sent
+func mockRecreateDocker(mockAPI *MockPortainerAPI, responses map[string]string) *[]recreateDockerCall {
+	calls := &[]recreateDockerCall{}
+
+	call := mockAPI.On("ProxyDockerRequest", 1, mock.Anything)
+	call.Run(func(args mock.Arguments) {
+		opts := args.Get(1).(client.ProxyRequestOptions)
+		request := opts.Method + " " + opts.APIPath
+
+		recorded := recreateDockerCall{Request: request, Query: opts.QueryParams}
+		if opts.Body != nil {
+			data, _ := io.ReadAll(opts.Body)
+			_ = json.Unmarshal(data, &recorded.Body)
+		}
+		*calls = append(*calls, recorded)
+
+		status, body := http.StatusNotFound, `{"message":"not found"}`
+		if response, ok := responses[request]; ok {
+			statusText, rest, _ := strings.Cut(response, " ")
+			status, body = map[string]int{"200": 200, "201": 201, "204": 204, "500": 500}[statusText], rest
+		}
+		call.ReturnArguments = mock.Arguments{&http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil}
+	})
+
+	return calls
+}
+
+// recreateRequests returns the method and path of the recorded requests
+func recreateRequests(calls []recreateDockerCall) []string {
+	requests := make([]string, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
+func TestRecreateContainer(t *testing.T) {
+	const containerInspect = `{
+		"Id": "abcdef1234567890",
+		"Name": "/web",
+		"Image": "sha256:old",
+		"State": {"Running": true},
+		"Config": {
+			"Hostname": "abcdef123456",
+			"Image": "nginx:latest",
+			"Env": ["PATH=/usr/bin", "NGINX_VERSION=1.25", "APP_ENV=prod"],
+			"Cmd": ["nginx", "-g", "daemon off;"],
+			"Labels": {"maintainer": "NGINX", "app": "web"},
+			"ExposedPorts": {"80/tcp": {}},
+			"Volumes": {"/cache": {}}
+		},
+		"HostConfig": {
+			"NetworkMode": "frontend",
+			"Binds": ["/srv/html:/usr/share/nginx/html:ro"],
+			"PortBindings": {"80/tcp": [{"HostIp": "", "HostPort": "8080"}]},
+			"RestartPolicy": {"Name": "always", "MaximumRetryCount": 0}
+		},
+		"Mounts": [
+			{"Type": "bind", "Source": "/srv/html", "Destination": "/usr/share/nginx/html"},
+			{"Type": "volume", "Name": "3f9a", "Destination": "/cache"}
+		],
+		"NetworkSettings": {
+			"Networks": {
+				"frontend": {"Aliases": ["web", "abcdef123456"], "NetworkID": "n1", "IPAddress": "172.18.0.2"},
+				"backend": {"IPAMConfig": {"IPv4Address": "10.0.0.5"}, "NetworkID": "n2", "IPAddress": "10.0.0.5"}
+			}
+		}
+	}`
+	const oldImageInspect = `{"Config": {
+		"Env": ["PATH=/usr/bin", "NGINX_VERSION=1.25"],
+		"Cmd": ["nginx", "-g", "daemon off;"],
+		"Labels": {"maintainer": "NGINX"},
+		"ExposedPorts": {"80/tcp": {}},
+		"Volumes": {"/cache": {}}
+	}}`
+
+	baseResponses := func() map[string]string {
+		return map[string]string{
+			"GET /containers/web/json":                 "200 " + containerInspect,
+			"POST /images/create":                      `200 {"status":"Pulling from library/nginx"}{"status":"Downloaded newer image for nginx:latest"}`,
+			"GET /images/sha256:old/json":              "200 " + oldImageInspect,
+			"GET /images/nginx:latest/json":            `200 {"Config": {}}`,
+			"POST /containers/abcdef1234567890/stop":   "204 ",
+			"POST /containers/abcdef1234567890/rename": "204 ",
+			"POST /containers/create":                  `201 {"Id":"new123"}`,
+			"POST /networks/backend/connect":           "200 ",
+			"POST /containers/new123/start":            "204 ",
+			"DELETE /containers/abcdef1234567890":      "204 ",
+			"DELETE /containers/new123":                "204 ",
+			"POST /containers/abcdef1234567890/start":  "204 ",
+		}
+	}
+
+	newMockAPI := func() *MockPortainerAPI {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ListRegistries").Return([]*apimodels.PortainereeRegistry{}, nil)
+		return mockAPI
+	}
+
+	t.Run("recreated with the configuration of the container", func(t *testing.T) {
+		mockAPI := newMockAPI()
+		calls := mockRecreateDocker(mockAPI, baseResponses())
+
+		c := &PortainerClient{cli: mockAPI}
+
+		err := c.RecreateContainer(1, "web", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /containers/web/json",
+			"POST /images/create",
+			"GET /images/sha256:old/json",
+			"GET /images/nginx:latest/json",
+			"POST /containers/abcdef1234567890/stop",
+			"POST /containers/abcdef1234567890/rename",
+			"POST /containers/create",
+			"POST /networks/backend/connect",
+			"POST /containers/new123/start",
+			"DELETE /containers/abcdef1234567890",
+		}, recreateRequests(*calls))
+
+		assert.Equal(t, map[string]string{"fromImage": "nginx:latest"}, (*calls)[1].Query)
+		assert.Equal(t, map[string]string{"name": "web-old-abcdef123456"}, (*calls)[5].Query)
+		assert.Equal(t, map[string]string{"name": "web"}, (*calls)[6].Query)
+
+		// The settings inherited from the old image are dropped, the anonymous volume is mounted by name
+		assert.Equal(t, map[string]any{
+			"Image":        "nginx:latest",
+			"Env":          []any{"APP_ENV=prod"},
+			"Labels":       map[string]any{"app": "web"},
+			"ExposedPorts": map[string]any{},
+			"Volumes":      map[string]any{},
+			"HostConfig": map[string]any{
+				"NetworkMode":   "frontend",
+				"Binds":         []any{"/srv/html:/usr/share/nginx/html:ro", "3f9a:/cache"},
+				"PortBindings":  map[string]any{"80/tcp": []any{map[string]any{"HostIp": "", "HostPort": "8080"}}},
+				"RestartPolicy": map[string]any{"Name": "always", "MaximumRetryCount": float64(0)},
+			},
+			"NetworkingConfig": map[string]any{
+				"EndpointsConfig": map[string]any{
+					"frontend": map[string]any{"Aliases": []any{"web"}},
+				},
+			},
+		}, (*calls)[6].Body)
+
+		assert.Equal(t, map[string]any{
+			"Container":      "new123",
+			"EndpointConfig": map[string]any{"IPAMConfig": map[string]any{"IPv4Address": "10.0.0.5"}},
+		}, (*calls)[7].Body)
+	})
+
+	t.Run("start failure rolls back", func(t *testing.T) {
+		responses := baseResponses()
+		responses["POST /containers/new123/start"] = `500 {"message":"port is already allocated"}`
+		mockAPI := newMockAPI()
+		calls := mockRecreateDocker(mockAPI, responses)
+
+		c := &PortainerClient{cli: mockAPI}
+
+		err := c.RecreateContainer(1, "web", false)
+
+		assert.ErrorContains(t, err, "failed to start container: unexpected status 500")
+		assert.ErrorContains(t, err, "the old container was restored")
+		assert.Equal(t, []string{
+			"GET /containers/web/json",
+			"GET /images/sha256:old/json",
+			"GET /images/nginx:latest/json",
+			"POST /containers/abcdef1234567890/stop",
+			"POST /containers/abcdef1234567890/rename",
+			"POST /containers/create",
+			"POST /networks/backend/connect",
+			"POST /containers/new123/start",
+			"DELETE /containers/new123",
+			"POST /containers/abcdef1234567890/rename",
+			"POST /containers/abcdef1234567890/start",
+		}, recreateRequests(*calls))
+		assert.Equal(t, map[string]string{"force": "1"}, (*calls)[8].Query)
+		assert.Equal(t, map[string]string{"name": "web"}, (*calls)[9].Query)
+		mockAPI.AssertNotCalled(t, "ListRegistries")
+	})
+
+	t.Run("failed rollback is reported", func(t *testing.T) {
+		responses := baseResponses()
+		responses["POST /containers/create"] = `500 {"message":"invalid mount config"}`
+		responses["POST /containers/abcdef1234567890/start"] = `500 {"message":"container is marked for removal"}`
+		mockAPI := newMockAPI()
+		mockRecreateDocker(mockAPI, responses)
+
+		c := &PortainerClient{cli: mockAPI}
+
+		err := c.RecreateContainer(1, "web", false)
+
+		assert.ErrorContains(t, err, "failed to create container: unexpected status 500")
+		assert.ErrorContains(t, err, "the rollback failed: failed to restart the old container")
+	})
+
+	t.Run("pull failure stops before the container", func(t *testing.T) {
+		responses := baseResponses()
+		responses["POST /images/create"] = `200 {"status":"Pulling from library/nginx"}{"error":"manifest for nginx:latest not found"}`
+		mockAPI := newMockAPI()
+		calls := mockRecreateDocker(mockAPI, responses)
+
+		c := &PortainerClient{cli: mockAPI}
+
+		err := c.RecreateContainer(1, "web", true)
+
+		assert.ErrorContains(t, err, "failed to pull image nginx:latest: manifest for nginx:latest not found")
+		assert.Equal(t, []string{"GET /containers/web/json", "POST /images/create"}, recreateRequests(*calls))
+	})
+
+	t.Run("swarm task", func(t *testing.T) {
+		responses := baseResponses()
+		responses["GET /containers/web/json"] = `200 {"Id":"abcdef1234567890","Name":"/web","Config":{"Image":"nginx:latest","Labels":{"com.docker.swarm.service.id":"s1"}}}`
+		mockAPI := newMockAPI()
+		calls := mockRecreateDocker(mockAPI, responses)
+
+		c := &PortainerClient{cli: mockAPI}
+
+		err := c.RecreateContainer(1, "web", true)
+
+		assert.ErrorContains(t, err, "is a task of a Docker Swarm service")
+		assert.Len(t, *calls, 1)
+	})
+
+	t.Run("kubernetes environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 5}, nil)
+
+		c := &PortainerClient{cli: mockAPI}
+
+		err := c.RecreateContainer(1, "web", true)
+
+		assert.ErrorContains(t, err, "it has no Docker daemon")
+		mockAPI.AssertNotCalled(t, "ProxyDockerRequest", mock.Anything, mock.Anything)
+	})
+}
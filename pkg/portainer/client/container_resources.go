@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+const (
+	// minContainerMemory is the smallest memory limit or reservation accepted by Docker
+	minContainerMemory = 6 << 20
+	// minContainerCPUs is the smallest CPU limit accepted by Docker
+	minContainerCPUs = 0.01
+	// minContainerCPUShares and maxContainerCPUShares bound the CPU shares accepted by the Linux kernel
+	minContainerCPUShares = 2
+	maxContainerCPUShares = 262144
+)
+
+// dockerContainerUpdate is the body of the Docker container update request, only the limits and the
+// restart policy being changed are sent as Docker leaves the missing ones unchanged
+type dockerContainerUpdate struct {
+	NanoCpus          *int64               `json:"NanoCpus,omitempty"`
+	CpuShares         *int64               `json:"CpuShares,omitempty"`
+	Memory            *int64               `json:"Memory,omitempty"`
+	MemoryReservation *int64               `json:"MemoryReservation,omitempty"`
+	MemorySwap        *int64               `json:"MemorySwap,omitempty"`
+	PidsLimit         *int64               `json:"PidsLimit,omitempty"`
+	RestartPolicy     *dockerRestartPolicy `json:"RestartPolicy,omitempty"`
+}
+
+// UpdateContainerResources changes the resource limits of a running container through the Docker
+// proxy, the equivalent of the `docker update` command. The container is not restarted and the limits
+// that are not provided are left unchanged.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//   - limits: The limits to apply, at least one limit is required
+//
+// Returns:
+//   - ErrResourceLimitUnsupported, wrapped with the Docker warnings, if the container was updated but
+//     the host could not apply some of the limits
+//   - An error if the limits are invalid, if the environment has no Docker daemon or if Docker rejects
+//     the update (the Docker error message is included)
+func (c *PortainerClient) UpdateContainerResources(environmentId int, containerId string, limits models.ResourceLimits) error {
+	if err := validateResourceLimits(limits); err != nil {
+		return fmt.Errorf("invalid resource limits: %w", err)
+	}
+
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return err
+	}
+
+	update := dockerContainerUpdate{
+		CpuShares:         limits.CPUShares,
+		Memory:            limits.MemoryBytes,
+		MemoryReservation: limits.MemoryReservationBytes,
+		MemorySwap:        limits.MemorySwapBytes,
+		PidsLimit:         limits.PidsLimit,
+	}
+	if limits.CPUs != nil {
+		nanoCPUs := int64(math.Round(*limits.CPUs * 1e9))
+		update.NanoCpus = &nanoCPUs
+	}
+
+	warnings, err := c.sendContainerUpdate(environmentId, containerId, update)
+	if err != nil {
+		return fmt.Errorf("failed to update container resources: %w", err)
+	}
+
+	// Docker drops the limits the host does not support and only reports them as warnings
+	if len(warnings) > 0 {
+		return fmt.Errorf("container %s was updated but some limits were not applied: %w: %s", containerId, ErrResourceLimitUnsupported, strings.Join(warnings, "; "))
+	}
+
+	return nil
+}
+
+// sendContainerUpdate sends a container update request to the Docker daemon of an environment and
+// returns the warnings of the response
+func (c *PortainerClient) sendContainerUpdate(environmentId int, containerId string, update dockerContainerUpdate) ([]string, error) {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode container update: %w", err)
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodPost,
+		Path:          fmt.Sprintf("/containers/%s/update", url.PathEscape(containerId)),
+		Headers:       map[string]string{"Content-Type": "application/json"},
+		Body:          bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result struct {
+		Warnings []string `json:"Warnings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode container update response: %w", err)
+	}
+
+	return result.Warnings, nil
+}
+
+// validateResourceLimits checks that the resource limits of a container update are within the ranges
+// accepted by Docker, so that invalid values are rejected before anything is sent
+func validateResourceLimits(limits models.ResourceLimits) error {
+	if limits == (models.ResourceLimits{}) {
+		return fmt.Errorf("at least one limit is required")
+	}
+
+	if limits.CPUs != nil && (math.IsNaN(*limits.CPUs) || *limits.CPUs < minContainerCPUs) {
+		return fmt.Errorf("cpus must be at least %.2f", minContainerCPUs)
+	}
+
+	if limits.CPUShares != nil && (*limits.CPUShares < minContainerCPUShares || *limits.CPUShares > maxContainerCPUShares) {
+		return fmt.Errorf("cpu shares must be between %d and %d", minContainerCPUShares, maxContainerCPUShares)
+	}
+
+	if limits.MemoryBytes != nil && *limits.MemoryBytes < minContainerMemory {
+		return fmt.Errorf("memory limit must be at least %d bytes", minContainerMemory)
+	}
+
+	if limits.MemoryReservationBytes != nil {
+		if *limits.MemoryReservationBytes < minContainerMemory {
+			return fmt.Errorf("memory reservation must be at least %d bytes", minContainerMemory)
+		}
+		if limits.MemoryBytes != nil && *limits.MemoryReservationBytes > *limits.MemoryBytes {
+			return fmt.Errorf("memory reservation cannot be larger than the memory limit")
+		}
+	}
+
+	if limits.MemorySwapBytes != nil && *limits.MemorySwapBytes != -1 {
+		if *limits.MemorySwapBytes <= 0 {
+			return fmt.Errorf("memory swap limit must be positive, or -1 for an unlimited swap")
+		}
+		if limits.MemoryBytes != nil && *limits.MemorySwapBytes < *limits.MemoryBytes {
+			return fmt.Errorf("memory swap limit includes the memory and cannot be smaller than the memory limit")
+		}
+	}
+
+	if limits.PidsLimit != nil && *limits.PidsLimit < -1 {
+		return fmt.Errorf("pids limit must be positive, or 0 or -1 to remove the limit")
+	}
+
+	return nil
+}
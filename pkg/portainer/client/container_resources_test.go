@@ -0,0 +1,150 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateContainerResources(t *testing.T) {
+	cpus := 1.5
+	memory := int64(512 << 20)
+	swap := int64(-1)
+	pids := int64(0)
+
+	tests := []struct {
+		name          string
+		limits        models.ResourceLimits
+		mockStatus    int
+		mockBody      string
+		expectUpdate  bool
+		expectedBody  map[string]any
+		expectedError string
+	}{
+		{
+			name:         "successful update",
+			limits:       models.ResourceLimits{CPUs: &cpus, MemoryBytes: &memory, MemorySwapBytes: &swap, PidsLimit: &pids},
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"Warnings":[]}`,
+			expectUpdate: true,
+			expectedBody: map[string]any{"NanoCpus": float64(1500000000), "Memory": float64(512 << 20), "MemorySwap": float64(-1), "PidsLimit": float64(0)},
+		},
+		{
+			name:          "limit not supported by the host",
+			limits:        models.ResourceLimits{MemoryBytes: &memory},
+			mockStatus:    http.StatusOK,
+			mockBody:      `{"Warnings":["Your kernel does not support swap limit capabilities or the cgroup is not mounted. Memory limited without swap."]}`,
+			expectUpdate:  true,
+			expectedBody:  map[string]any{"Memory": float64(512 << 20)},
+			expectedError: "Your kernel does not support swap limit capabilities",
+		},
+		{
+			name:          "docker error",
+			limits:        models.ResourceLimits{MemoryBytes: &memory},
+			mockStatus:    http.StatusBadRequest,
+			mockBody:      `{"message":"Memory limit should be smaller than already set memoryswap limit, update the memoryswap at the same time"}`,
+			expectUpdate:  true,
+			expectedBody:  map[string]any{"Memory": float64(512 << 20)},
+			expectedError: "unexpected status 400: {\"message\":\"Memory limit should be smaller",
+		},
+		{
+			name:          "no limit",
+			limits:        models.ResourceLimits{},
+			expectedError: "at least one limit is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent client.ProxyRequestOptions
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectUpdate {
+				mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodPost && opts.APIPath == "/containers/web/update"
+				})).Run(func(args mock.Arguments) {
+					sent = args.Get(1).(client.ProxyRequestOptions)
+				}).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateContainerResources(1, "web", tt.limits)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectUpdate {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(sent.Body).Decode(&body))
+				assert.Equal(t, tt.expectedBody, body)
+			} else {
+				mockAPI.AssertNotCalled(t, "ProxyDockerRequest", mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unsupported limit error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"Warnings":["Your kernel does not support CPU CFS scheduler. CPU period discarded."]}`)),
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateContainerResources(1, "web", models.ResourceLimits{CPUs: &cpus})
+
+		assert.ErrorIs(t, err, ErrResourceLimitUnsupported)
+	})
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	ptr := func(v int64) *int64 { return &v }
+	cpus := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name          string
+		limits        models.ResourceLimits
+		expectedError string
+	}{
+		{name: "valid limits", limits: models.ResourceLimits{CPUs: cpus(0.5), CPUShares: ptr(512), MemoryBytes: ptr(256 << 20), MemoryReservationBytes: ptr(128 << 20), MemorySwapBytes: ptr(512 << 20), PidsLimit: ptr(100)}},
+		{name: "unlimited swap and pids", limits: models.ResourceLimits{MemorySwapBytes: ptr(-1), PidsLimit: ptr(-1)}},
+		{name: "too few cpus", limits: models.ResourceLimits{CPUs: cpus(0.001)}, expectedError: "cpus must be at least 0.01"},
+		{name: "cpu shares out of range", limits: models.ResourceLimits{CPUShares: ptr(1)}, expectedError: "cpu shares must be between 2 and 262144"},
+		{name: "memory too low", limits: models.ResourceLimits{MemoryBytes: ptr(1 << 20)}, expectedError: "memory limit must be at least"},
+		{name: "reservation too low", limits: models.ResourceLimits{MemoryReservationBytes: ptr(1 << 20)}, expectedError: "memory reservation must be at least"},
+		{name: "reservation above limit", limits: models.ResourceLimits{MemoryBytes: ptr(64 << 20), MemoryReservationBytes: ptr(128 << 20)}, expectedError: "cannot be larger than the memory limit"},
+		{name: "swap below memory", limits: models.ResourceLimits{MemoryBytes: ptr(128 << 20), MemorySwapBytes: ptr(64 << 20)}, expectedError: "cannot be smaller than the memory limit"},
+		{name: "zero swap", limits: models.ResourceLimits{MemorySwapBytes: ptr(0)}, expectedError: "memory swap limit must be positive"},
+		{name: "negative pids", limits: models.ResourceLimits{PidsLimit: ptr(-2)}, expectedError: "pids limit must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResourceLimits(tt.limits)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// dockerRestartPolicy is the restart policy of the Docker container update request
+type dockerRestartPolicy struct {
+	Name              string `json:"Name"`
+	MaximumRetryCount int    `json:"MaximumRetryCount"`
+}
+
+// UpdateContainerRestartPolicy changes whether Docker restarts a container when it exits, through the
+// Docker proxy, the equivalent of the `docker update --restart` command. The container is neither
+// restarted nor redeployed, the new policy applies the next time it exits.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - containerId: The ID or the name of the container
+//   - policy: The restart policy to apply. The maximum retry count is only allowed with on-failure
+//
+// Returns:
+//   - An error if the policy is invalid, if the environment has no Docker daemon or if Docker rejects
+//     the update (the Docker error message is included)
+func (c *PortainerClient) UpdateContainerRestartPolicy(environmentId int, containerId string, policy models.RestartPolicy) error {
+	if err := validateRestartPolicy(policy); err != nil {
+		return fmt.Errorf("invalid restart policy: %w", err)
+	}
+
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return err
+	}
+
+	update := dockerContainerUpdate{
+		RestartPolicy: &dockerRestartPolicy{
+			Name:              policy.Name,
+			MaximumRetryCount: policy.MaximumRetryCount,
+		},
+	}
+
+	if _, err := c.sendContainerUpdate(environmentId, containerId, update); err != nil {
+		return fmt.Errorf("failed to update container restart policy: %w", err)
+	}
+
+	return nil
+}
+
+// validateRestartPolicy checks the name of a restart policy and that a maximum retry count is only
+// set for the on-failure policy, as Docker rejects it for the other policies
+func validateRestartPolicy(policy models.RestartPolicy) error {
+	if !slices.Contains(models.AllRestartPolicies, policy.Name) {
+		return fmt.Errorf("unknown policy %q: must be one of %s", policy.Name, strings.Join(models.AllRestartPolicies, ", "))
+	}
+
+	if policy.MaximumRetryCount < 0 {
+		return fmt.Errorf("maximum retry count cannot be negative")
+	}
+
+	if policy.MaximumRetryCount > 0 && policy.Name != models.RestartPolicyOnFailure {
+		return fmt.Errorf("maximum retry count is only allowed with the %s policy", models.RestartPolicyOnFailure)
+	}
+
+	return nil
+}
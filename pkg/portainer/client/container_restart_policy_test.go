@@ -0,0 +1,116 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateContainerRestartPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        models.RestartPolicy
+		mockStatus    int
+		mockBody      string
+		expectUpdate  bool
+		expectedBody  map[string]any
+		expectedError string
+	}{
+		{
+			name:         "unless-stopped policy",
+			policy:       models.RestartPolicy{Name: models.RestartPolicyUnlessStopped},
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"Warnings":null}`,
+			expectUpdate: true,
+			expectedBody: map[string]any{"RestartPolicy": map[string]any{"Name": "unless-stopped", "MaximumRetryCount": float64(0)}},
+		},
+		{
+			name:         "on-failure policy with max retries",
+			policy:       models.RestartPolicy{Name: models.RestartPolicyOnFailure, MaximumRetryCount: 5},
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"Warnings":null}`,
+			expectUpdate: true,
+			expectedBody: map[string]any{"RestartPolicy": map[string]any{"Name": "on-failure", "MaximumRetryCount": float64(5)}},
+		},
+		{
+			name:          "docker error",
+			policy:        models.RestartPolicy{Name: models.RestartPolicyAlways},
+			mockStatus:    http.StatusBadRequest,
+			mockBody:      `{"message":"Restart policy cannot be updated because AutoRemove is enabled for the container"}`,
+			expectUpdate:  true,
+			expectedBody:  map[string]any{"RestartPolicy": map[string]any{"Name": "always", "MaximumRetryCount": float64(0)}},
+			expectedError: "failed to update container restart policy: unexpected status 400: {\"message\":\"Restart policy cannot be updated",
+		},
+		{
+			name:          "unknown policy",
+			policy:        models.RestartPolicy{Name: "sometimes"},
+			expectedError: `unknown policy "sometimes": must be one of no, always, unless-stopped, on-failure`,
+		},
+		{
+			name:          "max retries without on-failure",
+			policy:        models.RestartPolicy{Name: models.RestartPolicyAlways, MaximumRetryCount: 3},
+			expectedError: "maximum retry count is only allowed with the on-failure policy",
+		},
+		{
+			name:          "negative max retries",
+			policy:        models.RestartPolicy{Name: models.RestartPolicyOnFailure, MaximumRetryCount: -1},
+			expectedError: "maximum retry count cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent client.ProxyRequestOptions
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectUpdate {
+				mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodPost && opts.APIPath == "/containers/web/update"
+				})).Run(func(args mock.Arguments) {
+					sent = args.Get(1).(client.ProxyRequestOptions)
+				}).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateContainerRestartPolicy(1, "web", tt.policy)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectUpdate {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(sent.Body).Decode(&body))
+				assert.Equal(t, tt.expectedBody, body)
+			} else {
+				mockAPI.AssertNotCalled(t, "ProxyDockerRequest", mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("kubernetes environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 5}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateContainerRestartPolicy(1, "web", models.RestartPolicy{Name: models.RestartPolicyNo})
+
+		assert.ErrorContains(t, err, "it has no Docker daemon")
+	})
+}
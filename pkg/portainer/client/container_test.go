@@ -0,0 +1,462 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListContainers(t *testing.T) {
+	mockContainers := `[
+		{"Id":"c2","Names":["/web"],"Image":"nginx:latest","State":"running","Status":"Up 2 hours","Created":1700000000},
+		{"Id":"c1","Names":["/api"],"Image":"api:1.0","State":"exited","Status":"Exited (0) 1 hour ago","Created":1700000100},
+		{"Id":"c0","Names":["/web"],"Image":"nginx:latest","State":"running","Status":"Up 1 hour","Created":1700000200}
+	]`
+
+	tests := []struct {
+		name          string
+		endpointType  int64
+		filter        models.ContainerFilter
+		expectedQuery map[string]string
+		mockStatus    int
+		expected      []models.Container
+		expectedError string
+	}{
+		{
+			name:          "running containers",
+			endpointType:  1,
+			expectedQuery: map[string]string{"all": "0"},
+			mockStatus:    http.StatusOK,
+			expected: []models.Container{
+				{ID: "c1", Name: "api", Image: "api:1.0", State: "exited", Status: "Exited (0) 1 hour ago", Created: "2023-11-14T22:15:00Z"},
+				{ID: "c0", Name: "web", Image: "nginx:latest", State: "running", Status: "Up 1 hour", Created: "2023-11-14T22:16:40Z"},
+				{ID: "c2", Name: "web", Image: "nginx:latest", State: "running", Status: "Up 2 hours", Created: "2023-11-14T22:13:20Z"},
+			},
+		},
+		{
+			name:          "all containers filtered by name",
+			endpointType:  2,
+			filter:        models.ContainerFilter{All: true, Name: "web"},
+			expectedQuery: map[string]string{"all": "1", "filters": `{"name":["web"]}`},
+			mockStatus:    http.StatusOK,
+			expected: []models.Container{
+				{ID: "c1", Name: "api", Image: "api:1.0", State: "exited", Status: "Exited (0) 1 hour ago", Created: "2023-11-14T22:15:00Z"},
+				{ID: "c0", Name: "web", Image: "nginx:latest", State: "running", Status: "Up 1 hour", Created: "2023-11-14T22:16:40Z"},
+				{ID: "c2", Name: "web", Image: "nginx:latest", State: "running", Status: "Up 2 hours", Created: "2023-11-14T22:13:20Z"},
+			},
+		},
+		{
+			name:         "filtered by labels, status and name",
+			endpointType: 1,
+			filter: models.ContainerFilter{
+				Name: "web",
+				Filters: map[string][]string{
+					"label":  {"com.docker.compose.project=shop", "tier"},
+					"status": {"running", "exited"},
+					"name":   {"api"},
+					"health": {},
+				},
+			},
+			expectedQuery: map[string]string{
+				"all":     "0",
+				"filters": `{"label":["com.docker.compose.project=shop","tier"],"name":["api","web"],"status":["running","exited"]}`,
+			},
+			mockStatus: http.StatusOK,
+			expected: []models.Container{
+				{ID: "c1", Name: "api", Image: "api:1.0", State: "exited", Status: "Exited (0) 1 hour ago", Created: "2023-11-14T22:15:00Z"},
+				{ID: "c0", Name: "web", Image: "nginx:latest", State: "running", Status: "Up 1 hour", Created: "2023-11-14T22:16:40Z"},
+				{ID: "c2", Name: "web", Image: "nginx:latest", State: "running", Status: "Up 2 hours", Created: "2023-11-14T22:13:20Z"},
+			},
+		},
+		{
+			name:          "unsupported filter",
+			endpointType:  1,
+			filter:        models.ContainerFilter{Filters: map[string][]string{"image": {"nginx"}}},
+			expectedError: `unsupported container filter "image"`,
+		},
+		{
+			name:          "kubernetes environment",
+			endpointType:  5,
+			expectedError: "it has no Docker daemon",
+		},
+		{
+			name:          "docker error",
+			endpointType:  1,
+			expectedQuery: map[string]string{"all": "0"},
+			mockStatus:    http.StatusInternalServerError,
+			expectedError: "failed to list containers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectedQuery != nil {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/json" && assert.ObjectsAreEqual(tt.expectedQuery, opts.QueryParams)
+				})).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(mockContainers)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			containers, err := client.ListContainers(1, tt.filter)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, containers)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetContainerChanges(t *testing.T) {
+	mockChanges := `[
+		{"Path":"/var/log/nginx/access.log","Kind":1},
+		{"Path":"/etc/nginx","Kind":0},
+		{"Path":"/tmp/cache","Kind":2}
+	]`
+
+	tests := []struct {
+		name          string
+		endpointType  int64
+		mockStatus    int
+		mockBody      string
+		expectProxy   bool
+		expected      []models.FilesystemChange
+		expectedError string
+	}{
+		{
+			name:         "changes sorted by path",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     mockChanges,
+			expectProxy:  true,
+			expected: []models.FilesystemChange{
+				{Path: "/etc/nginx", Kind: models.FilesystemChangeModified},
+				{Path: "/tmp/cache", Kind: models.FilesystemChangeDeleted},
+				{Path: "/var/log/nginx/access.log", Kind: models.FilesystemChangeAdded},
+			},
+		},
+		{
+			name:         "no changes",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     "null",
+			expectProxy:  true,
+			expected:     []models.FilesystemChange{},
+		},
+		{
+			name:          "kubernetes environment",
+			endpointType:  5,
+			expectedError: "it has no Docker daemon",
+		},
+		{
+			name:          "container not found",
+			endpointType:  1,
+			mockStatus:    http.StatusNotFound,
+			mockBody:      `{"message":"No such container: web"}`,
+			expectProxy:   true,
+			expectedError: "failed to get container changes: unexpected status 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectProxy {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/web/changes"
+				})).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			changes, err := client.GetContainerChanges(1, "web")
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, changes)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetContainerProcesses(t *testing.T) {
+	mockProcesses := `{
+		"Titles":["UID","PID","PPID","C","STIME","TTY","TIME","CMD"],
+		"Processes":[
+			["root","1234","1200","0","10:00","?","00:00:00","nginx: master process nginx -g daemon off;"],
+			["101","1290","1234","0","10:00","?","00:00:02","nginx: worker process"]
+		]
+	}`
+
+	tests := []struct {
+		name          string
+		endpointType  int64
+		mockStatus    int
+		mockBody      string
+		expectProxy   bool
+		expected      models.ProcessList
+		expectedError string
+	}{
+		{
+			name:         "running container",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     mockProcesses,
+			expectProxy:  true,
+			expected: models.ProcessList{
+				Titles: []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"},
+				Processes: []map[string]string{
+					{"UID": "root", "PID": "1234", "PPID": "1200", "C": "0", "STIME": "10:00", "TTY": "?", "TIME": "00:00:00", "CMD": "nginx: master process nginx -g daemon off;"},
+					{"UID": "101", "PID": "1290", "PPID": "1234", "C": "0", "STIME": "10:00", "TTY": "?", "TIME": "00:00:02", "CMD": "nginx: worker process"},
+				},
+			},
+		},
+		{
+			name:          "stopped container",
+			endpointType:  1,
+			mockStatus:    http.StatusConflict,
+			mockBody:      `{"message":"Container 4f2a is not running"}`,
+			expectProxy:   true,
+			expectedError: "container web is not running",
+		},
+		{
+			name:          "container not found",
+			endpointType:  1,
+			mockStatus:    http.StatusNotFound,
+			mockBody:      `{"message":"No such container: web"}`,
+			expectProxy:   true,
+			expectedError: "failed to get container processes: unexpected status 404",
+		},
+		{
+			name:          "kubernetes environment",
+			endpointType:  5,
+			expectedError: "it has no Docker daemon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectProxy {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/web/top"
+				})).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			processes, err := client.GetContainerProcesses(1, "web")
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, processes)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetContainerHealth(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpointType  int64
+		mockStatus    int
+		mockBody      string
+		expectProxy   bool
+		expected      models.HealthStatus
+		expectedError string
+	}{
+		{
+			name:         "unhealthy container",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody: `{"State":{"Status":"running","Health":{"Status":"unhealthy","FailingStreak":3,"Log":[
+				{"Start":"2024-05-01T10:00:00Z","End":"2024-05-01T10:00:01Z","ExitCode":0,"Output":"ok\n"},
+				{"Start":"2024-05-01T10:00:30Z","End":"2024-05-01T10:00:35Z","ExitCode":1,"Output":"curl: (7) Failed to connect to localhost port 80\n"}
+			]}}}`,
+			expectProxy: true,
+			expected: models.HealthStatus{
+				Status:        "unhealthy",
+				FailingStreak: 3,
+				LastProbe: &models.HealthProbe{
+					Start:    "2024-05-01T10:00:30Z",
+					End:      "2024-05-01T10:00:35Z",
+					ExitCode: 1,
+					Output:   "curl: (7) Failed to connect to localhost port 80",
+				},
+			},
+		},
+		{
+			name:         "no probe yet",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"State":{"Health":{"Status":"starting","FailingStreak":0,"Log":[]}}}`,
+			expectProxy:  true,
+			expected:     models.HealthStatus{Status: "starting"},
+		},
+		{
+			name:         "no healthcheck",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"State":{"Status":"running"}}`,
+			expectProxy:  true,
+			expected:     models.HealthStatus{Status: "none", Message: "no healthcheck configured"},
+		},
+		{
+			name:         "disabled healthcheck",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"State":{"Health":{"Status":"none"}}}`,
+			expectProxy:  true,
+			expected:     models.HealthStatus{Status: "none", Message: "no healthcheck configured"},
+		},
+		{
+			name:          "container not found",
+			endpointType:  1,
+			mockStatus:    http.StatusNotFound,
+			mockBody:      `{"message":"No such container: web"}`,
+			expectProxy:   true,
+			expectedError: "failed to inspect container: unexpected status 404",
+		},
+		{
+			name:          "kubernetes environment",
+			endpointType:  5,
+			expectedError: "it has no Docker daemon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectProxy {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/web/json"
+				})).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			health, err := client.GetContainerHealth(1, "web")
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, health)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetContainerMounts(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpointType  int64
+		mockStatus    int
+		mockBody      string
+		expectProxy   bool
+		expected      []models.Mount
+		expectedError string
+	}{
+		{
+			name:         "bind, volume and tmpfs mounts",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody: `{"Mounts":[
+				{"Type":"volume","Name":"web_data","Source":"/var/lib/docker/volumes/web_data/_data","Destination":"/data","Driver":"local","Mode":"z","RW":true,"Propagation":""},
+				{"Type":"bind","Source":"/etc/nginx/conf.d","Destination":"/etc/nginx/conf.d","Mode":"ro","RW":false,"Propagation":"rprivate"},
+				{"Type":"tmpfs","Source":"","Destination":"/cache","Mode":"","RW":true,"Propagation":""}
+			],"HostConfig":{"Tmpfs":{"/run":"rw,size=64m","/tmp":"ro"}}}`,
+			expectProxy: true,
+			expected: []models.Mount{
+				{Type: "tmpfs", Destination: "/cache", ReadWrite: true},
+				{Type: "volume", Source: "/var/lib/docker/volumes/web_data/_data", Destination: "/data", Name: "web_data", Driver: "local", ReadWrite: true, Persistent: true, Options: "z"},
+				{Type: "bind", Source: "/etc/nginx/conf.d", Destination: "/etc/nginx/conf.d", Persistent: true, Options: "ro", Propagation: "rprivate"},
+				{Type: "tmpfs", Destination: "/run", ReadWrite: true, Options: "rw,size=64m"},
+				{Type: "tmpfs", Destination: "/tmp", Options: "ro"},
+			},
+		},
+		{
+			name:         "no mounts",
+			endpointType: 1,
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"Mounts":[],"HostConfig":{}}`,
+			expectProxy:  true,
+			expected:     []models.Mount{},
+		},
+		{
+			name:          "container not found",
+			endpointType:  1,
+			mockStatus:    http.StatusNotFound,
+			mockBody:      `{"message":"No such container: web"}`,
+			expectProxy:   true,
+			expectedError: "failed to inspect container: unexpected status 404",
+		},
+		{
+			name:          "kubernetes environment",
+			endpointType:  5,
+			expectedError: "it has no Docker daemon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectProxy {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/web/json"
+				})).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			mounts, err := client.GetContainerMounts(1, "web")
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, mounts)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// customTemplateTypes maps the custom template types to their value in Portainer
+var customTemplateTypes = map[string]int64{
+	models.CustomTemplateTypeSwarm:      1,
+	models.CustomTemplateTypeCompose:    2,
+	models.CustomTemplateTypeKubernetes: 3,
+}
+
+// customTemplatePlatforms maps the custom template platforms to their value in Portainer
+var customTemplatePlatforms = map[string]int64{
+	models.CustomTemplatePlatformLinux:   1,
+	models.CustomTemplatePlatformWindows: 2,
+}
+
+// customTemplatePlaceholder matches the {{ name }} placeholders of the variables in the stack file of a template
+var customTemplatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// GetCustomTemplates retrieves all the custom templates from the Portainer server.
+//
+// Returns:
+//   - A slice of CustomTemplate objects, without the content of their stack file
+//   - An error if the operation fails
+func (c *PortainerClient) GetCustomTemplates() ([]models.CustomTemplate, error) {
+	rawTemplates, err := c.cli.ListCustomTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom templates: %w", err)
+	}
+
+	templates := make([]models.CustomTemplate, len(rawTemplates))
+	for i, rawTemplate := range rawTemplates {
+		templates[i] = models.ConvertToCustomTemplate(rawTemplate)
+	}
+
+	return templates, nil
+}
+
+// CreateCustomTemplate creates a custom template. The stack file of the template is either provided
+// inline in FileContent, in which case Portainer stores it, or read from the git repository in Git.
+// The git password is never included in the returned errors.
+//
+// Parameters:
+//   - template: The custom template to create, its ID is ignored
+//
+// Returns:
+//   - The ID of the created custom template
+//   - An error if the template is invalid or if the operation fails
+func (c *PortainerClient) CreateCustomTemplate(template models.CustomTemplate) (int, error) {
+	if err := validateCustomTemplate(template); err != nil {
+		return 0, fmt.Errorf("invalid custom template: %w", err)
+	}
+
+	templateType := customTemplateTypes[template.Type]
+	platform := customTemplatePlatforms[template.Platform]
+
+	var id int64
+	var err error
+	if template.Git == nil {
+		id, err = c.cli.CreateCustomTemplateFromFile(&apimodels.CustomtemplatesCustomTemplateFromFileContentPayload{
+			Title:        &template.Title,
+			Description:  &template.Description,
+			Note:         template.Note,
+			Logo:         template.Logo,
+			Type:         &templateType,
+			Platform:     platform,
+			EdgeTemplate: template.EdgeTemplate,
+			FileContent:  &template.FileContent,
+		})
+	} else {
+		git := template.Git
+		payload := &apimodels.CustomtemplatesCustomTemplateFromGitRepositoryPayload{
+			Title:                    &template.Title,
+			Description:              &template.Description,
+			Note:                     template.Note,
+			Logo:                     template.Logo,
+			Type:                     &templateType,
+			Platform:                 platform,
+			EdgeTemplate:             template.EdgeTemplate,
+			RepositoryURL:            &git.URL,
+			RepositoryReferenceName:  git.ReferenceName,
+			RepositoryAuthentication: git.Username != "",
+			RepositoryUsername:       git.Username,
+			RepositoryPassword:       git.Password,
+			TlsskipVerify:            git.TLSSkipVerify,
+		}
+		if git.ConfigFilePath != "" {
+			payload.ComposeFilePathInRepository = &git.ConfigFilePath
+		}
+		id, err = c.cli.CreateCustomTemplateFromGit(payload)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to create custom template: %w", err)
+	}
+
+	return int(id), nil
+}
+
+// DeleteCustomTemplate deletes a custom template from the Portainer server.
+// The stacks deployed from the template are not affected.
+//
+// Parameters:
+//   - id: The ID of the custom template to delete
+//
+// Returns:
+//   - An error if the operation fails
+func (c *PortainerClient) DeleteCustomTemplate(id int) error {
+	err := c.cli.DeleteCustomTemplate(int64(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete custom template: %w", err)
+	}
+
+	return nil
+}
+
+// DeployCustomTemplate deploys a custom template as a new stack on environment groups. The variables of the
+// template, written {{ name }} in its stack file, are replaced by the provided values or by their default value.
+// All the variables without a default value must be provided, the deployment is rejected before anything is
+// created otherwise.
+//
+// Parameters:
+//   - templateId: The ID of the custom template to deploy
+//   - name: The name of the stack
+//   - environmentGroupIds: The IDs of the environment groups to deploy the stack to
+//   - variables: A map of the variable names of the template to their value
+//
+// Returns:
+//   - The ID of the created stack
+//   - An error if a variable is missing or unknown, if the template cannot be deployed as a stack or if the
+//     operation fails
+func (c *PortainerClient) DeployCustomTemplate(templateId int, name string, environmentGroupIds []int, variables map[string]string) (int, error) {
+	rawTemplate, err := c.cli.GetCustomTemplate(int64(templateId))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get custom template: %w", err)
+	}
+	template := models.ConvertToCustomTemplate(rawTemplate)
+
+	// Stacks are deployed with Docker Compose on the environments
+	if template.Type == models.CustomTemplateTypeKubernetes {
+		return 0, fmt.Errorf("custom template %d is a kubernetes template, only compose and swarm templates can be deployed as stacks", templateId)
+	}
+
+	values, err := resolveCustomTemplateVariables(template.Variables, variables)
+	if err != nil {
+		return 0, fmt.Errorf("invalid variables for custom template %d: %w", templateId, err)
+	}
+
+	file, err := c.cli.GetCustomTemplateFile(int64(templateId))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get custom template file: %w", err)
+	}
+
+	return c.CreateStack(name, renderCustomTemplateFile(file, values), environmentGroupIds)
+}
+
+// resolveCustomTemplateVariables returns the value of each variable of a template, the provided value or
+// the default value of the variable. The missing variables are all reported at once.
+func resolveCustomTemplateVariables(definitions []models.CustomTemplateVariable, variables map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(definitions))
+	var missing []string
+	for _, definition := range definitions {
+		value, provided := variables[definition.Name]
+		if !provided {
+			if definition.DefaultValue == "" {
+				missing = append(missing, definition.Name)
+				continue
+			}
+			value = definition.DefaultValue
+		}
+		values[definition.Name] = value
+	}
+
+	var unknown []string
+	for name := range variables {
+		if _, defined := values[name]; !defined {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required variables: %s", strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, fmt.Errorf("unknown variables: %s", strings.Join(unknown, ", "))
+	}
+
+	return values, nil
+}
+
+// renderCustomTemplateFile replaces the {{ name }} placeholders of a stack file by the value of the variables
+// in a single pass, so that placeholders inside the values are not replaced. The values are inserted as is,
+// the placeholders of undefined variables are left unchanged.
+func renderCustomTemplateFile(file string, values map[string]string) string {
+	return customTemplatePlaceholder.ReplaceAllStringFunc(file, func(placeholder string) string {
+		name := customTemplatePlaceholder.FindStringSubmatch(placeholder)[1]
+		if value, defined := values[name]; defined {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// validateCustomTemplate checks the fields of a custom template without contacting Portainer
+func validateCustomTemplate(template models.CustomTemplate) error {
+	if strings.TrimSpace(template.Title) == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	if strings.TrimSpace(template.Description) == "" {
+		return fmt.Errorf("description cannot be empty")
+	}
+
+	if !models.IsValidCustomTemplateType(template.Type) {
+		return fmt.Errorf("invalid type %q: must be one of: %v", template.Type, models.AllCustomTemplateTypes)
+	}
+
+	// Portainer only stores a platform for the Docker templates
+	if template.Type == models.CustomTemplateTypeKubernetes {
+		if template.Platform != "" {
+			return fmt.Errorf("kubernetes templates have no platform")
+		}
+	} else if !models.IsValidCustomTemplatePlatform(template.Platform) {
+		return fmt.Errorf("invalid platform %q: must be one of: %v", template.Platform, models.AllCustomTemplatePlatforms)
+	}
+
+	switch {
+	case template.Git == nil && template.FileContent == "":
+		return fmt.Errorf("either a file content or a git repository is required")
+	case template.Git != nil && template.FileContent != "":
+		return fmt.Errorf("a file content and a git repository cannot be used together")
+	case template.Git != nil:
+		if strings.TrimSpace(template.Git.URL) == "" {
+			return fmt.Errorf("git repository URL cannot be empty")
+		}
+		if template.Git.Password != "" && template.Git.Username == "" {
+			return fmt.Errorf("a git username is required with a git password")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCustomTemplates(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockTemplates []*apimodels.PortainereeCustomTemplate
+		mockError     error
+		expectedIDs   []int
+		expectedError bool
+	}{
+		{
+			name: "successful retrieval",
+			mockTemplates: []*apimodels.PortainereeCustomTemplate{
+				{ID: 1, Title: "nginx", Type: 2, Platform: 1},
+				{ID: 2, Title: "redis", Type: 3},
+			},
+			expectedIDs: []int{1, 2},
+		},
+		{
+			name:          "empty templates",
+			mockTemplates: []*apimodels.PortainereeCustomTemplate{},
+			expectedIDs:   []int{},
+		},
+		{
+			name:          "list error",
+			mockError:     errors.New("failed to list custom templates"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListCustomTemplates").Return(tt.mockTemplates, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			templates, err := client.GetCustomTemplates()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := make([]int, len(templates))
+			for i, template := range templates {
+				ids[i] = template.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateCustomTemplate(t *testing.T) {
+	fileTemplate := models.CustomTemplate{
+		Title:       "nginx",
+		Description: "Nginx web server",
+		Type:        models.CustomTemplateTypeCompose,
+		Platform:    models.CustomTemplatePlatformLinux,
+		FileContent: "services:\n  web:\n    image: nginx",
+	}
+
+	tests := []struct {
+		name          string
+		template      models.CustomTemplate
+		mockID        int64
+		mockError     error
+		expectedID    int
+		expectedError string
+	}{
+		{
+			name:       "from file",
+			template:   fileTemplate,
+			mockID:     7,
+			expectedID: 7,
+		},
+		{
+			name:          "missing title",
+			template:      models.CustomTemplate{Description: "Nginx web server", Type: models.CustomTemplateTypeCompose, Platform: models.CustomTemplatePlatformLinux, FileContent: "services: {}"},
+			expectedError: "title cannot be empty",
+		},
+		{
+			name:          "missing description",
+			template:      models.CustomTemplate{Title: "nginx", Type: models.CustomTemplateTypeCompose, Platform: models.CustomTemplatePlatformLinux, FileContent: "services: {}"},
+			expectedError: "description cannot be empty",
+		},
+		{
+			name:          "invalid type",
+			template:      models.CustomTemplate{Title: "nginx", Description: "Nginx web server", Type: "helm", FileContent: "services: {}"},
+			expectedError: "invalid type",
+		},
+		{
+			name:          "invalid platform",
+			template:      models.CustomTemplate{Title: "nginx", Description: "Nginx web server", Type: models.CustomTemplateTypeSwarm, Platform: "darwin", FileContent: "services: {}"},
+			expectedError: "invalid platform",
+		},
+		{
+			name:          "kubernetes template with platform",
+			template:      models.CustomTemplate{Title: "redis", Description: "Redis deployment", Type: models.CustomTemplateTypeKubernetes, Platform: models.CustomTemplatePlatformLinux, FileContent: "kind: Deployment"},
+			expectedError: "kubernetes templates have no platform",
+		},
+		{
+			name:          "no file and no git",
+			template:      models.CustomTemplate{Title: "nginx", Description: "Nginx web server", Type: models.CustomTemplateTypeCompose, Platform: models.CustomTemplatePlatformLinux},
+			expectedError: "either a file content or a git repository is required",
+		},
+		{
+			name: "file and git",
+			template: models.CustomTemplate{Title: "nginx", Description: "Nginx web server", Type: models.CustomTemplateTypeCompose, Platform: models.CustomTemplatePlatformLinux,
+				FileContent: "services: {}", Git: &models.CustomTemplateGit{URL: "https://github.com/example/stacks.git"}},
+			expectedError: "cannot be used together",
+		},
+		{
+			name: "git password without username",
+			template: models.CustomTemplate{Title: "nginx", Description: "Nginx web server", Type: models.CustomTemplateTypeCompose, Platform: models.CustomTemplatePlatformLinux,
+				Git: &models.CustomTemplateGit{URL: "https://github.com/example/stacks.git", Password: "s3cret"}},
+			expectedError: "a git username is required",
+		},
+		{
+			name:          "create error",
+			template:      fileTemplate,
+			mockError:     errors.New("Invalid custom template platform"),
+			expectedError: "failed to create custom template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("CreateCustomTemplateFromFile", mock.MatchedBy(func(payload *apimodels.CustomtemplatesCustomTemplateFromFileContentPayload) bool {
+				return *payload.Title == tt.template.Title && *payload.Type == 2 && payload.Platform == 1 && *payload.FileContent == tt.template.FileContent
+			})).Return(tt.mockID, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			id, err := client.CreateCustomTemplate(tt.template)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				if tt.mockError == nil {
+					mockAPI.AssertNotCalled(t, "CreateCustomTemplateFromFile", mock.Anything)
+					mockAPI.AssertNotCalled(t, "CreateCustomTemplateFromGit", mock.Anything)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("from git", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("CreateCustomTemplateFromGit", mock.MatchedBy(func(payload *apimodels.CustomtemplatesCustomTemplateFromGitRepositoryPayload) bool {
+			return *payload.Title == "agent" && *payload.Type == 1 && payload.Platform == 2 &&
+				*payload.RepositoryURL == "https://github.com/example/stacks.git" &&
+				payload.RepositoryReferenceName == "refs/heads/main" &&
+				*payload.ComposeFilePathInRepository == "agent/docker-compose.yml" &&
+				payload.RepositoryAuthentication && payload.RepositoryUsername == "deploy" && payload.RepositoryPassword == "s3cret"
+		})).Return(int64(8), nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		id, err := client.CreateCustomTemplate(models.CustomTemplate{
+			Title:       "agent",
+			Description: "Monitoring agent",
+			Type:        models.CustomTemplateTypeSwarm,
+			Platform:    models.CustomTemplatePlatformWindows,
+			Git: &models.CustomTemplateGit{
+				URL:            "https://github.com/example/stacks.git",
+				ReferenceName:  "refs/heads/main",
+				ConfigFilePath: "agent/docker-compose.yml",
+				Username:       "deploy",
+				Password:       "s3cret",
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8, id)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("from public git repository", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("CreateCustomTemplateFromGit", mock.MatchedBy(func(payload *apimodels.CustomtemplatesCustomTemplateFromGitRepositoryPayload) bool {
+			return *payload.Type == 3 && payload.Platform == 0 && payload.ComposeFilePathInRepository == nil && !payload.RepositoryAuthentication
+		})).Return(int64(9), nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		id, err := client.CreateCustomTemplate(models.CustomTemplate{
+			Title:       "redis",
+			Description: "Redis deployment",
+			Type:        models.CustomTemplateTypeKubernetes,
+			Git:         &models.CustomTemplateGit{URL: "https://github.com/example/manifests.git"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9, id)
+		mockAPI.AssertExpectations(t)
+	})
+}
+
+func TestDeleteCustomTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int
+		mockError     error
+		expectedError bool
+	}{
+		{
+			name: "successful deletion",
+			id:   1,
+		},
+		{
+			name:          "delete error",
+			id:            2,
+			mockError:     errors.New("failed to delete custom template"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("DeleteCustomTemplate", int64(tt.id)).Return(tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.DeleteCustomTemplate(tt.id)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeployCustomTemplate(t *testing.T) {
+	composeTemplate := &apimodels.PortainereeCustomTemplate{
+		ID:   4,
+		Type: 2,
+		Variables: []*apimodels.PortainerCustomTemplateVariableDefinition{
+			{Name: "domain"},
+			{Name: "port", DefaultValue: "80"},
+		},
+	}
+	file := "services:\n  web:\n    image: nginx\n    ports:\n      - \"{{port}}:80\"\n    labels:\n      - \"host={{ domain }}\"\n      - \"{{ other }}\""
+
+	tests := []struct {
+		name          string
+		mockTemplate  *apimodels.PortainereeCustomTemplate
+		mockFileError error
+		variables     map[string]string
+		expectedFile  string
+		mockError     error
+		expectedID    int
+		expectedError string
+	}{
+		{
+			name:         "default values",
+			mockTemplate: composeTemplate,
+			variables:    map[string]string{"domain": "example.com"},
+			expectedFile: "services:\n  web:\n    image: nginx\n    ports:\n      - \"80:80\"\n    labels:\n      - \"host=example.com\"\n      - \"{{ other }}\"",
+			expectedID:   12,
+		},
+		{
+			name:         "provided values",
+			mockTemplate: composeTemplate,
+			variables:    map[string]string{"domain": "{{port}}", "port": "8080"},
+			expectedFile: "services:\n  web:\n    image: nginx\n    ports:\n      - \"8080:80\"\n    labels:\n      - \"host={{port}}\"\n      - \"{{ other }}\"",
+			expectedID:   12,
+		},
+		{
+			name:          "missing required variable",
+			mockTemplate:  composeTemplate,
+			variables:     map[string]string{"port": "8080"},
+			expectedError: "missing required variables: domain",
+		},
+		{
+			name:          "unknown variable",
+			mockTemplate:  composeTemplate,
+			variables:     map[string]string{"domain": "example.com", "replicas": "2"},
+			expectedError: "unknown variables: replicas",
+		},
+		{
+			name:          "kubernetes template",
+			mockTemplate:  &apimodels.PortainereeCustomTemplate{ID: 4, Type: 3},
+			expectedError: "only compose and swarm templates can be deployed",
+		},
+		{
+			name:          "file error",
+			mockTemplate:  composeTemplate,
+			mockFileError: errors.New("Unable to retrieve custom template file from disk"),
+			variables:     map[string]string{"domain": "example.com"},
+			expectedError: "failed to get custom template file",
+		},
+		{
+			name:          "create error",
+			mockTemplate:  composeTemplate,
+			variables:     map[string]string{"domain": "example.com"},
+			expectedFile:  "services:\n  web:\n    image: nginx\n    ports:\n      - \"80:80\"\n    labels:\n      - \"host=example.com\"\n      - \"{{ other }}\"",
+			mockError:     errors.New("a stack with the same name already exists"),
+			expectedError: "failed to create edge stack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetCustomTemplate", int64(4)).Return(tt.mockTemplate, nil)
+			mockAPI.On("GetCustomTemplateFile", int64(4)).Return(file, tt.mockFileError)
+			if tt.expectedFile != "" {
+				mockAPI.On("CreateEdgeStack", "web", tt.expectedFile, []int64{1, 2}).Return(int64(12), tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			id, err := client.DeployCustomTemplate(4, "web", []int{1, 2}, tt.variables)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				if tt.expectedFile == "" {
+					mockAPI.AssertNotCalled(t, "CreateEdgeStack", mock.Anything, mock.Anything, mock.Anything)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("template not found", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetCustomTemplate", int64(99)).Return(nil, errors.New("not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.DeployCustomTemplate(99, "web", []int{1}, nil)
+
+		assert.ErrorContains(t, err, "failed to get custom template")
+	})
+}
@@ -1,7 +1,11 @@
 package client
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/portainer/client-api-go/v2/client"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
@@ -12,13 +16,31 @@ import (
 // Parameters:
 //   - opts: Options defining the proxied request (environmentID, method, path, query params, headers, body)
 //
+// The path is prefixed with the Docker API version of the options, or with the version negotiated with
+// the Docker daemon of the environment when the client was created with WithDockerAPIVersion.
+//
 // Returns:
 //   - *http.Response: The response from the Docker API
 //   - error: Any error that occurred during the request
 func (c *PortainerClient) ProxyDockerRequest(opts models.DockerProxyRequestOptions) (*http.Response, error) {
+	apiPath := opts.Path
+	version := opts.APIVersion
+	if version == "" && c.maxDockerAPIVersion != "" {
+		var err error
+		if version, err = c.negotiatedDockerAPIVersion(opts.EnvironmentID); err != nil {
+			return nil, err
+		}
+	}
+	if version != "" {
+		var err error
+		if apiPath, err = dockerAPIVersionPath(version, apiPath); err != nil {
+			return nil, err
+		}
+	}
+
 	proxyOpts := client.ProxyRequestOptions{
 		Method:  opts.Method,
-		APIPath: opts.Path,
+		APIPath: apiPath,
 		Body:    opts.Body,
 	}
 
@@ -32,3 +54,113 @@ func (c *PortainerClient) ProxyDockerRequest(opts models.DockerProxyRequestOptio
 
 	return c.cli.ProxyDockerRequest(opts.EnvironmentID, proxyOpts)
 }
+
+// dockerInfo is the subset of the Docker system information response used to inspect Docker daemons
+type dockerInfo struct {
+	Name              string `json:"Name"`
+	ServerVersion     string `json:"ServerVersion"`
+	OperatingSystem   string `json:"OperatingSystem"`
+	OSType            string `json:"OSType"`
+	Architecture      string `json:"Architecture"`
+	KernelVersion     string `json:"KernelVersion"`
+	Driver            string `json:"Driver"`
+	MemTotal          int64  `json:"MemTotal"`
+	NCPU              int    `json:"NCPU"`
+	Containers        int    `json:"Containers"`
+	ContainersRunning int    `json:"ContainersRunning"`
+	Images            int    `json:"Images"`
+	Swarm             struct {
+		LocalNodeState   string `json:"LocalNodeState"`
+		ControlAvailable bool   `json:"ControlAvailable"`
+	} `json:"Swarm"`
+}
+
+// GetDockerInfo retrieves the system information of the Docker daemon of an environment
+// through the Docker proxy, the equivalent of the `docker info` command.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//
+// Returns:
+//   - The key system information of the Docker daemon
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) GetDockerInfo(environmentId int) (models.DockerInfo, error) {
+	info, err := c.getDockerInfo(environmentId)
+	if err != nil {
+		return models.DockerInfo{}, err
+	}
+
+	return models.DockerInfo{
+		Name:              info.Name,
+		ServerVersion:     info.ServerVersion,
+		OperatingSystem:   info.OperatingSystem,
+		OSType:            info.OSType,
+		Architecture:      info.Architecture,
+		KernelVersion:     info.KernelVersion,
+		StorageDriver:     info.Driver,
+		TotalMemoryBytes:  info.MemTotal,
+		CPUs:              info.NCPU,
+		Containers:        info.Containers,
+		ContainersRunning: info.ContainersRunning,
+		Images:            info.Images,
+	}, nil
+}
+
+// getDockerInfo checks that an environment is a Docker environment and retrieves the
+// system information of its Docker daemon
+func (c *PortainerClient) getDockerInfo(environmentId int) (dockerInfo, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return dockerInfo{}, err
+	}
+
+	var info dockerInfo
+	if err := c.getDockerJSON(environmentId, "/info", nil, &info); err != nil {
+		return dockerInfo{}, fmt.Errorf("failed to get docker info: %w", err)
+	}
+
+	return info, nil
+}
+
+// checkDockerEnvironment checks that an environment is a Docker environment
+func (c *PortainerClient) checkDockerEnvironment(environmentId int) error {
+	endpoint, err := c.cli.GetEndpoint(int64(environmentId))
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if !models.IsDockerEnvironment(environment.Type) {
+		return fmt.Errorf("environment %d is a %s environment, it has no Docker daemon", environmentId, environment.Type)
+	}
+
+	return nil
+}
+
+// getDockerJSON sends a GET request to the Docker API of an environment through the Docker proxy
+// and decodes the JSON response into v
+func (c *PortainerClient) getDockerJSON(environmentId int, path string, queryParams map[string]string, v any) error {
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          path,
+		QueryParams:   queryParams,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Numbers decoded into generic values are kept as json.Number so that they are sent back to Docker unchanged
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
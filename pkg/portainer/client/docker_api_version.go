@@ -0,0 +1,145 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/portainer/client-api-go/v2/client"
+)
+
+// dockerAPIVersionCache keeps the Docker API version negotiated with the daemon of each environment.
+// Only the negotiated versions are kept, an environment whose daemon could not be reached is
+// negotiated again on its next request.
+type dockerAPIVersionCache struct {
+	mu       sync.Mutex
+	versions map[int]string
+}
+
+// newDockerAPIVersionCache creates an empty Docker API version cache
+func newDockerAPIVersionCache() *dockerAPIVersionCache {
+	return &dockerAPIVersionCache{
+		versions: make(map[int]string),
+	}
+}
+
+// get returns the version negotiated with the Docker daemon of an environment
+func (c *dockerAPIVersionCache) get(environmentId int) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	version, ok := c.versions[environmentId]
+	return version, ok
+}
+
+// set stores the version negotiated with the Docker daemon of an environment
+func (c *dockerAPIVersionCache) set(environmentId int, version string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[environmentId] = version
+}
+
+// dockerAPIVersionPath prefixes a Docker API path with a version, e.g. /containers/json becomes
+// /v1.41/containers/json. Paths that are already versioned are kept unchanged.
+func dockerAPIVersionPath(version, path string) (string, error) {
+	if _, _, err := parseDockerAPIVersion(version); err != nil {
+		return "", err
+	}
+
+	if rest, found := strings.CutPrefix(path, "/v"); found {
+		if segment, _, _ := strings.Cut(rest, "/"); isDockerAPIVersion(segment) {
+			return path, nil
+		}
+	}
+
+	return "/v" + version + path, nil
+}
+
+// negotiatedDockerAPIVersion returns the Docker API version to use with the daemon of an environment:
+// the highest version supported by the client, lowered to the version of the daemon when it is older.
+// An empty version is returned when the daemon version cannot be read, the request is then sent
+// unversioned and Docker answers with its own version.
+func (c *PortainerClient) negotiatedDockerAPIVersion(environmentId int) (string, error) {
+	if !isDockerAPIVersion(c.maxDockerAPIVersion) {
+		return "", fmt.Errorf("invalid Docker API version %q, use a version such as 1.41", c.maxDockerAPIVersion)
+	}
+
+	if version, ok := c.dockerAPIVersions.get(environmentId); ok {
+		return version, nil
+	}
+
+	daemonVersion, err := c.getDaemonAPIVersion(environmentId)
+	if err != nil {
+		return "", nil
+	}
+
+	version := c.maxDockerAPIVersion
+	if compareDockerAPIVersions(daemonVersion, version) < 0 {
+		version = daemonVersion
+	}
+
+	c.dockerAPIVersions.set(environmentId, version)
+	return version, nil
+}
+
+// getDaemonAPIVersion reads the API version of the Docker daemon of an environment from the
+// unversioned /version endpoint, which every Docker version serves
+func (c *PortainerClient) getDaemonAPIVersion(environmentId int) (string, error) {
+	resp, err := c.cli.ProxyDockerRequest(environmentId, client.ProxyRequestOptions{
+		Method:  http.MethodGet,
+		APIPath: "/version",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var version struct {
+		APIVersion string `json:"ApiVersion"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", err
+	}
+	if _, _, err := parseDockerAPIVersion(version.APIVersion); err != nil {
+		return "", err
+	}
+
+	return version.APIVersion, nil
+}
+
+// isDockerAPIVersion reports whether a value is a Docker API version such as 1.41
+func isDockerAPIVersion(version string) bool {
+	_, _, err := parseDockerAPIVersion(version)
+	return err == nil
+}
+
+// parseDockerAPIVersion splits a Docker API version such as 1.41 into its major and minor numbers
+func parseDockerAPIVersion(version string) (int, int, error) {
+	majorPart, minorPart, found := strings.Cut(version, ".")
+	major, majorErr := strconv.Atoi(majorPart)
+	minor, minorErr := strconv.Atoi(minorPart)
+	if !found || majorErr != nil || minorErr != nil || major < 0 || minor < 0 {
+		return 0, 0, fmt.Errorf("invalid Docker API version %q, use a version such as 1.41", version)
+	}
+
+	return major, minor, nil
+}
+
+// compareDockerAPIVersions returns a negative number when a is older than b, zero when they are
+// equal and a positive number when a is newer. Both versions must be valid.
+func compareDockerAPIVersions(a, b string) int {
+	aMajor, aMinor, _ := parseDockerAPIVersion(a)
+	bMajor, bMinor, _ := parseDockerAPIVersion(b)
+	if aMajor != bMajor {
+		return aMajor - bMajor
+	}
+	return aMinor - bMinor
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyDockerRequestAPIVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxVersion    string
+		apiVersion    string
+		path          string
+		mockVersion   string
+		mockError     error
+		expectVersion bool
+		expectedPath  string
+		expectedError string
+	}{
+		{
+			name:         "unversioned by default",
+			path:         "/containers/json",
+			expectedPath: "/containers/json",
+		},
+		{
+			name:         "explicit version",
+			apiVersion:   "1.41",
+			path:         "/containers/json",
+			expectedPath: "/v1.41/containers/json",
+		},
+		{
+			name:         "explicit version overrides negotiation",
+			maxVersion:   "1.45",
+			apiVersion:   "1.41",
+			path:         "/containers/json",
+			expectedPath: "/v1.41/containers/json",
+		},
+		{
+			name:         "already versioned path",
+			apiVersion:   "1.41",
+			path:         "/v1.43/containers/json",
+			expectedPath: "/v1.43/containers/json",
+		},
+		{
+			name:          "negotiated with an older daemon",
+			maxVersion:    "1.45",
+			path:          "/containers/json",
+			mockVersion:   `{"ApiVersion":"1.41","MinAPIVersion":"1.12"}`,
+			expectVersion: true,
+			expectedPath:  "/v1.41/containers/json",
+		},
+		{
+			name:          "negotiated with a newer daemon",
+			maxVersion:    "1.45",
+			path:          "/containers/json",
+			mockVersion:   `{"ApiVersion":"1.48","MinAPIVersion":"1.24"}`,
+			expectVersion: true,
+			expectedPath:  "/v1.45/containers/json",
+		},
+		{
+			name:          "negotiation failure sends unversioned request",
+			maxVersion:    "1.45",
+			path:          "/containers/json",
+			mockError:     errors.New("environment is unreachable"),
+			expectVersion: true,
+			expectedPath:  "/containers/json",
+		},
+		{
+			name:          "invalid explicit version",
+			apiVersion:    "v1",
+			path:          "/containers/json",
+			expectedError: `invalid Docker API version "v1"`,
+		},
+		{
+			name:          "invalid negotiated version",
+			maxVersion:    "latest",
+			path:          "/containers/json",
+			expectedError: `invalid Docker API version "latest"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectVersion {
+				var resp *http.Response
+				if tt.mockError == nil {
+					resp = &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tt.mockVersion))}
+				}
+				mockAPI.On("ProxyDockerRequest", 1, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/version"}).Return(resp, tt.mockError).Once()
+			}
+			if tt.expectedError == "" {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.APIPath == tt.expectedPath
+				})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[]`))}, nil)
+			}
+
+			c := &PortainerClient{cli: mockAPI, maxDockerAPIVersion: tt.maxVersion, dockerAPIVersions: newDockerAPIVersionCache()}
+
+			_, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+				EnvironmentID: 1,
+				Method:        http.MethodGet,
+				Path:          tt.path,
+				APIVersion:    tt.apiVersion,
+			})
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				mockAPI.AssertNotCalled(t, "ProxyDockerRequest", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProxyDockerRequestCachesNegotiatedVersion(t *testing.T) {
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("ProxyDockerRequest", 1, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/version"}).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"ApiVersion":"1.41"}`)),
+	}, nil).Once()
+	mockAPI.On("ProxyDockerRequest", 2, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/version"}).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"ApiVersion":"1.47"}`)),
+	}, nil).Once()
+	mockAPI.On("ProxyDockerRequest", mock.Anything, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.APIPath != "/version"
+	})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil)
+
+	c := &PortainerClient{cli: mockAPI, maxDockerAPIVersion: "1.45", dockerAPIVersions: newDockerAPIVersionCache()}
+
+	for range 2 {
+		for _, environmentId := range []int{1, 2} {
+			_, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{EnvironmentID: environmentId, Method: http.MethodGet, Path: "/info"})
+			require.NoError(t, err)
+		}
+	}
+
+	mockAPI.AssertNumberOfCalls(t, "ProxyDockerRequest", 6)
+	mockAPI.AssertCalled(t, "ProxyDockerRequest", 1, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/v1.41/info"})
+	mockAPI.AssertCalled(t, "ProxyDockerRequest", 2, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/v1.45/info"})
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -111,3 +112,99 @@ func TestProxyDockerRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDockerInfo(t *testing.T) {
+	tests := []struct {
+		name              string
+		endpointType      int64
+		mockStatus        int
+		mockBody          string
+		mockProxyErr      error
+		expected          models.DockerInfo
+		expectProxyCall   bool
+		expectedError     bool
+		expectedErrorText string
+	}{
+		{
+			name:         "successful retrieval",
+			endpointType: 2,
+			mockStatus:   http.StatusOK,
+			mockBody: `{"Name":"node-1","ServerVersion":"27.3.1","OperatingSystem":"Ubuntu 24.04.1 LTS","OSType":"linux",
+				"Architecture":"x86_64","KernelVersion":"6.8.0-45-generic","Driver":"overlay2","MemTotal":16777216000,
+				"NCPU":8,"Containers":12,"ContainersRunning":10,"Images":30,"Swarm":{"LocalNodeState":"inactive"}}`,
+			expectProxyCall: true,
+			expected: models.DockerInfo{
+				Name:              "node-1",
+				ServerVersion:     "27.3.1",
+				OperatingSystem:   "Ubuntu 24.04.1 LTS",
+				OSType:            "linux",
+				Architecture:      "x86_64",
+				KernelVersion:     "6.8.0-45-generic",
+				StorageDriver:     "overlay2",
+				TotalMemoryBytes:  16777216000,
+				CPUs:              8,
+				Containers:        12,
+				ContainersRunning: 10,
+				Images:            30,
+			},
+		},
+		{
+			name:              "kubernetes environment",
+			endpointType:      5,
+			expectedError:     true,
+			expectedErrorText: "it has no Docker daemon",
+		},
+		{
+			name:              "unexpected status",
+			endpointType:      1,
+			mockStatus:        http.StatusInternalServerError,
+			mockBody:          "daemon unreachable",
+			expectProxyCall:   true,
+			expectedError:     true,
+			expectedErrorText: "daemon unreachable",
+		},
+		{
+			name:            "proxy error",
+			endpointType:    1,
+			mockProxyErr:    errors.New("connection refused"),
+			expectProxyCall: true,
+			expectedError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectProxyCall {
+				var resp *http.Response
+				if tt.mockProxyErr == nil {
+					resp = &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+					}
+				}
+				mockAPI.On("ProxyDockerRequest", 1, client.ProxyRequestOptions{
+					Method:  http.MethodGet,
+					APIPath: "/info",
+				}).Return(resp, tt.mockProxyErr)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			info, err := client.GetDockerInfo(1)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				if tt.expectedErrorText != "" {
+					assert.Contains(t, err.Error(), tt.expectedErrorText)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, info)
+			}
+
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
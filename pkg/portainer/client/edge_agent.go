@@ -0,0 +1,115 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+const (
+	// defaultEdgeCheckinInterval is the check-in interval of edge agents when it is set neither
+	// on the environment nor in the Portainer settings, it matches the Portainer default
+	defaultEdgeCheckinInterval = 5 * time.Second
+	// edgeHeartbeatSlack is the delay added to twice the check-in interval before an edge agent is
+	// considered offline, the same rule Portainer uses for the heartbeat of edge environments
+	edgeHeartbeatSlack = 20 * time.Second
+)
+
+// GetEdgeAgentStatus retrieves the connection status of the agents of all edge environments.
+// An agent is offline when its last check-in is older than the offline threshold. Unless it is set
+// with WithEdgeAgentOfflineThreshold, the threshold is derived from the check-in interval of each
+// environment like Portainer does: twice the interval plus 20 seconds. The age of the last check-in
+// is measured against the time of the Portainer server to be immune to clock drift.
+//
+// Returns:
+//   - A slice of EdgeAgentStatus objects sorted by environment ID
+//   - An error if the operation fails
+func (c *PortainerClient) GetEdgeAgentStatus() ([]models.EdgeAgentStatus, error) {
+	endpoints, err := c.cli.ListEndpoints()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	var defaultInterval time.Duration
+	statuses := make([]models.EdgeAgentStatus, 0)
+	for _, endpoint := range endpoints {
+		if endpoint.Type != 4 && endpoint.Type != 7 {
+			continue
+		}
+
+		interval := time.Duration(endpoint.EdgeCheckinInterval) * time.Second
+		if interval <= 0 {
+			if defaultInterval == 0 {
+				defaultInterval, err = c.getDefaultEdgeCheckinInterval()
+				if err != nil {
+					return nil, err
+				}
+			}
+			interval = defaultInterval
+		}
+
+		statuses = append(statuses, c.convertEdgeAgentStatus(endpoint, interval))
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].EnvironmentID < statuses[j].EnvironmentID
+	})
+
+	return statuses, nil
+}
+
+// getDefaultEdgeCheckinInterval returns the check-in interval of the edge agents set in the Portainer settings
+func (c *PortainerClient) getDefaultEdgeCheckinInterval() (time.Duration, error) {
+	settings, err := c.cli.GetSettings()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	if settings.EdgeAgentCheckinInterval <= 0 {
+		return defaultEdgeCheckinInterval, nil
+	}
+
+	return time.Duration(settings.EdgeAgentCheckinInterval) * time.Second, nil
+}
+
+// convertEdgeAgentStatus derives the connection status of the agent of an edge environment from its last check-in
+func (c *PortainerClient) convertEdgeAgentStatus(endpoint *apimodels.PortainereeEndpoint, interval time.Duration) models.EdgeAgentStatus {
+	threshold := c.edgeAgentOfflineThreshold
+	if threshold <= 0 {
+		threshold = 2*interval + edgeHeartbeatSlack
+	}
+
+	status := models.EdgeAgentStatus{
+		EnvironmentID:           int(endpoint.ID),
+		Name:                    endpoint.Name,
+		Type:                    models.ConvertEndpointToEnvironment(endpoint).Type,
+		EdgeID:                  endpoint.EdgeID,
+		Status:                  models.EdgeAgentStatusOffline,
+		CheckinIntervalSeconds:  int(interval.Seconds()),
+		OfflineThresholdSeconds: int(threshold.Seconds()),
+	}
+
+	if endpoint.LastCheckInDate <= 0 {
+		return status
+	}
+
+	lastCheckIn := time.Unix(endpoint.LastCheckInDate, 0)
+	now := time.Now()
+	if endpoint.QueryDate > 0 {
+		now = time.Unix(endpoint.QueryDate, 0)
+	}
+
+	elapsed := max(now.Sub(lastCheckIn), 0)
+	seconds := int(elapsed.Seconds())
+
+	status.LastCheckIn = lastCheckIn.UTC().Format(time.RFC3339)
+	status.SecondsSinceCheckIn = &seconds
+	if elapsed <= threshold {
+		status.Status = models.EdgeAgentStatusOnline
+	}
+
+	return status
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEdgeAgentStatus(t *testing.T) {
+	const queryDate = 1700000000
+
+	seconds := func(s int) *int { return &s }
+
+	endpoints := []*apimodels.PortainereeEndpoint{
+		{ID: 3, Name: "store-2", Type: 7, EdgeID: "edge-3", EdgeCheckinInterval: 60, LastCheckInDate: queryDate - 200, QueryDate: queryDate},
+		{ID: 1, Name: "local", Type: 1},
+		{ID: 2, Name: "store-1", Type: 4, EdgeID: "edge-2", LastCheckInDate: queryDate - 10, QueryDate: queryDate},
+		{ID: 4, Name: "store-3", Type: 4, EdgeID: "edge-4", EdgeCheckinInterval: 30},
+	}
+
+	tests := []struct {
+		name             string
+		threshold        time.Duration
+		settings         *apimodels.PortainereeSettings
+		mockListError    error
+		mockSettingError error
+		expected         []models.EdgeAgentStatus
+		expectedError    string
+	}{
+		{
+			name:     "threshold derived from check-in interval",
+			settings: &apimodels.PortainereeSettings{EdgeAgentCheckinInterval: 5},
+			expected: []models.EdgeAgentStatus{
+				{
+					EnvironmentID:           2,
+					Name:                    "store-1",
+					Type:                    models.EnvironmentTypeDockerEdgeAgent,
+					EdgeID:                  "edge-2",
+					Status:                  models.EdgeAgentStatusOnline,
+					LastCheckIn:             "2023-11-14T22:13:10Z",
+					SecondsSinceCheckIn:     seconds(10),
+					CheckinIntervalSeconds:  5,
+					OfflineThresholdSeconds: 30,
+				},
+				{
+					EnvironmentID:           3,
+					Name:                    "store-2",
+					Type:                    models.EnvironmentTypeKubernetesEdgeAgent,
+					EdgeID:                  "edge-3",
+					Status:                  models.EdgeAgentStatusOffline,
+					LastCheckIn:             "2023-11-14T22:10:00Z",
+					SecondsSinceCheckIn:     seconds(200),
+					CheckinIntervalSeconds:  60,
+					OfflineThresholdSeconds: 140,
+				},
+				{
+					EnvironmentID:           4,
+					Name:                    "store-3",
+					Type:                    models.EnvironmentTypeDockerEdgeAgent,
+					EdgeID:                  "edge-4",
+					Status:                  models.EdgeAgentStatusOffline,
+					CheckinIntervalSeconds:  30,
+					OfflineThresholdSeconds: 80,
+				},
+			},
+		},
+		{
+			name:      "configured threshold",
+			threshold: 5 * time.Minute,
+			settings:  &apimodels.PortainereeSettings{},
+			expected: []models.EdgeAgentStatus{
+				{
+					EnvironmentID:           2,
+					Name:                    "store-1",
+					Type:                    models.EnvironmentTypeDockerEdgeAgent,
+					EdgeID:                  "edge-2",
+					Status:                  models.EdgeAgentStatusOnline,
+					LastCheckIn:             "2023-11-14T22:13:10Z",
+					SecondsSinceCheckIn:     seconds(10),
+					CheckinIntervalSeconds:  5,
+					OfflineThresholdSeconds: 300,
+				},
+				{
+					EnvironmentID:           3,
+					Name:                    "store-2",
+					Type:                    models.EnvironmentTypeKubernetesEdgeAgent,
+					EdgeID:                  "edge-3",
+					Status:                  models.EdgeAgentStatusOnline,
+					LastCheckIn:             "2023-11-14T22:10:00Z",
+					SecondsSinceCheckIn:     seconds(200),
+					CheckinIntervalSeconds:  60,
+					OfflineThresholdSeconds: 300,
+				},
+				{
+					EnvironmentID:           4,
+					Name:                    "store-3",
+					Type:                    models.EnvironmentTypeDockerEdgeAgent,
+					EdgeID:                  "edge-4",
+					Status:                  models.EdgeAgentStatusOffline,
+					CheckinIntervalSeconds:  30,
+					OfflineThresholdSeconds: 300,
+				},
+			},
+		},
+		{
+			name:          "list error",
+			mockListError: errors.New("connection refused"),
+			expectedError: "failed to list endpoints: connection refused",
+		},
+		{
+			name:             "settings error",
+			settings:         &apimodels.PortainereeSettings{},
+			mockSettingError: errors.New("forbidden"),
+			expectedError:    "failed to get settings: forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.mockListError != nil {
+				mockAPI.On("ListEndpoints").Return(nil, tt.mockListError)
+			} else {
+				mockAPI.On("ListEndpoints").Return(endpoints, nil)
+			}
+			if tt.settings != nil {
+				mockAPI.On("GetSettings").Return(tt.settings, tt.mockSettingError)
+			}
+
+			client := &PortainerClient{cli: mockAPI, edgeAgentOfflineThreshold: tt.threshold}
+
+			statuses, err := client.GetEdgeAgentStatus()
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, statuses)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// GetEdgeConfigurations retrieves all the edge configurations from the Portainer server.
+//
+// Returns:
+//   - A slice of EdgeConfig objects
+//   - An error if the operation fails
+func (c *PortainerClient) GetEdgeConfigurations() ([]models.EdgeConfig, error) {
+	rawConfigs, err := c.cli.ListEdgeConfigs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge configurations: %w", err)
+	}
+
+	configs := make([]models.EdgeConfig, len(rawConfigs))
+	for i, rawConfig := range rawConfigs {
+		configs[i] = models.ConvertToEdgeConfig(rawConfig)
+	}
+
+	return configs, nil
+}
+
+// CreateEdgeConfiguration creates an edge configuration pushing files to the edge agents
+// of environment groups.
+// Portainer expects the files of an edge configuration as a zip archive, the archive is built
+// from the provided files. The content of the files is never included in the returned errors.
+//
+// Parameters:
+//   - config: The edge configuration to create, its ID and creation date are ignored
+//   - files: Map of file paths, relative to the base directory of the configuration, to their content
+//
+// Returns:
+//   - An error if the configuration or the files are invalid, or if the operation fails
+func (c *PortainerClient) CreateEdgeConfiguration(config models.EdgeConfig, files map[string]string) error {
+	if err := validateEdgeConfig(config); err != nil {
+		return fmt.Errorf("invalid edge configuration: %w", err)
+	}
+
+	archive, err := buildEdgeConfigArchive(files)
+	if err != nil {
+		return fmt.Errorf("invalid edge configuration files: %w", err)
+	}
+
+	err = c.cli.CreateEdgeConfig(config.Name, config.Type, config.Category, config.BaseDir, utils.IntToInt64Slice(config.EnvironmentGroupIds), archive)
+	if err != nil {
+		return fmt.Errorf("failed to create edge configuration: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEdgeConfiguration deletes an edge configuration from the Portainer server.
+//
+// Parameters:
+//   - id: The ID of the edge configuration to delete
+//
+// Returns:
+//   - An error if the operation fails
+func (c *PortainerClient) DeleteEdgeConfiguration(id int) error {
+	err := c.cli.DeleteEdgeConfig(int64(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete edge configuration: %w", err)
+	}
+
+	return nil
+}
+
+// validateEdgeConfig checks the fields of an edge configuration without contacting Portainer
+func validateEdgeConfig(config models.EdgeConfig) error {
+	if strings.TrimSpace(config.Name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	if !models.IsValidEdgeConfigType(config.Type) {
+		return fmt.Errorf("invalid type %q: must be one of: %v", config.Type, models.AllEdgeConfigTypes)
+	}
+
+	if !models.IsValidEdgeConfigCategory(config.Category) {
+		return fmt.Errorf("invalid category %q: must be one of: %v", config.Category, models.AllEdgeConfigCategories)
+	}
+
+	if len(config.EnvironmentGroupIds) == 0 {
+		return fmt.Errorf("at least one environment group is required")
+	}
+
+	return nil
+}
+
+// buildEdgeConfigArchive builds the zip archive uploaded to Portainer from the files of an edge configuration.
+// Files are added in path order so that the same files always produce the same archive.
+func buildEdgeConfigArchive(files map[string]string) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("at least one file is required")
+	}
+
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	slices.Sort(paths)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	added := make(map[string]bool, len(paths))
+
+	for _, filePath := range paths {
+		cleaned := path.Clean(filePath)
+		if filePath == "" || path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, fmt.Errorf("invalid file path %q: must be a relative path inside the base directory", filePath)
+		}
+		if added[cleaned] {
+			return nil, fmt.Errorf("duplicate file path %q", cleaned)
+		}
+		added[cleaned] = true
+
+		w, err := archive.Create(cleaned)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add file %q to archive: %w", filePath, err)
+		}
+
+		if _, err := w.Write([]byte(files[filePath])); err != nil {
+			return nil, fmt.Errorf("failed to add file %q to archive: %w", filePath, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEdgeConfigurations(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockConfigs   []*apimodels.PortainereeEdgeConfig
+		mockError     error
+		expectedIDs   []int
+		expectedError bool
+	}{
+		{
+			name: "successful retrieval",
+			mockConfigs: []*apimodels.PortainereeEdgeConfig{
+				{ID: 1, Name: "nginx-conf", Type: 0, Category: "configuration", EdgeGroupIDs: []int64{1}},
+				{ID: 2, Name: "certs", Type: 1, Category: "secret", EdgeGroupIDs: []int64{2, 3}},
+			},
+			expectedIDs: []int{1, 2},
+		},
+		{
+			name:        "empty configurations",
+			mockConfigs: []*apimodels.PortainereeEdgeConfig{},
+			expectedIDs: []int{},
+		},
+		{
+			name:          "list error",
+			mockError:     errors.New("failed to list edge configurations"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeConfigs").Return(tt.mockConfigs, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			configs, err := client.GetEdgeConfigurations()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := make([]int, len(configs))
+			for i, config := range configs {
+				ids[i] = config.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateEdgeConfiguration(t *testing.T) {
+	validConfig := models.EdgeConfig{
+		Name:                "nginx-conf",
+		Type:                models.EdgeConfigTypeGeneral,
+		Category:            models.EdgeConfigCategoryConfiguration,
+		BaseDir:             "/etc/nginx",
+		EnvironmentGroupIds: []int{1, 2},
+	}
+
+	tests := []struct {
+		name          string
+		config        models.EdgeConfig
+		files         map[string]string
+		mockError     error
+		expectedFiles map[string]string
+		expectedError bool
+		skipCreate    bool
+	}{
+		{
+			name:   "successful creation",
+			config: validConfig,
+			files: map[string]string{
+				"nginx.conf":         "worker_processes 1;",
+				"./conf.d/site.conf": "server {}",
+			},
+			expectedFiles: map[string]string{
+				"nginx.conf":       "worker_processes 1;",
+				"conf.d/site.conf": "server {}",
+			},
+		},
+		{
+			name:          "invalid type",
+			config:        models.EdgeConfig{Name: "nginx-conf", Type: "all", Category: models.EdgeConfigCategoryConfiguration, EnvironmentGroupIds: []int{1}},
+			files:         map[string]string{"nginx.conf": "worker_processes 1;"},
+			expectedError: true,
+			skipCreate:    true,
+		},
+		{
+			name:          "invalid category",
+			config:        models.EdgeConfig{Name: "nginx-conf", Type: models.EdgeConfigTypeGeneral, Category: "secrets", EnvironmentGroupIds: []int{1}},
+			files:         map[string]string{"nginx.conf": "worker_processes 1;"},
+			expectedError: true,
+			skipCreate:    true,
+		},
+		{
+			name:          "missing environment groups",
+			config:        models.EdgeConfig{Name: "nginx-conf", Type: models.EdgeConfigTypeGeneral, Category: models.EdgeConfigCategoryConfiguration},
+			files:         map[string]string{"nginx.conf": "worker_processes 1;"},
+			expectedError: true,
+			skipCreate:    true,
+		},
+		{
+			name:          "no files",
+			config:        validConfig,
+			files:         map[string]string{},
+			expectedError: true,
+			skipCreate:    true,
+		},
+		{
+			name:          "file outside base directory",
+			config:        validConfig,
+			files:         map[string]string{"../passwd": "root:x:0:0"},
+			expectedError: true,
+			skipCreate:    true,
+		},
+		{
+			name:          "duplicate file path",
+			config:        validConfig,
+			files:         map[string]string{"nginx.conf": "worker_processes 1;", "./nginx.conf": "worker_processes 2;"},
+			expectedError: true,
+			skipCreate:    true,
+		},
+		{
+			name:          "create error",
+			config:        validConfig,
+			files:         map[string]string{"nginx.conf": "worker_processes 1;"},
+			mockError:     errors.New("failed to create edge configuration"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			var archive []byte
+			if !tt.skipCreate {
+				mockAPI.On("CreateEdgeConfig", tt.config.Name, tt.config.Type, tt.config.Category, tt.config.BaseDir, []int64{1, 2}, mock.Anything).
+					Run(func(args mock.Arguments) { archive = args.Get(5).([]byte) }).
+					Return(tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.CreateEdgeConfiguration(tt.config, tt.files)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				for _, content := range tt.files {
+					assert.NotContains(t, err.Error(), content, "file content should not leak in errors")
+				}
+				if tt.skipCreate {
+					mockAPI.AssertNotCalled(t, "CreateEdgeConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+
+			reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+			require.NoError(t, err)
+
+			files := map[string]string{}
+			for _, f := range reader.File {
+				rc, err := f.Open()
+				require.NoError(t, err)
+				content, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				rc.Close()
+				files[f.Name] = string(content)
+			}
+			assert.Equal(t, tt.expectedFiles, files)
+		})
+	}
+}
+
+func TestDeleteEdgeConfiguration(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int
+		mockError     error
+		expectedError bool
+	}{
+		{
+			name: "successful deletion",
+			id:   1,
+		},
+		{
+			name:          "delete error",
+			id:            2,
+			mockError:     errors.New("failed to delete edge configuration"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("DeleteEdgeConfig", int64(tt.id)).Return(tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.DeleteEdgeConfiguration(tt.id)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
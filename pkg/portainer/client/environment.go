@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
 )
@@ -60,9 +61,16 @@ func (c *PortainerClient) UpdateEnvironmentTags(id int, tagIds []int) error {
 //   - readonly_user
 //   - operator_user
 //
+// The access map is validated before anything is sent to Portainer: every access level
+// must be valid and every user must exist.
+//
 // Returns:
-//   - An error if the operation fails
+//   - An error if the validation or the operation fails
 func (c *PortainerClient) UpdateEnvironmentUserAccesses(id int, userAccesses map[int]string) error {
+	if err := c.validateUserAccesses(userAccesses); err != nil {
+		return fmt.Errorf("invalid user accesses: %w", err)
+	}
+
 	uac := utils.IntToInt64Map(userAccesses)
 	err := c.cli.UpdateEndpoint(int64(id),
 		nil,
@@ -88,9 +96,16 @@ func (c *PortainerClient) UpdateEnvironmentUserAccesses(id int, userAccesses map
 //   - readonly_user
 //   - operator_user
 //
+// The access map is validated before anything is sent to Portainer: every access level
+// must be valid and every team must exist.
+//
 // Returns:
-//   - An error if the operation fails
+//   - An error if the validation or the operation fails
 func (c *PortainerClient) UpdateEnvironmentTeamAccesses(id int, teamAccesses map[int]string) error {
+	if err := c.validateTeamAccesses(teamAccesses); err != nil {
+		return fmt.Errorf("invalid team accesses: %w", err)
+	}
+
 	tac := utils.IntToInt64Map(teamAccesses)
 	err := c.cli.UpdateEndpoint(int64(id),
 		nil,
@@ -102,3 +117,94 @@ func (c *PortainerClient) UpdateEnvironmentTeamAccesses(id int, teamAccesses map
 	}
 	return nil
 }
+
+// GetEnvironmentGPUs retrieves the GPU configuration of a Docker environment.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - The GPU settings of the environment
+//   - An error if the environment is not a Docker environment or if the operation fails
+func (c *PortainerClient) GetEnvironmentGPUs(id int) (models.EnvironmentGPUSettings, error) {
+	endpoint, err := c.getDockerEndpoint(id)
+	if err != nil {
+		return models.EnvironmentGPUSettings{}, err
+	}
+
+	return models.ConvertToEnvironmentGPUSettings(endpoint), nil
+}
+
+// UpdateEnvironmentGPUs replaces the GPUs made available to the containers of a Docker environment.
+// GPU management is enabled when at least one GPU is provided, an empty slice removes all the GPUs.
+//
+// Parameters:
+//   - id: The ID of the environment to update
+//   - gpus: The GPUs of the environment
+//
+// Every GPU must have a unique name and a device ID that is either a GPU index or a GPU/MIG UUID.
+// The GPUs are validated before anything is sent to Portainer.
+//
+// Returns:
+//   - An error if the validation fails, if the environment is not a Docker environment,
+//     or if Portainer rejects the update (the Portainer error message is included)
+func (c *PortainerClient) UpdateEnvironmentGPUs(id int, gpus []models.GPUConfig) error {
+	if err := validateGPUs(gpus); err != nil {
+		return fmt.Errorf("invalid gpus: %w", err)
+	}
+
+	if _, err := c.getDockerEndpoint(id); err != nil {
+		return err
+	}
+
+	rawGPUs := make([]*apimodels.PortainerPair, len(gpus))
+	for i, gpu := range gpus {
+		rawGPUs[i] = &apimodels.PortainerPair{
+			Name:  gpu.Name,
+			Value: gpu.DeviceID,
+		}
+	}
+
+	if err := c.cli.UpdateEndpointGPUs(int64(id), rawGPUs); err != nil {
+		return fmt.Errorf("failed to update environment gpus: %w", err)
+	}
+
+	return nil
+}
+
+// getDockerEndpoint retrieves an endpoint and checks that it is a Docker environment,
+// as GPUs can only be configured on Docker environments.
+func (c *PortainerClient) getDockerEndpoint(id int) (*apimodels.PortainereeEndpoint, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if !models.IsDockerEnvironment(environment.Type) {
+		return nil, fmt.Errorf("environment %d is a %s environment, GPUs are only supported on Docker environments", id, environment.Type)
+	}
+
+	return endpoint, nil
+}
+
+// validateGPUs checks that every GPU has a unique, non-empty name and a valid device ID
+func validateGPUs(gpus []models.GPUConfig) error {
+	names := make(map[string]bool, len(gpus))
+	for _, gpu := range gpus {
+		if gpu.Name == "" {
+			return fmt.Errorf("gpu name cannot be empty")
+		}
+
+		if names[gpu.Name] {
+			return fmt.Errorf("duplicate gpu name %q", gpu.Name)
+		}
+		names[gpu.Name] = true
+
+		if !models.IsValidGPUDeviceID(gpu.DeviceID) {
+			return fmt.Errorf("invalid device ID %q for gpu %q: must be a GPU index (e.g. 0) or a GPU UUID (e.g. GPU-xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)", gpu.DeviceID, gpu.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// Types of the accesses granted by the bulk environment access updates
+const (
+	accessTypeUser = "user"
+	accessTypeTeam = "team"
+)
+
+// UpdateEnvironmentsUserAccessesBulk grants the same user accesses on several environments.
+// The accesses are added to the current user access policies of each environment, which are read
+// first: users that are not in the access map keep their access, users that are get the given level.
+//
+// The access map is validated before any change is made, every access level must be valid and every
+// user must exist. The environments are then updated one at a time in ascending ID order and a failing
+// update does not prevent the remaining ones from being applied.
+//
+// Parameters:
+//   - envIds: The IDs of the environments to update
+//   - userAccesses: Map of user IDs to their access level
+//
+// Returns:
+//   - An *EnvironmentAccessesUpdateError listing the updated and failed environments if some updates failed
+//   - An error if the validation fails
+func (c *PortainerClient) UpdateEnvironmentsUserAccessesBulk(envIds []int, userAccesses map[int]string) error {
+	if err := c.validateUserAccesses(userAccesses); err != nil {
+		return fmt.Errorf("invalid user accesses: %w", err)
+	}
+
+	return c.updateEnvironmentsAccessesBulk(accessTypeUser, envIds, userAccesses)
+}
+
+// UpdateEnvironmentsTeamAccessesBulk grants the same team accesses on several environments.
+// The accesses are added to the current team access policies of each environment, which are read
+// first: teams that are not in the access map keep their access, teams that are get the given level.
+//
+// The access map is validated before any change is made, every access level must be valid and every
+// team must exist. The environments are then updated one at a time in ascending ID order and a failing
+// update does not prevent the remaining ones from being applied.
+//
+// Parameters:
+//   - envIds: The IDs of the environments to update
+//   - teamAccesses: Map of team IDs to their access level
+//
+// Returns:
+//   - An *EnvironmentAccessesUpdateError listing the updated and failed environments if some updates failed
+//   - An error if the validation fails
+func (c *PortainerClient) UpdateEnvironmentsTeamAccessesBulk(envIds []int, teamAccesses map[int]string) error {
+	if err := c.validateTeamAccesses(teamAccesses); err != nil {
+		return fmt.Errorf("invalid team accesses: %w", err)
+	}
+
+	return c.updateEnvironmentsAccessesBulk(accessTypeTeam, envIds, teamAccesses)
+}
+
+// updateEnvironmentsAccessesBulk adds validated user or team accesses to several environments
+func (c *PortainerClient) updateEnvironmentsAccessesBulk(accessType string, envIds []int, accesses map[int]string) error {
+	if len(envIds) == 0 {
+		return fmt.Errorf("at least one environment ID is required")
+	}
+	if len(accesses) == 0 {
+		return fmt.Errorf("at least one %s access is required", accessType)
+	}
+
+	result := &EnvironmentAccessesUpdateError{
+		AccessType: accessType,
+		Updated:    []int{},
+		Failed:     map[int]error{},
+	}
+	for _, id := range uniqueSortedIDs(envIds) {
+		if err := c.addEnvironmentAccesses(accessType, id, accesses); err != nil {
+			result.Failed[id] = err
+			continue
+		}
+		result.Updated = append(result.Updated, id)
+	}
+
+	if len(result.Failed) > 0 {
+		return result
+	}
+
+	return nil
+}
+
+// addEnvironmentAccesses merges user or team accesses into the current access policies of an environment.
+// Environments with a policy using a role unknown to this client are left unchanged, since the policy
+// could not be sent back as is.
+func (c *PortainerClient) addEnvironmentAccesses(accessType string, id int, accesses map[int]string) error {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return fmt.Errorf("failed to get environment: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	current := environment.UserAccesses
+	if accessType == accessTypeTeam {
+		current = environment.TeamAccesses
+	}
+
+	for _, accessId := range sortedAccessIDs(current) {
+		if current[accessId] == models.AccessLevelUnknown {
+			return fmt.Errorf("%s %d has an access policy with an unknown role", accessType, accessId)
+		}
+	}
+
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = make(map[int]string, len(accesses))
+	}
+	maps.Copy(merged, accesses)
+
+	policies := utils.IntToInt64Map(merged)
+	if accessType == accessTypeTeam {
+		err = c.cli.UpdateEndpoint(int64(id), nil, nil, &policies)
+	} else {
+		err = c.cli.UpdateEndpoint(int64(id), nil, &policies, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update environment %s accesses: %w", accessType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// ExportEnvironmentAccess captures the access configuration of an environment: its access group
+// and the access levels of its users and teams.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - A snapshot of the access configuration, to be restored with RestoreEnvironmentAccess
+//   - An error if the operation fails
+func (c *PortainerClient) ExportEnvironmentAccess(id int) (models.AccessSnapshot, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return models.AccessSnapshot{}, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+
+	return models.AccessSnapshot{
+		EnvironmentID:   environment.ID,
+		EnvironmentName: environment.Name,
+		CapturedAt:      time.Now().UTC().Format(time.RFC3339),
+		AccessGroupID:   int(endpoint.GroupID),
+		UserAccesses:    environment.UserAccesses,
+		TeamAccesses:    environment.TeamAccesses,
+	}, nil
+}
+
+// RestoreEnvironmentAccess brings the access configuration of an environment back to a snapshot taken
+// with ExportEnvironmentAccess. The accesses that are not in the snapshot are removed. The restore is
+// applied with ApplyEnvironmentConfig, restoring a snapshot twice is a no-op.
+//
+// The users and teams of the snapshot that no longer exist are skipped, the rest of the snapshot is
+// restored and they are reported with an AccessSnapshotRestoreError.
+//
+// Parameters:
+//   - id: The ID of the environment
+//   - snapshot: The snapshot to restore, it must have been taken on the same environment
+//
+// Returns:
+//   - An AccessSnapshotRestoreError if the snapshot was restored without the users or teams that no longer exist
+//   - An error if the snapshot is invalid or if the operation fails
+func (c *PortainerClient) RestoreEnvironmentAccess(id int, snapshot models.AccessSnapshot) error {
+	if snapshot.EnvironmentID != id {
+		return fmt.Errorf("the snapshot was taken on environment %d and cannot be restored on environment %d", snapshot.EnvironmentID, id)
+	}
+
+	if err := validateAccessLevels("user", snapshot.UserAccesses); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if err := validateAccessLevels("team", snapshot.TeamAccesses); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	userAccesses, missingUsers, err := c.existingUserAccesses(snapshot.UserAccesses)
+	if err != nil {
+		return err
+	}
+
+	teamAccesses, missingTeams, err := c.existingTeamAccesses(snapshot.TeamAccesses)
+	if err != nil {
+		return err
+	}
+
+	desired := models.EnvironmentDesiredState{
+		UserAccesses: userAccesses,
+		TeamAccesses: teamAccesses,
+	}
+	if snapshot.AccessGroupID > 0 {
+		desired.AccessGroupID = &snapshot.AccessGroupID
+	}
+
+	if _, err := c.ApplyEnvironmentConfig(id, desired); err != nil {
+		return fmt.Errorf("failed to restore environment access: %w", err)
+	}
+
+	if len(missingUsers) > 0 || len(missingTeams) > 0 {
+		return &AccessSnapshotRestoreError{EnvironmentID: id, MissingUsers: missingUsers, MissingTeams: missingTeams}
+	}
+
+	return nil
+}
+
+// existingUserAccesses splits an access map between the users that exist and the IDs of the users
+// that no longer exist, in ascending order. Users are only listed when the map is not empty.
+func (c *PortainerClient) existingUserAccesses(accesses map[int]string) (map[int]string, []int, error) {
+	existing := map[int]bool{}
+	if len(accesses) > 0 {
+		users, err := c.cli.ListUsers()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		for _, user := range users {
+			existing[int(user.ID)] = true
+		}
+	}
+
+	kept, missing := splitAccesses(accesses, existing)
+	return kept, missing, nil
+}
+
+// existingTeamAccesses splits an access map between the teams that exist and the IDs of the teams
+// that no longer exist, in ascending order. Teams are only listed when the map is not empty.
+func (c *PortainerClient) existingTeamAccesses(accesses map[int]string) (map[int]string, []int, error) {
+	existing := map[int]bool{}
+	if len(accesses) > 0 {
+		teams, err := c.cli.ListTeams()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+		for _, team := range teams {
+			existing[int(team.ID)] = true
+		}
+	}
+
+	kept, missing := splitAccesses(accesses, existing)
+	return kept, missing, nil
+}
+
+// splitAccesses keeps the accesses of the existing IDs and returns the other IDs in ascending order
+func splitAccesses(accesses map[int]string, existing map[int]bool) (map[int]string, []int) {
+	kept := make(map[int]string, len(accesses))
+	var missing []int
+	for _, id := range sortedAccessIDs(accesses) {
+		if existing[id] {
+			kept[id] = accesses[id]
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return kept, missing
+}
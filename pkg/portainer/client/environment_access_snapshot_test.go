@@ -0,0 +1,177 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportEnvironmentAccess(t *testing.T) {
+	t.Run("successful export", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{
+			ID:      1,
+			Name:    "production",
+			GroupID: 2,
+			UserAccessPolicies: apimodels.PortainerUserAccessPolicies{
+				"1": apimodels.PortainerAccessPolicy{RoleID: 1}, // environment_administrator
+			},
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+				"3": apimodels.PortainerAccessPolicy{RoleID: 4}, // readonly_user
+			},
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		snapshot, err := client.ExportEnvironmentAccess(1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, snapshot.EnvironmentID)
+		assert.Equal(t, "production", snapshot.EnvironmentName)
+		assert.Equal(t, 2, snapshot.AccessGroupID)
+		assert.Equal(t, map[int]string{1: models.AccessLevelEnvironmentAdmin}, snapshot.UserAccesses)
+		assert.Equal(t, map[int]string{3: models.AccessLevelReadonlyUser}, snapshot.TeamAccesses)
+		_, err = time.Parse(time.RFC3339, snapshot.CapturedAt)
+		assert.NoError(t, err)
+	})
+
+	t.Run("get endpoint error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(nil, errors.New("endpoint not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.ExportEnvironmentAccess(1)
+
+		assert.ErrorContains(t, err, "failed to get endpoint")
+	})
+}
+
+func TestRestoreEnvironmentAccess(t *testing.T) {
+	mockEndpoint := &apimodels.PortainereeEndpoint{
+		ID:      1,
+		GroupID: 1,
+		UserAccessPolicies: apimodels.PortainerUserAccessPolicies{
+			"1": apimodels.PortainerAccessPolicy{RoleID: 1}, // environment_administrator
+		},
+		TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+			"1": apimodels.PortainerAccessPolicy{RoleID: 4}, // readonly_user
+		},
+	}
+
+	tests := []struct {
+		name          string
+		snapshot      models.AccessSnapshot
+		expectUpdate  bool
+		expectedUsers *map[int64]string
+		expectedTeams *map[int64]string
+		mockUpdateErr error
+		expectGroup   bool
+		expectedError string
+		expectMissing *AccessSnapshotRestoreError
+	}{
+		{
+			name: "already restored",
+			snapshot: models.AccessSnapshot{
+				EnvironmentID: 1,
+				AccessGroupID: 1,
+				UserAccesses:  map[int]string{1: models.AccessLevelEnvironmentAdmin},
+				TeamAccesses:  map[int]string{1: models.AccessLevelReadonlyUser},
+			},
+		},
+		{
+			name: "restore accesses and access group",
+			snapshot: models.AccessSnapshot{
+				EnvironmentID: 1,
+				AccessGroupID: 2,
+				UserAccesses:  map[int]string{1: models.AccessLevelStandardUser},
+				TeamAccesses:  map[int]string{},
+			},
+			expectUpdate:  true,
+			expectedUsers: &map[int64]string{1: models.AccessLevelStandardUser},
+			expectedTeams: &map[int64]string{},
+			expectGroup:   true,
+		},
+		{
+			name: "users and teams no longer exist",
+			snapshot: models.AccessSnapshot{
+				EnvironmentID: 1,
+				AccessGroupID: 1,
+				UserAccesses:  map[int]string{1: models.AccessLevelEnvironmentAdmin, 7: models.AccessLevelStandardUser, 4: models.AccessLevelReadonlyUser},
+				TeamAccesses:  map[int]string{9: models.AccessLevelOperatorUser},
+			},
+			expectUpdate:  true,
+			expectedTeams: &map[int64]string{},
+			expectMissing: &AccessSnapshotRestoreError{EnvironmentID: 1, MissingUsers: []int{4, 7}, MissingTeams: []int{9}},
+		},
+		{
+			name:          "snapshot of another environment",
+			snapshot:      models.AccessSnapshot{EnvironmentID: 2},
+			expectedError: "cannot be restored on environment 1",
+		},
+		{
+			name: "invalid access level",
+			snapshot: models.AccessSnapshot{
+				EnvironmentID: 1,
+				UserAccesses:  map[int]string{1: "owner"},
+			},
+			expectedError: "invalid access level",
+		},
+		{
+			name: "update error",
+			snapshot: models.AccessSnapshot{
+				EnvironmentID: 1,
+				AccessGroupID: 1,
+				UserAccesses:  map[int]string{},
+				TeamAccesses:  map[int]string{1: models.AccessLevelReadonlyUser},
+			},
+			expectUpdate:  true,
+			expectedUsers: &map[int64]string{},
+			mockUpdateErr: errors.New("failed to update endpoint"),
+			expectedError: "failed to restore environment access",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := newEnvironmentConfigMockAPI()
+			mockAPI.On("GetEndpoint", int64(1)).Return(mockEndpoint, nil).Maybe()
+			if tt.expectUpdate {
+				mockAPI.On("UpdateEndpoint", int64(1), (*[]int64)(nil), tt.expectedUsers, tt.expectedTeams).Return(tt.mockUpdateErr)
+			}
+			if tt.expectGroup {
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(1)).Return(nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.RestoreEnvironmentAccess(1, tt.snapshot)
+
+			switch {
+			case tt.expectedError != "":
+				assert.ErrorContains(t, err, tt.expectedError)
+				if !tt.expectUpdate {
+					mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
+				return
+			case tt.expectMissing != nil:
+				var restoreErr *AccessSnapshotRestoreError
+				require.ErrorAs(t, err, &restoreErr)
+				assert.Equal(t, tt.expectMissing, restoreErr)
+				assert.EqualError(t, err, "the access of environment 1 was restored without the accesses of users [4 7] and teams [9] as they no longer exist")
+			default:
+				assert.NoError(t, err)
+			}
+			if !tt.expectUpdate {
+				mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateEnvironmentsTeamAccessesBulk(t *testing.T) {
+	existingTeams := []*apimodels.PortainerTeam{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	t.Run("adds the accesses to the current policies", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListTeams").Return(existingTeams, nil)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{
+			ID: 1,
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+				"2": {RoleID: 4},
+				"3": {RoleID: 4},
+			},
+		}, nil)
+		mockAPI.On("GetEndpoint", int64(2)).Return(&apimodels.PortainereeEndpoint{ID: 2}, nil)
+		mockAPI.On("UpdateEndpoint", int64(1), (*[]int64)(nil), (*map[int64]string)(nil), &map[int64]string{
+			1: "standard_user",
+			2: "readonly_user",
+			3: "operator_user",
+		}).Return(nil)
+		mockAPI.On("UpdateEndpoint", int64(2), (*[]int64)(nil), (*map[int64]string)(nil), &map[int64]string{
+			1: "standard_user",
+			3: "operator_user",
+		}).Return(nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentsTeamAccessesBulk([]int{2, 1, 2}, map[int]string{1: "standard_user", 3: "operator_user"})
+
+		assert.NoError(t, err)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		updateErr := errors.New("environment is locked")
+
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListTeams").Return(existingTeams, nil)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1}, nil)
+		mockAPI.On("GetEndpoint", int64(2)).Return(&apimodels.PortainereeEndpoint{ID: 2}, nil)
+		mockAPI.On("GetEndpoint", int64(3)).Return(&apimodels.PortainereeEndpoint{
+			ID:                 3,
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"2": {RoleID: 42}},
+		}, nil)
+		mockAPI.On("UpdateEndpoint", int64(1), mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockAPI.On("UpdateEndpoint", int64(2), mock.Anything, mock.Anything, mock.Anything).Return(updateErr)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentsTeamAccessesBulk([]int{1, 2, 3}, map[int]string{1: "standard_user"})
+
+		var bulkErr *EnvironmentAccessesUpdateError
+		require.ErrorAs(t, err, &bulkErr)
+		assert.Equal(t, []int{1}, bulkErr.Updated)
+		assert.Len(t, bulkErr.Failed, 2)
+		assert.ErrorIs(t, err, updateErr)
+		assert.ErrorContains(t, bulkErr.Failed[3], "team 2 has an access policy with an unknown role")
+		assert.ErrorContains(t, err, "failed to update the team accesses of 2 of 3 environments")
+		mockAPI.AssertNotCalled(t, "UpdateEndpoint", int64(3), mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListTeams").Return(existingTeams, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		assert.ErrorContains(t, client.UpdateEnvironmentsTeamAccessesBulk([]int{1}, map[int]string{1: "superuser"}), "invalid team accesses")
+		assert.ErrorContains(t, client.UpdateEnvironmentsTeamAccessesBulk([]int{1}, map[int]string{99: "standard_user"}), "team 99 does not exist")
+		assert.ErrorContains(t, client.UpdateEnvironmentsTeamAccessesBulk([]int{}, map[int]string{1: "standard_user"}), "at least one environment ID is required")
+		assert.ErrorContains(t, client.UpdateEnvironmentsTeamAccessesBulk([]int{1}, map[int]string{}), "at least one team access is required")
+		mockAPI.AssertNotCalled(t, "GetEndpoint", mock.Anything)
+		mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateEnvironmentsUserAccessesBulk(t *testing.T) {
+	existingUsers := []*apimodels.PortainereeUser{{ID: 1}, {ID: 2}}
+
+	t.Run("adds the accesses to the current policies", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListUsers").Return(existingUsers, nil)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{
+			ID:                 1,
+			UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"2": {RoleID: 1}},
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"5": {RoleID: 4}},
+		}, nil)
+		mockAPI.On("UpdateEndpoint", int64(1), (*[]int64)(nil), &map[int64]string{
+			1: "helpdesk_user",
+			2: "environment_administrator",
+		}, (*map[int64]string)(nil)).Return(nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentsUserAccessesBulk([]int{1}, map[int]string{1: "helpdesk_user"})
+
+		assert.NoError(t, err)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("get environment error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListUsers").Return(existingUsers, nil)
+		mockAPI.On("GetEndpoint", int64(1)).Return(nil, errors.New("environment not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentsUserAccessesBulk([]int{1}, map[int]string{1: "helpdesk_user"})
+
+		var bulkErr *EnvironmentAccessesUpdateError
+		require.ErrorAs(t, err, &bulkErr)
+		assert.Empty(t, bulkErr.Updated)
+		assert.ErrorContains(t, err, "environment 1: failed to get environment: environment not found")
+		mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
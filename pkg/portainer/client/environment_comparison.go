@@ -0,0 +1,221 @@
+package client
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// CompareEnvironments compares the configuration of two environments: their tags, access group,
+// environment groups, user and team accesses and, for Docker environments, the version of the Docker daemon.
+// Tags, groups, users and teams are reported with their names so that the differences can be read without
+// further lookups.
+//
+// The Docker version of an environment that cannot be reached is left empty and reported in the
+// warnings of the comparison instead of failing the whole comparison.
+//
+// Parameters:
+//   - id1: The ID of the first environment
+//   - id2: The ID of the second environment
+//
+// Returns:
+//   - The comparison of the two environments
+//   - An error if the environments are the same or if the operation fails
+func (c *PortainerClient) CompareEnvironments(id1, id2 int) (models.EnvironmentComparison, error) {
+	if id1 == id2 {
+		return models.EnvironmentComparison{}, fmt.Errorf("cannot compare environment %d with itself", id1)
+	}
+
+	first, err := c.cli.GetEndpoint(int64(id1))
+	if err != nil {
+		return models.EnvironmentComparison{}, fmt.Errorf("failed to get endpoint %d: %w", id1, err)
+	}
+
+	second, err := c.cli.GetEndpoint(int64(id2))
+	if err != nil {
+		return models.EnvironmentComparison{}, fmt.Errorf("failed to get endpoint %d: %w", id2, err)
+	}
+
+	tags, err := c.cli.ListTags()
+	if err != nil {
+		return models.EnvironmentComparison{}, fmt.Errorf("failed to list tags: %w", err)
+	}
+	tagNames := make(map[int]string, len(tags))
+	for _, tag := range tags {
+		tagNames[int(tag.ID)] = tag.Name
+	}
+
+	accessGroups, err := c.cli.ListEndpointGroups()
+	if err != nil {
+		return models.EnvironmentComparison{}, fmt.Errorf("failed to list access groups: %w", err)
+	}
+	accessGroupNames := make(map[int]string, len(accessGroups))
+	for _, group := range accessGroups {
+		accessGroupNames[int(group.ID)] = group.Name
+	}
+
+	edgeGroups, err := c.cli.ListEdgeGroups()
+	if err != nil {
+		return models.EnvironmentComparison{}, fmt.Errorf("failed to list environment groups: %w", err)
+	}
+	groupNames := make(map[int]string, len(edgeGroups))
+	environmentGroups := make(map[int][]int)
+	for _, edgeGroup := range edgeGroups {
+		group := models.ConvertEdgeGroupToGroup(edgeGroup)
+		groupNames[group.ID] = group.Name
+		for _, environmentId := range group.EnvironmentIds {
+			environmentGroups[environmentId] = append(environmentGroups[environmentId], group.ID)
+		}
+	}
+
+	users, err := c.cli.ListUsers()
+	if err != nil {
+		return models.EnvironmentComparison{}, fmt.Errorf("failed to list users: %w", err)
+	}
+	usernames := make(map[int]string, len(users))
+	for _, user := range users {
+		usernames[int(user.ID)] = user.Username
+	}
+
+	teams, err := c.cli.ListTeams()
+	if err != nil {
+		return models.EnvironmentComparison{}, fmt.Errorf("failed to list teams: %w", err)
+	}
+	teamNames := make(map[int]string, len(teams))
+	for _, team := range teams {
+		teamNames[int(team.ID)] = team.Name
+	}
+
+	env1 := models.ConvertEndpointToEnvironment(first)
+	env2 := models.ConvertEndpointToEnvironment(second)
+
+	comparison := models.EnvironmentComparison{
+		First:             models.ComparedEnvironment{ID: env1.ID, Name: env1.Name, Type: env1.Type},
+		Second:            models.ComparedEnvironment{ID: env2.ID, Name: env2.Name, Type: env2.Type},
+		Tags:              compareItemSets(env1.TagIds, env2.TagIds, tagNames),
+		AccessGroup:       compareValues(itemName(int(first.GroupID), accessGroupNames), itemName(int(second.GroupID), accessGroupNames)),
+		EnvironmentGroups: compareItemSets(environmentGroups[env1.ID], environmentGroups[env2.ID], groupNames),
+		UserAccesses:      compareAccesses(env1.UserAccesses, env2.UserAccesses, usernames),
+		TeamAccesses:      compareAccesses(env1.TeamAccesses, env2.TeamAccesses, teamNames),
+	}
+
+	version1, ok1 := c.environmentDockerVersion(env1, &comparison.Warnings)
+	version2, ok2 := c.environmentDockerVersion(env2, &comparison.Warnings)
+	comparison.DockerVersion = compareValues(version1, version2)
+	// An unknown version cannot be reported as identical to the other one
+	comparison.DockerVersion.Same = comparison.DockerVersion.Same && ok1 && ok2
+
+	comparison.Identical = comparison.Tags.Same && comparison.AccessGroup.Same &&
+		comparison.EnvironmentGroups.Same && comparison.UserAccesses.Same &&
+		comparison.TeamAccesses.Same && comparison.DockerVersion.Same
+
+	return comparison, nil
+}
+
+// environmentDockerVersion returns the version of the Docker daemon of a Docker environment,
+// or an empty string for other environments. Failures are recorded as warnings and reported
+// with a false second return value.
+func (c *PortainerClient) environmentDockerVersion(environment models.Environment, warnings *[]string) (string, bool) {
+	if !models.IsDockerEnvironment(environment.Type) {
+		return "", true
+	}
+
+	var info dockerInfo
+	if err := c.getDockerJSON(environment.ID, "/info", nil, &info); err != nil {
+		*warnings = append(*warnings, fmt.Sprintf("failed to get the Docker version of environment %d: %v", environment.ID, err))
+		return "", false
+	}
+
+	return info.ServerVersion, true
+}
+
+// compareValues compares a single value of two environments
+func compareValues(first, second string) models.ValueComparison {
+	return models.ValueComparison{Same: first == second, First: first, Second: second}
+}
+
+// compareItemSets compares the IDs of the items found on two environments.
+// The items are named with names, unknown items are named after their ID.
+func compareItemSets(first, second []int, names map[int]string) models.ItemSetComparison {
+	comparison := models.ItemSetComparison{
+		OnlyInFirst:  []models.ComparedItem{},
+		OnlyInSecond: []models.ComparedItem{},
+		InBoth:       []models.ComparedItem{},
+	}
+
+	for _, id := range uniqueSortedIDs(first) {
+		item := models.ComparedItem{ID: id, Name: itemName(id, names)}
+		if slices.Contains(second, id) {
+			comparison.InBoth = append(comparison.InBoth, item)
+		} else {
+			comparison.OnlyInFirst = append(comparison.OnlyInFirst, item)
+		}
+	}
+
+	for _, id := range uniqueSortedIDs(second) {
+		if !slices.Contains(first, id) {
+			comparison.OnlyInSecond = append(comparison.OnlyInSecond, models.ComparedItem{ID: id, Name: itemName(id, names)})
+		}
+	}
+
+	for _, items := range [][]models.ComparedItem{comparison.OnlyInFirst, comparison.OnlyInSecond, comparison.InBoth} {
+		sortComparedItems(items)
+	}
+
+	comparison.Same = len(comparison.OnlyInFirst) == 0 && len(comparison.OnlyInSecond) == 0
+
+	return comparison
+}
+
+// compareAccesses returns the users or teams whose access level differs between two access maps
+func compareAccesses(first, second map[int]string, names map[int]string) models.AccessComparison {
+	comparison := models.AccessComparison{
+		Differences: []models.AccessDifference{},
+	}
+
+	ids := make([]int, 0, len(first)+len(second))
+	for id := range first {
+		ids = append(ids, id)
+	}
+	for id := range second {
+		ids = append(ids, id)
+	}
+
+	for _, id := range uniqueSortedIDs(ids) {
+		if first[id] == second[id] {
+			continue
+		}
+
+		comparison.Differences = append(comparison.Differences, models.AccessDifference{
+			ID:     id,
+			Name:   itemName(id, names),
+			First:  first[id],
+			Second: second[id],
+		})
+	}
+
+	slices.SortStableFunc(comparison.Differences, func(a, b models.AccessDifference) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	comparison.Same = len(comparison.Differences) == 0
+
+	return comparison
+}
+
+// itemName returns the name of an item, falling back to its ID when it is unknown
+func itemName(id int, names map[int]string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d", id)
+}
+
+// sortComparedItems sorts items by name, keeping the ID order for identical names
+func sortComparedItems(items []models.ComparedItem) {
+	slices.SortStableFunc(items, func(a, b models.ComparedItem) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
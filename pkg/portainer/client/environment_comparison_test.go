@@ -0,0 +1,205 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCompareEnvironments(t *testing.T) {
+	prod := &apimodels.PortainereeEndpoint{
+		ID:                 1,
+		Name:               "prod",
+		Type:               2,
+		GroupID:            2,
+		TagIds:             []int64{1, 2},
+		UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"2": {RoleID: 1}},
+		TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"1": {RoleID: 4}},
+	}
+	staging := &apimodels.PortainereeEndpoint{
+		ID:                 2,
+		Name:               "staging",
+		Type:               2,
+		GroupID:            3,
+		TagIds:             []int64{2, 3},
+		UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"2": {RoleID: 3}, "3": {RoleID: 4}},
+		TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"1": {RoleID: 4}},
+	}
+
+	mockTags := []*apimodels.PortainerTag{{ID: 1, Name: "critical"}, {ID: 2, Name: "linux"}, {ID: 3, Name: "testing"}}
+	mockAccessGroups := []*apimodels.PortainerEndpointGroup{{ID: 2, Name: "production"}, {ID: 3, Name: "staging"}}
+	mockEdgeGroups := []*apimodels.EdgegroupsDecoratedEdgeGroup{
+		{ID: 1, Name: "all", Endpoints: []int64{1, 2}},
+		{ID: 2, Name: "canary", Endpoints: []int64{2}},
+	}
+	mockUsers := []*apimodels.PortainereeUser{{ID: 2, Username: "alice"}, {ID: 3, Username: "bob"}}
+	mockTeams := []*apimodels.PortainerTeam{{ID: 1, Name: "devs"}}
+
+	tests := []struct {
+		name          string
+		id1           int
+		id2           int
+		dockerErr     error
+		expected      models.EnvironmentComparison
+		expectedError string
+	}{
+		{
+			name: "environments differ",
+			id1:  1,
+			id2:  2,
+			expected: models.EnvironmentComparison{
+				First:  models.ComparedEnvironment{ID: 1, Name: "prod", Type: models.EnvironmentTypeDockerAgent},
+				Second: models.ComparedEnvironment{ID: 2, Name: "staging", Type: models.EnvironmentTypeDockerAgent},
+				Tags: models.ItemSetComparison{
+					OnlyInFirst:  []models.ComparedItem{{ID: 1, Name: "critical"}},
+					OnlyInSecond: []models.ComparedItem{{ID: 3, Name: "testing"}},
+					InBoth:       []models.ComparedItem{{ID: 2, Name: "linux"}},
+				},
+				AccessGroup: models.ValueComparison{First: "production", Second: "staging"},
+				EnvironmentGroups: models.ItemSetComparison{
+					OnlyInFirst:  []models.ComparedItem{},
+					OnlyInSecond: []models.ComparedItem{{ID: 2, Name: "canary"}},
+					InBoth:       []models.ComparedItem{{ID: 1, Name: "all"}},
+				},
+				UserAccesses: models.AccessComparison{
+					Differences: []models.AccessDifference{
+						{ID: 2, Name: "alice", First: models.AccessLevelEnvironmentAdmin, Second: models.AccessLevelStandardUser},
+						{ID: 3, Name: "bob", First: "", Second: models.AccessLevelReadonlyUser},
+					},
+				},
+				TeamAccesses: models.AccessComparison{
+					Same:        true,
+					Differences: []models.AccessDifference{},
+				},
+				DockerVersion: models.ValueComparison{Same: true, First: "27.3.1", Second: "27.3.1"},
+			},
+		},
+		{
+			name:      "docker daemon unreachable",
+			id1:       1,
+			id2:       2,
+			dockerErr: errors.New("connection refused"),
+			expected: models.EnvironmentComparison{
+				First:  models.ComparedEnvironment{ID: 1, Name: "prod", Type: models.EnvironmentTypeDockerAgent},
+				Second: models.ComparedEnvironment{ID: 2, Name: "staging", Type: models.EnvironmentTypeDockerAgent},
+				Tags: models.ItemSetComparison{
+					OnlyInFirst:  []models.ComparedItem{{ID: 1, Name: "critical"}},
+					OnlyInSecond: []models.ComparedItem{{ID: 3, Name: "testing"}},
+					InBoth:       []models.ComparedItem{{ID: 2, Name: "linux"}},
+				},
+				AccessGroup: models.ValueComparison{First: "production", Second: "staging"},
+				EnvironmentGroups: models.ItemSetComparison{
+					OnlyInFirst:  []models.ComparedItem{},
+					OnlyInSecond: []models.ComparedItem{{ID: 2, Name: "canary"}},
+					InBoth:       []models.ComparedItem{{ID: 1, Name: "all"}},
+				},
+				UserAccesses: models.AccessComparison{
+					Differences: []models.AccessDifference{
+						{ID: 2, Name: "alice", First: models.AccessLevelEnvironmentAdmin, Second: models.AccessLevelStandardUser},
+						{ID: 3, Name: "bob", First: "", Second: models.AccessLevelReadonlyUser},
+					},
+				},
+				TeamAccesses: models.AccessComparison{
+					Same:        true,
+					Differences: []models.AccessDifference{},
+				},
+				DockerVersion: models.ValueComparison{},
+				Warnings: []string{
+					"failed to get the Docker version of environment 1: connection refused",
+					"failed to get the Docker version of environment 2: connection refused",
+				},
+			},
+		},
+		{
+			name:          "same environment",
+			id1:           1,
+			id2:           1,
+			expectedError: "cannot compare environment 1 with itself",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectedError == "" {
+				mockAPI.On("GetEndpoint", int64(1)).Return(prod, nil)
+				mockAPI.On("GetEndpoint", int64(2)).Return(staging, nil)
+				mockAPI.On("ListTags").Return(mockTags, nil)
+				mockAPI.On("ListEndpointGroups").Return(mockAccessGroups, nil)
+				mockAPI.On("ListEdgeGroups").Return(mockEdgeGroups, nil)
+				mockAPI.On("ListUsers").Return(mockUsers, nil)
+				mockAPI.On("ListTeams").Return(mockTeams, nil)
+				for _, id := range []int{1, 2} {
+					var resp *http.Response
+					if tt.dockerErr == nil {
+						resp = &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(strings.NewReader(`{"ServerVersion":"27.3.1"}`)),
+						}
+					}
+					mockAPI.On("ProxyDockerRequest", id, client.ProxyRequestOptions{
+						Method:  http.MethodGet,
+						APIPath: "/info",
+					}).Return(resp, tt.dockerErr).Once()
+				}
+			}
+
+			c := &PortainerClient{cli: mockAPI}
+
+			comparison, err := c.CompareEnvironments(tt.id1, tt.id2)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, comparison)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompareEnvironmentsIdentical(t *testing.T) {
+	endpoint := func(id int64) *apimodels.PortainereeEndpoint {
+		return &apimodels.PortainereeEndpoint{ID: id, Name: "k8s", Type: 5, GroupID: 1, TagIds: []int64{1}}
+	}
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("GetEndpoint", int64(1)).Return(endpoint(1), nil)
+	mockAPI.On("GetEndpoint", int64(2)).Return(endpoint(2), nil)
+	mockAPI.On("ListTags").Return([]*apimodels.PortainerTag{{ID: 1, Name: "linux"}}, nil)
+	mockAPI.On("ListEndpointGroups").Return([]*apimodels.PortainerEndpointGroup{{ID: 1, Name: "Unassigned"}}, nil)
+	mockAPI.On("ListEdgeGroups").Return([]*apimodels.EdgegroupsDecoratedEdgeGroup{}, nil)
+	mockAPI.On("ListUsers").Return([]*apimodels.PortainereeUser{}, nil)
+	mockAPI.On("ListTeams").Return([]*apimodels.PortainerTeam{}, nil)
+
+	c := &PortainerClient{cli: mockAPI}
+
+	comparison, err := c.CompareEnvironments(1, 2)
+
+	assert.NoError(t, err)
+	assert.True(t, comparison.Identical)
+	assert.Equal(t, models.ValueComparison{Same: true}, comparison.DockerVersion)
+	mockAPI.AssertNotCalled(t, "ProxyDockerRequest", mock.Anything, mock.Anything)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCompareEnvironmentsError(t *testing.T) {
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1}, nil)
+	mockAPI.On("GetEndpoint", int64(2)).Return(nil, errors.New("endpoint not found"))
+
+	c := &PortainerClient{cli: mockAPI}
+
+	_, err := c.CompareEnvironments(1, 2)
+
+	assert.ErrorContains(t, err, "failed to get endpoint 2: endpoint not found")
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// PlanEnvironmentConfig compares the tags, access group and access maps of an environment
+// to a desired state and returns the changes required to reach it, without applying them.
+// Only the fields set in the desired state are compared.
+//
+// The desired state is validated the same way it is when applied: every tag, access group,
+// user and team must exist and every access level must be valid.
+//
+// Parameters:
+//   - id: The ID of the environment
+//   - desired: The desired state of the environment
+//
+// Returns:
+//   - The changes required to bring the environment to the desired state
+//   - An error if the desired state is invalid or if the operation fails
+func (c *PortainerClient) PlanEnvironmentConfig(id int, desired models.EnvironmentDesiredState) (models.ConfigDiff, error) {
+	if err := c.validateEnvironmentDesiredState(desired); err != nil {
+		return models.ConfigDiff{}, fmt.Errorf("invalid desired state: %w", err)
+	}
+
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return models.ConfigDiff{}, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+
+	return diffEnvironmentConfig(environment, int(endpoint.GroupID), desired), nil
+}
+
+// ApplyEnvironmentConfig brings an environment to a desired state. The changes are planned
+// with PlanEnvironmentConfig first, nothing is sent to Portainer when the environment is already
+// in sync or when the desired state is invalid.
+// The tags and access maps are updated in a single request, the access group is changed afterwards.
+//
+// Parameters:
+//   - id: The ID of the environment
+//   - desired: The desired state of the environment
+//
+// Returns:
+//   - The changes that were applied
+//   - An error if the desired state is invalid or if the operation fails
+func (c *PortainerClient) ApplyEnvironmentConfig(id int, desired models.EnvironmentDesiredState) (models.ConfigDiff, error) {
+	diff, err := c.PlanEnvironmentConfig(id, desired)
+	if err != nil {
+		return models.ConfigDiff{}, err
+	}
+
+	if diff.InSync {
+		return diff, nil
+	}
+
+	var (
+		tagIds       *[]int64
+		userAccesses *map[int64]string
+		teamAccesses *map[int64]string
+	)
+
+	if len(diff.TagsAdded) > 0 || len(diff.TagsRemoved) > 0 {
+		tags := utils.IntToInt64Slice(uniqueSortedIDs(desired.TagIds))
+		tagIds = &tags
+	}
+
+	if !diff.UserAccesses.IsEmpty() {
+		uac := utils.IntToInt64Map(desired.UserAccesses)
+		userAccesses = &uac
+	}
+
+	if !diff.TeamAccesses.IsEmpty() {
+		tac := utils.IntToInt64Map(desired.TeamAccesses)
+		teamAccesses = &tac
+	}
+
+	if tagIds != nil || userAccesses != nil || teamAccesses != nil {
+		if err := c.cli.UpdateEndpoint(int64(id), tagIds, userAccesses, teamAccesses); err != nil {
+			return models.ConfigDiff{}, fmt.Errorf("failed to update environment: %w", err)
+		}
+	}
+
+	if diff.AccessGroupChange != nil {
+		if err := c.cli.AddEnvironmentToEndpointGroup(int64(diff.AccessGroupChange.To), int64(id)); err != nil {
+			return models.ConfigDiff{}, fmt.Errorf("failed to move environment to access group %d: %w", diff.AccessGroupChange.To, err)
+		}
+	}
+
+	return diff, nil
+}
+
+// validateEnvironmentDesiredState checks that every tag, access group, user and team referenced
+// by the desired state exists and that every access level is valid.
+func (c *PortainerClient) validateEnvironmentDesiredState(desired models.EnvironmentDesiredState) error {
+	if len(desired.TagIds) > 0 {
+		tags, err := c.cli.ListTags()
+		if err != nil {
+			return fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		existing := make(map[int]bool, len(tags))
+		for _, tag := range tags {
+			existing[int(tag.ID)] = true
+		}
+
+		for _, id := range uniqueSortedIDs(desired.TagIds) {
+			if !existing[id] {
+				return fmt.Errorf("tag %d does not exist", id)
+			}
+		}
+	}
+
+	if desired.AccessGroupID != nil {
+		groups, err := c.cli.ListEndpointGroups()
+		if err != nil {
+			return fmt.Errorf("failed to list access groups: %w", err)
+		}
+
+		found := slices.ContainsFunc(groups, func(group *apimodels.PortainerEndpointGroup) bool {
+			return int(group.ID) == *desired.AccessGroupID
+		})
+		if !found {
+			return fmt.Errorf("access group %d does not exist", *desired.AccessGroupID)
+		}
+	}
+
+	if err := c.validateUserAccesses(desired.UserAccesses); err != nil {
+		return err
+	}
+
+	return c.validateTeamAccesses(desired.TeamAccesses)
+}
+
+// diffEnvironmentConfig computes the changes required to bring an environment to a desired state.
+// The environment is in sync when no change is required.
+func diffEnvironmentConfig(environment models.Environment, accessGroupID int, desired models.EnvironmentDesiredState) models.ConfigDiff {
+	diff := models.ConfigDiff{
+		EnvironmentID: environment.ID,
+		TagsAdded:     []int{},
+		TagsRemoved:   []int{},
+		UserAccesses:  diffAccessMaps(nil, nil),
+		TeamAccesses:  diffAccessMaps(nil, nil),
+	}
+
+	if desired.TagIds != nil {
+		diff.TagsAdded, diff.TagsRemoved = diffIDs(environment.TagIds, desired.TagIds)
+	}
+
+	if desired.AccessGroupID != nil && *desired.AccessGroupID != accessGroupID {
+		diff.AccessGroupChange = &models.AccessGroupChange{From: accessGroupID, To: *desired.AccessGroupID}
+	}
+
+	if desired.UserAccesses != nil {
+		diff.UserAccesses = diffAccessMaps(environment.UserAccesses, desired.UserAccesses)
+	}
+
+	if desired.TeamAccesses != nil {
+		diff.TeamAccesses = diffAccessMaps(environment.TeamAccesses, desired.TeamAccesses)
+	}
+
+	diff.InSync = len(diff.TagsAdded) == 0 && len(diff.TagsRemoved) == 0 &&
+		diff.AccessGroupChange == nil &&
+		diff.UserAccesses.IsEmpty() && diff.TeamAccesses.IsEmpty()
+
+	return diff
+}
+
+// diffIDs returns the IDs present in desired but not in current, and the IDs present in current
+// but not in desired, both in ascending order.
+func diffIDs(current, desired []int) (added, removed []int) {
+	added, removed = []int{}, []int{}
+
+	for _, id := range uniqueSortedIDs(desired) {
+		if !slices.Contains(current, id) {
+			added = append(added, id)
+		}
+	}
+
+	for _, id := range uniqueSortedIDs(current) {
+		if !slices.Contains(desired, id) {
+			removed = append(removed, id)
+		}
+	}
+
+	return added, removed
+}
+
+// diffAccessMaps returns the accesses added, removed and changed between the current and the desired access maps.
+func diffAccessMaps(current, desired map[int]string) models.AccessMapDiff {
+	diff := models.AccessMapDiff{
+		Added:   map[int]string{},
+		Removed: map[int]string{},
+		Changed: map[int]models.AccessLevelChange{},
+	}
+
+	for id, access := range desired {
+		currentAccess, ok := current[id]
+		switch {
+		case !ok:
+			diff.Added[id] = access
+		case currentAccess != access:
+			diff.Changed[id] = models.AccessLevelChange{From: currentAccess, To: access}
+		}
+	}
+
+	for id, access := range current {
+		if _, ok := desired[id]; !ok {
+			diff.Removed[id] = access
+		}
+	}
+
+	return diff
+}
+
+// uniqueSortedIDs returns a sorted copy of the IDs without duplicates.
+func uniqueSortedIDs(ids []int) []int {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
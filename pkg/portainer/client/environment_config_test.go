@@ -0,0 +1,317 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiffEnvironmentConfig(t *testing.T) {
+	environment := models.Environment{
+		ID:           1,
+		TagIds:       []int{1, 2},
+		UserAccesses: map[int]string{1: models.AccessLevelEnvironmentAdmin, 2: models.AccessLevelStandardUser},
+		TeamAccesses: map[int]string{1: models.AccessLevelReadonlyUser},
+	}
+	groupID := 3
+
+	tests := []struct {
+		name     string
+		desired  models.EnvironmentDesiredState
+		expected models.ConfigDiff
+	}{
+		{
+			name:    "nothing managed",
+			desired: models.EnvironmentDesiredState{},
+			expected: models.ConfigDiff{
+				EnvironmentID: 1,
+				InSync:        true,
+				TagsAdded:     []int{},
+				TagsRemoved:   []int{},
+				UserAccesses:  emptyAccessMapDiff(),
+				TeamAccesses:  emptyAccessMapDiff(),
+			},
+		},
+		{
+			name: "in sync",
+			desired: models.EnvironmentDesiredState{
+				TagIds:        []int{2, 1, 2},
+				AccessGroupID: &groupID,
+				UserAccesses:  map[int]string{1: models.AccessLevelEnvironmentAdmin, 2: models.AccessLevelStandardUser},
+				TeamAccesses:  map[int]string{1: models.AccessLevelReadonlyUser},
+			},
+			expected: models.ConfigDiff{
+				EnvironmentID: 1,
+				InSync:        true,
+				TagsAdded:     []int{},
+				TagsRemoved:   []int{},
+				UserAccesses:  emptyAccessMapDiff(),
+				TeamAccesses:  emptyAccessMapDiff(),
+			},
+		},
+		{
+			name: "all changes",
+			desired: models.EnvironmentDesiredState{
+				TagIds:        []int{2, 4, 3},
+				AccessGroupID: intPtr(5),
+				UserAccesses:  map[int]string{1: models.AccessLevelOperatorUser, 3: models.AccessLevelReadonlyUser},
+				TeamAccesses:  map[int]string{},
+			},
+			expected: models.ConfigDiff{
+				EnvironmentID:     1,
+				TagsAdded:         []int{3, 4},
+				TagsRemoved:       []int{1},
+				AccessGroupChange: &models.AccessGroupChange{From: 3, To: 5},
+				UserAccesses: models.AccessMapDiff{
+					Added:   map[int]string{3: models.AccessLevelReadonlyUser},
+					Removed: map[int]string{2: models.AccessLevelStandardUser},
+					Changed: map[int]models.AccessLevelChange{
+						1: {From: models.AccessLevelEnvironmentAdmin, To: models.AccessLevelOperatorUser},
+					},
+				},
+				TeamAccesses: models.AccessMapDiff{
+					Added:   map[int]string{},
+					Removed: map[int]string{1: models.AccessLevelReadonlyUser},
+					Changed: map[int]models.AccessLevelChange{},
+				},
+			},
+		},
+		{
+			name:    "remove all tags",
+			desired: models.EnvironmentDesiredState{TagIds: []int{}},
+			expected: models.ConfigDiff{
+				EnvironmentID: 1,
+				TagsAdded:     []int{},
+				TagsRemoved:   []int{1, 2},
+				UserAccesses:  emptyAccessMapDiff(),
+				TeamAccesses:  emptyAccessMapDiff(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffEnvironmentConfig(environment, groupID, tt.desired))
+		})
+	}
+}
+
+func TestPlanEnvironmentConfig(t *testing.T) {
+	mockEndpoint := &apimodels.PortainereeEndpoint{
+		ID:      1,
+		GroupID: 1,
+		TagIds:  []int64{1},
+		UserAccessPolicies: apimodels.PortainerUserAccessPolicies{
+			"1": apimodels.PortainerAccessPolicy{RoleID: 3}, // standard_user
+		},
+	}
+
+	tests := []struct {
+		name           string
+		desired        models.EnvironmentDesiredState
+		expectEndpoint bool
+		mockGetError   error
+		expected       models.ConfigDiff
+		expectedError  string
+	}{
+		{
+			name: "successful plan",
+			desired: models.EnvironmentDesiredState{
+				TagIds:        []int{2},
+				AccessGroupID: intPtr(2),
+				UserAccesses:  map[int]string{1: models.AccessLevelReadonlyUser},
+			},
+			expectEndpoint: true,
+			expected: models.ConfigDiff{
+				EnvironmentID:     1,
+				TagsAdded:         []int{2},
+				TagsRemoved:       []int{1},
+				AccessGroupChange: &models.AccessGroupChange{From: 1, To: 2},
+				UserAccesses: models.AccessMapDiff{
+					Added:   map[int]string{},
+					Removed: map[int]string{},
+					Changed: map[int]models.AccessLevelChange{
+						1: {From: models.AccessLevelStandardUser, To: models.AccessLevelReadonlyUser},
+					},
+				},
+				TeamAccesses: emptyAccessMapDiff(),
+			},
+		},
+		{
+			name:          "unknown tag",
+			desired:       models.EnvironmentDesiredState{TagIds: []int{9}},
+			expectedError: "tag 9 does not exist",
+		},
+		{
+			name:          "unknown access group",
+			desired:       models.EnvironmentDesiredState{AccessGroupID: intPtr(9)},
+			expectedError: "access group 9 does not exist",
+		},
+		{
+			name:          "unknown user",
+			desired:       models.EnvironmentDesiredState{UserAccesses: map[int]string{9: models.AccessLevelReadonlyUser}},
+			expectedError: "user 9 does not exist",
+		},
+		{
+			name:          "invalid access level",
+			desired:       models.EnvironmentDesiredState{TeamAccesses: map[int]string{1: "superuser"}},
+			expectedError: "invalid access level",
+		},
+		{
+			name:           "get endpoint error",
+			desired:        models.EnvironmentDesiredState{},
+			expectEndpoint: true,
+			mockGetError:   errors.New("not found"),
+			expectedError:  "failed to get endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := newEnvironmentConfigMockAPI()
+			if tt.expectEndpoint {
+				mockAPI.On("GetEndpoint", int64(1)).Return(mockEndpoint, tt.mockGetError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			diff, err := client.PlanEnvironmentConfig(1, tt.desired)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, diff)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApplyEnvironmentConfig(t *testing.T) {
+	mockEndpoint := &apimodels.PortainereeEndpoint{
+		ID:      1,
+		GroupID: 1,
+		TagIds:  []int64{1},
+		TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+			"1": apimodels.PortainerAccessPolicy{RoleID: 4}, // readonly_user
+		},
+	}
+
+	tests := []struct {
+		name           string
+		desired        models.EnvironmentDesiredState
+		expectUpdate   bool
+		expectedTags   *[]int64
+		expectedUsers  *map[int64]string
+		expectedTeams  *map[int64]string
+		mockUpdateErr  error
+		expectGroup    bool
+		mockGroupErr   error
+		expectedInSync bool
+		expectedError  bool
+	}{
+		{
+			name:           "in sync",
+			desired:        models.EnvironmentDesiredState{TagIds: []int{1}, AccessGroupID: intPtr(1)},
+			expectedInSync: true,
+		},
+		{
+			name: "update tags and team accesses only",
+			desired: models.EnvironmentDesiredState{
+				TagIds:        []int{2, 1, 2},
+				AccessGroupID: intPtr(1),
+				UserAccesses:  map[int]string{},
+				TeamAccesses:  map[int]string{1: models.AccessLevelOperatorUser},
+			},
+			expectUpdate:  true,
+			expectedTags:  &[]int64{1, 2},
+			expectedTeams: &map[int64]string{1: models.AccessLevelOperatorUser},
+		},
+		{
+			name:        "move to access group",
+			desired:     models.EnvironmentDesiredState{AccessGroupID: intPtr(2)},
+			expectGroup: true,
+		},
+		{
+			name:          "update error",
+			desired:       models.EnvironmentDesiredState{TagIds: []int{2}, AccessGroupID: intPtr(2)},
+			expectUpdate:  true,
+			expectedTags:  &[]int64{2},
+			mockUpdateErr: errors.New("failed to update endpoint"),
+			expectedError: true,
+		},
+		{
+			name:          "access group error",
+			desired:       models.EnvironmentDesiredState{AccessGroupID: intPtr(2)},
+			expectGroup:   true,
+			mockGroupErr:  errors.New("failed to add environment"),
+			expectedError: true,
+		},
+		{
+			name:          "invalid desired state",
+			desired:       models.EnvironmentDesiredState{TagIds: []int{9}},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := newEnvironmentConfigMockAPI()
+			mockAPI.On("GetEndpoint", int64(1)).Return(mockEndpoint, nil).Maybe()
+			if tt.expectUpdate {
+				mockAPI.On("UpdateEndpoint", int64(1), tt.expectedTags, tt.expectedUsers, tt.expectedTeams).Return(tt.mockUpdateErr)
+			}
+			if tt.expectGroup {
+				mockAPI.On("AddEnvironmentToEndpointGroup", int64(2), int64(1)).Return(tt.mockGroupErr)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			diff, err := client.ApplyEnvironmentConfig(1, tt.desired)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedInSync, diff.InSync)
+			}
+
+			if !tt.expectUpdate {
+				mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if !tt.expectGroup {
+				mockAPI.AssertNotCalled(t, "AddEnvironmentToEndpointGroup", mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+// newEnvironmentConfigMockAPI returns a mock API knowing tags 1 and 2, access groups 1 and 2,
+// user 1 and team 1, used to validate desired states.
+func newEnvironmentConfigMockAPI() *MockPortainerAPI {
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("ListTags").Return([]*apimodels.PortainerTag{{ID: 1}, {ID: 2}}, nil).Maybe()
+	mockAPI.On("ListEndpointGroups").Return([]*apimodels.PortainerEndpointGroup{{ID: 1}, {ID: 2}}, nil).Maybe()
+	mockAPI.On("ListUsers").Return([]*apimodels.PortainereeUser{{ID: 1}}, nil).Maybe()
+	mockAPI.On("ListTeams").Return([]*apimodels.PortainerTeam{{ID: 1}}, nil).Maybe()
+	return mockAPI
+}
+
+func emptyAccessMapDiff() models.AccessMapDiff {
+	return models.AccessMapDiff{
+		Added:   map[int]string{},
+		Removed: map[int]string{},
+		Changed: map[int]models.AccessLevelChange{},
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// DetachEnvironment detaches an environment from everything that references it, to prepare its deletion.
+// The steps are applied in the following order:
+//  1. The environment is removed from the static environment groups (Edge Groups) it belongs to
+//  2. The environment is moved out of its access group, to the Unassigned access group
+//  3. The tags, the user accesses and the team accesses of the environment are cleared in a single request
+//
+// A failing step does not stop the next ones, so that as much as possible is detached. The environment
+// itself is left in place.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - A report of the steps that succeeded
+//   - An EnvironmentDetachError if some steps failed, the report lists the steps that were applied
+//   - An error if the environment could not be retrieved, nothing is changed in this case
+func (c *PortainerClient) DetachEnvironment(id int) (models.EnvironmentDetachReport, error) {
+	report := models.EnvironmentDetachReport{
+		EnvironmentID:              id,
+		RemovedEnvironmentGroupIds: []int{},
+		RemovedTagIds:              []int{},
+		RemovedUserAccesses:        []int{},
+		RemovedTeamAccesses:        []int{},
+	}
+
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return report, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	detachErr := &EnvironmentDetachError{EnvironmentID: id}
+
+	edgeGroups, err := c.cli.ListEdgeGroups()
+	if err != nil {
+		detachErr.Failed = append(detachErr.Failed, fmt.Errorf("failed to list environment groups: %w", err))
+	}
+	for _, group := range edgeGroups {
+		if group.Dynamic || !slices.Contains(group.Endpoints, int64(id)) {
+			continue
+		}
+
+		remaining := slices.DeleteFunc(slices.Clone(group.Endpoints), func(environmentId int64) bool {
+			return environmentId == int64(id)
+		})
+		if err := c.cli.UpdateEdgeGroup(group.ID, nil, &remaining, nil); err != nil {
+			detachErr.Failed = append(detachErr.Failed, fmt.Errorf("failed to remove environment from environment group %d: %w", group.ID, err))
+			continue
+		}
+		report.RemovedEnvironmentGroupIds = append(report.RemovedEnvironmentGroupIds, int(group.ID))
+	}
+
+	if endpoint.GroupID != unassignedAccessGroupID {
+		if err := c.cli.RemoveEnvironmentFromEndpointGroup(endpoint.GroupID, int64(id)); err != nil {
+			detachErr.Failed = append(detachErr.Failed, fmt.Errorf("failed to remove environment from access group %d: %w", endpoint.GroupID, err))
+		} else {
+			report.RemovedAccessGroupID = int(endpoint.GroupID)
+		}
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if len(environment.TagIds) > 0 || len(environment.UserAccesses) > 0 || len(environment.TeamAccesses) > 0 {
+		tagIds := []int64{}
+		userAccesses := map[int64]string{}
+		teamAccesses := map[int64]string{}
+
+		if err := c.cli.UpdateEndpoint(int64(id), &tagIds, &userAccesses, &teamAccesses); err != nil {
+			detachErr.Failed = append(detachErr.Failed, fmt.Errorf("failed to clear environment tags and accesses: %w", err))
+		} else {
+			report.RemovedTagIds = utils.Int64ToIntSlice(endpoint.TagIds)
+			report.RemovedUserAccesses = sortedAccessIDs(environment.UserAccesses)
+			report.RemovedTeamAccesses = sortedAccessIDs(environment.TeamAccesses)
+		}
+	}
+
+	if len(detachErr.Failed) > 0 {
+		return report, detachErr
+	}
+
+	return report, nil
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetachEnvironment(t *testing.T) {
+	mockEndpoint := &apimodels.PortainereeEndpoint{
+		ID:      3,
+		GroupID: 2,
+		TagIds:  []int64{1, 4},
+		UserAccessPolicies: apimodels.PortainerUserAccessPolicies{
+			"5": apimodels.PortainerAccessPolicy{RoleID: 3},
+			"2": apimodels.PortainerAccessPolicy{RoleID: 1},
+		},
+		TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+			"7": apimodels.PortainerAccessPolicy{RoleID: 4},
+		},
+	}
+	mockEdgeGroups := []*apimodels.EdgegroupsDecoratedEdgeGroup{
+		{ID: 1, Endpoints: []int64{1, 3, 5}},
+		{ID: 2, Endpoints: []int64{1}},
+		{ID: 3, Dynamic: true, Endpoints: []int64{3}, TagIds: []int64{4}},
+		{ID: 4, Endpoints: []int64{3}},
+	}
+	emptyTags := []int64{}
+	emptyAccesses := map[int64]string{}
+
+	t.Run("successful detach", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(3)).Return(mockEndpoint, nil)
+		mockAPI.On("ListEdgeGroups").Return(mockEdgeGroups, nil)
+		mockAPI.On("UpdateEdgeGroup", int64(1), (*string)(nil), &[]int64{1, 5}, (*[]int64)(nil)).Return(nil)
+		mockAPI.On("UpdateEdgeGroup", int64(4), (*string)(nil), &[]int64{}, (*[]int64)(nil)).Return(nil)
+		mockAPI.On("RemoveEnvironmentFromEndpointGroup", int64(2), int64(3)).Return(nil)
+		mockAPI.On("UpdateEndpoint", int64(3), &emptyTags, &emptyAccesses, &emptyAccesses).Return(nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		report, err := client.DetachEnvironment(3)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.EnvironmentDetachReport{
+			EnvironmentID:              3,
+			RemovedEnvironmentGroupIds: []int{1, 4},
+			RemovedAccessGroupID:       2,
+			RemovedTagIds:              []int{1, 4},
+			RemovedUserAccesses:        []int{2, 5},
+			RemovedTeamAccesses:        []int{7},
+		}, report)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("nothing to detach", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(3)).Return(&apimodels.PortainereeEndpoint{ID: 3, GroupID: 1}, nil)
+		mockAPI.On("ListEdgeGroups").Return([]*apimodels.EdgegroupsDecoratedEdgeGroup{}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		report, err := client.DetachEnvironment(3)
+
+		require.NoError(t, err)
+		assert.Empty(t, report.RemovedEnvironmentGroupIds)
+		assert.Zero(t, report.RemovedAccessGroupID)
+		assert.Empty(t, report.RemovedTagIds)
+		mockAPI.AssertNotCalled(t, "RemoveEnvironmentFromEndpointGroup", mock.Anything, mock.Anything)
+		mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed steps do not stop the next ones", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(3)).Return(mockEndpoint, nil)
+		mockAPI.On("ListEdgeGroups").Return(mockEdgeGroups, nil)
+		mockAPI.On("UpdateEdgeGroup", int64(1), mock.Anything, mock.Anything, mock.Anything).Return(errors.New("edge group locked"))
+		mockAPI.On("UpdateEdgeGroup", int64(4), mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockAPI.On("RemoveEnvironmentFromEndpointGroup", int64(2), int64(3)).Return(errors.New("server error"))
+		mockAPI.On("UpdateEndpoint", int64(3), &emptyTags, &emptyAccesses, &emptyAccesses).Return(nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		report, err := client.DetachEnvironment(3)
+
+		var detachErr *EnvironmentDetachError
+		require.ErrorAs(t, err, &detachErr)
+		assert.Len(t, detachErr.Failed, 2)
+		assert.ErrorContains(t, err, "environment group 1: edge group locked")
+		assert.ErrorContains(t, err, "access group 2: server error")
+
+		assert.Equal(t, []int{4}, report.RemovedEnvironmentGroupIds)
+		assert.Zero(t, report.RemovedAccessGroupID)
+		assert.Equal(t, []int{1, 4}, report.RemovedTagIds)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("list environment groups error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(3)).Return(mockEndpoint, nil)
+		mockAPI.On("ListEdgeGroups").Return(nil, errors.New("forbidden"))
+		mockAPI.On("RemoveEnvironmentFromEndpointGroup", int64(2), int64(3)).Return(nil)
+		mockAPI.On("UpdateEndpoint", int64(3), &emptyTags, &emptyAccesses, &emptyAccesses).Return(nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		report, err := client.DetachEnvironment(3)
+
+		assert.ErrorContains(t, err, "failed to list environment groups: forbidden")
+		assert.Equal(t, 2, report.RemovedAccessGroupID)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("environment not found", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(3)).Return(nil, errors.New("not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.DetachEnvironment(3)
+
+		assert.ErrorContains(t, err, "failed to get endpoint")
+		var detachErr *EnvironmentDetachError
+		assert.False(t, errors.As(err, &detachErr))
+		mockAPI.AssertNotCalled(t, "ListEdgeGroups")
+	})
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// environmentMetadataSeparator separates the key from the value in the name of the tags holding the
+// metadata of environments, e.g. the tag "region=eu-west" holds the key region
+const environmentMetadataSeparator = "="
+
+// GetEnvironmentMetadata retrieves the metadata of an environment. Portainer has no key/value metadata
+// on environments, the metadata are stored in tags named "key=value" assigned to the environment.
+// The tags without a separator are regular tags and are not part of the metadata.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - A map of the metadata keys to their value, empty when the environment has no metadata
+//   - An error if the operation fails
+func (c *PortainerClient) GetEnvironmentMetadata(id int) (map[string]string, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	tags, err := c.cli.ListTags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tagNames := make(map[int64]string, len(tags))
+	for _, tag := range tags {
+		tagNames[tag.ID] = tag.Name
+	}
+
+	// The tags are read in ID order so that the oldest tag wins when a key was assigned twice
+	tagIds := slices.Clone(endpoint.TagIds)
+	slices.Sort(tagIds)
+
+	metadata := map[string]string{}
+	for _, tagId := range tagIds {
+		key, value, ok := parseEnvironmentMetadataTag(tagNames[tagId])
+		if !ok {
+			continue
+		}
+		if _, exists := metadata[key]; !exists {
+			metadata[key] = value
+		}
+	}
+
+	return metadata, nil
+}
+
+// UpdateEnvironmentMetadata sets metadata keys of an environment. The keys that are not mentioned are
+// left unchanged, an empty value removes a key. Each "key=value" tag is created when it does not exist
+// yet, the tags of the previous values are unassigned from the environment but not deleted as other
+// environments may use them. Regular tags of the environment are kept.
+//
+// Parameters:
+//   - id: The ID of the environment
+//   - metadata: A map of the metadata keys to set to their value
+//
+// Returns:
+//   - An error if a key is invalid or if the operation fails
+func (c *PortainerClient) UpdateEnvironmentMetadata(id int, metadata map[string]string) error {
+	if len(metadata) == 0 {
+		return fmt.Errorf("at least one metadata key is required")
+	}
+	for key := range metadata {
+		if err := validateEnvironmentMetadataKey(key); err != nil {
+			return err
+		}
+	}
+
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	tags, err := c.cli.ListTags()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tagsByName := make(map[string]*apimodels.PortainerTag, len(tags))
+	tagNames := make(map[int64]string, len(tags))
+	for _, tag := range tags {
+		tagsByName[tag.Name] = tag
+		tagNames[tag.ID] = tag.Name
+	}
+
+	tagIds := make([]int64, 0, len(endpoint.TagIds)+len(metadata))
+	for _, tagId := range endpoint.TagIds {
+		if key, _, ok := parseEnvironmentMetadataTag(tagNames[tagId]); ok {
+			if _, updated := metadata[key]; updated {
+				continue
+			}
+		}
+		tagIds = append(tagIds, tagId)
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if metadata[key] == "" {
+			continue
+		}
+
+		name := key + environmentMetadataSeparator + metadata[key]
+		if tag, exists := tagsByName[name]; exists {
+			tagIds = append(tagIds, tag.ID)
+			continue
+		}
+
+		tagId, err := c.cli.CreateTag(name)
+		if err != nil {
+			return fmt.Errorf("failed to create tag %s: %w", name, err)
+		}
+		tagIds = append(tagIds, tagId)
+	}
+
+	current := slices.Clone(endpoint.TagIds)
+	slices.Sort(current)
+	slices.Sort(tagIds)
+	if slices.Equal(current, tagIds) {
+		return nil
+	}
+
+	if err := c.cli.UpdateEndpoint(int64(id), &tagIds, nil, nil); err != nil {
+		return fmt.Errorf("failed to update environment metadata: %w", err)
+	}
+
+	return nil
+}
+
+// parseEnvironmentMetadataTag splits the name of a metadata tag into its key and value,
+// it reports false for the regular tags
+func parseEnvironmentMetadataTag(name string) (string, string, bool) {
+	key, value, found := strings.Cut(name, environmentMetadataSeparator)
+	if !found || key == "" {
+		return "", "", false
+	}
+	return key, value, true
+}
+
+// validateEnvironmentMetadataKey checks that a metadata key can be stored in the name of a tag
+func validateEnvironmentMetadataKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("metadata keys cannot be empty")
+	}
+	if strings.Contains(key, environmentMetadataSeparator) {
+		return fmt.Errorf("metadata key %s cannot contain %s", key, environmentMetadataSeparator)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testMetadataTags are the tags of the Portainer server used by the environment metadata tests
+var testMetadataTags = []*apimodels.PortainerTag{
+	{ID: 1, Name: "production"},
+	{ID: 2, Name: "region=eu-west"},
+	{ID: 3, Name: "owner=team-a"},
+	{ID: 4, Name: "region=us-east"},
+	{ID: 5, Name: "tier=gold"},
+	{ID: 6, Name: "=orphan"},
+}
+
+func TestGetEnvironmentMetadata(t *testing.T) {
+	tests := []struct {
+		name          string
+		tagIds        []int64
+		mockTagsError error
+		expected      map[string]string
+		expectedError string
+	}{
+		{
+			name:     "metadata and regular tags",
+			tagIds:   []int64{1, 3, 2, 6},
+			expected: map[string]string{"region": "eu-west", "owner": "team-a"},
+		},
+		{
+			name:     "key assigned twice",
+			tagIds:   []int64{4, 2},
+			expected: map[string]string{"region": "eu-west"},
+		},
+		{
+			name:     "no metadata",
+			tagIds:   []int64{1},
+			expected: map[string]string{},
+		},
+		{
+			name:          "list tags error",
+			tagIds:        []int64{1},
+			mockTagsError: errors.New("forbidden"),
+			expectedError: "failed to list tags: forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, TagIds: tt.tagIds}, nil)
+			if tt.mockTagsError != nil {
+				mockAPI.On("ListTags").Return(nil, tt.mockTagsError)
+			} else {
+				mockAPI.On("ListTags").Return(testMetadataTags, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			metadata, err := client.GetEnvironmentMetadata(1)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, metadata)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateEnvironmentMetadata(t *testing.T) {
+	tests := []struct {
+		name           string
+		tagIds         []int64
+		metadata       map[string]string
+		createdTags    map[string]int64
+		expectedTagIds []int64
+		expectUpdate   bool
+		expectedError  string
+	}{
+		{
+			name:           "change a key and keep the others",
+			tagIds:         []int64{1, 2, 3},
+			metadata:       map[string]string{"region": "us-east"},
+			expectedTagIds: []int64{1, 3, 4},
+			expectUpdate:   true,
+		},
+		{
+			name:           "add a key with a new tag",
+			tagIds:         []int64{1, 2},
+			metadata:       map[string]string{"zone": "b"},
+			createdTags:    map[string]int64{"zone=b": 7},
+			expectedTagIds: []int64{1, 2, 7},
+			expectUpdate:   true,
+		},
+		{
+			name:           "remove a key",
+			tagIds:         []int64{1, 2, 3},
+			metadata:       map[string]string{"owner": ""},
+			expectedTagIds: []int64{1, 2},
+			expectUpdate:   true,
+		},
+		{
+			name:     "already up to date",
+			tagIds:   []int64{1, 2, 5},
+			metadata: map[string]string{"region": "eu-west", "tier": "gold", "owner": ""},
+		},
+		{
+			name:          "key with separator",
+			metadata:      map[string]string{"a=b": "c"},
+			expectedError: "cannot contain =",
+		},
+		{
+			name:          "empty key",
+			metadata:      map[string]string{" ": "c"},
+			expectedError: "metadata keys cannot be empty",
+		},
+		{
+			name:          "no metadata",
+			metadata:      map[string]string{},
+			expectedError: "at least one metadata key is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectedError == "" {
+				mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, TagIds: tt.tagIds}, nil)
+				mockAPI.On("ListTags").Return(testMetadataTags, nil)
+			}
+			for name, id := range tt.createdTags {
+				mockAPI.On("CreateTag", name).Return(id, nil)
+			}
+			if tt.expectUpdate {
+				mockAPI.On("UpdateEndpoint", int64(1), &tt.expectedTagIds, (*map[int64]string)(nil), (*map[int64]string)(nil)).Return(nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateEnvironmentMetadata(1, tt.metadata)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				mockAPI.AssertNotCalled(t, "GetEndpoint", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			if !tt.expectUpdate {
+				mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
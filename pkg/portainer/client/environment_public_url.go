@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// GetEnvironmentPublicURL retrieves the public URL of an environment, the host Portainer uses to build
+// the links to the ports published by the containers of the environment.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - The public URL of the environment, empty when it is not set and Portainer uses the environment URL
+//   - An error if the operation fails
+func (c *PortainerClient) GetEnvironmentPublicURL(id int) (string, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return "", fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	return endpoint.PublicURL, nil
+}
+
+// UpdateEnvironmentPublicURL sets the public URL of an environment. The other settings of the
+// environment are kept.
+//
+// Parameters:
+//   - id: The ID of the environment to update
+//   - publicURL: A hostname or an IP address, optionally prefixed with the http or https scheme.
+//     Portainer appends the published ports to it, so it cannot have a port or a path.
+//
+// Returns:
+//   - An error if the public URL is invalid or if the operation fails
+func (c *PortainerClient) UpdateEnvironmentPublicURL(id int, publicURL string) error {
+	if err := validatePublicURL(publicURL); err != nil {
+		return fmt.Errorf("invalid public URL: %w", err)
+	}
+
+	if err := c.cli.UpdateEndpointPublicURL(int64(id), publicURL); err != nil {
+		return fmt.Errorf("failed to update environment public URL: %w", err)
+	}
+
+	return nil
+}
+
+// validatePublicURL checks that a public URL is a hostname or an IP address, optionally prefixed with
+// the http or https scheme
+func validatePublicURL(publicURL string) error {
+	if publicURL == "" {
+		return fmt.Errorf("the public URL cannot be empty")
+	}
+
+	host := publicURL
+	if scheme, rest, ok := strings.Cut(publicURL, "://"); ok {
+		if scheme != "http" && scheme != "https" {
+			return fmt.Errorf("unsupported scheme %q, the scheme must be http or https", scheme)
+		}
+		host = strings.TrimSuffix(rest, "/")
+	}
+
+	u, err := url.Parse("//" + host)
+	if err != nil || u.Host != host {
+		return fmt.Errorf("%q must be a hostname or an IP address, without port, path or query", publicURL)
+	}
+	if u.Port() != "" {
+		return fmt.Errorf("%q has a port, Portainer appends the published ports to the public URL", publicURL)
+	}
+
+	hostname := u.Hostname()
+	if net.ParseIP(hostname) != nil || isValidHostname(hostname) {
+		return nil
+	}
+
+	return fmt.Errorf("%q is not a valid hostname or IP address", hostname)
+}
+
+// isValidHostname checks that a hostname is made of labels of at most 63 letters, digits and hyphens,
+// not starting nor ending with a hyphen
+func isValidHostname(hostname string) bool {
+	if hostname == "" || len(hostname) > 253 || strings.HasPrefix(hostname, "[") {
+		return false
+	}
+
+	for _, label := range strings.Split(strings.TrimSuffix(hostname, "."), ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetEnvironmentPublicURL(t *testing.T) {
+	t.Run("public URL set", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, PublicURL: "docker.example.com"}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		publicURL, err := client.GetEnvironmentPublicURL(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "docker.example.com", publicURL)
+	})
+
+	t.Run("get endpoint error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(nil, errors.New("not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetEnvironmentPublicURL(1)
+
+		assert.ErrorContains(t, err, "failed to get endpoint: not found")
+	})
+}
+
+func TestUpdateEnvironmentPublicURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		publicURL     string
+		mockError     error
+		expectedError string
+	}{
+		{name: "hostname", publicURL: "docker.example.com"},
+		{name: "ipv4 address", publicURL: "192.168.1.10"},
+		{name: "ipv6 address", publicURL: "[2001:db8::1]"},
+		{name: "https scheme", publicURL: "https://docker.example.com"},
+		{name: "empty", publicURL: "", expectedError: "the public URL cannot be empty"},
+		{name: "unsupported scheme", publicURL: "ftp://docker.example.com", expectedError: `unsupported scheme "ftp"`},
+		{name: "port", publicURL: "docker.example.com:8080", expectedError: "has a port"},
+		{name: "path", publicURL: "https://docker.example.com/apps", expectedError: "without port, path or query"},
+		{name: "invalid hostname", publicURL: "docker_host.example.com", expectedError: "is not a valid hostname or IP address"},
+		{name: "whitespace", publicURL: "docker example.com", expectedError: "must be a hostname or an IP address"},
+		{name: "update error", publicURL: "docker.example.com", mockError: errors.New("forbidden"), expectedError: "failed to update environment public URL: forbidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("UpdateEndpointPublicURL", int64(1), tt.publicURL).Return(tt.mockError).Maybe()
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateEnvironmentPublicURL(1, tt.publicURL)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				if tt.mockError == nil {
+					mockAPI.AssertNotCalled(t, "UpdateEndpointPublicURL", mock.Anything, mock.Anything)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
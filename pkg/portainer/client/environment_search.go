@@ -0,0 +1,134 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// FindEnvironmentsByImage retrieves the Docker environments with a container created from an image,
+// running or stopped. The containers of each environment are listed through the Docker proxy, at most
+// maxConcurrentEnvironmentRequests environments at a time. Kubernetes and Azure environments are not searched.
+//
+// An image without a tag or a digest matches every tag of its repository, e.g. "nginx" matches "nginx:1.25"
+// and "nginx:latest", an image with a tag or a digest only matches that tag or digest, e.g. "nginx:1.25".
+// The default Docker Hub registry and namespace are ignored, so "docker.io/library/nginx" matches "nginx".
+// Matching is case-insensitive.
+//
+// Parameters:
+//   - image: The repository or the full reference of the image, e.g. "nginx" or "nginx:1.25"
+//
+// Returns:
+//   - A slice of the matching Environment objects, sorted by ID
+//   - An *EnvironmentSearchError along with the matching environments if some environments could not be searched
+//   - An error if the image is empty or if the environments cannot be listed
+func (c *PortainerClient) FindEnvironmentsByImage(image string) ([]models.Environment, error) {
+	match, err := newImageReferenceMatcher(image)
+	if err != nil {
+		return nil, err
+	}
+
+	environments, err := c.GetEnvironments()
+	if err != nil {
+		return nil, err
+	}
+
+	var docker []models.Environment
+	for _, environment := range environments {
+		if models.IsDockerEnvironment(environment.Type) {
+			docker = append(docker, environment)
+		}
+	}
+
+	matched := make([]bool, len(docker))
+	errs := make([]error, len(docker))
+
+	forEachConcurrently(len(docker), maxConcurrentEnvironmentRequests, func(i int) {
+		matched[i], errs[i] = c.environmentUsesImage(docker[i].ID, match)
+	})
+
+	result := []models.Environment{}
+	failed := make(map[int]error)
+	for i, environment := range docker {
+		if errs[i] != nil {
+			failed[environment.ID] = errs[i]
+			continue
+		}
+		if matched[i] {
+			result = append(result, environment)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	if len(failed) > 0 {
+		return result, &EnvironmentSearchError{Searched: len(docker) - len(failed), Failed: failed}
+	}
+
+	return result, nil
+}
+
+// environmentUsesImage reports whether an environment has a container created from an image accepted by match
+func (c *PortainerClient) environmentUsesImage(environmentId int, match func(string) bool) (bool, error) {
+	containers, err := c.listDockerContainers(environmentId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, container := range containers {
+		if match(container.Image) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// newImageReferenceMatcher builds a case-insensitive matcher for the references of the images of the containers.
+// An image without a tag or a digest matches the references of its repository, any other image only matches
+// its own reference.
+func newImageReferenceMatcher(image string) (func(string) bool, error) {
+	image = strings.ToLower(strings.TrimSpace(image))
+	if image == "" {
+		return nil, fmt.Errorf("image cannot be empty")
+	}
+
+	wanted := normalizeImageReference(image)
+	if imageHasTagOrDigest(image) {
+		return func(reference string) bool {
+			return normalizeImageReference(strings.ToLower(reference)) == wanted
+		}, nil
+	}
+
+	repository := imageRepository(wanted)
+	return func(reference string) bool {
+		return imageRepository(normalizeImageReference(strings.ToLower(reference))) == repository
+	}, nil
+}
+
+// imageHasTagOrDigest reports whether an image reference has a tag or a digest. The port of a registry,
+// e.g. registry.example.com:5000/api, is not a tag.
+func imageHasTagOrDigest(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+
+	return strings.Contains(image[strings.LastIndex(image, "/")+1:], ":")
+}
+
+// imageRepository returns the repository of a normalized image reference, without its tag or digest
+func imageRepository(reference string) string {
+	if repository, _, found := strings.Cut(reference, "@"); found {
+		return repository
+	}
+
+	lastSlash := strings.LastIndex(reference, "/")
+	if lastColon := strings.LastIndex(reference, ":"); lastColon > lastSlash {
+		return reference[:lastColon]
+	}
+
+	return reference
+}
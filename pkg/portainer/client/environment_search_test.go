@@ -0,0 +1,143 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEnvironmentsByImage(t *testing.T) {
+	containers := map[int]string{
+		1: `[{"Image":"nginx:1.25","State":"running"},{"Image":"redis","State":"running"}]`,
+		2: `[{"Image":"docker.io/library/nginx:latest","State":"exited"}]`,
+		4: `[{"Image":"myorg/api:2.0","State":"running"}]`,
+	}
+
+	tests := []struct {
+		name          string
+		image         string
+		unreachable   bool
+		expectedIds   []int
+		expectedError string
+	}{
+		{
+			name:        "repository matches every tag",
+			image:       "nginx",
+			expectedIds: []int{1, 2},
+		},
+		{
+			name:        "full reference matches its tag",
+			image:       "docker.io/nginx:1.25",
+			expectedIds: []int{1},
+		},
+		{
+			name:        "latest tag",
+			image:       "NGINX:latest",
+			expectedIds: []int{2},
+		},
+		{
+			name:        "no match",
+			image:       "postgres",
+			expectedIds: []int{},
+		},
+		{
+			name:          "unreachable environment",
+			image:         "nginx",
+			unreachable:   true,
+			expectedIds:   []int{1},
+			expectedError: "failed to search 1 of 3 environments (environment 2: failed to list containers: unexpected status 502",
+		},
+		{
+			name:          "empty image",
+			image:         " ",
+			expectedError: "image cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEndpoints").Return([]*apimodels.PortainereeEndpoint{
+				{ID: 4, Name: "edge", Type: 4},
+				{ID: 1, Name: "local", Type: 1},
+				{ID: 2, Name: "agent", Type: 2},
+				{ID: 5, Name: "cluster", Type: 5},
+			}, nil).Maybe()
+			for id, body := range containers {
+				status := http.StatusOK
+				if tt.unreachable && id == 2 {
+					status, body = http.StatusBadGateway, "environment unreachable"
+				}
+				mockAPI.On("ProxyDockerRequest", id, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/json"
+				})).Return(&http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil).Maybe()
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			environments, err := client.FindEnvironmentsByImage(tt.image)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.expectedIds != nil {
+				ids := make([]int, len(environments))
+				for i, environment := range environments {
+					ids[i] = environment.ID
+				}
+				assert.Equal(t, tt.expectedIds, ids)
+			}
+			mockAPI.AssertNotCalled(t, "ProxyDockerRequest", 5, mock.Anything)
+		})
+	}
+
+	t.Run("list error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.FindEnvironmentsByImage("nginx")
+
+		assert.ErrorContains(t, err, "failed to list endpoints")
+	})
+}
+
+func TestNewImageReferenceMatcher(t *testing.T) {
+	tests := []struct {
+		image     string
+		reference string
+		expected  bool
+	}{
+		{"nginx", "nginx", true},
+		{"nginx", "nginx:1.25", true},
+		{"nginx", "docker.io/library/nginx:latest", true},
+		{"nginx", "nginx-proxy:latest", false},
+		{"nginx", "myorg/nginx:latest", false},
+		{"nginx:1.25", "nginx:1.25", true},
+		{"nginx:1.25", "nginx:1.25.3", false},
+		{"nginx:latest", "nginx", true},
+		{"registry.example.com:5000/api", "registry.example.com:5000/api:2", true},
+		{"registry.example.com:5000/api:2", "registry.example.com:5000/api:3", false},
+		{"nginx@sha256:abcdef", "nginx@sha256:abcdef", true},
+		{"nginx", "nginx@sha256:abcdef", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image+" "+tt.reference, func(t *testing.T) {
+			match, err := newImageReferenceMatcher(tt.image)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, match(tt.reference))
+		})
+	}
+}
@@ -184,7 +184,9 @@ func TestUpdateEnvironmentUserAccesses(t *testing.T) {
 		envID         int
 		userAccesses  map[int]string
 		mockError     error
+		mockListError error
 		expectedError bool
+		skipUpdate    bool
 	}{
 		{
 			name:  "successful update",
@@ -211,12 +213,45 @@ func TestUpdateEnvironmentUserAccesses(t *testing.T) {
 			envID:        1,
 			userAccesses: map[int]string{},
 		},
+		{
+			name:  "invalid access level",
+			envID: 1,
+			userAccesses: map[int]string{
+				1: "environment_administrator",
+				2: "superuser",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:  "non-existent user",
+			envID: 1,
+			userAccesses: map[int]string{
+				1:  "environment_administrator",
+				99: "standard_user",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:  "list users error",
+			envID: 1,
+			userAccesses: map[int]string{
+				1: "environment_administrator",
+			},
+			mockListError: errors.New("failed to list users"),
+			expectedError: true,
+			skipUpdate:    true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAPI := new(MockPortainerAPI)
-			mockAPI.On("UpdateEndpoint", int64(tt.envID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			mockAPI.On("ListUsers").Return(existingUsers, tt.mockListError).Maybe()
+			if !tt.skipUpdate {
+				mockAPI.On("UpdateEndpoint", int64(tt.envID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			}
 
 			client := &PortainerClient{cli: mockAPI}
 
@@ -224,6 +259,9 @@ func TestUpdateEnvironmentUserAccesses(t *testing.T) {
 
 			if tt.expectedError {
 				assert.Error(t, err)
+				if tt.skipUpdate {
+					mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -238,7 +276,9 @@ func TestUpdateEnvironmentTeamAccesses(t *testing.T) {
 		envID         int
 		teamAccesses  map[int]string
 		mockError     error
+		mockListError error
 		expectedError bool
+		skipUpdate    bool
 	}{
 		{
 			name:  "successful update",
@@ -265,23 +305,213 @@ func TestUpdateEnvironmentTeamAccesses(t *testing.T) {
 			envID:        1,
 			teamAccesses: map[int]string{},
 		},
+		{
+			name:  "invalid access level",
+			envID: 1,
+			teamAccesses: map[int]string{
+				1: "environment_administrator",
+				2: "superuser",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:  "non-existent team",
+			envID: 1,
+			teamAccesses: map[int]string{
+				1:  "environment_administrator",
+				99: "standard_user",
+			},
+			expectedError: true,
+			skipUpdate:    true,
+		},
+		{
+			name:  "list teams error",
+			envID: 1,
+			teamAccesses: map[int]string{
+				1: "environment_administrator",
+			},
+			mockListError: errors.New("failed to list teams"),
+			expectedError: true,
+			skipUpdate:    true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAPI := new(MockPortainerAPI)
-			mockAPI.On("UpdateEndpoint", int64(tt.envID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			mockAPI.On("ListTeams").Return(existingTeams, tt.mockListError).Maybe()
+			if !tt.skipUpdate {
+				mockAPI.On("UpdateEndpoint", int64(tt.envID), mock.Anything, mock.Anything, mock.Anything).Return(tt.mockError)
+			}
 
 			client := &PortainerClient{cli: mockAPI}
 
 			err := client.UpdateEnvironmentTeamAccesses(tt.envID, tt.teamAccesses)
 
+			if tt.expectedError {
+				assert.Error(t, err)
+				if tt.skipUpdate {
+					mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetEnvironmentGPUs(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockEndpoint  *apimodels.PortainereeEndpoint
+		mockError     error
+		expected      models.EnvironmentGPUSettings
+		expectedError bool
+	}{
+		{
+			name: "docker environment with gpus",
+			mockEndpoint: &apimodels.PortainereeEndpoint{
+				ID:                  1,
+				Type:                2,
+				EnableGPUManagement: true,
+				Gpus:                []*apimodels.PortainerPair{{Name: "gpu0", Value: "0"}},
+			},
+			expected: models.EnvironmentGPUSettings{
+				EnableGPUManagement: true,
+				GPUs:                []models.GPUConfig{{Name: "gpu0", DeviceID: "0"}},
+			},
+		},
+		{
+			name:          "kubernetes environment",
+			mockEndpoint:  &apimodels.PortainereeEndpoint{ID: 1, Type: 6},
+			expectedError: true,
+		},
+		{
+			name:          "get endpoint error",
+			mockError:     errors.New("failed to get endpoint"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(tt.mockEndpoint, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			settings, err := client.GetEnvironmentGPUs(1)
+
 			if tt.expectedError {
 				assert.Error(t, err)
 				return
 			}
 			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, settings)
 			mockAPI.AssertExpectations(t)
 		})
 	}
 }
+
+func TestUpdateEnvironmentGPUs(t *testing.T) {
+	dockerEndpoint := &apimodels.PortainereeEndpoint{ID: 1, Type: 1}
+
+	tests := []struct {
+		name             string
+		gpus             []models.GPUConfig
+		mockEndpoint     *apimodels.PortainereeEndpoint
+		mockGetError     error
+		expectGet        bool
+		expectUpdate     bool
+		expectedRawGPUs  []*apimodels.PortainerPair
+		mockUpdateError  error
+		expectedErrorMsg string
+	}{
+		{
+			name: "successful update",
+			gpus: []models.GPUConfig{
+				{Name: "gpu0", DeviceID: "0"},
+				{Name: "gpu1", DeviceID: "GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"},
+			},
+			mockEndpoint: dockerEndpoint,
+			expectGet:    true,
+			expectUpdate: true,
+			expectedRawGPUs: []*apimodels.PortainerPair{
+				{Name: "gpu0", Value: "0"},
+				{Name: "gpu1", Value: "GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"},
+			},
+		},
+		{
+			name:            "remove all gpus",
+			gpus:            []models.GPUConfig{},
+			mockEndpoint:    dockerEndpoint,
+			expectGet:       true,
+			expectUpdate:    true,
+			expectedRawGPUs: []*apimodels.PortainerPair{},
+		},
+		{
+			name:             "invalid device id",
+			gpus:             []models.GPUConfig{{Name: "gpu0", DeviceID: "nvidia0"}},
+			expectedErrorMsg: `invalid device ID "nvidia0"`,
+		},
+		{
+			name:             "empty gpu name",
+			gpus:             []models.GPUConfig{{DeviceID: "0"}},
+			expectedErrorMsg: "gpu name cannot be empty",
+		},
+		{
+			name:             "duplicate gpu name",
+			gpus:             []models.GPUConfig{{Name: "gpu0", DeviceID: "0"}, {Name: "gpu0", DeviceID: "1"}},
+			expectedErrorMsg: `duplicate gpu name "gpu0"`,
+		},
+		{
+			name:             "kubernetes environment",
+			gpus:             []models.GPUConfig{{Name: "gpu0", DeviceID: "0"}},
+			mockEndpoint:     &apimodels.PortainereeEndpoint{ID: 1, Type: 5},
+			expectGet:        true,
+			expectedErrorMsg: "GPUs are only supported on Docker environments",
+		},
+		{
+			name:             "get endpoint error",
+			gpus:             []models.GPUConfig{{Name: "gpu0", DeviceID: "0"}},
+			mockGetError:     errors.New("not found"),
+			expectGet:        true,
+			expectedErrorMsg: "failed to get endpoint",
+		},
+		{
+			name:             "portainer rejects the update",
+			gpus:             []models.GPUConfig{{Name: "gpu0", DeviceID: "0"}},
+			mockEndpoint:     dockerEndpoint,
+			expectGet:        true,
+			expectUpdate:     true,
+			expectedRawGPUs:  []*apimodels.PortainerPair{{Name: "gpu0", Value: "0"}},
+			mockUpdateError:  errors.New("GPUs are not supported on this host"),
+			expectedErrorMsg: "GPUs are not supported on this host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectGet {
+				mockAPI.On("GetEndpoint", int64(1)).Return(tt.mockEndpoint, tt.mockGetError)
+			}
+			if tt.expectUpdate {
+				mockAPI.On("UpdateEndpointGPUs", int64(1), tt.expectedRawGPUs).Return(tt.mockUpdateError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateEnvironmentGPUs(1, tt.gpus)
+
+			mockAPI.AssertExpectations(t)
+			if tt.expectedErrorMsg != "" {
+				assert.ErrorContains(t, err, tt.expectedErrorMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+)
+
+// ErrFeatureUnavailable is returned when the Portainer server does not expose the API
+// required by an operation, typically because the feature is only available in
+// Portainer Business Edition or in a different Portainer version.
+var ErrFeatureUnavailable = errors.New("feature not available on this Portainer edition or version")
+
+// ErrGitAuthentication is returned when Portainer could not authenticate against the git remote of a stack,
+// as opposed to a failure to deploy the content of the repository.
+var ErrGitAuthentication = errors.New("authentication against the git remote failed, check the git credentials of the stack")
+
+// ErrResourceLimitUnsupported is returned when Docker accepted a container update but could not apply some of
+// its limits, typically because the kernel or the cgroup configuration of the host does not support them.
+var ErrResourceLimitUnsupported = errors.New("resource limit not supported by the Docker host")
+
+// gitAuthenticationMessages are the messages Portainer reports when a git remote rejects its credentials
+var gitAuthenticationMessages = []string{
+	"authentication failed",
+	"authentication required",
+	"authorization failed",
+	"invalid username or password",
+}
+
+// hasStatusCode reports whether err was caused by the Portainer API answering with the given HTTP status code.
+func hasStatusCode(err error, code int) bool {
+	var status runtime.ClientResponseStatus
+	if errors.As(err, &status) {
+		return status.IsCode(code)
+	}
+	return false
+}
+
+// isNotFoundError reports whether err was caused by the Portainer API answering with a 404 status code.
+func isNotFoundError(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// isForbiddenError reports whether err was caused by the Portainer API answering with a 403 status code.
+func isForbiddenError(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// isGitAuthenticationError reports whether err was caused by a git remote rejecting the credentials used by Portainer.
+func isGitAuthenticationError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, authMessage := range gitAuthenticationMessages {
+		if strings.Contains(message, authMessage) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransientError reports whether err is likely to go away when the request is retried: the Portainer
+// server could not be reached, timed out or answered with a 5xx status code. Authentication failures
+// and other 4xx status codes are not transient.
+func IsTransientError(err error) bool {
+	var status runtime.ClientResponseStatus
+	if errors.As(err, &status) {
+		return status.IsServerError()
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// UserRolesUpdateError is returned by UpdateUserRolesBulk when the role of some users could not be updated.
+// The roles of the users listed in Updated were applied, the others were left unchanged.
+type UserRolesUpdateError struct {
+	// Updated holds the IDs of the users whose role was updated, in ascending order
+	Updated []int
+	// Failed maps the IDs of the users whose role could not be updated to the cause of the failure
+	Failed map[int]error
+}
+
+func (e *UserRolesUpdateError) Error() string {
+	ids := make([]int, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	failures := make([]string, len(ids))
+	for i, id := range ids {
+		failures[i] = fmt.Sprintf("user %d: %v", id, e.Failed[id])
+	}
+
+	return fmt.Sprintf("failed to update the role of %d of %d users (%s), updated users: %v",
+		len(e.Failed), len(e.Failed)+len(e.Updated), strings.Join(failures, "; "), e.Updated)
+}
+
+// Unwrap returns the causes of the failures so that they can be inspected with errors.Is and errors.As
+func (e *UserRolesUpdateError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// EnvironmentAccessesUpdateError is returned by UpdateEnvironmentsUserAccessesBulk and UpdateEnvironmentsTeamAccessesBulk
+// when the accesses of some environments could not be updated. The accesses were granted on the environments
+// listed in Updated, the other environments were left unchanged.
+type EnvironmentAccessesUpdateError struct {
+	// AccessType is the type of the accesses, user or team
+	AccessType string
+	// Updated holds the IDs of the environments whose accesses were updated, in ascending order
+	Updated []int
+	// Failed maps the IDs of the environments whose accesses could not be updated to the cause of the failure
+	Failed map[int]error
+}
+
+func (e *EnvironmentAccessesUpdateError) Error() string {
+	ids := make([]int, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	failures := make([]string, len(ids))
+	for i, id := range ids {
+		failures[i] = fmt.Sprintf("environment %d: %v", id, e.Failed[id])
+	}
+
+	return fmt.Sprintf("failed to update the %s accesses of %d of %d environments (%s), updated environments: %v",
+		e.AccessType, len(e.Failed), len(e.Failed)+len(e.Updated), strings.Join(failures, "; "), e.Updated)
+}
+
+// Unwrap returns the causes of the failures so that they can be inspected with errors.Is and errors.As
+func (e *EnvironmentAccessesUpdateError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// EnvironmentGroupsCreateError is returned by CreateEnvironmentGroupsBulk when some environment groups could not be created.
+// The groups listed in Created were created, the others were not.
+type EnvironmentGroupsCreateError struct {
+	// Created maps the names of the created groups to their ID
+	Created map[string]int
+	// Failed maps the names of the groups that could not be created to the cause of the failure
+	Failed map[string]error
+}
+
+func (e *EnvironmentGroupsCreateError) Error() string {
+	failedNames := make([]string, 0, len(e.Failed))
+	for name := range e.Failed {
+		failedNames = append(failedNames, name)
+	}
+	slices.Sort(failedNames)
+
+	failures := make([]string, len(failedNames))
+	for i, name := range failedNames {
+		failures[i] = fmt.Sprintf("group %s: %v", name, e.Failed[name])
+	}
+
+	createdNames := make([]string, 0, len(e.Created))
+	for name := range e.Created {
+		createdNames = append(createdNames, name)
+	}
+	slices.Sort(createdNames)
+
+	created := make([]string, len(createdNames))
+	for i, name := range createdNames {
+		created[i] = fmt.Sprintf("%s (ID %d)", name, e.Created[name])
+	}
+
+	return fmt.Sprintf("failed to create %d of %d environment groups (%s), created groups: [%s]",
+		len(e.Failed), len(e.Failed)+len(e.Created), strings.Join(failures, "; "), strings.Join(created, ", "))
+}
+
+// Unwrap returns the causes of the failures so that they can be inspected with errors.Is and errors.As
+func (e *EnvironmentGroupsCreateError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// AccessGroupUpdateError is returned by UpdateAccessGroup when a change failed after other changes were applied.
+// The applied changes were rolled back, the access group is only left partially updated when RollbackFailures
+// is not empty.
+type AccessGroupUpdateError struct {
+	// GroupID is the ID of the access group being updated
+	GroupID int
+	// Err is the cause of the failure of the update
+	Err error
+	// RollbackFailures describes the changes that could not be rolled back, empty when the access group was restored
+	RollbackFailures []string
+
+	// settingsUpdated, added and removed record the changes applied before the failure
+	settingsUpdated bool
+	added           []int
+	removed         []int
+}
+
+func (e *AccessGroupUpdateError) Error() string {
+	if len(e.RollbackFailures) == 0 {
+		return fmt.Sprintf("failed to update access group %d: %v, the changes already applied were rolled back", e.GroupID, e.Err)
+	}
+
+	return fmt.Sprintf("failed to update access group %d: %v, the access group is partially updated as the rollback failed (%s)",
+		e.GroupID, e.Err, strings.Join(e.RollbackFailures, "; "))
+}
+
+// Unwrap returns the cause of the failure so that it can be inspected with errors.Is and errors.As
+func (e *AccessGroupUpdateError) Unwrap() error {
+	return e.Err
+}
+
+// EnvironmentDetachError is returned by DetachEnvironment when some of the detach steps failed.
+// The other steps were applied, they are listed in the report returned with the error.
+type EnvironmentDetachError struct {
+	// EnvironmentID is the ID of the environment being detached
+	EnvironmentID int
+	// Failed holds the causes of the failed steps, in the order the steps were applied
+	Failed []error
+}
+
+func (e *EnvironmentDetachError) Error() string {
+	failures := make([]string, len(e.Failed))
+	for i, err := range e.Failed {
+		failures[i] = err.Error()
+	}
+
+	return fmt.Sprintf("failed to fully detach environment %d (%s)", e.EnvironmentID, strings.Join(failures, "; "))
+}
+
+// Unwrap returns the causes of the failures so that they can be inspected with errors.Is and errors.As
+func (e *EnvironmentDetachError) Unwrap() []error {
+	return e.Failed
+}
+
+// AccessSnapshotRestoreError is returned by RestoreEnvironmentAccess when some users or teams of the snapshot
+// no longer exist. The rest of the snapshot was restored, the accesses of these users and teams were skipped.
+type AccessSnapshotRestoreError struct {
+	// EnvironmentID is the ID of the restored environment
+	EnvironmentID int
+	// MissingUsers holds the IDs of the users of the snapshot that no longer exist, in ascending order
+	MissingUsers []int
+	// MissingTeams holds the IDs of the teams of the snapshot that no longer exist, in ascending order
+	MissingTeams []int
+}
+
+func (e *AccessSnapshotRestoreError) Error() string {
+	var missing []string
+	if len(e.MissingUsers) > 0 {
+		missing = append(missing, fmt.Sprintf("users %v", e.MissingUsers))
+	}
+	if len(e.MissingTeams) > 0 {
+		missing = append(missing, fmt.Sprintf("teams %v", e.MissingTeams))
+	}
+
+	return fmt.Sprintf("the access of environment %d was restored without the accesses of %s as they no longer exist",
+		e.EnvironmentID, strings.Join(missing, " and "))
+}
+
+// StackRestartError is returned by RestartStack when some containers of the stack could not be restarted.
+// The other containers were restarted, both are listed in the report returned with the error.
+type StackRestartError struct {
+	// StackID is the ID of the restarted stack
+	StackID int
+	// EnvironmentID is the ID of the environment the stack was restarted on
+	EnvironmentID int
+	// Failed is the number of containers that could not be restarted
+	Failed int
+	// Total is the number of containers of the stack on the environment
+	Total int
+}
+
+func (e *StackRestartError) Error() string {
+	return fmt.Sprintf("failed to restart %d of %d containers of stack %d on environment %d", e.Failed, e.Total, e.StackID, e.EnvironmentID)
+}
+
+// EnvironmentSearchError is returned by FindEnvironmentsByImage when some environments could not be searched,
+// typically because they are offline. The environments returned with the error are the matches found among
+// the environments that could be searched.
+type EnvironmentSearchError struct {
+	// Searched is the number of environments that could be searched
+	Searched int
+	// Failed maps the IDs of the environments that could not be searched to the cause of the failure
+	Failed map[int]error
+}
+
+func (e *EnvironmentSearchError) Error() string {
+	ids := make([]int, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	failures := make([]string, len(ids))
+	for i, id := range ids {
+		failures[i] = fmt.Sprintf("environment %d: %v", id, e.Failed[id])
+	}
+
+	return fmt.Sprintf("failed to search %d of %d environments (%s)",
+		len(e.Failed), len(e.Failed)+e.Searched, strings.Join(failures, "; "))
+}
+
+// Unwrap returns the causes of the failures so that they can be inspected with errors.Is and errors.As
+func (e *EnvironmentSearchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
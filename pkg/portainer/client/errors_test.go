@@ -0,0 +1,174 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/portainer/portainer-mcp/pkg/portainer/rawclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "api error with 404 status",
+			err:      runtime.NewAPIError("op", nil, http.StatusNotFound),
+			expected: true,
+		},
+		{
+			name:     "wrapped api error with 404 status",
+			err:      fmt.Errorf("failed to do something: %w", runtime.NewAPIError("op", nil, http.StatusNotFound)),
+			expected: true,
+		},
+		{
+			name:     "api error with another status",
+			err:      runtime.NewAPIError("op", nil, http.StatusForbidden),
+			expected: false,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isNotFoundError(tt.err))
+		})
+	}
+}
+
+func TestIsForbiddenError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "api error with 403 status",
+			err:      runtime.NewAPIError("op", nil, http.StatusForbidden),
+			expected: true,
+		},
+		{
+			name:     "wrapped api error with 403 status",
+			err:      fmt.Errorf("failed to do something: %w", runtime.NewAPIError("op", nil, http.StatusForbidden)),
+			expected: true,
+		},
+		{
+			name:     "api error with another status",
+			err:      runtime.NewAPIError("op", nil, http.StatusNotFound),
+			expected: false,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isForbiddenError(tt.err))
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "connection refused",
+			err:      fmt.Errorf("failed to get version: %w", &url.Error{Op: "Get", URL: "https://portainer:9443/api/system/status", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}),
+			expected: true,
+		},
+		{
+			name:     "api error with 503 status",
+			err:      fmt.Errorf("failed to get version: %w", &rawclient.APIError{StatusCode: http.StatusServiceUnavailable}),
+			expected: true,
+		},
+		{
+			name:     "generated api error with 502 status",
+			err:      runtime.NewAPIError("op", nil, http.StatusBadGateway),
+			expected: true,
+		},
+		{
+			name:     "api error with 401 status",
+			err:      fmt.Errorf("failed to get version: %w", &rawclient.APIError{StatusCode: http.StatusUnauthorized}),
+			expected: false,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("unsupported Portainer server version"),
+			expected: false,
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTransientError(tt.err))
+		})
+	}
+}
+
+func TestIsGitAuthenticationError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "invalid git credentials",
+			err:      &rawclient.APIError{StatusCode: http.StatusInternalServerError, Message: "Unable to clone git repository", Details: "authentication failed, please ensure that the git credentials are correct"},
+			expected: true,
+		},
+		{
+			name:     "missing git credentials",
+			err:      fmt.Errorf("failed: %w", &rawclient.APIError{StatusCode: http.StatusInternalServerError, Message: "Unable to clone git repository", Details: "Authentication required"}),
+			expected: true,
+		},
+		{
+			name:     "deploy failure",
+			err:      &rawclient.APIError{StatusCode: http.StatusInternalServerError, Message: "Unable to deploy stack", Details: "service web: invalid image reference"},
+			expected: false,
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isGitAuthenticationError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// GetFleetStats summarizes all the environments: how many are online and how many stacks and
+// containers they run according to their latest snapshot. The environments are inspected
+// concurrently, at most maxConcurrentEnvironmentRequests at a time. An environment that cannot
+// be inspected does not fail the summary, it is reported as unreachable instead.
+//
+// Returns:
+//   - A FleetStats object
+//   - An error if the environments cannot be listed
+func (c *PortainerClient) GetFleetStats() (models.FleetStats, error) {
+	endpoints, err := c.cli.ListEndpoints()
+	if err != nil {
+		return models.FleetStats{}, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	inspected := make([]*apimodels.PortainereeEndpoint, len(endpoints))
+	errs := make([]error, len(endpoints))
+
+	forEachConcurrently(len(endpoints), maxConcurrentEnvironmentRequests, func(i int) {
+		inspected[i], errs[i] = c.cli.GetEndpoint(endpoints[i].ID)
+	})
+
+	stats := models.FleetStats{TotalEnvironments: len(endpoints)}
+	for i, endpoint := range endpoints {
+		if models.ConvertEndpointToEnvironment(endpoint).Status == models.EnvironmentStatusActive {
+			stats.OnlineEnvironments++
+		} else {
+			stats.OfflineEnvironments++
+		}
+
+		if errs[i] != nil || inspected[i] == nil {
+			stats.UnreachableEnvironments++
+			stats.UnreachableEnvironmentIDs = append(stats.UnreachableEnvironmentIDs, int(endpoint.ID))
+			continue
+		}
+
+		snapshot := latestDockerSnapshot(inspected[i])
+		if snapshot == nil {
+			stats.EnvironmentsWithoutSnapshot++
+			continue
+		}
+		stats.TotalStacks += int(snapshot.StackCount)
+		stats.TotalContainers += int(snapshot.ContainerCount)
+		stats.RunningContainers += int(snapshot.RunningContainerCount)
+	}
+
+	return stats, nil
+}
+
+// latestDockerSnapshot returns the most recent Docker snapshot of an endpoint, nil when it has none
+func latestDockerSnapshot(endpoint *apimodels.PortainereeEndpoint) *apimodels.PortainerDockerSnapshot {
+	var latest *apimodels.PortainerDockerSnapshot
+
+	for _, snapshot := range endpoint.Snapshots {
+		if snapshot != nil && (latest == nil || snapshot.Time > latest.Time) {
+			latest = snapshot
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFleetStats(t *testing.T) {
+	docker := &apimodels.PortainereeEndpoint{ID: 1, Type: 1, Status: 1, Snapshots: []*apimodels.PortainerDockerSnapshot{
+		{Time: 100, StackCount: 1, ContainerCount: 3, RunningContainerCount: 1},
+		{Time: 200, StackCount: 2, ContainerCount: 5, RunningContainerCount: 4},
+	}}
+	offlineDocker := &apimodels.PortainereeEndpoint{ID: 2, Type: 2, Status: 2, Snapshots: []*apimodels.PortainerDockerSnapshot{
+		{Time: 150, StackCount: 1, ContainerCount: 2},
+	}}
+	kubernetes := &apimodels.PortainereeEndpoint{ID: 3, Type: 5, Status: 1}
+	unreachable := &apimodels.PortainereeEndpoint{ID: 4, Type: 1, Status: 1}
+
+	t.Run("aggregates the snapshots", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return([]*apimodels.PortainereeEndpoint{docker, offlineDocker, kubernetes, unreachable}, nil)
+		mockAPI.On("GetEndpoint", int64(1)).Return(docker, nil)
+		mockAPI.On("GetEndpoint", int64(2)).Return(offlineDocker, nil)
+		mockAPI.On("GetEndpoint", int64(3)).Return(kubernetes, nil)
+		mockAPI.On("GetEndpoint", int64(4)).Return(nil, errors.New("endpoint database is locked"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		stats, err := client.GetFleetStats()
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.FleetStats{
+			TotalEnvironments:           4,
+			OnlineEnvironments:          3,
+			OfflineEnvironments:         1,
+			TotalStacks:                 3,
+			TotalContainers:             7,
+			RunningContainers:           4,
+			EnvironmentsWithoutSnapshot: 1,
+			UnreachableEnvironments:     1,
+			UnreachableEnvironmentIDs:   []int{4},
+		}, stats)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("no environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return([]*apimodels.PortainereeEndpoint{}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		stats, err := client.GetFleetStats()
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.FleetStats{}, stats)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return(nil, errors.New("unauthorized"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetFleetStats()
+
+		assert.ErrorContains(t, err, "failed to list endpoints: unauthorized")
+	})
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gitRemoteTimeout is the maximum time spent reading the references of a git repository
+var gitRemoteTimeout = 30 * time.Second
+
+// maxGitRefsSize bounds the size of the references advertised by a git repository
+const maxGitRefsSize = 10 << 20
+
+// gitUploadPackAdvertisement is the content type of the references advertised by the smart HTTP protocol
+const gitUploadPackAdvertisement = "application/x-git-upload-pack-advertisement"
+
+// lsRemote reads the commit a reference of a git repository points to, like `git ls-remote`, with the refs
+// advertisement of the git smart HTTP protocol. Portainer does not expose the commits of the repositories,
+// the repository is read directly and without credentials, only public HTTP(S) repositories can be read.
+//
+// A short reference name is looked up as a branch and then as a tag, an empty reference is the HEAD of the
+// repository. Annotated tags resolve to the commit they point to.
+func lsRemote(repositoryURL, reference string, tlsSkipVerify bool) (string, error) {
+	u, err := url.Parse(repositoryURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("only http and https repositories can be read, not %s", u.Scheme)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/info/refs"
+	u.RawQuery = "service=git-upload-pack"
+
+	httpClient := &http.Client{Timeout: gitRemoteTimeout}
+	if tlsSkipVerify {
+		httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+
+	resp, err := httpClient.Get(u.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to read the repository references: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return "", fmt.Errorf("the repository requires credentials: unexpected status %s", resp.Status)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return "", fmt.Errorf("failed to read the repository references: unexpected status %s", resp.Status)
+	case resp.Header.Get("Content-Type") != gitUploadPackAdvertisement:
+		return "", fmt.Errorf("the git server does not support the smart HTTP protocol")
+	}
+
+	refs, err := parseGitRefs(io.LimitReader(resp.Body, maxGitRefsSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the repository references: %w", err)
+	}
+
+	candidates := []string{"HEAD"}
+	if reference != "" {
+		candidates = []string{reference, "refs/heads/" + reference, "refs/tags/" + reference}
+	}
+	for _, name := range candidates {
+		if commit, ok := refs[name+"^{}"]; ok {
+			return commit, nil
+		}
+		if commit, ok := refs[name]; ok {
+			return commit, nil
+		}
+	}
+
+	return "", fmt.Errorf("reference %s not found in the repository", reference)
+}
+
+// parseGitRefs parses the pkt-lines of a refs advertisement into the commit of each reference.
+// Each line is prefixed with its length in four hexadecimal digits, a length of zero is a flush
+// packet. The first reference carries the capabilities of the server after a NUL byte.
+func parseGitRefs(r io.Reader) (map[string]string, error) {
+	refs := map[string]string{}
+
+	var size [4]byte
+	for {
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return refs, nil
+			}
+			return nil, err
+		}
+
+		n, err := strconv.ParseUint(string(size[:]), 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pkt-line length %q", size[:])
+		}
+		if n == 0 {
+			continue
+		}
+		if n < 4 {
+			return nil, fmt.Errorf("invalid pkt-line length %d", n)
+		}
+
+		line := make([]byte, n-4)
+		if _, err := io.ReadFull(r, line); err != nil {
+			return nil, err
+		}
+
+		text := strings.TrimSuffix(string(line), "\n")
+		// The service announcement of the smart HTTP protocol
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+		text, _, _ = strings.Cut(text, "\x00")
+
+		if commit, name, ok := strings.Cut(text, " "); ok {
+			refs[name] = commit
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGitRefs returns the refs advertisement of a git smart HTTP server for the given lines
+func newTestGitRefs(lines ...string) string {
+	var b strings.Builder
+	b.WriteString("001e# service=git-upload-pack\n0000")
+	for _, line := range lines {
+		fmt.Fprintf(&b, "%04x%s\n", len(line)+5, line)
+	}
+	b.WriteString("0000")
+	return b.String()
+}
+
+// newTestGitServer starts a git smart HTTP server advertising the given refs
+func newTestGitServer(t *testing.T, status int, refs string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/acme/shop.git/info/refs", r.URL.Path)
+		assert.Equal(t, "git-upload-pack", r.URL.Query().Get("service"))
+
+		w.Header().Set("Content-Type", gitUploadPackAdvertisement)
+		w.WriteHeader(status)
+		w.Write([]byte(refs))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLsRemote(t *testing.T) {
+	refs := newTestGitRefs(
+		"1111111111111111111111111111111111111111 HEAD\x00multi_ack symref=HEAD:refs/heads/main",
+		"1111111111111111111111111111111111111111 refs/heads/main",
+		"2222222222222222222222222222222222222222 refs/heads/release",
+		"3333333333333333333333333333333333333333 refs/tags/v1.0",
+		"4444444444444444444444444444444444444444 refs/tags/v1.0^{}",
+	)
+
+	tests := []struct {
+		name          string
+		status        int
+		reference     string
+		expected      string
+		expectedError string
+	}{
+		{name: "full branch reference", status: http.StatusOK, reference: "refs/heads/release", expected: "2222222222222222222222222222222222222222"},
+		{name: "short branch name", status: http.StatusOK, reference: "main", expected: "1111111111111111111111111111111111111111"},
+		{name: "annotated tag", status: http.StatusOK, reference: "v1.0", expected: "4444444444444444444444444444444444444444"},
+		{name: "head", status: http.StatusOK, expected: "1111111111111111111111111111111111111111"},
+		{name: "unknown reference", status: http.StatusOK, reference: "refs/heads/dev", expectedError: "reference refs/heads/dev not found"},
+		{name: "private repository", status: http.StatusUnauthorized, reference: "main", expectedError: "the repository requires credentials"},
+		{name: "server error", status: http.StatusBadGateway, reference: "main", expectedError: "unexpected status 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestGitServer(t, tt.status, refs)
+
+			commit, err := lsRemote(server.URL+"/acme/shop.git/", tt.reference, false)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, commit)
+		})
+	}
+
+	t.Run("ssh repository", func(t *testing.T) {
+		_, err := lsRemote("ssh://git@github.com/acme/shop.git", "main", false)
+		assert.ErrorContains(t, err, "only http and https repositories")
+	})
+
+	t.Run("dumb http server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("1111111111111111111111111111111111111111\trefs/heads/main\n"))
+		}))
+		defer server.Close()
+
+		_, err := lsRemote(server.URL+"/acme/shop.git", "main", false)
+		assert.ErrorContains(t, err, "does not support the smart HTTP protocol")
+	})
+}
+
+func TestParseGitRefs(t *testing.T) {
+	_, err := parseGitRefs(strings.NewReader("zzzz"))
+	assert.ErrorContains(t, err, "invalid pkt-line length")
+
+	_, err = parseGitRefs(strings.NewReader("0032abc"))
+	assert.Error(t, err)
+
+	refs, err := parseGitRefs(strings.NewReader(newTestGitRefs("1111111111111111111111111111111111111111 refs/heads/main\x00agent=git/2.45")))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"refs/heads/main": "1111111111111111111111111111111111111111"}, refs)
+}
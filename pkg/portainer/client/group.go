@@ -2,6 +2,8 @@ package client
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
@@ -45,6 +47,106 @@ func (c *PortainerClient) CreateEnvironmentGroup(name string, environmentIds []i
 	return int(id), nil
 }
 
+// CreateEnvironmentGroupsBulk creates several environment groups in one call.
+// Environment groups are the equivalent of Edge Groups in Portainer.
+// The specs are validated and the environment names are resolved before any group is created,
+// so an invalid spec or an unknown environment name never leaves the batch half-applied.
+// The groups are then created one at a time in the order of the specs and a failing creation
+// does not prevent the remaining groups from being created.
+//
+// Parameters:
+//   - specs: The groups to create, each with a unique name and the IDs and/or names of its environments
+//
+// Returns:
+//   - The IDs of the created groups, in the order of the specs
+//   - A *EnvironmentGroupsCreateError listing the created and failed groups if some creations failed,
+//     the returned IDs then only include the created groups
+//   - An error if the validation or the resolution of the environment names fails
+func (c *PortainerClient) CreateEnvironmentGroupsBulk(specs []models.GroupSpec) ([]int, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("at least one environment group is required")
+	}
+
+	names := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("environment group name cannot be empty")
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("duplicate environment group name: %s", spec.Name)
+		}
+		names[spec.Name] = true
+	}
+
+	environmentIds, err := c.resolveGroupSpecEnvironments(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(specs))
+	result := &EnvironmentGroupsCreateError{
+		Created: map[string]int{},
+		Failed:  map[string]error{},
+	}
+	for i, spec := range specs {
+		id, err := c.cli.CreateEdgeGroup(spec.Name, utils.IntToInt64Slice(environmentIds[i]))
+		if err != nil {
+			result.Failed[spec.Name] = err
+			continue
+		}
+		ids = append(ids, int(id))
+		result.Created[spec.Name] = int(id)
+	}
+
+	if len(result.Failed) > 0 {
+		return ids, result
+	}
+
+	return ids, nil
+}
+
+// resolveGroupSpecEnvironments returns the environment IDs of every spec, merging the IDs of the
+// environments referenced by name with the ones referenced by ID. The environments are only listed
+// when a spec references environments by name, every unknown name is reported at once.
+func (c *PortainerClient) resolveGroupSpecEnvironments(specs []models.GroupSpec) ([][]int, error) {
+	environmentIds := make([][]int, len(specs))
+
+	var byName map[string]int
+	var unresolved []string
+	for i, spec := range specs {
+		ids := slices.Clone(spec.EnvironmentIds)
+
+		if len(spec.EnvironmentNames) > 0 && byName == nil {
+			endpoints, err := c.cli.ListEndpoints()
+			if err != nil {
+				return nil, fmt.Errorf("failed to list endpoints: %w", err)
+			}
+
+			byName = make(map[string]int, len(endpoints))
+			for _, endpoint := range endpoints {
+				byName[endpoint.Name] = int(endpoint.ID)
+			}
+		}
+
+		for _, name := range spec.EnvironmentNames {
+			id, ok := byName[name]
+			if !ok {
+				unresolved = append(unresolved, fmt.Sprintf("%s (group %s)", name, spec.Name))
+				continue
+			}
+			ids = append(ids, id)
+		}
+
+		environmentIds[i] = uniqueSortedIDs(ids)
+	}
+
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("unknown environment names, no environment group was created: %s", strings.Join(unresolved, ", "))
+	}
+
+	return environmentIds, nil
+}
+
 // UpdateEnvironmentGroupName updates the name of an existing environment group.
 // Environment groups are the equivalent of Edge Groups in Portainer.
 //
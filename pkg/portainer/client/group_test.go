@@ -134,6 +134,116 @@ func TestCreateEnvironmentGroup(t *testing.T) {
 	}
 }
 
+func TestCreateEnvironmentGroupsBulk(t *testing.T) {
+	mockEndpoints := []*apimodels.PortainereeEndpoint{
+		{ID: 1, Name: "prod"},
+		{ID: 2, Name: "staging"},
+		{ID: 3, Name: "dev"},
+	}
+
+	tests := []struct {
+		name              string
+		specs             []models.GroupSpec
+		expectList        bool
+		expectCreates     map[string][]int64
+		mockCreateErrors  map[string]error
+		expectedIDs       []int
+		expectedError     string
+		expectPartialFail bool
+	}{
+		{
+			name: "successful creation with IDs and names",
+			specs: []models.GroupSpec{
+				{Name: "web", EnvironmentIds: []int{3}, EnvironmentNames: []string{"prod", "staging"}},
+				{Name: "db", EnvironmentIds: []int{2, 1, 2}},
+			},
+			expectList:    true,
+			expectCreates: map[string][]int64{"web": {1, 2, 3}, "db": {1, 2}},
+			expectedIDs:   []int{10, 11},
+		},
+		{
+			name:          "IDs only do not list environments",
+			specs:         []models.GroupSpec{{Name: "db", EnvironmentIds: []int{1}}},
+			expectCreates: map[string][]int64{"db": {1}},
+			expectedIDs:   []int{10},
+		},
+		{
+			name: "unresolved names create nothing",
+			specs: []models.GroupSpec{
+				{Name: "web", EnvironmentNames: []string{"prod", "qa"}},
+				{Name: "db", EnvironmentNames: []string{"test"}},
+			},
+			expectList:    true,
+			expectedError: "unknown environment names, no environment group was created: qa (group web), test (group db)",
+		},
+		{
+			name: "partial failure",
+			specs: []models.GroupSpec{
+				{Name: "web", EnvironmentIds: []int{1}},
+				{Name: "db", EnvironmentIds: []int{2}},
+			},
+			expectCreates:     map[string][]int64{"web": {1}, "db": {2}},
+			mockCreateErrors:  map[string]error{"web": errors.New("name already used")},
+			expectedIDs:       []int{11},
+			expectedError:     "failed to create 1 of 2 environment groups (group web: name already used), created groups: [db (ID 11)]",
+			expectPartialFail: true,
+		},
+		{
+			name:          "duplicate group names",
+			specs:         []models.GroupSpec{{Name: "web"}, {Name: "web"}},
+			expectedError: "duplicate environment group name: web",
+		},
+		{
+			name:          "empty group name",
+			specs:         []models.GroupSpec{{Name: ""}},
+			expectedError: "environment group name cannot be empty",
+		},
+		{
+			name:          "no specs",
+			expectedError: "at least one environment group is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectList {
+				mockAPI.On("ListEndpoints").Return(mockEndpoints, nil)
+			}
+			nextID := int64(10)
+			for _, spec := range tt.specs {
+				environmentIds, ok := tt.expectCreates[spec.Name]
+				if !ok {
+					continue
+				}
+				if err := tt.mockCreateErrors[spec.Name]; err != nil {
+					mockAPI.On("CreateEdgeGroup", spec.Name, environmentIds).Return(int64(0), err)
+				} else {
+					mockAPI.On("CreateEdgeGroup", spec.Name, environmentIds).Return(nextID, nil)
+				}
+				nextID++
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			ids, err := client.CreateEnvironmentGroupsBulk(tt.specs)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				var createErr *EnvironmentGroupsCreateError
+				assert.Equal(t, tt.expectPartialFail, errors.As(err, &createErr))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			if tt.expectCreates == nil {
+				mockAPI.AssertNotCalled(t, "CreateEdgeGroup", mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUpdateEnvironmentGroupName(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/rawclient"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrHelmChartNotFound is returned when the chart or the chart version to install cannot be found in its repository
+	ErrHelmChartNotFound = errors.New("helm chart not found")
+	// ErrInvalidHelmValues is returned when the values of a chart to install are invalid
+	ErrInvalidHelmValues = errors.New("invalid helm values")
+)
+
+// maxHelmReleaseNameLength is the maximum length of a Helm release name
+const maxHelmReleaseNameLength = 53
+
+// helmReleaseNamePattern matches the release names accepted by Helm, which must be DNS-1123 labels
+var helmReleaseNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ListHelmReleases lists the Helm releases installed in a Kubernetes environment.
+// The releases are sorted by namespace and name.
+//
+// Parameters:
+//   - environmentId: The ID of the Kubernetes environment
+//   - namespace: The namespace of the releases, all namespaces are listed when empty
+//
+// Returns:
+//   - A slice of HelmRelease objects
+//   - ErrFeatureUnavailable if Helm is not available on the environment
+//   - An error if the operation fails
+func (c *PortainerClient) ListHelmReleases(environmentId int, namespace string) ([]models.HelmRelease, error) {
+	if err := c.checkHelmEnvironment(environmentId); err != nil {
+		return nil, err
+	}
+
+	rawReleases, err := c.cli.ListHelmReleases(int64(environmentId), namespace)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, fmt.Errorf("helm is not enabled on environment %d: %w", environmentId, ErrFeatureUnavailable)
+		}
+		return nil, fmt.Errorf("failed to list helm releases: %w", err)
+	}
+
+	releases := make([]models.HelmRelease, len(rawReleases))
+	for i, rawRelease := range rawReleases {
+		releases[i] = models.ConvertToHelmRelease(rawRelease)
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		if releases[i].Namespace != releases[j].Namespace {
+			return releases[i].Namespace < releases[j].Namespace
+		}
+		return releases[i].Name < releases[j].Name
+	})
+
+	return releases, nil
+}
+
+// InstallHelmChart installs a Helm chart in a Kubernetes environment, upgrading the release when it
+// already exists in the namespace. The options and the values are validated before anything is sent
+// to Portainer.
+//
+// Parameters:
+//   - environmentId: The ID of the Kubernetes environment
+//   - opts: The chart to install and the release to install it as
+//
+// Returns:
+//   - ErrInvalidHelmValues if the values are not a valid YAML or JSON object or are rejected by the chart
+//   - ErrHelmChartNotFound if the chart or the chart version cannot be found in the repository
+//   - ErrFeatureUnavailable if Helm is not available on the environment
+//   - An error if the operation fails
+func (c *PortainerClient) InstallHelmChart(environmentId int, opts models.HelmInstallOptions) error {
+	if err := validateHelmInstallOptions(opts); err != nil {
+		return err
+	}
+
+	if err := c.checkHelmEnvironment(environmentId); err != nil {
+		return err
+	}
+
+	err := c.cli.InstallHelmChart(int64(environmentId), opts.Repo, opts.Chart, opts.Version, opts.Namespace, opts.Name, opts.Values)
+	if err != nil {
+		return classifyHelmInstallError(environmentId, opts, err)
+	}
+
+	return nil
+}
+
+// checkHelmEnvironment checks that an environment is a Kubernetes environment, Helm is not available on the others
+func (c *PortainerClient) checkHelmEnvironment(environmentId int) error {
+	endpoint, err := c.cli.GetEndpoint(int64(environmentId))
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if !models.IsKubernetesEnvironment(environment.Type) {
+		return fmt.Errorf("environment %d is a %s environment, Helm is only available on Kubernetes environments: %w", environmentId, environment.Type, ErrFeatureUnavailable)
+	}
+
+	return nil
+}
+
+// validateHelmInstallOptions checks the options of a chart installation. The values must be a
+// YAML or JSON object, JSON being a subset of YAML a single parser handles both formats.
+func validateHelmInstallOptions(opts models.HelmInstallOptions) error {
+	switch {
+	case opts.Repo == "":
+		return fmt.Errorf("repo is required")
+	case opts.Chart == "":
+		return fmt.Errorf("chart is required")
+	case opts.Namespace == "":
+		return fmt.Errorf("namespace is required")
+	case opts.Name == "":
+		return fmt.Errorf("release name is required")
+	}
+
+	if len(opts.Name) > maxHelmReleaseNameLength || !helmReleaseNamePattern.MatchString(opts.Name) {
+		return fmt.Errorf("invalid release name %q: must be at most %d lowercase alphanumeric characters or '-', starting and ending with an alphanumeric character", opts.Name, maxHelmReleaseNameLength)
+	}
+
+	if strings.TrimSpace(opts.Values) == "" {
+		return nil
+	}
+
+	var values any
+	if err := yaml.Unmarshal([]byte(opts.Values), &values); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidHelmValues, err)
+	}
+	if _, ok := values.(map[string]any); !ok && values != nil {
+		return fmt.Errorf("%w: values must be an object mapping value names to values", ErrInvalidHelmValues)
+	}
+
+	return nil
+}
+
+// classifyHelmInstallError tells apart the failures of a chart installation reported by Helm.
+// Portainer reports them as a generic error with the message of Helm, so they are told apart by message.
+func classifyHelmInstallError(environmentId int, opts models.HelmInstallOptions, err error) error {
+	if isNotFoundError(err) {
+		return fmt.Errorf("helm is not enabled on environment %d: %w", environmentId, ErrFeatureUnavailable)
+	}
+
+	// Only the message of Portainer is matched, the context added to the error mentions the chart
+	message := err.Error()
+	var apiErr *rawclient.APIError
+	if errors.As(err, &apiErr) {
+		message = apiErr.Message + " " + apiErr.Details
+	}
+	message = strings.ToLower(message)
+
+	switch {
+	case strings.Contains(message, "chart") && (strings.Contains(message, "not found") || strings.Contains(message, "no chart")):
+		return fmt.Errorf("chart %s not found in repository %s: %w: %v", chartReference(opts), opts.Repo, ErrHelmChartNotFound, err)
+	case strings.Contains(message, "values") || strings.Contains(message, "yaml"):
+		return fmt.Errorf("%w: values rejected by chart %s: %v", ErrInvalidHelmValues, chartReference(opts), err)
+	}
+
+	return fmt.Errorf("failed to install helm chart: %w", err)
+}
+
+// chartReference returns the chart of an installation with its version when one is set, e.g. "nginx@15.0.0"
+func chartReference(opts models.HelmInstallOptions) string {
+	if opts.Version == "" {
+		return opts.Chart
+	}
+	return opts.Chart + "@" + opts.Version
+}
@@ -0,0 +1,285 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/rawclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListHelmReleases(t *testing.T) {
+	mockReleases := []*apimodels.ReleaseReleaseElement{
+		{Name: "redis", Namespace: "cache", Chart: "redis-18.1.0", AppVersion: "7.2.1", Revision: "2", Status: "failed"},
+		{Name: "nginx", Namespace: "web", Chart: "nginx-15.0.0", AppVersion: "1.25.2", Revision: "1", Status: "deployed"},
+		{Name: "api", Namespace: "web", Chart: "api-0.3.1", Revision: "4", Status: "deployed"},
+	}
+
+	tests := []struct {
+		name           string
+		endpointType   int64
+		namespace      string
+		expectList     bool
+		mockReleases   []*apimodels.ReleaseReleaseElement
+		mockError      error
+		expected       []models.HelmRelease
+		expectedError  error
+		expectedErrMsg string
+	}{
+		{
+			name:         "all namespaces",
+			endpointType: 5,
+			expectList:   true,
+			mockReleases: mockReleases,
+			expected: []models.HelmRelease{
+				{Name: "redis", Namespace: "cache", Chart: "redis", Version: "18.1.0", AppVersion: "7.2.1", Revision: "2", Status: "failed"},
+				{Name: "api", Namespace: "web", Chart: "api", Version: "0.3.1", Revision: "4", Status: "deployed"},
+				{Name: "nginx", Namespace: "web", Chart: "nginx", Version: "15.0.0", AppVersion: "1.25.2", Revision: "1", Status: "deployed"},
+			},
+		},
+		{
+			name:         "single namespace",
+			endpointType: 6,
+			namespace:    "cache",
+			expectList:   true,
+			mockReleases: mockReleases[:1],
+			expected: []models.HelmRelease{
+				{Name: "redis", Namespace: "cache", Chart: "redis", Version: "18.1.0", AppVersion: "7.2.1", Revision: "2", Status: "failed"},
+			},
+		},
+		{
+			name:         "no releases",
+			endpointType: 5,
+			expectList:   true,
+			mockReleases: []*apimodels.ReleaseReleaseElement{},
+			expected:     []models.HelmRelease{},
+		},
+		{
+			name:           "docker environment",
+			endpointType:   1,
+			expectedError:  ErrFeatureUnavailable,
+			expectedErrMsg: "Helm is only available on Kubernetes environments",
+		},
+		{
+			name:           "helm not enabled",
+			endpointType:   5,
+			expectList:     true,
+			mockError:      runtime.NewAPIError("HelmList", nil, http.StatusNotFound),
+			expectedError:  ErrFeatureUnavailable,
+			expectedErrMsg: "helm is not enabled on environment 1",
+		},
+		{
+			name:           "list error",
+			endpointType:   5,
+			expectList:     true,
+			mockError:      errors.New("connection refused"),
+			expectedErrMsg: "failed to list helm releases",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectList {
+				mockAPI.On("ListHelmReleases", int64(1), tt.namespace).Return(tt.mockReleases, tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			releases, err := client.ListHelmReleases(1, tt.namespace)
+
+			if !tt.expectList {
+				mockAPI.AssertNotCalled(t, "ListHelmReleases", mock.Anything, mock.Anything)
+			}
+			if tt.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tt.expectedErrMsg)
+				if tt.expectedError != nil {
+					assert.ErrorIs(t, err, tt.expectedError)
+				} else {
+					assert.NotErrorIs(t, err, ErrFeatureUnavailable)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, releases)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInstallHelmChart(t *testing.T) {
+	validOpts := func() models.HelmInstallOptions {
+		return models.HelmInstallOptions{
+			Repo:      "https://charts.bitnami.com/bitnami",
+			Chart:     "nginx",
+			Version:   "15.0.0",
+			Namespace: "web",
+			Name:      "web",
+			Values:    "replicaCount: 2\n",
+		}
+	}
+
+	tests := []struct {
+		name           string
+		opts           models.HelmInstallOptions
+		endpointType   int64
+		expectInstall  bool
+		mockError      error
+		expectedError  error
+		expectedErrMsg string
+	}{
+		{
+			name:          "successful installation",
+			opts:          validOpts(),
+			endpointType:  5,
+			expectInstall: true,
+		},
+		{
+			name: "json values",
+			opts: func() models.HelmInstallOptions {
+				opts := validOpts()
+				opts.Values = `{"replicaCount": 2, "service": {"type": "ClusterIP"}}`
+				return opts
+			}(),
+			endpointType:  5,
+			expectInstall: true,
+		},
+		{
+			name: "no values",
+			opts: func() models.HelmInstallOptions {
+				opts := validOpts()
+				opts.Values = ""
+				return opts
+			}(),
+			endpointType:  5,
+			expectInstall: true,
+		},
+		{
+			name: "missing chart",
+			opts: func() models.HelmInstallOptions {
+				opts := validOpts()
+				opts.Chart = ""
+				return opts
+			}(),
+			expectedErrMsg: "chart is required",
+		},
+		{
+			name: "invalid release name",
+			opts: func() models.HelmInstallOptions {
+				opts := validOpts()
+				opts.Name = "Web_App"
+				return opts
+			}(),
+			expectedErrMsg: "invalid release name",
+		},
+		{
+			name: "malformed values",
+			opts: func() models.HelmInstallOptions {
+				opts := validOpts()
+				opts.Values = "replicaCount: [2"
+				return opts
+			}(),
+			expectedError:  ErrInvalidHelmValues,
+			expectedErrMsg: "invalid helm values",
+		},
+		{
+			name: "values not an object",
+			opts: func() models.HelmInstallOptions {
+				opts := validOpts()
+				opts.Values = "- replicaCount"
+				return opts
+			}(),
+			expectedError:  ErrInvalidHelmValues,
+			expectedErrMsg: "values must be an object",
+		},
+		{
+			name:           "docker environment",
+			opts:           validOpts(),
+			endpointType:   1,
+			expectedError:  ErrFeatureUnavailable,
+			expectedErrMsg: "Helm is only available on Kubernetes environments",
+		},
+		{
+			name:          "chart not found",
+			opts:          validOpts(),
+			endpointType:  5,
+			expectInstall: true,
+			mockError: fmt.Errorf("failed to install helm chart: %w", &rawclient.APIError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Helm returned an error",
+				Details:    `chart "nginx" version "15.0.0" not found in https://charts.bitnami.com/bitnami repository`,
+			}),
+			expectedError:  ErrHelmChartNotFound,
+			expectedErrMsg: "chart nginx@15.0.0 not found in repository https://charts.bitnami.com/bitnami",
+		},
+		{
+			name:          "values rejected by chart",
+			opts:          validOpts(),
+			endpointType:  5,
+			expectInstall: true,
+			mockError: fmt.Errorf("failed to install helm chart: %w", &rawclient.APIError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Helm returned an error",
+				Details:    "values don't meet the specifications of the schema(s) in the following chart(s)",
+			}),
+			expectedError:  ErrInvalidHelmValues,
+			expectedErrMsg: "values rejected by chart nginx@15.0.0",
+		},
+		{
+			name:          "helm not enabled",
+			opts:          validOpts(),
+			endpointType:  5,
+			expectInstall: true,
+			mockError: fmt.Errorf("failed to install helm chart: %w", &rawclient.APIError{
+				StatusCode: http.StatusNotFound,
+			}),
+			expectedError:  ErrFeatureUnavailable,
+			expectedErrMsg: "helm is not enabled on environment 1",
+		},
+		{
+			name:          "other install error",
+			opts:          validOpts(),
+			endpointType:  5,
+			expectInstall: true,
+			mockError: fmt.Errorf("failed to install helm chart: %w", &rawclient.APIError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "Unable to find the namespace",
+			}),
+			expectedErrMsg: "Unable to find the namespace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectInstall {
+				mockAPI.On("InstallHelmChart", int64(1), tt.opts.Repo, tt.opts.Chart, tt.opts.Version, tt.opts.Namespace, tt.opts.Name, tt.opts.Values).Return(tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.InstallHelmChart(1, tt.opts)
+
+			if !tt.expectInstall {
+				mockAPI.AssertNotCalled(t, "InstallHelmChart", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tt.expectedErrMsg)
+				for _, sentinel := range []error{ErrFeatureUnavailable, ErrHelmChartNotFound, ErrInvalidHelmValues} {
+					assert.Equal(t, sentinel == tt.expectedError, errors.Is(err, sentinel), "errors.Is(err, %v)", sentinel)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// dockerHubDomain is the registry of the images whose reference does not name a registry
+const dockerHubDomain = "docker.io"
+
+// dockerImageInspect is the subset of the Docker image inspect response used to check image updates
+type dockerImageInspect struct {
+	RepoDigests []string `json:"RepoDigests"`
+}
+
+// dockerDistributionInspect is the subset of the Docker distribution inspect response, the descriptor
+// of the manifest the registry serves for a reference
+type dockerDistributionInspect struct {
+	Descriptor struct {
+		Digest string `json:"digest"`
+	} `json:"Descriptor"`
+}
+
+// CheckImageUpdates checks whether the images of the running containers of a Docker environment are
+// outdated. The digest the image was pulled with is compared to the digest the registry serves for the
+// same tag, the registry is queried by the Docker daemon of the environment through the Docker proxy.
+//
+// Images hosted on a registry configured with credentials in Portainer are queried with the ID of the
+// registry in the X-Registry-Auth header, the Docker proxy of Portainer authenticates with the stored
+// credentials. An image is reported as unknown, with the reason, when it cannot be compared: the
+// containers were not created from a tag, the image was built locally or the registry cannot be queried.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//
+// Returns:
+//   - A slice of ImageUpdateStatus objects, one per image and tag, sorted by image
+//   - An error if the environment has no Docker daemon or if the containers cannot be listed
+func (c *PortainerClient) CheckImageUpdates(environmentId int) ([]models.ImageUpdateStatus, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return nil, err
+	}
+
+	var rawContainers []dockerContainer
+	if err := c.getDockerJSON(environmentId, "/containers/json", map[string]string{"all": "0"}, &rawContainers); err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	registries, err := c.cli.ListRegistries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	// Containers running the same tag from the same image share a status
+	byImage := make(map[string]*models.ImageUpdateStatus)
+	for _, rawContainer := range rawContainers {
+		key := rawContainer.Image + "\x00" + rawContainer.ImageID
+		status, ok := byImage[key]
+		if !ok {
+			status = &models.ImageUpdateStatus{
+				Image:      rawContainer.Image,
+				ImageID:    rawContainer.ImageID,
+				Containers: []string{},
+			}
+			byImage[key] = status
+		}
+		status.Containers = append(status.Containers, containerName(rawContainer))
+	}
+
+	statuses := make([]models.ImageUpdateStatus, 0, len(byImage))
+	for _, status := range byImage {
+		sort.Strings(status.Containers)
+		c.checkImageUpdate(environmentId, registries, status)
+		statuses = append(statuses, *status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Image != statuses[j].Image {
+			return statuses[i].Image < statuses[j].Image
+		}
+		return statuses[i].ImageID < statuses[j].ImageID
+	})
+
+	return statuses, nil
+}
+
+// checkImageUpdate compares the local digest of an image with the digest the registry serves for its tag
+// and sets the status accordingly
+func (c *PortainerClient) checkImageUpdate(environmentId int, registries []*apimodels.PortainereeRegistry, status *models.ImageUpdateStatus) {
+	status.Status = models.ImageUpdateUnknown
+
+	if strings.HasPrefix(status.Image, "sha256:") || strings.Contains(status.Image, "@") {
+		status.Reason = "the containers were created from an image ID or digest, not from a tag"
+		return
+	}
+
+	repository := imageRepository(status.Image)
+
+	var image dockerImageInspect
+	if err := c.getDockerJSON(environmentId, "/images/"+status.ImageID+"/json", nil, &image); err != nil {
+		status.Reason = fmt.Sprintf("failed to inspect image: %s", err)
+		return
+	}
+
+	localDigests := []string{}
+	for _, repoDigest := range image.RepoDigests {
+		name, digest, ok := strings.Cut(repoDigest, "@")
+		if ok && familiarRepository(name) == familiarRepository(repository) {
+			localDigests = append(localDigests, digest)
+		}
+	}
+	if len(localDigests) == 0 {
+		status.Reason = "the image has no digest from its registry, it was built or loaded locally"
+		return
+	}
+	sort.Strings(localDigests)
+
+	domain := imageRegistryDomain(repository)
+	registry := findAuthenticatedRegistry(registries, domain)
+
+	headers := map[string]string{}
+	if registry != nil {
+		headers["X-Registry-Auth"] = registryAuthHeader(registry.ID)
+	}
+
+	distribution, err := c.inspectDistribution(environmentId, status.Image, headers)
+	status.LocalDigest = localDigests[0]
+	if err != nil {
+		status.Reason = err.Error()
+		return
+	}
+	if distribution.statusCode == http.StatusUnauthorized || distribution.statusCode == http.StatusForbidden {
+		status.Reason = authenticationFailureReason(domain, registry)
+		return
+	}
+	if distribution.statusCode != http.StatusOK {
+		status.Reason = fmt.Sprintf("failed to query the registry: unexpected status %d: %s", distribution.statusCode, distribution.body)
+		return
+	}
+
+	status.RemoteDigest = distribution.Descriptor.Digest
+	if slices.Contains(localDigests, status.RemoteDigest) {
+		status.LocalDigest = status.RemoteDigest
+		status.Status = models.ImageUpdateUpToDate
+		return
+	}
+
+	status.Status = models.ImageUpdateAvailable
+}
+
+// distributionResponse is the response of the Docker distribution inspect endpoint
+type distributionResponse struct {
+	dockerDistributionInspect
+	statusCode int
+	body       string
+}
+
+// inspectDistribution queries the registry of an image for the manifest it serves for the image
+// reference, through the Docker daemon of an environment
+func (c *PortainerClient) inspectDistribution(environmentId int, image string, headers map[string]string) (distributionResponse, error) {
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          "/distribution/" + image + "/json",
+		Headers:       headers,
+	})
+	if err != nil {
+		return distributionResponse{}, fmt.Errorf("failed to proxy docker request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return distributionResponse{}, fmt.Errorf("failed to read docker response: %w", err)
+	}
+
+	response := distributionResponse{statusCode: resp.StatusCode, body: strings.TrimSpace(string(body))}
+	if resp.StatusCode == http.StatusOK {
+		if err := json.Unmarshal(body, &response.dockerDistributionInspect); err != nil {
+			return distributionResponse{}, fmt.Errorf("failed to decode distribution response: %w", err)
+		}
+	}
+
+	return response, nil
+}
+
+// authenticationFailureReason explains a registry authentication failure
+func authenticationFailureReason(domain string, registry *apimodels.PortainereeRegistry) string {
+	if registry == nil {
+		return fmt.Sprintf("the registry %s requires authentication and no registry with credentials is configured in Portainer for it", domain)
+	}
+	return fmt.Sprintf("the registry %s rejected the credentials of the Portainer registry %s", domain, registry.Name)
+}
+
+// findAuthenticatedRegistry returns the registry configured with credentials in Portainer for a registry
+// domain, nil when there is none
+func findAuthenticatedRegistry(registries []*apimodels.PortainereeRegistry, domain string) *apimodels.PortainereeRegistry {
+	for _, registry := range registries {
+		if registry.Authentication && registryURLDomain(registry.URL) == domain {
+			return registry
+		}
+	}
+	return nil
+}
+
+// registryAuthHeader builds the X-Registry-Auth header referencing a registry of Portainer
+func registryAuthHeader(registryId int64) string {
+	data, _ := json.Marshal(map[string]int64{"registryId": registryId})
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// dockerHubAliases are the other domains of Docker Hub found in image references and registry URLs
+var dockerHubAliases = []string{"index.docker.io", "registry-1.docker.io"}
+
+// imageRegistryDomain returns the registry domain of a repository, Docker Hub when the first component
+// of the repository is not a hostname
+func imageRegistryDomain(repository string) string {
+	first, _, ok := strings.Cut(repository, "/")
+	if !ok || (!strings.ContainsAny(first, ".:") && first != "localhost") || slices.Contains(dockerHubAliases, first) {
+		return dockerHubDomain
+	}
+	return first
+}
+
+// familiarRepository returns the short form of a repository Docker uses in the repository digests,
+// e.g. nginx for docker.io/library/nginx
+func familiarRepository(repository string) string {
+	if imageRegistryDomain(repository) != dockerHubDomain {
+		return repository
+	}
+
+	if first, rest, ok := strings.Cut(repository, "/"); ok && (first == dockerHubDomain || slices.Contains(dockerHubAliases, first)) {
+		repository = rest
+	}
+	return strings.TrimPrefix(repository, "library/")
+}
+
+// registryURLDomain returns the domain of the URL of a Portainer registry, without scheme nor path
+func registryURLDomain(registryURL string) string {
+	domain := registryURL
+	if _, rest, ok := strings.Cut(domain, "://"); ok {
+		domain = rest
+	}
+	domain, _, _ = strings.Cut(domain, "/")
+	if slices.Contains(dockerHubAliases, domain) {
+		return dockerHubDomain
+	}
+	return domain
+}
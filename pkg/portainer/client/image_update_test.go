@@ -0,0 +1,138 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckImageUpdates(t *testing.T) {
+	containers := `[
+		{"Id":"c1","Names":["/web-2"],"Image":"nginx:latest","ImageID":"sha256:n1","State":"running"},
+		{"Id":"c2","Names":["/web-1"],"Image":"nginx:latest","ImageID":"sha256:n1","State":"running"},
+		{"Id":"c3","Names":["/api"],"Image":"myorg/api:1.2","ImageID":"sha256:a1","State":"running"},
+		{"Id":"c4","Names":["/app"],"Image":"registry.example.com/app:2","ImageID":"sha256:p1","State":"running"},
+		{"Id":"c5","Names":["/dev"],"Image":"myapp:dev","ImageID":"sha256:l1","State":"running"},
+		{"Id":"c6","Names":["/cache"],"Image":"redis@sha256:rrr","ImageID":"sha256:r1","State":"running"},
+		{"Id":"c7","Names":["/tool"],"Image":"quay.io/org/tool:1","ImageID":"sha256:q1","State":"running"}
+	]`
+
+	// dockerResponses maps a Docker API path to the status code and the body returned by the mock
+	dockerResponses := map[string]struct {
+		status int
+		body   string
+	}{
+		"/containers/json":                              {http.StatusOK, containers},
+		"/images/sha256:n1/json":                        {http.StatusOK, `{"RepoDigests":["nginx@sha256:aaa"]}`},
+		"/distribution/nginx:latest/json":               {http.StatusOK, `{"Descriptor":{"digest":"sha256:aaa"}}`},
+		"/images/sha256:a1/json":                        {http.StatusOK, `{"RepoDigests":["myorg/api@sha256:old"]}`},
+		"/distribution/myorg/api:1.2/json":              {http.StatusOK, `{"Descriptor":{"digest":"sha256:new"}}`},
+		"/images/sha256:p1/json":                        {http.StatusOK, `{"RepoDigests":["registry.example.com/app@sha256:ppp"]}`},
+		"/distribution/registry.example.com/app:2/json": {http.StatusUnauthorized, `{"message":"unauthorized"}`},
+		"/images/sha256:l1/json":                        {http.StatusOK, `{"RepoDigests":[]}`},
+		"/images/sha256:q1/json":                        {http.StatusOK, `{"RepoDigests":["quay.io/org/tool@sha256:qqq"]}`},
+		"/distribution/quay.io/org/tool:1/json":         {http.StatusUnauthorized, `{"message":"unauthorized"}`},
+	}
+
+	registries := []*apimodels.PortainereeRegistry{
+		{ID: 2, Name: "public", URL: "quay.io"},
+		{ID: 3, Name: "private", URL: "https://registry.example.com/", Authentication: true},
+	}
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+	mockAPI.On("ListRegistries").Return(registries, nil)
+	for path, response := range dockerResponses {
+		mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+			return opts.Method == http.MethodGet && opts.APIPath == path
+		})).Return(&http.Response{StatusCode: response.status, Body: io.NopCloser(strings.NewReader(response.body))}, nil)
+	}
+
+	c := &PortainerClient{cli: mockAPI}
+
+	statuses, err := c.CheckImageUpdates(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImageUpdateStatus{
+		{
+			Image: "myapp:dev", ImageID: "sha256:l1", Containers: []string{"dev"},
+			Status: models.ImageUpdateUnknown, Reason: "the image has no digest from its registry, it was built or loaded locally",
+		},
+		{
+			Image: "myorg/api:1.2", ImageID: "sha256:a1", Containers: []string{"api"},
+			LocalDigest: "sha256:old", RemoteDigest: "sha256:new", Status: models.ImageUpdateAvailable,
+		},
+		{
+			Image: "nginx:latest", ImageID: "sha256:n1", Containers: []string{"web-1", "web-2"},
+			LocalDigest: "sha256:aaa", RemoteDigest: "sha256:aaa", Status: models.ImageUpdateUpToDate,
+		},
+		{
+			Image: "quay.io/org/tool:1", ImageID: "sha256:q1", Containers: []string{"tool"}, LocalDigest: "sha256:qqq",
+			Status: models.ImageUpdateUnknown, Reason: "the registry quay.io requires authentication and no registry with credentials is configured in Portainer for it",
+		},
+		{
+			Image: "redis@sha256:rrr", ImageID: "sha256:r1", Containers: []string{"cache"},
+			Status: models.ImageUpdateUnknown, Reason: "the containers were created from an image ID or digest, not from a tag",
+		},
+		{
+			Image: "registry.example.com/app:2", ImageID: "sha256:p1", Containers: []string{"app"}, LocalDigest: "sha256:ppp",
+			Status: models.ImageUpdateUnknown, Reason: "the registry registry.example.com rejected the credentials of the Portainer registry private",
+		},
+	}, statuses)
+
+	// Only the registry configured with credentials is referenced in the X-Registry-Auth header
+	mockAPI.AssertCalled(t, "ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.APIPath == "/distribution/registry.example.com/app:2/json" && opts.Headers["X-Registry-Auth"] == "eyJyZWdpc3RyeUlkIjozfQ=="
+	}))
+	mockAPI.AssertCalled(t, "ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.APIPath == "/distribution/quay.io/org/tool:1/json" && opts.Headers == nil
+	}))
+}
+
+func TestCheckImageUpdatesErrors(t *testing.T) {
+	t.Run("kubernetes environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 5}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.CheckImageUpdates(1)
+
+		assert.ErrorContains(t, err, "it has no Docker daemon")
+	})
+
+	t.Run("list containers error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.CheckImageUpdates(1)
+
+		assert.ErrorContains(t, err, "failed to list containers: connection refused")
+		mockAPI.AssertNotCalled(t, "ListRegistries")
+	})
+
+	t.Run("list registries error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[]`))}, nil)
+		mockAPI.On("ListRegistries").Return(nil, errors.New("forbidden"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.CheckImageUpdates(1)
+
+		assert.ErrorContains(t, err, "failed to list registries: forbidden")
+	})
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/portainer/client-api-go/v2/client"
@@ -32,3 +33,32 @@ func (c *PortainerClient) ProxyKubernetesRequest(opts models.KubernetesProxyRequ
 
 	return c.cli.ProxyKubernetesRequest(opts.EnvironmentID, proxyOpts)
 }
+
+// GetKubeconfig retrieves the kubeconfig of a Kubernetes environment.
+// The kubeconfig embeds a token tied to the Portainer identity of the API token used by the client,
+// anyone holding it can reach the cluster through Portainer with the same rights. It must not be logged.
+//
+// Parameters:
+//   - environmentId: The ID of the Kubernetes environment
+//
+// Returns:
+//   - The kubeconfig in YAML format
+//   - An error if the environment is not a Kubernetes environment or if the operation fails
+func (c *PortainerClient) GetKubeconfig(environmentId int) (string, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(environmentId))
+	if err != nil {
+		return "", fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if !models.IsKubernetesEnvironment(environment.Type) {
+		return "", fmt.Errorf("environment %d is a %s environment, kubeconfig is only available for Kubernetes environments", environmentId, environment.Type)
+	}
+
+	kubeconfig, err := c.cli.GetKubeconfig(int64(environmentId))
+	if err != nil {
+		return "", fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	return kubeconfig, nil
+}
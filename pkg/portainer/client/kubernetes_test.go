@@ -9,8 +9,10 @@ import (
 	"testing"
 
 	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestProxyKubernetesRequest(t *testing.T) {
@@ -129,3 +131,76 @@ func TestProxyKubernetesRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKubeconfig(t *testing.T) {
+	const kubeconfig = "apiVersion: v1\nkind: Config\ncurrent-context: portainer-ctx-prod\n"
+
+	tests := []struct {
+		name             string
+		endpointType     int64
+		mockEndpointErr  error
+		expectKubeconfig bool
+		mockError        error
+		expected         string
+		expectedError    bool
+	}{
+		{
+			name:             "kubernetes environment",
+			endpointType:     5,
+			expectKubeconfig: true,
+			expected:         kubeconfig,
+		},
+		{
+			name:             "kubernetes edge environment",
+			endpointType:     7,
+			expectKubeconfig: true,
+			expected:         kubeconfig,
+		},
+		{
+			name:          "docker environment",
+			endpointType:  1,
+			expectedError: true,
+		},
+		{
+			name:            "get endpoint error",
+			mockEndpointErr: errors.New("failed to get endpoint"),
+			expectedError:   true,
+		},
+		{
+			name:             "get kubeconfig error",
+			endpointType:     6,
+			expectKubeconfig: true,
+			mockError:        errors.New("failed to get kubeconfig"),
+			expectedError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.mockEndpointErr != nil {
+				mockAPI.On("GetEndpoint", int64(1)).Return(nil, tt.mockEndpointErr)
+			} else {
+				mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			}
+			if tt.expectKubeconfig {
+				mockAPI.On("GetKubeconfig", int64(1)).Return(tt.expected, tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			config, err := client.GetKubeconfig(1)
+
+			if !tt.expectKubeconfig {
+				mockAPI.AssertNotCalled(t, "GetKubeconfig", mock.Anything)
+			}
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
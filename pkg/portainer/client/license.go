@@ -0,0 +1,33 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// GetLicenseInfo retrieves the license status of the Portainer server, including the license type,
+// the number of licensed and used nodes and the expiry date.
+// Portainer Community Edition does not use licenses and does not expose the license API,
+// in which case a license status without license is returned instead of an error.
+//
+// Returns:
+//   - The license status of the Portainer server
+//   - An error if the operation fails
+func (c *PortainerClient) GetLicenseInfo() (models.LicenseInfo, error) {
+	rawInfo, err := c.cli.GetLicenseInfo()
+	if err != nil {
+		if isNotFoundError(err) {
+			return models.CommunityLicenseInfo(), nil
+		}
+		return models.LicenseInfo{}, fmt.Errorf("failed to get license info: %w", err)
+	}
+
+	usedNodes, err := c.cli.GetNodesCount()
+	if err != nil {
+		return models.LicenseInfo{}, fmt.Errorf("failed to get nodes count: %w", err)
+	}
+
+	return models.ConvertToLicenseInfo(rawInfo, usedNodes, time.Now()), nil
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLicenseInfo(t *testing.T) {
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).Unix()
+
+	tests := []struct {
+		name          string
+		mockInfo      *apimodels.LicensesLicenseInfo
+		mockInfoErr   error
+		expectNodes   bool
+		mockNodes     int64
+		mockNodesErr  error
+		expected      models.LicenseInfo
+		expectedError bool
+	}{
+		{
+			name: "business edition",
+			mockInfo: &apimodels.LicensesLicenseInfo{
+				Company:   "acme",
+				ExpiresAt: expiresAt,
+				Nodes:     10,
+				Type:      2,
+				Valid:     true,
+			},
+			expectNodes: true,
+			mockNodes:   7,
+			expected: models.LicenseInfo{
+				Edition:       models.EditionBusiness,
+				Type:          models.LicenseTypeSubscription,
+				Company:       "acme",
+				Valid:         true,
+				LicensedNodes: 10,
+				UsedNodes:     7,
+				ExpiresAt:     time.Unix(expiresAt, 0).UTC().Format(time.RFC3339),
+			},
+		},
+		{
+			name:        "community edition",
+			mockInfoErr: runtime.NewAPIError("LicensesInfo", nil, http.StatusNotFound),
+			expected:    models.CommunityLicenseInfo(),
+		},
+		{
+			name:          "license info error",
+			mockInfoErr:   errors.New("failed to get license info"),
+			expectedError: true,
+		},
+		{
+			name:          "nodes count error",
+			mockInfo:      &apimodels.LicensesLicenseInfo{Nodes: 10},
+			expectNodes:   true,
+			mockNodesErr:  errors.New("failed to get nodes count"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetLicenseInfo").Return(tt.mockInfo, tt.mockInfoErr)
+			if tt.expectNodes {
+				mockAPI.On("GetNodesCount").Return(tt.mockNodes, tt.mockNodesErr)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			info, err := client.GetLicenseInfo()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			if tt.expected.Edition == models.EditionBusiness {
+				assert.NotEmpty(t, info.Summary)
+				info.Summary = ""
+			}
+			assert.Equal(t, tt.expected, info)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -95,12 +95,39 @@ func (m *MockPortainerAPI) UpdateEdgeStack(id int64, file string, environmentGro
 	return args.Error(0)
 }
 
+// DeleteEdgeStack mocks the DeleteEdgeStack method
+func (m *MockPortainerAPI) DeleteEdgeStack(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 // GetEdgeStackFile mocks the GetEdgeStackFile method
 func (m *MockPortainerAPI) GetEdgeStackFile(id int64) (string, error) {
 	args := m.Called(id)
 	return args.String(0), args.Error(1)
 }
 
+// GetEdgeStack mocks the GetEdgeStack method
+func (m *MockPortainerAPI) GetEdgeStack(id int64) (*apimodels.PortainereeEdgeStack, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.PortainereeEdgeStack), args.Error(1)
+}
+
+// RedeployEdgeStackFromGit mocks the RedeployEdgeStackFromGit method
+func (m *MockPortainerAPI) RedeployEdgeStackFromGit(stack *apimodels.PortainereeEdgeStack, rePullImage bool) error {
+	args := m.Called(stack, rePullImage)
+	return args.Error(0)
+}
+
+// UpdateEdgeStackAutoUpdate mocks the UpdateEdgeStackAutoUpdate method
+func (m *MockPortainerAPI) UpdateEdgeStackAutoUpdate(stack *apimodels.PortainereeEdgeStack, autoUpdate *apimodels.PortainerAutoUpdateSettings) error {
+	args := m.Called(stack, autoUpdate)
+	return args.Error(0)
+}
+
 // ListEndpointGroups mocks the ListEndpointGroups method
 func (m *MockPortainerAPI) ListEndpointGroups() ([]*apimodels.PortainerEndpointGroup, error) {
 	args := m.Called()
@@ -167,6 +194,48 @@ func (m *MockPortainerAPI) GetSettings() (*apimodels.PortainereeSettings, error)
 	return args.Get(0).(*apimodels.PortainereeSettings), args.Error(1)
 }
 
+// GetPublicSettings mocks the GetPublicSettings method
+func (m *MockPortainerAPI) GetPublicSettings() (*apimodels.SettingsPublicSettingsResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.SettingsPublicSettingsResponse), args.Error(1)
+}
+
+// UpdateAuthSettings mocks the UpdateAuthSettings method
+func (m *MockPortainerAPI) UpdateAuthSettings(authenticationMethod int64, oauthSettings *apimodels.PortainereeOAuthSettings) error {
+	args := m.Called(authenticationMethod, oauthSettings)
+	return args.Error(0)
+}
+
+// UpdateBrandingSettings mocks the UpdateBrandingSettings method
+func (m *MockPortainerAPI) UpdateBrandingSettings(logoURL, loginBanner string) error {
+	args := m.Called(logoURL, loginBanner)
+	return args.Error(0)
+}
+
+// UpdateSessionTimeout mocks the UpdateSessionTimeout method
+func (m *MockPortainerAPI) UpdateSessionTimeout(sessionTimeout string) error {
+	args := m.Called(sessionTimeout)
+	return args.Error(0)
+}
+
+// GetSSLSettings mocks the GetSSLSettings method
+func (m *MockPortainerAPI) GetSSLSettings() (*apimodels.PortainereeSSLSettings, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.PortainereeSSLSettings), args.Error(1)
+}
+
+// UpdateSSLSettings mocks the UpdateSSLSettings method
+func (m *MockPortainerAPI) UpdateSSLSettings(cert, key string, httpEnabled bool) error {
+	args := m.Called(cert, key, httpEnabled)
+	return args.Error(0)
+}
+
 // ListTags mocks the ListTags method
 func (m *MockPortainerAPI) ListTags() ([]*apimodels.PortainerTag, error) {
 	args := m.Called()
@@ -233,12 +302,36 @@ func (m *MockPortainerAPI) ListUsers() ([]*apimodels.PortainereeUser, error) {
 	return args.Get(0).([]*apimodels.PortainereeUser), args.Error(1)
 }
 
+// GetCurrentUser mocks the GetCurrentUser method
+func (m *MockPortainerAPI) GetCurrentUser() (*apimodels.PortainereeUser, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.PortainereeUser), args.Error(1)
+}
+
 // UpdateUserRole mocks the UpdateUserRole method
 func (m *MockPortainerAPI) UpdateUserRole(id int, role int64) error {
 	args := m.Called(id, role)
 	return args.Error(0)
 }
 
+// GetUser mocks the GetUser method
+func (m *MockPortainerAPI) GetUser(id int) (*apimodels.PortainereeUser, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.PortainereeUser), args.Error(1)
+}
+
+// UpdateUserPreferences mocks the UpdateUserPreferences method
+func (m *MockPortainerAPI) UpdateUserPreferences(id int, themeColor string, subtleUpgradeButton, useCache bool) error {
+	args := m.Called(id, themeColor, subtleUpgradeButton, useCache)
+	return args.Error(0)
+}
+
 // GetVersion mocks the GetVersion method
 func (m *MockPortainerAPI) GetVersion() (string, error) {
 	args := m.Called()
@@ -262,3 +355,216 @@ func (m *MockPortainerAPI) ProxyKubernetesRequest(environmentId int, opts client
 	}
 	return args.Get(0).(*http.Response), args.Error(1)
 }
+
+// ListUserActivityLogs mocks the ListUserActivityLogs method
+func (m *MockPortainerAPI) ListUserActivityLogs(after, before, limit int64) ([]*apimodels.PortainereeUserActivityLog, error) {
+	args := m.Called(after, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apimodels.PortainereeUserActivityLog), args.Error(1)
+}
+
+// PageUserActivityLogs mocks the PageUserActivityLogs method
+func (m *MockPortainerAPI) PageUserActivityLogs(after, before, limit, offset int64) ([]*apimodels.PortainereeUserActivityLog, int64, error) {
+	args := m.Called(after, before, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*apimodels.PortainereeUserActivityLog), args.Get(1).(int64), args.Error(2)
+}
+
+// UpdateResourceControl mocks the UpdateResourceControl method
+func (m *MockPortainerAPI) UpdateResourceControl(id int64, public, administratorsOnly bool, users, teams []int64) (*apimodels.PortainerResourceControl, error) {
+	args := m.Called(id, public, administratorsOnly, users, teams)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.PortainerResourceControl), args.Error(1)
+}
+
+// UpdateEndpointGPUs mocks the UpdateEndpointGPUs method
+func (m *MockPortainerAPI) UpdateEndpointGPUs(id int64, gpus []*apimodels.PortainerPair) error {
+	args := m.Called(id, gpus)
+	return args.Error(0)
+}
+
+// UpdateEndpointPublicURL mocks the UpdateEndpointPublicURL method
+func (m *MockPortainerAPI) UpdateEndpointPublicURL(id int64, publicURL string) error {
+	args := m.Called(id, publicURL)
+	return args.Error(0)
+}
+
+// ListEdgeConfigs mocks the ListEdgeConfigs method
+func (m *MockPortainerAPI) ListEdgeConfigs() ([]*apimodels.PortainereeEdgeConfig, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apimodels.PortainereeEdgeConfig), args.Error(1)
+}
+
+// CreateEdgeConfig mocks the CreateEdgeConfig method
+func (m *MockPortainerAPI) CreateEdgeConfig(name, configType, category, baseDir string, edgeGroupIDs []int64, archive []byte) error {
+	args := m.Called(name, configType, category, baseDir, edgeGroupIDs, archive)
+	return args.Error(0)
+}
+
+// DeleteEdgeConfig mocks the DeleteEdgeConfig method
+func (m *MockPortainerAPI) DeleteEdgeConfig(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// ListCustomTemplates mocks the ListCustomTemplates method
+func (m *MockPortainerAPI) ListCustomTemplates() ([]*apimodels.PortainereeCustomTemplate, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apimodels.PortainereeCustomTemplate), args.Error(1)
+}
+
+// GetCustomTemplate mocks the GetCustomTemplate method
+func (m *MockPortainerAPI) GetCustomTemplate(id int64) (*apimodels.PortainereeCustomTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.PortainereeCustomTemplate), args.Error(1)
+}
+
+// GetCustomTemplateFile mocks the GetCustomTemplateFile method
+func (m *MockPortainerAPI) GetCustomTemplateFile(id int64) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+// CreateCustomTemplateFromFile mocks the CreateCustomTemplateFromFile method
+func (m *MockPortainerAPI) CreateCustomTemplateFromFile(payload *apimodels.CustomtemplatesCustomTemplateFromFileContentPayload) (int64, error) {
+	args := m.Called(payload)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// CreateCustomTemplateFromGit mocks the CreateCustomTemplateFromGit method
+func (m *MockPortainerAPI) CreateCustomTemplateFromGit(payload *apimodels.CustomtemplatesCustomTemplateFromGitRepositoryPayload) (int64, error) {
+	args := m.Called(payload)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// DeleteCustomTemplate mocks the DeleteCustomTemplate method
+func (m *MockPortainerAPI) DeleteCustomTemplate(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// ListEdgeJobs mocks the ListEdgeJobs method
+func (m *MockPortainerAPI) ListEdgeJobs() ([]*apimodels.PortainerEdgeJob, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apimodels.PortainerEdgeJob), args.Error(1)
+}
+
+// CreateEdgeJob mocks the CreateEdgeJob method
+func (m *MockPortainerAPI) CreateEdgeJob(payload *apimodels.EdgejobsEdgeJobCreateFromFileContentPayload) (int64, error) {
+	args := m.Called(payload)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// DeleteEdgeJob mocks the DeleteEdgeJob method
+func (m *MockPortainerAPI) DeleteEdgeJob(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// ListEdgeJobTasks mocks the ListEdgeJobTasks method
+func (m *MockPortainerAPI) ListEdgeJobTasks(id int64) ([]*apimodels.EdgejobsTaskContainer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apimodels.EdgejobsTaskContainer), args.Error(1)
+}
+
+// GetEdgeJobTaskLogs mocks the GetEdgeJobTaskLogs method
+func (m *MockPortainerAPI) GetEdgeJobTaskLogs(id, taskId int64) (string, error) {
+	args := m.Called(id, taskId)
+	return args.String(0), args.Error(1)
+}
+
+// GetLicenseInfo mocks the GetLicenseInfo method
+func (m *MockPortainerAPI) GetLicenseInfo() (*apimodels.LicensesLicenseInfo, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apimodels.LicensesLicenseInfo), args.Error(1)
+}
+
+// GetNodesCount mocks the GetNodesCount method
+func (m *MockPortainerAPI) GetNodesCount() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// SnapshotEndpoint mocks the SnapshotEndpoint method
+func (m *MockPortainerAPI) SnapshotEndpoint(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// GetKubeconfig mocks the GetKubeconfig method
+func (m *MockPortainerAPI) GetKubeconfig(environmentId int64) (string, error) {
+	args := m.Called(environmentId)
+	return args.String(0), args.Error(1)
+}
+
+// ListHelmReleases mocks the ListHelmReleases method
+func (m *MockPortainerAPI) ListHelmReleases(environmentId int64, namespace string) ([]*apimodels.ReleaseReleaseElement, error) {
+	args := m.Called(environmentId, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apimodels.ReleaseReleaseElement), args.Error(1)
+}
+
+// InstallHelmChart mocks the InstallHelmChart method
+func (m *MockPortainerAPI) InstallHelmChart(environmentId int64, repo, chart, version, namespace, name, values string) error {
+	args := m.Called(environmentId, repo, chart, version, namespace, name, values)
+	return args.Error(0)
+}
+
+// CreateTeamMembershipWithRole mocks the CreateTeamMembershipWithRole method
+func (m *MockPortainerAPI) CreateTeamMembershipWithRole(teamId, userId int, role int64) error {
+	args := m.Called(teamId, userId, role)
+	return args.Error(0)
+}
+
+// UpdateTeamMembershipRole mocks the UpdateTeamMembershipRole method
+func (m *MockPortainerAPI) UpdateTeamMembershipRole(id, teamId, userId int, role int64) error {
+	args := m.Called(id, teamId, userId, role)
+	return args.Error(0)
+}
+
+// ListRegistries mocks the ListRegistries method
+func (m *MockPortainerAPI) ListRegistries() ([]*apimodels.PortainereeRegistry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apimodels.PortainereeRegistry), args.Error(1)
+}
+
+// UpdateEndpointRegistryAccess mocks the UpdateEndpointRegistryAccess method
+func (m *MockPortainerAPI) UpdateEndpointRegistryAccess(endpointId, registryId int64, userIds, teamIds []int64) error {
+	args := m.Called(endpointId, registryId, userIds, teamIds)
+	return args.Error(0)
+}
+
+// GetGitRepositoryFile mocks the GetGitRepositoryFile method
+func (m *MockPortainerAPI) GetGitRepositoryFile(payload *apimodels.GitopsRepositoryFilePreviewPayload) (string, error) {
+	args := m.Called(payload)
+	return args.String(0), args.Error(1)
+}
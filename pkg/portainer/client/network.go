@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// dockerNetworkConnect is the body of the Docker network connect and disconnect requests
+type dockerNetworkConnect struct {
+	Container string `json:"Container"`
+	Force     bool   `json:"Force,omitempty"`
+}
+
+// ConnectContainerToNetwork connects a container to a network through the Docker proxy,
+// the equivalent of the `docker network connect` command.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - networkId: The ID or the name of the network
+//   - containerId: The ID or the name of the container
+//
+// Returns:
+//   - An error if the environment has no Docker daemon or if Docker rejects the connection, e.g. when
+//     the container is already attached to the network (the Docker error message is included)
+func (c *PortainerClient) ConnectContainerToNetwork(environmentId int, networkId, containerId string) error {
+	if err := c.updateNetworkContainer(environmentId, networkId, "connect", dockerNetworkConnect{Container: containerId}); err != nil {
+		return fmt.Errorf("failed to connect container %s to network %s: %w", containerId, networkId, err)
+	}
+
+	return nil
+}
+
+// DisconnectContainerFromNetwork disconnects a container from a network through the Docker proxy,
+// the equivalent of the `docker network disconnect` command.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - networkId: The ID or the name of the network
+//   - containerId: The ID or the name of the container
+//   - force: Whether to force the disconnection, e.g. of a container that is not running anymore
+//
+// Returns:
+//   - An error if the environment has no Docker daemon or if Docker rejects the disconnection, e.g. when
+//     the container is not attached to the network (the Docker error message is included)
+func (c *PortainerClient) DisconnectContainerFromNetwork(environmentId int, networkId, containerId string, force bool) error {
+	if err := c.updateNetworkContainer(environmentId, networkId, "disconnect", dockerNetworkConnect{Container: containerId, Force: force}); err != nil {
+		return fmt.Errorf("failed to disconnect container %s from network %s: %w", containerId, networkId, err)
+	}
+
+	return nil
+}
+
+// updateNetworkContainer sends a connect or disconnect request for a container to the Docker API
+// of an environment through the Docker proxy
+func (c *PortainerClient) updateNetworkContainer(environmentId int, networkId, action string, payload dockerNetworkConnect) error {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode network %s request: %w", action, err)
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodPost,
+		Path:          fmt.Sprintf("/networks/%s/%s", url.PathEscape(networkId), action),
+		Headers:       map[string]string{"Content-Type": "application/json"},
+		Body:          bytes.NewReader(body),
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockNetworkRequest sets up the calls made to connect or disconnect a container of the Docker environment 1
+// and captures the options of the Docker request
+func mockNetworkRequest(mockAPI *MockPortainerAPI, path string, status int, body string, sent *client.ProxyRequestOptions) {
+	mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+	mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.Method == http.MethodPost && opts.APIPath == path
+	})).Run(func(args mock.Arguments) {
+		*sent = args.Get(1).(client.ProxyRequestOptions)
+	}).Return(&http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil)
+}
+
+func TestConnectContainerToNetwork(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockStatus    int
+		mockBody      string
+		expectedError string
+	}{
+		{
+			name:       "successful connection",
+			mockStatus: http.StatusOK,
+		},
+		{
+			name:          "already attached",
+			mockStatus:    http.StatusForbidden,
+			mockBody:      `{"message":"endpoint with name web already exists in network backend"}`,
+			expectedError: "failed to connect container web to network backend: unexpected status 403: {\"message\":\"endpoint with name web already exists in network backend\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent client.ProxyRequestOptions
+			mockAPI := new(MockPortainerAPI)
+			mockNetworkRequest(mockAPI, "/networks/backend/connect", tt.mockStatus, tt.mockBody, &sent)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.ConnectContainerToNetwork(1, "backend", "web")
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(sent.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"Container": "web"}, body)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisconnectContainerFromNetwork(t *testing.T) {
+	tests := []struct {
+		name          string
+		force         bool
+		mockStatus    int
+		mockBody      string
+		expectedBody  map[string]any
+		expectedError string
+	}{
+		{
+			name:         "successful disconnection",
+			mockStatus:   http.StatusOK,
+			expectedBody: map[string]any{"Container": "web"},
+		},
+		{
+			name:         "forced disconnection",
+			force:        true,
+			mockStatus:   http.StatusOK,
+			expectedBody: map[string]any{"Container": "web", "Force": true},
+		},
+		{
+			name:          "container not attached",
+			mockStatus:    http.StatusForbidden,
+			mockBody:      `{"message":"container 3f4e9a is not connected to network backend"}`,
+			expectedBody:  map[string]any{"Container": "web"},
+			expectedError: "container 3f4e9a is not connected to network backend",
+		},
+		{
+			name:          "network not found",
+			mockStatus:    http.StatusNotFound,
+			mockBody:      `{"message":"network backend not found"}`,
+			expectedBody:  map[string]any{"Container": "web"},
+			expectedError: "unexpected status 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent client.ProxyRequestOptions
+			mockAPI := new(MockPortainerAPI)
+			mockNetworkRequest(mockAPI, "/networks/backend/disconnect", tt.mockStatus, tt.mockBody, &sent)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.DisconnectContainerFromNetwork(1, "backend", "web", tt.force)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(sent.Body).Decode(&body))
+			assert.Equal(t, tt.expectedBody, body)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("kubernetes environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 5}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.DisconnectContainerFromNetwork(1, "backend", "web", false)
+
+		assert.ErrorContains(t, err, "it has no Docker daemon")
+		mockAPI.AssertNotCalled(t, "ProxyDockerRequest", mock.Anything, mock.Anything)
+	})
+}
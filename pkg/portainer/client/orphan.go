@@ -0,0 +1,131 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// systemNetworks are the networks Docker creates itself, they are never reported as orphaned.
+// ingress and docker_gwbridge are created when the daemon joins a Swarm cluster.
+var systemNetworks = []string{"bridge", "host", "none", "ingress", "docker_gwbridge"}
+
+// dockerContainerAttachments is the subset of the Docker container list response listing the
+// volumes mounted by a container and the networks it is attached to
+type dockerContainerAttachments struct {
+	Mounts []struct {
+		Type string `json:"Type"`
+		Name string `json:"Name"`
+	} `json:"Mounts"`
+	NetworkSettings struct {
+		Networks map[string]struct {
+			NetworkID string `json:"NetworkID"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// dockerVolumeList is the response of the Docker volume list endpoint
+type dockerVolumeList struct {
+	Volumes []struct {
+		Name      string            `json:"Name"`
+		Driver    string            `json:"Driver"`
+		CreatedAt string            `json:"CreatedAt"`
+		Labels    map[string]string `json:"Labels"`
+	} `json:"Volumes"`
+}
+
+// dockerNetwork is the subset of the Docker network list response used to find orphaned networks
+type dockerNetwork struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Driver string `json:"Driver"`
+	Scope  string `json:"Scope"`
+}
+
+// FindOrphanedResources lists the volumes and the networks of a Docker environment that no container,
+// running or stopped, uses, through the Docker proxy. Unlike the prunes, nothing is removed.
+//
+// The system networks (bridge, host, none and the ingress and docker_gwbridge networks of Swarm) are
+// never reported. The Swarm scoped networks are not reported either, they can be used by the tasks of
+// services running on other nodes of the cluster.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//
+// Returns:
+//   - An OrphanReport listing the orphaned volumes and networks, sorted by name
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) FindOrphanedResources(environmentId int) (models.OrphanReport, error) {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return models.OrphanReport{}, err
+	}
+
+	var containers []dockerContainerAttachments
+	if err := c.getDockerJSON(environmentId, "/containers/json", map[string]string{"all": "1"}, &containers); err != nil {
+		return models.OrphanReport{}, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var volumes dockerVolumeList
+	if err := c.getDockerJSON(environmentId, "/volumes", nil, &volumes); err != nil {
+		return models.OrphanReport{}, fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	var networks []dockerNetwork
+	if err := c.getDockerJSON(environmentId, "/networks", nil, &networks); err != nil {
+		return models.OrphanReport{}, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	usedVolumes := make(map[string]bool)
+	usedNetworks := make(map[string]bool)
+	for _, container := range containers {
+		for _, mount := range container.Mounts {
+			if mount.Type == "volume" {
+				usedVolumes[mount.Name] = true
+			}
+		}
+		for _, network := range container.NetworkSettings.Networks {
+			usedNetworks[network.NetworkID] = true
+		}
+	}
+
+	report := models.OrphanReport{
+		EnvironmentID: environmentId,
+		Volumes:       []models.OrphanedVolume{},
+		Networks:      []models.OrphanedNetwork{},
+	}
+
+	for _, volume := range volumes.Volumes {
+		if usedVolumes[volume.Name] {
+			continue
+		}
+		report.Volumes = append(report.Volumes, models.OrphanedVolume{
+			Name:      volume.Name,
+			Driver:    volume.Driver,
+			CreatedAt: volume.CreatedAt,
+			Labels:    volume.Labels,
+		})
+	}
+
+	for _, network := range networks {
+		if usedNetworks[network.ID] || network.Scope == "swarm" || slices.Contains(systemNetworks, network.Name) {
+			continue
+		}
+		report.Networks = append(report.Networks, models.OrphanedNetwork{
+			ID:     network.ID,
+			Name:   network.Name,
+			Driver: network.Driver,
+			Scope:  network.Scope,
+		})
+	}
+
+	sort.Slice(report.Volumes, func(i, j int) bool {
+		return report.Volumes[i].Name < report.Volumes[j].Name
+	})
+	sort.Slice(report.Networks, func(i, j int) bool {
+		return report.Networks[i].Name < report.Networks[j].Name
+	})
+
+	return report, nil
+}
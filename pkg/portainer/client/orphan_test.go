@@ -0,0 +1,118 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOrphanedResources(t *testing.T) {
+	containers := `[
+		{"Id":"c1","Mounts":[{"Type":"volume","Name":"db-data"},{"Type":"bind","Source":"/srv"}],"NetworkSettings":{"Networks":{"backend":{"NetworkID":"n1"}}}},
+		{"Id":"c2","State":"exited","Mounts":[{"Type":"volume","Name":"cache"}],"NetworkSettings":{"Networks":{"bridge":{"NetworkID":"n0"}}}}
+	]`
+	volumes := `{"Volumes":[
+		{"Name":"old-logs","Driver":"local","CreatedAt":"2024-01-02T10:00:00Z","Labels":{"app":"web"}},
+		{"Name":"db-data","Driver":"local"},
+		{"Name":"cache","Driver":"local"},
+		{"Name":"3f9a","Driver":"local"}
+	],"Warnings":null}`
+	networks := `[
+		{"Id":"n0","Name":"bridge","Driver":"bridge","Scope":"local"},
+		{"Id":"n1","Name":"backend","Driver":"bridge","Scope":"local"},
+		{"Id":"n2","Name":"frontend","Driver":"bridge","Scope":"local"},
+		{"Id":"n3","Name":"host","Driver":"host","Scope":"local"},
+		{"Id":"n4","Name":"none","Driver":"null","Scope":"local"},
+		{"Id":"n5","Name":"ingress","Driver":"overlay","Scope":"swarm"},
+		{"Id":"n6","Name":"app-overlay","Driver":"overlay","Scope":"swarm"},
+		{"Id":"n7","Name":"docker_gwbridge","Driver":"bridge","Scope":"local"},
+		{"Id":"n8","Name":"legacy","Driver":"macvlan","Scope":"local"}
+	]`
+
+	dockerResponses := map[string]string{
+		"/containers/json": containers,
+		"/volumes":         volumes,
+		"/networks":        networks,
+	}
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+	for path, body := range dockerResponses {
+		mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+			return opts.Method == http.MethodGet && opts.APIPath == path
+		})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil)
+	}
+
+	c := &PortainerClient{cli: mockAPI}
+
+	report, err := c.FindOrphanedResources(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrphanReport{
+		EnvironmentID: 1,
+		Volumes: []models.OrphanedVolume{
+			{Name: "3f9a", Driver: "local"},
+			{Name: "old-logs", Driver: "local", CreatedAt: "2024-01-02T10:00:00Z", Labels: map[string]string{"app": "web"}},
+		},
+		Networks: []models.OrphanedNetwork{
+			{ID: "n2", Name: "frontend", Driver: "bridge", Scope: "local"},
+			{ID: "n8", Name: "legacy", Driver: "macvlan", Scope: "local"},
+		},
+	}, report)
+
+	// The stopped containers are listed too, the volumes they mount are not orphaned
+	mockAPI.AssertCalled(t, "ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.APIPath == "/containers/json" && opts.QueryParams["all"] == "1"
+	}))
+}
+
+func TestFindOrphanedResourcesErrors(t *testing.T) {
+	t.Run("kubernetes environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 5}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.FindOrphanedResources(1)
+
+		assert.ErrorContains(t, err, "it has no Docker daemon")
+	})
+
+	t.Run("list containers error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.FindOrphanedResources(1)
+
+		assert.ErrorContains(t, err, "failed to list containers: connection refused")
+	})
+
+	t.Run("list volumes error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+			return opts.APIPath == "/containers/json"
+		})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[]`))}, nil)
+		mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+			return opts.APIPath == "/volumes"
+		})).Return(&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(`{"message":"volume store unavailable"}`))}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.FindOrphanedResources(1)
+
+		assert.ErrorContains(t, err, "failed to list volumes: unexpected status 500")
+	})
+}
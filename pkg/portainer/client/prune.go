@@ -0,0 +1,160 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// dockerContainersPruneReport is the response of the Docker API to a prune of the containers
+type dockerContainersPruneReport struct {
+	ContainersDeleted []string `json:"ContainersDeleted"`
+	SpaceReclaimed    uint64   `json:"SpaceReclaimed"`
+}
+
+// dockerNetworksPruneReport is the response of the Docker API to a prune of the networks
+type dockerNetworksPruneReport struct {
+	NetworksDeleted []string `json:"NetworksDeleted"`
+}
+
+// dockerBuildCachePruneReport is the response of the Docker API to a prune of the build cache
+type dockerBuildCachePruneReport struct {
+	CachesDeleted  []string `json:"CachesDeleted"`
+	SpaceReclaimed uint64   `json:"SpaceReclaimed"`
+}
+
+// PruneContainers removes the stopped containers of a Docker environment through the Docker proxy,
+// the equivalent of the `docker container prune` command.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - labels: Only prune the containers matching these label filters (e.g. "env=dev", "!keep"), all the stopped containers when empty
+//
+// Returns:
+//   - A PruneReport listing the IDs of the removed containers
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) PruneContainers(environmentId int, labels []string) (models.PruneReport, error) {
+	var raw dockerContainersPruneReport
+	if err := c.pruneDocker(environmentId, "/containers/prune", labels, &raw); err != nil {
+		return models.PruneReport{}, fmt.Errorf("failed to prune containers: %w", err)
+	}
+
+	return newPruneReport(models.PruneResourceContainers, raw.ContainersDeleted, raw.SpaceReclaimed), nil
+}
+
+// PruneNetworks removes the networks of a Docker environment not used by any container through
+// the Docker proxy, the equivalent of the `docker network prune` command.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - labels: Only prune the networks matching these label filters (e.g. "env=dev", "!keep"), all the unused networks when empty
+//
+// Returns:
+//   - A PruneReport listing the names of the removed networks
+//   - An error if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) PruneNetworks(environmentId int, labels []string) (models.PruneReport, error) {
+	var raw dockerNetworksPruneReport
+	if err := c.pruneDocker(environmentId, "/networks/prune", labels, &raw); err != nil {
+		return models.PruneReport{}, fmt.Errorf("failed to prune networks: %w", err)
+	}
+
+	return newPruneReport(models.PruneResourceNetworks, raw.NetworksDeleted, 0), nil
+}
+
+// PruneBuildCache removes the unused build cache of a Docker environment through the Docker proxy,
+// the equivalent of the `docker builder prune` command. Build cache records have no labels, the
+// prune cannot be scoped with label filters.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - labels: Must be empty, Docker cannot filter the build cache by label
+//
+// Returns:
+//   - A PruneReport listing the IDs of the removed cache records
+//   - An error if labels are provided, if the environment has no Docker daemon or if the operation fails
+func (c *PortainerClient) PruneBuildCache(environmentId int, labels []string) (models.PruneReport, error) {
+	if len(labels) > 0 {
+		return models.PruneReport{}, fmt.Errorf("the build cache cannot be pruned by label, Docker build cache records have no labels")
+	}
+
+	var raw dockerBuildCachePruneReport
+	if err := c.pruneDocker(environmentId, "/build/prune", nil, &raw); err != nil {
+		return models.PruneReport{}, fmt.Errorf("failed to prune build cache: %w", err)
+	}
+
+	return newPruneReport(models.PruneResourceBuildCache, raw.CachesDeleted, raw.SpaceReclaimed), nil
+}
+
+// pruneDocker sends a prune request to the Docker API of an environment through the Docker proxy,
+// scoped to the given label filters, and decodes the JSON response into v
+func (c *PortainerClient) pruneDocker(environmentId int, path string, labels []string, v any) error {
+	if err := c.checkDockerEnvironment(environmentId); err != nil {
+		return err
+	}
+
+	var queryParams map[string]string
+	if len(labels) > 0 {
+		filters, err := json.Marshal(convertPruneLabelFilters(labels))
+		if err != nil {
+			return fmt.Errorf("failed to encode prune filters: %w", err)
+		}
+		queryParams = map[string]string{"filters": string(filters)}
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodPost,
+		Path:          path,
+		QueryParams:   queryParams,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// convertPruneLabelFilters converts label filters into the filters of the Docker prune API.
+// Labels prefixed with "!" only keep the resources without the label.
+func convertPruneLabelFilters(labels []string) map[string]map[string]bool {
+	filters := make(map[string]map[string]bool)
+	for _, label := range labels {
+		key := "label"
+		if negated, ok := strings.CutPrefix(label, "!"); ok {
+			key, label = "label!", negated
+		}
+		if filters[key] == nil {
+			filters[key] = make(map[string]bool)
+		}
+		filters[key][label] = true
+	}
+
+	return filters
+}
+
+// newPruneReport creates a prune report, listing no deleted resource rather than nil when nothing was removed
+func newPruneReport(resource string, deleted []string, spaceReclaimed uint64) models.PruneReport {
+	if deleted == nil {
+		deleted = []string{}
+	}
+
+	return models.PruneReport{
+		Resource:       resource,
+		Deleted:        deleted,
+		SpaceReclaimed: spaceReclaimed,
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneDockerResources(t *testing.T) {
+	tests := []struct {
+		name            string
+		prune           func(c *PortainerClient, environmentId int, labels []string) (models.PruneReport, error)
+		labels          []string
+		endpointType    int64
+		expectedPath    string
+		expectedFilters string
+		mockStatus      int
+		mockBody        string
+		expected        models.PruneReport
+		expectedError   string
+	}{
+		{
+			name:            "containers with label filters",
+			prune:           (*PortainerClient).PruneContainers,
+			labels:          []string{"env=dev", "!keep"},
+			endpointType:    1,
+			expectedPath:    "/containers/prune",
+			expectedFilters: `{"label":{"env=dev":true},"label!":{"keep":true}}`,
+			mockStatus:      http.StatusOK,
+			mockBody:        `{"ContainersDeleted":["4f2a","9c1e"],"SpaceReclaimed":2048}`,
+			expected: models.PruneReport{
+				Resource:       models.PruneResourceContainers,
+				Deleted:        []string{"4f2a", "9c1e"},
+				SpaceReclaimed: 2048,
+			},
+		},
+		{
+			name:         "networks without filters",
+			prune:        (*PortainerClient).PruneNetworks,
+			endpointType: 1,
+			expectedPath: "/networks/prune",
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"NetworksDeleted":null}`,
+			expected: models.PruneReport{
+				Resource: models.PruneResourceNetworks,
+				Deleted:  []string{},
+			},
+		},
+		{
+			name:         "build cache",
+			prune:        (*PortainerClient).PruneBuildCache,
+			endpointType: 1,
+			expectedPath: "/build/prune",
+			mockStatus:   http.StatusOK,
+			mockBody:     `{"CachesDeleted":["u3wl8b5w0x7d"],"SpaceReclaimed":1048576}`,
+			expected: models.PruneReport{
+				Resource:       models.PruneResourceBuildCache,
+				Deleted:        []string{"u3wl8b5w0x7d"},
+				SpaceReclaimed: 1048576,
+			},
+		},
+		{
+			name:          "build cache with label filters",
+			prune:         (*PortainerClient).PruneBuildCache,
+			labels:        []string{"env=dev"},
+			endpointType:  1,
+			expectedError: "the build cache cannot be pruned by label",
+		},
+		{
+			name:          "prune already running",
+			prune:         (*PortainerClient).PruneContainers,
+			endpointType:  1,
+			expectedPath:  "/containers/prune",
+			mockStatus:    http.StatusConflict,
+			mockBody:      `{"message":"a prune operation is already running"}`,
+			expectedError: "failed to prune containers: unexpected status 409",
+		},
+		{
+			name:          "kubernetes environment",
+			prune:         (*PortainerClient).PruneNetworks,
+			endpointType:  5,
+			expectedError: "it has no Docker daemon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent client.ProxyRequestOptions
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil).Maybe()
+			if tt.expectedPath != "" {
+				mockAPI.On("ProxyDockerRequest", 1, mock.Anything).Run(func(args mock.Arguments) {
+					sent = args.Get(1).(client.ProxyRequestOptions)
+				}).Return(&http.Response{
+					StatusCode: tt.mockStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+				}, nil)
+			}
+
+			c := &PortainerClient{cli: mockAPI}
+
+			report, err := tt.prune(c, 1, tt.labels)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, report)
+			assert.Equal(t, http.MethodPost, sent.Method)
+			assert.Equal(t, tt.expectedPath, sent.APIPath)
+			if tt.expectedFilters != "" {
+				assert.JSONEq(t, tt.expectedFilters, sent.QueryParams["filters"])
+			} else {
+				assert.Empty(t, sent.QueryParams)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
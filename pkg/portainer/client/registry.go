@@ -0,0 +1,102 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// GetEnvironmentRegistries retrieves the registries of the Portainer server as seen from an environment,
+// reporting which ones the users of the environment can pull from.
+//
+// Parameters:
+//   - environmentId: The ID of the environment
+//
+// Returns:
+//   - A slice of EnvironmentRegistry objects
+//   - An error if the operation fails
+func (c *PortainerClient) GetEnvironmentRegistries(environmentId int) ([]models.EnvironmentRegistry, error) {
+	registries, err := c.cli.ListRegistries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	environmentRegistries := make([]models.EnvironmentRegistry, len(registries))
+	for i, registry := range registries {
+		environmentRegistries[i] = models.ConvertToEnvironmentRegistry(registry, environmentId)
+	}
+
+	return environmentRegistries, nil
+}
+
+// UpdateEnvironmentRegistries sets the registries the users of a Docker environment can pull from
+// with the credentials stored in Portainer. The listed registries are granted to the users and teams
+// that have access to the environment, the access to the other registries is removed. Administrators
+// can always use every registry. Kubernetes environments are not supported, their registries are
+// managed per namespace.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - registryIds: The IDs of the registries to enable on the environment
+//
+// Returns:
+//   - An error if a registry does not exist, if the caller cannot manage the environment
+//     or if the operation fails
+func (c *PortainerClient) UpdateEnvironmentRegistries(environmentId int, registryIds []int) error {
+	endpoint, err := c.cli.GetEndpoint(int64(environmentId))
+	if err != nil {
+		if isForbiddenError(err) {
+			return fmt.Errorf("not allowed to manage the registries of environment %d: %w", environmentId, err)
+		}
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if !models.IsDockerEnvironment(environment.Type) {
+		return fmt.Errorf("environment %d is a %s environment, only the registries of Docker environments can be managed", environmentId, environment.Type)
+	}
+
+	registries, err := c.cli.ListRegistries()
+	if err != nil {
+		return fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	existing := make(map[int]models.EnvironmentRegistry, len(registries))
+	for _, registry := range registries {
+		environmentRegistry := models.ConvertToEnvironmentRegistry(registry, environmentId)
+		existing[environmentRegistry.ID] = environmentRegistry
+	}
+
+	enabled := uniqueSortedIDs(registryIds)
+	for _, id := range enabled {
+		if _, ok := existing[id]; !ok {
+			return fmt.Errorf("registry %d does not exist", id)
+		}
+	}
+
+	userIds := utils.IntToInt64Slice(slices.Sorted(maps.Keys(environment.UserAccesses)))
+	teamIds := utils.IntToInt64Slice(slices.Sorted(maps.Keys(environment.TeamAccesses)))
+
+	for _, id := range slices.Sorted(maps.Keys(existing)) {
+		var err error
+		switch {
+		case slices.Contains(enabled, id):
+			err = c.cli.UpdateEndpointRegistryAccess(int64(environmentId), int64(id), userIds, teamIds)
+		case existing[id].Enabled:
+			err = c.cli.UpdateEndpointRegistryAccess(int64(environmentId), int64(id), []int64{}, []int64{})
+		default:
+			continue
+		}
+		if err != nil {
+			if isForbiddenError(err) {
+				return fmt.Errorf("not allowed to manage the registries of environment %d: %w", environmentId, err)
+			}
+			return fmt.Errorf("failed to update the access to registry %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testRegistries() []*apimodels.PortainereeRegistry {
+	return []*apimodels.PortainereeRegistry{
+		{
+			ID:   1,
+			Name: "ghcr",
+			URL:  "ghcr.io",
+			RegistryAccesses: apimodels.PortainerRegistryAccesses{
+				"1": {UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"2": {}}},
+			},
+		},
+		{
+			ID:   2,
+			Name: "quay",
+			URL:  "quay.io",
+		},
+		{
+			ID:   3,
+			Name: "internal",
+			URL:  "registry.example.com",
+			RegistryAccesses: apimodels.PortainerRegistryAccesses{
+				"4": {TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"1": {}}},
+			},
+		},
+	}
+}
+
+func TestGetEnvironmentRegistries(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockError     error
+		expected      []models.EnvironmentRegistry
+		expectedError bool
+	}{
+		{
+			name: "successful retrieval",
+			expected: []models.EnvironmentRegistry{
+				{ID: 1, Name: "ghcr", URL: "ghcr.io", Enabled: true, UserIds: []int{2}, TeamIds: []int{}},
+				{ID: 2, Name: "quay", URL: "quay.io", UserIds: []int{}, TeamIds: []int{}},
+				{ID: 3, Name: "internal", URL: "registry.example.com", UserIds: []int{}, TeamIds: []int{}},
+			},
+		},
+		{
+			name:          "list error",
+			mockError:     errors.New("failed to list registries"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.mockError != nil {
+				mockAPI.On("ListRegistries").Return(nil, tt.mockError)
+			} else {
+				mockAPI.On("ListRegistries").Return(testRegistries(), nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			registries, err := client.GetEnvironmentRegistries(1)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, registries)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateEnvironmentRegistries(t *testing.T) {
+	dockerEndpoint := &apimodels.PortainereeEndpoint{
+		ID:                 1,
+		Type:               1,
+		UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"5": {RoleID: 3}, "2": {RoleID: 3}},
+		TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"1": {RoleID: 4}},
+	}
+
+	t.Run("enable and disable registries", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(dockerEndpoint, nil)
+		mockAPI.On("ListRegistries").Return(testRegistries(), nil)
+		mockAPI.On("UpdateEndpointRegistryAccess", int64(1), int64(1), []int64{}, []int64{}).Return(nil)
+		mockAPI.On("UpdateEndpointRegistryAccess", int64(1), int64(2), []int64{2, 5}, []int64{1}).Return(nil)
+		mockAPI.On("UpdateEndpointRegistryAccess", int64(1), int64(3), []int64{2, 5}, []int64{1}).Return(nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentRegistries(1, []int{3, 2, 3})
+
+		assert.NoError(t, err)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("unknown registry", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(dockerEndpoint, nil)
+		mockAPI.On("ListRegistries").Return(testRegistries(), nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentRegistries(1, []int{2, 9})
+
+		assert.ErrorContains(t, err, "registry 9 does not exist")
+		mockAPI.AssertNotCalled(t, "UpdateEndpointRegistryAccess", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("kubernetes environment", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 5}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentRegistries(1, []int{1})
+
+		assert.ErrorContains(t, err, "only the registries of Docker environments can be managed")
+		mockAPI.AssertNotCalled(t, "ListRegistries")
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(dockerEndpoint, nil)
+		mockAPI.On("ListRegistries").Return(testRegistries(), nil)
+		mockAPI.On("UpdateEndpointRegistryAccess", int64(1), int64(1), []int64{2, 5}, []int64{1}).
+			Return(runtime.NewAPIError("EndpointRegistryAccess", nil, http.StatusForbidden))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentRegistries(1, []int{1})
+
+		assert.ErrorContains(t, err, "not allowed to manage the registries of environment 1")
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(dockerEndpoint, nil)
+		mockAPI.On("ListRegistries").Return(testRegistries(), nil)
+		mockAPI.On("UpdateEndpointRegistryAccess", int64(1), int64(1), []int64{}, []int64{}).Return(errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		err := client.UpdateEnvironmentRegistries(1, []int{})
+
+		assert.ErrorContains(t, err, "failed to update the access to registry 1: connection refused")
+	})
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// dockerInspectPaths maps the Docker resource types supporting resource controls to their inspect path
+var dockerInspectPaths = map[string]string{
+	models.ResourceTypeContainer: "/containers/%s/json",
+	models.ResourceTypeService:   "/services/%s",
+	models.ResourceTypeVolume:    "/volumes/%s",
+	models.ResourceTypeNetwork:   "/networks/%s",
+	models.ResourceTypeSecret:    "/secrets/%s",
+	models.ResourceTypeConfig:    "/configs/%s",
+}
+
+// GetResourceControl retrieves the resource control of a Docker resource.
+// Portainer decorates the Docker inspect response of a resource with its resource control,
+// the resource is therefore inspected through the Docker proxy of the environment.
+//
+// Parameters:
+//   - environmentId: The ID of the environment hosting the resource
+//   - resourceType: The type of the resource (container, service, volume, network, secret or config)
+//   - resourceId: The ID or name of the resource
+//
+// Returns:
+//   - The resource control of the resource
+//   - An error if the operation fails or if the resource has no resource control
+func (c *PortainerClient) GetResourceControl(environmentId int, resourceType, resourceId string) (models.ResourceControl, error) {
+	pathFormat, ok := dockerInspectPaths[resourceType]
+	if !ok {
+		return models.ResourceControl{}, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          fmt.Sprintf(pathFormat, url.PathEscape(resourceId)),
+	})
+	if err != nil {
+		return models.ResourceControl{}, fmt.Errorf("failed to inspect %s: %w", resourceType, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return models.ResourceControl{}, fmt.Errorf("failed to inspect %s: unexpected status %d: %s", resourceType, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var inspect struct {
+		Portainer *struct {
+			ResourceControl *apimodels.PortainerResourceControl `json:"ResourceControl"`
+		} `json:"Portainer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&inspect); err != nil {
+		return models.ResourceControl{}, fmt.Errorf("failed to decode %s: %w", resourceType, err)
+	}
+
+	if inspect.Portainer == nil || inspect.Portainer.ResourceControl == nil {
+		return models.ResourceControl{}, fmt.Errorf("%s %s has no resource control, it is only accessible by administrators", resourceType, resourceId)
+	}
+
+	return models.ConvertToResourceControl(inspect.Portainer.ResourceControl), nil
+}
+
+// UpdateResourceControl updates the ownership of a resource.
+// The resource is either made public, restricted to administrators, or restricted to the given users and teams.
+//
+// Parameters:
+//   - resourceControlId: The ID of the resource control to update
+//   - opts: The access settings to apply to the resource
+//
+// Returns:
+//   - An error if the options are inconsistent, if a user or team does not exist, or if the operation fails
+func (c *PortainerClient) UpdateResourceControl(resourceControlId int, opts models.ResourceControlOptions) error {
+	if opts.Public && opts.AdministratorsOnly {
+		return fmt.Errorf("invalid resource control options: a resource cannot be both public and restricted to administrators")
+	}
+
+	if !opts.Public && !opts.AdministratorsOnly && len(opts.UserIDs) == 0 && len(opts.TeamIDs) == 0 {
+		return fmt.Errorf("invalid resource control options: a restricted resource requires at least one user or team")
+	}
+
+	if err := c.validateUserIDs(opts.UserIDs); err != nil {
+		return fmt.Errorf("invalid users: %w", err)
+	}
+
+	if err := c.validateTeamIDs(opts.TeamIDs); err != nil {
+		return fmt.Errorf("invalid teams: %w", err)
+	}
+
+	_, err := c.cli.UpdateResourceControl(
+		int64(resourceControlId),
+		opts.Public,
+		opts.AdministratorsOnly,
+		utils.IntToInt64Slice(opts.UserIDs),
+		utils.IntToInt64Slice(opts.TeamIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update resource control: %w", err)
+	}
+
+	return nil
+}
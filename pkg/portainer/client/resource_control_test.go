@@ -0,0 +1,225 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetResourceControl(t *testing.T) {
+	tests := []struct {
+		name            string
+		resourceType    string
+		resourceId      string
+		expectedPath    string
+		mockStatus      int
+		mockBody        string
+		mockError       error
+		expectProxyCall bool
+		expected        models.ResourceControl
+		expectedError   bool
+	}{
+		{
+			name:            "volume with resource control",
+			resourceType:    models.ResourceTypeVolume,
+			resourceId:      "data",
+			expectedPath:    "/volumes/data",
+			mockStatus:      http.StatusOK,
+			mockBody:        `{"Name":"data","Portainer":{"ResourceControl":{"Id":4,"ResourceId":"data","Type":3,"UserAccesses":[{"UserId":2,"AccessLevel":1}],"TeamAccesses":[]}}}`,
+			expectProxyCall: true,
+			expected: models.ResourceControl{
+				ID:         4,
+				ResourceID: "data",
+				Type:       models.ResourceTypeVolume,
+				UserIDs:    []int{2},
+				TeamIDs:    []int{},
+			},
+		},
+		{
+			name:            "container path",
+			resourceType:    models.ResourceTypeContainer,
+			resourceId:      "abc123",
+			expectedPath:    "/containers/abc123/json",
+			mockStatus:      http.StatusOK,
+			mockBody:        `{"Id":"abc123","Portainer":{"ResourceControl":{"Id":1,"ResourceId":"abc123","Type":1,"Public":true}}}`,
+			expectProxyCall: true,
+			expected: models.ResourceControl{
+				ID:         1,
+				ResourceID: "abc123",
+				Type:       models.ResourceTypeContainer,
+				Public:     true,
+				UserIDs:    []int{},
+				TeamIDs:    []int{},
+			},
+		},
+		{
+			name:            "resource without resource control",
+			resourceType:    models.ResourceTypeNetwork,
+			resourceId:      "backend",
+			expectedPath:    "/networks/backend",
+			mockStatus:      http.StatusOK,
+			mockBody:        `{"Name":"backend"}`,
+			expectProxyCall: true,
+			expectedError:   true,
+		},
+		{
+			name:          "unsupported resource type",
+			resourceType:  models.ResourceTypeStack,
+			resourceId:    "1",
+			expectedError: true,
+		},
+		{
+			name:            "resource not found",
+			resourceType:    models.ResourceTypeVolume,
+			resourceId:      "missing",
+			expectedPath:    "/volumes/missing",
+			mockStatus:      http.StatusNotFound,
+			mockBody:        `{"message":"no such volume"}`,
+			expectProxyCall: true,
+			expectedError:   true,
+		},
+		{
+			name:            "proxy error",
+			resourceType:    models.ResourceTypeVolume,
+			resourceId:      "data",
+			expectedPath:    "/volumes/data",
+			mockError:       errors.New("proxy error"),
+			expectProxyCall: true,
+			expectedError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectProxyCall {
+				var resp *http.Response
+				if tt.mockError == nil {
+					resp = &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(strings.NewReader(tt.mockBody)),
+					}
+				}
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == tt.expectedPath
+				})).Return(resp, tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			rc, err := client.GetResourceControl(1, tt.resourceType, tt.resourceId)
+
+			mockAPI.AssertExpectations(t)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rc)
+		})
+	}
+}
+
+func TestUpdateResourceControl(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             models.ResourceControlOptions
+		expectListUsers  bool
+		expectListTeams  bool
+		expectUpdate     bool
+		mockUpdateError  error
+		expectedErrorMsg string
+	}{
+		{
+			name:            "restrict to users and teams",
+			opts:            models.ResourceControlOptions{UserIDs: []int{1}, TeamIDs: []int{2, 3}},
+			expectListUsers: true,
+			expectListTeams: true,
+			expectUpdate:    true,
+		},
+		{
+			name:         "make public",
+			opts:         models.ResourceControlOptions{Public: true},
+			expectUpdate: true,
+		},
+		{
+			name:         "restrict to administrators",
+			opts:         models.ResourceControlOptions{AdministratorsOnly: true},
+			expectUpdate: true,
+		},
+		{
+			name:             "public and administrators only",
+			opts:             models.ResourceControlOptions{Public: true, AdministratorsOnly: true},
+			expectedErrorMsg: "cannot be both public and restricted to administrators",
+		},
+		{
+			name:             "restricted without users or teams",
+			opts:             models.ResourceControlOptions{},
+			expectedErrorMsg: "requires at least one user or team",
+		},
+		{
+			name:             "non-existent user",
+			opts:             models.ResourceControlOptions{UserIDs: []int{42}},
+			expectListUsers:  true,
+			expectedErrorMsg: "user 42 does not exist",
+		},
+		{
+			name:             "non-existent team",
+			opts:             models.ResourceControlOptions{TeamIDs: []int{42}},
+			expectListTeams:  true,
+			expectedErrorMsg: "team 42 does not exist",
+		},
+		{
+			name:             "update error",
+			opts:             models.ResourceControlOptions{Public: true},
+			expectUpdate:     true,
+			mockUpdateError:  errors.New("update failed"),
+			expectedErrorMsg: "failed to update resource control",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectListUsers {
+				mockAPI.On("ListUsers").Return(existingUsers, nil)
+			}
+			if tt.expectListTeams {
+				mockAPI.On("ListTeams").Return(existingTeams, nil)
+			}
+			if tt.expectUpdate {
+				var rc *apimodels.PortainerResourceControl
+				if tt.mockUpdateError == nil {
+					rc = &apimodels.PortainerResourceControl{ID: 7}
+				}
+				mockAPI.On("UpdateResourceControl",
+					int64(7),
+					tt.opts.Public,
+					tt.opts.AdministratorsOnly,
+					utils.IntToInt64Slice(tt.opts.UserIDs),
+					utils.IntToInt64Slice(tt.opts.TeamIDs),
+				).Return(rc, tt.mockUpdateError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateResourceControl(7, tt.opts)
+
+			mockAPI.AssertExpectations(t)
+			if tt.expectedErrorMsg != "" {
+				assert.ErrorContains(t, err, tt.expectedErrorMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
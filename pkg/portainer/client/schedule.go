@@ -0,0 +1,146 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// scheduleNextRunsCount is the number of next runs returned with each recurring schedule
+const scheduleNextRunsCount = 5
+
+// GetSchedules retrieves all the schedules from the Portainer server. The schedules are the Edge Jobs
+// of Portainer, the scripts run on a cron schedule on edge environments. The next runs of each schedule
+// are computed from its cron expression.
+//
+// Returns:
+//   - A slice of Schedule objects, without the content of their script
+//   - An error if the operation fails
+func (c *PortainerClient) GetSchedules() ([]models.Schedule, error) {
+	rawEdgeJobs, err := c.cli.ListEdgeJobs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge jobs: %w", err)
+	}
+
+	now := time.Now().UTC()
+	schedules := make([]models.Schedule, len(rawEdgeJobs))
+	for i, rawEdgeJob := range rawEdgeJobs {
+		schedules[i] = models.ConvertToSchedule(rawEdgeJob)
+		// The cron expressions were accepted by Portainer, the ones this parser rejects have no next runs
+		if cron, err := parseCronExpression(schedules[i].CronExpression); err == nil {
+			schedules[i].NextRuns = scheduleNextRuns(cron, schedules[i].Recurring, now)
+		}
+	}
+
+	return schedules, nil
+}
+
+// CreateSchedule creates a schedule running a script on edge environments, an Edge Job in Portainer.
+// The cron expression is validated before the schedule is sent to Portainer. A schedule that is not
+// recurring runs once, at the first time matching its cron expression.
+//
+// Parameters:
+//   - schedule: The schedule to create, its ID, creation date and next runs are ignored
+//
+// Returns:
+//   - The created schedule, with its ID and its next runs
+//   - An error if the schedule is invalid or if the operation fails
+func (c *PortainerClient) CreateSchedule(schedule models.Schedule) (models.Schedule, error) {
+	cron, err := validateSchedule(schedule)
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	environmentIds := schedule.EnvironmentIds
+	if environmentIds == nil {
+		environmentIds = []int{}
+	}
+	environmentGroupIds := schedule.EnvironmentGroupIds
+	if environmentGroupIds == nil {
+		environmentGroupIds = []int{}
+	}
+
+	id, err := c.cli.CreateEdgeJob(&apimodels.EdgejobsEdgeJobCreateFromFileContentPayload{
+		Name:           schedule.Name,
+		CronExpression: schedule.CronExpression,
+		Recurring:      schedule.Recurring,
+		Endpoints:      utils.IntToInt64Slice(environmentIds),
+		EdgeGroups:     utils.IntToInt64Slice(environmentGroupIds),
+		FileContent:    schedule.Script,
+	})
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("failed to create edge job: %w", err)
+	}
+
+	return models.Schedule{
+		ID:                  int(id),
+		Name:                schedule.Name,
+		CronExpression:      schedule.CronExpression,
+		Recurring:           schedule.Recurring,
+		EnvironmentIds:      environmentIds,
+		EnvironmentGroupIds: environmentGroupIds,
+		NextRuns:            scheduleNextRuns(cron, schedule.Recurring, time.Now().UTC()),
+	}, nil
+}
+
+// DeleteSchedule deletes a schedule from the Portainer server. The scripts already running on the
+// environments are not stopped.
+//
+// Parameters:
+//   - id: The ID of the schedule to delete
+//
+// Returns:
+//   - An error if the operation fails
+func (c *PortainerClient) DeleteSchedule(id int) error {
+	err := c.cli.DeleteEdgeJob(int64(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete edge job: %w", err)
+	}
+
+	return nil
+}
+
+// validateSchedule checks that a schedule can be created and returns its parsed cron expression
+func validateSchedule(schedule models.Schedule) (*cronSchedule, error) {
+	if strings.TrimSpace(schedule.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	if strings.TrimSpace(schedule.Script) == "" {
+		return nil, fmt.Errorf("script is required")
+	}
+
+	if len(schedule.EnvironmentIds) == 0 && len(schedule.EnvironmentGroupIds) == 0 {
+		return nil, fmt.Errorf("at least one environment or environment group is required")
+	}
+
+	cron, err := parseCronExpression(schedule.CronExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := cron.next(time.Now().UTC()); !ok {
+		return nil, fmt.Errorf("cron expression %q never matches", schedule.CronExpression)
+	}
+
+	return cron, nil
+}
+
+// scheduleNextRuns formats the next runs of a schedule, a schedule that is not recurring only runs once
+func scheduleNextRuns(cron *cronSchedule, recurring bool, now time.Time) []string {
+	count := scheduleNextRunsCount
+	if !recurring {
+		count = 1
+	}
+
+	runs := cron.nextRuns(now, count)
+	formatted := make([]string, len(runs))
+	for i, run := range runs {
+		formatted[i] = run.Format(time.RFC3339)
+	}
+	return formatted
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search of the next run of a cron expression, the expressions that never
+// match, such as the 30th of February, have no next run
+const cronSearchYears = 5
+
+// cronField describes the range of the values of a field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+}
+
+// cronFields are the five fields of the cron expressions accepted by Portainer, in their order
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// cronSchedule is a parsed cron expression, each field holds a bit per value it matches
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays uint64
+	// daysRestricted and weekdaysRestricted report whether the day fields are not wildcards, when both
+	// are restricted a day matches if it matches either of them, as in the standard cron
+	daysRestricted, weekdaysRestricted bool
+}
+
+// parseCronExpression parses a cron expression of five space-separated fields: minute, hour, day of
+// month, month and day of week. Each field is a wildcard (*), a value, a range (1-5) or a list of them
+// (1,3-5), optionally with a step (*/15, 0-30/10). Both 0 and 7 are Sunday. Names and macros such as
+// @daily are not accepted by Portainer.
+func parseCronExpression(expression string) (*cronSchedule, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q must have %d fields (minute, hour, day of month, month, day of week), got %d", expression, len(cronFields), len(parts))
+	}
+
+	values := make([]uint64, len(cronFields))
+	for i, field := range cronFields {
+		bits, err := parseCronField(parts[i], field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+		}
+		values[i] = bits
+	}
+
+	// Sunday is both 0 and 7
+	weekdays := values[4]
+	if weekdays&(1<<7) != 0 {
+		weekdays = weekdays&^(1<<7) | 1
+	}
+
+	return &cronSchedule{
+		minutes:            values[0],
+		hours:              values[1],
+		days:               values[2],
+		months:             values[3],
+		weekdays:           weekdays,
+		daysRestricted:     !strings.HasPrefix(parts[2], "*"),
+		weekdaysRestricted: !strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseCronField parses a field of a cron expression into a bit per value it matches
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q in the %s field", stepPart, field.name)
+			}
+		}
+
+		start, end := field.min, field.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			low, high, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(low, field); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(high, field); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q in the %s field", rangePart, field.name)
+			}
+		default:
+			var err error
+			if start, err = parseCronValue(rangePart, field); err != nil {
+				return 0, err
+			}
+			// A single value with a step runs from the value to the end of the range
+			if !hasStep {
+				end = start
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+// parseCronValue parses a value of a cron field and checks that it is within the range of the field
+func parseCronValue(value string, field cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in the %s field", value, field.name)
+	}
+	if v < field.min || v > field.max {
+		return 0, fmt.Errorf("value %d of the %s field must be between %d and %d", v, field.name, field.min, field.max)
+	}
+	return v, nil
+}
+
+// next returns the first time strictly after the given time that matches the schedule, reporting false
+// when no time matches within cronSearchYears years
+func (s *cronSchedule) next(after time.Time) (time.Time, bool) {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hours&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minutes&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// matchesDay checks whether the day of the given time matches the day of month and day of week fields
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<t.Day()) != 0
+	weekday := s.weekdays&(1<<int(t.Weekday())) != 0
+	if s.daysRestricted && s.weekdaysRestricted {
+		return day || weekday
+	}
+	return day && weekday
+}
+
+// nextRuns returns up to count times after the given time matching the schedule
+func (s *cronSchedule) nextRuns(after time.Time, count int) []time.Time {
+	runs := make([]time.Time, 0, count)
+	for len(runs) < count {
+		run, ok := s.next(after)
+		if !ok {
+			break
+		}
+		runs = append(runs, run)
+		after = run
+	}
+	return runs
+}
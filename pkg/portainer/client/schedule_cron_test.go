@@ -0,0 +1,133 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		name          string
+		expression    string
+		expectedError string
+	}{
+		{name: "every minute", expression: "* * * * *"},
+		{name: "lists ranges and steps", expression: "*/15 8-18 1,15 1-12/3 1-5"},
+		{name: "sunday as 7", expression: "0 0 * * 7"},
+		{name: "value with step", expression: "5/20 * * * *"},
+		{
+			name:          "missing field",
+			expression:    "0 3 * *",
+			expectedError: "must have 5 fields",
+		},
+		{
+			name:          "seconds field",
+			expression:    "0 0 3 * * *",
+			expectedError: "must have 5 fields",
+		},
+		{
+			name:          "out of range value",
+			expression:    "60 * * * *",
+			expectedError: "value 60 of the minute field must be between 0 and 59",
+		},
+		{
+			name:          "invalid range",
+			expression:    "* 18-8 * * *",
+			expectedError: "invalid range \"18-8\" in the hour field",
+		},
+		{
+			name:          "invalid step",
+			expression:    "*/0 * * * *",
+			expectedError: "invalid step \"0\" in the minute field",
+		},
+		{
+			name:          "month name",
+			expression:    "0 0 1 JAN *",
+			expectedError: "invalid value \"JAN\" in the month field",
+		},
+		{
+			name:          "macro",
+			expression:    "@daily",
+			expectedError: "must have 5 fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCronExpression(tt.expression)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCronScheduleNextRuns(t *testing.T) {
+	// Wednesday 1 May 2024
+	after := time.Date(2024, time.May, 1, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		count      int
+		expected   []string
+	}{
+		{
+			name:       "every 15 minutes",
+			expression: "*/15 * * * *",
+			count:      3,
+			expected:   []string{"2024-05-01T10:15:00Z", "2024-05-01T10:30:00Z", "2024-05-01T10:45:00Z"},
+		},
+		{
+			name:       "daily at 3am",
+			expression: "0 3 * * *",
+			count:      2,
+			expected:   []string{"2024-05-02T03:00:00Z", "2024-05-03T03:00:00Z"},
+		},
+		{
+			name:       "weekdays only",
+			expression: "30 9 * * 1-5",
+			count:      3,
+			expected:   []string{"2024-05-02T09:30:00Z", "2024-05-03T09:30:00Z", "2024-05-06T09:30:00Z"},
+		},
+		{
+			name:       "day of month or day of week",
+			expression: "0 0 10 * 0",
+			count:      3,
+			expected:   []string{"2024-05-05T00:00:00Z", "2024-05-10T00:00:00Z", "2024-05-12T00:00:00Z"},
+		},
+		{
+			name:       "leap day",
+			expression: "0 12 29 2 *",
+			count:      1,
+			expected:   []string{"2028-02-29T12:00:00Z"},
+		},
+		{
+			name:       "never matches",
+			expression: "0 0 30 2 *",
+			count:      1,
+			expected:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := parseCronExpression(tt.expression)
+			require.NoError(t, err)
+
+			runs := cron.nextRuns(after, tt.count)
+
+			formatted := make([]string, len(runs))
+			for i, run := range runs {
+				formatted[i] = run.Format(time.RFC3339)
+			}
+			assert.Equal(t, tt.expected, formatted)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// maxScheduleResultLogSize bounds the size of the logs returned with each result of a schedule,
+// the full logs of an environment are returned by GetScheduleResultLog
+const maxScheduleResultLogSize = 4096
+
+// GetScheduleResults retrieves the results of the last run of a schedule on each of its environments.
+// Schedules are the equivalent of Edge Jobs in Portainer. Portainer does not record the exit code of
+// the scripts: a result only holds the output of the script, once the edge agent of the environment
+// sent it. The results of the environments whose logs have not been collected are pending.
+//
+// The collected logs are read at most maxConcurrentEnvironmentRequests environments at a time and
+// only their last maxScheduleResultLogSize bytes are returned. A result whose logs cannot be read
+// holds the error instead.
+//
+// Parameters:
+//   - scheduleId: The ID of the schedule
+//
+// Returns:
+//   - A slice of ScheduleResult objects, sorted by environment ID
+//   - An error if the operation fails
+func (c *PortainerClient) GetScheduleResults(scheduleId int) ([]models.ScheduleResult, error) {
+	tasks, err := c.cli.ListEdgeJobTasks(int64(scheduleId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge job tasks: %w", err)
+	}
+
+	results := make([]models.ScheduleResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = models.ConvertToScheduleResult(scheduleId, task)
+	}
+
+	forEachConcurrently(len(results), maxConcurrentEnvironmentRequests, func(i int) {
+		result := &results[i]
+		if result.Status != models.ScheduleResultStatusCollected {
+			return
+		}
+
+		logs, err := c.cli.GetEdgeJobTaskLogs(int64(scheduleId), int64(result.EnvironmentID))
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		if len(logs) > maxScheduleResultLogSize {
+			logs, result.LogTruncated = logs[len(logs)-maxScheduleResultLogSize:], true
+		}
+		result.Log = logs
+	})
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].EnvironmentID < results[j].EnvironmentID
+	})
+
+	return results, nil
+}
+
+// GetScheduleResultLog retrieves the full logs of the last run of a schedule on one of its environments.
+// The result is pending when the edge agent of the environment has not sent the logs yet.
+//
+// Parameters:
+//   - scheduleId: The ID of the schedule
+//   - environmentId: The ID of the environment
+//
+// Returns:
+//   - The ScheduleResult of the environment, with its full logs once collected
+//   - An error if the schedule does not run on the environment or if the operation fails
+func (c *PortainerClient) GetScheduleResultLog(scheduleId, environmentId int) (models.ScheduleResult, error) {
+	tasks, err := c.cli.ListEdgeJobTasks(int64(scheduleId))
+	if err != nil {
+		return models.ScheduleResult{}, fmt.Errorf("failed to list edge job tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		if int(task.EndpointID) != environmentId {
+			continue
+		}
+
+		result := models.ConvertToScheduleResult(scheduleId, task)
+		if result.Status == models.ScheduleResultStatusCollected {
+			logs, err := c.cli.GetEdgeJobTaskLogs(int64(scheduleId), int64(environmentId))
+			if err != nil {
+				return models.ScheduleResult{}, fmt.Errorf("failed to get edge job task logs: %w", err)
+			}
+			result.Log = logs
+		}
+
+		return result, nil
+	}
+
+	return models.ScheduleResult{}, fmt.Errorf("schedule %d does not run on environment %d", scheduleId, environmentId)
+}
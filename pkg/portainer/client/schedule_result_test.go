@@ -0,0 +1,124 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetScheduleResults(t *testing.T) {
+	t.Run("collected and pending results", func(t *testing.T) {
+		longLog := strings.Repeat("a", maxScheduleResultLogSize) + "done"
+
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEdgeJobTasks", int64(3)).Return([]*apimodels.EdgejobsTaskContainer{
+			{ID: "edgejob_task_3_4", EndpointID: 4, LogsStatus: 1},
+			{ID: "edgejob_task_3_1", EndpointID: 1, LogsStatus: 3},
+			{ID: "edgejob_task_3_2", EndpointID: 2, LogsStatus: 2},
+			{ID: "edgejob_task_3_5", EndpointID: 5, LogsStatus: 3},
+			{ID: "edgejob_task_3_6", EndpointID: 6, LogsStatus: 3},
+		}, nil)
+		mockAPI.On("GetEdgeJobTaskLogs", int64(3), int64(1)).Return("backup done\n", nil)
+		mockAPI.On("GetEdgeJobTaskLogs", int64(3), int64(5)).Return(longLog, nil)
+		mockAPI.On("GetEdgeJobTaskLogs", int64(3), int64(6)).Return("", errors.New("log file not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		results, err := client.GetScheduleResults(3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.ScheduleResult{
+			{ScheduleID: 3, EnvironmentID: 1, Status: models.ScheduleResultStatusCollected, Log: "backup done\n"},
+			{ScheduleID: 3, EnvironmentID: 2, Status: models.ScheduleResultStatusPending, LogsRequested: true},
+			{ScheduleID: 3, EnvironmentID: 4, Status: models.ScheduleResultStatusPending},
+			{ScheduleID: 3, EnvironmentID: 5, Status: models.ScheduleResultStatusCollected, Log: longLog[len(longLog)-maxScheduleResultLogSize:], LogTruncated: true},
+			{ScheduleID: 3, EnvironmentID: 6, Status: models.ScheduleResultStatusCollected, Error: "log file not found"},
+		}, results)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEdgeJobTasks", int64(3)).Return(nil, errors.New("edge job not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetScheduleResults(3)
+
+		assert.ErrorContains(t, err, "failed to list edge job tasks")
+	})
+}
+
+func TestGetScheduleResultLog(t *testing.T) {
+	tests := []struct {
+		name          string
+		environmentId int
+		setupMock     func(mockAPI *MockPortainerAPI)
+		expected      models.ScheduleResult
+		expectedError string
+	}{
+		{
+			name:          "collected logs",
+			environmentId: 1,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("GetEdgeJobTaskLogs", int64(3), int64(1)).Return("backup done\n", nil)
+			},
+			expected: models.ScheduleResult{ScheduleID: 3, EnvironmentID: 1, Status: models.ScheduleResultStatusCollected, Log: "backup done\n"},
+		},
+		{
+			name:          "logs never requested",
+			environmentId: 4,
+			setupMock:     func(mockAPI *MockPortainerAPI) {},
+			expected:      models.ScheduleResult{ScheduleID: 3, EnvironmentID: 4, Status: models.ScheduleResultStatusPending},
+		},
+		{
+			name:          "logs already requested",
+			environmentId: 2,
+			setupMock:     func(mockAPI *MockPortainerAPI) {},
+			expected:      models.ScheduleResult{ScheduleID: 3, EnvironmentID: 2, Status: models.ScheduleResultStatusPending, LogsRequested: true},
+		},
+		{
+			name:          "logs error",
+			environmentId: 1,
+			setupMock: func(mockAPI *MockPortainerAPI) {
+				mockAPI.On("GetEdgeJobTaskLogs", int64(3), int64(1)).Return("", errors.New("log file not found"))
+			},
+			expectedError: "failed to get edge job task logs",
+		},
+		{
+			name:          "environment not targeted",
+			environmentId: 9,
+			setupMock:     func(mockAPI *MockPortainerAPI) {},
+			expectedError: "schedule 3 does not run on environment 9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeJobTasks", int64(3)).Return([]*apimodels.EdgejobsTaskContainer{
+				{ID: "edgejob_task_3_1", EndpointID: 1, LogsStatus: 3},
+				{ID: "edgejob_task_3_2", EndpointID: 2, LogsStatus: 2},
+				{ID: "edgejob_task_3_4", EndpointID: 4, LogsStatus: 1},
+			}, nil)
+			tt.setupMock(mockAPI)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			result, err := client.GetScheduleResultLog(3, tt.environmentId)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
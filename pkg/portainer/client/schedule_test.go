@@ -0,0 +1,236 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSchedules(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockEdgeJobs  []*apimodels.PortainerEdgeJob
+		mockError     error
+		expectedIDs   []int
+		expectedRuns  []int
+		expectedError bool
+	}{
+		{
+			name: "successful retrieval",
+			mockEdgeJobs: []*apimodels.PortainerEdgeJob{
+				{ID: 1, Name: "cleanup", CronExpression: "0 3 * * *", Recurring: true, Endpoints: map[string]apimodels.PortainerEdgeJobEndpointMeta{"2": {}}},
+				{ID: 2, Name: "reboot", CronExpression: "30 4 1 6 *", EdgeGroups: []int64{1}},
+				{ID: 3, Name: "never", CronExpression: "0 0 30 2 *", Recurring: true},
+			},
+			expectedIDs:  []int{1, 2, 3},
+			expectedRuns: []int{scheduleNextRunsCount, 1, 0},
+		},
+		{
+			name:         "empty schedules",
+			mockEdgeJobs: []*apimodels.PortainerEdgeJob{},
+			expectedIDs:  []int{},
+			expectedRuns: []int{},
+		},
+		{
+			name:          "list error",
+			mockError:     errors.New("failed to list edge jobs"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeJobs").Return(tt.mockEdgeJobs, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			schedules, err := client.GetSchedules()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]int, len(schedules))
+			runs := make([]int, len(schedules))
+			for i, schedule := range schedules {
+				ids[i] = schedule.ID
+				runs[i] = len(schedule.NextRuns)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedRuns, runs)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateSchedule(t *testing.T) {
+	validSchedule := models.Schedule{
+		Name:           "cleanup",
+		CronExpression: "0 3 * * *",
+		Recurring:      true,
+		EnvironmentIds: []int{1, 2},
+		Script:         "docker system prune -f",
+	}
+
+	tests := []struct {
+		name          string
+		schedule      models.Schedule
+		expectCall    bool
+		mockError     error
+		expectedRuns  int
+		expectedError string
+	}{
+		{
+			name:         "recurring schedule",
+			schedule:     validSchedule,
+			expectCall:   true,
+			expectedRuns: scheduleNextRunsCount,
+		},
+		{
+			name: "one-time schedule",
+			schedule: models.Schedule{
+				Name:                "reboot",
+				CronExpression:      "30 4 1 6 *",
+				EnvironmentGroupIds: []int{3},
+				Script:              "reboot",
+			},
+			expectCall:   true,
+			expectedRuns: 1,
+		},
+		{
+			name: "missing name",
+			schedule: models.Schedule{
+				CronExpression: "0 3 * * *",
+				EnvironmentIds: []int{1},
+				Script:         "docker system prune -f",
+			},
+			expectedError: "name is required",
+		},
+		{
+			name: "missing script",
+			schedule: models.Schedule{
+				Name:           "cleanup",
+				CronExpression: "0 3 * * *",
+				EnvironmentIds: []int{1},
+			},
+			expectedError: "script is required",
+		},
+		{
+			name: "missing targets",
+			schedule: models.Schedule{
+				Name:           "cleanup",
+				CronExpression: "0 3 * * *",
+				Script:         "docker system prune -f",
+			},
+			expectedError: "at least one environment or environment group is required",
+		},
+		{
+			name: "invalid cron expression",
+			schedule: models.Schedule{
+				Name:           "cleanup",
+				CronExpression: "0 25 * * *",
+				EnvironmentIds: []int{1},
+				Script:         "docker system prune -f",
+			},
+			expectedError: "value 25 of the hour field must be between 0 and 23",
+		},
+		{
+			name: "cron expression never matches",
+			schedule: models.Schedule{
+				Name:           "cleanup",
+				CronExpression: "0 0 31 4 *",
+				EnvironmentIds: []int{1},
+				Script:         "docker system prune -f",
+			},
+			expectedError: "never matches",
+		},
+		{
+			name:          "create error",
+			schedule:      validSchedule,
+			expectCall:    true,
+			mockError:     errors.New("failed to create edge job"),
+			expectedError: "failed to create edge job",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectCall {
+				mockAPI.On("CreateEdgeJob", mock.MatchedBy(func(payload *apimodels.EdgejobsEdgeJobCreateFromFileContentPayload) bool {
+					return payload.Name == tt.schedule.Name &&
+						payload.CronExpression == tt.schedule.CronExpression &&
+						payload.Recurring == tt.schedule.Recurring &&
+						payload.FileContent == tt.schedule.Script &&
+						payload.Endpoints != nil && payload.EdgeGroups != nil
+				})).Return(int64(5), tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			schedule, err := client.CreateSchedule(tt.schedule)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				if !tt.expectCall {
+					mockAPI.AssertNotCalled(t, "CreateEdgeJob", mock.Anything)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 5, schedule.ID)
+			assert.Empty(t, schedule.Script)
+			require.Len(t, schedule.NextRuns, tt.expectedRuns)
+			for _, run := range schedule.NextRuns {
+				runTime, err := time.Parse(time.RFC3339, run)
+				require.NoError(t, err)
+				assert.True(t, runTime.After(time.Now().Add(-time.Minute)))
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteSchedule(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockError     error
+		expectedError bool
+	}{
+		{
+			name: "successful deletion",
+		},
+		{
+			name:          "delete error",
+			mockError:     errors.New("failed to delete edge job"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("DeleteEdgeJob", int64(3)).Return(tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.DeleteSchedule(3)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -1,7 +1,15 @@
 package client
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 )
@@ -14,3 +22,333 @@ func (c *PortainerClient) GetSettings() (models.PortainerSettings, error) {
 
 	return models.ConvertSettingsToPortainerSettings(settings), nil
 }
+
+// GetPublicSettings retrieves the settings Portainer exposes without authentication, the
+// authentication method and the feature flags the login page relies on. The request is sent
+// without the API key, it succeeds even when the key is invalid or expired.
+//
+// Returns:
+//   - The public settings of the Portainer instance
+//   - An error if the operation fails
+func (c *PortainerClient) GetPublicSettings() (models.PublicSettings, error) {
+	settings, err := c.cli.GetPublicSettings()
+	if err != nil {
+		return models.PublicSettings{}, fmt.Errorf("failed to get public settings: %w", err)
+	}
+
+	return models.ConvertToPublicSettings(settings), nil
+}
+
+// GetAuthSettings retrieves the authentication settings of the Portainer instance.
+// The OAuth client secret is never returned.
+//
+// Returns:
+//   - The authentication method and the key OAuth settings
+//   - An error if the operation fails
+func (c *PortainerClient) GetAuthSettings() (models.AuthSettings, error) {
+	settings, err := c.cli.GetSettings()
+	if err != nil {
+		return models.AuthSettings{}, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	return models.ConvertSettingsToAuthSettings(settings), nil
+}
+
+// UpdateAuthSettings updates the authentication method of the Portainer instance and,
+// when provided, its key OAuth settings. The provided OAuth settings replace the current
+// key OAuth settings while the other OAuth settings are kept as they are.
+// An empty client secret keeps the current client secret.
+//
+// Parameters:
+//   - settings: The authentication settings to apply
+//
+// Returns:
+//   - An error if the settings are invalid or if the operation fails
+func (c *PortainerClient) UpdateAuthSettings(settings models.AuthSettings) error {
+	if err := validateAuthSettings(settings); err != nil {
+		return err
+	}
+
+	current, err := c.cli.GetSettings()
+	if err != nil {
+		return fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	var oauthSettings *apimodels.PortainereeOAuthSettings
+	if settings.OAuth != nil {
+		oauthSettings = mergeOAuthSettings(current.OAuthSettings, *settings.OAuth)
+	} else if settings.Method == models.AuthenticationMethodOAuth && (current.OAuthSettings == nil || current.OAuthSettings.ClientID == "") {
+		return fmt.Errorf("oauth settings are required to enable oauth authentication")
+	}
+
+	err = c.cli.UpdateAuthSettings(convertAuthenticationMethodID(settings.Method), oauthSettings)
+	if err != nil {
+		return fmt.Errorf("failed to update auth settings: %w", err)
+	}
+
+	return nil
+}
+
+// validateAuthSettings checks the authentication method and the required OAuth settings.
+// Error messages never reference the client secret.
+func validateAuthSettings(settings models.AuthSettings) error {
+	if !models.IsValidAuthenticationMethod(settings.Method) {
+		return fmt.Errorf("invalid authentication method %q: must be one of: %v", settings.Method, models.AllAuthenticationMethods)
+	}
+
+	if settings.OAuth == nil {
+		return nil
+	}
+
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"client_id", settings.OAuth.ClientID},
+		{"authorization_uri", settings.OAuth.AuthorizationURI},
+		{"access_token_uri", settings.OAuth.AccessTokenURI},
+		{"resource_uri", settings.OAuth.ResourceURI},
+		{"redirect_uri", settings.OAuth.RedirectURI},
+		{"user_identifier", settings.OAuth.UserIdentifier},
+	}
+	for _, field := range required {
+		if strings.TrimSpace(field.value) == "" {
+			return fmt.Errorf("oauth %s cannot be empty", field.name)
+		}
+	}
+
+	return nil
+}
+
+// mergeOAuthSettings applies the key OAuth settings on top of the current OAuth settings.
+// The current client secret is never sent back, Portainer keeps it when the client secret is empty.
+func mergeOAuthSettings(current *apimodels.PortainereeOAuthSettings, settings models.OAuthSettings) *apimodels.PortainereeOAuthSettings {
+	merged := apimodels.PortainereeOAuthSettings{}
+	if current != nil {
+		merged = *current
+	}
+
+	merged.ClientID = settings.ClientID
+	merged.ClientSecret = settings.ClientSecret
+	merged.AuthorizationURI = settings.AuthorizationURI
+	merged.AccessTokenURI = settings.AccessTokenURI
+	merged.ResourceURI = settings.ResourceURI
+	merged.RedirectURI = settings.RedirectURI
+	merged.LogoutURI = settings.LogoutURI
+	merged.UserIdentifier = settings.UserIdentifier
+	merged.Scopes = settings.Scopes
+	merged.SSO = settings.SSO
+	merged.OAuthAutoCreateUsers = settings.AutoCreateUsers
+	merged.DefaultTeamID = int64(settings.DefaultTeamID)
+
+	return &merged
+}
+
+// convertAuthenticationMethodID converts an authentication method to its Portainer ID
+func convertAuthenticationMethodID(method string) int64 {
+	switch method {
+	case models.AuthenticationMethodInternal:
+		return 1
+	case models.AuthenticationMethodLDAP:
+		return 2
+	case models.AuthenticationMethodOAuth:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// GetBrandingSettings retrieves the branding settings of the Portainer instance.
+//
+// Returns:
+//   - The logo URL and the login banner, empty when Portainer uses its defaults
+//   - An error if the operation fails
+func (c *PortainerClient) GetBrandingSettings() (models.Branding, error) {
+	settings, err := c.cli.GetSettings()
+	if err != nil {
+		return models.Branding{}, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	return models.ConvertSettingsToBranding(settings), nil
+}
+
+// UpdateBrandingSettings updates the branding settings of the Portainer instance. The provided
+// settings are applied on top of the current branding settings: an empty logo URL or login banner
+// keeps the current one, Portainer cannot clear them through the settings update. The other
+// settings of the instance are left unchanged. Nothing is sent when the branding settings are
+// already up to date.
+//
+// Parameters:
+//   - branding: The branding settings to apply
+//
+// Returns:
+//   - An error if the logo URL is invalid or if the operation fails
+func (c *PortainerClient) UpdateBrandingSettings(branding models.Branding) error {
+	if branding.LogoURL == "" && branding.LoginBanner == "" {
+		return fmt.Errorf("a logo URL or a login banner is required")
+	}
+
+	if branding.LogoURL != "" {
+		if err := validateLogoURL(branding.LogoURL); err != nil {
+			return fmt.Errorf("invalid logo URL: %w", err)
+		}
+	}
+
+	settings, err := c.cli.GetSettings()
+	if err != nil {
+		return fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	current := models.ConvertSettingsToBranding(settings)
+	merged := current
+	if branding.LogoURL != "" {
+		merged.LogoURL = branding.LogoURL
+	}
+	if branding.LoginBanner != "" {
+		merged.LoginBanner = branding.LoginBanner
+	}
+
+	if merged == current {
+		return nil
+	}
+
+	err = c.cli.UpdateBrandingSettings(merged.LogoURL, merged.LoginBanner)
+	if err != nil {
+		return fmt.Errorf("failed to update branding settings: %w", err)
+	}
+
+	return nil
+}
+
+// logoImageExtensions lists the extensions accepted for a logo URL whose path has an extension
+var logoImageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".ico", ".bmp"}
+
+// validateLogoURL checks that a logo URL is an absolute http or https URL and, when its path has
+// an extension, that it points to an image. Paths without extension are accepted, many image
+// services do not use one.
+func validateLogoURL(logoURL string) error {
+	u, err := url.Parse(logoURL)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %w", logoURL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q, the scheme must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", logoURL)
+	}
+
+	if ext := strings.ToLower(path.Ext(u.Path)); ext != "" && !slices.Contains(logoImageExtensions, ext) {
+		return fmt.Errorf("%q does not point to an image, the extension must be one of: %v", logoURL, logoImageExtensions)
+	}
+
+	return nil
+}
+
+// minSessionTimeout is the shortest session timeout accepted, shorter sessions would log the users
+// out before they can use Portainer
+const minSessionTimeout = time.Minute
+
+// UpdateSessionTimeout updates the duration of the user sessions of the Portainer instance, after
+// which the users have to log in again. The other settings of the instance are left unchanged.
+// Nothing is sent when the session timeout is already the requested duration.
+//
+// Parameters:
+//   - sessionTimeout: The duration of a user session in the Go duration format, e.g. 30m, 8h or 168h
+//
+// Returns:
+//   - An error if the duration is invalid or if the operation fails
+func (c *PortainerClient) UpdateSessionTimeout(sessionTimeout string) error {
+	timeout, err := validateSessionTimeout(sessionTimeout)
+	if err != nil {
+		return err
+	}
+
+	settings, err := c.cli.GetSettings()
+	if err != nil {
+		return fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	if current, err := time.ParseDuration(settings.UserSessionTimeout); err == nil && current == timeout {
+		return nil
+	}
+
+	err = c.cli.UpdateSessionTimeout(sessionTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to update session timeout: %w", err)
+	}
+
+	return nil
+}
+
+// validateSessionTimeout parses a session timeout and checks that it is at least minSessionTimeout
+func validateSessionTimeout(sessionTimeout string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(sessionTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid session timeout %q: must be a duration such as 30m, 8h or 168h", sessionTimeout)
+	}
+
+	if timeout < minSessionTimeout {
+		return 0, fmt.Errorf("invalid session timeout %q: must be at least %s", sessionTimeout, minSessionTimeout)
+	}
+
+	return timeout, nil
+}
+
+// GetSSLSettings retrieves the TLS settings of the Portainer server.
+// The certificate and the private key are never returned, only their paths on the server.
+//
+// Returns:
+//   - The SSL settings of the Portainer server
+//   - An error if the operation fails
+func (c *PortainerClient) GetSSLSettings() (models.SSLSettings, error) {
+	settings, err := c.cli.GetSSLSettings()
+	if err != nil {
+		return models.SSLSettings{}, fmt.Errorf("failed to get SSL settings: %w", err)
+	}
+
+	return models.ConvertToSSLSettings(settings), nil
+}
+
+// UpdateSSLSettings updates the TLS settings of the Portainer server. A new certificate is
+// uploaded when both the certificate and the key are provided, the current certificate is kept
+// when both are empty. Portainer restarts its HTTP servers to apply the new settings, requests
+// can fail until it is back.
+//
+// Parameters:
+//   - settings: The SSL settings to apply, only Certificate, Key and HTTPEnabled are used
+//
+// Returns:
+//   - An error if the certificate and key are invalid or if the operation fails
+func (c *PortainerClient) UpdateSSLSettings(settings models.SSLSettings) error {
+	if err := validateSSLCertificate(settings.Certificate, settings.Key); err != nil {
+		return err
+	}
+
+	err := c.cli.UpdateSSLSettings(settings.Certificate, settings.Key, settings.HTTPEnabled)
+	if err != nil {
+		return fmt.Errorf("failed to update SSL settings: %w", err)
+	}
+
+	return nil
+}
+
+// validateSSLCertificate checks that the certificate and the key are provided together and form
+// a valid key pair, so that Portainer is not restarted with a certificate it cannot serve.
+// Error messages never include the key.
+func validateSSLCertificate(cert, key string) error {
+	if cert == "" && key == "" {
+		return nil
+	}
+
+	if cert == "" || key == "" {
+		return fmt.Errorf("the certificate and the key must be provided together")
+	}
+
+	if _, err := tls.X509KeyPair([]byte(cert), []byte(key)); err != nil {
+		return fmt.Errorf("invalid certificate and key pair: %w", err)
+	}
+
+	return nil
+}
@@ -1,12 +1,22 @@
 package client
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
 	"testing"
+	"time"
 
 	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetSettings(t *testing.T) {
@@ -21,6 +31,7 @@ func TestGetSettings(t *testing.T) {
 			name: "successful retrieval - internal auth",
 			mockSettings: &apimodels.PortainereeSettings{
 				AuthenticationMethod:      1, // internal
+				UserSessionTimeout:        "8h",
 				EnableEdgeComputeFeatures: true,
 				Edge: &apimodels.PortainereeEdge{
 					TunnelServerAddress: "tunnel.example.com",
@@ -28,9 +39,11 @@ func TestGetSettings(t *testing.T) {
 			},
 			expected: models.PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
-					Method: models.AuthenticationMethodInternal,
+					Method:         models.AuthenticationMethodInternal,
+					SessionTimeout: "8h",
 				},
 				Edge: struct {
 					Enabled   bool   `json:"enabled"`
@@ -52,7 +65,8 @@ func TestGetSettings(t *testing.T) {
 			},
 			expected: models.PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
 					Method: models.AuthenticationMethodLDAP,
 				},
@@ -76,7 +90,8 @@ func TestGetSettings(t *testing.T) {
 			},
 			expected: models.PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
 					Method: models.AuthenticationMethodOAuth,
 				},
@@ -100,7 +115,8 @@ func TestGetSettings(t *testing.T) {
 			},
 			expected: models.PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
 					Method: models.AuthenticationMethodUnknown,
 				},
@@ -139,3 +155,551 @@ func TestGetSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAuthSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSettings  *apimodels.PortainereeSettings
+		mockError     error
+		expected      models.AuthSettings
+		expectedError bool
+	}{
+		{
+			name: "client secret is never returned",
+			mockSettings: &apimodels.PortainereeSettings{
+				AuthenticationMethod: 3,
+				OAuthSettings: &apimodels.PortainereeOAuthSettings{
+					ClientID:     "portainer",
+					ClientSecret: "super-secret",
+					SSO:          true,
+				},
+			},
+			expected: models.AuthSettings{
+				Method: models.AuthenticationMethodOAuth,
+				OAuth: &models.OAuthSettings{
+					ClientID: "portainer",
+					SSO:      true,
+				},
+			},
+		},
+		{
+			name:          "get settings error",
+			mockError:     errors.New("failed to get settings"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetSettings").Return(tt.mockSettings, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			settings, err := client.GetAuthSettings()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, settings)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateAuthSettings(t *testing.T) {
+	validOAuth := func() *models.OAuthSettings {
+		return &models.OAuthSettings{
+			ClientID:         "portainer",
+			ClientSecret:     "new-secret",
+			AuthorizationURI: "https://idp.example.com/authorize",
+			AccessTokenURI:   "https://idp.example.com/token",
+			ResourceURI:      "https://idp.example.com/userinfo",
+			RedirectURI:      "https://portainer.example.com",
+			UserIdentifier:   "email",
+			Scopes:           "openid email",
+			SSO:              true,
+			DefaultTeamID:    2,
+		}
+	}
+
+	currentSettings := &apimodels.PortainereeSettings{
+		AuthenticationMethod: 1,
+		OAuthSettings: &apimodels.PortainereeOAuthSettings{
+			ClientID:          "old-client",
+			ClientSecret:      "old-secret",
+			MicrosoftTenantID: "tenant",
+		},
+	}
+
+	tests := []struct {
+		name              string
+		input             models.AuthSettings
+		currentSettings   *apimodels.PortainereeSettings
+		expectUpdate      bool
+		expectedMethod    int64
+		expectedOAuth     *apimodels.PortainereeOAuthSettings
+		mockUpdateError   error
+		expectedError     bool
+		expectedErrorText string
+	}{
+		{
+			name:            "update oauth settings",
+			input:           models.AuthSettings{Method: models.AuthenticationMethodOAuth, OAuth: validOAuth()},
+			currentSettings: currentSettings,
+			expectUpdate:    true,
+			expectedMethod:  3,
+			expectedOAuth: &apimodels.PortainereeOAuthSettings{
+				ClientID:          "portainer",
+				ClientSecret:      "new-secret",
+				AuthorizationURI:  "https://idp.example.com/authorize",
+				AccessTokenURI:    "https://idp.example.com/token",
+				ResourceURI:       "https://idp.example.com/userinfo",
+				RedirectURI:       "https://portainer.example.com",
+				UserIdentifier:    "email",
+				Scopes:            "openid email",
+				SSO:               true,
+				DefaultTeamID:     2,
+				MicrosoftTenantID: "tenant",
+			},
+		},
+		{
+			name:            "empty client secret keeps the current one",
+			input:           models.AuthSettings{Method: models.AuthenticationMethodOAuth, OAuth: func() *models.OAuthSettings { o := validOAuth(); o.ClientSecret = ""; return o }()},
+			currentSettings: currentSettings,
+			expectUpdate:    true,
+			expectedMethod:  3,
+			expectedOAuth: &apimodels.PortainereeOAuthSettings{
+				ClientID:          "portainer",
+				AuthorizationURI:  "https://idp.example.com/authorize",
+				AccessTokenURI:    "https://idp.example.com/token",
+				ResourceURI:       "https://idp.example.com/userinfo",
+				RedirectURI:       "https://portainer.example.com",
+				UserIdentifier:    "email",
+				Scopes:            "openid email",
+				SSO:               true,
+				DefaultTeamID:     2,
+				MicrosoftTenantID: "tenant",
+			},
+		},
+		{
+			name:            "switch to internal authentication",
+			input:           models.AuthSettings{Method: models.AuthenticationMethodInternal},
+			currentSettings: currentSettings,
+			expectUpdate:    true,
+			expectedMethod:  1,
+		},
+		{
+			name:              "enable oauth without oauth settings",
+			input:             models.AuthSettings{Method: models.AuthenticationMethodOAuth},
+			currentSettings:   &apimodels.PortainereeSettings{AuthenticationMethod: 1},
+			expectedError:     true,
+			expectedErrorText: "oauth settings are required",
+		},
+		{
+			name:              "invalid authentication method",
+			input:             models.AuthSettings{Method: "saml"},
+			expectedError:     true,
+			expectedErrorText: "invalid authentication method",
+		},
+		{
+			name:              "missing oauth client id",
+			input:             models.AuthSettings{Method: models.AuthenticationMethodOAuth, OAuth: func() *models.OAuthSettings { o := validOAuth(); o.ClientID = ""; return o }()},
+			expectedError:     true,
+			expectedErrorText: "oauth client_id cannot be empty",
+		},
+		{
+			name:            "update error",
+			input:           models.AuthSettings{Method: models.AuthenticationMethodLDAP},
+			currentSettings: currentSettings,
+			expectUpdate:    true,
+			expectedMethod:  2,
+			mockUpdateError: errors.New("failed to update settings"),
+			expectedError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.currentSettings != nil {
+				mockAPI.On("GetSettings").Return(tt.currentSettings, nil)
+			}
+			if tt.expectUpdate {
+				mockAPI.On("UpdateAuthSettings", tt.expectedMethod, tt.expectedOAuth).Return(tt.mockUpdateError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateAuthSettings(tt.input)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				if tt.expectedErrorText != "" {
+					assert.Contains(t, err.Error(), tt.expectedErrorText)
+				}
+				assert.NotContains(t, err.Error(), "secret")
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetPublicSettings(t *testing.T) {
+	t.Run("oauth authentication", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetPublicSettings").Return(&apimodels.SettingsPublicSettingsResponse{
+			AuthenticationMethod: 3,
+			OAuthLoginURI:        "https://sso.example.com/authorize",
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		settings, err := client.GetPublicSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PublicSettings{
+			AuthenticationMethod: models.AuthenticationMethodOAuth,
+			OAuthLoginURI:        "https://sso.example.com/authorize",
+		}, settings)
+	})
+
+	t.Run("get public settings error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetPublicSettings").Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetPublicSettings()
+
+		assert.ErrorContains(t, err, "failed to get public settings: connection refused")
+	})
+}
+
+func TestGetBrandingSettings(t *testing.T) {
+	t.Run("branding set", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetSettings").Return(&apimodels.PortainereeSettings{
+			LogoURL:           "https://example.com/logo.png",
+			CustomLoginBanner: "Authorized users only",
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		branding, err := client.GetBrandingSettings()
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.Branding{LogoURL: "https://example.com/logo.png", LoginBanner: "Authorized users only"}, branding)
+	})
+
+	t.Run("get settings error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetSettings").Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetBrandingSettings()
+
+		assert.ErrorContains(t, err, "failed to get settings: connection refused")
+	})
+}
+
+func TestUpdateBrandingSettings(t *testing.T) {
+	currentSettings := &apimodels.PortainereeSettings{
+		LogoURL:           "https://example.com/old-logo.png",
+		CustomLoginBanner: "Welcome",
+	}
+
+	tests := []struct {
+		name                string
+		input               models.Branding
+		expectUpdate        bool
+		expectedLogoURL     string
+		expectedLoginBanner string
+		mockUpdateError     error
+		expectedError       string
+	}{
+		{
+			name:                "update logo and login banner",
+			input:               models.Branding{LogoURL: "https://example.com/logo.svg", LoginBanner: "Authorized users only"},
+			expectUpdate:        true,
+			expectedLogoURL:     "https://example.com/logo.svg",
+			expectedLoginBanner: "Authorized users only",
+		},
+		{
+			name:                "empty login banner keeps the current banner",
+			input:               models.Branding{LogoURL: "https://cdn.example.com/images/12345"},
+			expectUpdate:        true,
+			expectedLogoURL:     "https://cdn.example.com/images/12345",
+			expectedLoginBanner: "Welcome",
+		},
+		{
+			name:                "empty logo URL keeps the current logo",
+			input:               models.Branding{LoginBanner: "Authorized users only"},
+			expectUpdate:        true,
+			expectedLogoURL:     "https://example.com/old-logo.png",
+			expectedLoginBanner: "Authorized users only",
+		},
+		{
+			name:  "already up to date",
+			input: models.Branding{LogoURL: "https://example.com/old-logo.png", LoginBanner: "Welcome"},
+		},
+		{
+			name:          "nothing to update",
+			input:         models.Branding{},
+			expectedError: "a logo URL or a login banner is required",
+		},
+		{
+			name:          "unsupported scheme",
+			input:         models.Branding{LogoURL: "ftp://example.com/logo.png"},
+			expectedError: `unsupported scheme "ftp"`,
+		},
+		{
+			name:          "relative URL",
+			input:         models.Branding{LogoURL: "logo.png"},
+			expectedError: `unsupported scheme ""`,
+		},
+		{
+			name:          "missing host",
+			input:         models.Branding{LogoURL: "https:///logo.png"},
+			expectedError: "has no host",
+		},
+		{
+			name:          "not an image",
+			input:         models.Branding{LogoURL: "https://example.com/index.html"},
+			expectedError: "does not point to an image",
+		},
+		{
+			name:                "update error",
+			input:               models.Branding{LogoURL: "https://example.com/logo.png"},
+			expectUpdate:        true,
+			expectedLogoURL:     "https://example.com/logo.png",
+			expectedLoginBanner: "Welcome",
+			mockUpdateError:     errors.New("forbidden"),
+			expectedError:       "failed to update branding settings: forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetSettings").Return(currentSettings, nil).Maybe()
+			if tt.expectUpdate {
+				mockAPI.On("UpdateBrandingSettings", tt.expectedLogoURL, tt.expectedLoginBanner).Return(tt.mockUpdateError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateBrandingSettings(tt.input)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.expectUpdate {
+				mockAPI.AssertNotCalled(t, "UpdateBrandingSettings", mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateSessionTimeout(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectUpdate    bool
+		mockUpdateError error
+		expectedError   string
+	}{
+		{
+			name:         "update session timeout",
+			input:        "24h",
+			expectUpdate: true,
+		},
+		{
+			name:  "same duration in another format",
+			input: "480m",
+		},
+		{
+			name:          "invalid duration",
+			input:         "8 hours",
+			expectedError: "invalid session timeout",
+		},
+		{
+			name:          "duration too short",
+			input:         "30s",
+			expectedError: "must be at least 1m0s",
+		},
+		{
+			name:            "update error",
+			input:           "24h",
+			expectUpdate:    true,
+			mockUpdateError: errors.New("forbidden"),
+			expectedError:   "failed to update session timeout: forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetSettings").Return(&apimodels.PortainereeSettings{UserSessionTimeout: "8h"}, nil)
+			if tt.expectUpdate {
+				mockAPI.On("UpdateSessionTimeout", tt.input).Return(tt.mockUpdateError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateSessionTimeout(tt.input)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.expectUpdate {
+				mockAPI.AssertNotCalled(t, "UpdateSessionTimeout", mock.Anything)
+			}
+		})
+	}
+}
+
+// newTestCertificate returns a PEM encoded self-signed certificate and its private key
+func newTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "portainer.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	return string(cert), string(keyPem)
+}
+
+func TestGetSSLSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSettings  *apimodels.PortainereeSSLSettings
+		mockError     error
+		expected      models.SSLSettings
+		expectedError bool
+	}{
+		{
+			name: "successful retrieval",
+			mockSettings: &apimodels.PortainereeSSLSettings{
+				CertPath:    "/certs/portainer.crt",
+				KeyPath:     "/certs/portainer.key",
+				HTTPEnabled: true,
+			},
+			expected: models.SSLSettings{
+				CertPath:    "/certs/portainer.crt",
+				KeyPath:     "/certs/portainer.key",
+				HTTPEnabled: true,
+			},
+		},
+		{
+			name:          "get error",
+			mockError:     errors.New("access denied"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetSSLSettings").Return(tt.mockSettings, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			settings, err := client.GetSSLSettings()
+
+			if tt.expectedError {
+				assert.ErrorContains(t, err, tt.mockError.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, settings)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateSSLSettings(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	otherCert, _ := newTestCertificate(t)
+
+	tests := []struct {
+		name          string
+		input         models.SSLSettings
+		expectUpdate  bool
+		mockError     error
+		expectedError string
+	}{
+		{
+			name:         "upload certificate",
+			input:        models.SSLSettings{Certificate: cert, Key: key, HTTPEnabled: true},
+			expectUpdate: true,
+		},
+		{
+			name:         "disable http only",
+			input:        models.SSLSettings{HTTPEnabled: false},
+			expectUpdate: true,
+		},
+		{
+			name:          "certificate without key",
+			input:         models.SSLSettings{Certificate: cert},
+			expectedError: "must be provided together",
+		},
+		{
+			name:          "key not matching the certificate",
+			input:         models.SSLSettings{Certificate: otherCert, Key: key},
+			expectedError: "invalid certificate and key pair",
+		},
+		{
+			name:          "update error",
+			input:         models.SSLSettings{HTTPEnabled: true},
+			expectUpdate:  true,
+			mockError:     errors.New("access denied"),
+			expectedError: "failed to update SSL settings: access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectUpdate {
+				mockAPI.On("UpdateSSLSettings", tt.input.Certificate, tt.input.Key, tt.input.HTTPEnabled).Return(tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateSSLSettings(tt.input)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.NotContains(t, err.Error(), key)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// snapshotPollInterval is the delay between two checks of the snapshot time of an environment
+var snapshotPollInterval = time.Second
+
+// TriggerEnvironmentSnapshot asks Portainer to take a new snapshot of an environment, refreshing
+// the containers, images, volumes and resource usage that Portainer reports for it.
+// Snapshots of Edge environments are sent by their agent and Azure environments have no snapshot,
+// these environments are rejected before anything is sent to Portainer.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - ErrFeatureUnavailable if the environment does not support snapshots on demand
+//   - An error if the operation fails
+func (c *PortainerClient) TriggerEnvironmentSnapshot(id int) error {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if !supportsSnapshotOnDemand(environment.Type) {
+		return fmt.Errorf("environment %d is a %s environment, it does not support snapshots on demand: %w", id, environment.Type, ErrFeatureUnavailable)
+	}
+
+	if err := c.cli.SnapshotEndpoint(int64(id)); err != nil {
+		return fmt.Errorf("failed to snapshot environment: %w", err)
+	}
+
+	return nil
+}
+
+// GetEnvironmentSnapshotTime retrieves the time of the latest snapshot of an environment.
+//
+// Parameters:
+//   - id: The ID of the environment
+//
+// Returns:
+//   - The time of the latest snapshot, the zero time when the environment has no snapshot
+//   - An error if the operation fails
+func (c *PortainerClient) GetEnvironmentSnapshotTime(id int) (time.Time, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(id))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	return latestSnapshotTime(endpoint), nil
+}
+
+// WaitForEnvironmentSnapshot waits until the latest snapshot of an environment is more recent than a given time.
+// The snapshot time is checked every second until the timeout is reached or the context is cancelled.
+//
+// Parameters:
+//   - ctx: The context of the wait, cancelling it stops the wait
+//   - id: The ID of the environment
+//   - after: The time the new snapshot must be more recent than, usually the time of the previous snapshot
+//   - timeout: The maximum time to wait for
+//
+// Returns:
+//   - The time of the new snapshot
+//   - An error if no new snapshot is available before the timeout, if the wait is cancelled or if the operation fails
+func (c *PortainerClient) WaitForEnvironmentSnapshot(ctx context.Context, id int, after time.Time, timeout time.Duration) (time.Time, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		snapshotTime, err := c.GetEnvironmentSnapshotTime(id)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if snapshotTime.After(after) {
+			return snapshotTime, nil
+		}
+
+		if !time.Now().Add(snapshotPollInterval).Before(deadline) {
+			return time.Time{}, fmt.Errorf("no new snapshot of environment %d after %s", id, timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return time.Time{}, fmt.Errorf("stopped waiting for a new snapshot of environment %d: %w", id, ctx.Err())
+		case <-time.After(snapshotPollInterval):
+		}
+	}
+}
+
+// supportsSnapshotOnDemand reports whether Portainer can take the snapshot of an environment type on demand
+func supportsSnapshotOnDemand(environmentType string) bool {
+	switch environmentType {
+	case models.EnvironmentTypeDockerLocal, models.EnvironmentTypeDockerAgent,
+		models.EnvironmentTypeKubernetesLocal, models.EnvironmentTypeKubernetesAgent:
+		return true
+	default:
+		return false
+	}
+}
+
+// latestSnapshotTime returns the time of the most recent Docker or Kubernetes snapshot of an endpoint
+func latestSnapshotTime(endpoint *apimodels.PortainereeEndpoint) time.Time {
+	var latest int64
+
+	for _, snapshot := range endpoint.Snapshots {
+		if snapshot != nil && snapshot.Time > latest {
+			latest = snapshot.Time
+		}
+	}
+
+	if endpoint.Kubernetes != nil {
+		for _, snapshot := range endpoint.Kubernetes.Snapshots {
+			if snapshot != nil && snapshot.Time > latest {
+				latest = snapshot.Time
+			}
+		}
+	}
+
+	if latest == 0 {
+		return time.Time{}
+	}
+	return time.Unix(latest, 0).UTC()
+}
+
+// GetEnvironmentSnapshots retrieves the counts of the latest snapshot of every environment. The
+// environments are inspected concurrently, at most maxConcurrentEnvironmentRequests at a time, as the
+// snapshots are only returned with the details of an environment. An environment that cannot be
+// inspected is returned without snapshot.
+//
+// Returns:
+//   - A slice of EnvironmentSnapshot objects, sorted by environment ID
+//   - An error if the environments cannot be listed
+func (c *PortainerClient) GetEnvironmentSnapshots() ([]models.EnvironmentSnapshot, error) {
+	endpoints, err := c.cli.ListEndpoints()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	inspected := make([]*apimodels.PortainereeEndpoint, len(endpoints))
+
+	forEachConcurrently(len(endpoints), maxConcurrentEnvironmentRequests, func(i int) {
+		if detailed, err := c.cli.GetEndpoint(endpoints[i].ID); err == nil {
+			inspected[i] = detailed
+		}
+	})
+
+	snapshots := make([]models.EnvironmentSnapshot, len(endpoints))
+	for i, endpoint := range endpoints {
+		environment := models.ConvertEndpointToEnvironment(endpoint)
+		snapshots[i] = models.EnvironmentSnapshot{
+			EnvironmentID:   environment.ID,
+			EnvironmentName: environment.Name,
+			Type:            environment.Type,
+			Status:          environment.Status,
+		}
+
+		if inspected[i] == nil {
+			continue
+		}
+		if docker := latestDockerSnapshot(inspected[i]); docker != nil {
+			snapshots[i].RunningContainers = int(docker.RunningContainerCount)
+			snapshots[i].StoppedContainers = int(docker.StoppedContainerCount)
+			snapshots[i].HealthyContainers = int(docker.HealthyContainerCount)
+			snapshots[i].UnhealthyContainers = int(docker.UnhealthyContainerCount)
+			snapshots[i].Stacks = int(docker.StackCount)
+			snapshots[i].Images = int(docker.ImageCount)
+			snapshots[i].Volumes = int(docker.VolumeCount)
+			snapshots[i].Nodes = int(docker.NodeCount)
+		}
+		if kubernetes := latestKubernetesSnapshot(inspected[i]); kubernetes != nil {
+			snapshots[i].Nodes = int(kubernetes.NodeCount)
+		}
+		if snapshotTime := latestSnapshotTime(inspected[i]); !snapshotTime.IsZero() {
+			snapshots[i].SnapshotTime = snapshotTime.Unix()
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].EnvironmentID < snapshots[j].EnvironmentID
+	})
+
+	return snapshots, nil
+}
+
+// latestKubernetesSnapshot returns the most recent Kubernetes snapshot of an endpoint, nil when it has none
+func latestKubernetesSnapshot(endpoint *apimodels.PortainereeEndpoint) *apimodels.PortainerKubernetesSnapshot {
+	if endpoint.Kubernetes == nil {
+		return nil
+	}
+
+	var latest *apimodels.PortainerKubernetesSnapshot
+	for _, snapshot := range endpoint.Kubernetes.Snapshots {
+		if snapshot != nil && (latest == nil || snapshot.Time > latest.Time) {
+			latest = snapshot
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerEnvironmentSnapshot(t *testing.T) {
+	tests := []struct {
+		name           string
+		endpointType   int64
+		expectSnapshot bool
+		mockError      error
+		expectedError  error
+	}{
+		{
+			name:           "docker environment",
+			endpointType:   1,
+			expectSnapshot: true,
+		},
+		{
+			name:           "kubernetes environment",
+			endpointType:   6,
+			expectSnapshot: true,
+		},
+		{
+			name:          "edge environment",
+			endpointType:  4,
+			expectedError: ErrFeatureUnavailable,
+		},
+		{
+			name:          "azure environment",
+			endpointType:  3,
+			expectedError: ErrFeatureUnavailable,
+		},
+		{
+			name:           "snapshot error",
+			endpointType:   2,
+			expectSnapshot: true,
+			mockError:      errors.New("failed to snapshot endpoint"),
+			expectedError:  errors.New("failed to snapshot endpoint"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.expectSnapshot {
+				mockAPI.On("SnapshotEndpoint", int64(1)).Return(tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.TriggerEnvironmentSnapshot(1)
+
+			switch {
+			case tt.expectedError == nil:
+				assert.NoError(t, err)
+			case errors.Is(tt.expectedError, ErrFeatureUnavailable):
+				assert.ErrorIs(t, err, ErrFeatureUnavailable)
+			default:
+				assert.ErrorContains(t, err, tt.expectedError.Error())
+			}
+
+			if !tt.expectSnapshot {
+				mockAPI.AssertNotCalled(t, "SnapshotEndpoint", mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetEnvironmentSnapshotTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *apimodels.PortainereeEndpoint
+		expected time.Time
+	}{
+		{
+			name: "docker snapshots",
+			endpoint: &apimodels.PortainereeEndpoint{
+				Snapshots: []*apimodels.PortainerDockerSnapshot{{Time: 1700000000}, {Time: 1700000100}},
+			},
+			expected: time.Unix(1700000100, 0).UTC(),
+		},
+		{
+			name: "kubernetes snapshots",
+			endpoint: &apimodels.PortainereeEndpoint{
+				Kubernetes: &apimodels.PortainereeKubernetesData{
+					Snapshots: []*apimodels.PortainerKubernetesSnapshot{{Time: 1700000200}},
+				},
+			},
+			expected: time.Unix(1700000200, 0).UTC(),
+		},
+		{
+			name:     "no snapshot",
+			endpoint: &apimodels.PortainereeEndpoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(tt.endpoint, nil)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			snapshotTime, err := client.GetEnvironmentSnapshotTime(1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, snapshotTime)
+		})
+	}
+}
+
+func TestWaitForEnvironmentSnapshot(t *testing.T) {
+	previousInterval := snapshotPollInterval
+	snapshotPollInterval = time.Millisecond
+	t.Cleanup(func() { snapshotPollInterval = previousInterval })
+
+	before := time.Unix(1700000000, 0).UTC()
+	oldEndpoint := &apimodels.PortainereeEndpoint{Snapshots: []*apimodels.PortainerDockerSnapshot{{Time: 1700000000}}}
+	newEndpoint := &apimodels.PortainereeEndpoint{Snapshots: []*apimodels.PortainerDockerSnapshot{{Time: 1700000060}}}
+
+	t.Run("new snapshot after a few checks", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(oldEndpoint, nil).Twice()
+		mockAPI.On("GetEndpoint", int64(1)).Return(newEndpoint, nil).Once()
+
+		client := &PortainerClient{cli: mockAPI}
+
+		snapshotTime, err := client.WaitForEnvironmentSnapshot(context.Background(), 1, before, time.Second)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Unix(1700000060, 0).UTC(), snapshotTime)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(oldEndpoint, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.WaitForEnvironmentSnapshot(context.Background(), 1, before, 20*time.Millisecond)
+
+		assert.ErrorContains(t, err, "no new snapshot of environment 1")
+	})
+
+	t.Run("get endpoint error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(nil, errors.New("not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.WaitForEnvironmentSnapshot(context.Background(), 1, before, time.Second)
+
+		assert.ErrorContains(t, err, "failed to get endpoint")
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEndpoint", int64(1)).Return(oldEndpoint, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.WaitForEnvironmentSnapshot(ctx, 1, before, time.Second)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		mockAPI.AssertNumberOfCalls(t, "GetEndpoint", 1)
+	})
+}
+
+func TestGetEnvironmentSnapshots(t *testing.T) {
+	docker := &apimodels.PortainereeEndpoint{ID: 2, Name: "prod", Type: 1, Status: 1, Snapshots: []*apimodels.PortainerDockerSnapshot{
+		{Time: 100, RunningContainerCount: 1},
+		{Time: 200, RunningContainerCount: 4, StoppedContainerCount: 1, HealthyContainerCount: 3, UnhealthyContainerCount: 1,
+			StackCount: 2, ImageCount: 7, VolumeCount: 3, NodeCount: 1},
+	}}
+	kubernetes := &apimodels.PortainereeEndpoint{ID: 1, Name: "k8s", Type: 5, Status: 1, Kubernetes: &apimodels.PortainereeKubernetesData{
+		Snapshots: []*apimodels.PortainerKubernetesSnapshot{{Time: 300, NodeCount: 3}},
+	}}
+	unreachable := &apimodels.PortainereeEndpoint{ID: 3, Name: "edge", Type: 4, Status: 2}
+
+	t.Run("latest snapshots", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return([]*apimodels.PortainereeEndpoint{docker, kubernetes, unreachable}, nil)
+		mockAPI.On("GetEndpoint", int64(2)).Return(docker, nil)
+		mockAPI.On("GetEndpoint", int64(1)).Return(kubernetes, nil)
+		mockAPI.On("GetEndpoint", int64(3)).Return(nil, errors.New("endpoint database is locked"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		snapshots, err := client.GetEnvironmentSnapshots()
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.EnvironmentSnapshot{
+			{EnvironmentID: 1, EnvironmentName: "k8s", Type: models.EnvironmentTypeKubernetesLocal, Status: models.EnvironmentStatusActive,
+				SnapshotTime: 300, Nodes: 3},
+			{EnvironmentID: 2, EnvironmentName: "prod", Type: models.EnvironmentTypeDockerLocal, Status: models.EnvironmentStatusActive,
+				SnapshotTime: 200, RunningContainers: 4, StoppedContainers: 1, HealthyContainers: 3, UnhealthyContainers: 1,
+				Stacks: 2, Images: 7, Volumes: 3, Nodes: 1},
+			{EnvironmentID: 3, EnvironmentName: "edge", Type: models.EnvironmentTypeDockerEdgeAgent, Status: models.EnvironmentStatusInactive},
+		}, snapshots)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEndpoints").Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetEnvironmentSnapshots()
+
+		assert.ErrorContains(t, err, "failed to list endpoints")
+	})
+}
@@ -2,6 +2,9 @@ package client
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
@@ -85,3 +88,71 @@ func (c *PortainerClient) UpdateStack(id int, file string, environmentGroupIds [
 
 	return nil
 }
+
+// CloneStack creates a new stack from the file of an existing stack.
+// This is typically used to promote a stack from one set of environment groups to another.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// Parameters:
+//   - sourceStackId: The ID of the stack to clone
+//   - newName: The name of the new stack
+//   - targetGroupIds: A slice of environment group IDs to deploy the new stack to
+//   - imageReplacements: An optional map of image references to replace in the stack file (e.g. "app:1.0" -> "app:1.1")
+//
+// Returns:
+//   - The ID of the created stack
+//   - An error if the operation fails or if an image replacement does not match any image of the stack file
+func (c *PortainerClient) CloneStack(sourceStackId int, newName string, targetGroupIds []int, imageReplacements map[string]string) (int, error) {
+	file, err := c.GetStackFile(sourceStackId)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err = replaceComposeImages(file, imageReplacements)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace images in stack file: %w", err)
+	}
+
+	return c.CreateStack(newName, file, targetGroupIds)
+}
+
+// composeImageLine matches an image declaration of a compose service, with optional quotes and trailing comment
+var composeImageLine = regexp.MustCompile(`^(\s*image:\s*["']?)([^"'\s#]+)(["']?\s*(?:#.*)?)$`)
+
+// replaceComposeImages replaces the image references of a compose file that exactly match a key of the replacements map.
+// The file is edited line by line so that its formatting and comments are preserved.
+func replaceComposeImages(file string, replacements map[string]string) (string, error) {
+	if len(replacements) == 0 {
+		return file, nil
+	}
+
+	used := make(map[string]bool, len(replacements))
+	lines := strings.Split(file, "\n")
+	for i, line := range lines {
+		match := composeImageLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		replacement, ok := replacements[match[2]]
+		if !ok {
+			continue
+		}
+
+		lines[i] = match[1] + replacement + match[3]
+		used[match[2]] = true
+	}
+
+	var unused []string
+	for image := range replacements {
+		if !used[image] {
+			unused = append(unused, image)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return "", fmt.Errorf("images not found in stack file: %s", strings.Join(unused, ", "))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
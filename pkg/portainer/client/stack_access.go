@@ -0,0 +1,55 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// edgeStackAccessNote explains why the access of a stack is restricted to administrators
+const edgeStackAccessNote = "edge stacks have no resource control in Portainer, only administrators and edge administrators can access them"
+
+// GetStackAccess retrieves who can access a stack.
+// Stacks are the equivalent of Edge Stacks in Portainer. Unlike the other resources, Portainer
+// does not attach a resource control to edge stacks: they have no owner, cannot be shared with
+// users or teams, and are only accessible to the administrators and edge administrators, whose
+// IDs are returned.
+//
+// Parameters:
+//   - stackId: The ID of the stack
+//
+// Returns:
+//   - The access of the stack
+//   - An error if the stack does not exist or if the operation fails
+func (c *PortainerClient) GetStackAccess(stackId int) (models.ResourceAccess, error) {
+	stack, err := c.cli.GetEdgeStack(int64(stackId))
+	if err != nil {
+		return models.ResourceAccess{}, fmt.Errorf("failed to get edge stack: %w", err)
+	}
+
+	users, err := c.GetUsers()
+	if err != nil {
+		return models.ResourceAccess{}, err
+	}
+
+	administratorIds := []int{}
+	for _, user := range users {
+		if user.Role == models.UserRoleAdmin || user.Role == models.UserRoleEdgeAdmin {
+			administratorIds = append(administratorIds, user.ID)
+		}
+	}
+	slices.Sort(administratorIds)
+
+	return models.ResourceAccess{
+		ResourceID:           strconv.Itoa(stackId),
+		Type:                 models.ResourceTypeStack,
+		Name:                 stack.Name,
+		AdministratorsOnly:   true,
+		UserIDs:              []int{},
+		TeamIDs:              []int{},
+		AdministratorUserIDs: administratorIds,
+		Note:                 edgeStackAccessNote,
+	}, nil
+}
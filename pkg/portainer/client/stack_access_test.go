@@ -0,0 +1,78 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStackAccess(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockStack     *apimodels.PortainereeEdgeStack
+		mockStackErr  error
+		mockUsers     []*apimodels.PortainereeUser
+		mockUsersErr  error
+		expectUsers   bool
+		expected      models.ResourceAccess
+		expectedError string
+	}{
+		{
+			name:      "administrators only",
+			mockStack: &apimodels.PortainereeEdgeStack{ID: 1, Name: "web"},
+			mockUsers: []*apimodels.PortainereeUser{
+				{ID: 4, Username: "edge-admin", Role: 3},
+				{ID: 2, Username: "alice", Role: 2},
+				{ID: 1, Username: "admin", Role: 1},
+			},
+			expectUsers: true,
+			expected: models.ResourceAccess{
+				ResourceID:           "1",
+				Type:                 models.ResourceTypeStack,
+				Name:                 "web",
+				AdministratorsOnly:   true,
+				UserIDs:              []int{},
+				TeamIDs:              []int{},
+				AdministratorUserIDs: []int{1, 4},
+				Note:                 edgeStackAccessNote,
+			},
+		},
+		{
+			name:          "stack not found",
+			mockStackErr:  errors.New("edge stack not found"),
+			expectedError: "failed to get edge stack: edge stack not found",
+		},
+		{
+			name:          "list users error",
+			mockStack:     &apimodels.PortainereeEdgeStack{ID: 1, Name: "web"},
+			mockUsersErr:  errors.New("access denied"),
+			expectUsers:   true,
+			expectedError: "failed to list users: access denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEdgeStack", int64(1)).Return(tt.mockStack, tt.mockStackErr)
+			if tt.expectUsers {
+				mockAPI.On("ListUsers").Return(tt.mockUsers, tt.mockUsersErr)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			access, err := client.GetStackAccess(1)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, access)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// DeleteStacksMatching deletes the stacks matching a filter, e.g. to clean up the stacks of a test
+// environment group. Stacks are the equivalent of Edge Stacks in Portainer. Nothing is deleted unless
+// the filter is confirmed: an unconfirmed filter lists the stacks that would be deleted, so that they
+// can be reviewed first.
+//
+// The stacks are deleted one at a time, a failure does not stop the deletion of the other stacks and is
+// reported in the status of the stack.
+//
+// Parameters:
+//   - filter: The criteria the stacks must match and the confirmation of the deletion
+//
+// Returns:
+//   - A BulkDeleteResult with the status of every matching stack
+//   - An error if the filter is invalid or if the stacks cannot be listed
+func (c *PortainerClient) DeleteStacksMatching(filter models.StackFilter) (models.BulkDeleteResult, error) {
+	if filter.NamePattern == "" && filter.EnvironmentGroupID == 0 {
+		return models.BulkDeleteResult{}, fmt.Errorf("invalid filter: a name pattern or an environment group is required")
+	}
+	if _, err := path.Match(filter.NamePattern, ""); err != nil {
+		return models.BulkDeleteResult{}, fmt.Errorf("invalid filter: invalid name pattern %q: %w", filter.NamePattern, err)
+	}
+
+	stacks, err := c.GetStacks()
+	if err != nil {
+		return models.BulkDeleteResult{}, err
+	}
+
+	sort.Slice(stacks, func(i, j int) bool {
+		return stacks[i].ID < stacks[j].ID
+	})
+
+	result := models.BulkDeleteResult{
+		Confirmed: filter.Confirm,
+		Stacks:    []models.StackDeleteStatus{},
+	}
+
+	for _, stack := range stacks {
+		if !stackMatchesFilter(stack, filter) {
+			continue
+		}
+
+		status := models.StackDeleteStatus{
+			StackID:   stack.ID,
+			StackName: stack.Name,
+		}
+
+		if filter.Confirm {
+			if err := c.cli.DeleteEdgeStack(int64(stack.ID)); err != nil {
+				status.Error = err.Error()
+				result.FailedCount++
+			} else {
+				status.Deleted = true
+				result.DeletedCount++
+			}
+		}
+
+		result.Stacks = append(result.Stacks, status)
+	}
+
+	return result, nil
+}
+
+// stackMatchesFilter checks that a stack matches all the criteria of a filter, the pattern was validated
+func stackMatchesFilter(stack models.Stack, filter models.StackFilter) bool {
+	if filter.NamePattern != "" {
+		if matched, _ := path.Match(filter.NamePattern, stack.Name); !matched {
+			return false
+		}
+	}
+
+	if filter.EnvironmentGroupID != 0 && !slices.Contains(stack.EnvironmentGroupIds, filter.EnvironmentGroupID) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteStacksMatching(t *testing.T) {
+	edgeStacks := []*apimodels.PortainereeEdgeStack{
+		{ID: 4, Name: "test-web", EdgeGroups: []int64{2}},
+		{ID: 2, Name: "test-db", EdgeGroups: []int64{3}},
+		{ID: 3, Name: "prod-web", EdgeGroups: []int64{2}},
+	}
+
+	tests := []struct {
+		name            string
+		filter          models.StackFilter
+		deleteErrors    map[int64]error
+		expected        models.BulkDeleteResult
+		expectedDeletes []int64
+		expectedError   string
+	}{
+		{
+			name:   "name pattern",
+			filter: models.StackFilter{NamePattern: "test-*", Confirm: true},
+			expected: models.BulkDeleteResult{
+				Confirmed: true,
+				Stacks: []models.StackDeleteStatus{
+					{StackID: 2, StackName: "test-db", Deleted: true},
+					{StackID: 4, StackName: "test-web", Deleted: true},
+				},
+				DeletedCount: 2,
+			},
+			expectedDeletes: []int64{2, 4},
+		},
+		{
+			name:   "name pattern and environment group",
+			filter: models.StackFilter{NamePattern: "*-web", EnvironmentGroupID: 2, Confirm: true},
+			expected: models.BulkDeleteResult{
+				Confirmed: true,
+				Stacks: []models.StackDeleteStatus{
+					{StackID: 3, StackName: "prod-web", Deleted: true},
+					{StackID: 4, StackName: "test-web", Deleted: true},
+				},
+				DeletedCount: 2,
+			},
+			expectedDeletes: []int64{3, 4},
+		},
+		{
+			name:         "partial failure",
+			filter:       models.StackFilter{EnvironmentGroupID: 2, Confirm: true},
+			deleteErrors: map[int64]error{3: errors.New("forbidden")},
+			expected: models.BulkDeleteResult{
+				Confirmed: true,
+				Stacks: []models.StackDeleteStatus{
+					{StackID: 3, StackName: "prod-web", Error: "forbidden"},
+					{StackID: 4, StackName: "test-web", Deleted: true},
+				},
+				DeletedCount: 1,
+				FailedCount:  1,
+			},
+			expectedDeletes: []int64{3, 4},
+		},
+		{
+			name:   "not confirmed",
+			filter: models.StackFilter{NamePattern: "test-*"},
+			expected: models.BulkDeleteResult{
+				Stacks: []models.StackDeleteStatus{
+					{StackID: 2, StackName: "test-db"},
+					{StackID: 4, StackName: "test-web"},
+				},
+			},
+		},
+		{
+			name:   "no match",
+			filter: models.StackFilter{NamePattern: "staging-*", Confirm: true},
+			expected: models.BulkDeleteResult{
+				Confirmed: true,
+				Stacks:    []models.StackDeleteStatus{},
+			},
+		},
+		{
+			name:          "no criteria",
+			filter:        models.StackFilter{Confirm: true},
+			expectedError: "a name pattern or an environment group is required",
+		},
+		{
+			name:          "invalid pattern",
+			filter:        models.StackFilter{NamePattern: "test-[", Confirm: true},
+			expectedError: `invalid name pattern "test-["`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeStacks").Return(edgeStacks, nil).Maybe()
+			for _, id := range tt.expectedDeletes {
+				mockAPI.On("DeleteEdgeStack", id).Return(tt.deleteErrors[id])
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			result, err := client.DeleteStacksMatching(tt.filter)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				mockAPI.AssertNotCalled(t, "ListEdgeStacks")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			if tt.expectedDeletes == nil {
+				mockAPI.AssertNotCalled(t, "DeleteEdgeStack", mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("list error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListEdgeStacks").Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.DeleteStacksMatching(models.StackFilter{NamePattern: "test-*", Confirm: true})
+
+		assert.ErrorContains(t, err, "failed to list edge stacks")
+		mockAPI.AssertNotCalled(t, "DeleteEdgeStack", mock.Anything)
+	})
+}
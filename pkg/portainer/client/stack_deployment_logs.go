@@ -0,0 +1,161 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// edgeStackStatusNames maps the deployment status types reported by the Edge agents to a readable name
+var edgeStackStatusNames = map[int64]string{
+	0:  "pending",
+	1:  "deployment received",
+	2:  "error",
+	3:  "acknowledged",
+	4:  "removed",
+	5:  "remote update success",
+	6:  "images pulled",
+	7:  "running",
+	8:  "deploying",
+	9:  "removing",
+	10: "paused deploying",
+	11: "rolling back",
+	12: "rolled back",
+}
+
+// buildKitStep matches the lines of a BuildKit build starting a step, e.g. "#5 [2/4] RUN apk add curl"
+var buildKitStep = regexp.MustCompile(`^#\d+ \[[^\]]+\] `)
+
+// dockerBuildMessage is a line of the JSON stream sent by the Docker build and pull endpoints
+type dockerBuildMessage struct {
+	Stream      string `json:"stream"`
+	Status      string `json:"status"`
+	ID          string `json:"id"`
+	Error       string `json:"error"`
+	ErrorDetail struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// GetStackDeploymentLogs retrieves the deployment log of a stack on an environment, the successive deployment
+// statuses reported by the Edge agent with their time. Portainer does not keep the output of successful
+// deployments, only the output of the deployments that failed, which includes the output of the image builds.
+// This output is summarized into the build steps and the errors instead of the raw Docker build stream.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// Parameters:
+//   - stackId: The ID of the stack
+//   - environmentId: The ID of the environment the stack is deployed to
+//
+// Returns:
+//   - The deployment log, one line per deployment status, the oldest first
+//   - An error if the stack has no deployment on the environment or if the operation fails
+func (c *PortainerClient) GetStackDeploymentLogs(stackId, environmentId int) (string, error) {
+	stack, err := c.cli.GetEdgeStack(int64(stackId))
+	if err != nil {
+		return "", fmt.Errorf("failed to get stack: %w", err)
+	}
+
+	status, ok := stack.Status[strconv.Itoa(environmentId)]
+	if !ok {
+		return "", fmt.Errorf("stack %d has no deployment on environment %d", stackId, environmentId)
+	}
+
+	var log strings.Builder
+	fmt.Fprintf(&log, "Deployment log of stack %s on environment %d\n", stack.Name, environmentId)
+
+	for _, deployment := range status.Status {
+		if deployment == nil {
+			continue
+		}
+
+		name, known := edgeStackStatusNames[deployment.Type]
+		if !known {
+			name = fmt.Sprintf("status %d", deployment.Type)
+		}
+		if deployment.Time > 0 {
+			fmt.Fprintf(&log, "%s %s", time.Unix(deployment.Time, 0).UTC().Format(time.RFC3339), name)
+		} else {
+			log.WriteString(name)
+		}
+		if deployment.Version > 0 {
+			fmt.Fprintf(&log, " (version %d)", deployment.Version)
+		}
+		log.WriteString("\n")
+
+		if deployment.Error != "" {
+			for _, line := range summarizeBuildOutput(deployment.Error) {
+				fmt.Fprintf(&log, "  %s\n", line)
+			}
+		}
+	}
+
+	// Older agents only report the last error of the deployment
+	if len(status.Status) == 0 && status.Error != "" {
+		log.WriteString("error\n")
+		for _, line := range summarizeBuildOutput(status.Error) {
+			fmt.Fprintf(&log, "  %s\n", line)
+		}
+	}
+
+	return strings.TrimSuffix(log.String(), "\n"), nil
+}
+
+// summarizeBuildOutput reduces the output of a failed deployment to its meaningful lines. The JSON lines of
+// the Docker build stream are replaced by the build steps and the errors they hold and the progress of the
+// image pulls and of the BuildKit steps is dropped. The other lines are kept as is.
+func summarizeBuildOutput(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "{") {
+			var message dockerBuildMessage
+			if err := json.Unmarshal([]byte(line), &message); err == nil {
+				if summary := summarizeBuildMessage(message); summary != "" {
+					lines = append(lines, summary)
+				}
+				continue
+			}
+		}
+
+		// BuildKit prints the progress of each step on lines starting with the step number,
+		// only the start of the steps and their errors are kept
+		if strings.HasPrefix(line, "#") && !buildKitStep.MatchString(line) && !strings.Contains(line, "ERROR") {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// summarizeBuildMessage returns the meaningful part of a Docker build stream message,
+// or an empty string when the message only reports some progress
+func summarizeBuildMessage(message dockerBuildMessage) string {
+	switch {
+	case message.Error != "":
+		return "ERROR: " + message.Error
+	case message.ErrorDetail.Message != "":
+		return "ERROR: " + message.ErrorDetail.Message
+	}
+
+	stream := strings.TrimSpace(message.Stream)
+	if strings.HasPrefix(stream, "Step ") || strings.HasPrefix(stream, "Successfully ") {
+		return stream
+	}
+
+	// The other pull messages with an ID report the progress of a single layer
+	if message.Status != "" && (message.ID == "" || strings.HasPrefix(message.Status, "Pulling from ")) {
+		return message.Status
+	}
+
+	return ""
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStackDeploymentLogs(t *testing.T) {
+	buildOutput := `{"stream":"Step 1/3 : FROM alpine:3.19"}
+{"status":"Pulling from library/alpine","id":"3.19"}
+{"status":"Downloading","progressDetail":{"current":1024,"total":3408729},"id":"4abcf2066143"}
+{"status":"Digest: sha256:c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b"}
+{"stream":" ---> 05455a08881e\n"}
+{"stream":"Step 2/3 : RUN apk add --no-cache curlx"}
+{"stream":"ERROR: unable to select packages:\n"}
+{"errorDetail":{"code":1,"message":"The command '/bin/sh -c apk add --no-cache curlx' returned a non-zero code: 1"},"error":"The command '/bin/sh -c apk add --no-cache curlx' returned a non-zero code: 1"}`
+
+	buildKitOutput := `failed to deploy a stack: compose build operation failed
+#1 [internal] load build definition from Dockerfile
+#1 transferring dockerfile: 120B done
+#1 DONE 0.0s
+#5 [2/3] RUN apk add --no-cache curlx
+#5 0.412 ERROR: unable to select packages:
+#5 ERROR: process "/bin/sh -c apk add --no-cache curlx" did not complete successfully: exit code: 1`
+
+	tests := []struct {
+		name          string
+		mockStack     *apimodels.PortainereeEdgeStack
+		mockError     error
+		expected      string
+		expectedError string
+	}{
+		{
+			name: "successful deployment",
+			mockStack: &apimodels.PortainereeEdgeStack{
+				ID:   1,
+				Name: "shop",
+				Status: map[string]apimodels.PortainerEdgeStackStatus{
+					"5": {Status: []*apimodels.PortainerEdgeStackDeploymentStatus{
+						{Type: 0, Time: 1714557600},
+						{Type: 1, Time: 1714557605, Version: 2},
+						{Type: 6, Time: 1714557630, Version: 2},
+						{Type: 7, Time: 1714557640, Version: 2},
+					}},
+				},
+			},
+			expected: "Deployment log of stack shop on environment 5\n" +
+				"2024-05-01T10:00:00Z pending\n" +
+				"2024-05-01T10:00:05Z deployment received (version 2)\n" +
+				"2024-05-01T10:00:30Z images pulled (version 2)\n" +
+				"2024-05-01T10:00:40Z running (version 2)",
+		},
+		{
+			name: "failed build with docker build stream",
+			mockStack: &apimodels.PortainereeEdgeStack{
+				ID:   1,
+				Name: "shop",
+				Status: map[string]apimodels.PortainerEdgeStackStatus{
+					"5": {Status: []*apimodels.PortainerEdgeStackDeploymentStatus{
+						{Type: 8, Time: 1714557600},
+						{Type: 2, Time: 1714557660, Error: buildOutput},
+					}},
+				},
+			},
+			expected: "Deployment log of stack shop on environment 5\n" +
+				"2024-05-01T10:00:00Z deploying\n" +
+				"2024-05-01T10:01:00Z error\n" +
+				"  Step 1/3 : FROM alpine:3.19\n" +
+				"  Pulling from library/alpine\n" +
+				"  Digest: sha256:c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b\n" +
+				"  Step 2/3 : RUN apk add --no-cache curlx\n" +
+				"  ERROR: The command '/bin/sh -c apk add --no-cache curlx' returned a non-zero code: 1",
+		},
+		{
+			name: "failed build with buildkit output",
+			mockStack: &apimodels.PortainereeEdgeStack{
+				ID:   1,
+				Name: "shop",
+				Status: map[string]apimodels.PortainerEdgeStackStatus{
+					"5": {Status: []*apimodels.PortainerEdgeStackDeploymentStatus{
+						{Type: 2, Time: 1714557660, Error: buildKitOutput},
+					}},
+				},
+			},
+			expected: "Deployment log of stack shop on environment 5\n" +
+				"2024-05-01T10:01:00Z error\n" +
+				"  failed to deploy a stack: compose build operation failed\n" +
+				"  #1 [internal] load build definition from Dockerfile\n" +
+				"  #5 [2/3] RUN apk add --no-cache curlx\n" +
+				"  #5 0.412 ERROR: unable to select packages:\n" +
+				"  #5 ERROR: process \"/bin/sh -c apk add --no-cache curlx\" did not complete successfully: exit code: 1",
+		},
+		{
+			name: "legacy status with unknown type",
+			mockStack: &apimodels.PortainereeEdgeStack{
+				ID:   1,
+				Name: "shop",
+				Status: map[string]apimodels.PortainerEdgeStackStatus{
+					"5": {Error: "unable to pull image nginx:doesnotexist"},
+					"6": {Status: []*apimodels.PortainerEdgeStackDeploymentStatus{{Type: 42}}},
+				},
+			},
+			expected: "Deployment log of stack shop on environment 5\n" +
+				"error\n" +
+				"  unable to pull image nginx:doesnotexist",
+		},
+		{
+			name: "stack not deployed to environment",
+			mockStack: &apimodels.PortainereeEdgeStack{
+				ID:     1,
+				Name:   "shop",
+				Status: map[string]apimodels.PortainerEdgeStackStatus{"6": {}},
+			},
+			expectedError: "stack 1 has no deployment on environment 5",
+		},
+		{
+			name:          "get stack error",
+			mockError:     errors.New("stack not found"),
+			expectedError: "failed to get stack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEdgeStack", int64(1)).Return(tt.mockStack, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			log, err := client.GetStackDeploymentLogs(1, 5)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, log)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unknown status type", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetEdgeStack", int64(1)).Return(&apimodels.PortainereeEdgeStack{
+			ID:   1,
+			Name: "shop",
+			Status: map[string]apimodels.PortainerEdgeStackStatus{
+				"5": {Status: []*apimodels.PortainerEdgeStackDeploymentStatus{{Type: 42}}},
+			},
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		log, err := client.GetStackDeploymentLogs(1, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Deployment log of stack shop on environment 5\nstatus 42", log)
+	})
+}
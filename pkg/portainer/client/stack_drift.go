@@ -0,0 +1,253 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+	// edgeStackProjectPrefix is the prefix used by the Edge agent for the compose project name of an Edge Stack
+	edgeStackProjectPrefix = "edge_"
+)
+
+// dockerContainer is the subset of the Docker container list response used to list containers and inspect stack deployments
+type dockerContainer struct {
+	ID      string            `json:"Id"`
+	Names   []string          `json:"Names"`
+	Created int64             `json:"Created"`
+	Image   string            `json:"Image"`
+	ImageID string            `json:"ImageID"`
+	State   string            `json:"State"`
+	Status  string            `json:"Status"`
+	Labels  map[string]string `json:"Labels"`
+}
+
+// DetectStackDrift compares the services declared in a stack file with the containers
+// running for that stack on a specific environment.
+// Stacks are the equivalent of Edge Stacks in Portainer, the stack must be deployed to the
+// environment through one of its environment groups.
+//
+// Parameters:
+//   - stackId: The ID of the stack to check
+//   - environmentId: The ID of the environment to inspect
+//
+// Returns:
+//   - A DriftReport listing missing services, extra services and image mismatches
+//   - An error if the operation fails or if the stack is not deployed to the environment
+func (c *PortainerClient) DetectStackDrift(stackId, environmentId int) (models.DriftReport, error) {
+	stack, err := c.findStack(stackId)
+	if err != nil {
+		return models.DriftReport{}, err
+	}
+
+	environmentIds, err := c.stackEnvironmentIds(stack)
+	if err != nil {
+		return models.DriftReport{}, err
+	}
+	if !slices.Contains(environmentIds, environmentId) {
+		return models.DriftReport{}, fmt.Errorf("stack %d is not deployed to environment %d", stackId, environmentId)
+	}
+
+	file, err := c.GetStackFile(stackId)
+	if err != nil {
+		return models.DriftReport{}, err
+	}
+
+	declared, err := parseComposeServices(file)
+	if err != nil {
+		return models.DriftReport{}, fmt.Errorf("failed to parse stack file: %w", err)
+	}
+
+	containers, err := c.listDockerContainers(environmentId)
+	if err != nil {
+		return models.DriftReport{}, err
+	}
+
+	report := compareStackServices(declared, runningStackContainers(containers, stack.Name))
+	report.StackID = stack.ID
+	report.StackName = stack.Name
+	report.EnvironmentID = environmentId
+
+	return report, nil
+}
+
+// findStack returns the stack with the given ID
+func (c *PortainerClient) findStack(id int) (models.Stack, error) {
+	stacks, err := c.GetStacks()
+	if err != nil {
+		return models.Stack{}, err
+	}
+
+	for _, stack := range stacks {
+		if stack.ID == id {
+			return stack, nil
+		}
+	}
+
+	return models.Stack{}, fmt.Errorf("stack %d does not exist", id)
+}
+
+// listDockerContainers lists all the containers of an environment through the Docker proxy
+func (c *PortainerClient) listDockerContainers(environmentId int) ([]dockerContainer, error) {
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          "/containers/json",
+		QueryParams:   map[string]string{"all": "1"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list containers: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var containers []dockerContainer
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return nil, fmt.Errorf("failed to decode containers: %w", err)
+	}
+
+	return containers, nil
+}
+
+// parseComposeServices returns the services declared in a compose file mapped to their image.
+// Services built from a Dockerfile have an empty image.
+func parseComposeServices(file string) (map[string]string, error) {
+	var compose struct {
+		Services map[string]struct {
+			Image string `yaml:"image"`
+		} `yaml:"services"`
+	}
+
+	if err := yaml.Unmarshal([]byte(file), &compose); err != nil {
+		return nil, err
+	}
+
+	if len(compose.Services) == 0 {
+		return nil, fmt.Errorf("no services declared")
+	}
+
+	services := make(map[string]string, len(compose.Services))
+	for name, service := range compose.Services {
+		services[name] = service.Image
+	}
+
+	return services, nil
+}
+
+// stackContainers returns the containers belonging to the compose project of a stack.
+// Both the plain stack name and the Edge agent project name are matched, as compose lowercases project names.
+func stackContainers(containers []dockerContainer, stackName string) []dockerContainer {
+	projects := []string{
+		strings.ToLower(stackName),
+		strings.ToLower(edgeStackProjectPrefix + stackName),
+	}
+
+	var matched []dockerContainer
+	for _, container := range containers {
+		if slices.Contains(projects, strings.ToLower(container.Labels[composeProjectLabel])) {
+			matched = append(matched, container)
+		}
+	}
+
+	return matched
+}
+
+// runningStackContainers groups the images of the running containers of a stack by service name
+func runningStackContainers(containers []dockerContainer, stackName string) map[string][]string {
+	running := make(map[string][]string)
+	for _, container := range stackContainers(containers, stackName) {
+		if container.State != "running" {
+			continue
+		}
+
+		service := container.Labels[composeServiceLabel]
+		if service == "" {
+			continue
+		}
+
+		running[service] = append(running[service], container.Image)
+	}
+
+	return running
+}
+
+// compareStackServices builds a drift report from the declared services and the running containers images
+func compareStackServices(declared map[string]string, running map[string][]string) models.DriftReport {
+	report := models.DriftReport{
+		MissingServices: []string{},
+		ExtraServices:   []string{},
+		ImageMismatches: []models.ServiceImageMismatch{},
+	}
+
+	for service, image := range declared {
+		images, ok := running[service]
+		if !ok {
+			report.MissingServices = append(report.MissingServices, service)
+			continue
+		}
+
+		if image == "" {
+			continue
+		}
+
+		expected := normalizeImageReference(image)
+		for _, runningImage := range images {
+			if normalizeImageReference(runningImage) != expected {
+				report.ImageMismatches = append(report.ImageMismatches, models.ServiceImageMismatch{
+					Service:       service,
+					ExpectedImage: image,
+					RunningImages: images,
+				})
+				break
+			}
+		}
+	}
+
+	for service := range running {
+		if _, ok := declared[service]; !ok {
+			report.ExtraServices = append(report.ExtraServices, service)
+		}
+	}
+
+	sort.Strings(report.MissingServices)
+	sort.Strings(report.ExtraServices)
+	sort.Slice(report.ImageMismatches, func(i, j int) bool {
+		return report.ImageMismatches[i].Service < report.ImageMismatches[j].Service
+	})
+
+	report.InSync = len(report.MissingServices) == 0 && len(report.ExtraServices) == 0 && len(report.ImageMismatches) == 0
+
+	return report
+}
+
+// normalizeImageReference expands an image reference so that equivalent references compare equal,
+// e.g. "nginx", "nginx:latest" and "docker.io/library/nginx:latest".
+func normalizeImageReference(image string) string {
+	ref := strings.TrimPrefix(image, "docker.io/")
+	ref = strings.TrimPrefix(ref, "library/")
+
+	if strings.Contains(ref, "@") {
+		return ref
+	}
+
+	lastSlash := strings.LastIndex(ref, "/")
+	if !strings.Contains(ref[lastSlash+1:], ":") {
+		ref += ":latest"
+	}
+
+	return ref
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const driftStackFile = `services:
+  web:
+    image: nginx
+  api:
+    image: myorg/api:1.2.0
+  worker:
+    build: ./worker
+`
+
+func TestDetectStackDrift(t *testing.T) {
+	driftStacks := []*apimodels.PortainereeEdgeStack{
+		{ID: 1, Name: "shop", EdgeGroups: []int64{10}},
+	}
+	driftGroups := []*apimodels.EdgegroupsDecoratedEdgeGroup{
+		{ID: 10, Name: "production", Endpoints: []int64{3, 4}},
+		{ID: 11, Name: "staging", Endpoints: []int64{5}},
+	}
+
+	tests := []struct {
+		name              string
+		stackId           int
+		environmentId     int
+		mockStackFile     string
+		mockStackFileErr  error
+		mockContainers    string
+		mockStatus        int
+		mockProxyErr      error
+		expectFileCall    bool
+		expectProxyCall   bool
+		expectedReport    models.DriftReport
+		expectedError     bool
+		expectedErrorText string
+	}{
+		{
+			name:          "stack in sync",
+			stackId:       1,
+			environmentId: 3,
+			mockStackFile: driftStackFile,
+			mockContainers: `[
+				{"Image":"nginx:latest","State":"running","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"web"}},
+				{"Image":"docker.io/myorg/api:1.2.0","State":"running","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"api"}},
+				{"Image":"sha256:abcdef","State":"running","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"worker"}},
+				{"Image":"redis","State":"running","Labels":{"com.docker.compose.project":"other","com.docker.compose.service":"cache"}}
+			]`,
+			mockStatus:      http.StatusOK,
+			expectFileCall:  true,
+			expectProxyCall: true,
+			expectedReport: models.DriftReport{
+				StackID:         1,
+				StackName:       "shop",
+				EnvironmentID:   3,
+				InSync:          true,
+				MissingServices: []string{},
+				ExtraServices:   []string{},
+				ImageMismatches: []models.ServiceImageMismatch{},
+			},
+		},
+		{
+			name:          "missing, extra and mismatched services",
+			stackId:       1,
+			environmentId: 4,
+			mockStackFile: driftStackFile,
+			mockContainers: `[
+				{"Image":"nginx:1.25","State":"running","Labels":{"com.docker.compose.project":"shop","com.docker.compose.service":"web"}},
+				{"Image":"myorg/api:1.2.0","State":"exited","Labels":{"com.docker.compose.project":"shop","com.docker.compose.service":"api"}},
+				{"Image":"myorg/debug","State":"running","Labels":{"com.docker.compose.project":"shop","com.docker.compose.service":"debug"}}
+			]`,
+			mockStatus:      http.StatusOK,
+			expectFileCall:  true,
+			expectProxyCall: true,
+			expectedReport: models.DriftReport{
+				StackID:         1,
+				StackName:       "shop",
+				EnvironmentID:   4,
+				InSync:          false,
+				MissingServices: []string{"api", "worker"},
+				ExtraServices:   []string{"debug"},
+				ImageMismatches: []models.ServiceImageMismatch{
+					{Service: "web", ExpectedImage: "nginx", RunningImages: []string{"nginx:1.25"}},
+				},
+			},
+		},
+		{
+			name:              "stack does not exist",
+			stackId:           2,
+			environmentId:     3,
+			expectedError:     true,
+			expectedErrorText: "stack 2 does not exist",
+		},
+		{
+			name:              "stack not deployed to environment",
+			stackId:           1,
+			environmentId:     5,
+			expectedError:     true,
+			expectedErrorText: "stack 1 is not deployed to environment 5",
+		},
+		{
+			name:             "stack file error",
+			stackId:          1,
+			environmentId:    3,
+			mockStackFileErr: errors.New("file error"),
+			expectFileCall:   true,
+			expectedError:    true,
+		},
+		{
+			name:              "invalid stack file",
+			stackId:           1,
+			environmentId:     3,
+			mockStackFile:     "version: '3'\n",
+			expectFileCall:    true,
+			expectedError:     true,
+			expectedErrorText: "failed to parse stack file",
+		},
+		{
+			name:            "docker proxy error",
+			stackId:         1,
+			environmentId:   3,
+			mockStackFile:   driftStackFile,
+			mockProxyErr:    errors.New("proxy error"),
+			expectFileCall:  true,
+			expectProxyCall: true,
+			expectedError:   true,
+		},
+		{
+			name:              "docker proxy unexpected status",
+			stackId:           1,
+			environmentId:     3,
+			mockStackFile:     driftStackFile,
+			mockContainers:    `{"message":"environment unreachable"}`,
+			mockStatus:        http.StatusBadGateway,
+			expectFileCall:    true,
+			expectProxyCall:   true,
+			expectedError:     true,
+			expectedErrorText: "unexpected status 502",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeStacks").Return(driftStacks, nil)
+			mockAPI.On("ListEdgeGroups").Return(driftGroups, nil).Maybe()
+			if tt.expectFileCall {
+				mockAPI.On("GetEdgeStackFile", int64(tt.stackId)).Return(tt.mockStackFile, tt.mockStackFileErr)
+			}
+			if tt.expectProxyCall {
+				var resp *http.Response
+				if tt.mockProxyErr == nil {
+					resp = &http.Response{
+						StatusCode: tt.mockStatus,
+						Body:       io.NopCloser(strings.NewReader(tt.mockContainers)),
+					}
+				}
+				mockAPI.On("ProxyDockerRequest", tt.environmentId, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/json" && opts.QueryParams["all"] == "1"
+				})).Return(resp, tt.mockProxyErr)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			report, err := client.DetectStackDrift(tt.stackId, tt.environmentId)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				if tt.expectedErrorText != "" {
+					assert.Contains(t, err.Error(), tt.expectedErrorText)
+				}
+				mockAPI.AssertExpectations(t)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedReport, report)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNormalizeImageReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{"nginx", "nginx:latest"},
+		{"nginx:1.25", "nginx:1.25"},
+		{"docker.io/library/nginx", "nginx:latest"},
+		{"myorg/api", "myorg/api:latest"},
+		{"registry.example.com:5000/api", "registry.example.com:5000/api:latest"},
+		{"registry.example.com:5000/api:2", "registry.example.com:5000/api:2"},
+		{"nginx@sha256:abcdef", "nginx@sha256:abcdef"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeImageReference(tt.image))
+		})
+	}
+}
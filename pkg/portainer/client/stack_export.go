@@ -0,0 +1,88 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// unsafeExportNameChars matches the characters replaced in the names of exported stacks,
+// so that every name can be used as a file or directory name
+var unsafeExportNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ExportAllStacks retrieves the files of all the stacks, typically to store them in a git repository.
+// Stacks are the equivalent of Edge Stacks in Portainer. The stack files are fetched in parallel,
+// at most maxConcurrentStackFileRequests at a time.
+//
+// Stacks are keyed by their name, with the characters that cannot be used in a file name replaced
+// by an underscore. When several stacks end up with the same name, case-insensitively, each of them
+// is keyed by its name followed by its ID (e.g. "web-3") so that none of them is overwritten. A counter
+// is appended as well (e.g. "web-3-2") when that name is already used by another stack.
+// Stacks without a name are keyed "stack-<id>". Stacks with an empty file are included with
+// an empty content.
+//
+// Returns:
+//   - A map of stack name to stack file content
+//   - An error if the operation fails or if the file of a stack cannot be retrieved
+func (c *PortainerClient) ExportAllStacks() (map[string]string, error) {
+	stacks, err := c.GetStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, len(stacks))
+	errs := make([]error, len(stacks))
+
+	forEachConcurrently(len(stacks), maxConcurrentStackFileRequests, func(i int) {
+		files[i], errs[i] = c.GetStackFile(stacks[i].ID)
+	})
+
+	names := make([]string, len(stacks))
+	counts := make(map[string]int, len(stacks))
+	for i, stack := range stacks {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to export stack %d: %w", stack.ID, errs[i])
+		}
+
+		names[i] = exportStackName(stack.Name, stack.ID)
+		counts[strings.ToLower(names[i])]++
+	}
+
+	// The renamed stacks must not clash with the names of the other stacks, nor with each other
+	used := make(map[string]bool, len(stacks))
+	for _, name := range names {
+		used[strings.ToLower(name)] = true
+	}
+
+	export := make(map[string]string, len(stacks))
+	for i, stack := range stacks {
+		name := names[i]
+		if counts[strings.ToLower(name)] > 1 {
+			name = uniqueExportStackName(fmt.Sprintf("%s-%d", name, stack.ID), used)
+		}
+		export[name] = files[i]
+	}
+
+	return export, nil
+}
+
+// uniqueExportStackName returns a name that is not used yet, appending a counter to name when needed,
+// and marks it as used
+func uniqueExportStackName(name string, used map[string]bool) string {
+	unique := name
+	for n := 2; used[strings.ToLower(unique)]; n++ {
+		unique = fmt.Sprintf("%s-%d", name, n)
+	}
+
+	used[strings.ToLower(unique)] = true
+	return unique
+}
+
+// exportStackName returns the name of a stack with the characters that cannot be used in a file name replaced
+func exportStackName(name string, id int) string {
+	name = unsafeExportNameChars.ReplaceAllString(strings.TrimSpace(name), "_")
+	if name == "" || strings.Trim(name, ".") == "" {
+		return fmt.Sprintf("stack-%d", id)
+	}
+	return name
+}
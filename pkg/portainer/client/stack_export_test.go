@@ -0,0 +1,122 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAllStacks(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockStacks    []*apimodels.PortainereeEdgeStack
+		mockFiles     map[int64]string
+		mockFileError error
+		expected      map[string]string
+		expectedError string
+	}{
+		{
+			name: "successful export",
+			mockStacks: []*apimodels.PortainereeEdgeStack{
+				{ID: 1, Name: "web"},
+				{ID: 2, Name: "cache"},
+			},
+			mockFiles: map[int64]string{
+				1: "services:\n  web:\n    image: nginx\n",
+				2: "services:\n  redis:\n    image: redis\n",
+			},
+			expected: map[string]string{
+				"web":   "services:\n  web:\n    image: nginx\n",
+				"cache": "services:\n  redis:\n    image: redis\n",
+			},
+		},
+		{
+			name: "name collisions",
+			mockStacks: []*apimodels.PortainereeEdgeStack{
+				{ID: 1, Name: "web"},
+				{ID: 2, Name: "Web"},
+				{ID: 3, Name: "web/prod"},
+				{ID: 4, Name: "web_prod"},
+			},
+			mockFiles: map[int64]string{1: "a", 2: "b", 3: "c", 4: "d"},
+			expected: map[string]string{
+				"web-1":      "a",
+				"Web-2":      "b",
+				"web_prod-3": "c",
+				"web_prod-4": "d",
+			},
+		},
+		{
+			name: "renamed stacks clashing with other names",
+			mockStacks: []*apimodels.PortainereeEdgeStack{
+				{ID: 3, Name: "web"},
+				{ID: 5, Name: "web"},
+				{ID: 7, Name: "web-3"},
+				{ID: 8, Name: "stack-9"},
+				{ID: 9, Name: ""},
+				{ID: 10, Name: "Web-5"},
+				{ID: 11, Name: "web-5"},
+			},
+			mockFiles: map[int64]string{3: "a", 5: "b", 7: "c", 8: "d", 9: "e", 10: "f", 11: "g"},
+			expected: map[string]string{
+				"web-3-2":   "a",
+				"web-5-2":   "b",
+				"web-3":     "c",
+				"stack-9-8": "d",
+				"stack-9-9": "e",
+				"Web-5-10":  "f",
+				"web-5-11":  "g",
+			},
+		},
+		{
+			name: "empty file and name",
+			mockStacks: []*apimodels.PortainereeEdgeStack{
+				{ID: 1, Name: "web"},
+				{ID: 2, Name: ".."},
+			},
+			mockFiles: map[int64]string{1: "", 2: "services: {}\n"},
+			expected: map[string]string{
+				"web":     "",
+				"stack-2": "services: {}\n",
+			},
+		},
+		{
+			name:       "no stacks",
+			mockStacks: []*apimodels.PortainereeEdgeStack{},
+			expected:   map[string]string{},
+		},
+		{
+			name: "get file error",
+			mockStacks: []*apimodels.PortainereeEdgeStack{
+				{ID: 1, Name: "web"},
+			},
+			mockFiles:     map[int64]string{1: ""},
+			mockFileError: errors.New("stack not found"),
+			expectedError: "failed to export stack 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeStacks").Return(tt.mockStacks, nil)
+			for id, file := range tt.mockFiles {
+				mockAPI.On("GetEdgeStackFile", id).Return(file, tt.mockFileError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			export, err := client.ExportAllStacks()
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, export)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
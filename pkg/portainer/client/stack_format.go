@@ -0,0 +1,162 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeFileIndent is the number of spaces used to indent the formatted compose files
+const composeFileIndent = 2
+
+// composeTopLevelOrder is the canonical order of the top-level keys of a compose file. The extension
+// fields (x-*) come before the services, as they usually hold the fragments reused by the services.
+var composeTopLevelOrder = []string{"version", "name", "include", "x-*", "services", "networks", "volumes", "configs", "secrets"}
+
+// composeSortedSections are the top-level keys whose content is sorted recursively by key
+var composeSortedSections = map[string]bool{
+	"services": true,
+	"networks": true,
+	"volumes":  true,
+	"configs":  true,
+	"secrets":  true,
+}
+
+// FormatComposeFile normalizes the formatting of a compose file: the file is parsed and serialized again
+// with an indentation of two spaces, the top-level keys in their canonical order, the services, networks,
+// volumes, configs and secrets sorted by name and the keys of their definitions sorted alphabetically.
+// The order of the lists is kept, as are the content of the extension fields (x-*), the quoting of the
+// values and the inline lists and mappings. Comments are kept with the key or value they are attached to.
+//
+// The keys are only reordered when the file has no anchors or aliases, an alias must follow its anchor and
+// reordering could break them; such files are only reindented. Formatting a formatted file returns it
+// unchanged.
+//
+// Parameters:
+//   - file: The content of the compose file
+//
+// Returns:
+//   - The formatted compose file
+//   - An error if the file is not a valid YAML mapping
+func (c *PortainerClient) FormatComposeFile(file string) (string, error) {
+	var document yaml.Node
+	decoder := yaml.NewDecoder(strings.NewReader(file))
+	if err := decoder.Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("the compose file is empty")
+		}
+		return "", fmt.Errorf("failed to parse the compose file: %w", err)
+	}
+
+	var next yaml.Node
+	if err := decoder.Decode(&next); !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("the compose file must hold a single YAML document")
+	}
+
+	if len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("the compose file must be a YAML mapping")
+	}
+	root := document.Content[0]
+
+	if !hasAnchorsOrAliases(root) {
+		sortComposeTopLevel(root)
+		for i := 0; i < len(root.Content); i += 2 {
+			if composeSortedSections[root.Content[i].Value] {
+				sortMappingKeys(root.Content[i+1])
+			}
+		}
+	}
+	clearMergeTags(root)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(composeFileIndent)
+	if err := encoder.Encode(&document); err != nil {
+		return "", fmt.Errorf("failed to serialize the compose file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to serialize the compose file: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// hasAnchorsOrAliases reports whether a node or one of its descendants is an anchor, an alias or a merge key
+func hasAnchorsOrAliases(node *yaml.Node) bool {
+	if node.Anchor != "" || node.Kind == yaml.AliasNode || node.Tag == "!!merge" {
+		return true
+	}
+	for _, child := range node.Content {
+		if hasAnchorsOrAliases(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// clearMergeTags removes the tags of the merge keys, which would otherwise be serialized explicitly as
+// "!!merge <<"
+func clearMergeTags(node *yaml.Node) {
+	if node.Tag == "!!merge" {
+		node.Tag = ""
+	}
+	for _, child := range node.Content {
+		clearMergeTags(child)
+	}
+}
+
+// composeTopLevelRank returns the position of a top-level key in composeTopLevelOrder, the unknown
+// keys come last
+func composeTopLevelRank(key string) int {
+	if strings.HasPrefix(key, "x-") {
+		key = "x-*"
+	}
+	for i, known := range composeTopLevelOrder {
+		if key == known {
+			return i
+		}
+	}
+	return len(composeTopLevelOrder)
+}
+
+// sortComposeTopLevel sorts the top-level keys of a compose file in their canonical order, keeping the
+// original order of the extension fields and of the unknown keys
+func sortComposeTopLevel(root *yaml.Node) {
+	sortMappingPairs(root, func(a, b *yaml.Node) bool {
+		return composeTopLevelRank(a.Value) < composeTopLevelRank(b.Value)
+	})
+}
+
+// sortMappingKeys sorts the keys of the mappings of a node and of its descendants alphabetically,
+// keeping the order of the sequences
+func sortMappingKeys(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		sortMappingPairs(node, func(a, b *yaml.Node) bool {
+			return a.Value < b.Value
+		})
+	}
+	for _, child := range node.Content {
+		sortMappingKeys(child)
+	}
+}
+
+// sortMappingPairs stably sorts the key and value pairs of a mapping node by key
+func sortMappingPairs(mapping *yaml.Node, less func(a, b *yaml.Node) bool) {
+	pairs := make([][2]*yaml.Node, 0, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{mapping.Content[i], mapping.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i][0], pairs[j][0])
+	})
+
+	for i, pair := range pairs {
+		mapping.Content[2*i], mapping.Content[2*i+1] = pair[0], pair[1]
+	}
+}
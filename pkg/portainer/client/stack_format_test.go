@@ -0,0 +1,132 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatComposeFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		expected      string
+		expectedError string
+	}{
+		{
+			name: "sorted and reindented",
+			file: `services:
+    web:
+        ports: ["80:80"]
+        image: "nginx:1.25"   # pinned
+        environment:
+            ZED: 1
+            ALPHA: two
+    # the database
+    db:
+      image: postgres
+      command: ["postgres", "-c", "max_connections=200"]
+volumes:
+  data: {}
+x-logging:
+  driver: json-file
+version: '3.8'
+`,
+			expected: `version: '3.8'
+x-logging:
+  driver: json-file
+services:
+  # the database
+  db:
+    command: ["postgres", "-c", "max_connections=200"]
+    image: postgres
+  web:
+    environment:
+      ALPHA: two
+      ZED: 1
+    image: "nginx:1.25" # pinned
+    ports: ["80:80"]
+volumes:
+  data: {}
+`,
+		},
+		{
+			name: "lists keep their order",
+			file: `services:
+  web:
+    image: nginx
+    ports:
+    - "443:443"
+    - target: 80
+      published: 8080
+`,
+			expected: `services:
+  web:
+    image: nginx
+    ports:
+      - "443:443"
+      - published: 8080
+        target: 80
+`,
+		},
+		{
+			name: "anchors are only reindented",
+			file: `x-common: &common
+    restart: always
+services:
+    web:
+        <<: *common
+        image: nginx
+version: "3.8"
+`,
+			expected: `x-common: &common
+  restart: always
+services:
+  web:
+    <<: *common
+    image: nginx
+version: "3.8"
+`,
+		},
+		{
+			name:          "empty file",
+			file:          "",
+			expectedError: "the compose file is empty",
+		},
+		{
+			name:          "invalid yaml",
+			file:          "services:\n  web:\n\timage: nginx\n",
+			expectedError: "failed to parse the compose file",
+		},
+		{
+			name:          "not a mapping",
+			file:          "- web\n- db\n",
+			expectedError: "the compose file must be a YAML mapping",
+		},
+		{
+			name:          "several documents",
+			file:          "services:\n  web:\n    image: nginx\n---\nservices: {}\n",
+			expectedError: "the compose file must hold a single YAML document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &PortainerClient{}
+
+			formatted, err := client.FormatComposeFile(tt.file)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, formatted)
+
+			again, err := client.FormatComposeFile(formatted)
+			require.NoError(t, err)
+			assert.Equal(t, formatted, again, "formatting a formatted file should not change it")
+		})
+	}
+}
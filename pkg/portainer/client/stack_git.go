@@ -0,0 +1,188 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// minAutoUpdateInterval is the shortest interval Portainer accepts to poll the git repository of a stack
+const minAutoUpdateInterval = time.Minute
+
+// GetStackGitConfig retrieves the git repository a stack is deployed from.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// Parameters:
+//   - stackId: The ID of the stack
+//
+// Returns:
+//   - The GitConfig of the stack, without the git password
+//   - An error if the stack is not deployed from a git repository or if the operation fails
+func (c *PortainerClient) GetStackGitConfig(stackId int) (models.GitConfig, error) {
+	stack, err := c.getGitStack(stackId)
+	if err != nil {
+		return models.GitConfig{}, err
+	}
+
+	return models.ConvertEdgeStackToGitConfig(stack), nil
+}
+
+// RedeployStackFromGit pulls the latest version of the git repository of a stack and redeploys it,
+// keeping its other settings unchanged. Portainer reuses the git credentials it stores for the stack.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// Parameters:
+//   - stackId: The ID of the stack
+//   - pullImage: Whether to pull the images of the stack again even if they are already present
+//
+// Returns:
+//   - An error wrapping ErrGitAuthentication if the git remote rejected the credentials of the stack
+//   - An error if the stack is not deployed from a git repository or if the operation fails
+func (c *PortainerClient) RedeployStackFromGit(stackId int, pullImage bool) error {
+	stack, err := c.getGitStack(stackId)
+	if err != nil {
+		return err
+	}
+
+	if err := c.cli.RedeployEdgeStackFromGit(stack, pullImage); err != nil {
+		if isGitAuthenticationError(err) {
+			return fmt.Errorf("failed to pull the repository of stack %d: %w: %w", stackId, ErrGitAuthentication, err)
+		}
+		return fmt.Errorf("failed to redeploy stack %d: %w", stackId, err)
+	}
+
+	return nil
+}
+
+// getGitStack retrieves an edge stack and checks that it is deployed from a git repository
+func (c *PortainerClient) getGitStack(stackId int) (*apimodels.PortainereeEdgeStack, error) {
+	stack, err := c.cli.GetEdgeStack(int64(stackId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edge stack: %w", err)
+	}
+
+	if stack.GitConfig == nil || stack.GitConfig.URL == "" {
+		return nil, fmt.Errorf("stack %d is not deployed from a git repository", stackId)
+	}
+
+	return stack, nil
+}
+
+// UpdateStackAutoUpdate changes the automatic updates of a stack deployed from git, without redeploying it.
+// Portainer can update the stack by polling its repository on an interval, when its webhook is called,
+// or both. The automatic updates are disabled when neither is enabled.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// Enabling the webhook creates a new webhook token, it is the secret part of the webhook URL and it is
+// only returned by this call. Disabling the webhook revokes its token.
+//
+// Parameters:
+//   - stackId: The ID of the stack
+//   - opts: The changes to apply, at least one option is required
+//
+// Returns:
+//   - The resulting automatic update settings, with the webhook token when the webhook was created
+//   - An error if the options are invalid, if the stack is not deployed from a git repository
+//     or if the operation fails
+func (c *PortainerClient) UpdateStackAutoUpdate(stackId int, opts models.AutoUpdateOptions) (models.AutoUpdateSettings, error) {
+	if opts.Interval == nil && opts.Webhook == nil && opts.ForcePullImage == nil {
+		return models.AutoUpdateSettings{}, fmt.Errorf("at least one auto update option is required")
+	}
+
+	if opts.Interval != nil && *opts.Interval != "" {
+		if err := validateAutoUpdateInterval(*opts.Interval); err != nil {
+			return models.AutoUpdateSettings{}, err
+		}
+	}
+
+	stack, err := c.getGitStack(stackId)
+	if err != nil {
+		return models.AutoUpdateSettings{}, err
+	}
+
+	autoUpdate := apimodels.PortainerAutoUpdateSettings{}
+	if stack.AutoUpdate != nil {
+		autoUpdate = *stack.AutoUpdate
+	}
+
+	if opts.Interval != nil {
+		autoUpdate.Interval = *opts.Interval
+	}
+
+	createdWebhook := false
+	if opts.Webhook != nil {
+		switch {
+		case !*opts.Webhook:
+			autoUpdate.Webhook = ""
+		case autoUpdate.Webhook == "":
+			token, err := newWebhookToken()
+			if err != nil {
+				return models.AutoUpdateSettings{}, err
+			}
+			autoUpdate.Webhook = token
+			createdWebhook = true
+		}
+	}
+
+	if opts.ForcePullImage != nil {
+		autoUpdate.ForcePullImage = *opts.ForcePullImage
+	}
+
+	var settings *apimodels.PortainerAutoUpdateSettings
+	if autoUpdate.Interval != "" || autoUpdate.Webhook != "" {
+		settings = &autoUpdate
+	} else if autoUpdate.ForcePullImage && opts.ForcePullImage != nil {
+		return models.AutoUpdateSettings{}, fmt.Errorf("force pull image requires an interval or a webhook to enable the automatic updates")
+	}
+
+	if err := c.cli.UpdateEdgeStackAutoUpdate(stack, settings); err != nil {
+		return models.AutoUpdateSettings{}, fmt.Errorf("failed to update the auto update of stack %d: %w", stackId, err)
+	}
+
+	result := models.AutoUpdateSettings{}
+	if settings != nil {
+		result = models.AutoUpdateSettings{
+			Enabled:        true,
+			Interval:       settings.Interval,
+			WebhookEnabled: settings.Webhook != "",
+			ForcePullImage: settings.ForcePullImage,
+		}
+	}
+	if createdWebhook {
+		result.WebhookToken = autoUpdate.Webhook
+		result.WebhookPath = "/api/edge_stacks/webhooks/" + autoUpdate.Webhook
+	}
+
+	return result, nil
+}
+
+// validateAutoUpdateInterval checks that an auto update interval is a duration of at least minAutoUpdateInterval
+func validateAutoUpdateInterval(interval string) error {
+	duration, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("invalid auto update interval %s, use a duration such as 5m or 1h30m", interval)
+	}
+
+	if duration < minAutoUpdateInterval {
+		return fmt.Errorf("auto update interval %s is shorter than the minimum of %s", interval, minAutoUpdateInterval)
+	}
+
+	return nil
+}
+
+// newWebhookToken generates the random UUID Portainer expects as the token of a stack webhook
+func newWebhookToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook token: %w", err)
+	}
+
+	// Version 4 and RFC 4122 variant bits
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// maxGitDriftDiffCells bounds the size of the table used to compare the deployed and the git files
+// line by line, the product of their line counts
+const maxGitDriftDiffCells = 4_000_000
+
+// GetStackGitDrift compares the file deployed for a stack deployed from git with the current file of its
+// git repository, and the commit the stack was deployed from with the latest commit of its reference.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// The git file is read by Portainer with the git credential of the stack. Portainer does not return the
+// git password of the stacks, the file of a repository authenticated with a password and no git credential
+// cannot be read. The latest commit is read directly from the repository, only for public HTTP(S)
+// repositories, as Portainer does not expose it. The drift of the file is reported even when the latest
+// commit could not be read.
+//
+// Parameters:
+//   - stackId: The ID of the stack
+//
+// Returns:
+//   - The GitDrift of the stack
+//   - An error wrapping ErrGitAuthentication if the git remote rejected the credentials of the stack
+//   - An error if the stack is not deployed from a git repository or if the operation fails
+func (c *PortainerClient) GetStackGitDrift(stackId int) (models.GitDrift, error) {
+	stack, err := c.getGitStack(stackId)
+	if err != nil {
+		return models.GitDrift{}, err
+	}
+
+	deployed, err := c.cli.GetEdgeStackFile(int64(stackId))
+	if err != nil {
+		return models.GitDrift{}, fmt.Errorf("failed to get edge stack file: %w", err)
+	}
+
+	gitConfig := stack.GitConfig
+	payload := &apimodels.GitopsRepositoryFilePreviewPayload{
+		Repository:    &gitConfig.URL,
+		Reference:     gitConfig.ReferenceName,
+		TargetFile:    gitConfig.ConfigFilePath,
+		TlsskipVerify: gitConfig.TlsskipVerify,
+	}
+	if auth := gitConfig.Authentication; auth != nil {
+		payload.Username = auth.Username
+		payload.GitCredentialID = auth.GitCredentialID
+	}
+
+	source, err := c.cli.GetGitRepositoryFile(payload)
+	if err != nil {
+		if isGitAuthenticationError(err) {
+			return models.GitDrift{}, fmt.Errorf("failed to read the repository of stack %d: %w: %w", stackId, ErrGitAuthentication, err)
+		}
+		return models.GitDrift{}, fmt.Errorf("failed to get the git file of stack %d: %w", stackId, err)
+	}
+
+	drift := models.GitDrift{
+		StackID:        stackId,
+		StackName:      stack.Name,
+		RepositoryURL:  gitConfig.URL,
+		ReferenceName:  gitConfig.ReferenceName,
+		ConfigFilePath: gitConfig.ConfigFilePath,
+		DeployedCommit: gitConfig.ConfigHash,
+	}
+
+	if gitConfig.Authentication != nil {
+		drift.LatestCommitError = "the repository requires credentials, the latest commit can only be read from public repositories"
+	} else if commit, err := lsRemote(gitConfig.URL, gitConfig.ReferenceName, gitConfig.TlsskipVerify); err != nil {
+		drift.LatestCommitError = err.Error()
+	} else {
+		drift.LatestCommit = commit
+		drift.BehindLatest = gitConfig.ConfigHash != "" && commit != gitConfig.ConfigHash
+	}
+
+	deployedLines := splitFileLines(deployed)
+	sourceLines := splitFileLines(source)
+	drift.FileInSync = strings.Join(deployedLines, "\n") == strings.Join(sourceLines, "\n")
+	if !drift.FileInSync {
+		if len(deployedLines)*len(sourceLines) > maxGitDriftDiffCells {
+			drift.DiffSkipped = true
+		} else {
+			drift.Diff = diffLines(deployedLines, sourceLines)
+		}
+	}
+
+	return drift, nil
+}
+
+// splitFileLines splits a file into its lines, ignoring the line endings and the trailing new lines
+// that git may convert
+func splitFileLines(file string) []string {
+	file = strings.TrimRight(strings.ReplaceAll(file, "\r\n", "\n"), "\n")
+	if file == "" {
+		return nil
+	}
+	return strings.Split(file, "\n")
+}
+
+// diffLines lists the lines removed from the before lines, prefixed with "-", and the lines added by the
+// after lines, prefixed with "+", keeping the longest common subsequence of the lines unchanged
+func diffLines(before, after []string) []string {
+	// common[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			i++
+			j++
+		case i < len(before) && (j == len(after) || common[i+1][j] >= common[i][j+1]):
+			diff = append(diff, "-"+before[i])
+			i++
+		default:
+			diff = append(diff, "+"+after[j])
+			j++
+		}
+	}
+
+	return diff
+}
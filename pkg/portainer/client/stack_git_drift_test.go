@@ -0,0 +1,121 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStackGitDrift(t *testing.T) {
+	deployedFile := "services:\n  web:\n    image: nginx:1.25\n    ports:\n      - 80:80\n"
+	gitServer := newTestGitServer(t, http.StatusOK, newTestGitRefs(
+		"9b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c refs/heads/main",
+	))
+
+	gitStack := func(hash string, auth *apimodels.GittypesGitAuthentication) *apimodels.PortainereeEdgeStack {
+		return &apimodels.PortainereeEdgeStack{
+			ID:   1,
+			Name: "shop",
+			GitConfig: &apimodels.GittypesRepoConfig{
+				URL:            gitServer.URL + "/acme/shop.git",
+				ReferenceName:  "refs/heads/main",
+				ConfigFilePath: "docker-compose.yml",
+				ConfigHash:     hash,
+				Authentication: auth,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		mockStack           *apimodels.PortainereeEdgeStack
+		mockGitFile         string
+		mockGitError        error
+		expectedInSync      bool
+		expectedDiff        []string
+		expectedLatest      string
+		expectedBehind      bool
+		expectedCommitError string
+		expectedError       string
+	}{
+		{
+			name:           "behind the latest commit",
+			mockStack:      gitStack("3f2a9c1d", nil),
+			mockGitFile:    "services:\n  web:\n    image: nginx:1.27\n    ports:\n      - 80:80\n  cache:\n    image: redis:7\n",
+			expectedDiff:   []string{"-    image: nginx:1.25", "+    image: nginx:1.27", "+  cache:", "+    image: redis:7"},
+			expectedLatest: "9b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c",
+			expectedBehind: true,
+		},
+		{
+			name:           "in sync with different line endings",
+			mockStack:      gitStack("9b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c", nil),
+			mockGitFile:    strings.ReplaceAll(deployedFile, "\n", "\r\n") + "\r\n",
+			expectedInSync: true,
+			expectedLatest: "9b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c",
+		},
+		{
+			name:                "private repository",
+			mockStack:           gitStack("3f2a9c1d", &apimodels.GittypesGitAuthentication{GitCredentialID: 4}),
+			mockGitFile:         deployedFile,
+			expectedInSync:      true,
+			expectedCommitError: "the repository requires credentials",
+		},
+		{
+			name:          "git authentication error",
+			mockStack:     gitStack("3f2a9c1d", &apimodels.GittypesGitAuthentication{Username: "bob"}),
+			mockGitError:  errors.New("Unable to clone git repository: authentication required"),
+			expectedError: ErrGitAuthentication.Error(),
+		},
+		{
+			name:          "not a git stack",
+			mockStack:     &apimodels.PortainereeEdgeStack{ID: 1, Name: "shop"},
+			expectedError: "stack 1 is not deployed from a git repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEdgeStack", int64(1)).Return(tt.mockStack, nil)
+			mockAPI.On("GetEdgeStackFile", int64(1)).Return(deployedFile, nil).Maybe()
+			mockAPI.On("GetGitRepositoryFile", mock.MatchedBy(func(payload *apimodels.GitopsRepositoryFilePreviewPayload) bool {
+				return *payload.Repository == gitServer.URL+"/acme/shop.git" && payload.Reference == "refs/heads/main" &&
+					payload.TargetFile == "docker-compose.yml"
+			})).Return(tt.mockGitFile, tt.mockGitError).Maybe()
+
+			client := &PortainerClient{cli: mockAPI}
+
+			drift, err := client.GetStackGitDrift(1)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "shop", drift.StackName)
+			assert.Equal(t, tt.mockStack.GitConfig.ConfigHash, drift.DeployedCommit)
+			assert.Equal(t, tt.expectedInSync, drift.FileInSync)
+			assert.Equal(t, tt.expectedDiff, drift.Diff)
+			assert.Equal(t, tt.expectedLatest, drift.LatestCommit)
+			assert.Equal(t, tt.expectedBehind, drift.BehindLatest)
+			if tt.expectedCommitError != "" {
+				assert.Contains(t, drift.LatestCommitError, tt.expectedCommitError)
+			} else {
+				assert.Empty(t, drift.LatestCommitError)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	assert.Nil(t, diffLines([]string{"a", "b"}, []string{"a", "b"}))
+	assert.Equal(t, []string{"+a"}, diffLines(nil, []string{"a"}))
+	assert.Equal(t, []string{"-b", "+c", "+e"}, diffLines([]string{"a", "b", "d"}, []string{"a", "c", "d", "e"}))
+}
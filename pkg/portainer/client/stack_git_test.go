@@ -0,0 +1,268 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/rawclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestGitStack() *apimodels.PortainereeEdgeStack {
+	return &apimodels.PortainereeEdgeStack{
+		ID:   1,
+		Name: "web",
+		GitConfig: &apimodels.GittypesRepoConfig{
+			URL:            "https://github.com/example/web.git",
+			ReferenceName:  "refs/heads/main",
+			ConfigFilePath: "docker-compose.yml",
+			ConfigHash:     "3f2a9c1",
+		},
+	}
+}
+
+func TestGetStackGitConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockStack     *apimodels.PortainereeEdgeStack
+		mockError     error
+		expected      models.GitConfig
+		expectedError string
+	}{
+		{
+			name:      "git stack",
+			mockStack: newTestGitStack(),
+			expected: models.GitConfig{
+				URL:            "https://github.com/example/web.git",
+				ReferenceName:  "refs/heads/main",
+				ConfigFilePath: "docker-compose.yml",
+				CommitHash:     "3f2a9c1",
+			},
+		},
+		{
+			name:          "stack not deployed from git",
+			mockStack:     &apimodels.PortainereeEdgeStack{ID: 1, Name: "web"},
+			expectedError: "stack 1 is not deployed from a git repository",
+		},
+		{
+			name:          "get error",
+			mockError:     errors.New("stack not found"),
+			expectedError: "failed to get edge stack: stack not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEdgeStack", int64(1)).Return(tt.mockStack, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			config, err := client.GetStackGitConfig(1)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRedeployStackFromGit(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockStack      *apimodels.PortainereeEdgeStack
+		mockError      error
+		expectRedeploy bool
+		expectAuthErr  bool
+		expectedError  string
+	}{
+		{
+			name:           "successful redeploy",
+			mockStack:      newTestGitStack(),
+			expectRedeploy: true,
+		},
+		{
+			name:           "git authentication failure",
+			mockStack:      newTestGitStack(),
+			mockError:      &rawclient.APIError{StatusCode: http.StatusInternalServerError, Message: "Unable to clone git repository", Details: "authentication failed, please ensure that the git credentials are correct"},
+			expectRedeploy: true,
+			expectAuthErr:  true,
+			expectedError:  "failed to pull the repository of stack 1",
+		},
+		{
+			name:           "deploy failure",
+			mockStack:      newTestGitStack(),
+			mockError:      &rawclient.APIError{StatusCode: http.StatusInternalServerError, Message: "Unable to deploy stack", Details: "invalid image reference"},
+			expectRedeploy: true,
+			expectedError:  "failed to redeploy stack 1",
+		},
+		{
+			name:          "stack not deployed from git",
+			mockStack:     &apimodels.PortainereeEdgeStack{ID: 1, Name: "web"},
+			expectedError: "stack 1 is not deployed from a git repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEdgeStack", int64(1)).Return(tt.mockStack, nil)
+			if tt.expectRedeploy {
+				mockAPI.On("RedeployEdgeStackFromGit", tt.mockStack, true).Return(tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.RedeployStackFromGit(1, true)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectAuthErr, errors.Is(err, ErrGitAuthentication))
+			if !tt.expectRedeploy {
+				mockAPI.AssertNotCalled(t, "RedeployEdgeStackFromGit", mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateStackAutoUpdate(t *testing.T) {
+	interval := func(s string) *string { return &s }
+	enabled := func(b bool) *bool { return &b }
+
+	withAutoUpdate := func(autoUpdate *apimodels.PortainerAutoUpdateSettings) *apimodels.PortainereeEdgeStack {
+		stack := newTestGitStack()
+		stack.AutoUpdate = autoUpdate
+		return stack
+	}
+
+	tests := []struct {
+		name           string
+		mockStack      *apimodels.PortainereeEdgeStack
+		opts           models.AutoUpdateOptions
+		mockError      error
+		expectUpdate   bool
+		expectSettings *apimodels.PortainerAutoUpdateSettings
+		expected       models.AutoUpdateSettings
+		expectWebhook  bool
+		expectedError  string
+	}{
+		{
+			name:           "enable polling",
+			mockStack:      newTestGitStack(),
+			opts:           models.AutoUpdateOptions{Interval: interval("5m")},
+			expectUpdate:   true,
+			expectSettings: &apimodels.PortainerAutoUpdateSettings{Interval: "5m"},
+			expected:       models.AutoUpdateSettings{Enabled: true, Interval: "5m"},
+		},
+		{
+			name:          "enable webhook",
+			mockStack:     newTestGitStack(),
+			opts:          models.AutoUpdateOptions{Webhook: enabled(true), ForcePullImage: enabled(true)},
+			expectUpdate:  true,
+			expected:      models.AutoUpdateSettings{Enabled: true, WebhookEnabled: true, ForcePullImage: true},
+			expectWebhook: true,
+		},
+		{
+			name:           "keep existing webhook",
+			mockStack:      withAutoUpdate(&apimodels.PortainerAutoUpdateSettings{Webhook: "05de31a2-79fa-4644-9c12-faa67e5c49f0"}),
+			opts:           models.AutoUpdateOptions{Webhook: enabled(true), Interval: interval("1h")},
+			expectUpdate:   true,
+			expectSettings: &apimodels.PortainerAutoUpdateSettings{Interval: "1h", Webhook: "05de31a2-79fa-4644-9c12-faa67e5c49f0"},
+			expected:       models.AutoUpdateSettings{Enabled: true, Interval: "1h", WebhookEnabled: true},
+		},
+		{
+			name:           "disable automatic updates",
+			mockStack:      withAutoUpdate(&apimodels.PortainerAutoUpdateSettings{Interval: "5m", Webhook: "05de31a2-79fa-4644-9c12-faa67e5c49f0", ForcePullImage: true}),
+			opts:           models.AutoUpdateOptions{Interval: interval(""), Webhook: enabled(false)},
+			expectUpdate:   true,
+			expectSettings: nil,
+			expected:       models.AutoUpdateSettings{},
+		},
+		{
+			name:          "force pull image without trigger",
+			mockStack:     newTestGitStack(),
+			opts:          models.AutoUpdateOptions{ForcePullImage: enabled(true)},
+			expectedError: "force pull image requires an interval or a webhook",
+		},
+		{
+			name:          "invalid interval",
+			opts:          models.AutoUpdateOptions{Interval: interval("often")},
+			expectedError: "invalid auto update interval often",
+		},
+		{
+			name:          "interval too short",
+			opts:          models.AutoUpdateOptions{Interval: interval("30s")},
+			expectedError: "auto update interval 30s is shorter than the minimum of 1m0s",
+		},
+		{
+			name:          "no options",
+			expectedError: "at least one auto update option is required",
+		},
+		{
+			name:          "stack not deployed from git",
+			mockStack:     &apimodels.PortainereeEdgeStack{ID: 1, Name: "web"},
+			opts:          models.AutoUpdateOptions{Interval: interval("5m")},
+			expectedError: "stack 1 is not deployed from a git repository",
+		},
+		{
+			name:           "update error",
+			mockStack:      newTestGitStack(),
+			opts:           models.AutoUpdateOptions{Interval: interval("5m")},
+			mockError:      errors.New("forbidden"),
+			expectUpdate:   true,
+			expectSettings: &apimodels.PortainerAutoUpdateSettings{Interval: "5m"},
+			expectedError:  "failed to update the auto update of stack 1: forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.mockStack != nil {
+				mockAPI.On("GetEdgeStack", int64(1)).Return(tt.mockStack, nil)
+			}
+			var sent *apimodels.PortainerAutoUpdateSettings
+			if tt.expectUpdate {
+				mockAPI.On("UpdateEdgeStackAutoUpdate", tt.mockStack, mock.Anything).Run(func(args mock.Arguments) {
+					sent = args.Get(1).(*apimodels.PortainerAutoUpdateSettings)
+				}).Return(tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			settings, err := client.UpdateStackAutoUpdate(1, tt.opts)
+
+			if !tt.expectUpdate {
+				mockAPI.AssertNotCalled(t, "UpdateEdgeStackAutoUpdate", mock.Anything, mock.Anything)
+			}
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+
+			if tt.expectWebhook {
+				assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, settings.WebhookToken)
+				assert.Equal(t, "/api/edge_stacks/webhooks/"+settings.WebhookToken, settings.WebhookPath)
+				assert.Equal(t, settings.WebhookToken, sent.Webhook)
+				settings.WebhookToken, settings.WebhookPath = "", ""
+			} else {
+				assert.Equal(t, tt.expectSettings, sent)
+			}
+			assert.Equal(t, tt.expected, settings)
+		})
+	}
+}
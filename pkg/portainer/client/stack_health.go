@@ -0,0 +1,140 @@
+package client
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// GetStackHealth aggregates the state of the containers of a stack across all the environments it is deployed to.
+// Stacks are the equivalent of Edge Stacks in Portainer, the environments are resolved from the stack environment groups.
+// See models.StackHealth for how the state of each environment maps to the overall status.
+//
+// Parameters:
+//   - stackId: The ID of the stack to check
+//
+// Returns:
+//   - The aggregated health of the stack, including the list of unhealthy containers
+//   - An error if the stack or its environment groups cannot be retrieved
+func (c *PortainerClient) GetStackHealth(stackId int) (models.StackHealth, error) {
+	stack, err := c.findStack(stackId)
+	if err != nil {
+		return models.StackHealth{}, err
+	}
+
+	environmentIds, err := c.stackEnvironmentIds(stack)
+	if err != nil {
+		return models.StackHealth{}, err
+	}
+
+	health := models.StackHealth{
+		StackID:             stack.ID,
+		StackName:           stack.Name,
+		Environments:        []models.StackEnvironmentHealth{},
+		UnhealthyContainers: []models.UnhealthyContainer{},
+	}
+
+	for _, environmentId := range environmentIds {
+		envHealth := models.StackEnvironmentHealth{EnvironmentID: environmentId}
+
+		containers, err := c.listDockerContainers(environmentId)
+		if err != nil {
+			envHealth.Status = models.StackHealthUnknown
+			envHealth.Error = err.Error()
+			health.Environments = append(health.Environments, envHealth)
+			continue
+		}
+
+		for _, container := range stackContainers(containers, stack.Name) {
+			envHealth.TotalContainers++
+			if isContainerHealthy(container) {
+				envHealth.HealthyContainers++
+				continue
+			}
+
+			health.UnhealthyContainers = append(health.UnhealthyContainers, models.UnhealthyContainer{
+				EnvironmentID: environmentId,
+				Name:          containerName(container),
+				Service:       container.Labels[composeServiceLabel],
+				State:         container.State,
+				Status:        container.Status,
+			})
+		}
+
+		envHealth.Status = environmentHealthStatus(envHealth)
+		health.Environments = append(health.Environments, envHealth)
+	}
+
+	health.Status = overallHealthStatus(health.Environments)
+
+	return health, nil
+}
+
+// stackEnvironmentIds returns the sorted IDs of the environments belonging to the stack environment groups
+func (c *PortainerClient) stackEnvironmentIds(stack models.Stack) ([]int, error) {
+	groups, err := c.GetEnvironmentGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, group := range groups {
+		if !slices.Contains(stack.EnvironmentGroupIds, group.ID) {
+			continue
+		}
+
+		for _, id := range group.EnvironmentIds {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	slices.Sort(ids)
+
+	return ids, nil
+}
+
+// isContainerHealthy reports whether a container is running and not reported unhealthy by its health check
+func isContainerHealthy(container dockerContainer) bool {
+	return container.State == "running" && !strings.Contains(container.Status, "(unhealthy)")
+}
+
+// containerName returns the name of a container without the leading slash added by Docker
+func containerName(container dockerContainer) string {
+	if len(container.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(container.Names[0], "/")
+}
+
+// environmentHealthStatus computes the status of a stack on a single environment
+func environmentHealthStatus(envHealth models.StackEnvironmentHealth) string {
+	switch {
+	case envHealth.HealthyContainers == 0:
+		return models.StackHealthUnhealthy
+	case envHealth.HealthyContainers == envHealth.TotalContainers:
+		return models.StackHealthHealthy
+	default:
+		return models.StackHealthDegraded
+	}
+}
+
+// overallHealthStatus computes the overall status of a stack from the status of each environment
+func overallHealthStatus(environments []models.StackEnvironmentHealth) string {
+	counts := make(map[string]int)
+	for _, env := range environments {
+		counts[env.Status]++
+	}
+
+	switch {
+	case len(environments) == 0 || counts[models.StackHealthUnknown] == len(environments):
+		return models.StackHealthUnknown
+	case counts[models.StackHealthHealthy] == len(environments):
+		return models.StackHealthHealthy
+	case counts[models.StackHealthHealthy]+counts[models.StackHealthDegraded] > 0:
+		return models.StackHealthDegraded
+	default:
+		return models.StackHealthUnhealthy
+	}
+}
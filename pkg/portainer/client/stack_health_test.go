@@ -0,0 +1,201 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStackHealth(t *testing.T) {
+	healthStacks := []*apimodels.PortainereeEdgeStack{
+		{ID: 1, Name: "shop", EdgeGroups: []int64{10, 11}},
+		{ID: 2, Name: "orphan", EdgeGroups: []int64{12}},
+	}
+	healthGroups := []*apimodels.EdgegroupsDecoratedEdgeGroup{
+		{ID: 10, Endpoints: []int64{4, 3}},
+		{ID: 11, Endpoints: []int64{3}},
+		{ID: 12, Endpoints: []int64{}},
+	}
+
+	healthyContainers := `[
+		{"Names":["/edge_shop-web-1"],"State":"running","Status":"Up 2 hours (healthy)","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"web"}},
+		{"Names":["/edge_shop-api-1"],"State":"running","Status":"Up 2 hours","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"api"}},
+		{"Names":["/other-db-1"],"State":"exited","Status":"Exited (1)","Labels":{"com.docker.compose.project":"other","com.docker.compose.service":"db"}}
+	]`
+	degradedContainers := `[
+		{"Names":["/edge_shop-web-1"],"State":"running","Status":"Up 5 minutes (unhealthy)","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"web"}},
+		{"Names":["/edge_shop-api-1"],"State":"running","Status":"Up 5 minutes","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"api"}}
+	]`
+	downContainers := `[
+		{"Names":["/edge_shop-web-1"],"State":"exited","Status":"Exited (137)","Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"web"}}
+	]`
+
+	type envResponse struct {
+		body string
+		err  error
+	}
+
+	tests := []struct {
+		name           string
+		stackId        int
+		responses      map[int]envResponse
+		expectedStatus string
+		expectedEnvs   []models.StackEnvironmentHealth
+		expectedCount  int
+		expectedError  bool
+	}{
+		{
+			name:    "healthy on every environment",
+			stackId: 1,
+			responses: map[int]envResponse{
+				3: {body: healthyContainers},
+				4: {body: healthyContainers},
+			},
+			expectedStatus: models.StackHealthHealthy,
+			expectedEnvs: []models.StackEnvironmentHealth{
+				{EnvironmentID: 3, Status: models.StackHealthHealthy, HealthyContainers: 2, TotalContainers: 2},
+				{EnvironmentID: 4, Status: models.StackHealthHealthy, HealthyContainers: 2, TotalContainers: 2},
+			},
+		},
+		{
+			name:    "degraded on one environment",
+			stackId: 1,
+			responses: map[int]envResponse{
+				3: {body: healthyContainers},
+				4: {body: degradedContainers},
+			},
+			expectedStatus: models.StackHealthDegraded,
+			expectedEnvs: []models.StackEnvironmentHealth{
+				{EnvironmentID: 3, Status: models.StackHealthHealthy, HealthyContainers: 2, TotalContainers: 2},
+				{EnvironmentID: 4, Status: models.StackHealthDegraded, HealthyContainers: 1, TotalContainers: 2},
+			},
+			expectedCount: 1,
+		},
+		{
+			name:    "down on one environment and unreachable on the other",
+			stackId: 1,
+			responses: map[int]envResponse{
+				3: {body: downContainers},
+				4: {err: errors.New("environment unreachable")},
+			},
+			expectedStatus: models.StackHealthUnhealthy,
+			expectedEnvs: []models.StackEnvironmentHealth{
+				{EnvironmentID: 3, Status: models.StackHealthUnhealthy, HealthyContainers: 0, TotalContainers: 1},
+				{EnvironmentID: 4, Status: models.StackHealthUnknown, Error: "failed to list containers: environment unreachable"},
+			},
+			expectedCount: 1,
+		},
+		{
+			name:    "not running on an environment",
+			stackId: 1,
+			responses: map[int]envResponse{
+				3: {body: healthyContainers},
+				4: {body: `[]`},
+			},
+			expectedStatus: models.StackHealthDegraded,
+			expectedEnvs: []models.StackEnvironmentHealth{
+				{EnvironmentID: 3, Status: models.StackHealthHealthy, HealthyContainers: 2, TotalContainers: 2},
+				{EnvironmentID: 4, Status: models.StackHealthUnhealthy},
+			},
+		},
+		{
+			name:           "not deployed to any environment",
+			stackId:        2,
+			expectedStatus: models.StackHealthUnknown,
+			expectedEnvs:   []models.StackEnvironmentHealth{},
+		},
+		{
+			name:          "stack does not exist",
+			stackId:       3,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeStacks").Return(healthStacks, nil)
+			mockAPI.On("ListEdgeGroups").Return(healthGroups, nil).Maybe()
+			for envId, response := range tt.responses {
+				var resp *http.Response
+				if response.err == nil {
+					resp = &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(response.body)),
+					}
+				}
+				mockAPI.On("ProxyDockerRequest", envId, mock.AnythingOfType("client.ProxyRequestOptions")).Return(resp, response.err)
+			}
+
+			c := &PortainerClient{cli: mockAPI}
+
+			health, err := c.GetStackHealth(tt.stackId)
+
+			mockAPI.AssertExpectations(t)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.stackId, health.StackID)
+			assert.Equal(t, tt.expectedStatus, health.Status)
+			assert.Equal(t, tt.expectedEnvs, health.Environments)
+			assert.Len(t, health.UnhealthyContainers, tt.expectedCount)
+		})
+	}
+}
+
+func TestGetStackHealthUnhealthyContainerDetails(t *testing.T) {
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("ListEdgeStacks").Return([]*apimodels.PortainereeEdgeStack{{ID: 1, Name: "shop", EdgeGroups: []int64{10}}}, nil)
+	mockAPI.On("ListEdgeGroups").Return([]*apimodels.EdgegroupsDecoratedEdgeGroup{{ID: 10, Endpoints: []int64{3}}}, nil)
+	mockAPI.On("ProxyDockerRequest", 3, mock.AnythingOfType("client.ProxyRequestOptions")).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`[
+			{"Names":["/shop-web-1"],"State":"running","Status":"Up 1 minute (unhealthy)","Labels":{"com.docker.compose.project":"shop","com.docker.compose.service":"web"}}
+		]`)),
+	}, nil)
+
+	c := &PortainerClient{cli: mockAPI}
+
+	health, err := c.GetStackHealth(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.UnhealthyContainer{
+		{EnvironmentID: 3, Name: "shop-web-1", Service: "web", State: "running", Status: "Up 1 minute (unhealthy)"},
+	}, health.UnhealthyContainers)
+	assert.Equal(t, models.StackHealthUnhealthy, health.Status)
+}
+
+func TestOverallHealthStatus(t *testing.T) {
+	env := func(status string) models.StackEnvironmentHealth {
+		return models.StackEnvironmentHealth{Status: status}
+	}
+
+	tests := []struct {
+		name         string
+		environments []models.StackEnvironmentHealth
+		expected     string
+	}{
+		{"no environment", nil, models.StackHealthUnknown},
+		{"all unknown", []models.StackEnvironmentHealth{env(models.StackHealthUnknown)}, models.StackHealthUnknown},
+		{"all healthy", []models.StackEnvironmentHealth{env(models.StackHealthHealthy), env(models.StackHealthHealthy)}, models.StackHealthHealthy},
+		{"healthy and unknown", []models.StackEnvironmentHealth{env(models.StackHealthHealthy), env(models.StackHealthUnknown)}, models.StackHealthDegraded},
+		{"healthy and unhealthy", []models.StackEnvironmentHealth{env(models.StackHealthHealthy), env(models.StackHealthUnhealthy)}, models.StackHealthDegraded},
+		{"only degraded", []models.StackEnvironmentHealth{env(models.StackHealthDegraded)}, models.StackHealthDegraded},
+		{"unhealthy and unknown", []models.StackEnvironmentHealth{env(models.StackHealthUnhealthy), env(models.StackHealthUnknown)}, models.StackHealthUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, overallHealthStatus(tt.environments))
+		})
+	}
+}
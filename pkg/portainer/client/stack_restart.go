@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// RestartStack restarts all the containers of a stack on an environment through the Docker proxy, the
+// equivalent of the `docker compose restart` command. The stack file is not redeployed and the containers
+// are not recreated. Stacks are the equivalent of Edge Stacks in Portainer, the stack must be deployed to
+// the environment through one of its environment groups.
+//
+// The containers are restarted one at a time, sorted by service, and a failure does not stop the restart of
+// the other containers.
+//
+// Parameters:
+//   - stackId: The ID of the stack to restart
+//   - environmentId: The ID of the environment to restart the stack on
+//
+// Returns:
+//   - A StackRestartReport listing the restarted containers and the containers that could not be restarted
+//   - A *StackRestartError along with the report if some containers could not be restarted
+//   - An error if the stack is not deployed to the environment, if it has no containers on it or if the operation fails
+func (c *PortainerClient) RestartStack(stackId, environmentId int) (models.StackRestartReport, error) {
+	stack, err := c.findStack(stackId)
+	if err != nil {
+		return models.StackRestartReport{}, err
+	}
+
+	environmentIds, err := c.stackEnvironmentIds(stack)
+	if err != nil {
+		return models.StackRestartReport{}, err
+	}
+	if !slices.Contains(environmentIds, environmentId) {
+		return models.StackRestartReport{}, fmt.Errorf("stack %d is not deployed to environment %d", stackId, environmentId)
+	}
+
+	containers, err := c.listDockerContainers(environmentId)
+	if err != nil {
+		return models.StackRestartReport{}, err
+	}
+
+	containers = stackContainers(containers, stack.Name)
+	if len(containers) == 0 {
+		return models.StackRestartReport{}, fmt.Errorf("stack %d has no containers on environment %d", stackId, environmentId)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		iService, jService := containers[i].Labels[composeServiceLabel], containers[j].Labels[composeServiceLabel]
+		if iService != jService {
+			return iService < jService
+		}
+		return containerName(containers[i]) < containerName(containers[j])
+	})
+
+	report := models.StackRestartReport{
+		StackID:       stack.ID,
+		StackName:     stack.Name,
+		EnvironmentID: environmentId,
+		Restarted:     []models.StackContainerRestart{},
+		Failed:        []models.StackContainerRestart{},
+	}
+
+	for _, container := range containers {
+		restart := models.StackContainerRestart{
+			Service:       container.Labels[composeServiceLabel],
+			ContainerID:   container.ID,
+			ContainerName: containerName(container),
+		}
+
+		if err := c.restartContainer(environmentId, container.ID); err != nil {
+			restart.Error = err.Error()
+			report.Failed = append(report.Failed, restart)
+			continue
+		}
+
+		report.Restarted = append(report.Restarted, restart)
+	}
+
+	if len(report.Failed) > 0 {
+		return report, &StackRestartError{
+			StackID:       stackId,
+			EnvironmentID: environmentId,
+			Failed:        len(report.Failed),
+			Total:         len(containers),
+		}
+	}
+
+	return report, nil
+}
+
+// restartContainer restarts a container through the Docker proxy, waiting for Docker to stop it
+func (c *PortainerClient) restartContainer(environmentId int, containerId string) error {
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodPost,
+		Path:          fmt.Sprintf("/containers/%s/restart", url.PathEscape(containerId)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart container: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to restart container: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
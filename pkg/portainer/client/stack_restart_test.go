@@ -0,0 +1,122 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartStack(t *testing.T) {
+	restartContainers := `[
+		{"Id":"c2","Names":["/edge_shop-web-1"],"Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"web"}},
+		{"Id":"c1","Names":["/edge_shop-api-1"],"Labels":{"com.docker.compose.project":"edge_shop","com.docker.compose.service":"api"}},
+		{"Id":"c3","Names":["/cache"],"Labels":{"com.docker.compose.project":"other","com.docker.compose.service":"cache"}}
+	]`
+
+	tests := []struct {
+		name             string
+		environmentId    int
+		mockContainers   string
+		restartStatuses  map[string]int
+		expectedReport   models.StackRestartReport
+		expectedError    string
+		expectRestartErr bool
+	}{
+		{
+			name:            "all containers restarted",
+			environmentId:   3,
+			mockContainers:  restartContainers,
+			restartStatuses: map[string]int{"c1": http.StatusNoContent, "c2": http.StatusNoContent},
+			expectedReport: models.StackRestartReport{
+				StackID:       1,
+				StackName:     "shop",
+				EnvironmentID: 3,
+				Restarted: []models.StackContainerRestart{
+					{Service: "api", ContainerID: "c1", ContainerName: "edge_shop-api-1"},
+					{Service: "web", ContainerID: "c2", ContainerName: "edge_shop-web-1"},
+				},
+				Failed: []models.StackContainerRestart{},
+			},
+		},
+		{
+			name:            "some containers fail",
+			environmentId:   3,
+			mockContainers:  restartContainers,
+			restartStatuses: map[string]int{"c1": http.StatusInternalServerError, "c2": http.StatusNoContent},
+			expectedReport: models.StackRestartReport{
+				StackID:       1,
+				StackName:     "shop",
+				EnvironmentID: 3,
+				Restarted: []models.StackContainerRestart{
+					{Service: "web", ContainerID: "c2", ContainerName: "edge_shop-web-1"},
+				},
+				Failed: []models.StackContainerRestart{
+					{Service: "api", ContainerID: "c1", ContainerName: "edge_shop-api-1", Error: "failed to restart container: unexpected status 500: cannot kill container"},
+				},
+			},
+			expectedError:    "failed to restart 1 of 2 containers of stack 1 on environment 3",
+			expectRestartErr: true,
+		},
+		{
+			name:           "no containers on the environment",
+			environmentId:  3,
+			mockContainers: `[{"Id":"c3","Labels":{"com.docker.compose.project":"other"}}]`,
+			expectedError:  "stack 1 has no containers on environment 3",
+		},
+		{
+			name:          "stack not deployed to the environment",
+			environmentId: 5,
+			expectedError: "stack 1 is not deployed to environment 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeStacks").Return([]*apimodels.PortainereeEdgeStack{{ID: 1, Name: "shop", EdgeGroups: []int64{10}}}, nil)
+			mockAPI.On("ListEdgeGroups").Return([]*apimodels.EdgegroupsDecoratedEdgeGroup{
+				{ID: 10, Name: "production", Endpoints: []int64{3, 4}},
+			}, nil)
+			if tt.mockContainers != "" {
+				mockAPI.On("ProxyDockerRequest", tt.environmentId, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == "/containers/json"
+				})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tt.mockContainers))}, nil)
+			}
+			for id, status := range tt.restartStatuses {
+				body := ""
+				if status >= 300 {
+					body = "cannot kill container"
+				}
+				mockAPI.On("ProxyDockerRequest", tt.environmentId, client.ProxyRequestOptions{
+					Method:  http.MethodPost,
+					APIPath: "/containers/" + id + "/restart",
+				}).Return(&http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			report, err := client.RestartStack(1, tt.environmentId)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				var restartErr *StackRestartError
+				assert.Equal(t, tt.expectRestartErr, errors.As(err, &restartErr))
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.expectedReport.StackID != 0 {
+				assert.Equal(t, tt.expectedReport, report)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// FindStacksByImage retrieves the stacks whose file references an image matching a pattern.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// The pattern is matched as a glob against the whole image reference when it contains
+// a wildcard (* or ?), e.g. "nginx:1.2*" or "*/log4j*". Otherwise stacks are matched when
+// one of their images contains the pattern, e.g. "redis" or "nginx:1.21".
+// Matching is case-insensitive and ignores the default Docker Hub registry and namespace,
+// so "docker.io/library/nginx" matches a stack using "nginx".
+//
+// Stacks whose file cannot be parsed, such as an empty file or a file declaring no service,
+// reference no image and are not matched, the other stacks are still searched.
+//
+// Parameters:
+//   - imagePattern: The glob or substring to match the images against
+//
+// Returns:
+//   - A slice of the matching Stack objects, in the order returned by Portainer
+//   - An error if the operation fails or if a stack file cannot be retrieved
+func (c *PortainerClient) FindStacksByImage(imagePattern string) ([]models.Stack, error) {
+	match, err := newImageMatcher(imagePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks, err := c.GetStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]bool, len(stacks))
+	errs := make([]error, len(stacks))
+
+	forEachConcurrently(len(stacks), maxConcurrentStackFileRequests, func(i int) {
+		matched[i], errs[i] = c.stackReferencesImage(stacks[i].ID, match)
+	})
+
+	result := []models.Stack{}
+	for i, stack := range stacks {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to scan stack %d: %w", stack.ID, errs[i])
+		}
+		if matched[i] {
+			result = append(result, stack)
+		}
+	}
+
+	return result, nil
+}
+
+// stackReferencesImage reports whether one of the services of a stack file uses an image accepted by match
+func (c *PortainerClient) stackReferencesImage(stackId int, match func(string) bool) (bool, error) {
+	file, err := c.GetStackFile(stackId)
+	if err != nil {
+		return false, err
+	}
+
+	// A file that cannot be parsed does not tell which images the stack uses, it is not a reason to
+	// fail the search of the other stacks
+	services, err := parseComposeServices(file)
+	if err != nil {
+		return false, nil
+	}
+
+	for _, image := range services {
+		if image == "" {
+			continue
+		}
+		if match(image) || match(normalizeImageReference(image)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// newImageMatcher builds a case-insensitive matcher for an image pattern.
+// Patterns containing * or ? are treated as globs matching the whole reference,
+// where * matches any sequence of characters (including /) and ? matches a single character.
+// Any other pattern is matched as a substring, without its docker.io/library/ prefix.
+func newImageMatcher(pattern string) (func(string) bool, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return nil, fmt.Errorf("image pattern cannot be empty")
+	}
+
+	if !strings.ContainsAny(pattern, "*?") {
+		pattern = strings.TrimPrefix(pattern, "docker.io/")
+		pattern = strings.TrimPrefix(pattern, "library/")
+		return func(image string) bool {
+			return strings.Contains(strings.ToLower(image), pattern)
+		}, nil
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, `.*`)
+	expr = strings.ReplaceAll(expr, `\?`, `.`)
+
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid image pattern %q: %w", pattern, err)
+	}
+
+	return func(image string) bool {
+		return re.MatchString(strings.ToLower(image))
+	}, nil
+}
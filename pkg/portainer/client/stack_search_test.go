@@ -0,0 +1,139 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindStacksByImage(t *testing.T) {
+	mockStacks := []*apimodels.PortainereeEdgeStack{
+		{ID: 1, Name: "web"},
+		{ID: 2, Name: "cache"},
+		{ID: 3, Name: "logging"},
+	}
+	mockFiles := map[int64]string{
+		1: "services:\n  web:\n    image: nginx:1.21\n  app:\n    build: .\n",
+		2: "services:\n  redis:\n    image: redis:7-alpine\n",
+		3: "services:\n  collector:\n    image: docker.io/myorg/log4j-collector:2.14\n",
+	}
+
+	tests := []struct {
+		name          string
+		pattern       string
+		mockFileError error
+		expectedIDs   []int
+		expectedError bool
+		skipList      bool
+	}{
+		{
+			name:        "substring match",
+			pattern:     "redis",
+			expectedIDs: []int{2},
+		},
+		{
+			name:        "substring match is case-insensitive",
+			pattern:     "NGINX:1.21",
+			expectedIDs: []int{1},
+		},
+		{
+			name:        "substring ignores the default registry",
+			pattern:     "docker.io/library/nginx",
+			expectedIDs: []int{1},
+		},
+		{
+			name:        "glob match",
+			pattern:     "nginx:1.2?",
+			expectedIDs: []int{1},
+		},
+		{
+			name:        "glob wildcard spans path separators",
+			pattern:     "*log4j*",
+			expectedIDs: []int{3},
+		},
+		{
+			name:        "glob matches the whole reference",
+			pattern:     "redis*",
+			expectedIDs: []int{2},
+		},
+		{
+			name:        "glob matches several stacks",
+			pattern:     "*:*",
+			expectedIDs: []int{1, 2, 3},
+		},
+		{
+			name:        "no match",
+			pattern:     "postgres",
+			expectedIDs: []int{},
+		},
+		{
+			name:          "empty pattern",
+			pattern:       " ",
+			expectedError: true,
+			skipList:      true,
+		},
+		{
+			name:          "get file error",
+			pattern:       "redis",
+			mockFileError: errors.New("failed to get stack file"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if !tt.skipList {
+				mockAPI.On("ListEdgeStacks").Return(mockStacks, nil)
+				for id, file := range mockFiles {
+					mockAPI.On("GetEdgeStackFile", id).Return(file, tt.mockFileError)
+				}
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			stacks, err := client.FindStacksByImage(tt.pattern)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := make([]int, len(stacks))
+			for i, stack := range stacks {
+				ids[i] = stack.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFindStacksByImageUnparsableStack(t *testing.T) {
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("ListEdgeStacks").Return([]*apimodels.PortainereeEdgeStack{
+		{ID: 1, Name: "web"},
+		{ID: 2, Name: "empty"},
+		{ID: 3, Name: "broken"},
+		{ID: 4, Name: "proxy"},
+	}, nil)
+	mockAPI.On("GetEdgeStackFile", int64(1)).Return("services:\n  web:\n    image: nginx:1.21\n", nil)
+	mockAPI.On("GetEdgeStackFile", int64(2)).Return("", nil)
+	mockAPI.On("GetEdgeStackFile", int64(3)).Return("services: [nginx\n", nil)
+	mockAPI.On("GetEdgeStackFile", int64(4)).Return("services:\n  proxy:\n    image: nginx:1.25\n", nil)
+
+	client := &PortainerClient{cli: mockAPI}
+
+	stacks, err := client.FindStacksByImage("nginx")
+
+	assert.NoError(t, err)
+	ids := make([]int, len(stacks))
+	for i, stack := range stacks {
+		ids[i] = stack.ID
+	}
+	assert.Equal(t, []int{1, 4}, ids)
+	mockAPI.AssertExpectations(t)
+}
@@ -218,3 +218,99 @@ func TestUpdateStack(t *testing.T) {
 		})
 	}
 }
+
+func TestCloneStack(t *testing.T) {
+	sourceFile := "services:\n  web:\n    image: nginx:1.25 # frontend\n  api:\n    image: \"myorg/api:1.2.0\"\n"
+
+	tests := []struct {
+		name              string
+		sourceStackID     int
+		newName           string
+		targetGroupIds    []int
+		imageReplacements map[string]string
+		mockFile          string
+		mockFileError     error
+		expectedFile      string
+		mockID            int64
+		mockCreateError   error
+		expected          int
+		expectedError     bool
+		skipCreate        bool
+	}{
+		{
+			name:           "successful clone without replacements",
+			sourceStackID:  1,
+			newName:        "shop-prod",
+			targetGroupIds: []int{3},
+			mockFile:       sourceFile,
+			expectedFile:   sourceFile,
+			mockID:         7,
+			expected:       7,
+		},
+		{
+			name:           "successful clone with replacements",
+			sourceStackID:  1,
+			newName:        "shop-prod",
+			targetGroupIds: []int{3, 4},
+			imageReplacements: map[string]string{
+				"nginx:1.25":      "nginx:1.27",
+				"myorg/api:1.2.0": "myorg/api:1.3.0",
+			},
+			mockFile:     sourceFile,
+			expectedFile: "services:\n  web:\n    image: nginx:1.27 # frontend\n  api:\n    image: \"myorg/api:1.3.0\"\n",
+			mockID:       8,
+			expected:     8,
+		},
+		{
+			name:              "replacement not found",
+			sourceStackID:     1,
+			newName:           "shop-prod",
+			targetGroupIds:    []int{3},
+			imageReplacements: map[string]string{"redis:7": "redis:8"},
+			mockFile:          sourceFile,
+			expectedError:     true,
+			skipCreate:        true,
+		},
+		{
+			name:           "get file error",
+			sourceStackID:  1,
+			newName:        "shop-prod",
+			targetGroupIds: []int{3},
+			mockFileError:  errors.New("failed to get stack file"),
+			expectedError:  true,
+			skipCreate:     true,
+		},
+		{
+			name:            "create error",
+			sourceStackID:   1,
+			newName:         "shop-prod",
+			targetGroupIds:  []int{3},
+			mockFile:        sourceFile,
+			expectedFile:    sourceFile,
+			mockCreateError: errors.New("failed to create stack"),
+			expectedError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEdgeStackFile", int64(tt.sourceStackID)).Return(tt.mockFile, tt.mockFileError)
+			if !tt.skipCreate {
+				mockAPI.On("CreateEdgeStack", tt.newName, tt.expectedFile, utils.IntToInt64Slice(tt.targetGroupIds)).Return(tt.mockID, tt.mockCreateError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			id, err := client.CloneStack(tt.sourceStackID, tt.newName, tt.targetGroupIds, tt.imageReplacements)
+
+			mockAPI.AssertExpectations(t)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
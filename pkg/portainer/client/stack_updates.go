@@ -0,0 +1,119 @@
+package client
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// stackUpdateAction matches the actions of the activity logs that change an edge stack, e.g.
+// "PUT /edge_stacks/3", and captures the ID of the stack
+var stackUpdateAction = regexp.MustCompile(`^(?:PUT|POST|PATCH) (?:/api)?/edge_stacks/(\d+)(?:[/?]|$)`)
+
+// annotateStackUpdates sets the time and the author of the last update of the stacks from the activity
+// logs, Portainer does not store them on the edge stacks. The logs recorded since the creation of the
+// oldest stack are scanned page by page, the most recent first, until the last update of every stack is
+// found or after maxActivityLogPages pages.
+func (c *PortainerClient) annotateStackUpdates(stacks []models.Stack) error {
+	if len(stacks) == 0 {
+		return nil
+	}
+
+	// A stack cannot be updated before its creation, older logs are not scanned
+	var after int64
+	indexes := make(map[int]int, len(stacks))
+	for i, stack := range stacks {
+		indexes[stack.ID] = i
+		if createdAt, err := time.Parse(time.RFC3339, stack.CreatedAt); err == nil && (after == 0 || createdAt.Unix() < after) {
+			after = createdAt.Unix()
+		}
+	}
+
+	remaining := len(stacks)
+	var offset int64
+	for page := 0; page < maxActivityLogPages && remaining > 0; page++ {
+		rawLogs, totalCount, err := c.cli.PageUserActivityLogs(after, 0, activityLogPageSize, offset)
+		if err != nil {
+			return convertActivityLogError(err)
+		}
+
+		// The first update of a stack found is its last one
+		for _, rawLog := range rawLogs {
+			match := stackUpdateAction.FindStringSubmatch(rawLog.Action)
+			if match == nil {
+				continue
+			}
+
+			id, err := strconv.Atoi(match[1])
+			if err != nil {
+				continue
+			}
+
+			i, ok := indexes[id]
+			if !ok || stacks[i].UpdatedAt != "" {
+				continue
+			}
+
+			stacks[i].UpdatedAt = time.Unix(rawLog.Timestamp, 0).UTC().Format(time.RFC3339)
+			stacks[i].UpdatedBy = rawLog.Username
+			remaining--
+		}
+
+		offset += int64(len(rawLogs))
+		if len(rawLogs) < activityLogPageSize || offset >= totalCount {
+			break
+		}
+	}
+
+	return nil
+}
+
+// GetRecentlyChangedStacks retrieves the stacks sorted by the time of their last change, the most
+// recent first. The last change of a stack is its last recorded update, or its creation when no update
+// was recorded. The author of the change is only known for the recorded updates.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// The updates are read from the activity logs, only available on Portainer Business Edition. The
+// updates older than the maxActivityLogPages most recent pages of activity logs are not found.
+//
+// Parameters:
+//   - limit: The maximum number of stacks to return, 0 returns all the stacks
+//
+// Returns:
+//   - A slice of Stack objects, the most recently changed first
+//   - ErrFeatureUnavailable if the Portainer server does not expose activity logs
+//   - An error if the operation fails
+func (c *PortainerClient) GetRecentlyChangedStacks(limit int) ([]models.Stack, error) {
+	stacks, err := c.GetStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.annotateStackUpdates(stacks); err != nil {
+		return nil, err
+	}
+
+	// The dates are formatted in RFC3339 by the conversions, they are compared as time values as the
+	// creation dates are not formatted in UTC
+	changedAt := make(map[int]time.Time, len(stacks))
+	for _, stack := range stacks {
+		date := stack.UpdatedAt
+		if date == "" {
+			date = stack.CreatedAt
+		}
+		changedAt[stack.ID], _ = time.Parse(time.RFC3339, date)
+	}
+
+	sort.SliceStable(stacks, func(i, j int) bool {
+		return changedAt[stacks[i].ID].After(changedAt[stacks[j].ID])
+	})
+
+	if limit > 0 && len(stacks) > limit {
+		stacks = stacks[:limit]
+	}
+
+	return stacks, nil
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecentlyChangedStacks(t *testing.T) {
+	mockStacks := []*apimodels.PortainereeEdgeStack{
+		{ID: 1, Name: "shop", CreationDate: 1714000000},
+		{ID: 2, Name: "blog", CreationDate: 1714700000},
+		{ID: 3, Name: "wiki", CreationDate: 1713000000},
+	}
+	mockLogs := []*apimodels.PortainereeUserActivityLog{
+		{Timestamp: 1714800000, Username: "alice", Action: "PUT /edge_stacks/3"},
+		{Timestamp: 1714600000, Username: "bob", Action: "PUT /edge_stacks/1"},
+	}
+
+	tests := []struct {
+		name              string
+		limit             int
+		mockError         error
+		mockLogsError     error
+		expectedNames     []string
+		expectedBy        []string
+		expectedError     bool
+		expectUnavailable bool
+	}{
+		{
+			name:          "sorted by last change",
+			expectedNames: []string{"wiki", "blog", "shop"},
+			expectedBy:    []string{"alice", "", "bob"},
+		},
+		{
+			name:          "with limit",
+			limit:         2,
+			expectedNames: []string{"wiki", "blog"},
+			expectedBy:    []string{"alice", ""},
+		},
+		{
+			name:              "activity logs unavailable",
+			mockLogsError:     runtime.NewAPIError("LogsList", nil, http.StatusNotFound),
+			expectedError:     true,
+			expectUnavailable: true,
+		},
+		{
+			name:          "activity logs forbidden",
+			mockLogsError: runtime.NewAPIError("LogsList", nil, http.StatusForbidden),
+			expectedError: true,
+		},
+		{
+			name:          "list error",
+			mockError:     errors.New("failed to list stacks"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListEdgeStacks").Return(mockStacks, tt.mockError)
+			// The logs older than the oldest stack are not scanned
+			mockAPI.On("PageUserActivityLogs", int64(1713000000), int64(0), int64(activityLogPageSize), int64(0)).Return(mockLogs, int64(len(mockLogs)), tt.mockLogsError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			stacks, err := client.GetRecentlyChangedStacks(tt.limit)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectUnavailable, errors.Is(err, ErrFeatureUnavailable))
+				return
+			}
+			assert.NoError(t, err)
+
+			names := make([]string, len(stacks))
+			authors := make([]string, len(stacks))
+			for i, stack := range stacks {
+				names[i] = stack.Name
+				authors[i] = stack.UpdatedBy
+			}
+			assert.Equal(t, tt.expectedNames, names)
+			assert.Equal(t, tt.expectedBy, authors)
+		})
+	}
+}
+
+func TestGetRecentlyChangedStacksPaging(t *testing.T) {
+	mockStacks := []*apimodels.PortainereeEdgeStack{
+		{ID: 1, Name: "shop", CreationDate: 1714000000},
+		{ID: 2, Name: "blog", CreationDate: 1714100000},
+	}
+
+	// The update of the blog is on the first page, the update of the shop on the second one
+	firstPage := make([]*apimodels.PortainereeUserActivityLog, activityLogPageSize)
+	for i := range firstPage {
+		firstPage[i] = &apimodels.PortainereeUserActivityLog{Timestamp: 1714900000, Username: "alice", Action: "GET /edge_stacks"}
+	}
+	firstPage[10] = &apimodels.PortainereeUserActivityLog{Timestamp: 1714900000, Username: "alice", Action: "PUT /edge_stacks/2"}
+	secondPage := []*apimodels.PortainereeUserActivityLog{
+		{Timestamp: 1714500000, Username: "bob", Action: "PUT /edge_stacks/1"},
+	}
+	totalCount := int64(3 * activityLogPageSize)
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("ListEdgeStacks").Return(mockStacks, nil)
+	mockAPI.On("PageUserActivityLogs", int64(1714000000), int64(0), int64(activityLogPageSize), int64(0)).Return(firstPage, totalCount, nil)
+	mockAPI.On("PageUserActivityLogs", int64(1714000000), int64(0), int64(activityLogPageSize), int64(activityLogPageSize)).Return(secondPage, totalCount, nil)
+
+	client := &PortainerClient{cli: mockAPI}
+
+	stacks, err := client.GetRecentlyChangedStacks(0)
+
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 2)
+	assert.Equal(t, "alice", stacks[0].UpdatedBy)
+	assert.Equal(t, "bob", stacks[1].UpdatedBy)
+	assert.Equal(t, "2024-04-30T18:00:00Z", stacks[1].UpdatedAt)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestGetRecentlyChangedStacksStopsOnceAllUpdatesFound(t *testing.T) {
+	fullPage := make([]*apimodels.PortainereeUserActivityLog, activityLogPageSize)
+	for i := range fullPage {
+		fullPage[i] = &apimodels.PortainereeUserActivityLog{Timestamp: 1714900000, Username: "alice", Action: "PUT /edge_stacks/1"}
+	}
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("ListEdgeStacks").Return([]*apimodels.PortainereeEdgeStack{{ID: 1, Name: "shop", CreationDate: 1714000000}}, nil)
+	mockAPI.On("PageUserActivityLogs", int64(1714000000), int64(0), int64(activityLogPageSize), int64(0)).Return(fullPage, int64(10*activityLogPageSize), nil)
+
+	client := &PortainerClient{cli: mockAPI}
+
+	stacks, err := client.GetRecentlyChangedStacks(0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", stacks[0].UpdatedBy)
+	mockAPI.AssertNumberOfCalls(t, "PageUserActivityLogs", 1)
+}
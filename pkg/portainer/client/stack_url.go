@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// maxStackFileSize is the maximum size of a stack file fetched from a URL
+const maxStackFileSize = 1 << 20
+
+// stackURLFetchTimeout is the maximum time spent fetching a stack file from a URL
+var stackURLFetchTimeout = 30 * time.Second
+
+// CreateStackFromURL creates a new stack from a Compose file fetched from a URL.
+// Stacks are the equivalent of Edge Stacks in Portainer.
+//
+// Portainer can only create Kubernetes stacks of a single environment from a URL, Edge Stacks
+// are created from the content of their file. The file is therefore downloaded by the server
+// and the stack is created from its content, the URL is not stored in Portainer and the stack
+// is not updated when the file changes.
+//
+// Only http and https URLs are accepted, and their host must be one of the allowed hosts when
+// hosts are restricted with WithAllowedStackURLHosts. Hosts that are not explicitly allowed cannot
+// be reached on a loopback, link-local, private or unspecified address: the check is made on the
+// resolved address of every connection, so that it also applies to redirects and to host names
+// resolving to internal addresses. Redirects are checked the same way.
+//
+// Parameters:
+//   - name: The name of the stack
+//   - composeURL: The URL of the Compose file of the stack
+//   - environmentGroupIds: A slice of environment group IDs to include in the stack
+//
+// Returns:
+//   - The ID of the created stack
+//   - An error if the URL is not allowed, the file cannot be fetched or the operation fails
+func (c *PortainerClient) CreateStackFromURL(name, composeURL string, environmentGroupIds []int) (int, error) {
+	if err := c.validateStackURL(composeURL); err != nil {
+		return 0, err
+	}
+
+	file, err := c.fetchStackFile(composeURL)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := c.cli.CreateEdgeStack(name, file, utils.IntToInt64Slice(environmentGroupIds))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create edge stack: %w", err)
+	}
+
+	return int(id), nil
+}
+
+// validateStackURL checks that a stack file URL uses http or https and targets an allowed host
+func (c *PortainerClient) validateStackURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid stack file URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid stack file URL %s: only http and https URLs are allowed", rawURL)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("invalid stack file URL %s: missing host", rawURL)
+	}
+
+	if len(c.stackURLHosts) > 0 && !c.isAllowedStackURLHost(host) {
+		return fmt.Errorf("invalid stack file URL %s: host %s is not allowed", rawURL, host)
+	}
+
+	return nil
+}
+
+// isAllowedStackURLHost reports whether a host is one of the allowed stack file hosts
+func (c *PortainerClient) isAllowedStackURLHost(host string) bool {
+	return slices.ContainsFunc(c.stackURLHosts, func(allowed string) bool {
+		return strings.EqualFold(allowed, host)
+	})
+}
+
+// dialStackFileHost opens the connections of the stack file downloads. The addresses of the hosts that are
+// not explicitly allowed are checked once resolved, just before connecting, so that a host name cannot
+// be used to reach an internal service.
+func (c *PortainerClient) dialStackFileHost(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: stackURLFetchTimeout}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !c.isAllowedStackURLHost(host) {
+		dialer.Control = checkPublicAddress
+	}
+
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// checkPublicAddress is a net.Dialer control function rejecting the connections to loopback, link-local,
+// private and unspecified addresses
+func checkPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %s", host)
+	}
+
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() || ip.IsUnspecified() {
+		return fmt.Errorf("address %s is not a public address, allow the host to reach it", host)
+	}
+
+	return nil
+}
+
+// fetchStackFile downloads a stack file, validating every redirect like the original URL
+func (c *PortainerClient) fetchStackFile(rawURL string) (string, error) {
+	httpClient := &http.Client{
+		Timeout: stackURLFetchTimeout,
+		// The connections are not sent through a proxy, the addresses they reach could not be checked otherwise
+		Transport: &http.Transport{
+			DialContext:         c.dialStackFileHost,
+			TLSHandshakeTimeout: 10 * time.Second,
+			DisableKeepAlives:   true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			return c.validateStackURL(req.URL.String())
+		},
+	}
+
+	resp, err := httpClient.Get(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch stack file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to fetch stack file: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxStackFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read stack file: %w", err)
+	}
+	if len(body) > maxStackFileSize {
+		return "", fmt.Errorf("stack file is larger than %d bytes", maxStackFileSize)
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateStackFromURL(t *testing.T) {
+	const composeFile = "services:\n  web:\n    image: nginx\n"
+	var srvURL string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/compose.yml":
+			_, _ = w.Write([]byte(composeFile))
+		case "/large.yml":
+			_, _ = w.Write([]byte(strings.Repeat("a", maxStackFileSize+1)))
+		case "/redirect":
+			http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+		case "/redirect-localhost":
+			http.Redirect(w, r, strings.Replace(srvURL, "127.0.0.1", "localhost", 1)+"/compose.yml", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+	localhostURL := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	tests := []struct {
+		name          string
+		composeURL    string
+		allowedHosts  []string
+		mockError     error
+		expectCreate  bool
+		expectedError string
+	}{
+		{
+			name:         "successful creation",
+			composeURL:   srv.URL + "/compose.yml",
+			allowedHosts: []string{"127.0.0.1"},
+			expectCreate: true,
+		},
+		{
+			name:         "allowed host",
+			composeURL:   srv.URL + "/compose.yml",
+			allowedHosts: []string{"example.com", "127.0.0.1"},
+			expectCreate: true,
+		},
+		{
+			name:          "loopback address",
+			composeURL:    srv.URL + "/compose.yml",
+			expectedError: "address 127.0.0.1 is not a public address",
+		},
+		{
+			name:          "host name resolving to a loopback address",
+			composeURL:    localhostURL + "/compose.yml",
+			expectedError: "is not a public address",
+		},
+		{
+			name:          "redirect to a host not allowed",
+			composeURL:    srv.URL + "/redirect-localhost",
+			allowedHosts:  []string{"127.0.0.1"},
+			expectedError: "host localhost is not allowed",
+		},
+		{
+			name:          "host not allowed",
+			composeURL:    srv.URL + "/compose.yml",
+			allowedHosts:  []string{"example.com"},
+			expectedError: "host 127.0.0.1 is not allowed",
+		},
+		{
+			name:          "unsupported scheme",
+			composeURL:    "file:///etc/passwd",
+			expectedError: "only http and https URLs are allowed",
+		},
+		{
+			name:          "missing host",
+			composeURL:    "http:///compose.yml",
+			expectedError: "missing host",
+		},
+		{
+			name:          "redirect to unsupported scheme",
+			composeURL:    srv.URL + "/redirect",
+			allowedHosts:  []string{"127.0.0.1"},
+			expectedError: "only http and https URLs are allowed",
+		},
+		{
+			name:          "file not found",
+			composeURL:    srv.URL + "/missing.yml",
+			allowedHosts:  []string{"127.0.0.1"},
+			expectedError: "unexpected status 404 Not Found",
+		},
+		{
+			name:          "file too large",
+			composeURL:    srv.URL + "/large.yml",
+			allowedHosts:  []string{"127.0.0.1"},
+			expectedError: "stack file is larger than",
+		},
+		{
+			name:          "create error",
+			composeURL:    srv.URL + "/compose.yml",
+			allowedHosts:  []string{"127.0.0.1"},
+			mockError:     errors.New("name already used"),
+			expectCreate:  true,
+			expectedError: "failed to create edge stack: name already used",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectCreate {
+				mockAPI.On("CreateEdgeStack", "web", composeFile, []int64{1, 2}).Return(int64(5), tt.mockError)
+			}
+
+			client := &PortainerClient{cli: mockAPI, stackURLHosts: tt.allowedHosts}
+
+			id, err := client.CreateStackFromURL("web", tt.composeURL, []int{1, 2})
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 5, id)
+			}
+			if !tt.expectCreate {
+				mockAPI.AssertNotCalled(t, "CreateEdgeStack", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckPublicAddress(t *testing.T) {
+	tests := []struct {
+		address     string
+		expectError bool
+	}{
+		{address: "93.184.216.34:443"},
+		{address: "[2606:4700::1111]:443"},
+		{address: "127.0.0.1:80", expectError: true},
+		{address: "[::1]:80", expectError: true},
+		{address: "10.0.0.5:80", expectError: true},
+		{address: "172.16.3.4:80", expectError: true},
+		{address: "192.168.1.10:80", expectError: true},
+		{address: "169.254.169.254:80", expectError: true},
+		{address: "[fe80::1]:80", expectError: true},
+		{address: "[fd00::1]:80", expectError: true},
+		{address: "0.0.0.0:80", expectError: true},
+		{address: "[::ffff:127.0.0.1]:80", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := checkPublicAddress("tcp", tt.address, nil)
+			if tt.expectError {
+				assert.ErrorContains(t, err, "is not a public address")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// TestStackFile validates a stack file against a Docker environment without deploying or persisting it.
+// Portainer does not expose a compose validation endpoint, so the test is a dry-run: the stack file
+// is parsed and the image of every service is looked up on the environment through the Docker proxy,
+// first in the local image store and then in its registry.
+//
+// A syntax valid stack file can still fail to deploy, the result only reports WouldDeploy when
+// the file is syntax valid and every service image is available. Other deploy time failures,
+// such as port conflicts or missing volumes, are not detected.
+//
+// Parameters:
+//   - file: The content of the stack file (docker-compose format)
+//   - environmentId: The ID of the Docker environment to test the stack file against
+//
+// Returns:
+//   - A StackTestResult describing the syntax check, the image checks and any issue found
+//   - An error if the environment cannot be inspected
+func (c *PortainerClient) TestStackFile(file string, environmentId int) (models.StackTestResult, error) {
+	endpoint, err := c.cli.GetEndpoint(int64(environmentId))
+	if err != nil {
+		return models.StackTestResult{}, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	environment := models.ConvertEndpointToEnvironment(endpoint)
+	if !models.IsDockerEnvironment(environment.Type) {
+		return models.StackTestResult{}, fmt.Errorf("environment %d is a %s environment, stack files can only be tested on Docker environments", environmentId, environment.Type)
+	}
+
+	result := models.StackTestResult{
+		EnvironmentID: environmentId,
+		Images:        []models.StackImageCheck{},
+		Issues:        []string{},
+	}
+
+	services, err := parseComposeServices(file)
+	if err != nil {
+		result.Issues = append(result.Issues, fmt.Sprintf("invalid stack file: %s", err))
+		return result, nil
+	}
+	result.SyntaxValid = true
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checked := make(map[string]models.StackImageCheck)
+	for _, name := range names {
+		image := services[name]
+		if image == "" {
+			result.Issues = append(result.Issues, fmt.Sprintf("service %s does not declare an image, images built from a Dockerfile cannot be checked", name))
+			continue
+		}
+
+		check, ok := checked[image]
+		if !ok {
+			check = c.checkImageAvailability(environmentId, image)
+			checked[image] = check
+		}
+		check.Service = name
+
+		if !check.Available {
+			result.Issues = append(result.Issues, fmt.Sprintf("image %s of service %s is not available: %s", image, name, check.Error))
+		}
+		result.Images = append(result.Images, check)
+	}
+
+	result.WouldDeploy = len(result.Issues) == 0
+
+	return result, nil
+}
+
+// checkImageAvailability looks up an image in the local image store of an environment,
+// then in its registry when the image is not present locally
+func (c *PortainerClient) checkImageAvailability(environmentId int, image string) models.StackImageCheck {
+	check := models.StackImageCheck{Image: image}
+
+	status, body, err := c.dockerGet(environmentId, "/images/"+image+"/json")
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	if status == http.StatusOK {
+		check.Available = true
+		check.Source = models.StackImageSourceLocal
+		return check
+	}
+	if status != http.StatusNotFound {
+		check.Error = fmt.Sprintf("failed to inspect image: unexpected status %d: %s", status, body)
+		return check
+	}
+
+	status, body, err = c.dockerGet(environmentId, "/distribution/"+image+"/json")
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	if status != http.StatusOK {
+		check.Error = fmt.Sprintf("image not found locally nor in its registry: %s", body)
+		return check
+	}
+
+	check.Available = true
+	check.Source = models.StackImageSourceRegistry
+	return check
+}
+
+// dockerGet sends a GET request to the Docker API of an environment through the Docker proxy
+// and returns the response status code and the trimmed response body
+func (c *PortainerClient) dockerGet(environmentId int, path string) (int, string, error) {
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodGet,
+		Path:          path,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to proxy docker request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read docker response: %w", err)
+	}
+
+	return resp.StatusCode, strings.TrimSpace(string(body)), nil
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTestStackFile(t *testing.T) {
+	const validStackFile = `services:
+  web:
+    image: nginx:latest
+  api:
+    image: myorg/api:1.2.0
+  cache:
+    image: redis:7
+`
+
+	// dockerResponses maps a Docker API path to the status code returned by the mock
+	dockerResponses := map[string]int{
+		"/images/nginx:latest/json":          http.StatusOK,
+		"/images/myorg/api:1.2.0/json":       http.StatusNotFound,
+		"/distribution/myorg/api:1.2.0/json": http.StatusOK,
+		"/images/redis:7/json":               http.StatusNotFound,
+		"/distribution/redis:7/json":         http.StatusUnauthorized,
+	}
+
+	tests := []struct {
+		name              string
+		file              string
+		endpointType      int64
+		mockEndpointErr   error
+		expected          models.StackTestResult
+		expectedError     bool
+		expectedErrorText string
+	}{
+		{
+			name: "image available in registry only",
+			file: `services:
+  web:
+    image: nginx:latest
+  api:
+    image: myorg/api:1.2.0
+`,
+			endpointType: 1,
+			expected: models.StackTestResult{
+				EnvironmentID: 1,
+				SyntaxValid:   true,
+				WouldDeploy:   true,
+				Images: []models.StackImageCheck{
+					{Service: "api", Image: "myorg/api:1.2.0", Available: true, Source: models.StackImageSourceRegistry},
+					{Service: "web", Image: "nginx:latest", Available: true, Source: models.StackImageSourceLocal},
+				},
+				Issues: []string{},
+			},
+		},
+		{
+			name:         "syntax valid but image unavailable",
+			file:         validStackFile,
+			endpointType: 2,
+			expected: models.StackTestResult{
+				EnvironmentID: 1,
+				SyntaxValid:   true,
+				WouldDeploy:   false,
+				Images: []models.StackImageCheck{
+					{Service: "api", Image: "myorg/api:1.2.0", Available: true, Source: models.StackImageSourceRegistry},
+					{Service: "cache", Image: "redis:7", Error: "image not found locally nor in its registry: registry error"},
+					{Service: "web", Image: "nginx:latest", Available: true, Source: models.StackImageSourceLocal},
+				},
+				Issues: []string{"image redis:7 of service cache is not available: image not found locally nor in its registry: registry error"},
+			},
+		},
+		{
+			name: "service without image",
+			file: `services:
+  web:
+    image: nginx:latest
+  worker:
+    build: ./worker
+`,
+			endpointType: 1,
+			expected: models.StackTestResult{
+				EnvironmentID: 1,
+				SyntaxValid:   true,
+				WouldDeploy:   false,
+				Images: []models.StackImageCheck{
+					{Service: "web", Image: "nginx:latest", Available: true, Source: models.StackImageSourceLocal},
+				},
+				Issues: []string{"service worker does not declare an image, images built from a Dockerfile cannot be checked"},
+			},
+		},
+		{
+			name:         "invalid syntax",
+			file:         "services: [",
+			endpointType: 1,
+			expected: models.StackTestResult{
+				EnvironmentID: 1,
+				SyntaxValid:   false,
+				WouldDeploy:   false,
+				Images:        []models.StackImageCheck{},
+			},
+		},
+		{
+			name:              "kubernetes environment",
+			file:              validStackFile,
+			endpointType:      5,
+			expectedError:     true,
+			expectedErrorText: "stack files can only be tested on Docker environments",
+		},
+		{
+			name:            "get endpoint error",
+			file:            validStackFile,
+			mockEndpointErr: errors.New("endpoint not found"),
+			expectedError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, tt.mockEndpointErr)
+			for path, status := range dockerResponses {
+				body := "{}"
+				if status == http.StatusUnauthorized {
+					body = "registry error"
+				}
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodGet && opts.APIPath == path
+				})).Return(&http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil).Maybe()
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			result, err := client.TestStackFile(tt.file, 1)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				if tt.expectedErrorText != "" {
+					assert.Contains(t, err.Error(), tt.expectedErrorText)
+				}
+				return
+			}
+			assert.NoError(t, err)
+
+			if !tt.expected.SyntaxValid {
+				assert.False(t, result.SyntaxValid)
+				assert.False(t, result.WouldDeploy)
+				assert.Len(t, result.Issues, 1)
+				assert.Contains(t, result.Issues[0], "invalid stack file")
+				return
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
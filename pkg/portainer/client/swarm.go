@@ -0,0 +1,291 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+)
+
+// dockerSwarmService is the subset of the Docker service response used to describe Swarm services
+type dockerSwarmService struct {
+	ID   string `json:"ID"`
+	Spec struct {
+		Name         string `json:"Name"`
+		TaskTemplate struct {
+			ContainerSpec struct {
+				Image string `json:"Image"`
+			} `json:"ContainerSpec"`
+		} `json:"TaskTemplate"`
+		Mode struct {
+			Replicated *struct {
+				Replicas *int `json:"Replicas"`
+			} `json:"Replicated"`
+			Global *struct{} `json:"Global"`
+		} `json:"Mode"`
+	} `json:"Spec"`
+	ServiceStatus *struct {
+		RunningTasks int `json:"RunningTasks"`
+		DesiredTasks int `json:"DesiredTasks"`
+	} `json:"ServiceStatus"`
+}
+
+// dockerSwarmTask is the subset of the Docker task response used to describe Swarm tasks
+type dockerSwarmTask struct {
+	ID        string `json:"ID"`
+	ServiceID string `json:"ServiceID"`
+	NodeID    string `json:"NodeID"`
+	Slot      int    `json:"Slot"`
+	UpdatedAt string `json:"UpdatedAt"`
+	Spec      struct {
+		ContainerSpec struct {
+			Image string `json:"Image"`
+		} `json:"ContainerSpec"`
+	} `json:"Spec"`
+	Status struct {
+		State   string `json:"State"`
+		Message string `json:"Message"`
+		Err     string `json:"Err"`
+	} `json:"Status"`
+	DesiredState string `json:"DesiredState"`
+}
+
+// ListSwarmServices lists the services of the Swarm cluster an environment belongs to.
+// The environment must be a Swarm manager.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//
+// Returns:
+//   - The services, sorted by name, with their requested replicas and their task counts
+//   - An error if the environment is not a Swarm manager or if the operation fails
+func (c *PortainerClient) ListSwarmServices(environmentId int) ([]models.SwarmService, error) {
+	if err := c.checkSwarmManager(environmentId); err != nil {
+		return nil, err
+	}
+
+	var rawServices []dockerSwarmService
+	err := c.getDockerJSON(environmentId, "/services", map[string]string{"status": "true"}, &rawServices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list swarm services: %w", err)
+	}
+
+	services := make([]models.SwarmService, len(rawServices))
+	for i, rawService := range rawServices {
+		services[i] = convertSwarmService(rawService)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	return services, nil
+}
+
+// ListSwarmTasks lists the tasks of a Swarm service.
+// The environment must be a Swarm manager.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - serviceId: The ID or the name of the service, an empty value lists the tasks of all the services
+//
+// Returns:
+//   - The tasks, sorted by service, slot and most recent update first, with their current and desired state
+//   - An error if the environment is not a Swarm manager or if the operation fails
+func (c *PortainerClient) ListSwarmTasks(environmentId int, serviceId string) ([]models.SwarmTask, error) {
+	if err := c.checkSwarmManager(environmentId); err != nil {
+		return nil, err
+	}
+
+	var queryParams map[string]string
+	if serviceId != "" {
+		filters, err := json.Marshal(map[string][]string{"service": {serviceId}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode task filters: %w", err)
+		}
+		queryParams = map[string]string{"filters": string(filters)}
+	}
+
+	var rawTasks []dockerSwarmTask
+	if err := c.getDockerJSON(environmentId, "/tasks", queryParams, &rawTasks); err != nil {
+		return nil, fmt.Errorf("failed to list swarm tasks: %w", err)
+	}
+
+	tasks := make([]models.SwarmTask, len(rawTasks))
+	for i, rawTask := range rawTasks {
+		tasks[i] = models.SwarmTask{
+			ID:           rawTask.ID,
+			ServiceID:    rawTask.ServiceID,
+			NodeID:       rawTask.NodeID,
+			Slot:         rawTask.Slot,
+			Image:        rawTask.Spec.ContainerSpec.Image,
+			State:        rawTask.Status.State,
+			DesiredState: rawTask.DesiredState,
+			Message:      rawTask.Status.Message,
+			Error:        rawTask.Status.Err,
+			UpdatedAt:    rawTask.UpdatedAt,
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].ServiceID != tasks[j].ServiceID {
+			return tasks[i].ServiceID < tasks[j].ServiceID
+		}
+		if tasks[i].Slot != tasks[j].Slot {
+			return tasks[i].Slot < tasks[j].Slot
+		}
+		return tasks[i].UpdatedAt > tasks[j].UpdatedAt
+	})
+
+	return tasks, nil
+}
+
+// UpdateSwarmService scales a replicated Swarm service and/or updates the image of its tasks.
+// The current version of the service is fetched automatically, as Docker requires it to
+// detect concurrent updates. The rest of the service specification is left unchanged.
+// The environment must be a Swarm manager.
+//
+// Parameters:
+//   - environmentId: The ID of the Docker environment
+//   - serviceId: The ID or the name of the service to update
+//   - opts: The changes to apply, at least one change is required
+//
+// Returns:
+//   - An error if the changes are invalid, if the environment is not a Swarm manager,
+//     or if Docker rejects the update (the Docker error message is included)
+func (c *PortainerClient) UpdateSwarmService(environmentId int, serviceId string, opts models.SwarmServiceUpdate) error {
+	if err := validateSwarmServiceUpdate(opts); err != nil {
+		return fmt.Errorf("invalid service update: %w", err)
+	}
+
+	if err := c.checkSwarmManager(environmentId); err != nil {
+		return err
+	}
+
+	// The specification is kept as a generic map so that the fields that are not updated are sent back unchanged
+	var current struct {
+		Version struct {
+			Index uint64 `json:"Index"`
+		} `json:"Version"`
+		Spec map[string]any `json:"Spec"`
+	}
+	if err := c.getDockerJSON(environmentId, "/services/"+url.PathEscape(serviceId), nil, &current); err != nil {
+		return fmt.Errorf("failed to get swarm service: %w", err)
+	}
+
+	if err := applySwarmServiceUpdate(current.Spec, opts); err != nil {
+		return fmt.Errorf("failed to update swarm service %s: %w", serviceId, err)
+	}
+
+	body, err := json.Marshal(current.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to encode swarm service spec: %w", err)
+	}
+
+	resp, err := c.ProxyDockerRequest(models.DockerProxyRequestOptions{
+		EnvironmentID: environmentId,
+		Method:        http.MethodPost,
+		Path:          "/services/" + url.PathEscape(serviceId) + "/update",
+		QueryParams:   map[string]string{"version": strconv.FormatUint(current.Version.Index, 10)},
+		Headers:       map[string]string{"Content-Type": "application/json"},
+		Body:          bytes.NewReader(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update swarm service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update swarm service: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// validateSwarmServiceUpdate checks that a service update contains at least one valid change
+func validateSwarmServiceUpdate(opts models.SwarmServiceUpdate) error {
+	if opts.Replicas == nil && opts.Image == nil {
+		return fmt.Errorf("at least one of replicas or image is required")
+	}
+
+	if opts.Replicas != nil && *opts.Replicas < 0 {
+		return fmt.Errorf("replicas cannot be negative")
+	}
+
+	if opts.Image != nil && strings.TrimSpace(*opts.Image) == "" {
+		return fmt.Errorf("image cannot be empty")
+	}
+
+	return nil
+}
+
+// applySwarmServiceUpdate applies a service update to a Docker service specification.
+// Only replicated services can be scaled.
+func applySwarmServiceUpdate(spec map[string]any, opts models.SwarmServiceUpdate) error {
+	if opts.Replicas != nil {
+		mode, _ := spec["Mode"].(map[string]any)
+		replicated, ok := mode["Replicated"].(map[string]any)
+		if !ok {
+			return fmt.Errorf("only replicated services can be scaled")
+		}
+		replicated["Replicas"] = *opts.Replicas
+	}
+
+	if opts.Image != nil {
+		taskTemplate, _ := spec["TaskTemplate"].(map[string]any)
+		containerSpec, ok := taskTemplate["ContainerSpec"].(map[string]any)
+		if !ok {
+			return fmt.Errorf("the service does not run containers")
+		}
+		containerSpec["Image"] = *opts.Image
+	}
+
+	return nil
+}
+
+// checkSwarmManager checks that the Docker daemon of an environment is a manager of an active Swarm cluster,
+// as only Swarm managers can inspect the services and tasks of the cluster
+func (c *PortainerClient) checkSwarmManager(environmentId int) error {
+	info, err := c.getDockerInfo(environmentId)
+	if err != nil {
+		return err
+	}
+
+	if info.Swarm.LocalNodeState != "active" || !info.Swarm.ControlAvailable {
+		return fmt.Errorf("environment %d is not a Swarm manager", environmentId)
+	}
+
+	return nil
+}
+
+// convertSwarmService converts a Docker service to a Swarm service
+func convertSwarmService(rawService dockerSwarmService) models.SwarmService {
+	service := models.SwarmService{
+		ID:    rawService.ID,
+		Name:  rawService.Spec.Name,
+		Image: rawService.Spec.TaskTemplate.ContainerSpec.Image,
+	}
+
+	switch {
+	case rawService.Spec.Mode.Global != nil:
+		service.Mode = models.SwarmServiceModeGlobal
+	case rawService.Spec.Mode.Replicated != nil:
+		service.Mode = models.SwarmServiceModeReplicated
+		service.Replicas = rawService.Spec.Mode.Replicated.Replicas
+	}
+
+	if rawService.ServiceStatus != nil {
+		service.RunningTasks = rawService.ServiceStatus.RunningTasks
+		service.DesiredTasks = rawService.ServiceStatus.DesiredTasks
+	}
+
+	return service
+}
@@ -0,0 +1,332 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	swarmManagerInfo = `{"Swarm":{"LocalNodeState":"active","ControlAvailable":true}}`
+	swarmWorkerInfo  = `{"Swarm":{"LocalNodeState":"active","ControlAvailable":false}}`
+	standaloneInfo   = `{"Swarm":{"LocalNodeState":"inactive","ControlAvailable":false}}`
+)
+
+// mockDockerGet registers a mocked Docker API GET request on an environment
+func mockDockerGet(mockAPI *MockPortainerAPI, environmentId int, path string, status int, body string) {
+	mockAPI.On("ProxyDockerRequest", environmentId, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.Method == http.MethodGet && opts.APIPath == path
+	})).Return(&http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil).Once()
+}
+
+func TestListSwarmServices(t *testing.T) {
+	replicas := 3
+
+	tests := []struct {
+		name              string
+		endpointType      int64
+		mockInfo          string
+		mockServices      string
+		mockStatus        int
+		expected          []models.SwarmService
+		expectedError     bool
+		expectedErrorText string
+	}{
+		{
+			name:         "successful listing",
+			endpointType: 2,
+			mockInfo:     swarmManagerInfo,
+			mockServices: `[
+				{"ID":"svc2","Spec":{"Name":"web","TaskTemplate":{"ContainerSpec":{"Image":"nginx:1.27"}},"Mode":{"Replicated":{"Replicas":3}}},"ServiceStatus":{"RunningTasks":2,"DesiredTasks":3}},
+				{"ID":"svc1","Spec":{"Name":"agent","TaskTemplate":{"ContainerSpec":{"Image":"portainer/agent:2.31.2"}},"Mode":{"Global":{}}},"ServiceStatus":{"RunningTasks":4,"DesiredTasks":4}}
+			]`,
+			mockStatus: http.StatusOK,
+			expected: []models.SwarmService{
+				{ID: "svc1", Name: "agent", Image: "portainer/agent:2.31.2", Mode: models.SwarmServiceModeGlobal, RunningTasks: 4, DesiredTasks: 4},
+				{ID: "svc2", Name: "web", Image: "nginx:1.27", Mode: models.SwarmServiceModeReplicated, Replicas: &replicas, RunningTasks: 2, DesiredTasks: 3},
+			},
+		},
+		{
+			name:              "swarm worker",
+			endpointType:      1,
+			mockInfo:          swarmWorkerInfo,
+			expectedError:     true,
+			expectedErrorText: "is not a Swarm manager",
+		},
+		{
+			name:              "standalone docker",
+			endpointType:      1,
+			mockInfo:          standaloneInfo,
+			expectedError:     true,
+			expectedErrorText: "is not a Swarm manager",
+		},
+		{
+			name:              "kubernetes environment",
+			endpointType:      5,
+			expectedError:     true,
+			expectedErrorText: "it has no Docker daemon",
+		},
+		{
+			name:              "docker error",
+			endpointType:      1,
+			mockInfo:          swarmManagerInfo,
+			mockServices:      "internal error",
+			mockStatus:        http.StatusInternalServerError,
+			expectedError:     true,
+			expectedErrorText: "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: tt.endpointType}, nil)
+			if tt.mockInfo != "" {
+				mockDockerGet(mockAPI, 1, "/info", http.StatusOK, tt.mockInfo)
+			}
+			if tt.mockServices != "" {
+				mockDockerGet(mockAPI, 1, "/services", tt.mockStatus, tt.mockServices)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			services, err := client.ListSwarmServices(1)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrorText)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, services)
+			}
+
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListSwarmTasks(t *testing.T) {
+	const mockTasks = `[
+		{"ID":"task3","ServiceID":"svc1","NodeID":"node2","Slot":2,"UpdatedAt":"2025-05-01T10:00:00Z","Spec":{"ContainerSpec":{"Image":"nginx:1.27"}},"Status":{"State":"running","Message":"started"},"DesiredState":"running"},
+		{"ID":"task1","ServiceID":"svc1","NodeID":"node1","Slot":1,"UpdatedAt":"2025-05-01T09:00:00Z","Spec":{"ContainerSpec":{"Image":"nginx:1.26"}},"Status":{"State":"failed","Message":"started","Err":"task: non-zero exit (1)"},"DesiredState":"shutdown"},
+		{"ID":"task2","ServiceID":"svc1","NodeID":"node1","Slot":1,"UpdatedAt":"2025-05-01T10:00:00Z","Spec":{"ContainerSpec":{"Image":"nginx:1.27"}},"Status":{"State":"running","Message":"started"},"DesiredState":"running"}
+	]`
+
+	tests := []struct {
+		name              string
+		serviceId         string
+		mockInfo          string
+		expectedFilters   string
+		expected          []models.SwarmTask
+		expectedError     bool
+		expectedErrorText string
+	}{
+		{
+			name:            "tasks of a service",
+			serviceId:       "svc1",
+			mockInfo:        swarmManagerInfo,
+			expectedFilters: `{"service":["svc1"]}`,
+			expected: []models.SwarmTask{
+				{ID: "task2", ServiceID: "svc1", NodeID: "node1", Slot: 1, Image: "nginx:1.27", State: "running", DesiredState: "running", Message: "started", UpdatedAt: "2025-05-01T10:00:00Z"},
+				{ID: "task1", ServiceID: "svc1", NodeID: "node1", Slot: 1, Image: "nginx:1.26", State: "failed", DesiredState: "shutdown", Message: "started", Error: "task: non-zero exit (1)", UpdatedAt: "2025-05-01T09:00:00Z"},
+				{ID: "task3", ServiceID: "svc1", NodeID: "node2", Slot: 2, Image: "nginx:1.27", State: "running", DesiredState: "running", Message: "started", UpdatedAt: "2025-05-01T10:00:00Z"},
+			},
+		},
+		{
+			name:              "not a swarm manager",
+			serviceId:         "svc1",
+			mockInfo:          standaloneInfo,
+			expectedError:     true,
+			expectedErrorText: "is not a Swarm manager",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 1}, nil)
+			mockDockerGet(mockAPI, 1, "/info", http.StatusOK, tt.mockInfo)
+			if tt.expectedFilters != "" {
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.APIPath == "/tasks" && opts.QueryParams["filters"] == tt.expectedFilters
+				})).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(mockTasks)),
+				}, nil).Once()
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			tasks, err := client.ListSwarmTasks(1, tt.serviceId)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrorText)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, tasks)
+			}
+
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateSwarmService(t *testing.T) {
+	const mockService = `{"ID":"svc1","Version":{"Index":42},"Spec":{"Name":"web","Labels":{"team":"ops"},
+		"TaskTemplate":{"ContainerSpec":{"Image":"nginx:1.26@sha256:abc","Env":["A=1"]},"RestartPolicy":{"Delay":123456789012345678}},
+		"Mode":{"Replicated":{"Replicas":2}}}}`
+	const mockGlobalService = `{"ID":"svc2","Version":{"Index":7},"Spec":{"Name":"agent",
+		"TaskTemplate":{"ContainerSpec":{"Image":"portainer/agent:2.31.2"}},"Mode":{"Global":{}}}}`
+
+	replicas := 5
+	zero := 0
+	negative := -1
+	image := "nginx:1.27"
+	empty := " "
+
+	tests := []struct {
+		name              string
+		opts              models.SwarmServiceUpdate
+		mockService       string
+		expectUpdate      bool
+		updateStatus      int
+		expectedSpec      string
+		expectedError     bool
+		expectedErrorText string
+	}{
+		{
+			name:         "scale and update image",
+			opts:         models.SwarmServiceUpdate{Replicas: &replicas, Image: &image},
+			mockService:  mockService,
+			expectUpdate: true,
+			updateStatus: http.StatusOK,
+			expectedSpec: `{"Labels":{"team":"ops"},"Mode":{"Replicated":{"Replicas":5}},"Name":"web",` +
+				`"TaskTemplate":{"ContainerSpec":{"Env":["A=1"],"Image":"nginx:1.27"},"RestartPolicy":{"Delay":123456789012345678}}}`,
+		},
+		{
+			name:         "scale to zero",
+			opts:         models.SwarmServiceUpdate{Replicas: &zero},
+			mockService:  mockService,
+			expectUpdate: true,
+			updateStatus: http.StatusOK,
+			expectedSpec: `{"Labels":{"team":"ops"},"Mode":{"Replicated":{"Replicas":0}},"Name":"web",` +
+				`"TaskTemplate":{"ContainerSpec":{"Env":["A=1"],"Image":"nginx:1.26@sha256:abc"},"RestartPolicy":{"Delay":123456789012345678}}}`,
+		},
+		{
+			name:              "scale global service",
+			opts:              models.SwarmServiceUpdate{Replicas: &replicas},
+			mockService:       mockGlobalService,
+			expectedError:     true,
+			expectedErrorText: "only replicated services can be scaled",
+		},
+		{
+			name:              "docker rejects the update",
+			opts:              models.SwarmServiceUpdate{Image: &image},
+			mockService:       mockService,
+			expectUpdate:      true,
+			updateStatus:      http.StatusBadRequest,
+			expectedError:     true,
+			expectedErrorText: "update out of sequence",
+		},
+		{
+			name:              "no change",
+			opts:              models.SwarmServiceUpdate{},
+			expectedError:     true,
+			expectedErrorText: "at least one of replicas or image is required",
+		},
+		{
+			name:              "negative replicas",
+			opts:              models.SwarmServiceUpdate{Replicas: &negative},
+			expectedError:     true,
+			expectedErrorText: "replicas cannot be negative",
+		},
+		{
+			name:              "empty image",
+			opts:              models.SwarmServiceUpdate{Image: &empty},
+			expectedError:     true,
+			expectedErrorText: "image cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.mockService != "" {
+				mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 2}, nil)
+				mockDockerGet(mockAPI, 1, "/info", http.StatusOK, swarmManagerInfo)
+				mockDockerGet(mockAPI, 1, "/services/svc1", http.StatusOK, tt.mockService)
+			}
+			if tt.expectUpdate {
+				respBody := `{"Warnings":null}`
+				if tt.updateStatus != http.StatusOK {
+					respBody = `{"message":"update out of sequence"}`
+				}
+				mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+					return opts.Method == http.MethodPost && opts.APIPath == "/services/svc1/update"
+				})).Run(func(args mock.Arguments) {
+					opts := args.Get(1).(client.ProxyRequestOptions)
+					assert.Equal(t, "42", opts.QueryParams["version"])
+					assert.Equal(t, "application/json", opts.Headers["Content-Type"])
+					if tt.expectedSpec != "" {
+						body, err := io.ReadAll(opts.Body)
+						assert.NoError(t, err)
+						assert.JSONEq(t, tt.expectedSpec, string(body))
+						assert.Contains(t, string(body), "123456789012345678", "large numbers should be sent back unchanged")
+					}
+				}).Return(&http.Response{
+					StatusCode: tt.updateStatus,
+					Body:       io.NopCloser(strings.NewReader(respBody)),
+				}, nil).Once()
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateSwarmService(1, "svc1", tt.opts)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErrorText)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateSwarmServiceEscapesServiceId(t *testing.T) {
+	const serviceId = "../containers/x/kill"
+	const escapedPath = "/services/..%2Fcontainers%2Fx%2Fkill"
+
+	mockAPI := new(MockPortainerAPI)
+	mockAPI.On("GetEndpoint", int64(1)).Return(&apimodels.PortainereeEndpoint{ID: 1, Type: 2}, nil)
+	mockDockerGet(mockAPI, 1, "/info", http.StatusOK, swarmManagerInfo)
+	mockDockerGet(mockAPI, 1, escapedPath, http.StatusOK, `{"ID":"svc1","Version":{"Index":42},"Spec":{"Name":"web",
+		"TaskTemplate":{"ContainerSpec":{"Image":"nginx:1.26"}},"Mode":{"Replicated":{"Replicas":2}}}}`)
+	mockAPI.On("ProxyDockerRequest", 1, mock.MatchedBy(func(opts client.ProxyRequestOptions) bool {
+		return opts.Method == http.MethodPost && opts.APIPath == escapedPath+"/update"
+	})).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"Warnings":null}`)),
+	}, nil).Once()
+
+	client := &PortainerClient{cli: mockAPI}
+
+	replicas := 3
+	err := client.UpdateSwarmService(1, serviceId, models.SwarmServiceUpdate{Replicas: &replicas})
+
+	assert.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
@@ -110,3 +110,88 @@ func (c *PortainerClient) UpdateTeamMembers(teamId int, userIds []int) error {
 
 	return nil
 }
+
+// UpdateTeamMemberships updates the members of a team and their role inside the team.
+// Team leaders can manage the members of their team in Portainer, regular members cannot.
+// Every role and user is validated before any change is made. Users of the team missing from
+// members are removed from it, the role of the other existing members is updated when it changes
+// and the new members are added with their role.
+//
+// Parameters:
+//   - teamId: The ID of the team to update
+//   - members: Map of user IDs to their role inside the team. Each role must be one of: leader, member
+//
+// Returns:
+//   - An error if the validation fails or if Portainer rejects a change, including the reason given by Portainer
+func (c *PortainerClient) UpdateTeamMemberships(teamId int, members map[int]string) error {
+	userIds := sortedAccessIDs(members)
+	for _, userId := range userIds {
+		if !models.IsValidTeamMembershipRole(members[userId]) {
+			return fmt.Errorf("invalid role %q for user %d: must be one of: %v", members[userId], userId, models.AllTeamMembershipRoles)
+		}
+	}
+
+	if err := c.validateUserIDs(userIds); err != nil {
+		return err
+	}
+
+	memberships, err := c.cli.ListTeamMemberships()
+	if err != nil {
+		return fmt.Errorf("failed to list team memberships: %w", err)
+	}
+
+	existingMembers := make(map[int]bool)
+	for _, membership := range memberships {
+		if membership.TeamID != int64(teamId) {
+			continue
+		}
+
+		userId := int(membership.UserID)
+		existingMembers[userId] = true
+
+		role, keep := members[userId]
+		if !keep {
+			if err := c.cli.DeleteTeamMembership(int(membership.ID)); err != nil {
+				return fmt.Errorf("failed to delete team membership for user %d: %w", userId, err)
+			}
+			continue
+		}
+
+		roleInt := convertTeamMembershipRole(role)
+		if membership.Role == roleInt {
+			continue
+		}
+
+		if err := c.cli.UpdateTeamMembershipRole(int(membership.ID), teamId, userId, roleInt); err != nil {
+			return teamMembershipRoleError(teamId, userId, role, err)
+		}
+	}
+
+	for _, userId := range userIds {
+		if existingMembers[userId] {
+			continue
+		}
+
+		if err := c.cli.CreateTeamMembershipWithRole(teamId, userId, convertTeamMembershipRole(members[userId])); err != nil {
+			return teamMembershipRoleError(teamId, userId, members[userId], err)
+		}
+	}
+
+	return nil
+}
+
+// convertTeamMembershipRole converts a team membership role to its Portainer value
+func convertTeamMembershipRole(role string) int64 {
+	if role == models.TeamMembershipRoleLeader {
+		return 1
+	}
+	return 2
+}
+
+// teamMembershipRoleError describes a failure to give a role to a user inside a team
+func teamMembershipRoleError(teamId, userId int, role string, err error) error {
+	if role == models.TeamMembershipRoleLeader {
+		return fmt.Errorf("failed to make user %d a leader of team %d: %w", userId, teamId, err)
+	}
+	return fmt.Errorf("failed to make user %d a member of team %d: %w", userId, teamId, err)
+}
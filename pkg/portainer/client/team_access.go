@@ -0,0 +1,286 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// GetTeamAccessOverview retrieves everywhere a team has access: the access groups (endpoint groups) and
+// the environments granting it an access level, the environments it can reach through them and the
+// environment groups (edge groups) of these environments. An access of the team set on an environment
+// replaces the access the team inherits from the access group of the environment.
+//
+// Parameters:
+//   - teamId: The ID of the team
+//
+// Returns:
+//   - The TeamAccessOverview of the team
+//   - An error if the team does not exist or if the operation fails
+func (c *PortainerClient) GetTeamAccessOverview(teamId int) (models.TeamAccessOverview, error) {
+	team, err := c.getTeam(teamId)
+	if err != nil {
+		return models.TeamAccessOverview{}, err
+	}
+
+	accessGroups, err := c.GetAccessGroups()
+	if err != nil {
+		return models.TeamAccessOverview{}, fmt.Errorf("failed to get access groups: %w", err)
+	}
+
+	environments, err := c.GetEnvironments()
+	if err != nil {
+		return models.TeamAccessOverview{}, err
+	}
+
+	environmentGroups, err := c.GetEnvironmentGroups()
+	if err != nil {
+		return models.TeamAccessOverview{}, err
+	}
+
+	return buildTeamAccessOverview(team, accessGroups, environments, environmentGroups), nil
+}
+
+// ApplyTeamAccess sets the accesses of a team on the environments and on the access groups to a desired
+// state, see TeamAccessDesiredState. Only the environments and access groups whose access for the team
+// changes are updated, the accesses of the other teams are kept. The desired state is validated before
+// anything is sent to Portainer, the updates are then sent one environment or access group at a time and
+// the ones sent before a failure are kept.
+//
+// Parameters:
+//   - teamId: The ID of the team
+//   - desired: The desired accesses of the team
+//
+// Returns:
+//   - The TeamAccessOverview of the team once the desired state is applied
+//   - An error if the desired state is invalid, if the team does not exist or if the operation fails
+func (c *PortainerClient) ApplyTeamAccess(teamId int, desired models.TeamAccessDesiredState) (models.TeamAccessOverview, error) {
+	if desired.EnvironmentAccesses == nil && desired.AccessGroupAccesses == nil {
+		return models.TeamAccessOverview{}, fmt.Errorf("invalid desired state: environment accesses or access group accesses are required")
+	}
+	if err := validateAccessLevels("environment", desired.EnvironmentAccesses); err != nil {
+		return models.TeamAccessOverview{}, fmt.Errorf("invalid desired state: %w", err)
+	}
+	if err := validateAccessLevels("access group", desired.AccessGroupAccesses); err != nil {
+		return models.TeamAccessOverview{}, fmt.Errorf("invalid desired state: %w", err)
+	}
+
+	team, err := c.getTeam(teamId)
+	if err != nil {
+		return models.TeamAccessOverview{}, err
+	}
+
+	accessGroups, err := c.GetAccessGroups()
+	if err != nil {
+		return models.TeamAccessOverview{}, fmt.Errorf("failed to get access groups: %w", err)
+	}
+
+	environments, err := c.GetEnvironments()
+	if err != nil {
+		return models.TeamAccessOverview{}, err
+	}
+
+	if err := validateTeamAccessTargets(desired, accessGroups, environments); err != nil {
+		return models.TeamAccessOverview{}, fmt.Errorf("invalid desired state: %w", err)
+	}
+
+	if desired.AccessGroupAccesses != nil {
+		for i, group := range accessGroups {
+			accesses, changed, err := withTeamAccess(group.TeamAccesses, teamId, desired.AccessGroupAccesses, group.ID)
+			if err != nil {
+				return models.TeamAccessOverview{}, fmt.Errorf("cannot update access group %d: %w", group.ID, err)
+			}
+			if !changed {
+				continue
+			}
+
+			tac := utils.IntToInt64Map(accesses)
+			if err := c.cli.UpdateEndpointGroup(int64(group.ID), nil, nil, &tac); err != nil {
+				return models.TeamAccessOverview{}, fmt.Errorf("failed to update the team accesses of access group %d: %w", group.ID, err)
+			}
+			accessGroups[i].TeamAccesses = accesses
+		}
+	}
+
+	if desired.EnvironmentAccesses != nil {
+		for i, environment := range environments {
+			accesses, changed, err := withTeamAccess(environment.TeamAccesses, teamId, desired.EnvironmentAccesses, environment.ID)
+			if err != nil {
+				return models.TeamAccessOverview{}, fmt.Errorf("cannot update environment %d: %w", environment.ID, err)
+			}
+			if !changed {
+				continue
+			}
+
+			tac := utils.IntToInt64Map(accesses)
+			if err := c.cli.UpdateEndpoint(int64(environment.ID), nil, nil, &tac); err != nil {
+				return models.TeamAccessOverview{}, fmt.Errorf("failed to update the team accesses of environment %d: %w", environment.ID, err)
+			}
+			environments[i].TeamAccesses = accesses
+		}
+	}
+
+	environmentGroups, err := c.GetEnvironmentGroups()
+	if err != nil {
+		return models.TeamAccessOverview{}, err
+	}
+
+	return buildTeamAccessOverview(team, accessGroups, environments, environmentGroups), nil
+}
+
+// getTeam retrieves a team by ID
+func (c *PortainerClient) getTeam(teamId int) (models.Team, error) {
+	teams, err := c.cli.ListTeams()
+	if err != nil {
+		return models.Team{}, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	for _, team := range teams {
+		if int(team.ID) == teamId {
+			return models.ConvertToTeam(team, nil), nil
+		}
+	}
+
+	return models.Team{}, fmt.Errorf("team %d not found", teamId)
+}
+
+// validateTeamAccessTargets checks that the environments and access groups of a desired state exist
+func validateTeamAccessTargets(desired models.TeamAccessDesiredState, accessGroups []models.AccessGroup, environments []models.Environment) error {
+	existingGroups := make(map[int]bool, len(accessGroups))
+	for _, group := range accessGroups {
+		existingGroups[group.ID] = true
+	}
+	for _, id := range sortedAccessIDs(desired.AccessGroupAccesses) {
+		if !existingGroups[id] {
+			return fmt.Errorf("access group %d does not exist", id)
+		}
+	}
+
+	existingEnvironments := make(map[int]bool, len(environments))
+	for _, environment := range environments {
+		existingEnvironments[environment.ID] = true
+	}
+	for _, id := range sortedAccessIDs(desired.EnvironmentAccesses) {
+		if !existingEnvironments[id] {
+			return fmt.Errorf("environment %d does not exist", id)
+		}
+	}
+
+	return nil
+}
+
+// withTeamAccess returns the team accesses of an environment or an access group once the access of a team
+// is set to its desired level, removed when the desired accesses have no entry for the target. It reports
+// whether the accesses changed. The accesses are sent back to Portainer as a whole, they cannot be changed
+// when another team has a policy with a role unknown to this client.
+func withTeamAccess(current map[int]string, teamId int, desired map[int]string, targetId int) (map[int]string, bool, error) {
+	level, wanted := desired[targetId]
+	currentLevel, exists := current[teamId]
+	if wanted == exists && level == currentLevel {
+		return current, false, nil
+	}
+
+	for _, id := range sortedAccessIDs(current) {
+		if id != teamId && current[id] == models.AccessLevelUnknown {
+			return nil, false, fmt.Errorf("team %d has an access policy with an unknown role", id)
+		}
+	}
+
+	accesses := maps.Clone(current)
+	if accesses == nil {
+		accesses = map[int]string{}
+	}
+	if wanted {
+		accesses[teamId] = level
+	} else {
+		delete(accesses, teamId)
+	}
+
+	return accesses, true, nil
+}
+
+// buildTeamAccessOverview resolves the accesses of a team on the access groups and the environments
+func buildTeamAccessOverview(team models.Team, accessGroups []models.AccessGroup, environments []models.Environment, environmentGroups []models.Group) models.TeamAccessOverview {
+	overview := models.TeamAccessOverview{
+		TeamID:            team.ID,
+		TeamName:          team.Name,
+		AccessGroups:      []models.TeamAccessGroupAccess{},
+		Environments:      []models.TeamEnvironmentAccess{},
+		EnvironmentGroups: []models.TeamEnvironmentGroupAccess{},
+	}
+
+	environmentGroupIds := make(map[int]int)
+	for _, group := range accessGroups {
+		for _, environmentId := range group.EnvironmentIds {
+			environmentGroupIds[environmentId] = group.ID
+		}
+
+		if level, ok := group.TeamAccesses[team.ID]; ok {
+			overview.AccessGroups = append(overview.AccessGroups, models.TeamAccessGroupAccess{
+				AccessGroupID:   group.ID,
+				AccessGroupName: group.Name,
+				AccessLevel:     level,
+				EnvironmentIds:  group.EnvironmentIds,
+			})
+		}
+	}
+
+	groupLevels := make(map[int]string, len(overview.AccessGroups))
+	for _, access := range overview.AccessGroups {
+		groupLevels[access.AccessGroupID] = access.AccessLevel
+	}
+
+	reachable := make(map[int]bool)
+	for _, environment := range environments {
+		access := models.TeamEnvironmentAccess{
+			EnvironmentID:   environment.ID,
+			EnvironmentName: environment.Name,
+			AccessGroupID:   environmentGroupIds[environment.ID],
+		}
+
+		if level, ok := environment.TeamAccesses[team.ID]; ok {
+			access.AccessLevel, access.Source = level, models.TeamAccessSourceEnvironment
+		} else if level, ok := groupLevels[access.AccessGroupID]; ok {
+			access.AccessLevel, access.Source = level, models.TeamAccessSourceAccessGroup
+		} else {
+			continue
+		}
+
+		overview.Environments = append(overview.Environments, access)
+		reachable[environment.ID] = true
+	}
+
+	for _, group := range environmentGroups {
+		access := models.TeamEnvironmentGroupAccess{
+			GroupID:           group.ID,
+			GroupName:         group.Name,
+			EnvironmentIds:    []int{},
+			TotalEnvironments: len(group.EnvironmentIds),
+		}
+		for _, environmentId := range group.EnvironmentIds {
+			if reachable[environmentId] {
+				access.EnvironmentIds = append(access.EnvironmentIds, environmentId)
+			}
+		}
+
+		if len(access.EnvironmentIds) > 0 {
+			sort.Ints(access.EnvironmentIds)
+			overview.EnvironmentGroups = append(overview.EnvironmentGroups, access)
+		}
+	}
+
+	sort.Slice(overview.AccessGroups, func(i, j int) bool {
+		return overview.AccessGroups[i].AccessGroupID < overview.AccessGroups[j].AccessGroupID
+	})
+	sort.Slice(overview.Environments, func(i, j int) bool {
+		return overview.Environments[i].EnvironmentID < overview.Environments[j].EnvironmentID
+	})
+	sort.Slice(overview.EnvironmentGroups, func(i, j int) bool {
+		return overview.EnvironmentGroups[i].GroupID < overview.EnvironmentGroups[j].GroupID
+	})
+
+	return overview
+}
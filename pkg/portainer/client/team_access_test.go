@@ -0,0 +1,218 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTeamAccess sets up a team devs (ID 5) with an operator access on the production access group,
+// overridden with a read-only access on the prod-eu environment, and an administrator access on the
+// staging environment of the Unassigned access group
+func mockTeamAccess(mockAPI *MockPortainerAPI) {
+	mockAPI.On("ListTeams").Return([]*apimodels.PortainerTeam{{ID: 5, Name: "devs"}, {ID: 6, Name: "ops"}}, nil)
+	mockAPI.On("ListEndpointGroups").Return([]*apimodels.PortainerEndpointGroup{
+		{ID: 1, Name: "Unassigned"},
+		{
+			ID:   2,
+			Name: "production",
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+				"5": apimodels.PortainerAccessPolicy{RoleID: 5}, // operator_user
+				"6": apimodels.PortainerAccessPolicy{RoleID: 1}, // environment_administrator
+			},
+		},
+	}, nil)
+	mockAPI.On("ListEndpoints").Return([]*apimodels.PortainereeEndpoint{
+		{
+			ID:      4,
+			Name:    "prod-eu",
+			GroupID: 2,
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+				"5": apimodels.PortainerAccessPolicy{RoleID: 4}, // readonly_user
+			},
+		},
+		{ID: 3, Name: "prod-us", GroupID: 2},
+		{
+			ID:      7,
+			Name:    "staging",
+			GroupID: 1,
+			TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+				"5": apimodels.PortainerAccessPolicy{RoleID: 1}, // environment_administrator
+			},
+		},
+		{ID: 8, Name: "lab", GroupID: 1},
+	}, nil)
+	mockAPI.On("ListEdgeGroups").Return([]*apimodels.EdgegroupsDecoratedEdgeGroup{
+		{ID: 10, Name: "europe", Endpoints: []int64{4, 8}},
+		{ID: 11, Name: "lab", Endpoints: []int64{8}},
+	}, nil).Maybe()
+}
+
+func TestGetTeamAccessOverview(t *testing.T) {
+	t.Run("team with accesses", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockTeamAccess(mockAPI)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		overview, err := client.GetTeamAccessOverview(5)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.TeamAccessOverview{
+			TeamID:   5,
+			TeamName: "devs",
+			AccessGroups: []models.TeamAccessGroupAccess{
+				{AccessGroupID: 2, AccessGroupName: "production", AccessLevel: models.AccessLevelOperatorUser, EnvironmentIds: []int{4, 3}},
+			},
+			Environments: []models.TeamEnvironmentAccess{
+				{EnvironmentID: 3, EnvironmentName: "prod-us", AccessLevel: models.AccessLevelOperatorUser, Source: models.TeamAccessSourceAccessGroup, AccessGroupID: 2},
+				{EnvironmentID: 4, EnvironmentName: "prod-eu", AccessLevel: models.AccessLevelReadonlyUser, Source: models.TeamAccessSourceEnvironment, AccessGroupID: 2},
+				{EnvironmentID: 7, EnvironmentName: "staging", AccessLevel: models.AccessLevelEnvironmentAdmin, Source: models.TeamAccessSourceEnvironment, AccessGroupID: 1},
+			},
+			EnvironmentGroups: []models.TeamEnvironmentGroupAccess{
+				{GroupID: 10, GroupName: "europe", EnvironmentIds: []int{4}, TotalEnvironments: 2},
+			},
+		}, overview)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListTeams").Return([]*apimodels.PortainerTeam{{ID: 5, Name: "devs"}}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetTeamAccessOverview(9)
+
+		assert.ErrorContains(t, err, "team 9 not found")
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListTeams").Return(nil, errors.New("connection refused"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetTeamAccessOverview(5)
+
+		assert.ErrorContains(t, err, "failed to list teams")
+	})
+}
+
+func TestApplyTeamAccess(t *testing.T) {
+	tests := []struct {
+		name                string
+		desired             models.TeamAccessDesiredState
+		expectedGroupUpdate *map[int64]string
+		expectedEnvUpdates  map[int64]*map[int64]string
+		expectedEnvironment []int
+		expectedError       string
+	}{
+		{
+			name: "environments and access groups",
+			desired: models.TeamAccessDesiredState{
+				EnvironmentAccesses: map[int]string{4: models.AccessLevelReadonlyUser, 8: models.AccessLevelStandardUser},
+				AccessGroupAccesses: map[int]string{},
+			},
+			// The access of devs on production is removed, the one of ops is kept
+			expectedGroupUpdate: &map[int64]string{6: models.AccessLevelEnvironmentAdmin},
+			expectedEnvUpdates: map[int64]*map[int64]string{
+				7: {},
+				8: {5: models.AccessLevelStandardUser},
+			},
+			expectedEnvironment: []int{4, 8},
+		},
+		{
+			name: "already in sync",
+			desired: models.TeamAccessDesiredState{
+				AccessGroupAccesses: map[int]string{2: models.AccessLevelOperatorUser},
+			},
+			expectedEnvironment: []int{3, 4, 7},
+		},
+		{
+			name:          "nothing managed",
+			desired:       models.TeamAccessDesiredState{},
+			expectedError: "environment accesses or access group accesses are required",
+		},
+		{
+			name: "invalid access level",
+			desired: models.TeamAccessDesiredState{
+				EnvironmentAccesses: map[int]string{4: "owner"},
+			},
+			expectedError: `invalid access level "owner" for environment 4`,
+		},
+		{
+			name: "unknown environment",
+			desired: models.TeamAccessDesiredState{
+				EnvironmentAccesses: map[int]string{99: models.AccessLevelReadonlyUser},
+			},
+			expectedError: "environment 99 does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockTeamAccess(mockAPI)
+			if tt.expectedGroupUpdate != nil {
+				mockAPI.On("UpdateEndpointGroup", int64(2), (*string)(nil), (*map[int64]string)(nil), tt.expectedGroupUpdate).Return(nil)
+			}
+			for id, accesses := range tt.expectedEnvUpdates {
+				mockAPI.On("UpdateEndpoint", id, (*[]int64)(nil), (*map[int64]string)(nil), accesses).Return(nil)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			overview, err := client.ApplyTeamAccess(5, tt.desired)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				mockAPI.AssertNotCalled(t, "UpdateEndpointGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+
+			environmentIds := make([]int, len(overview.Environments))
+			for i, access := range overview.Environments {
+				environmentIds[i] = access.EnvironmentID
+			}
+			assert.Equal(t, tt.expectedEnvironment, environmentIds)
+			if tt.expectedGroupUpdate == nil && tt.expectedEnvUpdates == nil {
+				mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				mockAPI.AssertNotCalled(t, "UpdateEndpointGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unknown role of another team", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("ListTeams").Return([]*apimodels.PortainerTeam{{ID: 5, Name: "devs"}}, nil)
+		mockAPI.On("ListEndpointGroups").Return([]*apimodels.PortainerEndpointGroup{{ID: 1, Name: "Unassigned"}}, nil)
+		mockAPI.On("ListEndpoints").Return([]*apimodels.PortainereeEndpoint{
+			{
+				ID:      4,
+				Name:    "prod-eu",
+				GroupID: 1,
+				TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{
+					"6": apimodels.PortainerAccessPolicy{RoleID: 42},
+				},
+			},
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.ApplyTeamAccess(5, models.TeamAccessDesiredState{
+			EnvironmentAccesses: map[int]string{4: models.AccessLevelReadonlyUser},
+		})
+
+		assert.ErrorContains(t, err, "team 6 has an access policy with an unknown role")
+		mockAPI.AssertNotCalled(t, "UpdateEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
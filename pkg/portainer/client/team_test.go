@@ -7,6 +7,7 @@ import (
 	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetTeams(t *testing.T) {
@@ -338,3 +339,114 @@ func TestUpdateTeamMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTeamMemberships(t *testing.T) {
+	mockUsers := []*apimodels.PortainereeUser{{ID: 100}, {ID: 101}, {ID: 102}, {ID: 103}}
+	mockMemberships := []*apimodels.PortainerTeamMembership{
+		{ID: 1, TeamID: 1, UserID: 100, Role: 2},
+		{ID: 2, TeamID: 1, UserID: 101, Role: 2},
+		{ID: 3, TeamID: 1, UserID: 102, Role: 1},
+		{ID: 4, TeamID: 2, UserID: 103, Role: 1},
+	}
+
+	tests := []struct {
+		name           string
+		members        map[int]string
+		expectUsers    bool
+		expectList     bool
+		expectDeletes  []int
+		expectUpdates  map[int]int64
+		expectCreates  map[int]int64
+		mockUpdateErr  error
+		mockCreateErr  error
+		expectedErrMsg string
+	}{
+		{
+			name:          "promote, demote, remove and add members",
+			members:       map[int]string{101: models.TeamMembershipRoleLeader, 102: models.TeamMembershipRoleMember, 103: models.TeamMembershipRoleLeader},
+			expectUsers:   true,
+			expectList:    true,
+			expectDeletes: []int{1},
+			expectUpdates: map[int]int64{2: 1, 3: 2},
+			expectCreates: map[int]int64{103: 1},
+		},
+		{
+			name:        "no changes needed",
+			members:     map[int]string{100: models.TeamMembershipRoleMember, 101: models.TeamMembershipRoleMember, 102: models.TeamMembershipRoleLeader},
+			expectUsers: true,
+			expectList:  true,
+		},
+		{
+			name:           "invalid role",
+			members:        map[int]string{101: "owner"},
+			expectedErrMsg: `invalid role "owner" for user 101`,
+		},
+		{
+			name:           "unknown user",
+			members:        map[int]string{999: models.TeamMembershipRoleLeader},
+			expectUsers:    true,
+			expectedErrMsg: "user 999 does not exist",
+		},
+		{
+			name:           "leader promotion rejected",
+			members:        map[int]string{100: models.TeamMembershipRoleMember, 101: models.TeamMembershipRoleLeader, 102: models.TeamMembershipRoleLeader},
+			expectUsers:    true,
+			expectList:     true,
+			expectUpdates:  map[int]int64{2: 1},
+			mockUpdateErr:  errors.New("portainer API error (status 403): Permission denied to update the membership"),
+			expectedErrMsg: "failed to make user 101 a leader of team 1: portainer API error (status 403): Permission denied to update the membership",
+		},
+		{
+			name:           "new leader rejected",
+			members:        map[int]string{100: models.TeamMembershipRoleMember, 101: models.TeamMembershipRoleMember, 102: models.TeamMembershipRoleLeader, 103: models.TeamMembershipRoleLeader},
+			expectUsers:    true,
+			expectList:     true,
+			expectCreates:  map[int]int64{103: 1},
+			mockCreateErr:  errors.New("portainer API error (status 409): Team membership already registered"),
+			expectedErrMsg: "failed to make user 103 a leader of team 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			if tt.expectUsers {
+				mockAPI.On("ListUsers").Return(mockUsers, nil)
+			}
+			if tt.expectList {
+				mockAPI.On("ListTeamMemberships").Return(mockMemberships, nil)
+			}
+			for _, id := range tt.expectDeletes {
+				mockAPI.On("DeleteTeamMembership", id).Return(nil)
+			}
+			for _, membership := range mockMemberships {
+				if role, ok := tt.expectUpdates[int(membership.ID)]; ok {
+					mockAPI.On("UpdateTeamMembershipRole", int(membership.ID), 1, int(membership.UserID), role).Return(tt.mockUpdateErr)
+				}
+			}
+			for userId, role := range tt.expectCreates {
+				mockAPI.On("CreateTeamMembershipWithRole", 1, userId, role).Return(tt.mockCreateErr)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateTeamMemberships(1, tt.members)
+
+			if tt.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, tt.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.expectList {
+				mockAPI.AssertNotCalled(t, "ListTeamMemberships")
+			}
+			if len(tt.expectUpdates) == 0 {
+				mockAPI.AssertNotCalled(t, "UpdateTeamMembershipRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if len(tt.expectCreates) == 0 {
+				mockAPI.AssertNotCalled(t, "CreateTeamMembershipWithRole", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
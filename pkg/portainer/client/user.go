@@ -2,6 +2,8 @@ package client
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 )
@@ -25,6 +27,21 @@ func (c *PortainerClient) GetUsers() ([]models.User, error) {
 	return users, nil
 }
 
+// GetCurrentUser retrieves the user the API token of the client belongs to, with its role and the
+// Portainer-wide authorizations granted to it.
+//
+// Returns:
+//   - A CurrentUser object describing the user
+//   - An error if the operation fails
+func (c *PortainerClient) GetCurrentUser() (models.CurrentUser, error) {
+	user, err := c.cli.GetCurrentUser()
+	if err != nil {
+		return models.CurrentUser{}, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	return models.ConvertToCurrentUser(user), nil
+}
+
 // UpdateUserRole updates the role of a user.
 //
 // Parameters:
@@ -42,6 +59,116 @@ func (c *PortainerClient) UpdateUserRole(id int, role string) error {
 	return c.cli.UpdateUserRole(id, roleInt)
 }
 
+// UpdateUserRolesBulk updates the role of several users.
+// Every role and user ID is validated before any change is made, so an invalid entry never leaves
+// the batch half-applied. The updates are then applied one user at a time in ascending ID order
+// and a failing update does not prevent the remaining ones from being applied.
+//
+// Parameters:
+//   - updates: Map of user IDs to their new role. Each role must be one of: admin, user, edge_admin
+//
+// Returns:
+//   - A *UserRolesUpdateError listing the updated and failed users if some updates failed
+//   - An error if the validation fails
+func (c *PortainerClient) UpdateUserRolesBulk(updates map[int]string) error {
+	ids := sortedAccessIDs(updates)
+
+	roles := make(map[int]int64, len(updates))
+	for _, id := range ids {
+		roles[id] = convertRole(updates[id])
+		if roles[id] == 0 {
+			return fmt.Errorf("invalid role %q for user %d: must be admin, user or edge_admin", updates[id], id)
+		}
+	}
+
+	if err := c.validateUserIDs(ids); err != nil {
+		return err
+	}
+
+	result := &UserRolesUpdateError{
+		Updated: []int{},
+		Failed:  map[int]error{},
+	}
+	for _, id := range ids {
+		if err := c.cli.UpdateUserRole(id, roles[id]); err != nil {
+			result.Failed[id] = err
+			continue
+		}
+		result.Updated = append(result.Updated, id)
+	}
+
+	if len(result.Failed) > 0 {
+		return result
+	}
+
+	return nil
+}
+
+// GetUserPreferences retrieves the UI preferences of a user.
+//
+// Parameters:
+//   - userId: The ID of the user
+//
+// Returns:
+//   - The theme and the UI settings of the user
+//   - An error if the operation fails
+func (c *PortainerClient) GetUserPreferences(userId int) (models.UserPreferences, error) {
+	user, err := c.cli.GetUser(userId)
+	if err != nil {
+		return models.UserPreferences{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return models.ConvertToUserPreferences(user), nil
+}
+
+// UpdateUserPreferences updates the UI preferences of a user. The provided preferences are applied
+// on top of the current ones: an empty theme or a nil flag keeps the current value. Nothing is sent
+// when the preferences are already up to date.
+//
+// Parameters:
+//   - userId: The ID of the user to update
+//   - prefs: The preferences to apply. The theme must be one of: auto, light, dark, highcontrast
+//
+// Returns:
+//   - An error if the theme is invalid or if the operation fails
+func (c *PortainerClient) UpdateUserPreferences(userId int, prefs models.UserPreferences) error {
+	if prefs.Theme == "" && prefs.SubtleUpgradeButton == nil && prefs.UseCache == nil {
+		return fmt.Errorf("at least one preference is required")
+	}
+
+	if prefs.Theme != "" && !slices.Contains(models.AllThemes, prefs.Theme) {
+		return fmt.Errorf("invalid theme %q: must be one of %s", prefs.Theme, strings.Join(models.AllThemes, ", "))
+	}
+
+	user, err := c.cli.GetUser(userId)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	current := models.ConvertToUserPreferences(user)
+	theme, subtleUpgradeButton, useCache := current.Theme, *current.SubtleUpgradeButton, *current.UseCache
+	if prefs.Theme != "" {
+		theme = prefs.Theme
+	}
+	if prefs.SubtleUpgradeButton != nil {
+		subtleUpgradeButton = *prefs.SubtleUpgradeButton
+	}
+	if prefs.UseCache != nil {
+		useCache = *prefs.UseCache
+	}
+
+	if theme == current.Theme && subtleUpgradeButton == *current.SubtleUpgradeButton && useCache == *current.UseCache {
+		return nil
+	}
+
+	err = c.cli.UpdateUserPreferences(userId, theme, subtleUpgradeButton, useCache)
+	if err != nil {
+		return fmt.Errorf("failed to update user preferences: %w", err)
+	}
+
+	return nil
+}
+
 func convertRole(role string) int64 {
 	switch role {
 	case models.UserRoleAdmin:
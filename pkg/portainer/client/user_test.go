@@ -7,6 +7,7 @@ import (
 	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 	"github.com/portainer/portainer-mcp/pkg/portainer/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetUsers(t *testing.T) {
@@ -96,6 +97,67 @@ func TestGetUsers(t *testing.T) {
 	}
 }
 
+func TestGetCurrentUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockUser      *apimodels.PortainereeUser
+		mockError     error
+		expected      models.CurrentUser
+		expectedError bool
+	}{
+		{
+			name: "admin user",
+			mockUser: &apimodels.PortainereeUser{
+				ID:       1,
+				Username: "admin",
+				Role:     1,
+			},
+			expected: models.CurrentUser{
+				User:           models.User{ID: 1, Username: "admin", Role: models.UserRoleAdmin},
+				IsAdmin:        true,
+				Authorizations: []string{},
+			},
+		},
+		{
+			name: "regular user",
+			mockUser: &apimodels.PortainereeUser{
+				ID:                      2,
+				Username:                "operator",
+				Role:                    2,
+				PortainerAuthorizations: apimodels.PortainerAuthorizations{"PortainerUserListToken": true},
+			},
+			expected: models.CurrentUser{
+				User:           models.User{ID: 2, Username: "operator", Role: models.UserRoleUser},
+				Authorizations: []string{"PortainerUserListToken"},
+			},
+		},
+		{
+			name:          "get error",
+			mockError:     errors.New("invalid JWT token"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetCurrentUser").Return(tt.mockUser, tt.mockError)
+
+			client := &PortainerClient{cli: mockAPI}
+
+			user, err := client.GetCurrentUser()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, user)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUpdateUserRole(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -159,3 +221,224 @@ func TestUpdateUserRole(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateUserRolesBulk(t *testing.T) {
+	tests := []struct {
+		name            string
+		updates         map[int]string
+		mockListError   error
+		mockUpdates     map[int]int64
+		mockErrors      map[int]error
+		expectedError   bool
+		expectedUpdated []int
+		expectedFailed  []int
+	}{
+		{
+			name: "successful update",
+			updates: map[int]string{
+				1: models.UserRoleAdmin,
+				2: models.UserRoleUser,
+				3: models.UserRoleEdgeAdmin,
+			},
+			mockUpdates: map[int]int64{1: 1, 2: 2, 3: 3},
+		},
+		{
+			name:    "empty updates",
+			updates: map[int]string{},
+		},
+		{
+			name: "invalid role applies nothing",
+			updates: map[int]string{
+				1: models.UserRoleAdmin,
+				2: "superuser",
+			},
+			expectedError: true,
+		},
+		{
+			name: "unknown user applies nothing",
+			updates: map[int]string{
+				1:  models.UserRoleAdmin,
+				99: models.UserRoleUser,
+			},
+			expectedError: true,
+		},
+		{
+			name: "list users error",
+			updates: map[int]string{
+				1: models.UserRoleAdmin,
+			},
+			mockListError: errors.New("failed to list users"),
+			expectedError: true,
+		},
+		{
+			name: "partial failure",
+			updates: map[int]string{
+				1: models.UserRoleAdmin,
+				2: models.UserRoleUser,
+				3: models.UserRoleUser,
+			},
+			mockUpdates:     map[int]int64{1: 1, 2: 2, 3: 2},
+			mockErrors:      map[int]error{2: errors.New("user is the last administrator")},
+			expectedError:   true,
+			expectedUpdated: []int{1, 3},
+			expectedFailed:  []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("ListUsers").Return(existingUsers, tt.mockListError).Maybe()
+			for id, role := range tt.mockUpdates {
+				mockAPI.On("UpdateUserRole", id, role).Return(tt.mockErrors[id])
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateUserRolesBulk(tt.updates)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				if len(tt.mockUpdates) == 0 {
+					mockAPI.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything)
+				}
+				if tt.expectedFailed != nil {
+					var updateErr *UserRolesUpdateError
+					assert.ErrorAs(t, err, &updateErr)
+					assert.Equal(t, tt.expectedUpdated, updateErr.Updated)
+					for _, id := range tt.expectedFailed {
+						assert.ErrorIs(t, err, tt.mockErrors[id])
+						assert.Contains(t, err.Error(), tt.mockErrors[id].Error())
+					}
+					mockAPI.AssertExpectations(t)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetUserPreferences(t *testing.T) {
+	t.Run("preferences set", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetUser", 2).Return(&apimodels.PortainereeUser{
+			ID:            2,
+			ThemeSettings: &apimodels.PortainereeUserThemeSettings{Color: "dark"},
+			UseCache:      true,
+		}, nil)
+
+		client := &PortainerClient{cli: mockAPI}
+
+		prefs, err := client.GetUserPreferences(2)
+
+		enabled, disabled := true, false
+		assert.NoError(t, err)
+		assert.Equal(t, models.UserPreferences{Theme: models.ThemeDark, SubtleUpgradeButton: &disabled, UseCache: &enabled}, prefs)
+	})
+
+	t.Run("get user error", func(t *testing.T) {
+		mockAPI := new(MockPortainerAPI)
+		mockAPI.On("GetUser", 2).Return(nil, errors.New("user not found"))
+
+		client := &PortainerClient{cli: mockAPI}
+
+		_, err := client.GetUserPreferences(2)
+
+		assert.ErrorContains(t, err, "failed to get user: user not found")
+	})
+}
+
+func TestUpdateUserPreferences(t *testing.T) {
+	currentUser := &apimodels.PortainereeUser{
+		ID:            2,
+		ThemeSettings: &apimodels.PortainereeUserThemeSettings{Color: "light", SubtleUpgradeButton: true},
+		UseCache:      true,
+	}
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name                        string
+		input                       models.UserPreferences
+		expectUpdate                bool
+		expectedTheme               string
+		expectedSubtleUpgradeButton bool
+		expectedUseCache            bool
+		mockUpdateError             error
+		expectedError               string
+	}{
+		{
+			name:                        "update every preference",
+			input:                       models.UserPreferences{Theme: models.ThemeHighContrast, SubtleUpgradeButton: &disabled, UseCache: &disabled},
+			expectUpdate:                true,
+			expectedTheme:               models.ThemeHighContrast,
+			expectedSubtleUpgradeButton: false,
+			expectedUseCache:            false,
+		},
+		{
+			name:                        "theme only keeps the current flags",
+			input:                       models.UserPreferences{Theme: models.ThemeDark},
+			expectUpdate:                true,
+			expectedTheme:               models.ThemeDark,
+			expectedSubtleUpgradeButton: true,
+			expectedUseCache:            true,
+		},
+		{
+			name:                        "flag only keeps the current theme",
+			input:                       models.UserPreferences{UseCache: &disabled},
+			expectUpdate:                true,
+			expectedTheme:               models.ThemeLight,
+			expectedSubtleUpgradeButton: true,
+			expectedUseCache:            false,
+		},
+		{
+			name:  "already up to date",
+			input: models.UserPreferences{Theme: models.ThemeLight, UseCache: &enabled},
+		},
+		{
+			name:          "nothing to update",
+			input:         models.UserPreferences{},
+			expectedError: "at least one preference is required",
+		},
+		{
+			name:          "invalid theme",
+			input:         models.UserPreferences{Theme: "purple"},
+			expectedError: `invalid theme "purple": must be one of auto, light, dark, highcontrast`,
+		},
+		{
+			name:                        "update error",
+			input:                       models.UserPreferences{Theme: models.ThemeAuto},
+			expectUpdate:                true,
+			expectedTheme:               models.ThemeAuto,
+			expectedSubtleUpgradeButton: true,
+			expectedUseCache:            true,
+			mockUpdateError:             errors.New("forbidden"),
+			expectedError:               "failed to update user preferences: forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := new(MockPortainerAPI)
+			mockAPI.On("GetUser", 2).Return(currentUser, nil).Maybe()
+			if tt.expectUpdate {
+				mockAPI.On("UpdateUserPreferences", 2, tt.expectedTheme, tt.expectedSubtleUpgradeButton, tt.expectedUseCache).Return(tt.mockUpdateError)
+			}
+
+			client := &PortainerClient{cli: mockAPI}
+
+			err := client.UpdateUserPreferences(2, tt.input)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tt.expectUpdate {
+				mockAPI.AssertNotCalled(t, "UpdateUserPreferences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -28,3 +28,19 @@ func ConvertEndpointGroupToAccessGroup(rawGroup *apimodels.PortainerEndpointGrou
 		TeamAccesses:   convertAccesses(rawGroup.TeamAccessPolicies),
 	}
 }
+
+// AccessGroupUpdate describes the changes to apply to an access group.
+// Nil fields are left unchanged. An empty, non-nil field means the access group should have
+// no environments or no accesses.
+type AccessGroupUpdate struct {
+	Name *string
+	// EnvironmentIds is the complete list of the environments that should be part of the access group
+	EnvironmentIds []int
+	UserAccesses   map[int]string
+	TeamAccesses   map[int]string
+}
+
+// IsEmpty reports whether the update does not change anything
+func (u AccessGroupUpdate) IsEmpty() bool {
+	return u.Name == nil && u.EnvironmentIds == nil && u.UserAccesses == nil && u.TeamAccesses == nil
+}
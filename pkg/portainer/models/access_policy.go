@@ -1,11 +1,36 @@
 package models
 
 import (
+	"slices"
 	"strconv"
 
 	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 )
 
+// Access level constants
+const (
+	AccessLevelEnvironmentAdmin = "environment_administrator"
+	AccessLevelHelpdeskUser     = "helpdesk_user"
+	AccessLevelStandardUser     = "standard_user"
+	AccessLevelReadonlyUser     = "readonly_user"
+	AccessLevelOperatorUser     = "operator_user"
+	AccessLevelUnknown          = "unknown"
+)
+
+// AllAccessLevels lists the access levels accepted by Portainer access policies
+var AllAccessLevels = []string{
+	AccessLevelEnvironmentAdmin,
+	AccessLevelHelpdeskUser,
+	AccessLevelStandardUser,
+	AccessLevelReadonlyUser,
+	AccessLevelOperatorUser,
+}
+
+// IsValidAccessLevel checks if a given string is an access level accepted by Portainer
+func IsValidAccessLevel(access string) bool {
+	return slices.Contains(AllAccessLevels, access)
+}
+
 func convertAccesses[T apimodels.PortainerUserAccessPolicies | apimodels.PortainerTeamAccessPolicies](rawPolicies T) map[int]string {
 	accesses := make(map[int]string)
 	for idStr, role := range rawPolicies {
@@ -20,16 +45,16 @@ func convertAccesses[T apimodels.PortainerUserAccessPolicies | apimodels.Portain
 func convertAccessPolicyRole(rawPolicy *apimodels.PortainerAccessPolicy) string {
 	switch rawPolicy.RoleID {
 	case 1:
-		return "environment_administrator"
+		return AccessLevelEnvironmentAdmin
 	case 2:
-		return "helpdesk_user"
+		return AccessLevelHelpdeskUser
 	case 3:
-		return "standard_user"
+		return AccessLevelStandardUser
 	case 4:
-		return "readonly_user"
+		return AccessLevelReadonlyUser
 	case 5:
-		return "operator_user"
+		return AccessLevelOperatorUser
 	default:
-		return "unknown"
+		return AccessLevelUnknown
 	}
 }
@@ -86,3 +86,53 @@ func TestConvertAccesses(t *testing.T) {
 		}
 	})
 }
+
+func TestIsValidAccessLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		access   string
+		expected bool
+	}{
+		{name: "environment administrator", access: "environment_administrator", expected: true},
+		{name: "helpdesk user", access: "helpdesk_user", expected: true},
+		{name: "standard user", access: "standard_user", expected: true},
+		{name: "readonly user", access: "readonly_user", expected: true},
+		{name: "operator user", access: "operator_user", expected: true},
+		{name: "unknown is not accepted", access: "unknown", expected: false},
+		{name: "empty", access: "", expected: false},
+		{name: "wrong case", access: "Standard_User", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidAccessLevel(tt.access); got != tt.expected {
+				t.Errorf("IsValidAccessLevel(%q) = %v, want %v", tt.access, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsMorePrivilegedAccessLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{name: "admin over operator", a: AccessLevelEnvironmentAdmin, b: AccessLevelOperatorUser, expected: true},
+		{name: "operator over helpdesk", a: AccessLevelOperatorUser, b: AccessLevelHelpdeskUser, expected: true},
+		{name: "standard over readonly", a: AccessLevelStandardUser, b: AccessLevelReadonlyUser, expected: true},
+		{name: "readonly under standard", a: AccessLevelReadonlyUser, b: AccessLevelStandardUser, expected: false},
+		{name: "same level", a: AccessLevelStandardUser, b: AccessLevelStandardUser, expected: false},
+		{name: "known over unknown", a: AccessLevelReadonlyUser, b: AccessLevelUnknown, expected: true},
+		{name: "unknown under known", a: AccessLevelUnknown, b: AccessLevelReadonlyUser, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMorePrivilegedAccessLevel(tt.a, tt.b); got != tt.expected {
+				t.Errorf("IsMorePrivilegedAccessLevel(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
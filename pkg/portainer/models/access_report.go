@@ -0,0 +1,39 @@
+package models
+
+// EffectiveAccess represents the role a user ends up with on an environment once all the
+// access policies applying to the user are resolved.
+type EffectiveAccess struct {
+	UserID          int    `json:"user_id"`
+	Username        string `json:"username"`
+	EnvironmentID   int    `json:"environment_id"`
+	EnvironmentName string `json:"environment_name"`
+	Role            string `json:"role"`
+	// Source describes the access policy the role comes from, e.g. "team devs access on access group production"
+	Source string `json:"source"`
+}
+
+// accessLevelPriorities ranks the access levels from the most to the least privileged,
+// Portainer keeps the most privileged role when a user gets several roles through teams.
+var accessLevelPriorities = map[string]int{
+	AccessLevelEnvironmentAdmin: 1,
+	AccessLevelOperatorUser:     2,
+	AccessLevelHelpdeskUser:     3,
+	AccessLevelStandardUser:     4,
+	AccessLevelReadonlyUser:     5,
+}
+
+// IsMorePrivilegedAccessLevel reports whether access level a grants more privileges than access level b.
+// Unknown access levels are less privileged than any known access level.
+func IsMorePrivilegedAccessLevel(a, b string) bool {
+	pa, ok := accessLevelPriorities[a]
+	if !ok {
+		return false
+	}
+
+	pb, ok := accessLevelPriorities[b]
+	if !ok {
+		return true
+	}
+
+	return pa < pb
+}
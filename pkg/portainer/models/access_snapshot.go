@@ -0,0 +1,13 @@
+package models
+
+// AccessSnapshot is the access configuration of an environment captured at a point in time,
+// so that it can be restored later.
+type AccessSnapshot struct {
+	EnvironmentID   int    `json:"environment_id"`
+	EnvironmentName string `json:"environment_name"`
+	CapturedAt      string `json:"captured_at"`
+	// AccessGroupID is the ID of the access group (endpoint group) the environment belongs to
+	AccessGroupID int            `json:"access_group_id"`
+	UserAccesses  map[int]string `json:"user_accesses"`
+	TeamAccesses  map[int]string `json:"team_accesses"`
+}
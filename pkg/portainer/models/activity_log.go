@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ActivityLog represents a user activity entry recorded by Portainer.
+type ActivityLog struct {
+	ID        int    `json:"id"`
+	Timestamp string `json:"timestamp"`
+	Username  string `json:"username"`
+	Context   string `json:"context"`
+	Action    string `json:"action"`
+}
+
+// ActivityLogFilter defines the criteria used to query the activity logs.
+// Zero values disable the corresponding filter.
+type ActivityLogFilter struct {
+	// After only keeps the entries recorded after this time.
+	After time.Time
+	// Before only keeps the entries recorded before this time.
+	Before time.Time
+	// Username only keeps the entries of this user (case-insensitive exact match).
+	Username string
+	// Action only keeps the entries whose action contains this value (case-insensitive).
+	Action string
+	// Limit is the maximum number of matching entries to return.
+	Limit int
+}
+
+// ActivityLogPage holds the entries of an activity log query with the items, totalCount and hasMore
+// fields of the envelope of the list tools. TotalCount is the number of entries matching the query,
+// HasMore reports that the limit left out older matching entries. When the entries are filtered by
+// username or action, the scan stops early and TotalCount is a lower bound when HasMore is set.
+type ActivityLogPage struct {
+	Items      []ActivityLog `json:"items"`
+	TotalCount int           `json:"totalCount"`
+	HasMore    bool          `json:"hasMore"`
+}
+
+func ConvertToActivityLog(rawLog *apimodels.PortainereeUserActivityLog) ActivityLog {
+	return ActivityLog{
+		ID:        int(rawLog.ID),
+		Timestamp: time.Unix(rawLog.Timestamp, 0).UTC().Format(time.RFC3339),
+		Username:  rawLog.Username,
+		Context:   rawLog.Context,
+		Action:    rawLog.Action,
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertToActivityLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *apimodels.PortainereeUserActivityLog
+		expected ActivityLog
+	}{
+		{
+			name: "complete log entry",
+			input: &apimodels.PortainereeUserActivityLog{
+				ID:        7,
+				Timestamp: 1717243200,
+				Username:  "admin",
+				Context:   "Portainer",
+				Action:    "POST /endpoints",
+			},
+			expected: ActivityLog{
+				ID:        7,
+				Timestamp: "2024-06-01T12:00:00Z",
+				Username:  "admin",
+				Context:   "Portainer",
+				Action:    "POST /endpoints",
+			},
+		},
+		{
+			name:  "empty log entry",
+			input: &apimodels.PortainereeUserActivityLog{},
+			expected: ActivityLog{
+				Timestamp: "1970-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ConvertToActivityLog(tt.input))
+		})
+	}
+}
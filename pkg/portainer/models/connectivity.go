@@ -0,0 +1,35 @@
+package models
+
+// Connectivity failure constants
+const (
+	// ConnectivityFailureUnreachable is reported when Portainer or the environment could not be reached
+	ConnectivityFailureUnreachable = "unreachable"
+	// ConnectivityFailureAuthentication is reported when Portainer or the environment rejected the credentials
+	ConnectivityFailureAuthentication = "authentication"
+	// ConnectivityFailureTLS is reported when the TLS handshake or the certificate verification failed
+	ConnectivityFailureTLS = "tls"
+	// ConnectivityFailureUnexpected is reported for any other failure
+	ConnectivityFailureUnexpected = "unexpected"
+)
+
+// ConnectivityResult is the result of a cheap request sent to the Docker daemon or the Kubernetes API of an
+// environment through the Portainer proxy. When the request failed, Failure classifies the failure and Hint
+// suggests what to check.
+type ConnectivityResult struct {
+	EnvironmentID   int    `json:"environment_id"`
+	EnvironmentName string `json:"environment_name"`
+	Type            string `json:"type"`
+	// Path is the path of the request sent through the proxy, /_ping for Docker and /healthz for Kubernetes
+	Path      string `json:"path"`
+	Success   bool   `json:"success"`
+	LatencyMs int64  `json:"latency_ms"`
+	// StatusCode is the status code of the response, zero when no response was received
+	StatusCode int `json:"status_code,omitempty"`
+	// DaemonVersion is the version of the Docker daemon or of Kubernetes, empty when it could not be read
+	DaemonVersion string `json:"daemon_version,omitempty"`
+	// APIVersion is the API version of the Docker daemon, empty for Kubernetes
+	APIVersion string `json:"api_version,omitempty"`
+	Failure    string `json:"failure,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Hint       string `json:"hint,omitempty"`
+}
@@ -0,0 +1,198 @@
+package models
+
+// Container represents a Docker container of an environment.
+type Container struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+	State   string `json:"state"`
+	Status  string `json:"status"`
+	Created string `json:"created"`
+}
+
+// ContainerFilter defines the criteria used to list the containers of an environment.
+type ContainerFilter struct {
+	// All includes the stopped containers, only running containers are listed otherwise.
+	All bool
+	// Name only keeps the containers whose name contains this value, it is matched by Docker.
+	Name string
+	// Filters are Docker container filters keyed by filter name, a container must match one of the values
+	// of each filter, e.g. {"label": ["com.docker.compose.project=web"], "status": ["exited"]}
+	Filters map[string][]string
+}
+
+// ContainerPage is a page of the containers of an environment. It carries the same items, totalCount
+// and hasMore fields as the envelope of the other list tools. NextCursor is empty on the last page.
+type ContainerPage struct {
+	EnvironmentID int         `json:"environment_id"`
+	Items         []Container `json:"items"`
+	TotalCount    int         `json:"totalCount"`
+	HasMore       bool        `json:"hasMore"`
+	NextCursor    string      `json:"next_cursor,omitempty"`
+}
+
+// Kinds of the changes to the filesystem of a container
+const (
+	FilesystemChangeModified = "modified"
+	FilesystemChangeAdded    = "added"
+	FilesystemChangeDeleted  = "deleted"
+)
+
+// FilesystemChange is a change to the filesystem of a container compared to its image,
+// the equivalent of a line of the docker diff command.
+type FilesystemChange struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+}
+
+// ContainerChanges holds the paths changed in the filesystem of a container, grouped by kind of change.
+type ContainerChanges struct {
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Deleted  []string `json:"deleted"`
+}
+
+// GroupFilesystemChanges groups the paths of filesystem changes by kind, keeping their order.
+// Changes of an unknown kind are ignored.
+func GroupFilesystemChanges(changes []FilesystemChange) ContainerChanges {
+	grouped := ContainerChanges{
+		Added:    []string{},
+		Modified: []string{},
+		Deleted:  []string{},
+	}
+
+	for _, change := range changes {
+		switch change.Kind {
+		case FilesystemChangeAdded:
+			grouped.Added = append(grouped.Added, change.Path)
+		case FilesystemChangeModified:
+			grouped.Modified = append(grouped.Modified, change.Path)
+		case FilesystemChangeDeleted:
+			grouped.Deleted = append(grouped.Deleted, change.Path)
+		}
+	}
+
+	return grouped
+}
+
+// ContainerFile is a file copied out of a container.
+// Text files are returned as is, binary files are encoded in base64 and flagged with a warning.
+type ContainerFile struct {
+	Path     string `json:"path"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+	Warning  string `json:"warning,omitempty"`
+}
+
+// ProcessList holds the processes running in a container, the equivalent of the docker top command.
+// Titles are the columns reported by ps in their original order and each process maps the titles
+// to its values.
+type ProcessList struct {
+	Titles    []string            `json:"titles"`
+	Processes []map[string]string `json:"processes"`
+}
+
+// Streams of the log lines of a container
+const (
+	ContainerLogStreamStdout = "stdout"
+	ContainerLogStreamStderr = "stderr"
+)
+
+// ContainerLogLine is a line written by a container to its standard output or error.
+// Timestamp is the time Docker received the line, in RFC 3339 format with nanoseconds.
+type ContainerLogLine struct {
+	Stream    string `json:"stream"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Text      string `json:"text"`
+}
+
+// Restart policies of a container
+const (
+	RestartPolicyNo            = "no"
+	RestartPolicyAlways        = "always"
+	RestartPolicyUnlessStopped = "unless-stopped"
+	RestartPolicyOnFailure     = "on-failure"
+)
+
+// AllRestartPolicies lists the restart policies of a container
+var AllRestartPolicies = []string{RestartPolicyNo, RestartPolicyAlways, RestartPolicyUnlessStopped, RestartPolicyOnFailure}
+
+// RestartPolicy represents whether Docker restarts a container when it exits. MaximumRetryCount
+// bounds the restarts attempted by the on-failure policy, 0 retries without limit.
+type RestartPolicy struct {
+	Name              string `json:"name"`
+	MaximumRetryCount int    `json:"maximum_retry_count,omitempty"`
+}
+
+// ResourceLimits represents the resource limits to apply to a running container, the equivalent of
+// the options of the docker update command. Nil fields are left unchanged.
+type ResourceLimits struct {
+	// CPUs is the number of CPUs the container can use, fractions are allowed (e.g. 1.5)
+	CPUs *float64 `json:"cpus,omitempty"`
+	// CPUShares is the relative weight of the container when CPUs are contended, 1024 by default
+	CPUShares *int64 `json:"cpu_shares,omitempty"`
+	// MemoryBytes is the hard memory limit of the container
+	MemoryBytes *int64 `json:"memory_bytes,omitempty"`
+	// MemoryReservationBytes is the soft memory limit enforced when the host runs low on memory
+	MemoryReservationBytes *int64 `json:"memory_reservation_bytes,omitempty"`
+	// MemorySwapBytes is the limit of memory plus swap, -1 allows an unlimited swap
+	MemorySwapBytes *int64 `json:"memory_swap_bytes,omitempty"`
+	// PidsLimit is the maximum number of processes of the container, 0 or -1 removes the limit
+	PidsLimit *int64 `json:"pids_limit,omitempty"`
+}
+
+// Health states of a container, the states reported by Docker plus ContainerHealthNone
+// for the containers without a healthcheck
+const (
+	ContainerHealthStarting  = "starting"
+	ContainerHealthHealthy   = "healthy"
+	ContainerHealthUnhealthy = "unhealthy"
+	ContainerHealthNone      = "none"
+)
+
+// HealthStatus is the state of the Docker healthcheck of a container.
+// When the container has no healthcheck, Status is ContainerHealthNone and Message explains it.
+type HealthStatus struct {
+	Status string `json:"status"`
+	// FailingStreak is the number of consecutive failed probes
+	FailingStreak int    `json:"failing_streak"`
+	Message       string `json:"message,omitempty"`
+	// LastProbe is the most recent probe, omitted while no probe has run yet
+	LastProbe *HealthProbe `json:"last_probe,omitempty"`
+}
+
+// HealthProbe is the result of a run of the healthcheck command of a container.
+// Start and End are in RFC 3339 format.
+type HealthProbe struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	ExitCode int    `json:"exit_code"`
+	Output   string `json:"output"`
+}
+
+// Types of the mounts of a container, as reported by Docker
+const (
+	MountTypeBind   = "bind"
+	MountTypeVolume = "volume"
+	MountTypeTmpfs  = "tmpfs"
+)
+
+// Mount is a filesystem mounted in a container.
+// Bind mounts expose a path of the host, named volumes are managed by Docker and both outlive the
+// container. Tmpfs mounts are kept in memory and are lost when the container stops.
+type Mount struct {
+	Type string `json:"type"`
+	// Source is the path on the host of a bind mount or of the data of a volume, empty for tmpfs mounts
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination"`
+	// Name and Driver are only set for named volumes
+	Name      string `json:"name,omitempty"`
+	Driver    string `json:"driver,omitempty"`
+	ReadWrite bool   `json:"read_write"`
+	// Persistent reports whether the data written to the mount outlives the container
+	Persistent bool `json:"persistent"`
+	// Options holds the mode of bind mounts and volumes or the options of tmpfs mounts, e.g. "z" or "size=64m"
+	Options     string `json:"options,omitempty"`
+	Propagation string `json:"propagation,omitempty"`
+}
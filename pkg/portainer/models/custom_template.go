@@ -0,0 +1,148 @@
+package models
+
+import (
+	"slices"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// CustomTemplate is a stack template maintained by the users of Portainer, as opposed to the
+// application templates of the template catalog. The file of a template is either stored by
+// Portainer or read from a git repository.
+type CustomTemplate struct {
+	ID           int    `json:"id"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Note         string `json:"note,omitempty"`
+	Logo         string `json:"logo,omitempty"`
+	Type         string `json:"type"`
+	Platform     string `json:"platform,omitempty"`
+	EdgeTemplate bool   `json:"edge_template"`
+	// FileContent is the content of the stack file, it is only used to create a template from an inline
+	// file and is never returned
+	FileContent string `json:"file_content,omitempty"`
+	// Git is the git repository the stack file is read from, nil for the templates stored by Portainer
+	Git *CustomTemplateGit `json:"git,omitempty"`
+	// Variables are the variables substituted in the stack file when the template is deployed
+	Variables []CustomTemplateVariable `json:"variables,omitempty"`
+}
+
+// CustomTemplateVariable is a variable of a custom template, written {{ name }} in its stack file.
+// A variable without a default value must be provided to deploy the template.
+type CustomTemplateVariable struct {
+	Name         string `json:"name"`
+	Label        string `json:"label,omitempty"`
+	Description  string `json:"description,omitempty"`
+	DefaultValue string `json:"default_value,omitempty"`
+}
+
+// CustomTemplateGit is the git repository the stack file of a custom template is read from.
+// The password is only used to create the template and is never returned.
+type CustomTemplateGit struct {
+	URL            string `json:"url"`
+	ReferenceName  string `json:"reference_name,omitempty"`
+	ConfigFilePath string `json:"config_file_path,omitempty"`
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"-"`
+	TLSSkipVerify  bool   `json:"tls_skip_verify"`
+}
+
+// Custom template type constants, the type of the stacks deployed from a template
+const (
+	CustomTemplateTypeSwarm      = "swarm"
+	CustomTemplateTypeCompose    = "compose"
+	CustomTemplateTypeKubernetes = "kubernetes"
+	CustomTemplateTypeUnknown    = "unknown"
+)
+
+// AllCustomTemplateTypes lists the custom template types accepted by Portainer
+var AllCustomTemplateTypes = []string{
+	CustomTemplateTypeSwarm,
+	CustomTemplateTypeCompose,
+	CustomTemplateTypeKubernetes,
+}
+
+// Custom template platform constants, only the Docker templates have a platform
+const (
+	CustomTemplatePlatformLinux   = "linux"
+	CustomTemplatePlatformWindows = "windows"
+)
+
+// AllCustomTemplatePlatforms lists the custom template platforms accepted by Portainer
+var AllCustomTemplatePlatforms = []string{
+	CustomTemplatePlatformLinux,
+	CustomTemplatePlatformWindows,
+}
+
+// IsValidCustomTemplateType checks if a given string is a valid custom template type
+func IsValidCustomTemplateType(templateType string) bool {
+	return slices.Contains(AllCustomTemplateTypes, templateType)
+}
+
+// IsValidCustomTemplatePlatform checks if a given string is a valid custom template platform
+func IsValidCustomTemplatePlatform(platform string) bool {
+	return slices.Contains(AllCustomTemplatePlatforms, platform)
+}
+
+func ConvertToCustomTemplate(rawTemplate *apimodels.PortainereeCustomTemplate) CustomTemplate {
+	template := CustomTemplate{
+		ID:           int(rawTemplate.ID),
+		Title:        rawTemplate.Title,
+		Description:  rawTemplate.Description,
+		Note:         rawTemplate.Note,
+		Logo:         rawTemplate.Logo,
+		Type:         convertCustomTemplateType(rawTemplate.Type),
+		Platform:     convertCustomTemplatePlatform(rawTemplate.Platform),
+		EdgeTemplate: rawTemplate.EdgeTemplate,
+	}
+
+	if rawConfig := rawTemplate.GitConfig; rawConfig != nil {
+		template.Git = &CustomTemplateGit{
+			URL:            rawConfig.URL,
+			ReferenceName:  rawConfig.ReferenceName,
+			ConfigFilePath: rawConfig.ConfigFilePath,
+			TLSSkipVerify:  rawConfig.TlsskipVerify,
+		}
+		if rawConfig.Authentication != nil {
+			template.Git.Username = rawConfig.Authentication.Username
+		}
+	}
+
+	for _, rawVariable := range rawTemplate.Variables {
+		if rawVariable == nil {
+			continue
+		}
+		template.Variables = append(template.Variables, CustomTemplateVariable{
+			Name:         rawVariable.Name,
+			Label:        rawVariable.Label,
+			Description:  rawVariable.Description,
+			DefaultValue: rawVariable.DefaultValue,
+		})
+	}
+
+	return template
+}
+
+func convertCustomTemplateType(templateType int64) string {
+	switch templateType {
+	case 1:
+		return CustomTemplateTypeSwarm
+	case 2:
+		return CustomTemplateTypeCompose
+	case 3:
+		return CustomTemplateTypeKubernetes
+	default:
+		return CustomTemplateTypeUnknown
+	}
+}
+
+func convertCustomTemplatePlatform(platform int64) string {
+	switch platform {
+	case 1:
+		return CustomTemplatePlatformLinux
+	case 2:
+		return CustomTemplatePlatformWindows
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+func TestConvertToCustomTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *models.PortainereeCustomTemplate
+		expected CustomTemplate
+	}{
+		{
+			name: "compose template stored by portainer",
+			input: &models.PortainereeCustomTemplate{
+				ID:          1,
+				Title:       "nginx",
+				Description: "Nginx web server",
+				Note:        "Exposes port 80",
+				Logo:        "https://example.com/nginx.png",
+				Type:        2,
+				Platform:    1,
+				EntryPoint:  "docker-compose.yml",
+				Variables: []*models.PortainerCustomTemplateVariableDefinition{
+					{Name: "port", Label: "Port", Description: "The published port", DefaultValue: "80"},
+					{Name: "domain", Label: "Domain"},
+				},
+			},
+			expected: CustomTemplate{
+				ID:          1,
+				Title:       "nginx",
+				Description: "Nginx web server",
+				Note:        "Exposes port 80",
+				Logo:        "https://example.com/nginx.png",
+				Type:        CustomTemplateTypeCompose,
+				Platform:    CustomTemplatePlatformLinux,
+				Variables: []CustomTemplateVariable{
+					{Name: "port", Label: "Port", Description: "The published port", DefaultValue: "80"},
+					{Name: "domain", Label: "Domain"},
+				},
+			},
+		},
+		{
+			name: "edge swarm template from git",
+			input: &models.PortainereeCustomTemplate{
+				ID:           2,
+				Title:        "agent",
+				Description:  "Monitoring agent",
+				Type:         1,
+				Platform:     2,
+				EdgeTemplate: true,
+				GitConfig: &models.GittypesRepoConfig{
+					URL:            "https://github.com/example/stacks.git",
+					ReferenceName:  "refs/heads/main",
+					ConfigFilePath: "agent/docker-compose.yml",
+					Authentication: &models.GittypesGitAuthentication{
+						Username: "deploy",
+						Password: "secret",
+					},
+				},
+			},
+			expected: CustomTemplate{
+				ID:           2,
+				Title:        "agent",
+				Description:  "Monitoring agent",
+				Type:         CustomTemplateTypeSwarm,
+				Platform:     CustomTemplatePlatformWindows,
+				EdgeTemplate: true,
+				Git: &CustomTemplateGit{
+					URL:            "https://github.com/example/stacks.git",
+					ReferenceName:  "refs/heads/main",
+					ConfigFilePath: "agent/docker-compose.yml",
+					Username:       "deploy",
+				},
+			},
+		},
+		{
+			name: "kubernetes template",
+			input: &models.PortainereeCustomTemplate{
+				ID:          3,
+				Title:       "redis",
+				Description: "Redis deployment",
+				Type:        3,
+			},
+			expected: CustomTemplate{
+				ID:          3,
+				Title:       "redis",
+				Description: "Redis deployment",
+				Type:        CustomTemplateTypeKubernetes,
+			},
+		},
+		{
+			name: "unknown type",
+			input: &models.PortainereeCustomTemplate{
+				ID:    4,
+				Title: "future",
+				Type:  9,
+			},
+			expected: CustomTemplate{
+				ID:    4,
+				Title: "future",
+				Type:  CustomTemplateTypeUnknown,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToCustomTemplate(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToCustomTemplate() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsValidCustomTemplateType(t *testing.T) {
+	for _, templateType := range AllCustomTemplateTypes {
+		if !IsValidCustomTemplateType(templateType) {
+			t.Errorf("IsValidCustomTemplateType(%q) = false, want true", templateType)
+		}
+	}
+
+	for _, templateType := range []string{"", CustomTemplateTypeUnknown, "Compose"} {
+		if IsValidCustomTemplateType(templateType) {
+			t.Errorf("IsValidCustomTemplateType(%q) = true, want false", templateType)
+		}
+	}
+}
+
+func TestIsValidCustomTemplatePlatform(t *testing.T) {
+	for _, platform := range AllCustomTemplatePlatforms {
+		if !IsValidCustomTemplatePlatform(platform) {
+			t.Errorf("IsValidCustomTemplatePlatform(%q) = false, want true", platform)
+		}
+	}
+
+	for _, platform := range []string{"", "darwin", "Linux"} {
+		if IsValidCustomTemplatePlatform(platform) {
+			t.Errorf("IsValidCustomTemplatePlatform(%q) = true, want false", platform)
+		}
+	}
+}
@@ -10,6 +10,10 @@ type DockerProxyRequestOptions struct {
 	Method string
 	// Path is the Docker API endpoint path to proxy to (e.g., "/containers/json"). Must include the leading slash.
 	Path string
+	// APIVersion is the Docker API version the path is prefixed with (e.g., "1.41" sends "/v1.41/containers/json").
+	// When empty, the version negotiated with the Docker daemon of the environment is used if the client
+	// negotiates versions, otherwise the path is sent unversioned and Docker uses its own version.
+	APIVersion string
 	// QueryParams is a map of query parameters to include in the request URL.
 	QueryParams map[string]string
 	// Headers is a map of headers to include in the request.
@@ -17,3 +21,20 @@ type DockerProxyRequestOptions struct {
 	// Body is the request body to send (set it to nil for requests that don't have a body).
 	Body io.Reader
 }
+
+// DockerInfo represents the key system information of the Docker daemon of an environment,
+// the equivalent of the `docker info` command.
+type DockerInfo struct {
+	Name              string `json:"name"`
+	ServerVersion     string `json:"server_version"`
+	OperatingSystem   string `json:"operating_system"`
+	OSType            string `json:"os_type"`
+	Architecture      string `json:"architecture"`
+	KernelVersion     string `json:"kernel_version"`
+	StorageDriver     string `json:"storage_driver"`
+	TotalMemoryBytes  int64  `json:"total_memory_bytes"`
+	CPUs              int    `json:"cpus"`
+	Containers        int    `json:"containers"`
+	ContainersRunning int    `json:"containers_running"`
+	Images            int    `json:"images"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// Edge agent status constants
+const (
+	EdgeAgentStatusOnline  = "online"
+	EdgeAgentStatusOffline = "offline"
+)
+
+// EdgeAgentStatus is the connection status of the agent of an edge environment, derived from its
+// last check-in. The agent is offline when it did not check in for more than OfflineThresholdSeconds
+// or when it never checked in, in which case LastCheckIn is empty and SecondsSinceCheckIn is not set.
+type EdgeAgentStatus struct {
+	EnvironmentID           int    `json:"environment_id"`
+	Name                    string `json:"name"`
+	Type                    string `json:"type"`
+	EdgeID                  string `json:"edge_id"`
+	Status                  string `json:"status"`
+	LastCheckIn             string `json:"last_check_in"`
+	SecondsSinceCheckIn     *int   `json:"seconds_since_check_in,omitempty"`
+	CheckinIntervalSeconds  int    `json:"checkin_interval_seconds"`
+	OfflineThresholdSeconds int    `json:"offline_threshold_seconds"`
+}
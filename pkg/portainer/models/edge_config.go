@@ -0,0 +1,86 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// EdgeConfig represents a set of files pushed by Portainer to the edge agents of environment groups.
+// The content of the files is never part of the edge configuration.
+type EdgeConfig struct {
+	ID                  int    `json:"id"`
+	Name                string `json:"name"`
+	Type                string `json:"type"`
+	Category            string `json:"category"`
+	BaseDir             string `json:"base_dir"`
+	CreatedAt           string `json:"created_at"`
+	EnvironmentGroupIds []int  `json:"group_ids"`
+}
+
+// Edge configuration type constants
+const (
+	// EdgeConfigTypeGeneral pushes the same files to every edge device
+	EdgeConfigTypeGeneral = "general"
+	// EdgeConfigTypeFilename pushes to each edge device the files named after its Edge ID
+	EdgeConfigTypeFilename = "filename"
+	// EdgeConfigTypeFoldername pushes to each edge device the folder named after its Edge ID
+	EdgeConfigTypeFoldername = "foldername"
+	EdgeConfigTypeUnknown    = "unknown"
+)
+
+// AllEdgeConfigTypes lists the edge configuration types accepted by Portainer
+var AllEdgeConfigTypes = []string{
+	EdgeConfigTypeGeneral,
+	EdgeConfigTypeFilename,
+	EdgeConfigTypeFoldername,
+}
+
+// Edge configuration category constants
+const (
+	EdgeConfigCategoryConfiguration = "configuration"
+	EdgeConfigCategorySecret        = "secret"
+)
+
+// AllEdgeConfigCategories lists the edge configuration categories accepted by Portainer
+var AllEdgeConfigCategories = []string{
+	EdgeConfigCategoryConfiguration,
+	EdgeConfigCategorySecret,
+}
+
+// IsValidEdgeConfigType checks if a given string is a valid edge configuration type
+func IsValidEdgeConfigType(configType string) bool {
+	return slices.Contains(AllEdgeConfigTypes, configType)
+}
+
+// IsValidEdgeConfigCategory checks if a given string is a valid edge configuration category
+func IsValidEdgeConfigCategory(category string) bool {
+	return slices.Contains(AllEdgeConfigCategories, category)
+}
+
+func ConvertToEdgeConfig(rawEdgeConfig *apimodels.PortainereeEdgeConfig) EdgeConfig {
+	return EdgeConfig{
+		ID:                  int(rawEdgeConfig.ID),
+		Name:                rawEdgeConfig.Name,
+		Type:                convertEdgeConfigType(rawEdgeConfig.Type),
+		Category:            rawEdgeConfig.Category,
+		BaseDir:             rawEdgeConfig.BaseDir,
+		CreatedAt:           time.Unix(rawEdgeConfig.Created, 0).Format(time.RFC3339),
+		EnvironmentGroupIds: utils.Int64ToIntSlice(rawEdgeConfig.EdgeGroupIDs),
+	}
+}
+
+func convertEdgeConfigType(configType int64) string {
+	switch configType {
+	case 0:
+		return EdgeConfigTypeGeneral
+	case 1:
+		return EdgeConfigTypeFilename
+	case 2:
+		return EdgeConfigTypeFoldername
+	default:
+		return EdgeConfigTypeUnknown
+	}
+}
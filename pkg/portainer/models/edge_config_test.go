@@ -0,0 +1,113 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+func TestConvertToEdgeConfig(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name     string
+		input    *models.PortainereeEdgeConfig
+		expected EdgeConfig
+	}{
+		{
+			name: "general configuration",
+			input: &models.PortainereeEdgeConfig{
+				ID:           1,
+				Name:         "nginx-conf",
+				Type:         0,
+				Category:     "configuration",
+				BaseDir:      "/etc/nginx",
+				Created:      now,
+				EdgeGroupIDs: []int64{1, 2},
+			},
+			expected: EdgeConfig{
+				ID:                  1,
+				Name:                "nginx-conf",
+				Type:                EdgeConfigTypeGeneral,
+				Category:            EdgeConfigCategoryConfiguration,
+				BaseDir:             "/etc/nginx",
+				CreatedAt:           time.Unix(now, 0).Format(time.RFC3339),
+				EnvironmentGroupIds: []int{1, 2},
+			},
+		},
+		{
+			name: "per device secret",
+			input: &models.PortainereeEdgeConfig{
+				ID:           2,
+				Name:         "certs",
+				Type:         1,
+				Category:     "secret",
+				Created:      now,
+				EdgeGroupIDs: []int64{3},
+			},
+			expected: EdgeConfig{
+				ID:                  2,
+				Name:                "certs",
+				Type:                EdgeConfigTypeFilename,
+				Category:            EdgeConfigCategorySecret,
+				CreatedAt:           time.Unix(now, 0).Format(time.RFC3339),
+				EnvironmentGroupIds: []int{3},
+			},
+		},
+		{
+			name: "unknown type",
+			input: &models.PortainereeEdgeConfig{
+				ID:      3,
+				Name:    "future",
+				Type:    9,
+				Created: now,
+			},
+			expected: EdgeConfig{
+				ID:                  3,
+				Name:                "future",
+				Type:                EdgeConfigTypeUnknown,
+				CreatedAt:           time.Unix(now, 0).Format(time.RFC3339),
+				EnvironmentGroupIds: []int{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToEdgeConfig(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToEdgeConfig() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsValidEdgeConfigType(t *testing.T) {
+	for _, configType := range AllEdgeConfigTypes {
+		if !IsValidEdgeConfigType(configType) {
+			t.Errorf("IsValidEdgeConfigType(%q) = false, want true", configType)
+		}
+	}
+
+	for _, configType := range []string{"", EdgeConfigTypeUnknown, "General"} {
+		if IsValidEdgeConfigType(configType) {
+			t.Errorf("IsValidEdgeConfigType(%q) = true, want false", configType)
+		}
+	}
+}
+
+func TestIsValidEdgeConfigCategory(t *testing.T) {
+	for _, category := range AllEdgeConfigCategories {
+		if !IsValidEdgeConfigCategory(category) {
+			t.Errorf("IsValidEdgeConfigCategory(%q) = false, want true", category)
+		}
+	}
+
+	for _, category := range []string{"", "secrets", "Configuration"} {
+		if IsValidEdgeConfigCategory(category) {
+			t.Errorf("IsValidEdgeConfigCategory(%q) = true, want false", category)
+		}
+	}
+}
@@ -34,6 +34,26 @@ const (
 	EnvironmentTypeUnknown             = "unknown"
 )
 
+// IsDockerEnvironment checks if an environment type is a Docker environment.
+func IsDockerEnvironment(environmentType string) bool {
+	switch environmentType {
+	case EnvironmentTypeDockerLocal, EnvironmentTypeDockerAgent, EnvironmentTypeDockerEdgeAgent:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsKubernetesEnvironment checks if an environment type is a Kubernetes environment.
+func IsKubernetesEnvironment(environmentType string) bool {
+	switch environmentType {
+	case EnvironmentTypeKubernetesLocal, EnvironmentTypeKubernetesAgent, EnvironmentTypeKubernetesEdgeAgent:
+		return true
+	default:
+		return false
+	}
+}
+
 func ConvertEndpointToEnvironment(rawEndpoint *apimodels.PortainereeEndpoint) Environment {
 	return Environment{
 		ID:           int(rawEndpoint.ID),
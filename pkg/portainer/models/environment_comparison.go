@@ -0,0 +1,67 @@
+package models
+
+// EnvironmentComparison describes the configuration differences between two environments.
+// Every section holds the values of both environments, whether they differ or not, so that
+// the comparison can be rendered side by side.
+type EnvironmentComparison struct {
+	First  ComparedEnvironment `json:"first"`
+	Second ComparedEnvironment `json:"second"`
+	// Identical is set when no section differs between the two environments
+	Identical bool              `json:"identical"`
+	Tags      ItemSetComparison `json:"tags"`
+	// AccessGroup compares the names of the access groups (endpoint groups) of the environments
+	AccessGroup ValueComparison `json:"access_group"`
+	// EnvironmentGroups compares the environment groups (edge groups) the environments belong to
+	EnvironmentGroups ItemSetComparison `json:"environment_groups"`
+	UserAccesses      AccessComparison  `json:"user_accesses"`
+	TeamAccesses      AccessComparison  `json:"team_accesses"`
+	// DockerVersion compares the versions of the Docker daemons, empty for non Docker environments.
+	// The versions are not the same when the version of a Docker environment could not be retrieved.
+	DockerVersion ValueComparison `json:"docker_version"`
+	// Warnings lists the information that could not be retrieved, e.g. the Docker version of an unreachable environment
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ComparedEnvironment identifies one of the two compared environments.
+type ComparedEnvironment struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ComparedItem is a named item, such as a tag or a group, found on a compared environment.
+type ComparedItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ItemSetComparison compares the items found on two environments. The items are sorted by name.
+type ItemSetComparison struct {
+	Same         bool           `json:"same"`
+	OnlyInFirst  []ComparedItem `json:"only_in_first"`
+	OnlyInSecond []ComparedItem `json:"only_in_second"`
+	InBoth       []ComparedItem `json:"in_both"`
+}
+
+// ValueComparison compares a single value of two environments.
+type ValueComparison struct {
+	Same   bool   `json:"same"`
+	First  string `json:"first"`
+	Second string `json:"second"`
+}
+
+// AccessComparison compares the access policies of users or teams on two environments.
+// Differences only lists the users or teams whose access level differs, sorted by name.
+type AccessComparison struct {
+	Same        bool               `json:"same"`
+	Differences []AccessDifference `json:"differences"`
+}
+
+// AccessDifference describes the access levels of a user or a team on both environments.
+// An empty access level means the user or the team has no access policy on the environment.
+type AccessDifference struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	First  string `json:"first"`
+	Second string `json:"second"`
+}
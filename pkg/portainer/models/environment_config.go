@@ -0,0 +1,47 @@
+package models
+
+// EnvironmentDesiredState describes the configuration an environment should have.
+// Nil fields are not managed: they are left out of the diff and never changed when the state is applied.
+// An empty, non-nil field means the environment should have no tags or no accesses.
+type EnvironmentDesiredState struct {
+	TagIds []int `json:"tag_ids,omitempty"`
+	// AccessGroupID is the ID of the access group (endpoint group) the environment should belong to
+	AccessGroupID *int           `json:"access_group_id,omitempty"`
+	UserAccesses  map[int]string `json:"user_accesses,omitempty"`
+	TeamAccesses  map[int]string `json:"team_accesses,omitempty"`
+}
+
+// ConfigDiff describes the changes required to bring an environment to its desired state.
+type ConfigDiff struct {
+	EnvironmentID     int                `json:"environment_id"`
+	InSync            bool               `json:"in_sync"`
+	TagsAdded         []int              `json:"tags_added"`
+	TagsRemoved       []int              `json:"tags_removed"`
+	AccessGroupChange *AccessGroupChange `json:"access_group_change,omitempty"`
+	UserAccesses      AccessMapDiff      `json:"user_accesses"`
+	TeamAccesses      AccessMapDiff      `json:"team_accesses"`
+}
+
+// AccessGroupChange describes the move of an environment from an access group to another.
+type AccessGroupChange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// AccessMapDiff describes the changes between two access maps, keyed by user or team ID.
+type AccessMapDiff struct {
+	Added   map[int]string            `json:"added"`
+	Removed map[int]string            `json:"removed"`
+	Changed map[int]AccessLevelChange `json:"changed"`
+}
+
+// AccessLevelChange describes the change of the access level of a user or a team.
+type AccessLevelChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// IsEmpty reports whether the access maps are identical.
+func (d AccessMapDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
@@ -0,0 +1,18 @@
+package models
+
+// EnvironmentDetachReport describes what was detached from an environment before it is decommissioned.
+// Only the steps that succeeded are listed, the failed steps are reported by the error returned with the report.
+type EnvironmentDetachReport struct {
+	EnvironmentID int `json:"environment_id"`
+	// RemovedEnvironmentGroupIds are the static environment groups the environment was removed from.
+	// Dynamic environment groups select their environments by tag, they no longer match once the tags are cleared.
+	RemovedEnvironmentGroupIds []int `json:"removed_environment_group_ids"`
+	// RemovedAccessGroupID is the access group the environment was moved out of, to the Unassigned access group.
+	// It is omitted when the environment was already unassigned.
+	RemovedAccessGroupID int   `json:"removed_access_group_id,omitempty"`
+	RemovedTagIds        []int `json:"removed_tag_ids"`
+	// RemovedUserAccesses and RemovedTeamAccesses are the IDs of the users and teams that lost their access
+	// to the environment
+	RemovedUserAccesses []int `json:"removed_user_accesses"`
+	RemovedTeamAccesses []int `json:"removed_team_accesses"`
+}
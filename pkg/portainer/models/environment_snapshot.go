@@ -0,0 +1,22 @@
+package models
+
+// EnvironmentSnapshot holds the counts of the latest snapshot Portainer took of an environment.
+// The container, stack, image and volume counts are only reported by the Docker snapshots, the
+// Kubernetes snapshots only report their nodes.
+type EnvironmentSnapshot struct {
+	EnvironmentID   int    `json:"environment_id"`
+	EnvironmentName string `json:"environment_name"`
+	Type            string `json:"type"`
+	Status          string `json:"status"`
+	// SnapshotTime is the time of the latest snapshot in Unix seconds, 0 when the environment has no snapshot
+	// or could not be inspected
+	SnapshotTime        int64 `json:"snapshot_time"`
+	RunningContainers   int   `json:"running_containers"`
+	StoppedContainers   int   `json:"stopped_containers"`
+	HealthyContainers   int   `json:"healthy_containers"`
+	UnhealthyContainers int   `json:"unhealthy_containers"`
+	Stacks              int   `json:"stacks"`
+	Images              int   `json:"images"`
+	Volumes             int   `json:"volumes"`
+	Nodes               int   `json:"nodes"`
+}
@@ -231,3 +231,51 @@ func TestConvertEnvironmentType(t *testing.T) {
 		})
 	}
 }
+
+func TestIsDockerEnvironment(t *testing.T) {
+	tests := []struct {
+		environmentType string
+		want            bool
+	}{
+		{EnvironmentTypeDockerLocal, true},
+		{EnvironmentTypeDockerAgent, true},
+		{EnvironmentTypeDockerEdgeAgent, true},
+		{EnvironmentTypeAzureACI, false},
+		{EnvironmentTypeKubernetesLocal, false},
+		{EnvironmentTypeKubernetesAgent, false},
+		{EnvironmentTypeKubernetesEdgeAgent, false},
+		{EnvironmentTypeUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environmentType, func(t *testing.T) {
+			if got := IsDockerEnvironment(tt.environmentType); got != tt.want {
+				t.Errorf("IsDockerEnvironment(%q) = %v, want %v", tt.environmentType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsKubernetesEnvironment(t *testing.T) {
+	tests := []struct {
+		environmentType string
+		want            bool
+	}{
+		{EnvironmentTypeDockerLocal, false},
+		{EnvironmentTypeDockerAgent, false},
+		{EnvironmentTypeDockerEdgeAgent, false},
+		{EnvironmentTypeAzureACI, false},
+		{EnvironmentTypeKubernetesLocal, true},
+		{EnvironmentTypeKubernetesAgent, true},
+		{EnvironmentTypeKubernetesEdgeAgent, true},
+		{EnvironmentTypeUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environmentType, func(t *testing.T) {
+			if got := IsKubernetesEnvironment(tt.environmentType); got != tt.want {
+				t.Errorf("IsKubernetesEnvironment(%q) = %v, want %v", tt.environmentType, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+// FleetStats is a summary of all the environments managed by Portainer, aggregated from the latest
+// snapshot of each environment. The container and stack counts are those of the Docker environments,
+// Kubernetes snapshots do not report them.
+type FleetStats struct {
+	TotalEnvironments   int `json:"total_environments"`
+	OnlineEnvironments  int `json:"online_environments"`
+	OfflineEnvironments int `json:"offline_environments"`
+	TotalStacks         int `json:"total_stacks"`
+	TotalContainers     int `json:"total_containers"`
+	RunningContainers   int `json:"running_containers"`
+	// EnvironmentsWithoutSnapshot counts the reachable environments with no Docker snapshot, such as
+	// Kubernetes environments or Docker environments that were never snapshotted
+	EnvironmentsWithoutSnapshot int `json:"environments_without_snapshot"`
+	// UnreachableEnvironments counts the environments Portainer failed to return, they are left out of
+	// the stack and container counts
+	UnreachableEnvironments   int   `json:"unreachable_environments"`
+	UnreachableEnvironmentIDs []int `json:"unreachable_environment_ids,omitempty"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"regexp"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// GPUConfig represents a GPU made available to the containers of a Docker environment.
+type GPUConfig struct {
+	// Name is the display name of the GPU (e.g. "gpu0").
+	Name string `json:"name"`
+	// DeviceID identifies the GPU on the Docker host: either its index (e.g. "0") or its UUID (e.g. "GPU-...", "MIG-...").
+	DeviceID string `json:"device_id"`
+}
+
+// EnvironmentGPUSettings represents the GPU configuration of a Docker environment.
+type EnvironmentGPUSettings struct {
+	EnableGPUManagement bool        `json:"enable_gpu_management"`
+	GPUs                []GPUConfig `json:"gpus"`
+}
+
+// gpuDeviceIDPattern matches the device identifiers accepted by the Docker --gpus option:
+// a GPU index, a GPU UUID or a MIG device UUID as reported by nvidia-smi -L
+var gpuDeviceIDPattern = regexp.MustCompile(`^(\d+|GPU-[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}|MIG-[0-9a-zA-Z/-]+)$`)
+
+// IsValidGPUDeviceID checks if a GPU device identifier is a GPU index or a GPU/MIG UUID.
+func IsValidGPUDeviceID(deviceID string) bool {
+	return gpuDeviceIDPattern.MatchString(deviceID)
+}
+
+func ConvertToEnvironmentGPUSettings(rawEndpoint *apimodels.PortainereeEndpoint) EnvironmentGPUSettings {
+	gpus := make([]GPUConfig, 0, len(rawEndpoint.Gpus))
+	for _, gpu := range rawEndpoint.Gpus {
+		gpus = append(gpus, GPUConfig{
+			Name:     gpu.Name,
+			DeviceID: gpu.Value,
+		})
+	}
+
+	return EnvironmentGPUSettings{
+		EnableGPUManagement: rawEndpoint.EnableGPUManagement,
+		GPUs:                gpus,
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+func TestIsValidGPUDeviceID(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviceID string
+		want     bool
+	}{
+		{"Index", "0", true},
+		{"MultiDigitIndex", "12", true},
+		{"GPUUUID", "GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d", true},
+		{"MIGUUID", "MIG-GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d/1/0", true},
+		{"Empty", "", false},
+		{"NegativeIndex", "-1", false},
+		{"All", "all", false},
+		{"TruncatedUUID", "GPU-3a1b2c4d", false},
+		{"Name", "nvidia", false},
+		{"Whitespace", " 0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidGPUDeviceID(tt.deviceID); got != tt.want {
+				t.Errorf("IsValidGPUDeviceID(%q) = %v, want %v", tt.deviceID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertToEnvironmentGPUSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *models.PortainereeEndpoint
+		expected EnvironmentGPUSettings
+	}{
+		{
+			name: "gpu management enabled",
+			input: &models.PortainereeEndpoint{
+				EnableGPUManagement: true,
+				Gpus: []*models.PortainerPair{
+					{Name: "gpu0", Value: "0"},
+					{Name: "gpu1", Value: "GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"},
+				},
+			},
+			expected: EnvironmentGPUSettings{
+				EnableGPUManagement: true,
+				GPUs: []GPUConfig{
+					{Name: "gpu0", DeviceID: "0"},
+					{Name: "gpu1", DeviceID: "GPU-3a1b2c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"},
+				},
+			},
+		},
+		{
+			name:  "no gpus",
+			input: &models.PortainereeEndpoint{},
+			expected: EnvironmentGPUSettings{
+				GPUs: []GPUConfig{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToEnvironmentGPUSettings(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToEnvironmentGPUSettings() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -12,6 +12,14 @@ type Group struct {
 	TagIds         []int  `json:"tag_ids"`
 }
 
+// GroupSpec describes an environment group to create. The environments of the group can be
+// referenced by ID, by name or both, the names being resolved to the IDs of the environments.
+type GroupSpec struct {
+	Name             string   `json:"name"`
+	EnvironmentIds   []int    `json:"environment_ids"`
+	EnvironmentNames []string `json:"environment_names"`
+}
+
 func ConvertEdgeGroupToGroup(rawEdgeGroup *apimodels.EdgegroupsDecoratedEdgeGroup) Group {
 	return Group{
 		ID:             int(rawEdgeGroup.ID),
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// chartVersionPattern matches the SemVer 2 version of a Helm chart
+var chartVersionPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// HelmRelease represents a Helm release installed in a Kubernetes environment.
+type HelmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Chart     string `json:"chart"`
+	// Version is the version of the chart, AppVersion the version of the application it packages
+	Version    string `json:"version"`
+	AppVersion string `json:"app_version,omitempty"`
+	Revision   string `json:"revision"`
+	Status     string `json:"status"`
+	Updated    string `json:"updated,omitempty"`
+}
+
+func ConvertToHelmRelease(rawRelease *apimodels.ReleaseReleaseElement) HelmRelease {
+	chart, version := splitChartReference(rawRelease.Chart)
+
+	return HelmRelease{
+		Name:       rawRelease.Name,
+		Namespace:  rawRelease.Namespace,
+		Chart:      chart,
+		Version:    version,
+		AppVersion: rawRelease.AppVersion,
+		Revision:   rawRelease.Revision,
+		Status:     rawRelease.Status,
+		Updated:    rawRelease.Updated,
+	}
+}
+
+// splitChartReference splits a chart reference as listed by Helm, e.g. "cert-manager-v1.14.2",
+// into the chart name and the chart version. Chart names may contain dashes and versions may
+// have a pre-release suffix, so the version is the longest suffix matching a SemVer version.
+// The reference is returned as the chart name when it holds no version.
+func splitChartReference(reference string) (string, string) {
+	for i := strings.Index(reference, "-"); i != -1; {
+		if chartVersionPattern.MatchString(reference[i+1:]) {
+			return reference[:i], reference[i+1:]
+		}
+
+		next := strings.Index(reference[i+1:], "-")
+		if next == -1 {
+			break
+		}
+		i += next + 1
+	}
+
+	return reference, ""
+}
+
+// HelmInstallOptions defines a Helm chart to install, or to upgrade when the release already exists.
+type HelmInstallOptions struct {
+	// Repo is the URL of the Helm repository holding the chart, e.g. https://charts.bitnami.com/bitnami
+	Repo  string
+	Chart string
+	// Version is the version of the chart, the latest version is installed when empty
+	Version   string
+	Namespace string
+	// Name is the name of the release
+	Name string
+	// Values overrides the default values of the chart, in YAML or JSON format
+	Values string
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertToHelmRelease(t *testing.T) {
+	rawRelease := &apimodels.ReleaseReleaseElement{
+		Name:       "cert-manager",
+		Namespace:  "cert-manager",
+		Chart:      "cert-manager-v1.14.2",
+		AppVersion: "v1.14.2",
+		Revision:   "3",
+		Status:     "deployed",
+		Updated:    "2026-10-01 09:12:44.123456 +0000 UTC",
+	}
+
+	expected := HelmRelease{
+		Name:       "cert-manager",
+		Namespace:  "cert-manager",
+		Chart:      "cert-manager",
+		Version:    "v1.14.2",
+		AppVersion: "v1.14.2",
+		Revision:   "3",
+		Status:     "deployed",
+		Updated:    "2026-10-01 09:12:44.123456 +0000 UTC",
+	}
+
+	assert.Equal(t, expected, ConvertToHelmRelease(rawRelease))
+}
+
+func TestSplitChartReference(t *testing.T) {
+	tests := []struct {
+		reference       string
+		expectedChart   string
+		expectedVersion string
+	}{
+		{"nginx-15.0.0", "nginx", "15.0.0"},
+		{"cert-manager-v1.14.2", "cert-manager", "v1.14.2"},
+		{"app-1.2.3-rc-1", "app", "1.2.3-rc-1"},
+		{"redis-6-1.0.0+build.5", "redis-6", "1.0.0+build.5"},
+		{"no-version", "no-version", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			chart, version := splitChartReference(tt.reference)
+			assert.Equal(t, tt.expectedChart, chart)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
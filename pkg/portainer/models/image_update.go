@@ -0,0 +1,20 @@
+package models
+
+// Statuses of the update check of an image
+const (
+	ImageUpdateAvailable = "update_available"
+	ImageUpdateUpToDate  = "up_to_date"
+	ImageUpdateUnknown   = "unknown"
+)
+
+// ImageUpdateStatus reports whether a newer image is available in the registry for the tag
+// the running containers of an environment were created from. Reason explains an unknown status.
+type ImageUpdateStatus struct {
+	Image        string   `json:"image"`
+	ImageID      string   `json:"image_id"`
+	Containers   []string `json:"containers"`
+	LocalDigest  string   `json:"local_digest,omitempty"`
+	RemoteDigest string   `json:"remote_digest,omitempty"`
+	Status       string   `json:"status"`
+	Reason       string   `json:"reason,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// InheritedAccess describes the user and team accesses of an access group (endpoint group) and how
+// the environments of the group inherit them.
+type InheritedAccess struct {
+	AccessGroupID   int            `json:"access_group_id"`
+	AccessGroupName string         `json:"access_group_name"`
+	UserAccesses    map[int]string `json:"user_accesses"`
+	TeamAccesses    map[int]string `json:"team_accesses"`
+	// Environments are the environments of the access group, sorted by ID
+	Environments []EnvironmentInheritedAccess `json:"environments"`
+}
+
+// EnvironmentInheritedAccess describes the accesses an environment inherits from its access group.
+// An access set on the environment for a user or a team replaces the access inherited for the same
+// user or team, the replaced accesses are listed as overridden.
+type EnvironmentInheritedAccess struct {
+	EnvironmentID   int    `json:"environment_id"`
+	EnvironmentName string `json:"environment_name"`
+	// InheritedUserAccesses and InheritedTeamAccesses are the accesses of the access group applying to the environment
+	InheritedUserAccesses map[int]string `json:"inherited_user_accesses"`
+	InheritedTeamAccesses map[int]string `json:"inherited_team_accesses"`
+	// OverriddenUserAccesses and OverriddenTeamAccesses map the users and teams whose access on the environment
+	// replaces the one of the access group to their access level on the environment
+	OverriddenUserAccesses map[int]string `json:"overridden_user_accesses"`
+	OverriddenTeamAccesses map[int]string `json:"overridden_team_accesses"`
+	// DirectUserAccesses and DirectTeamAccesses are the accesses set on the environment for the users and
+	// teams without an access on the access group
+	DirectUserAccesses map[int]string `json:"direct_user_accesses"`
+	DirectTeamAccesses map[int]string `json:"direct_team_accesses"`
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+const (
+	EditionCommunity = "CE"
+	EditionBusiness  = "BE"
+)
+
+const (
+	LicenseTypeTrial        = "trial"
+	LicenseTypeSubscription = "subscription"
+	LicenseTypeUnknown      = "unknown"
+)
+
+// LicenseSummaryCommunity is the summary of the license status of a Portainer Community Edition server
+const LicenseSummaryCommunity = "no license (CE)"
+
+// LicenseInfo represents the license status of the Portainer server.
+// On Community Edition, only the edition and the summary are set.
+type LicenseInfo struct {
+	Edition       string `json:"edition"`
+	Type          string `json:"type,omitempty"`
+	Company       string `json:"company,omitempty"`
+	Valid         bool   `json:"valid"`
+	LicensedNodes int    `json:"licensed_nodes"`
+	UsedNodes     int    `json:"used_nodes"`
+	ExpiresAt     string `json:"expires_at,omitempty"`
+	Expired       bool   `json:"expired"`
+	// Summary is a human readable description of the license status,
+	// e.g. "subscription license for acme: 7 of 10 nodes used, expires on 2026-12-01 (48 days left)"
+	Summary string `json:"summary"`
+}
+
+// CommunityLicenseInfo returns the license status of a Portainer Community Edition server, which has no license
+func CommunityLicenseInfo() LicenseInfo {
+	return LicenseInfo{
+		Edition: EditionCommunity,
+		Summary: LicenseSummaryCommunity,
+	}
+}
+
+// ConvertToLicenseInfo converts the raw license info of a Portainer Business Edition server to a LicenseInfo.
+// The expiry of the license is evaluated against now.
+func ConvertToLicenseInfo(rawInfo *apimodels.LicensesLicenseInfo, usedNodes int64, now time.Time) LicenseInfo {
+	info := LicenseInfo{
+		Edition:       EditionBusiness,
+		Type:          convertLicenseType(rawInfo.Type),
+		Company:       rawInfo.Company,
+		Valid:         rawInfo.Valid,
+		LicensedNodes: int(rawInfo.Nodes),
+		UsedNodes:     int(usedNodes),
+	}
+
+	summary := fmt.Sprintf("%s license", info.Type)
+	if info.Company != "" {
+		summary += fmt.Sprintf(" for %s", info.Company)
+	}
+	summary += fmt.Sprintf(": %d of %d nodes used", info.UsedNodes, info.LicensedNodes)
+	if info.LicensedNodes > 0 && info.UsedNodes > info.LicensedNodes {
+		summary += " (over the licensed node count)"
+	}
+
+	if rawInfo.ExpiresAt > 0 {
+		expiresAt := time.Unix(rawInfo.ExpiresAt, 0).UTC()
+		info.ExpiresAt = expiresAt.Format(time.RFC3339)
+		info.Expired = !now.Before(expiresAt)
+
+		if info.Expired {
+			summary += fmt.Sprintf(", expired on %s", expiresAt.Format(time.DateOnly))
+		} else {
+			days := int(expiresAt.Sub(now).Hours() / 24)
+			summary += fmt.Sprintf(", expires on %s (%d days left)", expiresAt.Format(time.DateOnly), days)
+		}
+	}
+
+	if !info.Valid {
+		summary += ", not valid"
+	}
+
+	info.Summary = summary
+	return info
+}
+
+func convertLicenseType(licenseType int64) string {
+	switch licenseType {
+	case 1:
+		return LicenseTypeTrial
+	case 2:
+		return LicenseTypeSubscription
+	default:
+		return LicenseTypeUnknown
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertToLicenseInfo(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		rawInfo   *apimodels.LicensesLicenseInfo
+		usedNodes int64
+		expected  LicenseInfo
+	}{
+		{
+			name: "valid subscription",
+			rawInfo: &apimodels.LicensesLicenseInfo{
+				Company:   "acme",
+				ExpiresAt: now.Add(48 * 24 * time.Hour).Unix(),
+				Nodes:     10,
+				Type:      2,
+				Valid:     true,
+			},
+			usedNodes: 7,
+			expected: LicenseInfo{
+				Edition:       EditionBusiness,
+				Type:          LicenseTypeSubscription,
+				Company:       "acme",
+				Valid:         true,
+				LicensedNodes: 10,
+				UsedNodes:     7,
+				ExpiresAt:     "2026-12-01T12:00:00Z",
+				Summary:       "subscription license for acme: 7 of 10 nodes used, expires on 2026-12-01 (48 days left)",
+			},
+		},
+		{
+			name: "expired trial over node count",
+			rawInfo: &apimodels.LicensesLicenseInfo{
+				ExpiresAt: now.Add(-24 * time.Hour).Unix(),
+				Nodes:     5,
+				Type:      1,
+			},
+			usedNodes: 6,
+			expected: LicenseInfo{
+				Edition:       EditionBusiness,
+				Type:          LicenseTypeTrial,
+				LicensedNodes: 5,
+				UsedNodes:     6,
+				ExpiresAt:     "2026-10-13T12:00:00Z",
+				Expired:       true,
+				Summary:       "trial license: 6 of 5 nodes used (over the licensed node count), expired on 2026-10-13, not valid",
+			},
+		},
+		{
+			name: "no expiry and unknown type",
+			rawInfo: &apimodels.LicensesLicenseInfo{
+				Nodes: 3,
+				Type:  42,
+				Valid: true,
+			},
+			usedNodes: 1,
+			expected: LicenseInfo{
+				Edition:       EditionBusiness,
+				Type:          LicenseTypeUnknown,
+				Valid:         true,
+				LicensedNodes: 3,
+				UsedNodes:     1,
+				Summary:       "unknown license: 1 of 3 nodes used",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ConvertToLicenseInfo(tt.rawInfo, tt.usedNodes, now))
+		})
+	}
+}
+
+func TestCommunityLicenseInfo(t *testing.T) {
+	info := CommunityLicenseInfo()
+
+	assert.Equal(t, EditionCommunity, info.Edition)
+	assert.Equal(t, LicenseSummaryCommunity, info.Summary)
+	assert.Empty(t, info.Type)
+}
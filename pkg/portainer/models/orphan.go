@@ -0,0 +1,25 @@
+package models
+
+// OrphanedVolume is a Docker volume that no container, running or stopped, mounts
+type OrphanedVolume struct {
+	Name      string            `json:"name"`
+	Driver    string            `json:"driver"`
+	CreatedAt string            `json:"created_at,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// OrphanedNetwork is a Docker network that no container, running or stopped, is attached to
+type OrphanedNetwork struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Driver string `json:"driver"`
+	Scope  string `json:"scope"`
+}
+
+// OrphanReport lists the volumes and the networks of a Docker environment that are not used by any
+// container, the candidates of a cleanup
+type OrphanReport struct {
+	EnvironmentID int               `json:"environment_id"`
+	Volumes       []OrphanedVolume  `json:"volumes"`
+	Networks      []OrphanedNetwork `json:"networks"`
+}
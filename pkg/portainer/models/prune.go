@@ -0,0 +1,45 @@
+package models
+
+// Docker resources that can be pruned
+const (
+	PruneResourceContainers = "containers"
+	PruneResourceNetworks   = "networks"
+	PruneResourceBuildCache = "build_cache"
+)
+
+// AllPruneResources is the list of the Docker resources that can be pruned, in the order they are pruned
+var AllPruneResources = []string{
+	PruneResourceContainers,
+	PruneResourceNetworks,
+	PruneResourceBuildCache,
+}
+
+// PruneReport is what was removed by the prune of a kind of Docker resource.
+// Networks take no disk space, SpaceReclaimed is always zero for them.
+type PruneReport struct {
+	Resource       string   `json:"resource"`
+	Deleted        []string `json:"deleted"`
+	SpaceReclaimed uint64   `json:"space_reclaimed"`
+}
+
+// PruneSummary aggregates the reports of several prunes of a Docker environment.
+type PruneSummary struct {
+	Reports             []PruneReport `json:"reports"`
+	TotalDeleted        int           `json:"total_deleted"`
+	TotalSpaceReclaimed uint64        `json:"total_space_reclaimed"`
+}
+
+// SummarizePruneReports aggregates prune reports into a single summary, keeping their order.
+func SummarizePruneReports(reports []PruneReport) PruneSummary {
+	summary := PruneSummary{
+		Reports: make([]PruneReport, 0, len(reports)),
+	}
+
+	for _, report := range reports {
+		summary.Reports = append(summary.Reports, report)
+		summary.TotalDeleted += len(report.Deleted)
+		summary.TotalSpaceReclaimed += report.SpaceReclaimed
+	}
+
+	return summary
+}
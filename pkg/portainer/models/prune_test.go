@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizePruneReports(t *testing.T) {
+	tests := []struct {
+		name    string
+		reports []PruneReport
+		want    PruneSummary
+	}{
+		{
+			name: "several reports",
+			reports: []PruneReport{
+				{Resource: PruneResourceContainers, Deleted: []string{"4f2a", "9c1e"}, SpaceReclaimed: 1024},
+				{Resource: PruneResourceNetworks, Deleted: []string{"frontend"}},
+				{Resource: PruneResourceBuildCache, Deleted: []string{}, SpaceReclaimed: 0},
+			},
+			want: PruneSummary{
+				Reports: []PruneReport{
+					{Resource: PruneResourceContainers, Deleted: []string{"4f2a", "9c1e"}, SpaceReclaimed: 1024},
+					{Resource: PruneResourceNetworks, Deleted: []string{"frontend"}},
+					{Resource: PruneResourceBuildCache, Deleted: []string{}, SpaceReclaimed: 0},
+				},
+				TotalDeleted:        3,
+				TotalSpaceReclaimed: 1024,
+			},
+		},
+		{
+			name:    "no reports",
+			reports: nil,
+			want:    PruneSummary{Reports: []PruneReport{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SummarizePruneReports(tt.reports))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"slices"
+	"strconv"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// EnvironmentRegistry is a registry as seen from a single environment. Enabled reports whether
+// users of the environment can pull from the registry with its credentials, administrators can
+// always use every registry.
+type EnvironmentRegistry struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+	UserIds []int  `json:"user_ids"`
+	TeamIds []int  `json:"team_ids"`
+}
+
+// ConvertToEnvironmentRegistry converts a raw registry into the registry as seen from the given environment
+func ConvertToEnvironmentRegistry(rawRegistry *apimodels.PortainereeRegistry, environmentId int) EnvironmentRegistry {
+	registry := EnvironmentRegistry{
+		ID:      int(rawRegistry.ID),
+		Name:    rawRegistry.Name,
+		URL:     rawRegistry.URL,
+		UserIds: []int{},
+		TeamIds: []int{},
+	}
+
+	access, ok := rawRegistry.RegistryAccesses[strconv.Itoa(environmentId)]
+	if !ok {
+		return registry
+	}
+
+	for id := range convertAccesses(access.UserAccessPolicies) {
+		registry.UserIds = append(registry.UserIds, id)
+	}
+	for id := range convertAccesses(access.TeamAccessPolicies) {
+		registry.TeamIds = append(registry.TeamIds, id)
+	}
+	slices.Sort(registry.UserIds)
+	slices.Sort(registry.TeamIds)
+	registry.Enabled = len(registry.UserIds) > 0 || len(registry.TeamIds) > 0
+
+	return registry
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"testing"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertToEnvironmentRegistry(t *testing.T) {
+	tests := []struct {
+		name          string
+		registry      *apimodels.PortainereeRegistry
+		environmentId int
+		want          EnvironmentRegistry
+	}{
+		{
+			name: "registry enabled on the environment",
+			registry: &apimodels.PortainereeRegistry{
+				ID:   1,
+				Name: "ghcr",
+				URL:  "ghcr.io",
+				RegistryAccesses: apimodels.PortainerRegistryAccesses{
+					"3": {
+						UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"7": {}, "2": {}},
+						TeamAccessPolicies: apimodels.PortainerTeamAccessPolicies{"4": {}},
+					},
+					"5": {
+						UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"9": {}},
+					},
+				},
+			},
+			environmentId: 3,
+			want: EnvironmentRegistry{
+				ID:      1,
+				Name:    "ghcr",
+				URL:     "ghcr.io",
+				Enabled: true,
+				UserIds: []int{2, 7},
+				TeamIds: []int{4},
+			},
+		},
+		{
+			name: "registry not configured on the environment",
+			registry: &apimodels.PortainereeRegistry{
+				ID:   2,
+				Name: "quay",
+				URL:  "quay.io",
+				RegistryAccesses: apimodels.PortainerRegistryAccesses{
+					"5": {
+						UserAccessPolicies: apimodels.PortainerUserAccessPolicies{"9": {}},
+					},
+				},
+			},
+			environmentId: 3,
+			want: EnvironmentRegistry{
+				ID:      2,
+				Name:    "quay",
+				URL:     "quay.io",
+				UserIds: []int{},
+				TeamIds: []int{},
+			},
+		},
+		{
+			name: "registry access cleared on the environment",
+			registry: &apimodels.PortainereeRegistry{
+				ID:   3,
+				Name: "internal",
+				URL:  "registry.example.com",
+				RegistryAccesses: apimodels.PortainerRegistryAccesses{
+					"3": {},
+				},
+			},
+			environmentId: 3,
+			want: EnvironmentRegistry{
+				ID:      3,
+				Name:    "internal",
+				URL:     "registry.example.com",
+				UserIds: []int{},
+				TeamIds: []int{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConvertToEnvironmentRegistry(tt.registry, tt.environmentId))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ResourceControl represents the ownership settings of a resource in Portainer.
+type ResourceControl struct {
+	ID                 int    `json:"id"`
+	ResourceID         string `json:"resource_id"`
+	Type               string `json:"type"`
+	Public             bool   `json:"public"`
+	AdministratorsOnly bool   `json:"administrators_only"`
+	UserIDs            []int  `json:"user_ids"`
+	TeamIDs            []int  `json:"team_ids"`
+}
+
+// ResourceAccess describes who can access a resource: its owner, the users and teams it is shared with
+// and whether it is public. Administrators can access every resource, they are listed separately.
+type ResourceAccess struct {
+	ResourceID           string `json:"resource_id"`
+	Type                 string `json:"type"`
+	Name                 string `json:"name"`
+	Public               bool   `json:"public"`
+	AdministratorsOnly   bool   `json:"administrators_only"`
+	OwnerUserID          int    `json:"owner_user_id,omitempty"`
+	UserIDs              []int  `json:"user_ids"`
+	TeamIDs              []int  `json:"team_ids"`
+	AdministratorUserIDs []int  `json:"administrator_user_ids"`
+	Note                 string `json:"note,omitempty"`
+}
+
+// ResourceControlOptions defines who can access a resource.
+// A resource is either public, restricted to administrators, or restricted to the listed users and teams.
+type ResourceControlOptions struct {
+	// Public makes the resource accessible by all users.
+	Public bool
+	// AdministratorsOnly restricts the resource to administrators.
+	AdministratorsOnly bool
+	// UserIDs are the users allowed to access a restricted resource.
+	UserIDs []int
+	// TeamIDs are the teams allowed to access a restricted resource.
+	TeamIDs []int
+}
+
+// Resource control type constants
+const (
+	ResourceTypeContainer      = "container"
+	ResourceTypeService        = "service"
+	ResourceTypeVolume         = "volume"
+	ResourceTypeNetwork        = "network"
+	ResourceTypeSecret         = "secret"
+	ResourceTypeStack          = "stack"
+	ResourceTypeConfig         = "config"
+	ResourceTypeCustomTemplate = "custom_template"
+	ResourceTypeContainerGroup = "container_group"
+	ResourceTypeUnknown        = "unknown"
+)
+
+func ConvertToResourceControl(rawResourceControl *apimodels.PortainerResourceControl) ResourceControl {
+	userIDs := make([]int, 0, len(rawResourceControl.UserAccesses))
+	for _, access := range rawResourceControl.UserAccesses {
+		userIDs = append(userIDs, int(access.UserID))
+	}
+
+	teamIDs := make([]int, 0, len(rawResourceControl.TeamAccesses))
+	for _, access := range rawResourceControl.TeamAccesses {
+		teamIDs = append(teamIDs, int(access.TeamID))
+	}
+
+	return ResourceControl{
+		ID:                 int(rawResourceControl.ID),
+		ResourceID:         rawResourceControl.ResourceID,
+		Type:               convertResourceControlType(rawResourceControl.Type),
+		Public:             rawResourceControl.Public,
+		AdministratorsOnly: rawResourceControl.AdministratorsOnly,
+		UserIDs:            userIDs,
+		TeamIDs:            teamIDs,
+	}
+}
+
+func convertResourceControlType(rawType int64) string {
+	switch rawType {
+	case 1:
+		return ResourceTypeContainer
+	case 2:
+		return ResourceTypeService
+	case 3:
+		return ResourceTypeVolume
+	case 4:
+		return ResourceTypeNetwork
+	case 5:
+		return ResourceTypeSecret
+	case 6:
+		return ResourceTypeStack
+	case 7:
+		return ResourceTypeConfig
+	case 8:
+		return ResourceTypeCustomTemplate
+	case 9:
+		return ResourceTypeContainerGroup
+	default:
+		return ResourceTypeUnknown
+	}
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+func TestConvertToResourceControl(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *models.PortainerResourceControl
+		expected ResourceControl
+	}{
+		{
+			name: "restricted volume",
+			input: &models.PortainerResourceControl{
+				ID:         4,
+				ResourceID: "data",
+				Type:       3,
+				UserAccesses: []*models.PortainerUserResourceAccess{
+					{UserID: 2, AccessLevel: 1},
+				},
+				TeamAccesses: []*models.PortainerTeamResourceAccess{
+					{TeamID: 3, AccessLevel: 1},
+					{TeamID: 5, AccessLevel: 1},
+				},
+			},
+			expected: ResourceControl{
+				ID:         4,
+				ResourceID: "data",
+				Type:       ResourceTypeVolume,
+				UserIDs:    []int{2},
+				TeamIDs:    []int{3, 5},
+			},
+		},
+		{
+			name: "public container",
+			input: &models.PortainerResourceControl{
+				ID:         1,
+				ResourceID: "abc123",
+				Type:       1,
+				Public:     true,
+			},
+			expected: ResourceControl{
+				ID:         1,
+				ResourceID: "abc123",
+				Type:       ResourceTypeContainer,
+				Public:     true,
+				UserIDs:    []int{},
+				TeamIDs:    []int{},
+			},
+		},
+		{
+			name: "administrators only stack",
+			input: &models.PortainerResourceControl{
+				ID:                 9,
+				ResourceID:         "1_shop",
+				Type:               6,
+				AdministratorsOnly: true,
+			},
+			expected: ResourceControl{
+				ID:                 9,
+				ResourceID:         "1_shop",
+				Type:               ResourceTypeStack,
+				AdministratorsOnly: true,
+				UserIDs:            []int{},
+				TeamIDs:            []int{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToResourceControl(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToResourceControl() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertResourceControlType(t *testing.T) {
+	tests := []struct {
+		input    int64
+		expected string
+	}{
+		{1, ResourceTypeContainer},
+		{2, ResourceTypeService},
+		{3, ResourceTypeVolume},
+		{4, ResourceTypeNetwork},
+		{5, ResourceTypeSecret},
+		{6, ResourceTypeStack},
+		{7, ResourceTypeConfig},
+		{8, ResourceTypeCustomTemplate},
+		{9, ResourceTypeContainerGroup},
+		{0, ResourceTypeUnknown},
+		{99, ResourceTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			result := convertResourceControlType(tt.input)
+			if result != tt.expected {
+				t.Errorf("convertResourceControlType(%d) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/portainer/portainer-mcp/pkg/portainer/utils"
+)
+
+// Schedule represents a script run on a cron schedule on edge environments, an Edge Job in Portainer.
+// The content of the script is only used to create a schedule and is never returned.
+type Schedule struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	CronExpression string `json:"cron_expression"`
+	// Recurring is false for the schedules run only once, at the first time matching their cron expression
+	Recurring           bool   `json:"recurring"`
+	CreatedAt           string `json:"created_at,omitempty"`
+	EnvironmentIds      []int  `json:"environment_ids"`
+	EnvironmentGroupIds []int  `json:"environment_group_ids"`
+	Script              string `json:"script,omitempty"`
+	// NextRuns are the next times the schedule runs, in UTC and in the RFC3339 format
+	NextRuns []string `json:"next_runs,omitempty"`
+}
+
+func ConvertToSchedule(rawEdgeJob *apimodels.PortainerEdgeJob) Schedule {
+	environmentIds := make([]int, 0, len(rawEdgeJob.Endpoints))
+	for id := range rawEdgeJob.Endpoints {
+		if environmentId, err := strconv.Atoi(id); err == nil {
+			environmentIds = append(environmentIds, environmentId)
+		}
+	}
+	slices.Sort(environmentIds)
+
+	schedule := Schedule{
+		ID:                  int(rawEdgeJob.ID),
+		Name:                rawEdgeJob.Name,
+		CronExpression:      rawEdgeJob.CronExpression,
+		Recurring:           rawEdgeJob.Recurring,
+		EnvironmentIds:      environmentIds,
+		EnvironmentGroupIds: utils.Int64ToIntSlice(rawEdgeJob.EdgeGroups),
+	}
+	if rawEdgeJob.Created > 0 {
+		schedule.CreatedAt = time.Unix(rawEdgeJob.Created, 0).Format(time.RFC3339)
+	}
+
+	return schedule
+}
@@ -0,0 +1,52 @@
+package models
+
+import apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+
+// Statuses of the result of a schedule on an environment
+const (
+	// ScheduleResultStatusCollected is a result whose logs were sent by the edge agent of the environment
+	ScheduleResultStatusCollected = "collected"
+	// ScheduleResultStatusPending is a result not reported yet, its logs were never requested or the edge
+	// agent did not send them yet
+	ScheduleResultStatusPending = "pending"
+)
+
+// Logs statuses of the tasks of an Edge Job in Portainer
+const (
+	edgeJobLogsStatusPending   = 2
+	edgeJobLogsStatusCollected = 3
+)
+
+// ScheduleResult is the result of the last run of a schedule on one of its environments. Portainer does
+// not record the exit code of the scripts, only their output, which the edge agent sends on its next
+// check-in once the logs of the environment are requested from Portainer.
+type ScheduleResult struct {
+	ScheduleID    int `json:"schedule_id"`
+	EnvironmentID int `json:"environment_id"`
+	// Status is ScheduleResultStatusCollected or ScheduleResultStatusPending
+	Status string `json:"status"`
+	// LogsRequested is true for the pending results whose logs were requested from the edge agent
+	LogsRequested bool   `json:"logs_requested,omitempty"`
+	Log           string `json:"log,omitempty"`
+	// LogTruncated is true when Log only holds the end of the logs
+	LogTruncated bool `json:"log_truncated,omitempty"`
+	// Error is set when the collected logs could not be read
+	Error string `json:"error,omitempty"`
+}
+
+func ConvertToScheduleResult(scheduleId int, rawTask *apimodels.EdgejobsTaskContainer) ScheduleResult {
+	result := ScheduleResult{
+		ScheduleID:    scheduleId,
+		EnvironmentID: int(rawTask.EndpointID),
+		Status:        ScheduleResultStatusPending,
+	}
+
+	switch rawTask.LogsStatus {
+	case edgeJobLogsStatusCollected:
+		result.Status = ScheduleResultStatusCollected
+	case edgeJobLogsStatusPending:
+		result.LogsRequested = true
+	}
+
+	return result
+}
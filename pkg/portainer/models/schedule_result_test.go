@@ -0,0 +1,54 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+func TestConvertToScheduleResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *models.EdgejobsTaskContainer
+		expected ScheduleResult
+	}{
+		{
+			name:  "collected logs",
+			input: &models.EdgejobsTaskContainer{ID: "edgejob_task_3_1", EndpointID: 1, LogsStatus: 3},
+			expected: ScheduleResult{
+				ScheduleID:    3,
+				EnvironmentID: 1,
+				Status:        ScheduleResultStatusCollected,
+			},
+		},
+		{
+			name:  "requested logs",
+			input: &models.EdgejobsTaskContainer{ID: "edgejob_task_3_2", EndpointID: 2, LogsStatus: 2},
+			expected: ScheduleResult{
+				ScheduleID:    3,
+				EnvironmentID: 2,
+				Status:        ScheduleResultStatusPending,
+				LogsRequested: true,
+			},
+		},
+		{
+			name:  "logs never requested",
+			input: &models.EdgejobsTaskContainer{ID: "edgejob_task_3_4", EndpointID: 4, LogsStatus: 1},
+			expected: ScheduleResult{
+				ScheduleID:    3,
+				EnvironmentID: 4,
+				Status:        ScheduleResultStatusPending,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToScheduleResult(3, tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToScheduleResult() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
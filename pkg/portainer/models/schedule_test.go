@@ -0,0 +1,69 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+func TestConvertToSchedule(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name     string
+		input    *models.PortainerEdgeJob
+		expected Schedule
+	}{
+		{
+			name: "recurring schedule on environments",
+			input: &models.PortainerEdgeJob{
+				ID:             1,
+				Name:           "cleanup",
+				CronExpression: "0 3 * * *",
+				Recurring:      true,
+				Created:        now,
+				Endpoints: map[string]models.PortainerEdgeJobEndpointMeta{
+					"7": {},
+					"2": {CollectLogs: true},
+				},
+				EdgeGroups: []int64{},
+			},
+			expected: Schedule{
+				ID:                  1,
+				Name:                "cleanup",
+				CronExpression:      "0 3 * * *",
+				Recurring:           true,
+				CreatedAt:           time.Unix(now, 0).Format(time.RFC3339),
+				EnvironmentIds:      []int{2, 7},
+				EnvironmentGroupIds: []int{},
+			},
+		},
+		{
+			name: "one-time schedule on environment groups",
+			input: &models.PortainerEdgeJob{
+				ID:             2,
+				Name:           "reboot",
+				CronExpression: "30 4 1 6 *",
+				EdgeGroups:     []int64{3},
+			},
+			expected: Schedule{
+				ID:                  2,
+				Name:                "reboot",
+				CronExpression:      "30 4 1 6 *",
+				EnvironmentIds:      []int{},
+				EnvironmentGroupIds: []int{3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToSchedule(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToSchedule() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+// RedactedValue replaces the secrets of the server configuration
+const RedactedValue = "[REDACTED]"
+
+// ServerConfig is the effective configuration of a running MCP server, as built from its functional options.
+// Secrets are replaced with RedactedValue. Durations are formatted as Go durations, e.g. 1m30s, and are empty
+// when the option is not set. The versions are empty when the server hides its version information.
+type ServerConfig struct {
+	Version                   string `json:"version,omitempty"`
+	SupportedPortainerVersion string `json:"supported_portainer_version,omitempty"`
+	// PortainerURL is the URL of the Portainer server, with the password of its user information redacted
+	PortainerURL string `json:"portainer_url"`
+	// Token is RedactedValue when an API token is configured, empty otherwise
+	Token               string `json:"token"`
+	ToolsPath           string `json:"tools_path"`
+	ReadOnly            bool   `json:"read_only"`
+	DisableVersionCheck bool   `json:"disable_version_check"`
+	HideVersionInfo     bool   `json:"hide_version_info"`
+	CachedVersion       string `json:"cached_version,omitempty"`
+	VersionCacheTTL     string `json:"version_cache_ttl,omitempty"`
+	StartupAttempts     int    `json:"startup_attempts"`
+	StartupDelay        string `json:"startup_delay,omitempty"`
+	ResponseFormat      string `json:"response_format"`
+	// MaxResponseBytes is the maximum size of the text of a tool result, 0 when results are not truncated
+	MaxResponseBytes int      `json:"max_response_bytes"`
+	ToolPriority     []string `json:"tool_priority"`
+	RegisteredTools  int      `json:"registered_tools"`
+	// Transport is stdio or http once the server is started, empty before
+	Transport       string `json:"transport,omitempty"`
+	HTTPPort        int    `json:"http_port,omitempty"`
+	HTTPEndpoint    string `json:"http_endpoint,omitempty"`
+	HTTPCompression bool   `json:"http_compression"`
+	Tracing         bool   `json:"tracing"`
+	// CustomClient is set when the server was created with a custom Portainer client, the client settings
+	// below are then not applied
+	CustomClient  bool `json:"custom_client"`
+	SkipTLSVerify bool `json:"skip_tls_verify"`
+	// ConnectionPool is the connection pool of the HTTP transport, nil when the defaults are used
+	ConnectionPool            *ConnectionPoolConfig `json:"connection_pool,omitempty"`
+	AllowedStackURLHosts      []string              `json:"allowed_stack_url_hosts"`
+	EdgeAgentOfflineThreshold string                `json:"edge_agent_offline_threshold,omitempty"`
+	DockerAPIVersion          string                `json:"docker_api_version,omitempty"`
+	// SnapshotMetricsTTL is how long the snapshot metrics are cached, empty when they are not served
+	SnapshotMetricsTTL string `json:"snapshot_metrics_ttl,omitempty"`
+}
+
+// ConnectionPoolConfig is the connection pool of the HTTP transport used to talk to the Portainer server
+type ConnectionPoolConfig struct {
+	MaxIdleConns        int    `json:"max_idle_conns"`
+	MaxIdleConnsPerHost int    `json:"max_idle_conns_per_host"`
+	IdleConnTimeout     string `json:"idle_conn_timeout,omitempty"`
+}
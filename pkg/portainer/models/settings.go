@@ -1,10 +1,15 @@
 package models
 
-import apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+import (
+	"slices"
+
+	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
+)
 
 type PortainerSettings struct {
 	Authentication struct {
-		Method string `json:"method"`
+		Method         string `json:"method"`
+		SessionTimeout string `json:"session_timeout"`
 	} `json:"authentication"`
 	Edge struct {
 		Enabled   bool   `json:"enabled"`
@@ -19,16 +24,156 @@ const (
 	AuthenticationMethodUnknown  = "unknown"
 )
 
+// AuthSettings represents the authentication settings of the Portainer instance.
+type AuthSettings struct {
+	Method string         `json:"method"`
+	OAuth  *OAuthSettings `json:"oauth,omitempty"`
+}
+
+// OAuthSettings represents the key OAuth settings of the Portainer instance.
+// ClientSecret is write-only, it is never populated from the Portainer settings.
+type OAuthSettings struct {
+	ClientID         string `json:"client_id"`
+	ClientSecret     string `json:"client_secret,omitempty"`
+	AuthorizationURI string `json:"authorization_uri"`
+	AccessTokenURI   string `json:"access_token_uri"`
+	ResourceURI      string `json:"resource_uri"`
+	RedirectURI      string `json:"redirect_uri"`
+	LogoutURI        string `json:"logout_uri"`
+	UserIdentifier   string `json:"user_identifier"`
+	Scopes           string `json:"scopes"`
+	SSO              bool   `json:"sso"`
+	AutoCreateUsers  bool   `json:"auto_create_users"`
+	DefaultTeamID    int    `json:"default_team_id"`
+}
+
+// SSLSettings represents the TLS settings of the Portainer server.
+// Certificate and Key are write-only, they are only used to upload a new certificate and are
+// never populated from the Portainer SSL settings. Key is never serialized.
+type SSLSettings struct {
+	CertPath    string `json:"cert_path"`
+	KeyPath     string `json:"key_path"`
+	CACertPath  string `json:"ca_cert_path,omitempty"`
+	SelfSigned  bool   `json:"self_signed"`
+	HTTPEnabled bool   `json:"http_enabled"`
+	Certificate string `json:"certificate,omitempty"`
+	Key         string `json:"-"`
+}
+
+// Branding represents the branding settings of the Portainer instance, the logo and the banner
+// displayed on the login page.
+type Branding struct {
+	LogoURL     string `json:"logo_url"`
+	LoginBanner string `json:"login_banner"`
+}
+
+// PublicSettings represents the settings Portainer exposes without authentication, the ones the
+// login page relies on. OAuthLoginURI and OAuthLogoutURI are only set when the OAuth
+// authentication is configured.
+type PublicSettings struct {
+	AuthenticationMethod       string          `json:"authentication_method"`
+	OAuthLoginURI              string          `json:"oauth_login_uri,omitempty"`
+	OAuthLogoutURI             string          `json:"oauth_logout_uri,omitempty"`
+	OAuthHideInternalAuth      bool            `json:"oauth_hide_internal_auth"`
+	TeamSync                   bool            `json:"team_sync"`
+	RequiredPasswordLength     int             `json:"required_password_length"`
+	LogoURL                    string          `json:"logo_url,omitempty"`
+	LoginBanner                string          `json:"login_banner,omitempty"`
+	EdgeComputeEnabled         bool            `json:"edge_compute_enabled"`
+	TelemetryEnabled           bool            `json:"telemetry_enabled"`
+	KubeShellDisabled          bool            `json:"kube_shell_disabled"`
+	KubeconfigDownloadDisabled bool            `json:"kubeconfig_download_disabled"`
+	Features                   map[string]bool `json:"features,omitempty"`
+}
+
+// AllAuthenticationMethods lists the authentication methods that can be configured
+var AllAuthenticationMethods = []string{
+	AuthenticationMethodInternal,
+	AuthenticationMethodLDAP,
+	AuthenticationMethodOAuth,
+}
+
+// IsValidAuthenticationMethod checks if the authentication method can be configured
+func IsValidAuthenticationMethod(method string) bool {
+	return slices.Contains(AllAuthenticationMethods, method)
+}
+
 func ConvertSettingsToPortainerSettings(rawSettings *apimodels.PortainereeSettings) PortainerSettings {
 	s := PortainerSettings{}
 
 	s.Authentication.Method = convertAuthenticationMethod(rawSettings.AuthenticationMethod)
+	s.Authentication.SessionTimeout = rawSettings.UserSessionTimeout
 	s.Edge.Enabled = rawSettings.EnableEdgeComputeFeatures
 	s.Edge.ServerURL = rawSettings.Edge.TunnelServerAddress
 
 	return s
 }
 
+// ConvertSettingsToAuthSettings converts the raw settings to the authentication settings.
+// The OAuth client secret is never copied.
+func ConvertSettingsToAuthSettings(rawSettings *apimodels.PortainereeSettings) AuthSettings {
+	s := AuthSettings{
+		Method: convertAuthenticationMethod(rawSettings.AuthenticationMethod),
+	}
+
+	if rawSettings.OAuthSettings != nil {
+		oauth := rawSettings.OAuthSettings
+		s.OAuth = &OAuthSettings{
+			ClientID:         oauth.ClientID,
+			AuthorizationURI: oauth.AuthorizationURI,
+			AccessTokenURI:   oauth.AccessTokenURI,
+			ResourceURI:      oauth.ResourceURI,
+			RedirectURI:      oauth.RedirectURI,
+			LogoutURI:        oauth.LogoutURI,
+			UserIdentifier:   oauth.UserIdentifier,
+			Scopes:           oauth.Scopes,
+			SSO:              oauth.SSO,
+			AutoCreateUsers:  oauth.OAuthAutoCreateUsers,
+			DefaultTeamID:    int(oauth.DefaultTeamID),
+		}
+	}
+
+	return s
+}
+
+// ConvertSettingsToBranding converts the raw settings to the branding settings
+func ConvertSettingsToBranding(rawSettings *apimodels.PortainereeSettings) Branding {
+	return Branding{
+		LogoURL:     rawSettings.LogoURL,
+		LoginBanner: rawSettings.CustomLoginBanner,
+	}
+}
+
+// ConvertToPublicSettings converts the raw public settings to the public settings
+func ConvertToPublicSettings(rawSettings *apimodels.SettingsPublicSettingsResponse) PublicSettings {
+	return PublicSettings{
+		AuthenticationMethod:       convertAuthenticationMethod(rawSettings.AuthenticationMethod),
+		OAuthLoginURI:              rawSettings.OAuthLoginURI,
+		OAuthLogoutURI:             rawSettings.OAuthLogoutURI,
+		OAuthHideInternalAuth:      rawSettings.OAuthHideInternalAuth,
+		TeamSync:                   rawSettings.TeamSync,
+		RequiredPasswordLength:     int(rawSettings.RequiredPasswordLength),
+		LogoURL:                    rawSettings.LogoURL,
+		LoginBanner:                rawSettings.CustomLoginBanner,
+		EdgeComputeEnabled:         rawSettings.EnableEdgeComputeFeatures,
+		TelemetryEnabled:           rawSettings.EnableTelemetry,
+		KubeShellDisabled:          rawSettings.DisableKubeShell,
+		KubeconfigDownloadDisabled: rawSettings.DisableKubeconfigDownload,
+		Features:                   rawSettings.Features,
+	}
+}
+
+// ConvertToSSLSettings converts the raw SSL settings to the SSL settings
+func ConvertToSSLSettings(rawSettings *apimodels.PortainereeSSLSettings) SSLSettings {
+	return SSLSettings{
+		CertPath:    rawSettings.CertPath,
+		KeyPath:     rawSettings.KeyPath,
+		CACertPath:  rawSettings.CaCertPath,
+		SelfSigned:  rawSettings.SelfSigned,
+		HTTPEnabled: rawSettings.HTTPEnabled,
+	}
+}
+
 func convertAuthenticationMethod(method int64) string {
 	switch method {
 	case 1:
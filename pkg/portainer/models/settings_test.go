@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/portainer/client-api-go/v2/pkg/models"
@@ -64,6 +65,7 @@ func TestConvertSettingsToPortainerSettings(t *testing.T) {
 			name: "Complete settings conversion",
 			input: &models.PortainereeSettings{
 				AuthenticationMethod:      1,
+				UserSessionTimeout:        "8h",
 				EnableEdgeComputeFeatures: true,
 				Edge: &models.PortainereeEdge{
 					TunnelServerAddress: "https://edge.example.com",
@@ -71,9 +73,11 @@ func TestConvertSettingsToPortainerSettings(t *testing.T) {
 			},
 			expectedOutput: PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
-					Method: AuthenticationMethodInternal,
+					Method:         AuthenticationMethodInternal,
+					SessionTimeout: "8h",
 				},
 				Edge: struct {
 					Enabled   bool   `json:"enabled"`
@@ -95,7 +99,8 @@ func TestConvertSettingsToPortainerSettings(t *testing.T) {
 			},
 			expectedOutput: PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
 					Method: AuthenticationMethodLDAP,
 				},
@@ -119,7 +124,8 @@ func TestConvertSettingsToPortainerSettings(t *testing.T) {
 			},
 			expectedOutput: PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
 					Method: AuthenticationMethodOAuth,
 				},
@@ -143,7 +149,8 @@ func TestConvertSettingsToPortainerSettings(t *testing.T) {
 			},
 			expectedOutput: PortainerSettings{
 				Authentication: struct {
-					Method string `json:"method"`
+					Method         string `json:"method"`
+					SessionTimeout string `json:"session_timeout"`
 				}{
 					Method: AuthenticationMethodUnknown,
 				},
@@ -179,3 +186,199 @@ func TestConvertSettingsToPortainerSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertSettingsToAuthSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *models.PortainereeSettings
+		expectedOutput AuthSettings
+	}{
+		{
+			name: "OAuth settings without client secret",
+			input: &models.PortainereeSettings{
+				AuthenticationMethod: 3,
+				OAuthSettings: &models.PortainereeOAuthSettings{
+					ClientID:             "portainer",
+					ClientSecret:         "super-secret",
+					AuthorizationURI:     "https://idp.example.com/authorize",
+					AccessTokenURI:       "https://idp.example.com/token",
+					ResourceURI:          "https://idp.example.com/userinfo",
+					RedirectURI:          "https://portainer.example.com",
+					LogoutURI:            "https://idp.example.com/logout",
+					UserIdentifier:       "email",
+					Scopes:               "openid email",
+					SSO:                  true,
+					OAuthAutoCreateUsers: true,
+					DefaultTeamID:        2,
+				},
+			},
+			expectedOutput: AuthSettings{
+				Method: AuthenticationMethodOAuth,
+				OAuth: &OAuthSettings{
+					ClientID:         "portainer",
+					AuthorizationURI: "https://idp.example.com/authorize",
+					AccessTokenURI:   "https://idp.example.com/token",
+					ResourceURI:      "https://idp.example.com/userinfo",
+					RedirectURI:      "https://portainer.example.com",
+					LogoutURI:        "https://idp.example.com/logout",
+					UserIdentifier:   "email",
+					Scopes:           "openid email",
+					SSO:              true,
+					AutoCreateUsers:  true,
+					DefaultTeamID:    2,
+				},
+			},
+		},
+		{
+			name: "Settings without OAuth settings",
+			input: &models.PortainereeSettings{
+				AuthenticationMethod: 2,
+			},
+			expectedOutput: AuthSettings{
+				Method: AuthenticationMethodLDAP,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertSettingsToAuthSettings(tt.input)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
+func TestConvertSettingsToBranding(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *models.PortainereeSettings
+		expectedOutput Branding
+	}{
+		{
+			name: "Custom logo and login banner",
+			input: &models.PortainereeSettings{
+				LogoURL:           "https://example.com/logo.png",
+				CustomLoginBanner: "Authorized users only",
+			},
+			expectedOutput: Branding{
+				LogoURL:     "https://example.com/logo.png",
+				LoginBanner: "Authorized users only",
+			},
+		},
+		{
+			name:           "Default branding",
+			input:          &models.PortainereeSettings{},
+			expectedOutput: Branding{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertSettingsToBranding(tt.input)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
+func TestConvertToPublicSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *models.SettingsPublicSettingsResponse
+		expectedOutput PublicSettings
+	}{
+		{
+			name: "OAuth authentication",
+			input: &models.SettingsPublicSettingsResponse{
+				AuthenticationMethod:      3,
+				OAuthLoginURI:             "https://sso.example.com/authorize?client_id=portainer",
+				OAuthLogoutURI:            "https://sso.example.com/logout",
+				OAuthHideInternalAuth:     true,
+				RequiredPasswordLength:    12,
+				EnableEdgeComputeFeatures: true,
+				DisableKubeconfigDownload: true,
+				Features:                  map[string]bool{"disableRoleDiagnostics": false},
+			},
+			expectedOutput: PublicSettings{
+				AuthenticationMethod:       AuthenticationMethodOAuth,
+				OAuthLoginURI:              "https://sso.example.com/authorize?client_id=portainer",
+				OAuthLogoutURI:             "https://sso.example.com/logout",
+				OAuthHideInternalAuth:      true,
+				RequiredPasswordLength:     12,
+				EdgeComputeEnabled:         true,
+				KubeconfigDownloadDisabled: true,
+				Features:                   map[string]bool{"disableRoleDiagnostics": false},
+			},
+		},
+		{
+			name: "LDAP authentication with team sync",
+			input: &models.SettingsPublicSettingsResponse{
+				AuthenticationMethod: 2,
+				TeamSync:             true,
+				CustomLoginBanner:    "Authorized users only",
+			},
+			expectedOutput: PublicSettings{
+				AuthenticationMethod: AuthenticationMethodLDAP,
+				TeamSync:             true,
+				LoginBanner:          "Authorized users only",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToPublicSettings(tt.input)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
+func TestConvertToSSLSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *models.PortainereeSSLSettings
+		expectedOutput SSLSettings
+	}{
+		{
+			name: "Custom certificate",
+			input: &models.PortainereeSSLSettings{
+				CertPath:    "/certs/portainer.crt",
+				KeyPath:     "/certs/portainer.key",
+				CaCertPath:  "/certs/ca.crt",
+				HTTPEnabled: true,
+			},
+			expectedOutput: SSLSettings{
+				CertPath:    "/certs/portainer.crt",
+				KeyPath:     "/certs/portainer.key",
+				CACertPath:  "/certs/ca.crt",
+				HTTPEnabled: true,
+			},
+		},
+		{
+			name: "Self-signed certificate",
+			input: &models.PortainereeSSLSettings{
+				CertPath:   "/data/certs/cert.pem",
+				KeyPath:    "/data/certs/key.pem",
+				SelfSigned: true,
+			},
+			expectedOutput: SSLSettings{
+				CertPath:   "/data/certs/cert.pem",
+				KeyPath:    "/data/certs/key.pem",
+				SelfSigned: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToSSLSettings(tt.input)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
+func TestSSLSettingsKeyIsNeverSerialized(t *testing.T) {
+	data, err := json.Marshal(SSLSettings{Certificate: "certificate", Key: "private-key"})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "private-key")
+}
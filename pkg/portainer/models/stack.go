@@ -12,6 +12,21 @@ type Stack struct {
 	Name                string `json:"name"`
 	CreatedAt           string `json:"created_at"`
 	EnvironmentGroupIds []int  `json:"group_ids"`
+	// UpdatedAt and UpdatedBy are the time and the author of the last change of the stack recorded in the
+	// activity logs, they are only read by the recently changed stacks and empty when no change was recorded
+	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+}
+
+// StackExport is the export of the files of all the stacks, keyed by stack name.
+// The files are either returned as is in Stacks or bundled in a zip archive encoded in base64 in Archive.
+// EmptyStacks lists the stacks whose file is empty.
+type StackExport struct {
+	Format      string            `json:"format"`
+	StackCount  int               `json:"stack_count"`
+	Stacks      map[string]string `json:"stacks,omitempty"`
+	Archive     string            `json:"archive,omitempty"`
+	EmptyStacks []string          `json:"empty_stacks,omitempty"`
 }
 
 func ConvertEdgeStackToStack(rawEdgeStack *apimodels.PortainereeEdgeStack) Stack {
@@ -24,3 +39,66 @@ func ConvertEdgeStackToStack(rawEdgeStack *apimodels.PortainereeEdgeStack) Stack
 		EnvironmentGroupIds: utils.Int64ToIntSlice(rawEdgeStack.EdgeGroups),
 	}
 }
+
+// GitConfig is the git repository a stack is deployed from. The git password is never returned.
+type GitConfig struct {
+	URL                string `json:"url"`
+	ReferenceName      string `json:"reference_name"`
+	ConfigFilePath     string `json:"config_file_path"`
+	CommitHash         string `json:"commit_hash"`
+	Authenticated      bool   `json:"authenticated"`
+	Username           string `json:"username,omitempty"`
+	TLSSkipVerify      bool   `json:"tls_skip_verify"`
+	AutoUpdate         bool   `json:"auto_update"`
+	AutoUpdateInterval string `json:"auto_update_interval,omitempty"`
+}
+
+// AutoUpdateOptions describes the changes to the automatic updates of a stack deployed from git.
+// Nil fields are left unchanged.
+type AutoUpdateOptions struct {
+	// Interval is how often Portainer polls the git repository for changes, as a duration (e.g. 5m or 1h30m).
+	// An empty interval disables the polling.
+	Interval *string
+	// Webhook enables the webhook that triggers an update of the stack when it is called
+	Webhook *bool
+	// ForcePullImage pulls the images of the stack again on every update
+	ForcePullImage *bool
+}
+
+// AutoUpdateSettings are the automatic update settings of a stack deployed from git.
+// The webhook token is the secret part of the webhook URL, it is only returned when the webhook is created.
+type AutoUpdateSettings struct {
+	Enabled        bool   `json:"enabled"`
+	Interval       string `json:"interval,omitempty"`
+	WebhookEnabled bool   `json:"webhook_enabled"`
+	WebhookToken   string `json:"webhook_token,omitempty"`
+	// WebhookPath is the path of the webhook on the Portainer server, only returned with the webhook token
+	WebhookPath    string `json:"webhook_path,omitempty"`
+	ForcePullImage bool   `json:"force_pull_image"`
+}
+
+// ConvertEdgeStackToGitConfig converts the git configuration of a raw edge stack, the stack must be deployed from git
+func ConvertEdgeStackToGitConfig(rawEdgeStack *apimodels.PortainereeEdgeStack) GitConfig {
+	rawConfig := rawEdgeStack.GitConfig
+
+	config := GitConfig{
+		URL:            rawConfig.URL,
+		ReferenceName:  rawConfig.ReferenceName,
+		ConfigFilePath: rawConfig.ConfigFilePath,
+		CommitHash:     rawConfig.ConfigHash,
+		TLSSkipVerify:  rawConfig.TlsskipVerify,
+	}
+
+	if rawConfig.Authentication != nil {
+		config.Authenticated = true
+		config.Username = rawConfig.Authentication.Username
+	}
+
+	// The automatic updates are triggered by polling on an interval, by a webhook or both
+	if rawEdgeStack.AutoUpdate != nil && (rawEdgeStack.AutoUpdate.Interval != "" || rawEdgeStack.AutoUpdate.Webhook != "") {
+		config.AutoUpdate = true
+		config.AutoUpdateInterval = rawEdgeStack.AutoUpdate.Interval
+	}
+
+	return config
+}
@@ -0,0 +1,32 @@
+package models
+
+// StackFilter selects the stacks deleted by DeleteStacksMatching. The criteria that are set must all
+// match, at least one criterion is required so that a filter never selects every stack by mistake.
+type StackFilter struct {
+	// NamePattern is a glob matched against the whole name of the stacks, e.g. "test-*" or "demo-?"
+	NamePattern string `json:"name_pattern,omitempty"`
+	// EnvironmentGroupID selects the stacks deployed to the environment group, 0 when not filtered
+	EnvironmentGroupID int `json:"environment_group_id,omitempty"`
+	// Confirm must be set to delete the stacks, the matching stacks are only listed otherwise
+	Confirm bool `json:"confirm"`
+}
+
+// BulkDeleteResult is the outcome of the deletion of the stacks matching a filter.
+type BulkDeleteResult struct {
+	// Confirmed is false when the deletion was not confirmed, nothing is deleted and Stacks lists the
+	// stacks that would be deleted
+	Confirmed bool `json:"confirmed"`
+	// Stacks are the stacks matching the filter, sorted by ID
+	Stacks       []StackDeleteStatus `json:"stacks"`
+	DeletedCount int                 `json:"deleted_count"`
+	FailedCount  int                 `json:"failed_count"`
+}
+
+// StackDeleteStatus is the outcome of the deletion of a stack.
+type StackDeleteStatus struct {
+	StackID   int    `json:"stack_id"`
+	StackName string `json:"stack_name"`
+	Deleted   bool   `json:"deleted"`
+	// Error is the cause of the failure when the stack could not be deleted
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,40 @@
+package models
+
+// DriftReport describes the differences between the services declared in a stack file
+// and the containers running for that stack on a specific environment.
+type DriftReport struct {
+	StackID         int                    `json:"stack_id"`
+	StackName       string                 `json:"stack_name"`
+	EnvironmentID   int                    `json:"environment_id"`
+	InSync          bool                   `json:"in_sync"`
+	MissingServices []string               `json:"missing_services"`
+	ExtraServices   []string               `json:"extra_services"`
+	ImageMismatches []ServiceImageMismatch `json:"image_mismatches"`
+}
+
+// ServiceImageMismatch describes a service whose running containers do not use the image declared in the stack file.
+type ServiceImageMismatch struct {
+	Service       string   `json:"service"`
+	ExpectedImage string   `json:"expected_image"`
+	RunningImages []string `json:"running_images"`
+}
+
+// GitDrift compares the file deployed for a stack with the file of the git repository the stack is deployed from.
+// LatestCommit is empty when the commit of the reference could not be read, LatestCommitError then explains why.
+// Diff lists the removed lines of the deployed file, prefixed with "-", and the added lines of the git file,
+// prefixed with "+", in the order of the files.
+type GitDrift struct {
+	StackID           int      `json:"stack_id"`
+	StackName         string   `json:"stack_name"`
+	RepositoryURL     string   `json:"repository_url"`
+	ReferenceName     string   `json:"reference_name"`
+	ConfigFilePath    string   `json:"config_file_path"`
+	DeployedCommit    string   `json:"deployed_commit"`
+	LatestCommit      string   `json:"latest_commit,omitempty"`
+	LatestCommitError string   `json:"latest_commit_error,omitempty"`
+	BehindLatest      bool     `json:"behind_latest"`
+	FileInSync        bool     `json:"file_in_sync"`
+	Diff              []string `json:"diff,omitempty"`
+	// DiffSkipped is set when the files are too large to be compared line by line
+	DiffSkipped bool `json:"diff_skipped,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+// Stack health status constants
+const (
+	// StackHealthHealthy means every container of the stack is running and healthy
+	StackHealthHealthy = "healthy"
+	// StackHealthDegraded means the stack is partially available
+	StackHealthDegraded = "degraded"
+	// StackHealthUnhealthy means the stack is not available
+	StackHealthUnhealthy = "unhealthy"
+	// StackHealthUnknown means the health of the stack could not be determined
+	StackHealthUnknown = "unknown"
+)
+
+// StackHealth is the aggregated health of a stack across all the environments it is deployed to.
+//
+// The status of each environment is:
+//   - healthy: at least one container and all containers are running and not reported unhealthy
+//   - degraded: some containers are healthy and some are not
+//   - unhealthy: no container is healthy, including when no container of the stack exists on the environment
+//   - unknown: the containers of the environment could not be listed
+//
+// The overall status is:
+//   - healthy: every environment is healthy
+//   - degraded: at least one environment is healthy or degraded, but not all of them are healthy
+//   - unhealthy: no environment is healthy or degraded, and at least one is unhealthy
+//   - unknown: the stack is not deployed to any environment, or no environment could be inspected
+type StackHealth struct {
+	StackID             int                      `json:"stack_id"`
+	StackName           string                   `json:"stack_name"`
+	Status              string                   `json:"status"`
+	Environments        []StackEnvironmentHealth `json:"environments"`
+	UnhealthyContainers []UnhealthyContainer     `json:"unhealthy_containers"`
+}
+
+// StackEnvironmentHealth is the health of a stack on a single environment.
+type StackEnvironmentHealth struct {
+	EnvironmentID     int    `json:"environment_id"`
+	Status            string `json:"status"`
+	HealthyContainers int    `json:"healthy_containers"`
+	TotalContainers   int    `json:"total_containers"`
+	Error             string `json:"error,omitempty"`
+}
+
+// UnhealthyContainer describes a container of a stack that is not running or reported unhealthy.
+type UnhealthyContainer struct {
+	EnvironmentID int    `json:"environment_id"`
+	Name          string `json:"name"`
+	Service       string `json:"service"`
+	State         string `json:"state"`
+	Status        string `json:"status"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// StackRestartReport lists the containers of a stack restarted on an environment. The containers that could not be
+// restarted are listed in Failed with the cause of the failure, the other containers were restarted.
+type StackRestartReport struct {
+	StackID       int                     `json:"stack_id"`
+	StackName     string                  `json:"stack_name"`
+	EnvironmentID int                     `json:"environment_id"`
+	Restarted     []StackContainerRestart `json:"restarted"`
+	Failed        []StackContainerRestart `json:"failed"`
+}
+
+// StackContainerRestart is the restart of a container of a stack, Error is only set when the restart failed
+type StackContainerRestart struct {
+	Service       string `json:"service"`
+	ContainerID   string `json:"container_id"`
+	ContainerName string `json:"container_name"`
+	Error         string `json:"error,omitempty"`
+}
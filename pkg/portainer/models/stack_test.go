@@ -86,3 +86,82 @@ func TestConvertEdgeStackToStack(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertEdgeStackToGitConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		edgeStack *models.PortainereeEdgeStack
+		want      GitConfig
+	}{
+		{
+			name: "authenticated repository with auto update",
+			edgeStack: &models.PortainereeEdgeStack{
+				ID: 1,
+				GitConfig: &models.GittypesRepoConfig{
+					URL:            "https://github.com/acme/web",
+					ReferenceName:  "refs/heads/main",
+					ConfigFilePath: "deploy/compose.yml",
+					ConfigHash:     "9f2c1e4",
+					Authentication: &models.GittypesGitAuthentication{Username: "deploy", Password: "secret"},
+				},
+				AutoUpdate: &models.PortainerAutoUpdateSettings{Interval: "5m"},
+			},
+			want: GitConfig{
+				URL:                "https://github.com/acme/web",
+				ReferenceName:      "refs/heads/main",
+				ConfigFilePath:     "deploy/compose.yml",
+				CommitHash:         "9f2c1e4",
+				Authenticated:      true,
+				Username:           "deploy",
+				AutoUpdate:         true,
+				AutoUpdateInterval: "5m",
+			},
+		},
+		{
+			name: "webhook auto update",
+			edgeStack: &models.PortainereeEdgeStack{
+				ID: 3,
+				GitConfig: &models.GittypesRepoConfig{
+					URL:            "https://github.com/acme/api",
+					ReferenceName:  "refs/heads/main",
+					ConfigFilePath: "compose.yml",
+				},
+				AutoUpdate: &models.PortainerAutoUpdateSettings{Webhook: "05de31a2-79fa-4644-9c12-faa67e5c49f0"},
+			},
+			want: GitConfig{
+				URL:            "https://github.com/acme/api",
+				ReferenceName:  "refs/heads/main",
+				ConfigFilePath: "compose.yml",
+				AutoUpdate:     true,
+			},
+		},
+		{
+			name: "public repository",
+			edgeStack: &models.PortainereeEdgeStack{
+				ID: 2,
+				GitConfig: &models.GittypesRepoConfig{
+					URL:            "https://gitlab.example.com/ops/web",
+					ReferenceName:  "refs/tags/v1.2.0",
+					ConfigFilePath: "compose.yml",
+					TlsskipVerify:  true,
+				},
+				AutoUpdate: &models.PortainerAutoUpdateSettings{},
+			},
+			want: GitConfig{
+				URL:            "https://gitlab.example.com/ops/web",
+				ReferenceName:  "refs/tags/v1.2.0",
+				ConfigFilePath: "compose.yml",
+				TLSSkipVerify:  true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertEdgeStackToGitConfig(tt.edgeStack)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertEdgeStackToGitConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package models
+
+const (
+	// StackImageSourceLocal is used when an image is already present on the environment
+	StackImageSourceLocal = "local"
+	// StackImageSourceRegistry is used when an image is not present on the environment but can be pulled from its registry
+	StackImageSourceRegistry = "registry"
+)
+
+// StackTestResult describes the outcome of a dry-run of a stack file against an environment.
+// SyntaxValid only tells whether the stack file could be parsed, WouldDeploy additionally requires
+// every service image to be available to the environment. Nothing is deployed or persisted.
+type StackTestResult struct {
+	EnvironmentID int               `json:"environment_id"`
+	SyntaxValid   bool              `json:"syntax_valid"`
+	WouldDeploy   bool              `json:"would_deploy"`
+	Images        []StackImageCheck `json:"images"`
+	Issues        []string          `json:"issues"`
+}
+
+// StackImageCheck describes whether the image of a stack service is available to an environment.
+type StackImageCheck struct {
+	Service   string `json:"service"`
+	Image     string `json:"image"`
+	Available bool   `json:"available"`
+	// Source is either "local" or "registry" when the image is available
+	Source string `json:"source,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
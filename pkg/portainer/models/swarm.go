@@ -0,0 +1,42 @@
+package models
+
+// Swarm service mode constants
+const (
+	SwarmServiceModeReplicated = "replicated"
+	SwarmServiceModeGlobal     = "global"
+)
+
+// SwarmService represents a service of a Docker Swarm cluster.
+type SwarmService struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	Mode  string `json:"mode"`
+	// Replicas is the number of replicas requested for a replicated service, it is not set for global services
+	Replicas     *int `json:"replicas,omitempty"`
+	RunningTasks int  `json:"running_tasks"`
+	DesiredTasks int  `json:"desired_tasks"`
+}
+
+// SwarmTask represents a task of a Docker Swarm service, the unit scheduled on a node to run a replica.
+type SwarmTask struct {
+	ID           string `json:"id"`
+	ServiceID    string `json:"service_id"`
+	NodeID       string `json:"node_id"`
+	Slot         int    `json:"slot,omitempty"`
+	Image        string `json:"image"`
+	State        string `json:"state"`
+	DesiredState string `json:"desired_state"`
+	Message      string `json:"message,omitempty"`
+	Error        string `json:"error,omitempty"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// SwarmServiceUpdate represents the changes to apply to a Docker Swarm service.
+// Nil fields are left unchanged.
+type SwarmServiceUpdate struct {
+	// Replicas is the number of replicas of a replicated service, 0 stops all the tasks of the service
+	Replicas *int `json:"replicas,omitempty"`
+	// Image is the image to run the tasks of the service with
+	Image *string `json:"image,omitempty"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"slices"
+
 	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 )
 
@@ -10,6 +12,23 @@ type Team struct {
 	MemberIDs []int  `json:"members"`
 }
 
+// Team membership role constants
+const (
+	TeamMembershipRoleLeader = "leader"
+	TeamMembershipRoleMember = "member"
+)
+
+// AllTeamMembershipRoles lists the roles a user can have inside a team
+var AllTeamMembershipRoles = []string{
+	TeamMembershipRoleLeader,
+	TeamMembershipRoleMember,
+}
+
+// IsValidTeamMembershipRole checks if a given string is a role a user can have inside a team
+func IsValidTeamMembershipRole(role string) bool {
+	return slices.Contains(AllTeamMembershipRoles, role)
+}
+
 func ConvertToTeam(rawTeam *apimodels.PortainerTeam, rawMemberships []*apimodels.PortainerTeamMembership) Team {
 	memberIDs := make([]int, 0)
 	for _, member := range rawMemberships {
@@ -0,0 +1,61 @@
+package models
+
+// Sources of the access of a team to an environment
+const (
+	// TeamAccessSourceEnvironment is an access set on the environment itself
+	TeamAccessSourceEnvironment = "environment"
+	// TeamAccessSourceAccessGroup is an access inherited from the access group of the environment
+	TeamAccessSourceAccessGroup = "access_group"
+)
+
+// TeamAccessOverview describes everywhere a team has access: the access groups (endpoint groups) and the
+// environments granting it an access level, the environments it can reach through them and the environment
+// groups (edge groups) of these environments.
+type TeamAccessOverview struct {
+	TeamID   int    `json:"team_id"`
+	TeamName string `json:"team_name"`
+	// AccessGroups are the access groups granting an access to the team, sorted by ID
+	AccessGroups []TeamAccessGroupAccess `json:"access_groups"`
+	// Environments are the environments the team can reach, sorted by ID
+	Environments []TeamEnvironmentAccess `json:"environments"`
+	// EnvironmentGroups are the environment groups with at least one environment the team can reach, sorted by ID
+	EnvironmentGroups []TeamEnvironmentGroupAccess `json:"environment_groups"`
+}
+
+// TeamAccessGroupAccess is the access level an access group grants to a team on its environments.
+type TeamAccessGroupAccess struct {
+	AccessGroupID   int    `json:"access_group_id"`
+	AccessGroupName string `json:"access_group_name"`
+	AccessLevel     string `json:"access_level"`
+	EnvironmentIds  []int  `json:"environment_ids"`
+}
+
+// TeamEnvironmentAccess is the access level of a team on an environment. An access set on the environment
+// replaces the access inherited from its access group, even when it grants fewer privileges.
+type TeamEnvironmentAccess struct {
+	EnvironmentID   int    `json:"environment_id"`
+	EnvironmentName string `json:"environment_name"`
+	AccessLevel     string `json:"access_level"`
+	// Source is TeamAccessSourceEnvironment or TeamAccessSourceAccessGroup
+	Source        string `json:"source"`
+	AccessGroupID int    `json:"access_group_id"`
+}
+
+// TeamEnvironmentGroupAccess lists the environments of an environment group a team can reach.
+type TeamEnvironmentGroupAccess struct {
+	GroupID   int    `json:"group_id"`
+	GroupName string `json:"group_name"`
+	// EnvironmentIds are the environments of the group the team can reach
+	EnvironmentIds []int `json:"environment_ids"`
+	// TotalEnvironments is the number of environments of the group
+	TotalEnvironments int `json:"total_environments"`
+}
+
+// TeamAccessDesiredState describes the accesses a team should have on the environments and on the access groups,
+// keyed by environment or access group ID. Nil fields are not managed and left unchanged. The accesses of the
+// team on the environments or access groups missing from a managed field are removed, an empty, non-nil field
+// removes all of them. The accesses of the other teams are never changed.
+type TeamAccessDesiredState struct {
+	EnvironmentAccesses map[int]string `json:"environment_accesses,omitempty"`
+	AccessGroupAccesses map[int]string `json:"access_group_accesses,omitempty"`
+}
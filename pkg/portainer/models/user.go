@@ -1,6 +1,8 @@
 package models
 
 import (
+	"sort"
+
 	apimodels "github.com/portainer/client-api-go/v2/pkg/models"
 )
 
@@ -26,6 +28,72 @@ func ConvertToUser(rawUser *apimodels.PortainereeUser) User {
 	}
 }
 
+// CurrentUser is the user the Portainer API token acts as.
+// Only the administrators can perform the admin-only operations, such as managing users and settings.
+type CurrentUser struct {
+	User
+	IsAdmin bool `json:"is_admin"`
+	// Authorizations are the Portainer-wide operations granted to the user, sorted by name
+	Authorizations []string `json:"authorizations"`
+}
+
+// ConvertToCurrentUser converts the raw user the API token belongs to, keeping only the granted authorizations
+func ConvertToCurrentUser(rawUser *apimodels.PortainereeUser) CurrentUser {
+	user := CurrentUser{
+		User:           ConvertToUser(rawUser),
+		Authorizations: []string{},
+	}
+	user.IsAdmin = user.Role == UserRoleAdmin
+
+	for authorization, granted := range rawUser.PortainerAuthorizations {
+		if granted {
+			user.Authorizations = append(user.Authorizations, authorization)
+		}
+	}
+	sort.Strings(user.Authorizations)
+
+	return user
+}
+
+// Themes of the Portainer UI
+const (
+	ThemeAuto         = "auto"
+	ThemeLight        = "light"
+	ThemeDark         = "dark"
+	ThemeHighContrast = "highcontrast"
+)
+
+// AllThemes lists the themes of the Portainer UI
+var AllThemes = []string{ThemeAuto, ThemeLight, ThemeDark, ThemeHighContrast}
+
+// UserPreferences represents the UI preferences of a Portainer user. Portainer has no instance-wide
+// default preferences, each user has their own. Theme is empty when the user never chose a theme.
+// On update, an empty theme and nil flags keep the current preferences.
+type UserPreferences struct {
+	Theme               string `json:"theme"`
+	SubtleUpgradeButton *bool  `json:"subtle_upgrade_button,omitempty"`
+	UseCache            *bool  `json:"use_cache,omitempty"`
+}
+
+// ConvertToUserPreferences converts the raw user to its UI preferences
+func ConvertToUserPreferences(rawUser *apimodels.PortainereeUser) UserPreferences {
+	useCache := rawUser.UseCache
+	prefs := UserPreferences{
+		Theme:               rawUser.UserTheme,
+		SubtleUpgradeButton: new(bool),
+		UseCache:            &useCache,
+	}
+
+	if rawUser.ThemeSettings != nil {
+		if rawUser.ThemeSettings.Color != "" {
+			prefs.Theme = rawUser.ThemeSettings.Color
+		}
+		*prefs.SubtleUpgradeButton = rawUser.ThemeSettings.SubtleUpgradeButton
+	}
+
+	return prefs
+}
+
 func convertUserRole(rawUser *apimodels.PortainereeUser) string {
 	switch rawUser.Role {
 	case 1:
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/portainer/client-api-go/v2/pkg/models"
@@ -100,3 +101,101 @@ func TestConvertUserRole(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertToCurrentUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *models.PortainereeUser
+		expected CurrentUser
+	}{
+		{
+			name: "admin user",
+			input: &models.PortainereeUser{
+				ID:       1,
+				Username: "admin",
+				Role:     1,
+			},
+			expected: CurrentUser{
+				User:           User{ID: 1, Username: "admin", Role: UserRoleAdmin},
+				IsAdmin:        true,
+				Authorizations: []string{},
+			},
+		},
+		{
+			name: "regular user with authorizations",
+			input: &models.PortainereeUser{
+				ID:       2,
+				Username: "user1",
+				Role:     2,
+				PortainerAuthorizations: models.PortainerAuthorizations{
+					"PortainerUserListToken":     true,
+					"PortainerDockerHubInspect":  true,
+					"PortainerEndpointGroupList": false,
+				},
+			},
+			expected: CurrentUser{
+				User:           User{ID: 2, Username: "user1", Role: UserRoleUser},
+				Authorizations: []string{"PortainerDockerHubInspect", "PortainerUserListToken"},
+			},
+		},
+		{
+			name: "edge admin user",
+			input: &models.PortainereeUser{
+				ID:       3,
+				Username: "edge",
+				Role:     3,
+			},
+			expected: CurrentUser{
+				User:           User{ID: 3, Username: "edge", Role: UserRoleEdgeAdmin},
+				Authorizations: []string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToCurrentUser(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToCurrentUser() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertToUserPreferences(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name     string
+		input    *models.PortainereeUser
+		expected UserPreferences
+	}{
+		{
+			name: "theme settings",
+			input: &models.PortainereeUser{
+				ThemeSettings: &models.PortainereeUserThemeSettings{Color: "dark", SubtleUpgradeButton: true},
+				UseCache:      true,
+			},
+			expected: UserPreferences{Theme: ThemeDark, SubtleUpgradeButton: &enabled, UseCache: &enabled},
+		},
+		{
+			name:     "legacy theme",
+			input:    &models.PortainereeUser{UserTheme: "highcontrast"},
+			expected: UserPreferences{Theme: ThemeHighContrast, SubtleUpgradeButton: &disabled, UseCache: &disabled},
+		},
+		{
+			name:     "no theme chosen",
+			input:    &models.PortainereeUser{},
+			expected: UserPreferences{SubtleUpgradeButton: &disabled, UseCache: &disabled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConvertToUserPreferences(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConvertToUserPreferences() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Package rawclient extends the client-api-go SDK client with the Portainer API
+// operations that the SDK does not wrap yet. It also reimplements the SDK operations
+// used by the client package so that every request goes through the transport of this package.
+//
+// The extended operations are built on top of the go-swagger generated client
+// shipped with the SDK and work with the raw models from
+// github.com/portainer/client-api-go/v2/pkg/models.
+package rawclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/portainer/client-api-go/v2/client"
+	apiclient "github.com/portainer/client-api-go/v2/pkg/client"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Default HTTP transport settings. Go keeps at most 2 idle connections per host by default,
+// which is too low for a client talking to a single Portainer server: concurrent requests
+// beyond that limit open new connections that are closed right after use, leaving sockets
+// in TIME_WAIT under sustained load.
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 32
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// unixSocketPrefix is the prefix of the server addresses pointing to a Unix socket
+const unixSocketPrefix = "unix://"
+
+// unixSocketHost is the host used in the requests sent over a Unix socket.
+// The connections are dialed to the socket, the host only ends up in the Host header.
+const unixSocketHost = "localhost"
+
+// PortainerClient embeds the SDK client and adds the operations that are
+// missing from it. All the SDK methods remain available on this type.
+//
+// The operations added by this package, the SDK operations it reimplements and the Docker
+// and Kubernetes proxy requests share a single tunable and traceable HTTP transport, which can
+// dial a Unix socket. The other SDK operations use the transport of the SDK and only support TCP.
+type PortainerClient struct {
+	*client.PortainerClient
+	api *apiclient.PortainerClientAPI
+	// transport sends the requests of api, it is shared by the copies made by WithContext
+	transport runtime.ClientTransport
+	// ctx is the context the requests are sent with, nil for the background context
+	ctx context.Context
+	// proxyCli sends the Docker and Kubernetes proxy requests
+	proxyCli *http.Client
+	// scheme is the scheme of the requests sent to the server, "http" when connected to a Unix socket
+	scheme string
+	host   string
+	apiKey string
+}
+
+// ClientOption defines a functional option for configuring the raw client
+type ClientOption func(*clientOptions)
+
+// clientOptions holds all configuration for the raw client
+type clientOptions struct {
+	skipTLSVerify       bool
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	tracerProvider      trace.TracerProvider
+	// socketPath is the path of the Unix socket to dial, empty when connecting over TCP
+	socketPath string
+}
+
+// WithSkipTLSVerify enables or disables TLS verification
+func WithSkipTLSVerify(skip bool) ClientOption {
+	return func(o *clientOptions) {
+		o.skipTLSVerify = skip
+	}
+}
+
+// WithTransportConfig tunes the connection pool of the HTTP transport.
+// Values lower than or equal to zero keep the defaults: 100 idle connections in total,
+// 32 idle connections to the Portainer server and a 90s idle timeout.
+func WithTransportConfig(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		if maxIdleConns > 0 {
+			o.maxIdleConns = maxIdleConns
+		}
+		if maxIdleConnsPerHost > 0 {
+			o.maxIdleConnsPerHost = maxIdleConnsPerHost
+		}
+		if idleTimeout > 0 {
+			o.idleConnTimeout = idleTimeout
+		}
+	}
+}
+
+// NewPortainerClient creates a new raw client for the Portainer server reachable at host
+// (e.g. "portainer.example.com:9443"), authenticating with the provided API key.
+//
+// A host of the form "unix:///path/to/socket" connects to the Unix socket at that path instead.
+// The requests are sent over plain HTTP to the socket and TLS options are ignored.
+func NewPortainerClient(host, apiKey string, opts ...ClientOption) *PortainerClient {
+	options := &clientOptions{
+		maxIdleConns:        defaultMaxIdleConns,
+		maxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+		idleConnTimeout:     defaultIdleConnTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	scheme := "https"
+	if socketPath, ok := strings.CutPrefix(host, unixSocketPrefix); ok {
+		options.socketPath = socketPath
+		scheme = "http"
+		host = unixSocketHost
+	}
+
+	var httpTransport http.RoundTripper = newHTTPTransport(options)
+	if options.tracerProvider != nil {
+		httpTransport = newTracingTransport(httpTransport, options.tracerProvider)
+	}
+
+	transport := httptransport.New(host, apiclient.DefaultBasePath, []string{scheme})
+	transport.Transport = &errorTransport{
+		next: httpTransport,
+	}
+	transport.DefaultAuthentication = runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+		return r.SetHeaderParam("x-api-key", apiKey)
+	})
+
+	return &PortainerClient{
+		PortainerClient: client.NewPortainerClient(host, apiKey, client.WithSkipTLSVerify(options.skipTLSVerify)),
+		api:             apiclient.New(transport, nil),
+		transport:       transport,
+		proxyCli:        &http.Client{Transport: httpTransport},
+		scheme:          scheme,
+		host:            host,
+		apiKey:          apiKey,
+	}
+}
+
+// WithContext returns a copy of the client sending its requests with the given context, so that they are
+// cancelled with it and traced as children of its span. The copy shares the transport of the client.
+// The SDK operations that are not reimplemented by this package do not use the context.
+func (c *PortainerClient) WithContext(ctx context.Context) *PortainerClient {
+	clone := *c
+	clone.ctx = ctx
+	clone.api = apiclient.New(&contextTransport{next: c.transport, ctx: ctx}, nil)
+	return &clone
+}
+
+// requestContext returns the context the requests are sent with
+func (c *PortainerClient) requestContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// contextTransport sends the operations of the generated client with a context,
+// the generated parameters are created without one
+type contextTransport struct {
+	next runtime.ClientTransport
+	ctx  context.Context
+}
+
+func (t *contextTransport) Submit(operation *runtime.ClientOperation) (any, error) {
+	if operation.Context == nil {
+		operation.Context = t.ctx
+	}
+	return t.next.Submit(operation)
+}
+
+// newHTTPTransport creates the HTTP transport shared by the requests sent by the raw client
+func newHTTPTransport(options *clientOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: options.skipTLSVerify,
+	}
+	transport.MaxIdleConns = options.maxIdleConns
+	transport.MaxIdleConnsPerHost = options.maxIdleConnsPerHost
+	transport.IdleConnTimeout = options.idleConnTimeout
+
+	if options.socketPath != "" {
+		dialer := &net.Dialer{}
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", options.socketPath)
+		}
+	}
+
+	return transport
+}
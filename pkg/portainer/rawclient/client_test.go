@@ -0,0 +1,123 @@
+package rawclient
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/portainer/client-api-go/v2/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKey = "test-api-key"
+
+// newTestClient starts a TLS test server serving the provided handler and
+// returns a raw client configured to talk to it.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *PortainerClient {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+	return NewPortainerClient(host, testAPIKey, append(opts, WithSkipTLSVerify(true))...)
+}
+
+func TestNewPortainerClient(t *testing.T) {
+	c := NewPortainerClient("portainer.example.com:9443", testAPIKey, WithSkipTLSVerify(true))
+
+	assert.NotNil(t, c.PortainerClient, "SDK client should be embedded")
+	assert.NotNil(t, c.api, "generated API client should be configured")
+}
+
+func TestWithTransportConfig(t *testing.T) {
+	tests := []struct {
+		name                        string
+		opts                        []ClientOption
+		expectedMaxIdleConns        int
+		expectedMaxIdleConnsPerHost int
+		expectedIdleConnTimeout     time.Duration
+	}{
+		{
+			name:                        "defaults",
+			expectedMaxIdleConns:        defaultMaxIdleConns,
+			expectedMaxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+			expectedIdleConnTimeout:     defaultIdleConnTimeout,
+		},
+		{
+			name:                        "custom values",
+			opts:                        []ClientOption{WithTransportConfig(200, 64, time.Minute)},
+			expectedMaxIdleConns:        200,
+			expectedMaxIdleConnsPerHost: 64,
+			expectedIdleConnTimeout:     time.Minute,
+		},
+		{
+			name:                        "zero values keep the defaults",
+			opts:                        []ClientOption{WithTransportConfig(0, 16, 0)},
+			expectedMaxIdleConns:        defaultMaxIdleConns,
+			expectedMaxIdleConnsPerHost: 16,
+			expectedIdleConnTimeout:     defaultIdleConnTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewPortainerClient("portainer.example.com:9443", testAPIKey, tt.opts...)
+
+			transport, ok := c.proxyCli.Transport.(*http.Transport)
+			assert.True(t, ok, "proxy client should use an http.Transport")
+			assert.Equal(t, tt.expectedMaxIdleConns, transport.MaxIdleConns)
+			assert.Equal(t, tt.expectedMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+			assert.Equal(t, tt.expectedIdleConnTimeout, transport.IdleConnTimeout)
+		})
+	}
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	var receivedKey string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		receivedKey = r.Header.Get("x-api-key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"logs":[]}`))
+	})
+
+	_, err := c.ListUserActivityLogs(0, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testAPIKey, receivedKey)
+}
+
+func TestUnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "portainer.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	var paths []string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, testAPIKey, r.Header.Get("x-api-key"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Version":"2.31.2"}`))
+	}))
+	srv.Listener = listener
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	c := NewPortainerClient("unix://"+socketPath, testAPIKey)
+
+	version, err := c.GetVersion()
+	require.NoError(t, err)
+	assert.Equal(t, "2.31.2", version)
+
+	resp, err := c.ProxyDockerRequest(3, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/info"})
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"/api/system/status", "/api/endpoints/3/docker/info"}, paths)
+}
@@ -0,0 +1,79 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/custom_templates"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListCustomTemplates lists all the custom templates, including the edge templates.
+func (c *PortainerClient) ListCustomTemplates() ([]*models.PortainereeCustomTemplate, error) {
+	resp, err := c.api.CustomTemplates.CustomTemplateList(custom_templates.NewCustomTemplateListParams(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom templates: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetCustomTemplate gets a custom template.
+func (c *PortainerClient) GetCustomTemplate(id int64) (*models.PortainereeCustomTemplate, error) {
+	resp, err := c.api.CustomTemplates.CustomTemplateInspect(custom_templates.NewCustomTemplateInspectParams().WithID(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom template: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetCustomTemplateFile gets the content of the stack file of a custom template.
+// The file of the templates read from a git repository is the one of the last clone made by Portainer.
+func (c *PortainerClient) GetCustomTemplateFile(id int64) (string, error) {
+	resp, err := c.api.CustomTemplates.CustomTemplateFile(custom_templates.NewCustomTemplateFileParams().WithID(id), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get custom template file: %w", err)
+	}
+
+	return resp.Payload.FileContent, nil
+}
+
+// CreateCustomTemplateFromFile creates a custom template whose stack file is stored by Portainer.
+//
+// Parameters:
+//   - payload: The settings of the custom template and the content of its stack file
+func (c *PortainerClient) CreateCustomTemplateFromFile(payload *models.CustomtemplatesCustomTemplateFromFileContentPayload) (int64, error) {
+	resp, err := c.api.CustomTemplates.CustomTemplateCreateString(custom_templates.NewCustomTemplateCreateStringParams().WithBody(payload), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create custom template: %w", err)
+	}
+
+	return resp.Payload.ID, nil
+}
+
+// CreateCustomTemplateFromGit creates a custom template whose stack file is read from a git repository.
+// The git password is never included in the returned errors.
+//
+// Parameters:
+//   - payload: The settings of the custom template and of its git repository
+func (c *PortainerClient) CreateCustomTemplateFromGit(payload *models.CustomtemplatesCustomTemplateFromGitRepositoryPayload) (int64, error) {
+	resp, err := c.api.CustomTemplates.CustomTemplateCreateRepository(custom_templates.NewCustomTemplateCreateRepositoryParams().WithBody(payload), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create custom template: %w", err)
+	}
+
+	return resp.Payload.ID, nil
+}
+
+// DeleteCustomTemplate deletes a custom template.
+//
+// Parameters:
+//   - id: The ID of the custom template to delete
+func (c *PortainerClient) DeleteCustomTemplate(id int64) error {
+	_, err := c.api.CustomTemplates.CustomTemplateDelete(custom_templates.NewCustomTemplateDeleteParams().WithID(id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom template: %w", err)
+	}
+
+	return nil
+}
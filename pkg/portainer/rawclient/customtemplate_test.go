@@ -0,0 +1,328 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCustomTemplates(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedIDs   []int64
+		expectedError bool
+	}{
+		{
+			name:        "successful retrieval",
+			status:      http.StatusOK,
+			body:        `[{"Id":1,"Title":"nginx","Type":2,"Platform":1},{"Id":2,"Title":"redis","Type":3}]`,
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			body:          `{"message":"Unable to retrieve custom templates from the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/custom_templates", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			templates, err := c.ListCustomTemplates()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]int64, len(templates))
+			for i, template := range templates {
+				ids[i] = template.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestGetCustomTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "successful retrieval",
+			status: http.StatusOK,
+			body:   `{"Id":4,"Title":"nginx","Type":2,"variables":[{"name":"port","defaultValue":"80"}]}`,
+		},
+		{
+			name:          "custom template not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find a custom template with the specified identifier inside the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/custom_templates/4", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			template, err := c.GetCustomTemplate(4)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(4), template.ID)
+			require.Len(t, template.Variables, 1)
+			assert.Equal(t, "port", template.Variables[0].Name)
+			assert.Equal(t, "80", template.Variables[0].DefaultValue)
+		})
+	}
+}
+
+func TestGetCustomTemplateFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "successful retrieval",
+			status:   http.StatusOK,
+			body:     `{"FileContent":"services:\n  web:\n    image: nginx"}`,
+			expected: "services:\n  web:\n    image: nginx",
+		},
+		{
+			name:          "file not found",
+			status:        http.StatusInternalServerError,
+			body:          `{"message":"Unable to retrieve custom template file from disk"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/custom_templates/4/file", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			file, err := c.GetCustomTemplateFile(4)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, file)
+		})
+	}
+}
+
+func TestCreateCustomTemplateFromFile(t *testing.T) {
+	title, description, content, templateType := "nginx", "Nginx web server", "services: {}", int64(2)
+	payload := &models.CustomtemplatesCustomTemplateFromFileContentPayload{
+		Title:       &title,
+		Description: &description,
+		FileContent: &content,
+		Type:        &templateType,
+		Platform:    1,
+	}
+
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedID    int64
+		expectedError bool
+	}{
+		{
+			name:       "successful creation",
+			status:     http.StatusOK,
+			body:       `{"Id":7,"Title":"nginx"}`,
+			expectedID: 7,
+		},
+		{
+			name:          "invalid payload",
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Invalid request payload","details":"Invalid custom template platform"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/custom_templates/create/string", r.URL.Path)
+
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "nginx", body["title"])
+				assert.Equal(t, "services: {}", body["fileContent"])
+				assert.Equal(t, float64(2), body["type"])
+				assert.Equal(t, float64(1), body["platform"])
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			id, err := c.CreateCustomTemplateFromFile(payload)
+
+			if tt.expectedError {
+				assert.ErrorContains(t, err, "Invalid custom template platform")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+		})
+	}
+}
+
+func TestCreateCustomTemplateFromGit(t *testing.T) {
+	title, description, url, templateType := "agent", "Monitoring agent", "https://github.com/example/stacks.git", int64(1)
+	filePath := "agent/docker-compose.yml"
+	payload := &models.CustomtemplatesCustomTemplateFromGitRepositoryPayload{
+		Title:                       &title,
+		Description:                 &description,
+		RepositoryURL:               &url,
+		Type:                        &templateType,
+		Platform:                    1,
+		RepositoryReferenceName:     "refs/heads/main",
+		ComposeFilePathInRepository: &filePath,
+		RepositoryAuthentication:    true,
+		RepositoryUsername:          "deploy",
+		RepositoryPassword:          "s3cret",
+	}
+
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedID    int64
+		expectedError bool
+	}{
+		{
+			name:       "successful creation",
+			status:     http.StatusOK,
+			body:       `{"Id":8,"Title":"agent"}`,
+			expectedID: 8,
+		},
+		{
+			name:          "clone failure",
+			status:        http.StatusInternalServerError,
+			body:          `{"message":"Unable to clone git repository","details":"authentication required"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/custom_templates/create/repository", r.URL.Path)
+
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "https://github.com/example/stacks.git", body["repositoryURL"])
+				assert.Equal(t, "refs/heads/main", body["repositoryReferenceName"])
+				assert.Equal(t, "agent/docker-compose.yml", body["composeFilePathInRepository"])
+				assert.Equal(t, true, body["repositoryAuthentication"])
+				assert.Equal(t, "deploy", body["repositoryUsername"])
+				assert.Equal(t, "s3cret", body["repositoryPassword"])
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			id, err := c.CreateCustomTemplateFromGit(payload)
+
+			if tt.expectedError {
+				assert.ErrorContains(t, err, "authentication required")
+				assert.NotContains(t, err.Error(), "s3cret")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+		})
+	}
+}
+
+func TestDeleteCustomTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "successful deletion",
+			id:     3,
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "custom template not found",
+			id:            99,
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find a custom template with the specified identifier inside the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, fmt.Sprintf("/api/custom_templates/%d", tt.id), r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.DeleteCustomTemplate(tt.id)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package rawclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/portainer/client-api-go/v2/pkg/client/edge_configs"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// edgeConfigArchiveName is the file name under which the archive of an edge configuration is uploaded
+const edgeConfigArchiveName = "config.zip"
+
+// ListEdgeConfigs lists all the edge configurations.
+func (c *PortainerClient) ListEdgeConfigs() ([]*models.PortainereeEdgeConfig, error) {
+	resp, err := c.api.EdgeConfigs.EdgeConfigList(edge_configs.NewEdgeConfigListParams(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge configurations: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// CreateEdgeConfig creates an edge configuration from a zip archive.
+// The archive is sent as a multipart file upload, its content is never included in the returned errors.
+//
+// Parameters:
+//   - name: The name of the edge configuration
+//   - configType: The type of the edge configuration (general, filename or foldername)
+//   - category: The category of the edge configuration (configuration or secret)
+//   - baseDir: The directory where the files are extracted on the edge devices
+//   - edgeGroupIDs: The IDs of the edge groups the configuration is pushed to
+//   - archive: The zip archive holding the files of the edge configuration
+func (c *PortainerClient) CreateEdgeConfig(name, configType, category, baseDir string, edgeGroupIDs []int64, archive []byte) error {
+	params := edge_configs.NewEdgeConfigCreateParams().
+		WithName(&name).
+		WithType(&configType).
+		WithCategory(&category).
+		WithBaseDir(&baseDir).
+		WithFile(runtime.NamedReader(edgeConfigArchiveName, bytes.NewReader(archive)))
+
+	if edgeGroupIDs == nil {
+		edgeGroupIDs = []int64{}
+	}
+	groups, err := json.Marshal(edgeGroupIDs)
+	if err != nil {
+		return fmt.Errorf("failed to encode edge group IDs: %w", err)
+	}
+
+	// Portainer decodes the edge group IDs form value as a JSON array while the generated
+	// client sends them as comma-separated values, the form value is written here instead.
+	withEdgeGroupIDs := func(op *runtime.ClientOperation) {
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			return r.SetFormParam("edgeGroupIDs", string(groups))
+		})
+	}
+
+	_, err = c.api.EdgeConfigs.EdgeConfigCreate(params, nil, withEdgeGroupIDs)
+	if err != nil {
+		return fmt.Errorf("failed to create edge configuration: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEdgeConfig deletes an edge configuration.
+//
+// Parameters:
+//   - id: The ID of the edge configuration to delete
+func (c *PortainerClient) DeleteEdgeConfig(id int64) error {
+	_, err := c.api.EdgeConfigs.EdgeConfigDelete(edge_configs.NewEdgeConfigDeleteParams().WithID(id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete edge configuration: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package rawclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEdgeConfigs(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedIDs   []int64
+		expectedError bool
+	}{
+		{
+			name:        "successful retrieval",
+			status:      http.StatusOK,
+			body:        `[{"id":1,"name":"nginx-conf","type":0,"category":"configuration","edgeGroupIDs":[1,2]},{"id":2,"name":"certs","type":1,"category":"secret","edgeGroupIDs":[3]}]`,
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			body:          `{"message":"Unable to retrieve edge configurations"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/edge_configurations", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			configs, err := c.ListEdgeConfigs()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]int64, len(configs))
+			for i, config := range configs {
+				ids[i] = config.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestCreateEdgeConfig(t *testing.T) {
+	archive := []byte("PK\x03\x04 archive content")
+
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "successful creation",
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "invalid archive",
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Invalid request payload","details":"the file must be a zip archive"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/edge_configurations", r.URL.Path)
+
+				require.NoError(t, r.ParseMultipartForm(1<<20))
+				assert.Equal(t, "nginx-conf", r.FormValue("name"))
+				assert.Equal(t, "general", r.FormValue("type"))
+				assert.Equal(t, "configuration", r.FormValue("category"))
+				assert.Equal(t, "/etc/nginx", r.FormValue("baseDir"))
+				assert.Equal(t, []string{"[1,2]"}, r.MultipartForm.Value["edgeGroupIDs"])
+
+				file, header, err := r.FormFile("File")
+				require.NoError(t, err)
+				defer file.Close()
+				assert.Equal(t, edgeConfigArchiveName, header.Filename)
+				content, err := io.ReadAll(file)
+				require.NoError(t, err)
+				assert.Equal(t, archive, content)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.CreateEdgeConfig("nginx-conf", "general", "configuration", "/etc/nginx", []int64{1, 2}, archive)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "the file must be a zip archive")
+				assert.NotContains(t, err.Error(), string(archive))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteEdgeConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "successful deletion",
+			id:     3,
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "edge configuration not found",
+			id:            99,
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an edge configuration with the specified identifier"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, fmt.Sprintf("/api/edge_configurations/%d", tt.id), r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.DeleteEdgeConfig(tt.id)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
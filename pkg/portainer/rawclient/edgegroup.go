@@ -0,0 +1,70 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/edge_groups"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListEdgeGroups lists all edge groups
+func (c *PortainerClient) ListEdgeGroups() ([]*models.EdgegroupsDecoratedEdgeGroup, error) {
+	params := edge_groups.NewEdgeGroupListParams()
+	resp, err := c.api.EdgeGroups.EdgeGroupList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge groups: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// CreateEdgeGroup creates a new static edge group holding the given environments
+//
+// Parameters:
+//   - name: The name of the edge group
+//   - environmentIds: The IDs of the environments of the edge group
+func (c *PortainerClient) CreateEdgeGroup(name string, environmentIds []int64) (int64, error) {
+	params := edge_groups.NewEdgeGroupCreateParams().WithBody(&models.EdgegroupsEdgeGroupCreatePayload{
+		Name:      name,
+		Endpoints: environmentIds,
+		Dynamic:   false,
+	})
+
+	resp, err := c.api.EdgeGroups.EdgeGroupCreate(params, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create edge group: %w", err)
+	}
+
+	return resp.Payload.ID, nil
+}
+
+// UpdateEdgeGroup updates an existing edge group.
+//
+// Parameters:
+//   - id: The ID of the edge group to update
+//   - name: Optional. If provided, updates the name of the edge group. Use nil to keep the existing name
+//   - environmentIds: Optional. If provided, updates the environments of the edge group. Use nil to keep the existing environments
+//   - tagIds: Optional. If provided, updates the tags of the edge group and makes it dynamic. Use nil to keep the existing tags
+func (c *PortainerClient) UpdateEdgeGroup(id int64, name *string, environmentIds *[]int64, tagIds *[]int64) error {
+	params := edge_groups.NewEdgeGroupUpdateParams().WithID(id).WithBody(&models.EdgegroupsEdgeGroupUpdatePayload{})
+
+	if name != nil {
+		params.Body.Name = *name
+	}
+
+	if environmentIds != nil {
+		params.Body.Endpoints = *environmentIds
+	}
+
+	if tagIds != nil {
+		params.Body.TagIDs = *tagIds
+		params.Body.Dynamic = true
+	}
+
+	_, err := c.api.EdgeGroups.EdgeGroupUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update edge group: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEdgeGroups(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/edge_groups", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":1,"Name":"edge","Endpoints":[2,3]}]`))
+	})
+
+	groups, err := c.ListEdgeGroups()
+
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, int64(1), groups[0].ID)
+	assert.Equal(t, []int64{2, 3}, groups[0].Endpoints)
+}
+
+func TestCreateEdgeGroup(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/edge_groups", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "edge", payload["name"])
+		assert.Equal(t, []any{float64(2), float64(3)}, payload["endpoints"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":4,"Name":"edge"}`))
+	})
+
+	id, err := c.CreateEdgeGroup("edge", []int64{2, 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), id)
+}
+
+func TestUpdateEdgeGroup(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{
+			name:   "successful update",
+			status: http.StatusOK,
+			body:   `{"Id":4}`,
+		},
+		{
+			name:          "edge group not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an Edge group with the specified identifier inside the database"}`,
+			expectedError: "Unable to find an Edge group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/edge_groups/4", r.URL.Path)
+
+				var payload map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "renamed", payload["name"])
+				assert.Equal(t, []any{float64(1)}, payload["tagIDs"])
+				assert.Equal(t, true, payload["dynamic"])
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			name := "renamed"
+			tagIds := []int64{1}
+			err := c.UpdateEdgeGroup(4, &name, nil, &tagIds)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
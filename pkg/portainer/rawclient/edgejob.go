@@ -0,0 +1,74 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/edge_jobs"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListEdgeJobs lists all the edge jobs, the scheduled scripts run on edge environments.
+func (c *PortainerClient) ListEdgeJobs() ([]*models.PortainerEdgeJob, error) {
+	resp, err := c.api.EdgeJobs.EdgeJobList(edge_jobs.NewEdgeJobListParams(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge jobs: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// CreateEdgeJob creates an edge job whose script is stored by Portainer.
+//
+// Parameters:
+//   - payload: The schedule and the targets of the edge job and the content of its script
+func (c *PortainerClient) CreateEdgeJob(payload *models.EdgejobsEdgeJobCreateFromFileContentPayload) (int64, error) {
+	resp, err := c.api.EdgeJobs.EdgeJobCreateString(edge_jobs.NewEdgeJobCreateStringParams().WithBody(payload), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create edge job: %w", err)
+	}
+
+	return resp.Payload.ID, nil
+}
+
+// DeleteEdgeJob deletes an edge job.
+//
+// Parameters:
+//   - id: The ID of the edge job to delete
+func (c *PortainerClient) DeleteEdgeJob(id int64) error {
+	_, err := c.api.EdgeJobs.EdgeJobDelete(edge_jobs.NewEdgeJobDeleteParams().WithID(id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete edge job: %w", err)
+	}
+
+	return nil
+}
+
+// ListEdgeJobTasks lists the tasks of an edge job, one for each environment it runs on.
+//
+// Parameters:
+//   - id: The ID of the edge job
+func (c *PortainerClient) ListEdgeJobTasks(id int64) ([]*models.EdgejobsTaskContainer, error) {
+	resp, err := c.api.EdgeJobs.EdgeJobTasksList(edge_jobs.NewEdgeJobTasksListParams().WithID(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge job tasks: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetEdgeJobTaskLogs retrieves the logs collected for a task of an edge job.
+//
+// Parameters:
+//   - id: The ID of the edge job
+//   - taskId: The ID of the task, the ID of the environment it runs on
+func (c *PortainerClient) GetEdgeJobTaskLogs(id, taskId int64) (string, error) {
+	resp, err := c.api.EdgeJobs.EdgeJobTaskLogsInspect(edge_jobs.NewEdgeJobTaskLogsInspectParams().WithID(id).WithTaskID(taskId), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get edge job task logs: %w", err)
+	}
+
+	if resp.Payload == nil {
+		return "", nil
+	}
+	return resp.Payload.FileContent, nil
+}
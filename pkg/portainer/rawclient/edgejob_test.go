@@ -0,0 +1,261 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEdgeJobs(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedIDs   []int64
+		expectedError bool
+	}{
+		{
+			name:        "successful retrieval",
+			status:      http.StatusOK,
+			body:        `[{"Id":1,"Name":"cleanup","CronExpression":"0 3 * * *","Recurring":true},{"Id":2,"Name":"reboot","CronExpression":"30 4 1 6 *"}]`,
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			body:          `{"message":"Unable to retrieve Edge jobs from the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/edge_jobs", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			jobs, err := c.ListEdgeJobs()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]int64, len(jobs))
+			for i, job := range jobs {
+				ids[i] = job.ID
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestCreateEdgeJob(t *testing.T) {
+	payload := &models.EdgejobsEdgeJobCreateFromFileContentPayload{
+		Name:           "cleanup",
+		CronExpression: "0 3 * * *",
+		Recurring:      true,
+		Endpoints:      []int64{1, 2},
+		EdgeGroups:     []int64{},
+		FileContent:    "docker system prune -f",
+	}
+
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedID    int64
+		expectedError bool
+	}{
+		{
+			name:       "successful creation",
+			status:     http.StatusOK,
+			body:       `{"Id":5,"Name":"cleanup"}`,
+			expectedID: 5,
+		},
+		{
+			name:          "invalid payload",
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Invalid request payload","details":"Invalid cron expression"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/edge_jobs/create/string", r.URL.Path)
+
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "cleanup", body["name"])
+				assert.Equal(t, "0 3 * * *", body["cronExpression"])
+				assert.Equal(t, true, body["recurring"])
+				assert.Equal(t, []any{float64(1), float64(2)}, body["endpoints"])
+				assert.Equal(t, "docker system prune -f", body["fileContent"])
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			id, err := c.CreateEdgeJob(payload)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+		})
+	}
+}
+
+func TestDeleteEdgeJob(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "successful deletion",
+			id:     3,
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "edge job not found",
+			id:            99,
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an Edge job with the specified identifier inside the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, fmt.Sprintf("/api/edge_jobs/%d", tt.id), r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.DeleteEdgeJob(tt.id)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListEdgeJobTasks(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expected      []*models.EdgejobsTaskContainer
+		expectedError bool
+	}{
+		{
+			name:   "successful listing",
+			status: http.StatusOK,
+			body:   `[{"Id":"edgejob_task_3_1","EndpointId":1,"LogsStatus":3},{"Id":"edgejob_task_3_2","EndpointId":2,"LogsStatus":1}]`,
+			expected: []*models.EdgejobsTaskContainer{
+				{ID: "edgejob_task_3_1", EndpointID: 1, LogsStatus: 3},
+				{ID: "edgejob_task_3_2", EndpointID: 2, LogsStatus: 1},
+			},
+		},
+		{
+			name:          "edge job not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an Edge job with the specified identifier inside the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/edge_jobs/3/tasks", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			tasks, err := c.ListEdgeJobTasks(3)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tasks)
+		})
+	}
+}
+
+func TestGetEdgeJobTaskLogs(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "successful retrieval",
+			status:   http.StatusOK,
+			body:     `{"FileContent":"backup done\n"}`,
+			expected: "backup done\n",
+		},
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			body:          `{"message":"Unable to retrieve Edge job task logs"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/edge_jobs/3/tasks/2/logs", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			logs, err := c.GetEdgeJobTaskLogs(3, 2)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, logs)
+		})
+	}
+}
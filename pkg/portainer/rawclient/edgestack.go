@@ -0,0 +1,163 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/edge_stacks"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListEdgeStacks lists all edge stacks
+func (c *PortainerClient) ListEdgeStacks() ([]*models.PortainereeEdgeStack, error) {
+	params := edge_stacks.NewEdgeStackListParams()
+	resp, err := c.api.EdgeStacks.EdgeStackList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list edge stacks: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// CreateEdgeStack creates a new edge stack from the content of a compose file
+//
+// Parameters:
+//   - name: The name of the edge stack
+//   - file: The content of the compose file of the edge stack
+//   - environmentGroupIds: The IDs of the edge groups to deploy the edge stack to
+func (c *PortainerClient) CreateEdgeStack(name string, file string, environmentGroupIds []int64) (int64, error) {
+	params := edge_stacks.NewEdgeStackCreateStringParams().WithBody(&models.EdgestacksEdgeStackFromStringPayload{
+		Name:             &name,
+		StackFileContent: &file,
+		EdgeGroups:       environmentGroupIds,
+		DeploymentType:   0,
+	})
+
+	resp, err := c.api.EdgeStacks.EdgeStackCreateString(params, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create edge stack: %w", err)
+	}
+
+	return resp.Payload.ID, nil
+}
+
+// UpdateEdgeStack updates the compose file and the edge groups of an edge stack and redeploys it
+//
+// Parameters:
+//   - id: The ID of the edge stack to update
+//   - file: The content of the new compose file of the edge stack
+//   - environmentGroupIds: The IDs of the edge groups to deploy the edge stack to
+func (c *PortainerClient) UpdateEdgeStack(id int64, file string, environmentGroupIds []int64) error {
+	params := edge_stacks.NewEdgeStackUpdateParams().WithID(id).WithBody(&models.EdgestacksUpdateEdgeStackPayload{
+		StackFileContent: file,
+		EdgeGroups:       environmentGroupIds,
+		UpdateVersion:    true,
+	})
+
+	_, err := c.api.EdgeStacks.EdgeStackUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update edge stack: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEdgeStack deletes an edge stack, Portainer removes it from the environments of its edge groups
+func (c *PortainerClient) DeleteEdgeStack(id int64) error {
+	params := edge_stacks.NewEdgeStackDeleteParams().WithID(id)
+
+	_, err := c.api.EdgeStacks.EdgeStackDelete(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete edge stack: %w", err)
+	}
+
+	return nil
+}
+
+// GetEdgeStackFile gets the content of the compose file of an edge stack
+func (c *PortainerClient) GetEdgeStackFile(id int64) (string, error) {
+	params := edge_stacks.NewEdgeStackFileParams().WithID(id)
+	resp, err := c.api.EdgeStacks.EdgeStackFile(params, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get edge stack file: %w", err)
+	}
+
+	return resp.Payload.StackFileContent, nil
+}
+
+// GetEdgeStack gets an edge stack
+func (c *PortainerClient) GetEdgeStack(id int64) (*models.PortainereeEdgeStack, error) {
+	params := edge_stacks.NewEdgeStackInspectParams().WithID(id)
+	resp, err := c.api.EdgeStacks.EdgeStackInspect(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edge stack: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// RedeployEdgeStackFromGit pulls the latest version of the git repository of an edge stack and redeploys it.
+// The settings of the stack are sent back unchanged, the git password is left empty so that Portainer keeps
+// the one it stores.
+//
+// Parameters:
+//   - stack: The git-based edge stack to redeploy, as returned by GetEdgeStack
+//   - rePullImage: Whether to pull the images of the stack again even if they are already present
+func (c *PortainerClient) RedeployEdgeStackFromGit(stack *models.PortainereeEdgeStack, rePullImage bool) error {
+	payload := newEdgeStackGitUpdatePayload(stack)
+	payload.RePullImage = rePullImage
+	payload.UpdateVersion = true
+
+	params := edge_stacks.NewEdgeStackUpdateFromGitParams().WithID(stack.ID).WithBody(payload)
+	_, err := c.api.EdgeStacks.EdgeStackUpdateFromGit(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to redeploy edge stack from git: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEdgeStackAutoUpdate updates the automatic update settings of a git-based edge stack without
+// redeploying it. The other settings of the stack are sent back unchanged.
+//
+// Parameters:
+//   - stack: The git-based edge stack to update, as returned by GetEdgeStack
+//   - autoUpdate: The new automatic update settings, nil disables the automatic updates
+func (c *PortainerClient) UpdateEdgeStackAutoUpdate(stack *models.PortainereeEdgeStack, autoUpdate *models.PortainerAutoUpdateSettings) error {
+	payload := newEdgeStackGitUpdatePayload(stack)
+	payload.AutoUpdate = autoUpdate
+
+	params := edge_stacks.NewEdgeStackUpdateFromGitParams().WithID(stack.ID).WithBody(payload)
+	_, err := c.api.EdgeStacks.EdgeStackUpdateFromGit(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update edge stack auto update: %w", err)
+	}
+
+	return nil
+}
+
+// newEdgeStackGitUpdatePayload builds a git update payload holding the current settings of an edge stack.
+// The git password is left empty so that Portainer keeps the one it stores.
+func newEdgeStackGitUpdatePayload(stack *models.PortainereeEdgeStack) *models.EdgestacksStackGitUpdatePayload {
+	payload := &models.EdgestacksStackGitUpdatePayload{
+		AutoUpdate:     stack.AutoUpdate,
+		DeploymentType: stack.DeploymentType,
+		EnvVars:        stack.EnvVars,
+		GroupIds:       stack.EdgeGroups,
+		PrePullImage:   stack.PrePullImage,
+		Registries:     stack.Registries,
+		RetryDeploy:    stack.RetryDeploy,
+		RetryPeriod:    stack.RetryPeriod,
+		StaggerConfig:  stack.StaggerConfig,
+	}
+	if stack.GitConfig != nil {
+		payload.RefName = stack.GitConfig.ReferenceName
+		if auth := stack.GitConfig.Authentication; auth != nil {
+			payload.Authentication = &models.GittypesGitAuthentication{
+				Username:        auth.Username,
+				GitCredentialID: auth.GitCredentialID,
+			}
+		}
+	}
+
+	return payload
+}
@@ -0,0 +1,227 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEdgeStacks(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/edge_stacks", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":1,"Name":"web","EdgeGroups":[2]}]`))
+	})
+
+	stacks, err := c.ListEdgeStacks()
+
+	require.NoError(t, err)
+	require.Len(t, stacks, 1)
+	assert.Equal(t, "web", stacks[0].Name)
+	assert.Equal(t, []int64{2}, stacks[0].EdgeGroups)
+}
+
+func TestCreateEdgeStack(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/edge_stacks/create/string", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "web", payload["name"])
+		assert.Equal(t, "services: {}", payload["stackFileContent"])
+		assert.Equal(t, []any{float64(2)}, payload["edgeGroups"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":5,"Name":"web"}`))
+	})
+
+	id, err := c.CreateEdgeStack("web", "services: {}", []int64{2})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+}
+
+func TestUpdateEdgeStack(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/edge_stacks/5", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "services: {}", payload["stackFileContent"])
+		assert.Equal(t, true, payload["updateVersion"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":5}`))
+	})
+
+	err := c.UpdateEdgeStack(5, "services: {}", []int64{2})
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteEdgeStack(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{
+			name:   "successful deletion",
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "edge stack not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an edge stack with the specified identifier inside the database"}`,
+			expectedError: "Unable to find an edge stack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, "/api/edge_stacks/5", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.DeleteEdgeStack(5)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetEdgeStackFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "successful retrieval",
+			status:   http.StatusOK,
+			body:     `{"StackFileContent":"services: {}"}`,
+			expected: "services: {}",
+		},
+		{
+			name:          "edge stack not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an edge stack with the specified identifier inside the database"}`,
+			expectedError: "Unable to find an edge stack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/edge_stacks/5/file", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			file, err := c.GetEdgeStackFile(5)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, file)
+		})
+	}
+}
+
+func TestGetEdgeStack(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/edge_stacks/5", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":5,"Name":"web","gitConfig":{"url":"https://github.com/acme/web","referenceName":"refs/heads/main"}}`))
+	})
+
+	stack, err := c.GetEdgeStack(5)
+
+	require.NoError(t, err)
+	assert.Equal(t, "web", stack.Name)
+	require.NotNil(t, stack.GitConfig)
+	assert.Equal(t, "https://github.com/acme/web", stack.GitConfig.URL)
+}
+
+func TestRedeployEdgeStackFromGit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/edge_stacks/5/git", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, true, payload["updateVersion"])
+		assert.Equal(t, true, payload["rePullImage"])
+		assert.Equal(t, "refs/heads/main", payload["refName"])
+		assert.Equal(t, []any{float64(2)}, payload["groupIds"])
+		assert.Equal(t, map[string]any{"username": "deploy"}, payload["authentication"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.RedeployEdgeStackFromGit(&models.PortainereeEdgeStack{
+		ID:         5,
+		EdgeGroups: []int64{2},
+		GitConfig: &models.GittypesRepoConfig{
+			URL:            "https://github.com/acme/web",
+			ReferenceName:  "refs/heads/main",
+			Authentication: &models.GittypesGitAuthentication{Username: "deploy", Password: "secret"},
+		},
+	}, true)
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateEdgeStackAutoUpdate(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/edge_stacks/5/git", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Nil(t, payload["updateVersion"], "the stack should not be redeployed")
+		assert.Equal(t, map[string]any{"interval": "5m", "forcePullImage": true}, payload["autoUpdate"])
+		assert.Equal(t, "refs/heads/main", payload["refName"])
+		assert.Equal(t, []any{float64(2)}, payload["groupIds"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.UpdateEdgeStackAutoUpdate(&models.PortainereeEdgeStack{
+		ID:         5,
+		EdgeGroups: []int64{2},
+		GitConfig: &models.GittypesRepoConfig{
+			URL:           "https://github.com/acme/web",
+			ReferenceName: "refs/heads/main",
+		},
+	}, &models.PortainerAutoUpdateSettings{Interval: "5m", ForcePullImage: true})
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,139 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/client/utils"
+	"github.com/portainer/client-api-go/v2/pkg/client/endpoints"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListEndpoints lists all endpoints
+func (c *PortainerClient) ListEndpoints() ([]*models.PortainereeEndpoint, error) {
+	params := endpoints.NewEndpointListParams()
+	resp, err := c.api.Endpoints.EndpointList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetEndpoint gets an endpoint by ID
+func (c *PortainerClient) GetEndpoint(id int64) (*models.PortainereeEndpoint, error) {
+	params := endpoints.NewEndpointInspectParams().WithID(id)
+	resp, err := c.api.Endpoints.EndpointInspect(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// UpdateEndpoint updates the tags and the access policies of an endpoint.
+//
+// Parameters:
+//   - id: The ID of the endpoint to update
+//   - tagIds: Optional. If provided (including empty slice), updates the tags of the endpoint.
+//     Use nil to keep the existing tags.
+//   - userAccesses: Optional. If provided (including empty map), updates user access policies.
+//     Use nil to keep existing policies. Map of user ID to role name.
+//   - teamAccesses: Optional. If provided (including empty map), updates team access policies.
+//     Use nil to keep existing policies. Map of team ID to role name.
+//
+// Invalid roles are ignored.
+func (c *PortainerClient) UpdateEndpoint(id int64, tagIds *[]int64, userAccesses *map[int64]string, teamAccesses *map[int64]string) error {
+	params := endpoints.NewEndpointUpdateParams().WithID(id).WithBody(&models.EndpointsEndpointUpdatePayload{})
+
+	if tagIds != nil {
+		params.Body.TagIDs = *tagIds
+	}
+	if userAccesses != nil {
+		params.Body.UserAccessPolicies = utils.BuildAccessPolicies[models.PortainerUserAccessPolicies](*userAccesses)
+	}
+	if teamAccesses != nil {
+		params.Body.TeamAccessPolicies = utils.BuildAccessPolicies[models.PortainerTeamAccessPolicies](*teamAccesses)
+	}
+
+	_, err := c.api.Endpoints.EndpointUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEndpointGPUs updates the GPUs declared on an endpoint through the endpoint settings.
+// The SDK endpoint update does not expose the GPU settings.
+//
+// Parameters:
+//   - id: The ID of the endpoint to update
+//   - gpus: The GPUs of the endpoint, an empty slice removes all the GPUs
+//
+// GPU management is enabled on the endpoint when at least one GPU is provided.
+func (c *PortainerClient) UpdateEndpointGPUs(id int64, gpus []*models.PortainerPair) error {
+	if gpus == nil {
+		gpus = []*models.PortainerPair{}
+	}
+
+	params := endpoints.NewEndpointSettingsUpdateParams().
+		WithID(id).
+		WithBody(&models.EndpointsEndpointSettingsUpdatePayload{
+			EnableGPUManagement: len(gpus) > 0,
+			Gpus:                gpus,
+		})
+
+	_, err := c.api.Endpoints.EndpointSettingsUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update endpoint settings: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEndpointPublicURL updates the public URL of an endpoint, used to build the links to the ports
+// published by its containers. The endpoint is read first and its tags and GPUs are sent back with the
+// new public URL, as the SDK always sends these fields and an update must not clobber them.
+//
+// Parameters:
+//   - id: The ID of the endpoint to update
+//   - publicURL: The new public URL of the endpoint, it cannot be empty as the SDK omits empty values
+func (c *PortainerClient) UpdateEndpointPublicURL(id int64, publicURL string) error {
+	endpoint, err := c.GetEndpoint(id)
+	if err != nil {
+		return err
+	}
+
+	tagIds := endpoint.TagIds
+	if tagIds == nil {
+		tagIds = []int64{}
+	}
+
+	params := endpoints.NewEndpointUpdateParams().
+		WithID(id).
+		WithBody(&models.EndpointsEndpointUpdatePayload{
+			PublicURL: publicURL,
+			TagIDs:    tagIds,
+			Gpus:      endpoint.Gpus,
+		})
+
+	_, err = c.api.Endpoints.EndpointUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// SnapshotEndpoint takes a new snapshot of an endpoint. Portainer takes the snapshot before answering,
+// snapshots are not supported on Edge and Azure endpoints.
+func (c *PortainerClient) SnapshotEndpoint(id int64) error {
+	params := endpoints.NewEndpointSnapshotParams().WithID(id)
+
+	_, err := c.api.Endpoints.EndpointSnapshot(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot endpoint: %w", err)
+	}
+
+	return nil
+}
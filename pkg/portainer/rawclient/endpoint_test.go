@@ -0,0 +1,242 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEndpoints(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/endpoints", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":3,"Name":"local","Type":1}]`))
+	})
+
+	endpoints, err := c.ListEndpoints()
+
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "local", endpoints[0].Name)
+}
+
+func TestGetEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		expectedName string
+		expectedCode int
+	}{
+		{
+			name:         "successful retrieval",
+			status:       http.StatusOK,
+			body:         `{"Id":3,"Name":"local"}`,
+			expectedName: "local",
+		},
+		{
+			name:         "endpoint not found",
+			status:       http.StatusNotFound,
+			body:         `{"message":"Unable to find an environment with the specified identifier inside the database"}`,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/endpoints/3", r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			endpoint, err := c.GetEndpoint(3)
+
+			if tt.expectedCode != 0 {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tt.expectedCode, apiErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, endpoint.Name)
+		})
+	}
+}
+
+func TestUpdateEndpoint(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/endpoints/3", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, []any{float64(1), float64(2)}, payload["tagIDs"])
+		assert.Equal(t, map[string]any{"4": map[string]any{"RoleId": float64(3)}}, payload["teamAccessPolicies"])
+		assert.NotContains(t, payload, "userAccessPolicies")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":3}`))
+	})
+
+	tagIds := []int64{1, 2}
+	teamAccesses := map[int64]string{4: "standard_user"}
+	err := c.UpdateEndpoint(3, &tagIds, nil, &teamAccesses)
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateEndpointPublicURL(t *testing.T) {
+	t.Run("keeps the tags and gpus", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/endpoints/3", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+
+			if r.Method == http.MethodGet {
+				w.Write([]byte(`{"Id":3,"PublicURL":"old.example.com","TagIds":[1,2],"Gpus":[{"name":"gpu0","value":"0"}]}`))
+				return
+			}
+
+			assert.Equal(t, http.MethodPut, r.Method)
+			var payload map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]any{
+				"publicURL": "docker.example.com",
+				"tagIDs":    []any{float64(1), float64(2)},
+				"gpus":      []any{map[string]any{"name": "gpu0", "value": "0"}},
+			}, payload)
+
+			w.Write([]byte(`{"Id":3}`))
+		})
+
+		err := c.UpdateEndpointPublicURL(3, "docker.example.com")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("endpoint not found", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Unable to find an environment with the specified identifier inside the database"}`))
+		})
+
+		err := c.UpdateEndpointPublicURL(3, "docker.example.com")
+
+		assert.ErrorContains(t, err, "failed to get endpoint")
+	})
+}
+
+func TestUpdateEndpointGPUs(t *testing.T) {
+	tests := []struct {
+		name          string
+		gpus          []*models.PortainerPair
+		status        int
+		body          string
+		expectedBody  map[string]any
+		expectedError string
+	}{
+		{
+			name:   "set gpus",
+			gpus:   []*models.PortainerPair{{Name: "gpu0", Value: "0"}},
+			status: http.StatusOK,
+			body:   `{"Id":3}`,
+			expectedBody: map[string]any{
+				"enableGPUManagement": true,
+				"gpus":                []any{map[string]any{"name": "gpu0", "value": "0"}},
+			},
+		},
+		{
+			name:   "remove gpus",
+			status: http.StatusOK,
+			body:   `{"Id":3}`,
+			expectedBody: map[string]any{
+				"gpus": []any{},
+			},
+		},
+		{
+			name:          "portainer rejects the update",
+			gpus:          []*models.PortainerPair{{Name: "gpu0", Value: "0"}},
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Invalid request payload","details":"GPUs are not supported on this environment"}`,
+			expectedError: "GPUs are not supported on this environment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/endpoints/3/settings", r.URL.Path)
+
+				var body map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				if tt.expectedBody != nil {
+					assert.Equal(t, tt.expectedBody, body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.UpdateEndpointGPUs(3, tt.gpus)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSnapshotEndpoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{
+			name:   "successful snapshot",
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "snapshots not supported",
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Snapshots not supported for this environment"}`,
+			expectedError: "Snapshots not supported for this environment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/endpoints/3/snapshot", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.SnapshotEndpoint(3)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEndpointGroups(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/endpoint_groups", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":1,"Name":"Unassigned"}]`))
+	})
+
+	groups, err := c.ListEndpointGroups()
+
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "Unassigned", groups[0].Name)
+}
+
+func TestCreateEndpointGroup(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/endpoint_groups", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "production", payload["name"])
+		assert.Equal(t, []any{float64(3)}, payload["associatedEndpoints"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":2,"Name":"production"}`))
+	})
+
+	id, err := c.CreateEndpointGroup("production", []int64{3})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), id)
+}
+
+func TestUpdateEndpointGroup(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/endpoint_groups/2", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]any{"3": map[string]any{"RoleId": float64(1)}}, payload["userAccessPolicies"])
+		assert.NotContains(t, payload, "teamAccessPolicies")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":2}`))
+	})
+
+	userAccesses := map[int64]string{3: "environment_administrator"}
+	err := c.UpdateEndpointGroup(2, nil, &userAccesses, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestEndpointGroupEnvironments(t *testing.T) {
+	tests := []struct {
+		name           string
+		expectedMethod string
+		call           func(c *PortainerClient) error
+	}{
+		{
+			name:           "add environment",
+			expectedMethod: http.MethodPut,
+			call: func(c *PortainerClient) error {
+				return c.AddEnvironmentToEndpointGroup(2, 3)
+			},
+		},
+		{
+			name:           "remove environment",
+			expectedMethod: http.MethodDelete,
+			call: func(c *PortainerClient) error {
+				return c.RemoveEnvironmentFromEndpointGroup(2, 3)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.expectedMethod, r.Method)
+				assert.Equal(t, "/api/endpoint_groups/2/endpoints/3", r.URL.Path)
+
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			assert.NoError(t, tt.call(c))
+		})
+	}
+}
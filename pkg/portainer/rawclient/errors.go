@@ -0,0 +1,88 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize is the maximum number of bytes read from an error response body
+const maxErrorBodySize = 64 * 1024
+
+// APIError is returned when the Portainer API answers with an error status code.
+// Unlike the errors generated by go-swagger, it carries the message returned by Portainer.
+// It implements runtime.ClientResponseStatus so that callers can inspect the status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Details    string
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Message != "" && e.Details != "" && e.Details != e.Message:
+		return fmt.Sprintf("portainer API error (status %d): %s: %s", e.StatusCode, e.Message, e.Details)
+	case e.Message != "":
+		return fmt.Sprintf("portainer API error (status %d): %s", e.StatusCode, e.Message)
+	default:
+		return fmt.Sprintf("portainer API error (status %d)", e.StatusCode)
+	}
+}
+
+// IsSuccess returns true when the status code is 2xx
+func (e *APIError) IsSuccess() bool { return e.StatusCode/100 == 2 }
+
+// IsRedirect returns true when the status code is 3xx
+func (e *APIError) IsRedirect() bool { return e.StatusCode/100 == 3 }
+
+// IsClientError returns true when the status code is 4xx
+func (e *APIError) IsClientError() bool { return e.StatusCode/100 == 4 }
+
+// IsServerError returns true when the status code is 5xx
+func (e *APIError) IsServerError() bool { return e.StatusCode/100 == 5 }
+
+// IsCode returns true when the status code matches the given code
+func (e *APIError) IsCode(code int) bool { return e.StatusCode == code }
+
+// errorTransport turns the error responses of the Portainer API into an APIError.
+// The generated client discards the body of error responses, which hides the reason
+// of the failure reported by Portainer (e.g. an unsupported operation on an environment).
+type errorTransport struct {
+	next http.RoundTripper
+}
+
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	return nil, newAPIError(resp)
+}
+
+// newAPIError builds an APIError from an error response, using the message of the
+// Portainer error body when one is available
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(body) == 0 {
+		return apiErr
+	}
+
+	var payload struct {
+		Message string `json:"message"`
+		Details string `json:"details"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Message != "" {
+		apiErr.Message = payload.Message
+		apiErr.Details = payload.Details
+		return apiErr
+	}
+
+	apiErr.Message = strings.TrimSpace(string(body))
+	return apiErr
+}
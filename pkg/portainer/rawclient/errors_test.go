@@ -0,0 +1,91 @@
+package rawclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *APIError
+		expected string
+	}{
+		{
+			name:     "message and details",
+			err:      &APIError{StatusCode: 400, Message: "Invalid request payload", Details: "gpus are not supported"},
+			expected: "portainer API error (status 400): Invalid request payload: gpus are not supported",
+		},
+		{
+			name:     "identical message and details",
+			err:      &APIError{StatusCode: 404, Message: "Not found", Details: "Not found"},
+			expected: "portainer API error (status 404): Not found",
+		},
+		{
+			name:     "status only",
+			err:      &APIError{StatusCode: 500},
+			expected: "portainer API error (status 500)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+func TestErrorTransport(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		expectedMessage string
+		expectedDetails string
+	}{
+		{
+			name:            "portainer error body",
+			status:          http.StatusBadRequest,
+			body:            `{"message":"Unable to update settings","details":"GPU management is not supported"}`,
+			expectedMessage: "Unable to update settings",
+			expectedDetails: "GPU management is not supported",
+		},
+		{
+			name:            "plain text body",
+			status:          http.StatusBadGateway,
+			body:            "upstream unavailable\n",
+			expectedMessage: "upstream unavailable",
+		},
+		{
+			name:   "empty body",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := c.ListUserActivityLogs(0, 0, 0)
+			require.Error(t, err)
+
+			var apiErr *APIError
+			require.True(t, errors.As(err, &apiErr), "error should wrap an APIError")
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+			assert.Equal(t, tt.expectedMessage, apiErr.Message)
+			assert.Equal(t, tt.expectedDetails, apiErr.Details)
+
+			var status runtime.ClientResponseStatus
+			require.True(t, errors.As(err, &status), "error should expose its status code")
+			assert.True(t, status.IsCode(tt.status))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/gitops"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// GetGitRepositoryFile gets the content of a file of a git repository, as cloned by Portainer.
+func (c *PortainerClient) GetGitRepositoryFile(payload *models.GitopsRepositoryFilePreviewPayload) (string, error) {
+	params := gitops.NewGitOperationRepoFilePreviewParams().WithBody(payload)
+	resp, err := c.api.Gitops.GitOperationRepoFilePreview(params, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to preview git repository file: %w", err)
+	}
+
+	return resp.Payload.FileContent, nil
+}
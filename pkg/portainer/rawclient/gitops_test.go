@@ -0,0 +1,68 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGitRepositoryFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "successful retrieval",
+			status:   http.StatusOK,
+			body:     `{"fileContent":"services:\n  web:\n    image: nginx:1.27\n"}`,
+			expected: "services:\n  web:\n    image: nginx:1.27\n",
+		},
+		{
+			name:          "git error",
+			status:        http.StatusInternalServerError,
+			body:          `{"message":"Unable to clone git repository","details":"authentication required"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/gitops/repo/file/preview", r.URL.Path)
+
+				var payload models.GitopsRepositoryFilePreviewPayload
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				require.NotNil(t, payload.Repository)
+				assert.Equal(t, "https://github.com/acme/shop.git", *payload.Repository)
+				assert.Equal(t, "refs/heads/main", payload.Reference)
+				assert.Equal(t, "docker-compose.yml", payload.TargetFile)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			repository := "https://github.com/acme/shop.git"
+			file, err := c.GetGitRepositoryFile(&models.GitopsRepositoryFilePreviewPayload{
+				Repository: &repository,
+				Reference:  "refs/heads/main",
+				TargetFile: "docker-compose.yml",
+			})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, file)
+		})
+	}
+}
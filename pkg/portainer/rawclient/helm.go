@@ -0,0 +1,56 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/helm"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListHelmReleases lists the Helm releases installed in a Kubernetes environment.
+//
+// Parameters:
+//   - environmentId: The ID of the Kubernetes environment
+//   - namespace: The namespace of the releases, all namespaces are listed when empty
+func (c *PortainerClient) ListHelmReleases(environmentId int64, namespace string) ([]*models.ReleaseReleaseElement, error) {
+	params := helm.NewHelmListParams().WithID(environmentId)
+	if namespace != "" {
+		params = params.WithNamespace(&namespace)
+	}
+
+	resp, err := c.api.Helm.HelmList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list helm releases: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// InstallHelmChart installs a Helm chart in a Kubernetes environment. Portainer upgrades the release
+// when it already exists in the namespace.
+//
+// Parameters:
+//   - environmentId: The ID of the Kubernetes environment
+//   - repo: The URL of the Helm repository holding the chart
+//   - chart: The name of the chart
+//   - version: The version of the chart, the latest version is installed when empty
+//   - namespace: The namespace of the release
+//   - name: The name of the release
+//   - values: The values of the chart in YAML format, the default values are used when empty
+func (c *PortainerClient) InstallHelmChart(environmentId int64, repo, chart, version, namespace, name, values string) error {
+	params := helm.NewHelmInstallParams().WithID(environmentId).WithPayload(&models.HelmInstallChartPayload{
+		Repo:      repo,
+		Chart:     chart,
+		Version:   version,
+		Namespace: namespace,
+		Name:      name,
+		Values:    values,
+	})
+
+	_, err := c.api.Helm.HelmInstall(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to install helm chart: %w", err)
+	}
+
+	return nil
+}
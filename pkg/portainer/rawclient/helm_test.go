@@ -0,0 +1,124 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListHelmReleases(t *testing.T) {
+	tests := []struct {
+		name              string
+		namespace         string
+		status            int
+		body              string
+		expectedNamespace string
+		expectedReleases  int
+		expectedError     bool
+	}{
+		{
+			name:              "all namespaces",
+			status:            http.StatusOK,
+			body:              `[{"name":"nginx","namespace":"web","chart":"nginx-15.0.0","revision":"1","status":"deployed"},{"name":"redis","namespace":"cache","chart":"redis-18.1.0","revision":"2","status":"failed"}]`,
+			expectedReleases:  2,
+			expectedNamespace: "",
+		},
+		{
+			name:              "single namespace",
+			namespace:         "web",
+			status:            http.StatusOK,
+			body:              `[{"name":"nginx","namespace":"web","chart":"nginx-15.0.0","revision":"1","status":"deployed"}]`,
+			expectedReleases:  1,
+			expectedNamespace: "web",
+		},
+		{
+			name:          "environment not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an environment with the specified identifier inside the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/endpoints/3/kubernetes/helm", r.URL.Path)
+				assert.Equal(t, tt.expectedNamespace, r.URL.Query().Get("namespace"))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			releases, err := c.ListHelmReleases(3, tt.namespace)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, releases, tt.expectedReleases)
+			assert.Equal(t, "nginx", releases[0].Name)
+		})
+	}
+}
+
+func TestInstallHelmChart(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		expectedError   bool
+		expectedMessage string
+	}{
+		{
+			name:   "successful installation",
+			status: http.StatusCreated,
+			body:   `{"name":"web","namespace":"web","version":1}`,
+		},
+		{
+			name:            "chart not found",
+			status:          http.StatusInternalServerError,
+			body:            `{"message":"Helm returned an error","details":"chart \"nginx\" version \"99.0.0\" not found in https://charts.bitnami.com/bitnami repository"}`,
+			expectedError:   true,
+			expectedMessage: "Helm returned an error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/endpoints/3/kubernetes/helm", r.URL.Path)
+
+				var payload map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, map[string]any{
+					"repo":      "https://charts.bitnami.com/bitnami",
+					"chart":     "nginx",
+					"version":   "15.0.0",
+					"namespace": "web",
+					"name":      "web",
+					"values":    "replicaCount: 2\n",
+				}, payload)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.InstallHelmChart(3, "https://charts.bitnami.com/bitnami", "nginx", "15.0.0", "web", "web", "replicaCount: 2\n")
+
+			if tt.expectedError {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tt.expectedMessage, apiErr.Message)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package rawclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// GetKubeconfig retrieves the kubeconfig of a Kubernetes environment in YAML format.
+// The SDK decodes the response of this endpoint as JSON, so the request is sent directly.
+// The kubeconfig embeds a token tied to the Portainer user owning the API key, it must not be logged.
+//
+// Parameters:
+//   - environmentId: The ID of the Kubernetes environment
+func (c *PortainerClient) GetKubeconfig(environmentId int64) (string, error) {
+	url := fmt.Sprintf("%s://%s/api/kubernetes/config", c.scheme, c.host)
+
+	req, err := http.NewRequestWithContext(c.requestContext(), http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create kubeconfig request: %w", err)
+	}
+
+	// Portainer reads array query parameters with the bracket notation
+	q := req.URL.Query()
+	q.Set("ids[]", strconv.FormatInt(environmentId, 10))
+	req.URL.RawQuery = q.Encode()
+
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("Accept", "text/yaml")
+
+	resp, err := c.proxyCli.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to get kubeconfig: %w", newAPIError(resp))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+
+	return string(body), nil
+}
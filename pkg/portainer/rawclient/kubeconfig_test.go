@@ -0,0 +1,58 @@
+package rawclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKubeconfig(t *testing.T) {
+	const kubeconfig = "apiVersion: v1\nkind: Config\ncurrent-context: portainer-ctx-prod\n"
+
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "successful retrieval",
+			status: http.StatusOK,
+			body:   kubeconfig,
+		},
+		{
+			name:          "environment not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"Unable to find an environment with the specified identifier inside the database"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/kubernetes/config", r.URL.Path)
+				assert.Equal(t, []string{"3"}, r.URL.Query()["ids[]"])
+				assert.Equal(t, testAPIKey, r.Header.Get("x-api-key"))
+				assert.Equal(t, "text/yaml", r.Header.Get("Accept"))
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			config, err := c.GetKubeconfig(3)
+
+			if tt.expectedError {
+				require.Error(t, err)
+				var apiErr *APIError
+				assert.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, kubeconfig, config)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/license"
+	"github.com/portainer/client-api-go/v2/pkg/client/system"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// GetLicenseInfo retrieves the summary of the licenses installed on the Portainer server.
+// This endpoint is only available on Portainer Business Edition.
+func (c *PortainerClient) GetLicenseInfo() (*models.LicensesLicenseInfo, error) {
+	resp, err := c.api.License.LicensesInfo(license.NewLicensesInfoParams(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get license info: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetNodesCount retrieves the number of nodes managed by the Portainer server,
+// which is the number of nodes counted against the license.
+func (c *PortainerClient) GetNodesCount() (int64, error) {
+	resp, err := c.api.System.SystemNodesCount(system.NewSystemNodesCountParams(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nodes count: %w", err)
+	}
+
+	return resp.Payload.Nodes, nil
+}
@@ -0,0 +1,69 @@
+package rawclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLicenseInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedNodes int64
+		expectedError bool
+	}{
+		{
+			name:          "successful retrieval",
+			status:        http.StatusOK,
+			body:          `{"company":"acme","expiresAt":1790000000,"nodes":10,"type":2,"valid":true}`,
+			expectedNodes: 10,
+		},
+		{
+			name:          "endpoint not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"not found"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/licenses/info", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			info, err := c.GetLicenseInfo()
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNodes, info.Nodes)
+			assert.Equal(t, "acme", info.Company)
+			assert.True(t, info.Valid)
+		})
+	}
+}
+
+func TestGetNodesCount(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/system/nodes", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"nodes":7}`))
+	})
+
+	count, err := c.GetNodesCount()
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+}
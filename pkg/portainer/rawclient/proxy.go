@@ -0,0 +1,60 @@
+package rawclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/portainer/client-api-go/v2/client"
+)
+
+// ProxyDockerRequest proxies a request to the Docker API of an environment through the Portainer API.
+// It replaces the SDK implementation to send the request with the transport of the raw client.
+//
+// Parameters:
+//   - environmentId: The ID of the target Docker environment in Portainer
+//   - opts: Options defining the proxied request (method, path, query params, headers, body)
+func (c *PortainerClient) ProxyDockerRequest(environmentId int, opts client.ProxyRequestOptions) (*http.Response, error) {
+	url := fmt.Sprintf("%s://%s/api/endpoints/%d/docker%s", c.scheme, c.host, environmentId, opts.APIPath)
+	return c.proxyRequest(url, opts)
+}
+
+// ProxyKubernetesRequest proxies a request to the Kubernetes API of an environment through the Portainer API.
+// It replaces the SDK implementation to send the request with the transport of the raw client.
+//
+// Parameters:
+//   - environmentId: The ID of the target Kubernetes environment in Portainer
+//   - opts: Options defining the proxied request (method, path, query params, headers, body)
+func (c *PortainerClient) ProxyKubernetesRequest(environmentId int, opts client.ProxyRequestOptions) (*http.Response, error) {
+	url := fmt.Sprintf("%s://%s/api/endpoints/%d/kubernetes%s", c.scheme, c.host, environmentId, opts.APIPath)
+	return c.proxyRequest(url, opts)
+}
+
+// proxyRequest sends a proxy request. Unlike the other operations, error responses are returned
+// as is so that callers get the status code and the body of the proxied API.
+func (c *PortainerClient) proxyRequest(url string, opts client.ProxyRequestOptions) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(c.requestContext(), opts.Method, url, opts.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proxy request: %w", err)
+	}
+
+	if len(opts.QueryParams) > 0 {
+		q := req.URL.Query()
+		for k, v := range opts.QueryParams {
+			q.Set(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	req.Header.Set("x-api-key", c.apiKey)
+
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.proxyCli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send proxy request: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+package rawclient
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		kubernetes   bool
+		opts         client.ProxyRequestOptions
+		status       int
+		body         string
+		expectedPath string
+	}{
+		{
+			name: "docker request",
+			opts: client.ProxyRequestOptions{
+				Method:      http.MethodGet,
+				APIPath:     "/containers/json",
+				QueryParams: map[string]string{"all": "1"},
+			},
+			status:       http.StatusOK,
+			body:         `[]`,
+			expectedPath: "/api/endpoints/3/docker/containers/json",
+		},
+		{
+			name:       "kubernetes request",
+			kubernetes: true,
+			opts: client.ProxyRequestOptions{
+				Method:  http.MethodPost,
+				APIPath: "/api/v1/namespaces",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    strings.NewReader(`{}`),
+			},
+			status:       http.StatusCreated,
+			body:         `{}`,
+			expectedPath: "/api/endpoints/3/kubernetes/api/v1/namespaces",
+		},
+		{
+			name: "error responses are returned as is",
+			opts: client.ProxyRequestOptions{
+				Method:  http.MethodGet,
+				APIPath: "/images/missing/json",
+			},
+			status:       http.StatusNotFound,
+			body:         `{"message":"No such image: missing"}`,
+			expectedPath: "/api/endpoints/3/docker/images/missing/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.opts.Method, r.Method)
+				assert.Equal(t, tt.expectedPath, r.URL.Path)
+				assert.Equal(t, testAPIKey, r.Header.Get("x-api-key"))
+				for k, v := range tt.opts.QueryParams {
+					assert.Equal(t, v, r.URL.Query().Get(k))
+				}
+				for k, v := range tt.opts.Headers {
+					assert.Equal(t, v, r.Header.Get(k))
+				}
+
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			var (
+				resp *http.Response
+				err  error
+			)
+			if tt.kubernetes {
+				resp, err = c.ProxyKubernetesRequest(3, tt.opts)
+			} else {
+				resp, err = c.ProxyDockerRequest(3, tt.opts)
+			}
+
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package rawclient
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/endpoints"
+	"github.com/portainer/client-api-go/v2/pkg/client/registries"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListRegistries lists all registries
+func (c *PortainerClient) ListRegistries() ([]*models.PortainereeRegistry, error) {
+	params := registries.NewRegistryListParams()
+	resp, err := c.api.Registries.RegistryList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// UpdateEndpointRegistryAccess sets the users and teams that can use a registry on an endpoint.
+// Empty user and team lists remove the access to the registry on the endpoint.
+//
+// Parameters:
+//   - endpointId: The ID of the endpoint
+//   - registryId: The ID of the registry
+//   - userIds: The IDs of the users that can use the registry on the endpoint
+//   - teamIds: The IDs of the teams that can use the registry on the endpoint
+func (c *PortainerClient) UpdateEndpointRegistryAccess(endpointId, registryId int64, userIds, teamIds []int64) error {
+	payload := &models.EndpointsRegistryAccessPayload{
+		UserAccessPolicies: models.PortainerUserAccessPolicies{},
+		TeamAccessPolicies: models.PortainerTeamAccessPolicies{},
+	}
+	for _, id := range userIds {
+		payload.UserAccessPolicies[strconv.FormatInt(id, 10)] = models.PortainerAccessPolicy{}
+	}
+	for _, id := range teamIds {
+		payload.TeamAccessPolicies[strconv.FormatInt(id, 10)] = models.PortainerAccessPolicy{}
+	}
+
+	params := endpoints.NewEndpointRegistryAccessParams().WithID(endpointId).WithRegistryID(registryId).WithBody(payload)
+	_, err := c.api.Endpoints.EndpointRegistryAccess(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update endpoint registry access: %w", err)
+	}
+
+	return nil
+}
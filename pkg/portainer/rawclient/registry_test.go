@@ -0,0 +1,60 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRegistries(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/registries", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":1,"Name":"private","URL":"registry.example.com","RegistryAccesses":{"3":{"UserAccessPolicies":{"2":{}}}}}]`))
+	})
+
+	registries, err := c.ListRegistries()
+
+	require.NoError(t, err)
+	require.Len(t, registries, 1)
+	assert.Equal(t, "private", registries[0].Name)
+	assert.Contains(t, registries[0].RegistryAccesses["3"].UserAccessPolicies, "2")
+}
+
+func TestUpdateEndpointRegistryAccess(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/endpoints/3/registries/1", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]any{"2": map[string]any{}}, payload["userAccessPolicies"])
+		assert.Equal(t, map[string]any{"4": map[string]any{}, "5": map[string]any{}}, payload["teamAccessPolicies"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.UpdateEndpointRegistryAccess(3, 1, []int64{2}, []int64{4, 5})
+
+	require.NoError(t, err)
+}
+
+func TestUpdateEndpointRegistryAccessForbidden(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"User is not authorized"}`))
+	})
+
+	err := c.UpdateEndpointRegistryAccess(3, 1, nil, nil)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Contains(t, err.Error(), "User is not authorized")
+}
@@ -0,0 +1,42 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/resource_controls"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// UpdateResourceControl updates the ownership of a resource control.
+//
+// Parameters:
+//   - id: The ID of the resource control to update
+//   - public: Whether the resource is accessible by all users
+//   - administratorsOnly: Whether the resource is only accessible by administrators
+//   - users: The IDs of the users allowed to access the resource
+//   - teams: The IDs of the teams allowed to access the resource
+func (c *PortainerClient) UpdateResourceControl(id int64, public, administratorsOnly bool, users, teams []int64) (*models.PortainerResourceControl, error) {
+	if users == nil {
+		users = []int64{}
+	}
+
+	if teams == nil {
+		teams = []int64{}
+	}
+
+	params := resource_controls.NewResourceControlUpdateParams().
+		WithID(id).
+		WithBody(&models.ResourcecontrolsResourceControlUpdatePayload{
+			Public:             public,
+			AdministratorsOnly: administratorsOnly,
+			Users:              users,
+			Teams:              teams,
+		})
+
+	resp, err := c.api.ResourceControls.ResourceControlUpdate(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update resource control: %w", err)
+	}
+
+	return resp.Payload, nil
+}
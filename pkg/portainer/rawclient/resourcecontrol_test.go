@@ -0,0 +1,89 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateResourceControl(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		public        bool
+		adminOnly     bool
+		users         []int64
+		teams         []int64
+		status        int
+		body          string
+		expectedBody  map[string]any
+		expectedID    int64
+		expectedError bool
+	}{
+		{
+			name:   "restricted to users and teams",
+			id:     12,
+			users:  []int64{2},
+			teams:  []int64{3, 4},
+			status: http.StatusOK,
+			body:   `{"Id":12,"ResourceId":"vol1","Type":3}`,
+			expectedBody: map[string]any{
+				"users": []any{float64(2)},
+				"teams": []any{float64(3), float64(4)},
+			},
+			expectedID: 12,
+		},
+		{
+			name:   "public without users or teams",
+			id:     5,
+			public: true,
+			status: http.StatusOK,
+			body:   `{"Id":5,"Public":true}`,
+			expectedBody: map[string]any{
+				"public": true,
+				"users":  []any{},
+				"teams":  []any{},
+			},
+			expectedID: 5,
+		},
+		{
+			name:          "resource control not found",
+			id:            99,
+			status:        http.StatusNotFound,
+			body:          `{"message":"not found"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, fmt.Sprintf("/api/resource_controls/%d", tt.id), r.URL.Path)
+
+				var body map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				if tt.expectedBody != nil {
+					assert.Equal(t, tt.expectedBody, body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			rc, err := c.UpdateResourceControl(tt.id, tt.public, tt.adminOnly, tt.users, tt.teams)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, rc.ID)
+		})
+	}
+}
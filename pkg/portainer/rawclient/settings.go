@@ -0,0 +1,102 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/portainer/client-api-go/v2/pkg/client/settings"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// GetSettings retrieves the settings of the Portainer instance
+func (c *PortainerClient) GetSettings() (*models.PortainereeSettings, error) {
+	params := settings.NewSettingsInspectParams()
+	resp, err := c.api.Settings.SettingsInspect(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetPublicSettings retrieves the settings Portainer exposes without authentication.
+// The request is sent without the API key, so that it succeeds whether the key is valid or not.
+func (c *PortainerClient) GetPublicSettings() (*models.SettingsPublicSettingsResponse, error) {
+	withoutAPIKey := func(op *runtime.ClientOperation) {
+		op.AuthInfo = runtime.ClientAuthInfoWriterFunc(func(runtime.ClientRequest, strfmt.Registry) error {
+			return nil
+		})
+	}
+
+	resp, err := c.api.Settings.SettingsPublic(settings.NewSettingsPublicParams(), withoutAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public settings: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// UpdateAuthSettings updates the authentication settings of the Portainer instance.
+// The SDK settings update only exposes the Edge settings.
+//
+// Parameters:
+//   - authenticationMethod: The authentication method, 1 for internal, 2 for LDAP or 3 for OAuth
+//   - oauthSettings: The OAuth settings, nil leaves the current OAuth settings unchanged
+//
+// Portainer replaces the whole OAuth settings but keeps the current client secret when
+// the provided client secret is empty.
+func (c *PortainerClient) UpdateAuthSettings(authenticationMethod int64, oauthSettings *models.PortainereeOAuthSettings) error {
+	params := settings.NewSettingsUpdateParams().
+		WithBody(&models.SettingsSettingsUpdatePayload{
+			AuthenticationMethod: authenticationMethod,
+			OauthSettings:        oauthSettings,
+		})
+
+	_, err := c.api.Settings.SettingsUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update settings: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSessionTimeout updates the duration of the user sessions of the Portainer instance.
+// The other settings are not sent and are left unchanged by Portainer.
+//
+// Parameters:
+//   - sessionTimeout: The duration of a user session, in the Go duration format, e.g. 8h
+func (c *PortainerClient) UpdateSessionTimeout(sessionTimeout string) error {
+	params := settings.NewSettingsUpdateParams().
+		WithBody(&models.SettingsSettingsUpdatePayload{
+			UserSessionTimeout: sessionTimeout,
+		})
+
+	_, err := c.api.Settings.SettingsUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update settings: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBrandingSettings updates the branding settings of the Portainer instance.
+// The other settings are not sent and are left unchanged by Portainer.
+//
+// Parameters:
+//   - logoURL: The URL of the logo, empty leaves the current logo unchanged
+//   - loginBanner: The banner displayed on the login page, empty leaves the current banner unchanged
+func (c *PortainerClient) UpdateBrandingSettings(logoURL, loginBanner string) error {
+	params := settings.NewSettingsUpdateParams().
+		WithBody(&models.SettingsSettingsUpdatePayload{
+			LogoURL:           logoURL,
+			CustomLoginBanner: loginBanner,
+		})
+
+	_, err := c.api.Settings.SettingsUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update settings: %w", err)
+	}
+
+	return nil
+}
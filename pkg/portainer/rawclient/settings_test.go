@@ -0,0 +1,240 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSettings(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/settings", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"AuthenticationMethod":1,"EnableEdgeComputeFeatures":true}`))
+	})
+
+	settings, err := c.GetSettings()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), settings.AuthenticationMethod)
+	assert.True(t, settings.EnableEdgeComputeFeatures)
+}
+
+func TestGetPublicSettings(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/settings/public", r.URL.Path)
+		assert.Empty(t, r.Header.Get("x-api-key"), "the public settings must be requested without the API key")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"AuthenticationMethod":3,"OAuthLoginURI":"https://sso.example.com/authorize","RequiredPasswordLength":12}`))
+	})
+
+	settings, err := c.GetPublicSettings()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), settings.AuthenticationMethod)
+	assert.Equal(t, "https://sso.example.com/authorize", settings.OAuthLoginURI)
+	assert.Equal(t, int64(12), settings.RequiredPasswordLength)
+}
+
+func TestUpdateAuthSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        int64
+		oauthSettings *models.PortainereeOAuthSettings
+		status        int
+		body          string
+		expectedBody  map[string]any
+		expectedError string
+	}{
+		{
+			name:   "update oauth settings",
+			method: 3,
+			oauthSettings: &models.PortainereeOAuthSettings{
+				ClientID:     "portainer",
+				ClientSecret: "secret",
+				SSO:          true,
+			},
+			status: http.StatusOK,
+			body:   `{}`,
+			expectedBody: map[string]any{
+				"authenticationMethod": float64(3),
+				"blackListedLabels":    nil,
+				"oauthSettings": map[string]any{
+					"ClientID":      "portainer",
+					"ClientSecret":  "secret",
+					"KubeSecretKey": nil,
+					"SSO":           true,
+				},
+			},
+		},
+		{
+			name:   "update authentication method only",
+			method: 1,
+			status: http.StatusOK,
+			body:   `{}`,
+			expectedBody: map[string]any{
+				"authenticationMethod": float64(1),
+				"blackListedLabels":    nil,
+			},
+		},
+		{
+			name:          "portainer rejects the update",
+			method:        2,
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Invalid request payload","details":"Invalid authentication method value"}`,
+			expectedError: "Invalid authentication method value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/settings", r.URL.Path)
+
+				var body map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				if tt.expectedBody != nil {
+					assert.Equal(t, tt.expectedBody, body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.UpdateAuthSettings(tt.method, tt.oauthSettings)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateSessionTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{
+			name:   "update session timeout",
+			status: http.StatusOK,
+			body:   `{}`,
+		},
+		{
+			name:          "portainer rejects the update",
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Invalid request payload","details":"Invalid user session timeout"}`,
+			expectedError: "Invalid user session timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/settings", r.URL.Path)
+
+				var body map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, map[string]any{
+					"blackListedLabels":  nil,
+					"userSessionTimeout": "8h",
+				}, body)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.UpdateSessionTimeout("8h")
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateBrandingSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		logoURL       string
+		loginBanner   string
+		status        int
+		body          string
+		expectedBody  map[string]any
+		expectedError string
+	}{
+		{
+			name:        "update logo and login banner",
+			logoURL:     "https://example.com/logo.png",
+			loginBanner: "Authorized users only",
+			status:      http.StatusOK,
+			body:        `{}`,
+			expectedBody: map[string]any{
+				"blackListedLabels": nil,
+				"logoURL":           "https://example.com/logo.png",
+				"customLoginBanner": "Authorized users only",
+			},
+		},
+		{
+			name:    "update logo only",
+			logoURL: "https://example.com/logo.png",
+			status:  http.StatusOK,
+			body:    `{}`,
+			expectedBody: map[string]any{
+				"blackListedLabels": nil,
+				"logoURL":           "https://example.com/logo.png",
+			},
+		},
+		{
+			name:          "portainer rejects the update",
+			logoURL:       "logo",
+			status:        http.StatusBadRequest,
+			body:          `{"message":"Invalid request payload","details":"Invalid logo URL. Must correspond to a valid URL format"}`,
+			expectedError: "Invalid logo URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/settings", r.URL.Path)
+
+				var body map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				if tt.expectedBody != nil {
+					assert.Equal(t, tt.expectedBody, body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.UpdateBrandingSettings(tt.logoURL, tt.loginBanner)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
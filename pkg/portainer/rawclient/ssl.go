@@ -0,0 +1,56 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/portainer/client-api-go/v2/pkg/client/ssl"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// sslUpdatePayload is the body of the SSL settings update. The generated payload omits
+// httpenabled when it is false, which makes it impossible to disable HTTP.
+type sslUpdatePayload struct {
+	Cert        string `json:"cert,omitempty"`
+	Key         string `json:"key,omitempty"`
+	HTTPEnabled bool   `json:"httpenabled"`
+}
+
+// GetSSLSettings retrieves the SSL settings of the Portainer server
+func (c *PortainerClient) GetSSLSettings() (*models.PortainereeSSLSettings, error) {
+	resp, err := c.api.Ssl.SSLInspect(ssl.NewSSLInspectParams(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SSL settings: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// UpdateSSLSettings updates the SSL settings of the Portainer server.
+// Portainer restarts its HTTP servers to apply the new settings.
+//
+// Parameters:
+//   - cert: The PEM encoded certificate, empty to keep the current certificate
+//   - key: The PEM encoded private key of the certificate, empty to keep the current key
+//   - httpEnabled: Whether Portainer also serves plain HTTP
+func (c *PortainerClient) UpdateSSLSettings(cert, key string, httpEnabled bool) error {
+	payload := sslUpdatePayload{
+		Cert:        cert,
+		Key:         key,
+		HTTPEnabled: httpEnabled,
+	}
+
+	withPayload := func(op *runtime.ClientOperation) {
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			return r.SetBodyParam(payload)
+		})
+	}
+
+	_, err := c.api.Ssl.SSLUpdate(ssl.NewSSLUpdateParams(), nil, withPayload)
+	if err != nil {
+		return fmt.Errorf("failed to update SSL settings: %w", err)
+	}
+
+	return nil
+}
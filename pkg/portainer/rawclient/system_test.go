@@ -0,0 +1,24 @@
+package rawclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersion(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/system/status", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Version":"2.31.2"}`))
+	})
+
+	version, err := c.GetVersion()
+
+	require.NoError(t, err)
+	assert.Equal(t, "2.31.2", version)
+}
@@ -0,0 +1,75 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTags(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/tags", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":1,"name":"prod"}]`))
+	})
+
+	tags, err := c.ListTags()
+
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "prod", tags[0].Name)
+}
+
+func TestCreateTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedID    int64
+		expectedError string
+	}{
+		{
+			name:       "successful creation",
+			status:     http.StatusOK,
+			body:       `{"id":2,"name":"prod"}`,
+			expectedID: 2,
+		},
+		{
+			name:          "tag already exists",
+			status:        http.StatusConflict,
+			body:          `{"message":"This name is already associated to a tag"}`,
+			expectedError: "already associated to a tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/api/tags", r.URL.Path)
+
+				var payload map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, map[string]any{"name": "prod"}, payload)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			id, err := c.CreateTag("prod")
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/team_memberships"
+	"github.com/portainer/client-api-go/v2/pkg/client/teams"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListTeams lists all teams
+func (c *PortainerClient) ListTeams() ([]*models.PortainerTeam, error) {
+	params := teams.NewTeamListParams()
+	resp, err := c.api.Teams.TeamList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// CreateTeam creates a new team
+func (c *PortainerClient) CreateTeam(name string) (int64, error) {
+	params := teams.NewTeamCreateParams().WithBody(&models.TeamsTeamCreatePayload{
+		Name: &name,
+	})
+
+	resp, err := c.api.Teams.TeamCreate(params, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create team: %w", err)
+	}
+
+	return resp.Payload.ID, nil
+}
+
+// UpdateTeamName updates the name of a team
+func (c *PortainerClient) UpdateTeamName(id int, name string) error {
+	params := teams.NewTeamUpdateParams().WithID(int64(id)).WithBody(&models.TeamsTeamUpdatePayload{
+		Name: name,
+	})
+
+	_, err := c.api.Teams.TeamUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+
+	return nil
+}
+
+// ListTeamMemberships lists all team memberships
+func (c *PortainerClient) ListTeamMemberships() ([]*models.PortainerTeamMembership, error) {
+	params := team_memberships.NewTeamMembershipListParams()
+	resp, err := c.api.TeamMemberships.TeamMembershipList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team memberships: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// CreateTeamMembership adds a user to a team as a regular member
+func (c *PortainerClient) CreateTeamMembership(teamId, userId int) error {
+	return c.CreateTeamMembershipWithRole(teamId, userId, 2)
+}
+
+// DeleteTeamMembership removes a user from a team by deleting the team membership with the given ID
+func (c *PortainerClient) DeleteTeamMembership(id int) error {
+	params := team_memberships.NewTeamMembershipDeleteParams().WithID(int64(id))
+
+	_, err := c.api.TeamMemberships.TeamMembershipDelete(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete team membership: %w", err)
+	}
+
+	return nil
+}
+
+// CreateTeamMembershipWithRole adds a user to a team with the given role.
+// Unlike CreateTeamMembership, which always adds regular members, it lets callers choose the role.
+//
+// Parameters:
+//   - teamId: The ID of the team
+//   - userId: The ID of the user to add to the team
+//   - role: The role of the user inside the team (1 for leader and 2 for regular member)
+func (c *PortainerClient) CreateTeamMembershipWithRole(teamId, userId int, role int64) error {
+	teamID := int64(teamId)
+	userID := int64(userId)
+	params := team_memberships.NewTeamMembershipCreateParams().WithBody(&models.TeammembershipsTeamMembershipCreatePayload{
+		Role:   &role,
+		TeamID: &teamID,
+		UserID: &userID,
+	})
+
+	_, err := c.api.TeamMemberships.TeamMembershipCreate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create team membership: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTeamMembershipRole changes the role of a user inside a team.
+//
+// Parameters:
+//   - id: The ID of the team membership
+//   - teamId: The ID of the team of the membership
+//   - userId: The ID of the user of the membership
+//   - role: The new role of the user inside the team (1 for leader and 2 for regular member)
+func (c *PortainerClient) UpdateTeamMembershipRole(id, teamId, userId int, role int64) error {
+	teamID := int64(teamId)
+	userID := int64(userId)
+	params := team_memberships.NewTeamMembershipUpdateParams().WithID(int64(id)).WithBody(&models.TeammembershipsTeamMembershipUpdatePayload{
+		Role:   &role,
+		TeamID: &teamID,
+		UserID: &userID,
+	})
+
+	_, err := c.api.TeamMemberships.TeamMembershipUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update team membership: %w", err)
+	}
+
+	return nil
+}
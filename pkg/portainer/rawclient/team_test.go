@@ -0,0 +1,174 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTeams(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/teams", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":2,"Name":"devs"}]`))
+	})
+
+	teams, err := c.ListTeams()
+
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "devs", teams[0].Name)
+}
+
+func TestCreateTeam(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/teams", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "devs", payload["name"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":2,"Name":"devs"}`))
+	})
+
+	id, err := c.CreateTeam("devs")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), id)
+}
+
+func TestUpdateTeamName(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/teams/2", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "ops", payload["name"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":2,"Name":"ops"}`))
+	})
+
+	err := c.UpdateTeamName(2, "ops")
+
+	assert.NoError(t, err)
+}
+
+func TestListTeamMemberships(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/team_memberships", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":7,"Role":2,"TeamID":2,"UserID":5}]`))
+	})
+
+	memberships, err := c.ListTeamMemberships()
+
+	require.NoError(t, err)
+	require.Len(t, memberships, 1)
+	assert.Equal(t, int64(5), memberships[0].UserID)
+}
+
+func TestCreateTeamMembership(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/team_memberships", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]any{"role": float64(2), "teamID": float64(2), "userID": float64(5)}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":7,"Role":2,"TeamID":2,"UserID":5}`))
+	})
+
+	err := c.CreateTeamMembership(2, 5)
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteTeamMembership(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/team_memberships/7", r.URL.Path)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.DeleteTeamMembership(7)
+
+	assert.NoError(t, err)
+}
+
+func TestCreateTeamMembershipWithRole(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/team_memberships", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]any{"role": float64(1), "teamID": float64(2), "userID": float64(5)}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":7,"Role":1,"TeamID":2,"UserID":5}`))
+	})
+
+	err := c.CreateTeamMembershipWithRole(2, 5, 1)
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateTeamMembershipRole(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "successful update",
+			status: http.StatusOK,
+			body:   `{"Id":7,"Role":1,"TeamID":2,"UserID":5}`,
+		},
+		{
+			name:          "permission denied",
+			status:        http.StatusForbidden,
+			body:          `{"message":"Permission denied to update the membership"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/team_memberships/7", r.URL.Path)
+
+				var payload map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, map[string]any{"role": float64(1), "teamID": float64(2), "userID": float64(5)}, payload)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.UpdateTeamMembershipRole(7, 2, 5, 1)
+
+			if tt.expectedError {
+				assert.ErrorContains(t, err, "Permission denied to update the membership")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package rawclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer used for the requests sent to the Portainer API
+const tracerName = "github.com/portainer/portainer-mcp/pkg/portainer/rawclient"
+
+// tracePropagator injects the trace context into the requests sent to the Portainer API
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// WithTracerProvider traces the requests sent to the Portainer API with the given tracer provider.
+// Every request gets a client span tagged with its method, path and response status code,
+// and the trace context is propagated to Portainer. Query strings are never recorded.
+// Requests are not traced when no tracer provider is set.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(o *clientOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// tracingTransport records a span for every request sent to the Portainer API
+type tracingTransport struct {
+	next   http.RoundTripper
+	tracer trace.Tracer
+}
+
+func newTracingTransport(next http.RoundTripper, tp trace.TracerProvider) *tracingTransport {
+	return &tracingTransport{
+		next:   next,
+		tracer: tp.Tracer(tracerName),
+	}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The path identifies the Portainer endpoint, it is an attribute rather than part of the span name
+	// to keep span names low cardinality
+	ctx, span := t.tracer.Start(req.Context(), fmt.Sprintf("portainer %s", req.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("server.address", req.URL.Host),
+		),
+	)
+	defer span.End()
+
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(ctx)
+	tracePropagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
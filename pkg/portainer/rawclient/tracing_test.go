@@ -0,0 +1,207 @@
+package rawclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/portainer/client-api-go/v2/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingTracerProvider records the spans started by its tracers
+type recordingTracerProvider struct {
+	noop.TracerProvider
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: p}
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	provider *recordingTracerProvider
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordingSpan{
+		name:       name,
+		kind:       config.SpanKind(),
+		attributes: config.Attributes(),
+		parent:     trace.SpanContextFromContext(ctx),
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01},
+			SpanID:     trace.SpanID{byte(len(t.provider.spans) + 1)},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+
+	t.provider.mu.Lock()
+	t.provider.spans = append(t.provider.spans, span)
+	t.provider.mu.Unlock()
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	noop.Span
+	name        string
+	kind        trace.SpanKind
+	attributes  []attribute.KeyValue
+	parent      trace.SpanContext
+	spanContext trace.SpanContext
+	status      codes.Code
+	ended       bool
+}
+
+func (s *recordingSpan) SpanContext() trace.SpanContext { return s.spanContext }
+func (s *recordingSpan) IsRecording() bool              { return true }
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, kv...)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+func (s *recordingSpan) End(...trace.SpanEndOption)          { s.ended = true }
+
+func (s *recordingSpan) attribute(key string) attribute.Value {
+	for _, kv := range s.attributes {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingTransport(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		expectedStatus codes.Code
+	}{
+		{
+			name:           "successful request",
+			status:         http.StatusOK,
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "error response",
+			status:         http.StatusInternalServerError,
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp := &recordingTracerProvider{}
+			var traceparent string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				traceparent = r.Header.Get("traceparent")
+				w.WriteHeader(tt.status)
+			}, WithTracerProvider(tp))
+
+			resp, err := c.ProxyDockerRequest(1, client.ProxyRequestOptions{
+				Method:      http.MethodGet,
+				APIPath:     "/containers/json",
+				QueryParams: map[string]string{"filters": `{"name":["secret"]}`},
+			})
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			require.Len(t, tp.spans, 1)
+			span := tp.spans[0]
+			assert.Equal(t, "portainer GET", span.name)
+			assert.Equal(t, trace.SpanKindClient, span.kind)
+			assert.Equal(t, "/api/endpoints/1/docker/containers/json", span.attribute("url.path").AsString())
+			assert.Equal(t, http.MethodGet, span.attribute("http.request.method").AsString())
+			assert.Equal(t, int64(tt.status), span.attribute("http.response.status_code").AsInt64())
+			assert.Equal(t, tt.expectedStatus, span.status)
+			assert.True(t, span.ended)
+
+			for _, kv := range span.attributes {
+				assert.NotContains(t, kv.Value.Emit(), "secret", "query strings should not be recorded")
+			}
+
+			assert.Equal(t, "00-"+span.spanContext.TraceID().String()+"-"+span.spanContext.SpanID().String()+"-01", traceparent)
+		})
+	}
+}
+
+func TestTracingTransportErrorResponse(t *testing.T) {
+	tp := &recordingTracerProvider{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}, WithTracerProvider(tp))
+
+	_, err := c.GetLicenseInfo()
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr, "error responses should still be turned into an APIError")
+	require.Len(t, tp.spans, 1)
+	assert.Equal(t, "/api/licenses/info", tp.spans[0].attribute("url.path").AsString())
+	assert.Equal(t, codes.Error, tp.spans[0].status)
+}
+
+func TestNoTracerProvider(t *testing.T) {
+	c := NewPortainerClient("portainer.example.com:9443", testAPIKey)
+
+	_, ok := c.proxyCli.Transport.(*http.Transport)
+	assert.True(t, ok, "requests should not be traced without a tracer provider")
+}
+
+func TestWithContext(t *testing.T) {
+	tp := &recordingTracerProvider{}
+	var traceparents []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}, WithTracerProvider(tp))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "tools/call getLicenseInfo")
+	cli := c.WithContext(ctx)
+
+	_, err := cli.GetLicenseInfo()
+	require.NoError(t, err)
+	resp, err := cli.ProxyDockerRequest(1, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/info"})
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, tp.spans, 3)
+	for i, span := range tp.spans[1:] {
+		assert.Equal(t, parent.SpanContext(), span.parent, "the request spans should be children of the context span")
+		assert.Equal(t, "00-"+span.spanContext.TraceID().String()+"-"+span.spanContext.SpanID().String()+"-01", traceparents[i])
+	}
+
+	_, err = c.GetLicenseInfo()
+	require.NoError(t, err)
+	require.Len(t, tp.spans, 4)
+	assert.False(t, tp.spans[3].parent.IsValid(), "the original client should not use the context")
+}
+
+func TestWithContextCancelled(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.WithContext(ctx).GetLicenseInfo()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = c.WithContext(ctx).ProxyDockerRequest(1, client.ProxyRequestOptions{Method: http.MethodGet, APIPath: "/info"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
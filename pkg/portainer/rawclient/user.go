@@ -0,0 +1,111 @@
+package rawclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/users"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListUsers lists all users
+func (c *PortainerClient) ListUsers() ([]*models.PortainereeUser, error) {
+	params := users.NewUserListParams()
+	resp, err := c.api.Users.UserList(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetCurrentUser returns the user the API token belongs to
+func (c *PortainerClient) GetCurrentUser() (*models.PortainereeUser, error) {
+	params := users.NewCurrentUserInspectParams()
+	resp, err := c.api.Users.CurrentUserInspect(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// GetUser retrieves a user by ID
+func (c *PortainerClient) GetUser(id int) (*models.PortainereeUser, error) {
+	params := users.NewUserInspectParams().WithID(int64(id))
+	resp, err := c.api.Users.UserInspect(params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// UpdateUserRole updates the role of a user.
+//
+// Parameters:
+//   - id: The ID of the user to update
+//   - role: The new role of the user (1 for administrator, 2 for regular user and 3 for edge administrator)
+func (c *PortainerClient) UpdateUserRole(id int, role int64) error {
+	params := users.NewUserUpdateParams().WithID(int64(id)).WithBody(&models.UsersUserUpdatePayload{
+		Role: &role,
+	})
+
+	_, err := c.api.Users.UserUpdate(params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+// userPreferencesPayload is the part of the user update payload holding the UI preferences of a user
+type userPreferencesPayload struct {
+	Theme struct {
+		Color               string `json:"color"`
+		SubtleUpgradeButton bool   `json:"subtleUpgradeButton"`
+	} `json:"theme"`
+	UseCache bool `json:"useCache"`
+}
+
+// UpdateUserPreferences updates the UI preferences of a user.
+// The SDK omits the false values of the theme settings, so the request is sent directly.
+//
+// Parameters:
+//   - id: The ID of the user to update
+//   - themeColor: The theme of the UI, one of dark, light, highcontrast or auto
+//   - subtleUpgradeButton: Whether the upgrade button of the UI is displayed discreetly
+//   - useCache: Whether the UI caches the API responses
+func (c *PortainerClient) UpdateUserPreferences(id int, themeColor string, subtleUpgradeButton, useCache bool) error {
+	payload := userPreferencesPayload{UseCache: useCache}
+	payload.Theme.Color = themeColor
+	payload.Theme.SubtleUpgradeButton = subtleUpgradeButton
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode user preferences: %w", err)
+	}
+
+	url := fmt.Sprintf("%s://%s/api/users/%d", c.scheme, c.host, id)
+	req, err := http.NewRequestWithContext(c.requestContext(), http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create user update request: %w", err)
+	}
+
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.proxyCli.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to update user: %w", newAPIError(resp))
+	}
+
+	return nil
+}
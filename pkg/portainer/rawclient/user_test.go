@@ -0,0 +1,133 @@
+package rawclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUsers(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/users", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":1,"Username":"admin","Role":1}]`))
+	})
+
+	users, err := c.ListUsers()
+
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "admin", users[0].Username)
+	assert.Equal(t, int64(1), users[0].Role)
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/users/me", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":2,"Username":"operator","Role":2,"PortainerAuthorizations":{"PortainerUserListToken":true}}`))
+	})
+
+	user, err := c.GetCurrentUser()
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), user.ID)
+	assert.Equal(t, "operator", user.Username)
+	assert.True(t, user.PortainerAuthorizations["PortainerUserListToken"])
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/users/2", r.URL.Path)
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, float64(3), payload["role"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":2,"Role":3}`))
+	})
+
+	err := c.UpdateUserRole(2, 3)
+
+	assert.NoError(t, err)
+}
+
+func TestGetUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/users/2", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Id":2,"Username":"operator","Role":2,"ThemeSettings":{"color":"dark"},"UseCache":true}`))
+	})
+
+	user, err := c.GetUser(2)
+
+	require.NoError(t, err)
+	assert.Equal(t, "operator", user.Username)
+	require.NotNil(t, user.ThemeSettings)
+	assert.Equal(t, "dark", user.ThemeSettings.Color)
+	assert.True(t, user.UseCache)
+}
+
+func TestUpdateUserPreferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError bool
+	}{
+		{
+			name:   "false values are sent",
+			status: http.StatusOK,
+			body:   `{"Id":2}`,
+		},
+		{
+			name:          "not allowed to update the user",
+			status:        http.StatusForbidden,
+			body:          `{"message":"Permission denied to update user"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/api/users/2", r.URL.Path)
+				assert.Equal(t, testAPIKey, r.Header.Get("x-api-key"))
+
+				var payload map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, map[string]any{
+					"theme":    map[string]any{"color": "highcontrast", "subtleUpgradeButton": false},
+					"useCache": false,
+				}, payload)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := c.UpdateUserPreferences(2, "highcontrast", false, false)
+
+			if tt.expectedError {
+				require.Error(t, err)
+				var apiErr *APIError
+				assert.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
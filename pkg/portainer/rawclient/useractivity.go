@@ -0,0 +1,56 @@
+package rawclient
+
+import (
+	"fmt"
+
+	"github.com/portainer/client-api-go/v2/pkg/client/useractivity"
+	"github.com/portainer/client-api-go/v2/pkg/models"
+)
+
+// ListUserActivityLogs lists the user activity logs.
+// This endpoint is only available on Portainer Business Edition.
+//
+// Parameters:
+//   - after: Only return logs after this unix timestamp (0 to disable)
+//   - before: Only return logs before this unix timestamp (0 to disable)
+//   - limit: Maximum number of logs to return (0 to use the server default)
+func (c *PortainerClient) ListUserActivityLogs(after, before, limit int64) ([]*models.PortainereeUserActivityLog, error) {
+	logs, _, err := c.PageUserActivityLogs(after, before, limit, 0)
+	return logs, err
+}
+
+// PageUserActivityLogs lists a page of the user activity logs, most recent first, with the number of
+// logs of the time range. This endpoint is only available on Portainer Business Edition.
+//
+// Parameters:
+//   - after: Only return logs after this unix timestamp (0 to disable)
+//   - before: Only return logs before this unix timestamp (0 to disable)
+//   - limit: Maximum number of logs to return (0 to use the server default)
+//   - offset: Number of logs of the time range to skip
+func (c *PortainerClient) PageUserActivityLogs(after, before, limit, offset int64) ([]*models.PortainereeUserActivityLog, int64, error) {
+	sortDesc := true
+	params := useractivity.NewLogsListParams().WithSortDesc(&sortDesc)
+
+	if after > 0 {
+		params.SetAfter(&after)
+	}
+
+	if before > 0 {
+		params.SetBefore(&before)
+	}
+
+	if limit > 0 {
+		params.SetLimit(&limit)
+	}
+
+	if offset > 0 {
+		params.SetOffset(&offset)
+	}
+
+	resp, err := c.api.Useractivity.LogsList(params, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list user activity logs: %w", err)
+	}
+
+	return resp.Payload.Logs, resp.Payload.TotalCount, nil
+}
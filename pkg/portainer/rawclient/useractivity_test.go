@@ -0,0 +1,97 @@
+package rawclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageUserActivityLogs(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/useractivity/logs", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.Equal(t, "200", r.URL.Query().Get("offset"))
+		assert.Equal(t, "true", r.URL.Query().Get("sortDesc"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"logs":[{"id":3},{"id":2}],"totalCount":202}`))
+	})
+
+	logs, totalCount, err := c.PageUserActivityLogs(0, 0, 100, 200)
+
+	require.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, int64(202), totalCount)
+}
+
+func TestListUserActivityLogs(t *testing.T) {
+	tests := []struct {
+		name          string
+		after         int64
+		before        int64
+		limit         int64
+		status        int
+		body          string
+		expectedQuery map[string]string
+		expectedCount int
+		expectedError bool
+	}{
+		{
+			name:   "all filters",
+			after:  100,
+			before: 200,
+			limit:  10,
+			status: http.StatusOK,
+			body:   `{"logs":[{"id":1,"action":"create","username":"admin","timestamp":150}],"totalCount":1}`,
+			expectedQuery: map[string]string{
+				"after":    "100",
+				"before":   "200",
+				"limit":    "10",
+				"sortDesc": "true",
+			},
+			expectedCount: 1,
+		},
+		{
+			name:   "no filters",
+			status: http.StatusOK,
+			body:   `{"logs":[{"id":1},{"id":2}]}`,
+			expectedQuery: map[string]string{
+				"after":  "",
+				"before": "",
+				"limit":  "",
+			},
+			expectedCount: 2,
+		},
+		{
+			name:          "endpoint not found",
+			status:        http.StatusNotFound,
+			body:          `{"message":"not found"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/useractivity/logs", r.URL.Path)
+				for key, value := range tt.expectedQuery {
+					assert.Equal(t, value, r.URL.Query().Get(key), "query parameter %s", key)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			logs, err := c.ListUserActivityLogs(tt.after, tt.before, tt.limit)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, logs, tt.expectedCount)
+		})
+	}
+}
@@ -18,6 +18,13 @@ func NewParameterParser(request mcp.CallToolRequest) *ParameterParser {
 	}
 }
 
+// Has reports whether a non-null parameter is present in the request.
+// It allows telling an optional parameter that was not provided from one set to its zero value.
+func (p *ParameterParser) Has(name string) bool {
+	value, ok := p.args[name]
+	return ok && value != nil
+}
+
 // GetString extracts a string parameter from the request
 func (p *ParameterParser) GetString(name string, required bool) (string, error) {
 	value, ok := p.args[name]
@@ -99,6 +106,33 @@ func (p *ParameterParser) GetArrayOfIntegers(name string, required bool) ([]int,
 	return parseArrayOfIntegers(arrayValue)
 }
 
+// GetArrayOfStrings extracts an array of strings parameter from the request
+func (p *ParameterParser) GetArrayOfStrings(name string, required bool) ([]string, error) {
+	value, ok := p.args[name]
+	if !ok || value == nil {
+		if required {
+			return nil, fmt.Errorf("%s is required", name)
+		}
+		return []string{}, nil
+	}
+
+	arrayValue, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array", name)
+	}
+
+	result := make([]string, 0, len(arrayValue))
+	for _, item := range arrayValue {
+		str, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse '%v' as string", item)
+		}
+		result = append(result, str)
+	}
+
+	return result, nil
+}
+
 // GetArrayOfObjects extracts an array of objects parameter from the request
 func (p *ParameterParser) GetArrayOfObjects(name string, required bool) ([]any, error) {
 	value, ok := p.args[name]
@@ -579,3 +579,120 @@ func TestGetArrayOfIntegers(t *testing.T) {
 		})
 	}
 }
+
+func TestGetArrayOfStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		param    string
+		required bool
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "valid array of strings",
+			args:     map[string]any{"names": []any{"web", "db"}},
+			param:    "names",
+			required: true,
+			want:     []string{"web", "db"},
+			wantErr:  false,
+		},
+		{
+			name:     "empty array",
+			args:     map[string]any{"names": []any{}},
+			param:    "names",
+			required: true,
+			want:     []string{},
+			wantErr:  false,
+		},
+		{
+			name:     "missing required param",
+			args:     map[string]any{},
+			param:    "names",
+			required: true,
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "missing optional param",
+			args:     map[string]any{},
+			param:    "names",
+			required: false,
+			want:     []string{},
+			wantErr:  false,
+		},
+		{
+			name:     "invalid array with number",
+			args:     map[string]any{"names": []any{"web", float64(2)}},
+			param:    "names",
+			required: true,
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "wrong type (string instead of array)",
+			args:     map[string]any{"names": "web"},
+			param:    "names",
+			required: true,
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestParser(tt.args)
+			got, err := p.GetArrayOfStrings(tt.param, tt.required)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetArrayOfStrings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetArrayOfStrings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHas(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  map[string]any
+		param string
+		want  bool
+	}{
+		{
+			name:  "present param",
+			args:  map[string]any{"count": float64(3)},
+			param: "count",
+			want:  true,
+		},
+		{
+			name:  "present zero value",
+			args:  map[string]any{"count": float64(0)},
+			param: "count",
+			want:  true,
+		},
+		{
+			name:  "missing param",
+			args:  map[string]any{},
+			param: "count",
+			want:  false,
+		},
+		{
+			name:  "nil value",
+			args:  map[string]any{"count": nil},
+			param: "count",
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestParser(tt.args)
+			if got := p.Has(tt.param); got != tt.want {
+				t.Errorf("Has() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package toolgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// supportedParameterTypes are the parameter types converted by convertParameter,
+// the other types are silently loaded as strings
+var supportedParameterTypes = []string{"string", "number", "boolean", "array", "object"}
+
+// ValidationError lists the problems found in a tools file by ValidateToolsFile.
+// Each problem starts with the line of the tool or the parameter it is about.
+type ValidationError struct {
+	FilePath string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid tools file %s: %s", e.FilePath, strings.Join(e.Problems, "; "))
+}
+
+// ValidateToolsFile checks a tools.yaml file without starting the server, e.g. to lint a custom
+// tools file in CI. The checks are stricter than LoadToolsFromYAML, which skips the invalid tools
+// and loads the unknown parameter types as strings: every problem is reported instead.
+//
+// The file is rejected when:
+//   - it is not valid YAML or holds fields that are not part of the schema, e.g. a misspelled key
+//   - its version is missing, invalid, below the minimum version or above the maximum version
+//   - a tool has no name, no description or no annotations block, or has the name of another tool
+//   - a parameter has no name, the name of another parameter of the same tool or an unsupported type
+//   - an array parameter has no items, or a parameter other than a string has an enum
+//
+// Parameters:
+//   - filePath: The path of the tools.yaml file
+//   - minimumVersion: The minimum version of the tools.yaml file
+//   - maximumVersion: The maximum version of the tools.yaml file, empty to set no upper bound
+//
+// Returns:
+//   - A ValidationError listing every problem of the tool definitions
+//   - An error if the file cannot be read or parsed, or if its version is not supported
+func ValidateToolsFile(filePath, minimumVersion, maximumVersion string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var config ToolsConfig
+	if err := decoder.Decode(&config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: empty tools file", filePath)
+		}
+		return fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	if err := checkToolsVersion(config.Version, minimumVersion, maximumVersion); err != nil {
+		return fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	// The document is parsed a second time as nodes to locate the tools and parameters in the file
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("%s: %w", filePath, err)
+	}
+	toolNodes := sequenceItems(mappingValue(documentContent(&root), "tools"))
+
+	var problems []string
+	toolLines := make(map[string]int, len(config.Tools))
+
+	for i, def := range config.Tools {
+		line := nodeLine(toolNodes, i)
+		prefix := fmt.Sprintf("line %d: tool %s", line, def.Name)
+		if def.Name == "" {
+			prefix = fmt.Sprintf("line %d: tool #%d", line, i+1)
+		}
+
+		if def.Name != "" {
+			if firstLine, exists := toolLines[def.Name]; exists {
+				problems = append(problems, fmt.Sprintf("%s: duplicate tool name, already defined at line %d", prefix, firstLine))
+			} else {
+				toolLines[def.Name] = line
+			}
+		}
+
+		if _, err := convertToolDefinition(def); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", prefix, err))
+		}
+
+		var parameterNodes []*yaml.Node
+		if i < len(toolNodes) {
+			parameterNodes = sequenceItems(mappingValue(toolNodes[i], "parameters"))
+		}
+
+		parameterNames := make(map[string]bool, len(def.Parameters))
+		for j, param := range def.Parameters {
+			paramPrefix := fmt.Sprintf("line %d: tool %s: parameter %s", nodeLine(parameterNodes, j), def.Name, param.Name)
+
+			for _, problem := range validateParameterDefinition(param) {
+				problems = append(problems, fmt.Sprintf("%s: %s", paramPrefix, problem))
+			}
+
+			if param.Name != "" && parameterNames[param.Name] {
+				problems = append(problems, fmt.Sprintf("%s: duplicate parameter name", paramPrefix))
+			}
+			parameterNames[param.Name] = true
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{FilePath: filePath, Problems: problems}
+	}
+
+	return nil
+}
+
+// validateParameterDefinition returns the problems of a parameter definition
+func validateParameterDefinition(param ParameterDefinition) []string {
+	var problems []string
+
+	if param.Name == "" {
+		problems = append(problems, "parameter name is required")
+	}
+
+	if !slices.Contains(supportedParameterTypes, param.Type) {
+		problems = append(problems, fmt.Sprintf("unsupported type %q, must be one of %s", param.Type, strings.Join(supportedParameterTypes, ", ")))
+	}
+
+	if param.Type == "array" && len(param.Items) == 0 {
+		problems = append(problems, "items are required for array parameters")
+	}
+
+	if param.Enum != nil && param.Type != "string" {
+		problems = append(problems, "enum is only supported for string parameters")
+	}
+
+	return problems
+}
+
+// documentContent returns the top level node of a YAML document
+func documentContent(root *yaml.Node) *yaml.Node {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+	return root
+}
+
+// mappingValue returns the value of a key of a YAML mapping, nil when the node is not a mapping
+// or does not hold the key
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceItems returns the items of a YAML sequence, nil when the node is not a sequence
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+// nodeLine returns the line of the i-th node, 0 when it is unknown
+func nodeLine(nodes []*yaml.Node, i int) int {
+	if i < len(nodes) {
+		return nodes[i].Line
+	}
+	return 0
+}
@@ -0,0 +1,154 @@
+package toolgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateToolsFile(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		minimumVersion   string
+		maximumVersion   string
+		expectedProblems []string
+		expectedError    string
+	}{
+		{
+			name: "valid file",
+			content: `version: v1.2
+tools:
+  - name: listItems
+    description: List the items
+    parameters:
+      - name: ids
+        description: The IDs of the items
+        type: array
+        items:
+          type: number
+      - name: sort
+        description: The sort order
+        type: string
+        enum: [asc, desc]
+    annotations:
+      title: List Items
+      readOnlyHint: true
+`,
+		},
+		{
+			name: "invalid tool definitions",
+			content: `version: v1.2
+tools:
+  - name: listItems
+    description: List the items
+    parameters:
+      - name: ids
+        description: The IDs of the items
+        type: array
+      - name: limit
+        description: The number of items
+        type: integer
+        enum: ["10", "50"]
+      - name: limit
+        description: The number of items
+        type: number
+    annotations:
+      title: List Items
+  - name: listItems
+    description: List the items again
+    annotations:
+      title: List Items
+  - description: A tool without name
+    annotations:
+      title: No Name
+  - name: deleteItem
+    description: Delete an item
+`,
+			expectedProblems: []string{
+				"line 6: tool listItems: parameter ids: items are required for array parameters",
+				`line 9: tool listItems: parameter limit: unsupported type "integer", must be one of string, number, boolean, array, object`,
+				"line 9: tool listItems: parameter limit: enum is only supported for string parameters",
+				"line 13: tool listItems: parameter limit: duplicate parameter name",
+				"line 18: tool listItems: duplicate tool name, already defined at line 3",
+				"line 22: tool #3: tool name is required",
+				"line 25: tool deleteItem: annotations block is required for tool 'deleteItem'",
+			},
+		},
+		{
+			name: "unknown field",
+			content: `version: v1.2
+tools:
+  - name: listItems
+    description: List the items
+    parameters:
+      - name: ids
+        type: string
+        requried: true
+    annotations:
+      title: List Items
+`,
+			expectedError: "line 8: field requried not found",
+		},
+		{
+			name: "version below minimum",
+			content: `version: v1.0
+tools: []
+`,
+			minimumVersion: "v1.1",
+			expectedError:  "tools.yaml version v1.0 is below the minimum required version v1.1",
+		},
+		{
+			name: "version above maximum",
+			content: `version: v1.3
+tools: []
+`,
+			maximumVersion: "v1.2",
+			expectedError:  "tools.yaml version v1.3 is newer than the maximum supported version v1.2",
+		},
+		{
+			name:          "missing version",
+			content:       "tools: []\n",
+			expectedError: "missing version in tools.yaml",
+		},
+		{
+			name:          "empty file",
+			expectedError: "empty tools file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "tools.yaml")
+			require.NoError(t, os.WriteFile(filePath, []byte(tt.content), 0644))
+
+			minimumVersion := tt.minimumVersion
+			if minimumVersion == "" {
+				minimumVersion = "v1.0"
+			}
+
+			err := ValidateToolsFile(filePath, minimumVersion, tt.maximumVersion)
+
+			switch {
+			case tt.expectedError != "":
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.ErrorContains(t, err, filePath)
+			case tt.expectedProblems != nil:
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, filePath, validationErr.FilePath)
+				assert.Equal(t, tt.expectedProblems, validationErr.Problems)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("non-existent file", func(t *testing.T) {
+		err := ValidateToolsFile(filepath.Join(t.TempDir(), "missing.yaml"), "v1.0", "")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -45,7 +45,11 @@ type Annotations struct {
 
 // LoadToolsFromYAML loads tool definitions from a YAML file
 // It returns the tools and the version of the tools.yaml file
-func LoadToolsFromYAML(filePath string, minimumVersion string) (map[string]mcp.Tool, error) {
+//
+// The version of the file must be between minimumVersion and maximumVersion, an empty maximumVersion
+// sets no upper bound. A file newer than maximumVersion is rejected with an UnsupportedVersionError, as
+// it may rely on definitions this build does not understand.
+func LoadToolsFromYAML(filePath string, minimumVersion, maximumVersion string) (map[string]mcp.Tool, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
@@ -56,19 +60,44 @@ func LoadToolsFromYAML(filePath string, minimumVersion string) (map[string]mcp.T
 		return nil, err
 	}
 
-	if config.Version == "" {
-		return nil, fmt.Errorf("missing version in tools.yaml")
+	if err := checkToolsVersion(config.Version, minimumVersion, maximumVersion); err != nil {
+		return nil, err
 	}
 
-	if !semver.IsValid(config.Version) {
-		return nil, fmt.Errorf("invalid version in tools.yaml: %s", config.Version)
+	return convertToolDefinitions(config.Tools), nil
+}
+
+// UnsupportedVersionError is returned when the version of a tools.yaml file is newer than the maximum
+// version supported, e.g. when a tools.yaml file written by a newer release is kept after a downgrade.
+type UnsupportedVersionError struct {
+	Version        string
+	MaximumVersion string
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("tools.yaml version %s is newer than the maximum supported version %s", e.Version, e.MaximumVersion)
+}
+
+// checkToolsVersion checks that the version of a tools.yaml file is valid and between the minimum and the
+// maximum versions, the maximum version is not checked when it is empty
+func checkToolsVersion(version, minimumVersion, maximumVersion string) error {
+	if version == "" {
+		return fmt.Errorf("missing version in tools.yaml")
 	}
 
-	if semver.Compare(config.Version, minimumVersion) < 0 {
-		return nil, fmt.Errorf("tools.yaml version %s is below the minimum required version %s", config.Version, minimumVersion)
+	if !semver.IsValid(version) {
+		return fmt.Errorf("invalid version in tools.yaml: %s", version)
 	}
 
-	return convertToolDefinitions(config.Tools), nil
+	if semver.Compare(version, minimumVersion) < 0 {
+		return fmt.Errorf("tools.yaml version %s is below the minimum required version %s", version, minimumVersion)
+	}
+
+	if maximumVersion != "" && semver.Compare(version, maximumVersion) > 0 {
+		return &UnsupportedVersionError{Version: version, MaximumVersion: maximumVersion}
+	}
+
+	return nil
 }
 
 // convertToolDefinitions converts YAML tool definitions to mcp.Tool objects
@@ -143,6 +143,7 @@ tools:
 		name           string
 		filePath       string
 		minimumVersion string
+		maximumVersion string
 		wantErr        bool
 		wantTool       string // name of tool we expect to find
 		wantToolCount  int    // expected number of tools loaded
@@ -169,6 +170,22 @@ tools:
 			wantTool:       "testTool",
 			wantToolCount:  1,
 		},
+		{
+			name:           "version within the maximum version",
+			filePath:       newerVersionPath,
+			minimumVersion: "v1.0.0",
+			maximumVersion: "v1.2.0",
+			wantErr:        false,
+			wantTool:       "testTool",
+			wantToolCount:  1,
+		},
+		{
+			name:           "version above the maximum version",
+			filePath:       newerVersionPath,
+			minimumVersion: "v1.0.0",
+			maximumVersion: "v1.1.0",
+			wantErr:        true, // Error because file version is above maximum
+		},
 		{
 			name:           "older version yaml file",
 			filePath:       olderVersionPath,
@@ -211,7 +228,7 @@ tools:
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tools, err := LoadToolsFromYAML(tt.filePath, tt.minimumVersion)
+			tools, err := LoadToolsFromYAML(tt.filePath, tt.minimumVersion, tt.maximumVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("LoadToolsFromYAML() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -607,3 +624,14 @@ func TestConvertAnnotation(t *testing.T) {
 	assert.NotNil(t, option)
 	assert.Equal(t, want, dummyTool.Annotations)
 }
+
+func TestCheckToolsVersionAboveMaximum(t *testing.T) {
+	err := checkToolsVersion("v1.3", "v1.0", "v1.2")
+
+	var versionErr *UnsupportedVersionError
+	if assert.ErrorAs(t, err, &versionErr) {
+		assert.Equal(t, "v1.3", versionErr.Version)
+		assert.Equal(t, "v1.2", versionErr.MaximumVersion)
+	}
+	assert.EqualError(t, err, "tools.yaml version v1.3 is newer than the maximum supported version v1.2")
+}
@@ -20,11 +20,18 @@ const (
 	testEndpointName = "test-endpoint"
 	testTag1Name     = "tag1"
 	testTag2Name     = "tag2"
+	testEnvUserName  = "test-user-for-environment"
+	testEnvPassword  = "testpassword"
+	testEnvTeam1Name = "test-team1-for-environment"
+	testEnvTeam2Name = "test-team2-for-environment"
+	envUserRoleStd   = 2 // Portainer API role ID for Standard User
 )
 
 // prepareTestEnvironment prepares the test environment for the tests
-// It enables Edge Compute settings and creates an Edge Docker endpoint
-func prepareEnvironmentManagementTestEnvironment(t *testing.T, env *helpers.TestEnv) {
+// It enables Edge Compute settings, creates an Edge Docker endpoint and
+// the user and teams referenced by the access subtests.
+// It returns the ID of the created user and the IDs of the two created teams.
+func prepareEnvironmentManagementTestEnvironment(t *testing.T, env *helpers.TestEnv) (int, int, int) {
 	host, port := env.Portainer.GetHostAndPort()
 	serverAddr := fmt.Sprintf("%s:%s", host, port)
 	tunnelAddr := fmt.Sprintf("%s:8000", host)
@@ -34,6 +41,17 @@ func prepareEnvironmentManagementTestEnvironment(t *testing.T, env *helpers.Test
 
 	_, err = env.RawClient.CreateEdgeDockerEndpoint(testEndpointName)
 	require.NoError(t, err, "Failed to create Edge Docker endpoint")
+
+	userID, err := env.RawClient.CreateUser(testEnvUserName, testEnvPassword, envUserRoleStd)
+	require.NoError(t, err, "Failed to create test user")
+
+	team1ID, err := env.RawClient.CreateTeam(testEnvTeam1Name)
+	require.NoError(t, err, "Failed to create first test team")
+
+	team2ID, err := env.RawClient.CreateTeam(testEnvTeam2Name)
+	require.NoError(t, err, "Failed to create second test team")
+
+	return int(userID), int(team1ID), int(team2ID)
 }
 
 // TestEnvironmentManagement is an integration test suite that verifies the complete
@@ -45,7 +63,7 @@ func TestEnvironmentManagement(t *testing.T) {
 	defer env.Cleanup(t)
 
 	// Prepare the test environment
-	prepareEnvironmentManagementTestEnvironment(t, env)
+	testUserID, testTeam1ID, testTeam2ID := prepareEnvironmentManagementTestEnvironment(t, env)
 
 	var environment models.Environment
 
@@ -121,7 +139,7 @@ func TestEnvironmentManagement(t *testing.T) {
 			"id": float64(environment.ID),
 			"userAccesses": []any{
 				map[string]any{"id": float64(1), "access": "environment_administrator"},
-				map[string]any{"id": float64(2), "access": "standard_user"},
+				map[string]any{"id": float64(testUserID), "access": "standard_user"},
 			},
 		})
 
@@ -134,8 +152,8 @@ func TestEnvironmentManagement(t *testing.T) {
 		require.NoError(t, err, "Failed to get endpoint via client after user access update")
 
 		expectedRawUserAccesses := utils.BuildAccessPolicies[apimodels.PortainerUserAccessPolicies](map[int64]string{
-			1: "environment_administrator",
-			2: "standard_user",
+			1:                 "environment_administrator",
+			int64(testUserID): "standard_user",
 		})
 		assert.Equal(t, expectedRawUserAccesses, rawEndpoint.UserAccessPolicies, "User access policies mismatch (Client check)")
 	})
@@ -150,8 +168,8 @@ func TestEnvironmentManagement(t *testing.T) {
 		request := mcp.CreateMCPRequest(map[string]any{
 			"id": float64(environment.ID),
 			"teamAccesses": []any{
-				map[string]any{"id": float64(1), "access": "environment_administrator"},
-				map[string]any{"id": float64(2), "access": "standard_user"},
+				map[string]any{"id": float64(testTeam1ID), "access": "environment_administrator"},
+				map[string]any{"id": float64(testTeam2ID), "access": "standard_user"},
 			},
 		})
 
@@ -164,8 +182,8 @@ func TestEnvironmentManagement(t *testing.T) {
 		require.NoError(t, err, "Failed to get endpoint via client after team access update")
 
 		expectedRawTeamAccesses := utils.BuildAccessPolicies[apimodels.PortainerTeamAccessPolicies](map[int64]string{
-			1: "environment_administrator",
-			2: "standard_user",
+			int64(testTeam1ID): "environment_administrator",
+			int64(testTeam2ID): "standard_user",
 		})
 		assert.Equal(t, expectedRawTeamAccesses, rawEndpoint.TeamAccessPolicies, "Team access policies mismatch (Client check)")
 	})